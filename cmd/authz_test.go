@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase"
+	coachingusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/coaching"
+	ownershipusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/ownership"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/coaching"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/ownership"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/authtokens"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/denylist"
+)
+
+// ownedResourceParams — параметры пути, за которыми стоит сущность пользователя.
+// {id} считается сущностью только в перечисленных префиксах: у таймеров и ссылок
+// тренера свои проверки
+var ownedResourceParams = []string{"{workout_id}", "{exercise_id}", "{program_id}", "{day_type_id}"}
+
+var ownedIDPrefixes = []string{"/api/sets/", "/api/exercises/", "/api/measurements/"}
+
+func hasOwnedResource(path string) bool {
+	// публичный API ищет тренировку среди тренировок владельца токена, чужая — 404
+	if strings.HasPrefix(path, "/api/v1/") {
+		return false
+	}
+	for _, param := range ownedResourceParams {
+		if strings.Contains(path, param) {
+			return true
+		}
+	}
+	for _, prefix := range ownedIDPrefixes {
+		if strings.HasPrefix(path, prefix+"{id}") {
+			return true
+		}
+	}
+	return false
+}
+
+// allOwnedBy — любая сущность принадлежит одному пользователю
+type allOwnedBy int64
+
+func (o allOwnedBy) OwnerID(ownership.Resource, int64) (int64, error) {
+	return int64(o), nil
+}
+
+type noCoachLinks struct {
+	coaching.Repo
+}
+
+func (noCoachLinks) GetActive(int64, int64) (*models.CoachLink, error) {
+	return nil, coaching.NotFoundLinkErr
+}
+
+// TestForeignResourcesAreDenied — каждая операция с сущностью в пути отвечает 403 на
+// чужую сущность, не доходя до хендлера: в контейнере нет ничего, кроме проверки
+// доступа, и хендлер упал бы с 500
+func TestForeignResourcesAreDenied(t *testing.T) {
+	const ownerID, strangerID = 1, 2
+
+	container := &usecase.Container{
+		TokenDenylist: denylist.New(nil),
+		CheckResourceAccessUC: ownershipusecases.NewCheckAccessUseCase(
+			allOwnedBy(ownerID),
+			coachingusecases.NewCheckAccessUseCase(noCoachLinks{}, nil),
+		),
+	}
+	router := newRouter(container, nil)
+
+	access, err := authtokens.IssueAccess(strangerID, "session")
+	require.NoError(t, err)
+
+	checked := 0
+	for _, op := range api.OpenAPI().Operations() {
+		if len(op.Security) == 0 || !hasOwnedResource(op.Path) {
+			continue
+		}
+		checked++
+		t.Run(op.OperationID, func(t *testing.T) {
+			path := op.Path
+			for _, p := range op.Parameters {
+				if p.In == "path" {
+					path = strings.Replace(path, "{"+p.Name+"}", "7", 1)
+				}
+			}
+			body := ""
+			if op.RequestBody != nil {
+				body = "{}"
+			}
+
+			req := httptest.NewRequest(op.Method, path, strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer "+access.Token)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+			var resp apierrors.Body
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, apierrors.CodeForbidden, resp.Error.Code)
+		})
+	}
+	// защита от того, что фильтр выше перестанет находить операции
+	assert.Greater(t, checked, 30)
+}
+
+// TestGraphQLForeignWorkoutIsDenied — GraphQL проверяет корневые сущности теми же
+// правилами: чужая тренировка — null и ошибка forbidden, без обращения к выборкам
+func TestGraphQLForeignWorkoutIsDenied(t *testing.T) {
+	const ownerID, strangerID = 1, 2
+
+	container := &usecase.Container{
+		TokenDenylist: denylist.New(nil),
+		CheckResourceAccessUC: ownershipusecases.NewCheckAccessUseCase(
+			allOwnedBy(ownerID),
+			coachingusecases.NewCheckAccessUseCase(noCoachLinks{}, nil),
+		),
+	}
+	router := newRouter(container, nil)
+
+	access, err := authtokens.IssueAccess(strangerID, "session")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/graphql", strings.NewReader(`{"query": "{ workout(id: 7) { id exercises { id } } }"}`))
+	req.Header.Set("Authorization", "Bearer "+access.Token)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp struct {
+		Data   map[string]any `json:"data"`
+		Errors []struct {
+			Path       []any          `json:"path"`
+			Extensions map[string]any `json:"extensions"`
+		} `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Nil(t, resp.Data["workout"])
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, []any{"workout"}, resp.Errors[0].Path)
+	assert.Equal(t, apierrors.CodeForbidden, resp.Errors[0].Extensions["code"])
+}
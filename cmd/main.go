@@ -1,14 +1,23 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"time"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/authtokens"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/autofinish"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/feeddigest"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/leaderboard"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/service/limiter"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/logging"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/metrics"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/push"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	_ "github.com/lib/pq"
@@ -19,50 +28,104 @@ import (
 
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/api"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/authz"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/openapi"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/ownership"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/web"
 )
 
 func main() {
-	log.Println("DEPLOY MARK:", time.Now().UnixNano())
+	// JSON-логи в stdout, в том числе для оставшихся вызовов стандартного log
+	logging.Setup()
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "backfill-stats":
+			backfillStats(os.Args[2:])
+			return
+		case "backfill-achievements":
+			backfillAchievements(os.Args[2:])
+			return
+		}
+	}
+
+	slog.Info("deploy mark", "mark", time.Now().UnixNano())
 
 	token := os.Getenv("TELEGRAM_TOKEN")
 	dsn := os.Getenv("DATABASE_URL")
-	fmt.Printf("TELEGRAM_TOKEN: %s, DATABASE_URL: %s\n", token, dsn)
 
 	// init database
 	db := initDB(dsn)
 
-	// health check handler
+	// liveness, readiness и метрики
 	go healthCheckAPIHandler(db)
 
 	// use cases
 	container := usecase.NewContainer(db)
 
+	// отозванные токены и сессии проверяются в middlewares.Authenticator по списку в памяти
+	if err := container.TokenDenylist.Sync(); err != nil {
+		slog.Error("initial denylist sync failed", "err", err)
+	}
+	middlewares.SetPersonalTokens(container.AuthenticateAccessTokenUC)
+	// без списка прокси адрес клиента — адрес соединения, X-Forwarded-For не читается
+	trustedProxies, err := middlewares.ParseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		logging.Fatal("invalid TRUSTED_PROXIES", "err", err)
+	}
+	middlewares.SetTrustedProxies(trustedProxies)
+	go container.TokenDenylist.Run(30 * time.Second)
+
+	// автозавершение брошенных тренировок
+	sweeper := autofinish.NewSweeper(container.AutoFinishWorkoutsUC, autofinish.ConfigFromEnv(),
+		autofinish.NewPushNotifier(push.NewService(db)))
+	go sweeper.Run()
+
+	// сводка активности подписок, уходит в телеграм после инициализации бота
+	digestRunner := feeddigest.NewRunner(container.BuildFeedDigestsUC, feeddigest.ConfigFromEnv())
+	go digestRunner.Run()
+
+	// таблицы лидеров челленджей пересчитываются по событиям подходов, итоги рассылаются после окончания
+	go container.ChallengeTracker.Run()
+	challengeFinisher := leaderboard.NewFinisher(container.FinishChallengesUC,
+		leaderboard.NewPushNotifier(push.NewService(db)))
+	go challengeFinisher.Run()
+
+	// доменные события из outbox доставляются подписчикам (значки, рекорды, вебхуки, push об окончании отдыха) после коммита
+	go container.OutboxRelay.Run()
+	go container.WebhookDispatcher.Run()
+
 	// init telegram app
 	var app *telegram.App
 	go func() {
 		retryCount := 5
 		for {
 			if retryCount == 0 {
-				fmt.Println("retrying is end")
+				slog.Error("telegram bot init retries exhausted")
 				break
 			}
 			retryCount--
 			var err error
 			app, err = telegram.New(token, container)
 			if err != nil {
-				log.Printf("Telegram bot init failed: %v. Reconnecting in 10s...", err)
+				slog.Error("telegram bot init failed, reconnecting in 10s", "err", err)
 				time.Sleep(10 * time.Second)
 				continue
 			}
-			log.Println("Telegram bot initialized successfully")
+			slog.Info("telegram bot initialized")
+			sweeper.AddNotifier(app)
+			container.ShareNotifications.AddNotifier(app)
+			digestRunner.AddNotifier(app)
+			challengeFinisher.AddNotifier(app)
+			container.Achievements.AddNotifier(app)
 
 			// Запуск обработки обновлений Telegram
 			for {
 				if err := app.Run(); err != nil {
-					log.Printf("Telegram bot crashed: %v. Reconnecting in 10s...", err)
+					slog.Error("telegram bot crashed, reconnecting in 10s", "err", err)
 					time.Sleep(10 * time.Second)
 				}
 			}
@@ -75,16 +138,69 @@ func main() {
 	select {}
 }
 
+// backfillStats пересобирает агрегаты статистики из подходов: всех пользователей или одного (-user)
+// и завершается. Нужен после первого деплоя агрегатов и для починки расхождений после правок задним числом
+func backfillStats(args []string) {
+	fs := flag.NewFlagSet("backfill-stats", flag.ExitOnError)
+	userID := fs.Int64("user", 0, "id пользователя; 0 — все пользователи")
+	_ = fs.Parse(args)
+
+	container := usecase.NewContainer(initDB(os.Getenv("DATABASE_URL")))
+	if *userID != 0 {
+		if err := container.BackfillRollupsUC.ExecuteForUser(*userID); err != nil {
+			logging.Fatal("stats backfill failed", "user_id", *userID, "err", err)
+		}
+		slog.Info("stats backfill finished", "user_id", *userID)
+		return
+	}
+
+	rebuilt, err := container.BackfillRollupsUC.Execute()
+	if err != nil {
+		logging.Fatal("stats backfill failed", "rebuilt", rebuilt, "err", err)
+	}
+	slog.Info("stats backfill finished", "rebuilt", rebuilt)
+}
+
+// backfillAchievements выдает значки за прошлую историю без уведомлений: всем пользователям или одному (-user)
+// и завершается. Нужен после первого деплоя значков и после добавления новых в каталог
+func backfillAchievements(args []string) {
+	fs := flag.NewFlagSet("backfill-achievements", flag.ExitOnError)
+	userID := fs.Int64("user", 0, "id пользователя; 0 — все пользователи")
+	_ = fs.Parse(args)
+
+	container := usecase.NewContainer(initDB(os.Getenv("DATABASE_URL")))
+	if *userID != 0 {
+		awarded, err := container.BackfillAchievementsUC.ExecuteForUser(*userID)
+		if err != nil {
+			logging.Fatal("achievements backfill failed", "user_id", *userID, "err", err)
+		}
+		slog.Info("achievements backfill finished", "user_id", *userID, "awarded", awarded)
+		return
+	}
+
+	awarded, err := container.BackfillAchievementsUC.Execute()
+	if err != nil {
+		logging.Fatal("achievements backfill failed", "awarded", awarded, "err", err)
+	}
+	slog.Info("achievements backfill finished", "awarded", awarded)
+}
+
 func initDB(dsn string) *gorm.DB {
 	migrate(dsn)
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
-		log.Panicf("Failed to connect database: %s", err.Error())
+		logging.Fatal("failed to connect database", "err", err)
 	}
 	return db
 }
 
+// readyTimeout — сколько ждем ответа БД в /readyz
+const readyTimeout = 2 * time.Second
+
+// healthCheckAPIHandler поднимает служебный сервер на PORT (по умолчанию 10000):
+// /livez — процесс жив, /readyz и / — еще и БД отвечает, /metrics — метрики Prometheus.
+// Если задан METRICS_TOKEN, /metrics требует заголовок Authorization: Bearer <токен>
 func healthCheckAPIHandler(db *gorm.DB) {
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -92,53 +208,103 @@ func healthCheckAPIHandler(db *gorm.DB) {
 	}
 	dbObj, err := db.DB()
 	if err != nil {
-		log.Fatalf("cannot get *sql.DB")
+		logging.Fatal("cannot get *sql.DB", "err", err)
 	}
-	fmt.Println("port:", port)
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		if err = dbObj.Ping(); err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
+	metrics.RegisterDBStats(metrics.Default, dbObj)
+
+	slog.Info("health server started", "port", port)
+	if err = http.ListenAndServe(fmt.Sprintf(":%s", port), newHealthMux(dbObj, os.Getenv("METRICS_TOKEN"))); err != nil {
+		slog.Error("health server failed", "err", err)
+	}
+}
+
+func newHealthMux(dbObj *sql.DB, metricsToken string) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livez", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "OK")
+	})
+	ready := func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+		defer cancel()
+		if err := dbObj.PingContext(ctx); err != nil {
+			slog.Warn("readiness check failed", "err", err)
+			w.WriteHeader(http.StatusServiceUnavailable)
 			fmt.Fprintf(w, "DB ping failed")
 			return
 		}
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprintf(w, "OK")
-	})
-	if err := http.ListenAndServe(fmt.Sprintf(":%s", port), nil); err != nil {
-		log.Printf("Health check server failed: %s", err)
 	}
+	mux.HandleFunc("/readyz", ready)
+	// корень остается проверкой готовности для уже настроенного хостинга
+	mux.HandleFunc("/{$}", ready)
+
+	metricsHandler := metrics.Handler(metrics.Default)
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		if metricsToken != "" && r.Header.Get("Authorization") != "Bearer "+metricsToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		metricsHandler.ServeHTTP(w, r)
+	})
+	return mux
 }
 
 func migrate(dsn string) {
 	migrateDB, err := sql.Open("postgres", dsn)
 	if err != nil {
-		log.Panicf("Failed to migrate: open dsn: %s", err.Error())
+		logging.Fatal("failed to migrate: open dsn", "err", err)
 	}
 	defer migrateDB.Close()
 
 	if err = goose.SetDialect("postgres"); err != nil {
-		log.Panicf("Failed to migrate: set database dialect: %s", err.Error())
+		logging.Fatal("failed to migrate: set database dialect", "err", err)
 	}
 
 	if err := goose.Up(migrateDB, "db/migrations"); err != nil {
-		log.Panicf("Failed to migrate: goose up: %s", err.Error())
+		logging.Fatal("failed to migrate: goose up", "err", err)
 	}
 }
 
 func initServer(container *usecase.Container, db *gorm.DB) {
+	r := newRouter(container, db)
+
+	slog.Info("api server started", "addr", ":8080")
+	if err := http.ListenAndServe("0.0.0.0:8080", r); err != nil {
+		slog.Error("api server failed", "err", err)
+	}
+}
+
+// newRouter собирает все маршруты; вынесен из initServer, чтобы контрактные тесты
+// сверяли со спецификацией тот же роутер, что обслуживает прод
+func newRouter(container *usecase.Container, db *gorm.DB) chi.Router {
 	r := chi.NewRouter()
 
 	shareLimiter := limiter.NewRateLimiter(10, time.Minute)
 
 	// Средства middleware chi
-	r.Use(middleware.Logger)    // лог запросов
-	r.Use(middleware.Recoverer) // recovery от паник
 	r.Use(middleware.RequestID) // уникальный ID запроса
+	r.Use(middlewares.Observe)  // JSON-лог запросов, метрики по маршрутам и recovery от паник
 
 	// custom
 	r.Use(middlewares.ShareLimiterMiddleware(shareLimiter))
+	r.Use(openapi.Middleware(api.OpenAPI(), openapi.ConfigFromEnv())) // проверка запросов по спецификации
 
 	s := api.New(container, db)
+	auth := middlewares.NewAuthenticator(container.TokenDenylist)
+
+	// доступ к сущности из пути проверяется до хендлера, см. internal/api/authz
+	az := authz.New(container)
+	ownWorkout := az.Require("workout_id", authz.Owner(ownership.Workout))
+	viewWorkout := az.Require("workout_id", authz.OwnerOrCoach(ownership.Workout, models.CoachScopeWorkouts))
+	commentWorkout := az.Require("workout_id", authz.OwnerOrCoach(ownership.Workout, models.CoachScopeComments))
+	ownProgram := az.Require("program_id", authz.Owner(ownership.Program))
+	editProgram := az.Require("program_id", authz.OwnerOrCoach(ownership.Program, models.CoachScopePrograms))
+	editDay := az.Require("day_type_id", authz.OwnerOrCoach(ownership.DayType, models.CoachScopePrograms))
+	ownExercise := az.Require("exercise_id", authz.Owner(ownership.Exercise))
+
+	r.Get("/api/openapi.json", s.GetOpenAPI)
 
 	// авторизация через Telegram
 	r.Route("/api/telegram", func(r chi.Router) {
@@ -152,134 +318,325 @@ func initServer(container *usecase.Container, db *gorm.DB) {
 		r.Post("/login", s.YandexLoginHandler)
 	})
 
+	// вход через OIDC провайдеров из конфигурации (OAUTH_PROVIDERS)
+	r.Route("/api/oauth", func(r chi.Router) {
+		r.Get("/providers", s.GetOAuthProviders)
+		r.Get("/{provider}/login", s.OAuthRedirectHandler)
+		r.With(auth.OptionalAuth).Post("/{provider}/login", s.OAuthLoginHandler)
+	})
+
+	// локальный вход по email: пароль или ссылка из письма
+	r.Route("/api/email", func(r chi.Router) {
+		r.Post("/register", s.RegisterByEmail)
+		r.Post("/login", s.LoginByEmail)
+		r.With(auth.OptionalAuth).Post("/verify", s.VerifyEmail)
+		r.Post("/verify/resend", s.ResendEmailVerification)
+		r.Post("/magic-link", s.RequestMagicLink)
+		r.With(auth.OptionalAuth).Post("/magic-link/consume", s.ConsumeMagicLink)
+		r.Post("/password/forgot", s.ForgotPassword)
+		r.Post("/password/reset", s.ResetPassword)
+	})
+
+	// обмен refresh токена на новую пару, старый refresh токен после этого недействителен
+	r.Route("/api/auth", func(r chi.Router) {
+		r.Post("/refresh", s.RefreshToken)
+	})
+
 	r.Route("/api/logout", func(r chi.Router) {
-		r.Post("/", api.LogoutHandler)
+		r.Post("/", s.LogoutHandler)
 	})
 
 	r.Route("/api/me", func(r chi.Router) {
-		r.Use(middlewares.Auth)
+		r.Use(auth.Auth)
 
 		r.Get("/", s.MeHandler)
+
+		// активные сессии (устройства) и их удаленное завершение
+		r.Get("/sessions", s.GetAuthSessions)
+		r.Delete("/sessions/{session_id}", s.RevokeAuthSession)
+		r.Post("/sessions/revoke-others", s.RevokeOtherAuthSessions)
+
+		// привязка второго провайдера входа (со слиянием аккаунтов) и отвязка
+		r.Post("/link/telegram", s.LinkTelegram)
+		r.Post("/link/yandex", s.LinkYandex)
+		r.Post("/link/oauth/{provider}", s.LinkOAuth)
+		r.Post("/link/email", s.LinkEmail)
+		r.Delete("/link/{provider}", s.UnlinkProvider)
+	})
+
+	// админка: права проверяются по роли внутри обработчиков, каждое изменение пишется в audit log
+	r.Route("/api/admin", func(r chi.Router) {
+		r.Use(auth.Auth)
+
+		r.Get("/users", s.AdminGetUsers)
+		r.Put("/users/{user_id}/role", s.AdminSetUserRole)
+		r.Post("/users/{user_id}/disable", s.AdminDisableUser)
+		r.Post("/users/{user_id}/enable", s.AdminEnableUser)
+
+		r.Get("/stats", s.AdminGetUsageStats)
+
+		r.Post("/exercise-types", s.AdminCreateExerciseType)
+		r.Put("/exercise-types/{exercise_type_id}", s.AdminUpdateExerciseType)
+		r.Delete("/exercise-types/{exercise_type_id}", s.AdminDeleteExerciseType)
+
+		r.Get("/audit-log", s.AdminGetAuditLog)
 	})
 
 	r.Get("/api/vapid-key", s.GetVapidKey)
 
 	// video
 	r.Route("/api/video", func(r chi.Router) {
-		r.Use(middlewares.Auth)
+		r.Use(auth.Auth)
 		r.Get("/link", s.LinkVideo)
 	})
 	r.Get("/api/video/stream", s.StreamVideo)
 
 	r.Route("/api/excel", func(r chi.Router) {
-		r.Use(middlewares.Auth)
+		r.Use(auth.Auth)
 		r.Get("/workouts", s.DownloadExcelWorkoutsStats)
 	})
 
+	r.Route("/api/stats", func(r chi.Router) {
+		r.Use(auth.Auth)
+		r.Get("/weekly", s.GetWeeklyStats)
+	})
+
 	r.Route("/api/users", func(r chi.Router) {
-		r.Use(middlewares.Auth)
+		r.Use(auth.Auth)
 
 		r.Get("/icon", s.GetIcon)
 		r.Post("/change-icon", s.ChangeIcon)
 	})
 
 	r.Route("/api/workouts", func(r chi.Router) {
-		r.Use(middlewares.Auth)
+		r.Use(auth.Auth)
+
+		r.Get("/", s.GetAllWorkouts)                                     // GET /api/workouts
+		r.Post("/start", s.StartWorkout)                                 // POST /api/workouts/start
+		r.Post("/log", s.LogPastWorkout)                                 // POST /api/workouts/log — тренировка задним числом
+		r.With(ownWorkout).Post("/{workout_id}/finish", s.FinishWorkout) // POST /api/workouts/finish
+		r.With(viewWorkout).Get("/{workout_id}", s.ReadWorkout)          // GET /api/workouts/123
+		r.With(ownWorkout).Delete("/{workout_id}", s.DeleteWorkout)      // DELETE /api/workouts/123
+		r.With(ownWorkout).Post("/{workout_id}/share", s.CreateShareWorkout)
+		r.With(ownWorkout).Put("/{workout_id}/share/comments", s.SetShareCommentsEnabled)
+		r.With(ownWorkout).Post("/{workout_id}/reopen", s.ReopenWorkout)
+		r.With(ownWorkout).Patch("/{workout_id}/times", s.UpdateWorkoutTimes)
+		r.With(viewWorkout).Get("/{workout_id}/edits", s.GetWorkoutEdits)
+		r.With(ownWorkout).Post("/{workout_id}/undo-auto-finish", s.UndoAutoFinishWorkout)
+
+		r.With(viewWorkout).Get("/{workout_id}/comments", s.GetWorkoutComments)
+		r.With(commentWorkout).Post("/{workout_id}/comments", s.AddWorkoutComment)
+		r.With(commentWorkout).Delete("/{workout_id}/comments/{comment_id}", s.DeleteWorkoutComment) // автора или владельца проверяет usecase
+	})
+
+	// тренер и спортсмены: приглашения с согласием спортсмена и доступ по областям (scopes)
+	r.Route("/api/coaching", func(r chi.Router) {
+		r.Use(auth.Auth)
+
+		r.Get("/", s.GetCoachingOverview)
+		r.Post("/invites", s.CreateCoachInvite)
+		r.Post("/invites/accept", s.AcceptCoachInvite)
+		r.Delete("/links/{link_id}", s.RevokeCoachLink)
+		r.Put("/links/{link_id}/scopes", s.UpdateCoachScopes)
+
+		r.Get("/athletes/{athlete_id}/workouts", s.GetAthleteWorkouts)
+		r.Get("/athletes/{athlete_id}/measurements", s.GetAthleteMeasurements)
+		r.Get("/athletes/{athlete_id}/exercises/{exercise_type_id}/stats", s.GetAthleteExerciseStats)
+		r.Get("/athletes/{athlete_id}/programs", s.GetAthletePrograms)
+		r.Post("/athletes/{athlete_id}/programs", s.CreateAthleteProgram)
+		r.With(editProgram).Post("/athletes/{athlete_id}/programs/{program_id}/assign", s.AssignAthleteProgram)
+	})
+
+	// подписки и лента: видимость тренировок и рекордов задается настройками приватности автора
+	r.Route("/api/social", func(r chi.Router) {
+		r.Use(auth.Auth)
+
+		r.Get("/feed", s.GetFeed)
+		r.Get("/privacy", s.GetPrivacy)
+		r.Put("/privacy", s.UpdatePrivacy)
+
+		r.Get("/followers", s.GetFollowers) // ?pending=true — заявки на подписку
+		r.Post("/followers/{user_id}/accept", s.AcceptFollower)
+		r.Delete("/followers/{user_id}", s.RemoveFollower)
+
+		r.Get("/following", s.GetFollowing)
+		r.Post("/follow/{user_id}", s.Follow)
+		r.Delete("/follow/{user_id}", s.Unfollow)
+
+		r.Get("/users/{user_id}", s.GetSocialProfile)
+	})
+
+	// челленджи: вступление по ссылке-приглашению, таблица лидеров видна только участникам
+	r.Route("/api/challenges", func(r chi.Router) {
+		r.Use(auth.Auth)
+
+		r.Get("/", s.GetMyChallenges)
+		r.Post("/", s.CreateChallenge)
+		r.Get("/invites/{code}", s.GetChallengeInvite)
+		r.Post("/invites/{code}/join", s.JoinChallenge)
+		r.Get("/{challenge_id}", s.GetChallenge)
+		r.Delete("/{challenge_id}", s.DeleteChallenge)
+		r.Delete("/{challenge_id}/participants/me", s.LeaveChallenge)
+	})
+
+	// значки с прогрессом и серия недель с тренировками
+	r.Route("/api/achievements", func(r chi.Router) {
+		r.Use(auth.Auth)
+
+		r.Get("/", s.GetMyAchievements)
+	})
+
+	// вебхуки для внешних интеграций: события подписываются HMAC, журнал доставок виден владельцу
+	r.Route("/api/webhooks", func(r chi.Router) {
+		r.Use(auth.Auth)
+
+		r.Get("/", s.GetMyWebhooks)
+		r.Post("/", s.CreateWebhook)
+		r.Get("/event-types", s.GetWebhookEventTypes)
+		r.Put("/{webhook_id}", s.UpdateWebhook)
+		r.Delete("/{webhook_id}", s.DeleteWebhook)
+		r.Get("/{webhook_id}/deliveries", s.GetWebhookDeliveries)
+		r.Post("/{webhook_id}/test", s.SendTestWebhook)
+	})
+
+	// персональные токены выпускаются и отзываются только из сессии: сам токен сюда не пускается
+	r.Route("/api/tokens", func(r chi.Router) {
+		r.Use(auth.Auth)
+
+		r.Get("/", s.GetMyAccessTokens)
+		r.Post("/", s.CreateAccessToken)
+		r.Get("/scopes", s.GetAccessTokenScopes)
+		r.Delete("/{token_id}", s.RevokeAccessToken)
+	})
+
+	// стабильный публичный API для скриптов и интеграций: единая пагинация и ошибки в формате apierrors
+	r.Route("/api/v1", func(r chi.Router) {
+		r.Use(auth.APIAuth)
 
-		r.Get("/", s.GetAllWorkouts)                    // GET /api/workouts
-		r.Post("/start", s.StartWorkout)                // POST /api/workouts/start
-		r.Post("/{workout_id}/finish", s.FinishWorkout) // POST /api/workouts/finish
-		r.Get("/{workout_id}", s.ReadWorkout)           // GET /api/workouts/123
-		r.Delete("/{workout_id}", s.DeleteWorkout)      // DELETE /api/workouts/123
-		r.Post("/{workout_id}/share", s.CreateShareWorkout)
+		r.With(middlewares.RequireScopes()).Get("/me", s.V1GetMe)
+		r.With(middlewares.RequireScopes(authtokens.ScopeWorkoutsRead)).Get("/workouts", s.V1GetWorkouts)
+		r.With(middlewares.RequireScopes(authtokens.ScopeWorkoutsRead)).Get("/workouts/{workout_id}", s.V1GetWorkout)
+		r.With(middlewares.RequireScopes(authtokens.ScopeMeasurementsRead)).Get("/measurements", s.V1GetMeasurements)
+		r.With(middlewares.RequireScopes(authtokens.ScopeMeasurementsWrite)).Post("/measurements", s.V1CreateMeasurement)
 	})
 
 	r.Get("/api/public/workouts/{token}", s.GetPublicWorkout)
 
+	// реакции и комментарии по публичной ссылке: смотреть может любой, писать — только вошедший
+	r.Route("/api/public/workouts/{token}/social", func(r chi.Router) {
+		r.With(auth.OptionalAuth).Get("/", s.GetShareSocial)
+
+		r.Group(func(r chi.Router) {
+			r.Use(auth.Auth)
+
+			r.Put("/reaction", s.ReactToShare)
+			r.Delete("/reaction", s.RemoveShareReaction)
+			r.Post("/comments", s.CommentShare)
+			r.Delete("/comments/{comment_id}", s.DeleteShareComment)
+		})
+	})
+
 	r.Route("/api/sessions", func(r chi.Router) {
-		r.Use(middlewares.Auth)
+		r.Use(auth.Auth)
 
-		r.Get("/{workout_id}", s.ShowCurrentExerciseSession)
-		r.Post("/{workout_id}", s.MoveToExerciseSession)
-		r.Post("/{workout_id}/set-index/{index}", s.MoveToCertainExerciseSession)
+		r.With(ownWorkout).Get("/{workout_id}", s.ShowCurrentExerciseSession)
+		r.With(ownWorkout).Post("/{workout_id}", s.MoveToExerciseSession)
+		r.With(ownWorkout).Post("/{workout_id}/set-index/{index}", s.MoveToCertainExerciseSession)
+		r.With(ownWorkout).Get("/{workout_id}/events", s.StreamSessionEvents) // SSE: изменения подходов, переходы, таймеры
 	})
 
 	r.Route("/api/measurements", func(r chi.Router) {
-		r.Use(middlewares.Auth)
+		r.Use(auth.Auth)
 
 		r.Get("/", s.GetMeasurements)
 		r.Get("/types", s.GetMeasurementTypes)
 		r.Post("/", s.CreateMeasurement)
-		r.Delete("/{id}", s.DeleteMeasurement)
+		r.With(az.Require("id", authz.Owner(ownership.Measurement))).Delete("/{id}", s.DeleteMeasurement)
 	})
 
 	r.Route("/api/exercise-groups", func(r chi.Router) {
-		r.Use(middlewares.Auth)
+		r.Use(auth.Auth)
 
 		r.Get("/", s.GetExerciseGroups)
 		r.Get("/{group}", s.GetExerciseTypesByGroup)
 	})
 
 	r.Route("/api/presets", func(r chi.Router) {
-		r.Use(middlewares.Auth)
+		r.Use(auth.Auth)
 
 		r.Post("/parse", s.ParsePreset)
 		r.Post("/save", s.SavePreset)
 	})
 
 	r.Route("/api/programs", func(r chi.Router) {
-		r.Use(middlewares.Auth)
+		r.Use(auth.Auth)
 
 		r.Get("/", s.GetUserPrograms)
 		r.Post("/", s.CreateProgram)
 
 		r.Get("/active", s.GetActiveProgramForUser)
 
-		r.Post("/{program_id}/choose", s.ChooseProgram)
-		r.Delete("/{program_id}", s.DeleteProgram)
-		r.Get("/{program_id}", s.GetProgram)
-		r.Post("/{program_id}/rename", s.RenameProgram)
+		r.With(ownProgram).Post("/{program_id}/choose", s.ChooseProgram)
+		r.With(ownProgram).Delete("/{program_id}", s.DeleteProgram)
+		r.With(editProgram).Get("/{program_id}", s.GetProgram)
+		r.With(editProgram).Post("/{program_id}/rename", s.RenameProgram)
 
-		r.Post("/{program_id}/days", s.CreateProgramDay)
-		r.Delete("/{program_id}/days/{day_type_id}", s.DeleteProgramDay)
-		r.Post("/{program_id}/days/{day_type_id}", s.UpdateProgramDay)
-		r.Get("/{program_id}/days/{day_type_id}", s.GetProgramDay)
+		r.With(editProgram).Post("/{program_id}/days", s.CreateProgramDay)
+		// день проверяется отдельно: свой program_id не открывает чужой день
+		r.With(editProgram, editDay).Delete("/{program_id}/days/{day_type_id}", s.DeleteProgramDay)
+		r.With(editProgram, editDay).Post("/{program_id}/days/{day_type_id}", s.UpdateProgramDay)
+		r.With(editProgram, editDay).Get("/{program_id}/days/{day_type_id}", s.GetProgramDay)
 	})
 
 	r.Route("/api/sets", func(r chi.Router) {
-		r.Use(middlewares.Auth)
+		r.Use(auth.Auth)
+
+		ownSet := az.Require("id", authz.Owner(ownership.Set))
 
-		r.Post("/{exercise_id}", s.AddSet)
-		r.Delete("/{id}", s.DeleteSet)
-		r.Post("/{id}/complete", s.CompleteSet)
-		r.Post("/{id}/change", s.ChangeSet)
+		r.With(ownExercise).Post("/{exercise_id}", s.AddSet)
+		r.With(ownSet).Delete("/{id}", s.DeleteSet)
+		r.With(ownSet).Post("/{id}/complete", s.CompleteSet)
+		r.With(ownSet).Post("/{id}/change", s.ChangeSet)
 	})
 
 	r.Route("/api/exercises", func(r chi.Router) {
-		r.Use(middlewares.Auth)
+		r.Use(auth.Auth)
 
 		r.Post("/", s.AddExercise)
-		r.Delete("/{id}", s.DeleteExercise)
+		r.With(az.Require("id", authz.Owner(ownership.Exercise))).Delete("/{id}", s.DeleteExercise)
 		r.Get("/{exercise_type_id}/stats", s.GetExerciseStatsByUser)
 	})
 
 	r.Route("/api/push", func(r chi.Router) {
-		r.Use(middlewares.Auth)
+		r.Use(auth.Auth)
 
 		r.Post("/subscribe", s.PushSubscribe)
 		r.Post("/unsubscribe", s.PushUnsubscribe)
 	})
 
 	r.Route("/api/timers", func(r chi.Router) {
-		r.Use(middlewares.Auth)
+		r.Use(auth.Auth)
 
 		r.Post("/start", s.StartTimer)
 		r.Post("/cancel/{id}", s.CancelTimer)
 	})
 
+	r.Route("/api/sync", func(r chi.Router) {
+		r.Use(auth.Auth)
+
+		r.Post("/", s.Sync) // очередь офлайн-мутаций и изменения с прошлого курсора
+	})
+
+	// GraphQL только для чтения: доступ к сущностям проверяют резолверы по тем же правилам, что и authz
+	r.Route("/api/graphql", func(r chi.Router) {
+		r.Use(auth.Auth)
+
+		r.Post("/", s.GraphQL)
+		r.Get("/schema", s.GetGraphQLSchema) // SDL для генерации клиентских типов
+	})
+
 	// UI (React build)
 	web.MountSPA(r, "/")
 
-	log.Println("Server started on :8080")
-	http.ListenAndServe("0.0.0.0:8080", r)
+	return r
 }
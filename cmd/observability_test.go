@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/logging"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/metrics"
+)
+
+func TestHealthChecksAreSeparate(t *testing.T) {
+	// БД, до которой не достучаться: процесс жив, но не готов
+	db, err := sql.Open("postgres", "postgres://127.0.0.1:1/none?sslmode=disable&connect_timeout=1")
+	require.NoError(t, err)
+	defer db.Close()
+	mux := newHealthMux(db, "secret")
+
+	for _, tc := range []struct {
+		path   string
+		header string
+		status int
+	}{
+		{"/livez", "", http.StatusOK},
+		{"/readyz", "", http.StatusServiceUnavailable},
+		{"/", "", http.StatusServiceUnavailable},
+		{"/metrics", "", http.StatusUnauthorized},
+		{"/metrics", "Bearer wrong", http.StatusUnauthorized},
+		{"/metrics", "Bearer secret", http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		if tc.header != "" {
+			req.Header.Set("Authorization", tc.header)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		assert.Equal(t, tc.status, rec.Code, "%s %s", tc.path, tc.header)
+	}
+}
+
+func TestRequestsAreLoggedAndMeasuredByRoute(t *testing.T) {
+	var logs bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(logging.New(&logs, slog.LevelInfo))
+	defer slog.SetDefault(prev)
+
+	router := newRouter(emptyContainer(), nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil)
+	req.Header.Set("X-Request-Id", "req-42")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, "http request", entry["msg"])
+	assert.Equal(t, "req-42", entry["request_id"])
+	assert.Equal(t, "/api/openapi.json", entry["route"])
+	assert.EqualValues(t, http.StatusOK, entry["status"])
+
+	scrape := httptest.NewRecorder()
+	metrics.Handler(metrics.Default).ServeHTTP(scrape, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, scrape.Code)
+	assert.Contains(t, scrape.Body.String(), `http_request_duration_seconds_count{method="GET",route="/api/openapi.json",status="200"} 1`)
+}
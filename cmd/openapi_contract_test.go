@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/openapi"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/denylist"
+)
+
+// emptyContainer — контейнер без use case'ов: роутеру при сборке нужен только список отзыва токенов
+func emptyContainer() *usecase.Container {
+	return &usecase.Container{TokenDenylist: denylist.New(nil)}
+}
+
+// TestOpenAPIMatchesRouter — каждый маршрут роутера описан в спецификации тем же
+// хендлером, и в спецификации нет маршрутов, которых нет в роутере
+func TestOpenAPIMatchesRouter(t *testing.T) {
+	doc := api.OpenAPI()
+	router := newRouter(emptyContainer(), nil)
+
+	routed := map[string]string{}
+	err := chi.Walk(router, func(method, route string, handler http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if strings.HasSuffix(route, "/*") {
+			return nil // SPA
+		}
+		if len(route) > 1 {
+			route = strings.TrimSuffix(route, "/")
+		}
+		routed[method+" "+route] = handlerName(handler)
+		return nil
+	})
+	require.NoError(t, err)
+
+	documented := map[string]string{}
+	for _, op := range doc.Operations() {
+		documented[op.Method+" "+op.Path] = op.Handler
+	}
+
+	for _, route := range sortedKeys(routed) {
+		handler, ok := documented[route]
+		if !assert.True(t, ok, "route %s is not described in the OpenAPI document", route) {
+			continue
+		}
+		assert.Equal(t, handler, routed[route], "route %s is served by another handler", route)
+	}
+	for _, route := range sortedKeys(documented) {
+		_, ok := routed[route]
+		assert.True(t, ok, "operation %s is not registered in the router", route)
+	}
+}
+
+// TestSecuredOperationsRejectAnonymous — без токена защищенные операции отвечают 401,
+// и тело ответа совпадает с тем, что обещает документ
+func TestSecuredOperationsRejectAnonymous(t *testing.T) {
+	doc := api.OpenAPI()
+	router := newRouter(emptyContainer(), nil)
+
+	for _, op := range doc.Operations() {
+		if len(op.Security) == 0 {
+			continue
+		}
+		t.Run(op.OperationID, func(t *testing.T) {
+			path, query := op.Path, url.Values{}
+			for _, p := range op.Parameters {
+				value := "x"
+				if p.Schema.Type == "integer" {
+					value = "1"
+				}
+				switch {
+				case p.In == "path":
+					path = strings.Replace(path, "{"+p.Name+"}", value, 1)
+				case p.Required:
+					query.Set(p.Name, value)
+				}
+			}
+			if len(query) > 0 {
+				path += "?" + query.Encode()
+			}
+			var body *strings.Reader
+			if op.RequestBody != nil {
+				body = strings.NewReader("{}")
+			} else {
+				body = strings.NewReader("")
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(op.Method, path, body))
+
+			require.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.NoError(t, doc.ValidateResponse(op, rec.Code, rec.Header().Get("Content-Type"), rec.Body.Bytes()))
+
+			var resp apierrors.Body
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, apierrors.CodeUnauthorized, resp.Error.Code)
+			assert.NotEmpty(t, resp.Error.RequestID)
+		})
+	}
+}
+
+func TestOpenAPIDocumentIsServed(t *testing.T) {
+	router := newRouter(emptyContainer(), nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `"openapi":"`+openapi.Version+`"`)
+}
+
+func TestValidationMiddlewareRejectsBadRequests(t *testing.T) {
+	router := newRouter(emptyContainer(), nil)
+
+	cases := []struct {
+		name, method, path, body, code, field string
+	}{
+		{"path parameter", http.MethodGet, "/api/workouts/abc", "", apierrors.CodeInvalidParameter, "workout_id"},
+		{"query parameter", http.MethodGet, "/api/v1/workouts?limit=1000", "", apierrors.CodeInvalidParameter, "limit"},
+		{"enum", http.MethodGet, "/api/v1/workouts?status=paused", "", apierrors.CodeInvalidParameter, "status"},
+		{"body field type", http.MethodPost, "/api/email/login", `{"email": 42}`, apierrors.CodeInvalidBody, "email"},
+		{"missing body", http.MethodPost, "/api/auth/refresh", "", apierrors.CodeInvalidBody, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body)))
+
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+			var body apierrors.Body
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tc.code, body.Error.Code)
+			if tc.field != "" {
+				assert.Equal(t, tc.field, body.Error.Field)
+			}
+		})
+	}
+}
+
+// handlerName — имя метода api.serviceImpl за маршрутом, включая обернутые r.With
+func handlerName(h http.Handler) string {
+	if chain, ok := h.(*chi.ChainHandler); ok {
+		h = chain.Endpoint
+	}
+	name := runtime.FuncForPC(reflect.ValueOf(h).Pointer()).Name()
+	name = strings.TrimSuffix(name, "-fm")
+	return name[strings.LastIndexByte(name, '.')+1:]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/openapi"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/repotest"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/authtokens"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/logging"
+)
+
+// skippedOperations ходят к внешним сервисам или держат соединение открытым
+var skippedOperations = map[string]bool{
+	"YandexLoginHandler": true, // обмен кода в Yandex ID
+	"LinkYandex":         true, // тоже
+}
+
+// expectedClientErrors — операции, которые с подставленными значениями не доходят до успеха
+// и должны ответить 4xx: проверяется схема ответа с ошибкой. Причина у каждой своя
+var expectedClientErrors = map[string]string{
+	"TelegramRedirectHandler": "origin test не из списка разрешенных",
+	"YandexRedirectHandler":   "origin test не из списка разрешенных",
+	"OAuthRedirectHandler":    "origin test не из списка разрешенных",
+	"RegisterByEmail":         "origin test не из списка разрешенных",
+	"ResendEmailVerification": "origin test не из списка разрешенных",
+	"RequestMagicLink":        "origin test не из списка разрешенных",
+	"ForgotPassword":          "origin test не из списка разрешенных",
+	"LinkEmail":               "origin test не из списка разрешенных",
+	"LinkOAuth":               "origin test не из списка разрешенных",
+	"OAuthLoginHandler":       "провайдер test не настроен",
+	"TelegramLoginHandler":    "подпись test не сходится",
+	"LinkTelegram":            "подпись test не сходится",
+	"LoginByEmail":            "аккаунта test нет",
+	"VerifyEmail":             "токен test не выдавался",
+	"ConsumeMagicLink":        "токен test не выдавался",
+	"ResetPassword":           "токен test не выдавался",
+	"RefreshToken":            "токен test не выдавался",
+	"RevokeAuthSession":       "сессии со случайным id нет",
+	"UnlinkProvider":          "провайдера test нет",
+
+	"AdminSetUserRole":        "роли test нет",
+	"AdminCreateExerciseType": "группы и единиц test нет",
+	"AdminUpdateExerciseType": "группы и единиц test нет",
+	"AdminDeleteExerciseType": "упражнение есть в тренировке владельца",
+
+	"LogPastWorkout":        "начало совпадает с концом",
+	"UpdateWorkoutTimes":    "начало совпадает с концом",
+	"UndoAutoFinishWorkout": "тренировку не завершали автоматически",
+	"DeleteProgram":         "программа активна",
+	"DeleteProgramDay":      "по дню есть тренировки",
+	"Sync":                  "cursor test не время",
+
+	"GetAthleteWorkouts":      "владелец не тренирует stranger",
+	"GetAthleteMeasurements":  "владелец не тренирует stranger",
+	"GetAthleteExerciseStats": "владелец не тренирует stranger",
+	"GetAthletePrograms":      "владелец не тренирует stranger",
+	"CreateAthleteProgram":    "владелец не тренирует stranger",
+	"AssignAthleteProgram":    "владелец не тренирует stranger",
+	"CreateCoachInvite":       "области test нет",
+	"AcceptCoachInvite":       "приглашения test нет",
+	"UpdateCoachScopes":       "связей с тренером нет",
+	"RevokeCoachLink":         "связей с тренером нет",
+	"UpdatePrivacy":           "видимости test нет",
+
+	"CreateChallenge":    "метрики test нет",
+	"GetChallengeInvite": "приглашения test нет",
+	"JoinChallenge":      "приглашения test нет",
+	"GetChallenge":       "челленджей нет",
+	"DeleteChallenge":    "челленджей нет",
+	"LeaveChallenge":     "челленджей нет",
+
+	"CreateWebhook":        "url test не адрес",
+	"UpdateWebhook":        "вебхуков нет",
+	"DeleteWebhook":        "вебхуков нет",
+	"GetWebhookDeliveries": "вебхуков нет",
+	"SendTestWebhook":      "вебхуков нет",
+	"CreateAccessToken":    "области test нет",
+	"RevokeAccessToken":    "токенов нет",
+	"GetPublicWorkout":     "ссылки test нет",
+	"GetShareSocial":       "ссылки test нет",
+	"ReactToShare":         "ссылки test нет",
+	"RemoveShareReaction":  "ссылки test нет",
+	"CommentShare":         "ссылки test нет",
+	"DeleteShareComment":   "ссылки test нет",
+}
+
+// TestResponsesMatchOpenAPI прогоняет каждую JSON-операцию через настоящий роутер и контейнер
+// над чистой базой с OPENAPI_VALIDATE_RESPONSES=true: middleware сверяет ответ со схемой и
+// пишет расхождение в лог, тест собирает эти записи. Сначала идут чтения, потом изменения,
+// удаления в конце, чтобы заведенные сущности дожили до всех операций. Операция обязана
+// ответить успешным статусом из документа, кроме перечисленных в expectedClientErrors
+func TestResponsesMatchOpenAPI(t *testing.T) {
+	db := repotest.Open(t)
+	t.Setenv("OPENAPI_VALIDATE_RESPONSES", "true")
+
+	var logs bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(logging.New(&logs, slog.LevelWarn))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	owner, stranger := seedContractData(t, db)
+	router := newRouter(usecase.NewContainer(db), db)
+	access, err := authtokens.IssueAccess(owner, "session")
+	require.NoError(t, err)
+
+	doc := api.OpenAPI()
+	values := contractValues{doc: doc, owner: owner, stranger: stranger}
+	exercised := map[string]bool{}
+	for _, op := range orderedOperations(doc) {
+		if skippedOperations[op.Handler] || !jsonOperation(op) {
+			continue
+		}
+		exercised[op.Handler] = true
+		t.Run(op.OperationID, func(t *testing.T) {
+			req := httptest.NewRequest(op.Method, values.path(op), values.body(op))
+			req.Header.Set("Content-Type", "application/json")
+			if len(op.Security) > 0 {
+				req.Header.Set("Authorization", "Bearer "+access.Token)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if reason, ok := expectedClientErrors[op.Handler]; ok {
+				assert.True(t, rec.Code >= http.StatusBadRequest && rec.Code < http.StatusInternalServerError,
+					"ожидалась ошибка клиента (%s), получен %d: %s", reason, rec.Code, rec.Body.String())
+				return
+			}
+			assert.Equal(t, op.SuccessStatus, rec.Code, rec.Body.String())
+		})
+	}
+	for handler := range expectedClientErrors {
+		assert.True(t, exercised[handler], "%s из expectedClientErrors не проверялась", handler)
+	}
+
+	scanner := bufio.NewScanner(&logs)
+	for scanner.Scan() {
+		var entry map[string]any
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil || entry["msg"] != "openapi response mismatch" {
+			continue
+		}
+		t.Errorf("%v %v (%v): status %v: %v", entry["method"], entry["path"], entry["operation"], entry["status"], entry["err"])
+	}
+}
+
+// seedContractData заводит владельца (админ, чтобы дойти до админских хендлеров) с программой,
+// днем и завершенной тренировкой и публичного пользователя, на которого он подписан и который
+// ждет одобрения подписки на владельца. Схема чистая, поэтому у сущностей владельца id = 1
+func seedContractData(t *testing.T, db *gorm.DB) (int64, int64) {
+	owner := &models.User{FirstName: "owner", Email: "owner@example.com", Role: models.RoleAdmin}
+	require.NoError(t, db.Create(owner).Error)
+	stranger := &models.User{FirstName: "stranger", WorkoutsVisibility: models.VisibilityPublic,
+		RecordsVisibility: models.VisibilityPublic}
+	require.NoError(t, db.Create(stranger).Error)
+	require.NoError(t, db.Create(&models.Follow{FollowerID: owner.ID, FolloweeID: stranger.ID,
+		Status: models.FollowAccepted}).Error)
+	require.NoError(t, db.Create(&models.Follow{FollowerID: stranger.ID, FolloweeID: owner.ID,
+		Status: models.FollowPending}).Error)
+
+	program := &models.WorkoutProgram{UserID: owner.ID, Name: "program"}
+	require.NoError(t, db.Create(program).Error)
+	require.NoError(t, db.Model(owner).Update("active_program_id", program.ID).Error)
+	dayType := &models.WorkoutDayType{WorkoutProgramID: program.ID, Name: "day"}
+	require.NoError(t, db.Create(dayType).Error)
+
+	var exerciseTypeID int64
+	require.NoError(t, db.Raw("SELECT id FROM exercise_types ORDER BY id LIMIT 1").Scan(&exerciseTypeID).Error)
+	startedAt := time.Now().Add(-2 * time.Hour)
+	endedAt := startedAt.Add(time.Hour)
+	workout := &models.WorkoutDay{
+		UserID:           owner.ID,
+		WorkoutDayTypeID: dayType.ID,
+		StartedAt:        startedAt,
+		EndedAt:          &endedAt,
+		Completed:        true,
+		Exercises: []models.Exercise{{
+			ExerciseTypeID: exerciseTypeID,
+			Sets:           []models.Set{{Reps: 5, Weight: 50, Completed: true, CompletedAt: &endedAt}},
+		}},
+	}
+	require.NoError(t, db.Create(workout).Error)
+	require.NoError(t, db.Create(&models.WorkoutSession{WorkoutDayID: workout.ID, StartedAt: startedAt}).Error)
+	require.NoError(t, db.Create(&models.Measurement{UserID: owner.ID, CreatedAt: endedAt, Chest: 100}).Error)
+	return owner.ID, stranger.ID
+}
+
+// orderedOperations — GET, затем POST, PUT и PATCH, затем DELETE; внутри группы порядок документа,
+// только удаление тренировки последнее: вместе с ней удаляются ее подходы, упражнения и комментарии
+func orderedOperations(doc *openapi.Document) []*openapi.Operation {
+	rank := map[string]int{http.MethodGet: 0, http.MethodDelete: 2}
+	var groups [4][]*openapi.Operation
+	for _, op := range doc.Operations() {
+		r, ok := rank[op.Method]
+		switch {
+		case op.Handler == "DeleteWorkout":
+			r = 3
+		case !ok:
+			r = 1
+		}
+		groups[r] = append(groups[r], op)
+	}
+	return append(append(append(groups[0], groups[1]...), groups[2]...), groups[3]...)
+}
+
+// jsonOperation — успешный ответ JSON или пустой; стримы (SSE, видео, xlsx) middleware не сверяет
+func jsonOperation(op *openapi.Operation) bool {
+	for _, resp := range op.Responses {
+		for mediaType := range resp.Content {
+			if mediaType != "application/json" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// contractValues подставляет в запрос значения по схемам документа
+type contractValues struct {
+	doc      *openapi.Document
+	owner    int64
+	stranger int64
+}
+
+func (v contractValues) path(op *openapi.Operation) string {
+	path, query := op.Path, url.Values{}
+	for _, p := range op.Parameters {
+		var value string
+		switch p.Name {
+		case "user_id", "athlete_id":
+			value = strconv.FormatInt(v.stranger, 10)
+		case "index":
+			value = "0"
+		case "session_id":
+			value = uuid.NewString() // колонка uuid, строку test база не примет
+		default:
+			value = v.param(p.Schema)
+		}
+		switch {
+		case p.In == "path":
+			path = strings.Replace(path, "{"+p.Name+"}", url.PathEscape(value), 1)
+		case p.Required:
+			query.Set(p.Name, value)
+		}
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return path
+}
+
+func (v contractValues) param(s *openapi.Schema) string {
+	switch sample := v.sample(s).(type) {
+	case string:
+		return sample
+	default:
+		raw, _ := json.Marshal(sample)
+		return string(raw)
+	}
+}
+
+func (v contractValues) body(op *openapi.Operation) *strings.Reader {
+	if op.RequestBody == nil {
+		return strings.NewReader("")
+	}
+	raw, _ := json.Marshal(v.sample(op.RequestBody.Content["application/json"].Schema))
+	return strings.NewReader(string(raw))
+}
+
+// sample — минимальное значение, проходящее проверку запроса: первое значение перечисления,
+// 1 для чисел (с учетом minimum), все поля объекта. Ссылки на сущности указывают на сущности владельца
+func (v contractValues) sample(s *openapi.Schema) any {
+	for s.Ref != "" {
+		s = v.doc.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[0]
+	}
+	if len(s.AllOf) > 0 {
+		merged := map[string]any{}
+		for _, part := range s.AllOf {
+			if fields, ok := v.sample(part).(map[string]any); ok {
+				for k, val := range fields {
+					merged[k] = val
+				}
+			}
+		}
+		return merged
+	}
+	if len(s.AnyOf) > 0 {
+		return v.sample(s.AnyOf[0])
+	}
+
+	switch s.Type {
+	case "string":
+		switch s.Format {
+		case "date-time":
+			return time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+		case "date":
+			return time.Now().Format(time.DateOnly)
+		case "email":
+			return "owner@example.com"
+		}
+		return "test"
+	case "integer", "number":
+		n := 1.0
+		if s.Minimum != nil && *s.Minimum > n {
+			n = *s.Minimum
+		}
+		if s.Maximum != nil && *s.Maximum < n {
+			n = *s.Maximum
+		}
+		return n
+	case "boolean":
+		return true
+	case "array":
+		if s.Items == nil {
+			return []any{}
+		}
+		return []any{v.sample(s.Items)}
+	case "object", "":
+		fields := map[string]any{}
+		for name, prop := range s.Properties {
+			fields[name] = v.sample(prop)
+		}
+		return fields
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE sets
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE exercises
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE workout_days
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sets
+    DROP COLUMN version;
+
+ALTER TABLE exercises
+    DROP COLUMN version;
+
+ALTER TABLE workout_days
+    DROP COLUMN version;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE sets
+    ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+
+ALTER TABLE exercises
+    ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+
+ALTER TABLE workout_days
+    ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+
+CREATE INDEX idx_workout_days_user_updated_at ON workout_days (user_id, updated_at);
+CREATE INDEX idx_exercises_updated_at ON exercises (updated_at);
+
+CREATE TABLE sync_mutations
+(
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT                   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    client_id   UUID                     NOT NULL,
+    type        VARCHAR(32)              NOT NULL,
+    status      VARCHAR(16)              NOT NULL,
+    entity_id   BIGINT,
+    client_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    applied_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, client_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS sync_mutations;
+
+DROP INDEX IF EXISTS idx_exercises_updated_at;
+DROP INDEX IF EXISTS idx_workout_days_user_updated_at;
+
+ALTER TABLE sets
+    DROP COLUMN updated_at;
+
+ALTER TABLE exercises
+    DROP COLUMN updated_at;
+
+ALTER TABLE workout_days
+    DROP COLUMN updated_at;
+-- +goose StatementEnd
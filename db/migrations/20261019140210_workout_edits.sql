@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE workout_edits
+(
+    id             BIGSERIAL PRIMARY KEY,
+    workout_day_id BIGINT                   NOT NULL REFERENCES workout_days (id) ON DELETE CASCADE,
+    user_id        BIGINT                   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    action         VARCHAR(32)              NOT NULL,
+    changes        JSONB                    NOT NULL DEFAULT '[]',
+    snapshot       JSONB,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_workout_edits_workout_day_id ON workout_edits (workout_day_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS workout_edits;
+-- +goose StatementEnd
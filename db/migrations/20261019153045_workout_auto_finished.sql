@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE workout_days
+    ADD COLUMN auto_finished BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_workout_days_not_completed ON workout_days (started_at) WHERE completed = FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_workout_days_not_completed;
+
+ALTER TABLE workout_days
+    DROP COLUMN auto_finished;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE auth_sessions
+(
+    id                      UUID PRIMARY KEY,
+    user_id                 BIGINT                   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    refresh_token_hash      TEXT                     NOT NULL UNIQUE,
+    prev_refresh_token_hash TEXT,
+    user_agent              TEXT                     NOT NULL DEFAULT '',
+    ip                      TEXT                     NOT NULL DEFAULT '',
+    created_at              TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_used_at            TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at              TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at              TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_auth_sessions_user_id ON auth_sessions (user_id);
+CREATE INDEX idx_auth_sessions_prev_refresh_token_hash ON auth_sessions (prev_refresh_token_hash);
+
+-- denylist: jti отдельных access токенов и id отозванных сессий, пока их токены не истекли
+CREATE TABLE revoked_tokens
+(
+    id         TEXT PRIMARY KEY,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_revoked_tokens_created_at ON revoked_tokens (created_at);
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS revoked_tokens;
+DROP TABLE IF EXISTS auth_sessions;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_identities
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT                   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    provider   VARCHAR(64)              NOT NULL,
+    subject    TEXT                     NOT NULL,
+    email      TEXT                     NOT NULL DEFAULT '',
+    login      TEXT                     NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_identities;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_credentials
+(
+    user_id           BIGINT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    email             TEXT                     NOT NULL UNIQUE, -- всегда в нижнем регистре
+    password_hash     TEXT,                                     -- NULL, если входят только по magic link
+    email_verified_at TIMESTAMP WITH TIME ZONE,
+    created_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE email_tokens
+(
+    id         BIGSERIAL PRIMARY KEY,
+    email      TEXT                     NOT NULL,
+    purpose    VARCHAR(16)              NOT NULL,
+    token_hash TEXT                     NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at    TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_email_tokens_email ON email_tokens (email, purpose);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS email_tokens;
+DROP TABLE IF EXISTS user_credentials;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN role        VARCHAR(16) NOT NULL DEFAULT 'athlete',
+    ADD COLUMN disabled_at TIMESTAMP WITH TIME ZONE;
+
+-- раньше администратор был зашит в коде
+UPDATE users SET role = 'admin' WHERE id = 1 AND username = 'dsaenko';
+
+CREATE TABLE audit_log
+(
+    id          BIGSERIAL PRIMARY KEY,
+    actor_id    BIGINT                   REFERENCES users (id) ON DELETE SET NULL,
+    action      VARCHAR(64)              NOT NULL,
+    target_type VARCHAR(32)              NOT NULL,
+    target_id   TEXT                     NOT NULL,
+    details     JSONB,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_created_at ON audit_log (created_at DESC);
+CREATE INDEX idx_audit_log_actor_id ON audit_log (actor_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_log;
+ALTER TABLE users
+    DROP COLUMN IF EXISTS disabled_at,
+    DROP COLUMN IF EXISTS role;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE coach_links
+(
+    id          BIGSERIAL PRIMARY KEY,
+    coach_id    BIGINT                   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    athlete_id  BIGINT REFERENCES users (id) ON DELETE CASCADE, -- NULL, пока приглашение не принято
+    invite_code VARCHAR(16) UNIQUE,                             -- очищается после принятия
+    status      VARCHAR(16)              NOT NULL,
+    scopes      TEXT                     NOT NULL,              -- через запятую: workouts,stats,...
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    revoked_at  TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_coach_links_coach_id ON coach_links (coach_id);
+CREATE INDEX idx_coach_links_athlete_id ON coach_links (athlete_id);
+CREATE UNIQUE INDEX uniq_coach_links_active ON coach_links (coach_id, athlete_id) WHERE status = 'active';
+
+ALTER TABLE workout_programs
+    ADD COLUMN assigned_by_id BIGINT REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE TABLE workout_comments
+(
+    id             BIGSERIAL PRIMARY KEY,
+    workout_day_id BIGINT                   NOT NULL REFERENCES workout_days (id) ON DELETE CASCADE,
+    author_id      BIGINT                   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    text           TEXT                     NOT NULL,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_workout_comments_workout_day_id ON workout_comments (workout_day_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS workout_comments;
+ALTER TABLE workout_programs
+    DROP COLUMN IF EXISTS assigned_by_id;
+DROP TABLE IF EXISTS coach_links;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE workout_reactions
+(
+    id             BIGSERIAL PRIMARY KEY,
+    workout_day_id BIGINT                   NOT NULL REFERENCES workout_days (id) ON DELETE CASCADE,
+    user_id        BIGINT                   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    emoji          VARCHAR(16)              NOT NULL,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (workout_day_id, user_id)
+);
+
+-- комментарии по ссылке видны всем, у кого она есть; комментарии тренера остаются приватными
+ALTER TABLE workout_comments
+    ADD COLUMN public BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE workout_shares
+    ADD COLUMN comments_enabled BOOLEAN NOT NULL DEFAULT TRUE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE workout_shares
+    DROP COLUMN IF EXISTS comments_enabled;
+ALTER TABLE workout_comments
+    DROP COLUMN IF EXISTS public;
+DROP TABLE IF EXISTS workout_reactions;
+-- +goose StatementEnd
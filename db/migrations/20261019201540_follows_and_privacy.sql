@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE follows
+(
+    follower_id BIGINT                   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    followee_id BIGINT                   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    status      VARCHAR(16)              NOT NULL, -- pending, accepted
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX idx_follows_followee_id ON follows (followee_id, status);
+
+-- видимость: private, followers, public
+ALTER TABLE users
+    ADD COLUMN workouts_visibility VARCHAR(16) NOT NULL DEFAULT 'followers',
+    ADD COLUMN records_visibility  VARCHAR(16) NOT NULL DEFAULT 'followers',
+    ADD COLUMN feed_digest         BOOLEAN     NOT NULL DEFAULT TRUE,
+    ADD COLUMN feed_digest_sent_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_workout_days_user_completed ON workout_days (user_id, started_at) WHERE completed;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_workout_days_user_completed;
+ALTER TABLE users
+    DROP COLUMN IF EXISTS feed_digest_sent_at,
+    DROP COLUMN IF EXISTS feed_digest,
+    DROP COLUMN IF EXISTS records_visibility,
+    DROP COLUMN IF EXISTS workouts_visibility;
+DROP TABLE IF EXISTS follows;
+-- +goose StatementEnd
@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE challenges
+(
+    id               BIGSERIAL PRIMARY KEY,
+    owner_id         BIGINT                   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    title            VARCHAR(128)             NOT NULL,
+    metric           VARCHAR(32)              NOT NULL,                              -- workouts, tonnage, cardio_minutes, meters, e1rm
+    exercise_type_id BIGINT REFERENCES exercise_types (id) ON DELETE SET NULL, -- только для e1rm
+    invite_code      VARCHAR(16)              NOT NULL UNIQUE,
+    starts_at        TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at          TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at      TIMESTAMP WITH TIME ZONE,                                       -- итоги подведены и разосланы
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_challenges_ends_at ON challenges (ends_at) WHERE finished_at IS NULL;
+
+CREATE TABLE challenge_participants
+(
+    challenge_id BIGINT                   NOT NULL REFERENCES challenges (id) ON DELETE CASCADE,
+    user_id      BIGINT                   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    score        DOUBLE PRECISION         NOT NULL DEFAULT 0,
+    joined_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (challenge_id, user_id)
+);
+
+CREATE INDEX idx_challenge_participants_user_id ON challenge_participants (user_id);
+CREATE INDEX idx_challenge_participants_score ON challenge_participants (challenge_id, score DESC);
+
+-- вклад тренировки в результат участника: пересчитывается при каждом изменении подходов тренировки,
+-- результат участника — сумма (или максимум для e1rm) вкладов
+CREATE TABLE challenge_contributions
+(
+    challenge_id   BIGINT           NOT NULL REFERENCES challenges (id) ON DELETE CASCADE,
+    workout_day_id BIGINT           NOT NULL REFERENCES workout_days (id) ON DELETE CASCADE,
+    user_id        BIGINT           NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    value          DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (challenge_id, workout_day_id)
+);
+
+CREATE INDEX idx_challenge_contributions_user ON challenge_contributions (challenge_id, user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS challenge_contributions;
+DROP TABLE IF EXISTS challenge_participants;
+DROP TABLE IF EXISTS challenges;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- полученные значки; earned_at — момент из истории, когда условие выполнилось впервые
+CREATE TABLE user_achievements
+(
+    id             BIGSERIAL PRIMARY KEY,
+    user_id        BIGINT                   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    code           VARCHAR(64)              NOT NULL,
+    earned_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    workout_day_id BIGINT REFERENCES workout_days (id) ON DELETE SET NULL, -- тренировка, на которой значок получен
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, code)
+);
+
+CREATE INDEX idx_measurements_user_id_created_at ON measurements (user_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_measurements_user_id_created_at;
+DROP TABLE IF EXISTS user_achievements;
+-- +goose StatementEnd
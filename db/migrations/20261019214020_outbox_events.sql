@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- доменные события пишутся в одной транзакции с изменением и доставляются подписчикам после коммита
+CREATE TABLE outbox_events
+(
+    id              BIGSERIAL PRIMARY KEY,
+    type            VARCHAR(64)              NOT NULL,
+    user_id         BIGINT,                                     -- чьих данных касается событие
+    payload         JSONB                    NOT NULL,
+    delivered_to    JSONB                    NOT NULL DEFAULT '[]', -- подписчики, уже обработавшие событие
+    attempts        INT                      NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    processed_at    TIMESTAMP WITH TIME ZONE,
+    failed_at       TIMESTAMP WITH TIME ZONE,                   -- попытки исчерпаны, событие оставлено для разбора
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events (next_attempt_at, id)
+    WHERE processed_at IS NULL AND failed_at IS NULL;
+CREATE INDEX idx_outbox_events_processed_at ON outbox_events (processed_at) WHERE processed_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS outbox_events;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE webhooks
+(
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT                   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    url         TEXT                     NOT NULL,
+    secret      VARCHAR(128)             NOT NULL, -- ключ HMAC-подписи тела запроса
+    event_types JSONB                    NOT NULL DEFAULT '[]',
+    description VARCHAR(128)             NOT NULL DEFAULT '',
+    active      BOOLEAN                  NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhooks_user_id ON webhooks (user_id);
+
+-- журнал доставок: одна строка на событие и вебхук, попытки повторяются до успеха или исчерпания
+CREATE TABLE webhook_deliveries
+(
+    id              BIGSERIAL PRIMARY KEY,
+    webhook_id      BIGINT                   NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_type      VARCHAR(64)              NOT NULL,
+    payload         JSONB                    NOT NULL,
+    status          VARCHAR(16)              NOT NULL DEFAULT 'pending', -- pending, success, failed
+    attempts        INT                      NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    response_status INT,
+    response_body   TEXT,
+    error           TEXT,
+    duration_ms     INT,
+    delivered_at    TIMESTAMP WITH TIME ZONE,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries (next_attempt_at, id) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
+-- +goose StatementEnd
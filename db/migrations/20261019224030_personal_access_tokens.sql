@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- персональные токены для скриптов и интеграций; сам токен не хранится, только sha256
+CREATE TABLE personal_access_tokens
+(
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT                   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name         VARCHAR(64)              NOT NULL,
+    token_hash   VARCHAR(64)              NOT NULL UNIQUE,
+    token_prefix VARCHAR(16)              NOT NULL, -- начало токена, чтобы пользователь узнал его в списке
+    scopes       JSONB                    NOT NULL DEFAULT '[]',
+    expires_at   TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at   TIMESTAMP WITH TIME ZONE,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_personal_access_tokens_user_id ON personal_access_tokens (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS personal_access_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- постраничные списки и выгрузка идут по (started_at, id) от новых к старым
+CREATE INDEX idx_workout_days_user_started_id ON workout_days (user_id, started_at DESC, id DESC);
+-- упражнения и подходы догружаются и агрегируются по внешним ключам
+CREATE INDEX IF NOT EXISTS idx_exercises_workout_day_id ON exercises (workout_day_id);
+CREATE INDEX IF NOT EXISTS idx_sets_exercise_id ON sets (exercise_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_sets_exercise_id;
+DROP INDEX IF EXISTS idx_exercises_workout_day_id;
+DROP INDEX IF EXISTS idx_workout_days_user_started_id;
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+-- агрегаты выполненных подходов по дням и неделям (по Москве), чтобы статистика не пересчитывалась из подходов
+CREATE TABLE stats_daily_rollups
+(
+    user_id          BIGINT                   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    period           DATE                     NOT NULL,
+    exercise_type_id BIGINT                   NOT NULL REFERENCES exercise_types (id) ON DELETE CASCADE,
+    group_code       VARCHAR(64)              NOT NULL DEFAULT '',
+    workouts         INT                      NOT NULL DEFAULT 0,
+    sets             INT                      NOT NULL DEFAULT 0,
+    reps             INT                      NOT NULL DEFAULT 0,
+    tonnage          NUMERIC                  NOT NULL DEFAULT 0,
+    minutes          INT                      NOT NULL DEFAULT 0,
+    meters           INT                      NOT NULL DEFAULT 0,
+    max_weight       NUMERIC                  NOT NULL DEFAULT 0,
+    max_weight_reps  INT                      NOT NULL DEFAULT 0,
+    best_e1rm        NUMERIC                  NOT NULL DEFAULT 0,
+    min_minutes      INT                      NOT NULL DEFAULT 0,
+    max_minutes      INT                      NOT NULL DEFAULT 0,
+    min_meters       INT                      NOT NULL DEFAULT 0,
+    max_meters       INT                      NOT NULL DEFAULT 0,
+    updated_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, period, exercise_type_id)
+);
+
+-- period — понедельник недели
+CREATE TABLE stats_weekly_rollups
+(
+    LIKE stats_daily_rollups INCLUDING DEFAULTS,
+    PRIMARY KEY (user_id, period, exercise_type_id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (exercise_type_id) REFERENCES exercise_types (id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS stats_weekly_rollups;
+DROP TABLE IF EXISTS stats_daily_rollups;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- незавершенные входы через OIDC: живут несколько минут, пока пользователь на странице провайдера.
+-- В базе, а не в памяти, чтобы возврат от провайдера мог попасть на любой инстанс
+CREATE TABLE oauth_pending_logins
+(
+    state         VARCHAR(64) PRIMARY KEY,
+    provider      VARCHAR(32)              NOT NULL,
+    binding_hash  VARCHAR(64)              NOT NULL, -- sha256 значения из cookie браузера, начавшего вход
+    nonce         VARCHAR(64)              NOT NULL,
+    code_verifier VARCHAR(64)              NOT NULL,
+    redirect_uri  TEXT                     NOT NULL,
+    link_user_id  BIGINT REFERENCES users (id) ON DELETE CASCADE,
+    expires_at    TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_oauth_pending_logins_expires_at ON oauth_pending_logins (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS oauth_pending_logins;
+-- +goose StatementEnd
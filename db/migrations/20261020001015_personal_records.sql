@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- рекорды тренировок: лента читает их отсюда, а не ищет окном по всей истории подходов на каждый запрос
+CREATE TABLE personal_records
+(
+    workout_id       BIGINT NOT NULL REFERENCES workout_days (id) ON DELETE CASCADE,
+    exercise_type_id BIGINT NOT NULL REFERENCES exercise_types (id) ON DELETE CASCADE,
+    user_id          BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    weight           FLOAT8 NOT NULL,
+    prev_weight      FLOAT8 NOT NULL,
+    PRIMARY KEY (workout_id, exercise_type_id)
+);
+
+CREATE INDEX idx_personal_records_user_id ON personal_records (user_id);
+
+INSERT INTO personal_records (workout_id, exercise_type_id, user_id, weight, prev_weight)
+SELECT r.workout_id, r.exercise_type_id, r.user_id, r.weight, r.prev_weight
+FROM (
+    SELECT p.*, MAX(p.weight) OVER (
+        PARTITION BY p.user_id, p.exercise_type_id
+        ORDER BY p.started_at, p.workout_id
+        ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) AS prev_weight
+    FROM (
+        SELECT wd.user_id, wd.id AS workout_id, wd.started_at, e.exercise_type_id,
+            MAX(COALESCE(NULLIF(s.fact_weight, 0), s.weight))::FLOAT8 AS weight
+        FROM workout_days wd
+        JOIN exercises e ON e.workout_day_id = wd.id
+        JOIN sets s ON s.exercise_id = e.id AND s.completed
+        WHERE wd.completed
+        GROUP BY wd.user_id, wd.id, wd.started_at, e.exercise_type_id
+    ) p
+) r
+WHERE r.prev_weight > 0 AND r.weight > r.prev_weight;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS personal_records;
+-- +goose StatementEnd
@@ -3,9 +3,12 @@ package common
 import (
 	"errors"
 	"fmt"
+	"log/slog"
+
 	"github.com/SaenkoDmitry/training-tg-bot/internal/constants"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/metrics"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
@@ -34,7 +37,11 @@ func (p *Presenter) UnpinMessage(chatID int64, message tgbotapi.Message) {
 	p.bot.Request(unpinChatMessageConfig)
 }
 
+// HandleInternalError сообщает пользователю о серверной ошибке сценария name и учитывает ее в метриках
 func (p *Presenter) HandleInternalError(err error, chatID int64, name string) {
+	slog.Error("telegram action failed", "action", name, "chat_id", chatID, "err", err)
+	metrics.TelegramErrors.WithLabelValues(name).Inc()
+
 	if errors.Is(err, users.NotFoundUserErr) {
 		msg := tgbotapi.NewMessage(chatID, messages.FirstCreateUser)
 		msg.ParseMode = constants.MarkdownParseMode
@@ -43,7 +50,6 @@ func (p *Presenter) HandleInternalError(err error, chatID int64, name string) {
 
 	text := fmt.Sprintf("❌ Не удалось выполнить действие '%s' "+
 		"из-за серверной ошибки. Попробуйте позже", name)
-	fmt.Println(text)
 	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ParseMode = constants.MarkdownParseMode
 	p.bot.Send(msg)
@@ -0,0 +1,57 @@
+package achievements
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/common"
+	achievementusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/achievements"
+	userusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/users"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+type Handler struct {
+	presenter       *Presenter
+	commonPresenter *common.Presenter
+
+	findMyUC  *achievementusecases.FindMyUseCase
+	getUserUC *userusecases.GetUseCase
+}
+
+func NewHandler(
+	bot *tgbotapi.BotAPI,
+	findMyUC *achievementusecases.FindMyUseCase,
+	getUserUC *userusecases.GetUseCase,
+) *Handler {
+	return &Handler{
+		presenter:       NewPresenter(bot),
+		commonPresenter: common.NewPresenter(bot),
+		findMyUC:        findMyUC,
+		getUserUC:       getUserUC,
+	}
+}
+
+func (h *Handler) RouteCallback(chatID int64, data string) {
+	switch data {
+	case "achievements_list":
+		h.list(chatID)
+	}
+}
+
+func (h *Handler) RouteMessage(chatID int64, text string) {
+	switch text {
+	case "/achievements":
+		h.list(chatID)
+	}
+}
+
+func (h *Handler) list(chatID int64) {
+	user, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.findMyUC.Name())
+		return
+	}
+	result, err := h.findMyUC.Execute(user.ID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.findMyUC.Name())
+		return
+	}
+	h.presenter.ShowList(chatID, result)
+}
@@ -0,0 +1,56 @@
+package achievements
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/constants"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+type Presenter struct {
+	bot *tgbotapi.BotAPI
+}
+
+func NewPresenter(bot *tgbotapi.BotAPI) *Presenter {
+	return &Presenter{bot: bot}
+}
+
+func (p *Presenter) ShowList(chatID int64, result *dto.Achievements) {
+	var text bytes.Buffer
+	text.WriteString(fmt.Sprintf("<b>%s</b> — %d из %d\n", messages.Achievements, result.Earned, result.Total))
+	text.WriteString(fmt.Sprintf("🔥 Серия: %d нед. подряд, лучшая — %d\n\n", result.Streak.Current, result.Streak.Best))
+
+	for _, a := range result.Items {
+		if a.Earned {
+			text.WriteString(fmt.Sprintf("%s <b>%s</b> — %s\n", a.Icon, html.EscapeString(a.Title),
+				a.EarnedAt.Add(3*time.Hour).Format("02.01.2006")))
+			continue
+		}
+		text.WriteString(fmt.Sprintf("🔒 %s — %d/%d\n", html.EscapeString(a.Title), a.Progress, a.Threshold))
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text.String())
+	msg.ParseMode = constants.HtmlParseMode
+	p.bot.Send(msg)
+}
+
+// ShowUnlocked поздравляет с новыми значками
+func (p *Presenter) ShowUnlocked(unlock dto.AchievementUnlock) {
+	var text bytes.Buffer
+	text.WriteString(fmt.Sprintf("<b>%s</b>\n\n", messages.AchievementsUnlocked))
+	for _, a := range unlock.Achievements {
+		text.WriteString(fmt.Sprintf("%s <b>%s</b>\n%s\n\n", a.Icon, html.EscapeString(a.Title), html.EscapeString(a.Description)))
+	}
+
+	msg := tgbotapi.NewMessage(unlock.ChatID, text.String())
+	msg.ParseMode = constants.HtmlParseMode
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(messages.Achievements, "achievements_list"),
+	))
+	p.bot.Send(msg)
+}
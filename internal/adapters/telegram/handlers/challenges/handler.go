@@ -0,0 +1,119 @@
+package challenges
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/common"
+	challengeusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/challenges"
+	userusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/challenges"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+type Handler struct {
+	presenter       *Presenter
+	commonPresenter *common.Presenter
+
+	findMyUC  *challengeusecases.FindMyUseCase
+	getUC     *challengeusecases.GetUseCase
+	joinUC    *challengeusecases.JoinUseCase
+	getUserUC *userusecases.GetUseCase
+}
+
+func NewHandler(
+	bot *tgbotapi.BotAPI,
+	findMyUC *challengeusecases.FindMyUseCase,
+	getUC *challengeusecases.GetUseCase,
+	joinUC *challengeusecases.JoinUseCase,
+	getUserUC *userusecases.GetUseCase,
+) *Handler {
+	return &Handler{
+		presenter:       NewPresenter(bot),
+		commonPresenter: common.NewPresenter(bot),
+		findMyUC:        findMyUC,
+		getUC:           getUC,
+		joinUC:          joinUC,
+		getUserUC:       getUserUC,
+	}
+}
+
+func (h *Handler) RouteCallback(chatID int64, data string) {
+	switch {
+	case data == "challenge_list":
+		h.list(chatID)
+
+	case strings.HasPrefix(data, "challenge_show_"):
+		challengeID, _ := strconv.ParseInt(strings.TrimPrefix(data, "challenge_show_"), 10, 64)
+		h.show(chatID, challengeID)
+	}
+}
+
+func (h *Handler) RouteMessage(chatID int64, text string) {
+	switch {
+	case text == "/challenges":
+		h.list(chatID)
+
+	case strings.HasPrefix(text, "/join_challenge"):
+		h.join(chatID, strings.TrimSpace(strings.TrimPrefix(text, "/join_challenge")))
+	}
+}
+
+func (h *Handler) list(chatID int64) {
+	user, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.findMyUC.Name())
+		return
+	}
+	items, err := h.findMyUC.Execute(user.ID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.findMyUC.Name())
+		return
+	}
+	h.presenter.ShowList(chatID, items)
+}
+
+func (h *Handler) show(chatID, challengeID int64) {
+	user, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.getUC.Name())
+		return
+	}
+	details, err := h.getUC.Execute(user.ID, challengeID)
+	switch {
+	case errors.Is(err, challengeusecases.NotParticipantErr), errors.Is(err, challenges.NotFoundChallengeErr):
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.ChallengeNotAvailable)
+		return
+	case err != nil:
+		h.commonPresenter.HandleInternalError(err, chatID, h.getUC.Name())
+		return
+	}
+	h.presenter.ShowLeaderboard(chatID, details)
+}
+
+func (h *Handler) join(chatID int64, code string) {
+	if code == "" {
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.ChallengeJoinUsage)
+		return
+	}
+	user, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.joinUC.Name())
+		return
+	}
+	item, err := h.joinUC.Execute(user.ID, code)
+	switch {
+	case errors.Is(err, challenges.NotFoundChallengeErr):
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.ChallengeInvalidCode)
+		return
+	case errors.Is(err, challengeusecases.ChallengeFinishedErr):
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.ChallengeFinished)
+		return
+	case err != nil:
+		h.commonPresenter.HandleInternalError(err, chatID, h.joinUC.Name())
+		return
+	}
+	h.show(chatID, item.ID)
+}
@@ -0,0 +1,132 @@
+package challenges
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/constants"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/leaderboard"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// в таблице лидеров в телеграме показываем первых; полная таблица — в приложении
+const leaderboardSize = 10
+
+type Presenter struct {
+	bot *tgbotapi.BotAPI
+}
+
+func NewPresenter(bot *tgbotapi.BotAPI) *Presenter {
+	return &Presenter{bot: bot}
+}
+
+func (p *Presenter) ShowList(chatID int64, items []dto.ChallengeItem) {
+	if len(items) == 0 {
+		p.send(chatID, messages.ChallengesEmpty, nil)
+		return
+	}
+
+	var text bytes.Buffer
+	text.WriteString(fmt.Sprintf("<b>%s</b>\n\n", messages.Challenges))
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(items))
+	for i, item := range items {
+		status := fmt.Sprintf("до %s", formatDate(item.EndsAt))
+		if item.FinishedAt != nil {
+			status = "завершён"
+		}
+		text.WriteString(fmt.Sprintf("%d. %s — %s, %s\n", i+1, html.EscapeString(item.Title), metricTitle(item), status))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🏆 "+item.Title, fmt.Sprintf("challenge_show_%d", item.ID)),
+		))
+	}
+	markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	p.send(chatID, text.String(), &markup)
+}
+
+func (p *Presenter) ShowLeaderboard(chatID int64, details *dto.ChallengeDetails) {
+	c := details.Challenge
+	var text bytes.Buffer
+	text.WriteString(fmt.Sprintf("<b>🏆 %s</b>\n%s, %s — %s\n\n", html.EscapeString(c.Title), metricTitle(c),
+		formatDate(c.StartsAt), formatDate(c.EndsAt)))
+	writeLeaderboard(&text, c.Metric, details.Leaderboard)
+	if c.FinishedAt == nil && c.InviteCode != "" {
+		text.WriteString(fmt.Sprintf("\nПригласить: <code>/join_challenge %s</code>", c.InviteCode))
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔄 Обновить", fmt.Sprintf("challenge_show_%d", c.ID)),
+			tgbotapi.NewInlineKeyboardButtonData(messages.BackTo, "challenge_list"),
+		),
+	)
+	p.send(chatID, text.String(), &markup)
+}
+
+// ShowResult отправляет итоги участнику
+func (p *Presenter) ShowResult(result dto.ChallengeResult, recipient dto.ChallengeRecipient) {
+	title, body := leaderboard.Describe(result, recipient)
+	var text bytes.Buffer
+	text.WriteString(fmt.Sprintf("<b>%s</b>\n%s\n\n", html.EscapeString(title), html.EscapeString(body)))
+	writeLeaderboard(&text, result.Challenge.Metric, result.Leaderboard)
+	p.send(recipient.ChatID, text.String(), nil)
+}
+
+func writeLeaderboard(text *bytes.Buffer, metric string, entries []dto.LeaderboardEntry) {
+	for i, e := range entries {
+		if i == leaderboardSize {
+			text.WriteString(fmt.Sprintf("…и ещё %d\n", len(entries)-leaderboardSize))
+			break
+		}
+		name := html.EscapeString(e.User.Name)
+		if e.IsMe {
+			name = "<b>" + name + "</b>"
+		}
+		text.WriteString(fmt.Sprintf("%s %s — %s\n", place(e.Rank), name, leaderboard.FormatScore(metric, e.Score)))
+	}
+}
+
+func place(rank int) string {
+	switch rank {
+	case 1:
+		return "🥇"
+	case 2:
+		return "🥈"
+	case 3:
+		return "🥉"
+	}
+	return fmt.Sprintf("%d.", rank)
+}
+
+func metricTitle(c dto.ChallengeItem) string {
+	switch c.Metric {
+	case models.ChallengeMetricWorkouts:
+		return "тренировки"
+	case models.ChallengeMetricTonnage:
+		return "тоннаж"
+	case models.ChallengeMetricCardioMinutes:
+		return "минуты кардио"
+	case models.ChallengeMetricMeters:
+		return "метры"
+	case models.ChallengeMetricE1RM:
+		return fmt.Sprintf("1ПМ «%s»", html.EscapeString(c.ExerciseName))
+	}
+	return c.Metric
+}
+
+func formatDate(t time.Time) string {
+	return t.Add(3 * time.Hour).Format("02.01.2006")
+}
+
+func (p *Presenter) send(chatID int64, text string, markup *tgbotapi.InlineKeyboardMarkup) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = constants.HtmlParseMode
+	if markup != nil {
+		msg.ReplyMarkup = markup
+	}
+	p.bot.Send(msg)
+}
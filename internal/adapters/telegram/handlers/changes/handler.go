@@ -7,10 +7,12 @@ import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/daytypes"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/exercises/presenter"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/programs"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/workouts"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/groups"
 	measurementsusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/measurements"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/session"
 	userusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/users"
+	workoutusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/workouts"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/constants"
 	"strconv"
 	"strings"
@@ -36,6 +38,7 @@ type Handler struct {
 	programPresenter  *programs.Presenter
 
 	dayTypesHandler *daytypes.Handler
+	workoutsHandler *workouts.Handler
 
 	userStatesMachine *userstatemachine.UserStatesMachine
 
@@ -52,6 +55,8 @@ type Handler struct {
 	createMeasurementUC  *measurementsusecases.CreateUseCase
 	addExPresetUC        *daytypeusecases.AddExPresetUseCase
 	updatePresetUC       *daytypeusecases.UpdatePresetUseCase
+	updateWorkoutTimesUC *workoutusecases.UpdateTimesUseCase
+	logPastWorkoutUC     *workoutusecases.LogPastUseCase
 }
 
 func NewHandler(
@@ -70,6 +75,9 @@ func NewHandler(
 	editProgramUC *programusecases.GetUseCase,
 	dayTypesHandler *daytypes.Handler,
 	createMeasurementUC *measurementsusecases.CreateUseCase,
+	updateWorkoutTimesUC *workoutusecases.UpdateTimesUseCase,
+	logPastWorkoutUC *workoutusecases.LogPastUseCase,
+	workoutsHandler *workouts.Handler,
 ) *Handler {
 	return &Handler{
 		presenter:            NewPresenter(bot),
@@ -91,6 +99,9 @@ func NewHandler(
 		getProgramUC:         editProgramUC,
 		createMeasurementUC:  createMeasurementUC,
 		dayTypesHandler:      dayTypesHandler,
+		updateWorkoutTimesUC: updateWorkoutTimesUC,
+		logPastWorkoutUC:     logPastWorkoutUC,
+		workoutsHandler:      workoutsHandler,
 	}
 }
 
@@ -128,6 +139,16 @@ func (h *Handler) RouteCallback(chatID int64, data string) {
 		h.userStatesMachine.SetValue(chatID, fmt.Sprintf("awaiting_meters_%d", exerciseID))
 		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.EnterNewMeters)
 
+	case strings.HasPrefix(data, "change_workout_times_"):
+		// workoutID и дата начала тренировки
+		h.userStatesMachine.SetValue(chatID, "awaiting_workout_times_"+strings.TrimPrefix(data, "change_workout_times_"))
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.EnterWorkoutTimes)
+
+	case strings.HasPrefix(data, "change_log_past_workout_"):
+		dayTypeID, _ := strconv.ParseInt(strings.TrimPrefix(data, "change_log_past_workout_"), 10, 64)
+		h.userStatesMachine.SetValue(chatID, fmt.Sprintf("awaiting_past_workout_%d", dayTypeID))
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.EnterPastWorkoutTimes)
+
 	case strings.HasPrefix(data, "change_day_name_"):
 		programID, _ := strconv.ParseInt(strings.TrimPrefix(data, "change_day_name_"), 10, 64)
 		h.userStatesMachine.SetValue(chatID, fmt.Sprintf("awaiting_day_name_for_program_%d", programID))
@@ -306,6 +327,60 @@ func (h *Handler) RouteMessage(chatID int64, text string) {
 			h.exercisePresenter.ShowCurrentSession(chatID, sessionResult)
 		}
 
+	case strings.HasPrefix(state, "awaiting_workout_times_"):
+		parts := strings.Split(strings.TrimPrefix(state, "awaiting_workout_times_"), "_")
+		if len(parts) < 2 {
+			return
+		}
+		workoutID, _ := strconv.ParseInt(parts[0], 10, 64)
+		startedAt, endedAt, err := utils.ParseWorkoutTimes(text, parts[1])
+		if err != nil {
+			h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.IncorrectFormatWorkoutTimes)
+			return
+		}
+		user, err := h.getUserUC.Execute(chatID)
+		if err != nil {
+			h.commonPresenter.HandleInternalError(err, chatID, h.getUserUC.Name())
+			return
+		}
+		_, err = h.updateWorkoutTimesUC.Execute(user.ID, workoutID, 0, &dto.UpdateWorkoutTimes{StartedAt: startedAt, EndedAt: endedAt})
+		if err != nil {
+			if errors.Is(err, workoutusecases.InvalidTimesErr) {
+				h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.IncorrectWorkoutTimes)
+				return
+			}
+			h.commonPresenter.HandleInternalError(err, chatID, h.updateWorkoutTimesUC.Name())
+			return
+		}
+		h.userStatesMachine.Clear(chatID)
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.WorkoutTimesUpdated)
+		h.workoutsHandler.ShowProgress(chatID, workoutID, true)
+
+	case strings.HasPrefix(state, "awaiting_past_workout_"):
+		dayTypeID, _ := strconv.ParseInt(strings.TrimPrefix(state, "awaiting_past_workout_"), 10, 64)
+		startedAt, endedAt, err := utils.ParseWorkoutTimes(text, utils.FormatDate(time.Now()))
+		if err != nil || startedAt == nil {
+			h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.IncorrectFormatWorkoutTimes)
+			return
+		}
+		user, err := h.getUserUC.Execute(chatID)
+		if err != nil {
+			h.commonPresenter.HandleInternalError(err, chatID, h.getUserUC.Name())
+			return
+		}
+		res, err := h.logPastWorkoutUC.Execute(user.ID, dayTypeID, *startedAt, *endedAt)
+		if err != nil {
+			if errors.Is(err, workoutusecases.InvalidTimesErr) {
+				h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.IncorrectWorkoutTimes)
+				return
+			}
+			h.commonPresenter.HandleInternalError(err, chatID, h.logPastWorkoutUC.Name())
+			return
+		}
+		h.userStatesMachine.Clear(chatID)
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.PastWorkoutLogged)
+		h.workoutsHandler.ShowProgress(chatID, res.WorkoutID, true)
+
 	case strings.HasPrefix(state, "awaiting_program_name_"):
 		programID, _ := strconv.ParseInt(strings.TrimPrefix(state, "awaiting_program_name_"), 10, 64)
 		err := h.renameProgramUC.Execute(programID, text)
@@ -0,0 +1,202 @@
+package coaching
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/common"
+	coachingusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/coaching"
+	commentusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/comments"
+	userusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/users"
+	workoutusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/workouts"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+type Handler struct {
+	presenter       *Presenter
+	commonPresenter *common.Presenter
+
+	getOverviewUC  *coachingusecases.GetOverviewUseCase
+	createInviteUC *coachingusecases.CreateInviteUseCase
+	acceptInviteUC *coachingusecases.AcceptInviteUseCase
+	revokeLinkUC   *coachingusecases.RevokeLinkUseCase
+	checkAccessUC  *coachingusecases.CheckAccessUseCase
+	addCommentUC   *commentusecases.AddUseCase
+	showProgressUC *workoutusecases.ShowProgressUseCase
+	getUserUC      *userusecases.GetUseCase
+	getUserByIDUC  *userusecases.GetByIDUseCase
+}
+
+func NewHandler(
+	bot *tgbotapi.BotAPI,
+	getOverviewUC *coachingusecases.GetOverviewUseCase,
+	createInviteUC *coachingusecases.CreateInviteUseCase,
+	acceptInviteUC *coachingusecases.AcceptInviteUseCase,
+	revokeLinkUC *coachingusecases.RevokeLinkUseCase,
+	checkAccessUC *coachingusecases.CheckAccessUseCase,
+	addCommentUC *commentusecases.AddUseCase,
+	showProgressUC *workoutusecases.ShowProgressUseCase,
+	getUserUC *userusecases.GetUseCase,
+	getUserByIDUC *userusecases.GetByIDUseCase,
+) *Handler {
+	return &Handler{
+		presenter:       NewPresenter(bot),
+		commonPresenter: common.NewPresenter(bot),
+		getOverviewUC:   getOverviewUC,
+		createInviteUC:  createInviteUC,
+		acceptInviteUC:  acceptInviteUC,
+		revokeLinkUC:    revokeLinkUC,
+		checkAccessUC:   checkAccessUC,
+		addCommentUC:    addCommentUC,
+		showProgressUC:  showProgressUC,
+		getUserUC:       getUserUC,
+		getUserByIDUC:   getUserByIDUC,
+	}
+}
+
+func (h *Handler) RouteCallback(chatID int64, data string) {
+	switch {
+	case data == "coaching_overview":
+		h.overview(chatID)
+
+	case data == "coaching_invite":
+		h.invite(chatID)
+
+	case strings.HasPrefix(data, "coaching_revoke_"):
+		linkID, _ := strconv.ParseInt(strings.TrimPrefix(data, "coaching_revoke_"), 10, 64)
+		h.revoke(chatID, linkID)
+	}
+}
+
+func (h *Handler) RouteMessage(chatID int64, text string) {
+	switch {
+	case text == "/coach":
+		h.overview(chatID)
+
+	case strings.HasPrefix(text, "/accept_coach"):
+		h.accept(chatID, strings.TrimSpace(strings.TrimPrefix(text, "/accept_coach")))
+
+	case strings.HasPrefix(text, "/comment"):
+		h.comment(chatID, strings.TrimSpace(strings.TrimPrefix(text, "/comment")))
+	}
+}
+
+func (h *Handler) overview(chatID int64) {
+	user, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.getOverviewUC.Name())
+		return
+	}
+	res, err := h.getOverviewUC.Execute(user.ID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.getOverviewUC.Name())
+		return
+	}
+	h.presenter.ShowOverview(chatID, res, user.Role == models.RoleCoach || user.IsAdmin())
+}
+
+func (h *Handler) invite(chatID int64) {
+	user, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.createInviteUC.Name())
+		return
+	}
+	invite, err := h.createInviteUC.Execute(user.ID, nil)
+	if errors.Is(err, coachingusecases.NotCoachErr) {
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.CoachOnly)
+		return
+	}
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.createInviteUC.Name())
+		return
+	}
+	h.presenter.ShowInvite(chatID, invite)
+}
+
+func (h *Handler) accept(chatID int64, code string) {
+	user, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.acceptInviteUC.Name())
+		return
+	}
+	coach, err := h.acceptInviteUC.Execute(user.ID, code)
+	switch {
+	case errors.Is(err, coachingusecases.InvalidInviteErr), errors.Is(err, coachingusecases.SelfInviteErr):
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.CoachInviteInvalid)
+		return
+	case err != nil:
+		h.commonPresenter.HandleInternalError(err, chatID, h.acceptInviteUC.Name())
+		return
+	}
+	h.presenter.ShowAccepted(chatID, coach)
+}
+
+func (h *Handler) revoke(chatID int64, linkID int64) {
+	user, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.revokeLinkUC.Name())
+		return
+	}
+	err = h.revokeLinkUC.Execute(user.ID, linkID)
+	if errors.Is(err, coachingusecases.NotLinkParticipantErr) {
+		return
+	}
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.revokeLinkUC.Name())
+		return
+	}
+	h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.CoachLinkRevoked)
+}
+
+// comment разбирает "/comment <id тренировки> <текст>"; писать может владелец или тренер со scope comments
+func (h *Handler) comment(chatID int64, args string) {
+	idText, text, _ := strings.Cut(args, " ")
+	workoutID, err := strconv.ParseInt(idText, 10, 64)
+	if err != nil || strings.TrimSpace(text) == "" {
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.CommentUsage)
+		return
+	}
+
+	user, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.addCommentUC.Name())
+		return
+	}
+	progress, err := h.showProgressUC.Execute(workoutID)
+	if err != nil {
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.CommentNotAllowed)
+		return
+	}
+	ownerID := progress.Workout.UserID
+	if ownerID != user.ID {
+		allowed, err := h.checkAccessUC.Execute(user.ID, ownerID, models.CoachScopeComments)
+		if err != nil {
+			h.commonPresenter.HandleInternalError(err, chatID, h.addCommentUC.Name())
+			return
+		}
+		if !allowed {
+			h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.CommentNotAllowed)
+			return
+		}
+	}
+
+	item, err := h.addCommentUC.Execute(user.ID, workoutID, text, false)
+	switch {
+	case errors.Is(err, commentusecases.EmptyCommentErr), errors.Is(err, commentusecases.TooLongCommentErr):
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.CommentUsage)
+		return
+	case err != nil:
+		h.commonPresenter.HandleInternalError(err, chatID, h.addCommentUC.Name())
+		return
+	}
+	h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.CommentSaved)
+
+	if ownerID != user.ID {
+		if owner, err := h.getUserByIDUC.Execute(ownerID); err == nil && owner.HasTelegram() {
+			h.presenter.NotifyComment(owner.ChatID, workoutID, item)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package coaching
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/constants"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+type Presenter struct {
+	bot *tgbotapi.BotAPI
+}
+
+func NewPresenter(bot *tgbotapi.BotAPI) *Presenter {
+	return &Presenter{bot: bot}
+}
+
+func (p *Presenter) ShowOverview(chatID int64, res *dto.CoachingOverview, canInvite bool) {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0)
+	var text bytes.Buffer
+	text.WriteString(fmt.Sprintf("<b>%s</b>\n\n", messages.Coaching))
+
+	if len(res.Athletes) > 0 {
+		text.WriteString("<b>Спортсмены:</b>\n")
+		for i, a := range res.Athletes {
+			text.WriteString(fmt.Sprintf("%d. %s (%s)\n", i+1, html.EscapeString(a.Name), strings.Join(a.Scopes, ", ")))
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("📆️ "+a.Name, fmt.Sprintf("workout_show_by_user_id_%d", a.UserID)),
+				tgbotapi.NewInlineKeyboardButtonData("❌", fmt.Sprintf("coaching_revoke_%d", a.ID)),
+			))
+		}
+		text.WriteString("\n")
+	}
+
+	if len(res.Coaches) > 0 {
+		text.WriteString("<b>Мои тренеры:</b>\n")
+		for i, c := range res.Coaches {
+			text.WriteString(fmt.Sprintf("%d. %s (%s)\n", i+1, html.EscapeString(c.Name), strings.Join(c.Scopes, ", ")))
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("❌ Отключить "+c.Name, fmt.Sprintf("coaching_revoke_%d", c.ID)),
+			))
+		}
+		text.WriteString("\n")
+	}
+
+	if len(res.Athletes) == 0 && len(res.Coaches) == 0 {
+		text.WriteString(messages.CoachingEmpty + "\n")
+	}
+
+	if canInvite {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(messages.CoachInvite, "coaching_invite"),
+		))
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text.String())
+	msg.ParseMode = constants.HtmlParseMode
+	if len(rows) > 0 {
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	}
+	p.bot.Send(msg)
+}
+
+func (p *Presenter) ShowInvite(chatID int64, invite *dto.CoachInvite) {
+	text := fmt.Sprintf("🔑 Код приглашения: <code>%s</code>\n\n"+
+		"Спортсмену нужно отправить боту <code>/accept_coach %s</code> до %s",
+		invite.Code, invite.Code, invite.ExpiresAt.Add(3*time.Hour).Format("02.01.2006 15:04"))
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = constants.HtmlParseMode
+	p.bot.Send(msg)
+}
+
+func (p *Presenter) ShowAccepted(chatID int64, coach *dto.CoachLinkItem) {
+	text := fmt.Sprintf("🤝 Тренер <b>%s</b> теперь видит: %s\n\nОтключить доступ можно командой /coach",
+		html.EscapeString(coach.Name), strings.Join(coach.Scopes, ", "))
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = constants.HtmlParseMode
+	p.bot.Send(msg)
+}
+
+func (p *Presenter) NotifyComment(chatID int64, workoutID int64, item *dto.WorkoutCommentItem) {
+	text := fmt.Sprintf("💬 <b>%s</b> прокомментировал тренировку #%d:\n\n%s",
+		html.EscapeString(item.AuthorName), workoutID, html.EscapeString(item.Text))
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = constants.HtmlParseMode
+	p.bot.Send(msg)
+}
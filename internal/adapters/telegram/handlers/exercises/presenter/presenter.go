@@ -7,6 +7,7 @@ import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/utils"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"log/slog"
 	"strings"
 )
 
@@ -66,9 +67,9 @@ func (p *Presenter) ShowCurrentSession(chatID int64, res *dto.CurrentExerciseSes
 
 	rows := make([][]tgbotapi.InlineKeyboardButton, 0)
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData(messages.DoneSet, fmt.Sprintf("set_complete_%d", exercise.ID)),
-		tgbotapi.NewInlineKeyboardButtonData(messages.AddSet, fmt.Sprintf("set_add_one_%d", exercise.ID)),
-		tgbotapi.NewInlineKeyboardButtonData(messages.RemoveSet, fmt.Sprintf("set_remove_last_%d", exercise.ID)),
+		tgbotapi.NewInlineKeyboardButtonData(messages.DoneSet, fmt.Sprintf("set_complete_%d_v%d", exercise.ID, exercise.Version)),
+		tgbotapi.NewInlineKeyboardButtonData(messages.AddSet, fmt.Sprintf("set_add_one_%d_v%d", exercise.ID, exercise.Version)),
+		tgbotapi.NewInlineKeyboardButtonData(messages.RemoveSet, fmt.Sprintf("set_remove_last_%d_v%d", exercise.ID, exercise.Version)),
 		tgbotapi.NewInlineKeyboardButtonData(messages.Timer, fmt.Sprintf("timer_start_%d_ex_%d", exerciseObj.RestInSeconds, exercise.ID)),
 	))
 
@@ -228,12 +229,13 @@ func (p *Presenter) ShowSelectExerciseForCurrentWorkoutDialog(chatID, workoutID
 			),
 		))
 	}
-	fmt.Println("rows", len(rows), rows)
+	slog.Debug("exercise keyboard", "rows", len(rows))
 	msg := tgbotapi.NewMessage(chatID, text)
 	msg.ParseMode = constants.HtmlParseMode
 	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
-	_, err := p.bot.Send(msg)
-	fmt.Println("err:", err)
+	if _, err := p.bot.Send(msg); err != nil {
+		slog.Error("send exercise keyboard failed", "chat_id", chatID, "err", err)
+	}
 }
 
 func (p *Presenter) ShowAllGroups(chatID int64, groups []dto.Group) {
@@ -8,6 +8,7 @@ import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/timers"
 	exercisecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/session"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/versioning"
 	"strconv"
 	"strings"
 
@@ -58,25 +59,45 @@ func NewHandler(
 func (h *Handler) RouteCallback(chatID int64, data string) {
 	switch {
 	case strings.HasPrefix(data, "set_complete_"):
-		exerciseID, _ := strconv.ParseInt(strings.TrimPrefix(data, "set_complete_"), 10, 64)
-		h.completeExerciseSet(chatID, exerciseID)
+		exerciseID, version := parseExerciseVersion(strings.TrimPrefix(data, "set_complete_"))
+		h.completeExerciseSet(chatID, exerciseID, version)
 
 	case strings.HasPrefix(data, "set_add_one_"):
-		exerciseID, _ := strconv.ParseInt(strings.TrimPrefix(data, "set_add_one_"), 10, 64)
-		h.addOneMoreSet(chatID, exerciseID)
+		exerciseID, version := parseExerciseVersion(strings.TrimPrefix(data, "set_add_one_"))
+		h.addOneMoreSet(chatID, exerciseID, version)
 
 	case strings.HasPrefix(data, "set_remove_last_"):
-		exerciseID, _ := strconv.ParseInt(strings.TrimPrefix(data, "set_remove_last_"), 10, 64)
-		h.removeLastSet(chatID, exerciseID)
+		exerciseID, version := parseExerciseVersion(strings.TrimPrefix(data, "set_remove_last_"))
+		h.removeLastSet(chatID, exerciseID, version)
 	}
 }
 
-func (h *Handler) completeExerciseSet(chatID int64, exerciseID int64) {
-	res, err := h.completeSetUC.Execute(exerciseID)
+// parseExerciseVersion разбирает "<exerciseID>_v<version>", у старых кнопок версии нет
+func parseExerciseVersion(data string) (int64, int64) {
+	idStr, versionStr, _ := strings.Cut(data, "_v")
+	exerciseID, _ := strconv.ParseInt(idStr, 10, 64)
+	version, _ := strconv.ParseInt(versionStr, 10, 64)
+	return exerciseID, version
+}
+
+// refreshStale показывает актуальное состояние упражнения вместо применения устаревшего нажатия
+func (h *Handler) refreshStale(chatID int64, workoutID int64) {
+	h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.SetStale)
+	if sessionResult, sessionErr := h.showCurrentSessionUC.Execute(workoutID); sessionErr == nil {
+		h.exercisePresenter.ShowCurrentSession(chatID, sessionResult)
+	}
+}
+
+func (h *Handler) completeExerciseSet(chatID int64, exerciseID, version int64) {
+	res, err := h.completeSetUC.Execute(exerciseID, version)
 	if err != nil {
 		if errors.Is(err, setusecases.DoNothingErr) {
 			return
 		}
+		if errors.Is(err, versioning.ConflictErr) {
+			h.refreshStale(chatID, res.WorkoutID)
+			return
+		}
 		h.commonPresenter.HandleInternalError(err, chatID, h.completeSetUC.Name())
 		return
 	}
@@ -99,9 +120,13 @@ func (h *Handler) completeExerciseSet(chatID int64, exerciseID int64) {
 	}
 }
 
-func (h *Handler) addOneMoreSet(chatID int64, exerciseID int64) {
-	res, err := h.addOneMoreSetUC.Execute(exerciseID)
+func (h *Handler) addOneMoreSet(chatID int64, exerciseID, version int64) {
+	res, err := h.addOneMoreSetUC.Execute(exerciseID, version)
 	if err != nil {
+		if errors.Is(err, versioning.ConflictErr) {
+			h.refreshStale(chatID, res.WorkoutID)
+			return
+		}
 		h.commonPresenter.HandleInternalError(err, chatID, h.addOneMoreSetUC.Name())
 		return
 	}
@@ -112,9 +137,13 @@ func (h *Handler) addOneMoreSet(chatID int64, exerciseID int64) {
 	}
 }
 
-func (h *Handler) removeLastSet(chatID int64, exerciseID int64) {
-	res, err := h.removeLastSetUC.Execute(exerciseID)
+func (h *Handler) removeLastSet(chatID int64, exerciseID, version int64) {
+	res, err := h.removeLastSetUC.Execute(exerciseID, version)
 	if err != nil {
+		if errors.Is(err, versioning.ConflictErr) {
+			h.refreshStale(chatID, res.WorkoutID)
+			return
+		}
 		if errors.Is(err, setusecases.AddOneMoreExerciseToDeleteErr) {
 			h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.AddOneMoreExerciseToDelete)
 			return
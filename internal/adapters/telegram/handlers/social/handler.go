@@ -0,0 +1,175 @@
+package social
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/common"
+	socialusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/social"
+	userusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/follows"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	feedPageSize      = 10
+	followersPageSize = 20
+)
+
+type Handler struct {
+	presenter       *Presenter
+	commonPresenter *common.Presenter
+
+	getFeedUC        *socialusecases.GetFeedUseCase
+	followUC         *socialusecases.FollowUseCase
+	findFollowersUC  *socialusecases.FindFollowersUseCase
+	acceptFollowerUC *socialusecases.AcceptFollowerUseCase
+	removeFollowerUC *socialusecases.RemoveFollowerUseCase
+	getUserUC        *userusecases.GetUseCase
+}
+
+func NewHandler(
+	bot *tgbotapi.BotAPI,
+	getFeedUC *socialusecases.GetFeedUseCase,
+	followUC *socialusecases.FollowUseCase,
+	findFollowersUC *socialusecases.FindFollowersUseCase,
+	acceptFollowerUC *socialusecases.AcceptFollowerUseCase,
+	removeFollowerUC *socialusecases.RemoveFollowerUseCase,
+	getUserUC *userusecases.GetUseCase,
+) *Handler {
+	return &Handler{
+		presenter:        NewPresenter(bot),
+		commonPresenter:  common.NewPresenter(bot),
+		getFeedUC:        getFeedUC,
+		followUC:         followUC,
+		findFollowersUC:  findFollowersUC,
+		acceptFollowerUC: acceptFollowerUC,
+		removeFollowerUC: removeFollowerUC,
+		getUserUC:        getUserUC,
+	}
+}
+
+func (h *Handler) RouteCallback(chatID int64, data string) {
+	switch {
+	case strings.HasPrefix(data, "social_feed_"):
+		offset, _ := strconv.Atoi(strings.TrimPrefix(data, "social_feed_"))
+		h.feed(chatID, offset)
+
+	case data == "social_requests":
+		h.requests(chatID)
+
+	case strings.HasPrefix(data, "social_accept_"):
+		followerID, _ := strconv.ParseInt(strings.TrimPrefix(data, "social_accept_"), 10, 64)
+		h.accept(chatID, followerID)
+
+	case strings.HasPrefix(data, "social_decline_"):
+		followerID, _ := strconv.ParseInt(strings.TrimPrefix(data, "social_decline_"), 10, 64)
+		h.decline(chatID, followerID)
+	}
+}
+
+func (h *Handler) RouteMessage(chatID int64, text string) {
+	switch {
+	case text == "/feed":
+		h.feed(chatID, 0)
+
+	case text == "/followers":
+		h.requests(chatID)
+
+	case strings.HasPrefix(text, "/follow"):
+		h.follow(chatID, strings.TrimSpace(strings.TrimPrefix(text, "/follow")))
+	}
+}
+
+func (h *Handler) feed(chatID int64, offset int) {
+	user, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.getFeedUC.Name())
+		return
+	}
+	res, err := h.getFeedUC.Execute(user.ID, offset, feedPageSize)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.getFeedUC.Name())
+		return
+	}
+	h.presenter.ShowFeed(chatID, user.ID, res)
+}
+
+func (h *Handler) follow(chatID int64, args string) {
+	followeeID, err := strconv.ParseInt(args, 10, 64)
+	if err != nil {
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.FollowUsage)
+		return
+	}
+
+	user, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.followUC.Name())
+		return
+	}
+	status, err := h.followUC.Execute(user.ID, followeeID)
+	switch {
+	case errors.Is(err, socialusecases.SelfFollowErr):
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.FollowSelf)
+	case errors.Is(err, users.NotFoundUserErr):
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.FollowNotFound)
+	case err != nil:
+		h.commonPresenter.HandleInternalError(err, chatID, h.followUC.Name())
+	case status == models.FollowAccepted:
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.FollowAccepted)
+	default:
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.FollowRequested)
+	}
+}
+
+func (h *Handler) requests(chatID int64) {
+	user, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.findFollowersUC.Name())
+		return
+	}
+	res, err := h.findFollowersUC.Execute(user.ID, true, 0, followersPageSize)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.findFollowersUC.Name())
+		return
+	}
+	h.presenter.ShowRequests(chatID, res)
+}
+
+func (h *Handler) accept(chatID int64, followerID int64) {
+	user, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.acceptFollowerUC.Name())
+		return
+	}
+	err = h.acceptFollowerUC.Execute(user.ID, followerID)
+	if errors.Is(err, follows.NotFoundFollowErr) {
+		return
+	}
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.acceptFollowerUC.Name())
+		return
+	}
+	h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.FollowerAccepted)
+}
+
+func (h *Handler) decline(chatID int64, followerID int64) {
+	user, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.removeFollowerUC.Name())
+		return
+	}
+	err = h.removeFollowerUC.Execute(user.ID, followerID)
+	if errors.Is(err, follows.NotFoundFollowErr) {
+		return
+	}
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.removeFollowerUC.Name())
+		return
+	}
+	h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.FollowerRemoved)
+}
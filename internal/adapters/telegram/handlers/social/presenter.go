@@ -0,0 +1,123 @@
+package social
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/constants"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/feed"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+type Presenter struct {
+	bot *tgbotapi.BotAPI
+}
+
+func NewPresenter(bot *tgbotapi.BotAPI) *Presenter {
+	return &Presenter{bot: bot}
+}
+
+func (p *Presenter) ShowFeed(chatID, userID int64, res *dto.FeedResult) {
+	var text bytes.Buffer
+	text.WriteString(fmt.Sprintf("<b>%s</b>\n", messages.Feed))
+	text.WriteString(fmt.Sprintf("Ваш ID для подписки: <code>%d</code>\n\n", userID))
+
+	if len(res.Items) == 0 {
+		text.WriteString(messages.FeedEmpty)
+	}
+	for _, item := range res.Items {
+		text.WriteString(formatItem(item) + "\n")
+	}
+
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0)
+	nav := make([]tgbotapi.InlineKeyboardButton, 0)
+	if res.Pagination.Offset > 0 {
+		prev := max(res.Pagination.Offset-res.Pagination.Limit, 0)
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("⬅️", fmt.Sprintf("social_feed_%d", prev)))
+	}
+	if next := res.Pagination.Offset + res.Pagination.Limit; next < res.Pagination.Total {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("➡️", fmt.Sprintf("social_feed_%d", next)))
+	}
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(messages.FollowRequests, "social_requests"),
+	))
+
+	msg := tgbotapi.NewMessage(chatID, text.String())
+	msg.ParseMode = constants.HtmlParseMode
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	p.bot.Send(msg)
+}
+
+func (p *Presenter) ShowRequests(chatID int64, res *dto.FollowList) {
+	if len(res.Items) == 0 {
+		msg := tgbotapi.NewMessage(chatID, messages.FollowersEmpty)
+		p.bot.Send(msg)
+		return
+	}
+
+	var text bytes.Buffer
+	text.WriteString(fmt.Sprintf("<b>%s</b>\n\n", messages.FollowRequests))
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(res.Items))
+	for i, item := range res.Items {
+		text.WriteString(fmt.Sprintf("%d. %s (ID %d)\n", i+1, html.EscapeString(item.User.Name), item.User.ID))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ "+item.User.Name, fmt.Sprintf("social_accept_%d", item.User.ID)),
+			tgbotapi.NewInlineKeyboardButtonData("❌", fmt.Sprintf("social_decline_%d", item.User.ID)),
+		))
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text.String())
+	msg.ParseMode = constants.HtmlParseMode
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	p.bot.Send(msg)
+}
+
+func (p *Presenter) ShowDigest(digest dto.FeedDigest) {
+	var text bytes.Buffer
+	text.WriteString("<b>📰 Что нового у друзей</b>\n\n")
+	for _, item := range digest.Items {
+		text.WriteString(formatItem(item) + "\n")
+	}
+	if more := digest.Total - int64(len(digest.Items)); more > 0 {
+		text.WriteString(fmt.Sprintf("\n…и ещё %d\n", more))
+	}
+	text.WriteString("\n<i>" + messages.FeedDigestSettings + "</i>")
+
+	msg := tgbotapi.NewMessage(digest.ChatID, text.String())
+	msg.ParseMode = constants.HtmlParseMode
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(messages.Feed, "social_feed_0"),
+		),
+	)
+	p.bot.Send(msg)
+}
+
+func formatItem(item dto.FeedItem) string {
+	name := html.EscapeString(item.User.Name)
+	if item.Kind == feed.KindRecord {
+		return fmt.Sprintf("🏆 <b>%s</b>: рекорд в «%s» — %s кг (было %s кг)",
+			name, html.EscapeString(item.ExerciseName), formatWeight(item.Weight), formatWeight(item.PrevWeight))
+	}
+
+	at := item.StartedAt
+	if item.EndedAt != nil {
+		at = *item.EndedAt
+	}
+	text := fmt.Sprintf("💪 <b>%s</b> завершил тренировку", name)
+	if item.DayTypeName != "" {
+		text += fmt.Sprintf(" «%s»", html.EscapeString(item.DayTypeName))
+	}
+	return text + fmt.Sprintf(" %s", at.Add(3*time.Hour).Format("02.01 15:04"))
+}
+
+func formatWeight(weight float64) string {
+	return fmt.Sprintf("%g", weight)
+}
@@ -57,7 +57,6 @@ func (p *Presenter) ShowCreatedTimer(chatID int64, seconds int, res *dto.StartTi
 }
 
 func (p *Presenter) handleTimerExpired(chatID int64, timerID string, message tgbotapi.Message, stopTimer func(chatID int64, timerID string)) {
-	fmt.Println("expired")
 	stopTimer(chatID, timerID)
 	editMsg := tgbotapi.NewEditMessageText(
 		chatID,
@@ -70,7 +69,6 @@ func (p *Presenter) handleTimerExpired(chatID int64, timerID string, message tgb
 }
 
 func (p *Presenter) handleTimerStopped(chatID int64, message tgbotapi.Message) {
-	fmt.Println("stopped")
 	p.commonPresenter.UnpinMessage(chatID, message)
 	editMsg := tgbotapi.NewEditMessageText(chatID, message.MessageID, messages.TimerCanceled)
 	editMsg.ParseMode = constants.HtmlParseMode
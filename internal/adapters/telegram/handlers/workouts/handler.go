@@ -4,9 +4,13 @@ import (
 	"errors"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/common"
 	exercisepresenter "github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/exercises/presenter"
+	coachingusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/coaching"
 	programusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/programs"
 	exerciseusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/session"
 	userusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/rbac"
 	"strconv"
 	"strings"
 
@@ -26,6 +30,8 @@ type Handler struct {
 	confirmFinishUC *workoutusecases.ConfirmFinishUseCase
 	showByUserIDUC  *workoutusecases.FindByUserIDUseCase
 	statsUC         *workoutusecases.StatsUseCase
+	reopenUC        *workoutusecases.ReopenUseCase
+	undoAutoUC      *workoutusecases.UndoAutoFinishUseCase
 
 	getByUserProgramUC *programusecases.GetByUserUseCase
 
@@ -33,6 +39,8 @@ type Handler struct {
 
 	getUserUC *userusecases.GetUseCase
 
+	checkCoachAccessUC *coachingusecases.CheckAccessUseCase
+
 	presenter          *Presenter
 	exercisesPresenter *exercisepresenter.Presenter
 	commonPresenter    *common.Presenter
@@ -51,8 +59,11 @@ func NewHandler(
 	ShowCurrentExerciseSessionUC *exerciseusecases.ShowCurrentExerciseSessionUseCase,
 	showByUserIDUC *workoutusecases.FindByUserIDUseCase,
 	statsUC *workoutusecases.StatsUseCase,
+	reopenUC *workoutusecases.ReopenUseCase,
+	undoAutoUC *workoutusecases.UndoAutoFinishUseCase,
 	getByUserProgramUC *programusecases.GetByUserUseCase,
 	getUserUC *userusecases.GetUseCase,
+	checkCoachAccessUC *coachingusecases.CheckAccessUseCase,
 ) *Handler {
 	return &Handler{
 		deleteUC:                     deleteUC,
@@ -67,7 +78,10 @@ func NewHandler(
 		showCurrentExerciseSessionUC: ShowCurrentExerciseSessionUC,
 		getByUserProgramUC:           getByUserProgramUC,
 		statsUC:                      statsUC,
+		reopenUC:                     reopenUC,
+		undoAutoUC:                   undoAutoUC,
 		getUserUC:                    getUserUC,
+		checkCoachAccessUC:           checkCoachAccessUC,
 
 		presenter:          NewPresenter(bot),
 		commonPresenter:    common.NewPresenter(bot),
@@ -114,6 +128,17 @@ func (h *Handler) RouteCallback(chatID int64, data string) {
 		workoutID, _ := strconv.ParseInt(strings.TrimPrefix(data, "workout_finish_"), 10, 64)
 		h.finish(chatID, workoutID)
 
+	case strings.HasPrefix(data, "workout_reopen_"):
+		workoutID, _ := strconv.ParseInt(strings.TrimPrefix(data, "workout_reopen_"), 10, 64)
+		h.reopen(chatID, workoutID)
+
+	case strings.HasPrefix(data, "workout_undo_auto_finish_"):
+		workoutID, _ := strconv.ParseInt(strings.TrimPrefix(data, "workout_undo_auto_finish_"), 10, 64)
+		h.undoAutoFinish(chatID, workoutID)
+
+	case data == "workout_log_past_menu":
+		h.showLogPastMenu(chatID)
+
 	case strings.HasPrefix(data, "workout_show_by_user_id_"):
 		userID, _ := strconv.ParseInt(strings.TrimPrefix(data, "workout_show_by_user_id_"), 10, 64)
 		h.showByUserID(chatID, userID)
@@ -234,14 +259,72 @@ func (h *Handler) confirmFinish(chatID int64, workoutID int64) {
 }
 
 func (h *Handler) finish(chatID int64, workoutID int64) {
-	if _, err := h.finishUC.Execute(workoutID); err != nil {
+	if _, err := h.finishUC.Execute(workoutID, 0); err != nil {
 		h.commonPresenter.HandleInternalError(err, chatID, h.showMyUC.Name())
 		return
 	}
 	h.ShowProgress(chatID, workoutID, false)
 }
 
+func (h *Handler) reopen(chatID int64, workoutID int64) {
+	user, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.getUserUC.Name())
+		return
+	}
+	if _, err = h.reopenUC.Execute(user.ID, workoutID, 0); err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.reopenUC.Name())
+		return
+	}
+	h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.WorkoutReopened)
+	h.ShowProgress(chatID, workoutID, true)
+}
+
+func (h *Handler) undoAutoFinish(chatID int64, workoutID int64) {
+	user, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		h.commonPresenter.HandleInternalError(err, chatID, h.getUserUC.Name())
+		return
+	}
+	if _, err = h.undoAutoUC.Execute(user.ID, workoutID); err != nil {
+		if errors.Is(err, workoutusecases.NotAutoFinishedErr) {
+			h.commonPresenter.SendSimpleHtmlMessage(chatID, messages.WorkoutNotAutoFinished)
+			return
+		}
+		h.commonPresenter.HandleInternalError(err, chatID, h.undoAutoUC.Name())
+		return
+	}
+	if res, showErr := h.showCurrentExerciseSessionUC.Execute(workoutID); showErr == nil {
+		h.exercisesPresenter.ShowCurrentSession(chatID, res)
+	}
+}
+
+func (h *Handler) showLogPastMenu(chatID int64) {
+	program, err := h.getByUserProgramUC.Execute(chatID)
+	if err != nil {
+		return
+	}
+	if len(program.DayTypes) == 0 {
+		h.commonPresenter.SendSimpleHtmlMessage(chatID, "Добавьте тренировочные дни в программу через '⚙️ Настройки'")
+		return
+	}
+
+	h.presenter.ShowLogPastMenu(chatID, program)
+}
+
 func (h *Handler) showByUserID(chatID int64, userID int64) {
+	// кнопка есть только в админке и у тренера, но callback data может прислать кто угодно
+	viewer, err := h.getUserUC.Execute(chatID)
+	if err != nil {
+		return
+	}
+	if !rbac.Allowed(viewer, rbac.ViewAnyWorkouts) {
+		allowed, err := h.checkCoachAccessUC.Execute(viewer.ID, userID, models.CoachScopeWorkouts)
+		if err != nil || !allowed {
+			return
+		}
+	}
+
 	res, err := h.showByUserIDUC.Execute(userID)
 	if err != nil {
 		if errors.Is(err, workoutusecases.EmptyWorkoutsErr) {
@@ -8,6 +8,8 @@ import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/utils"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"html"
+	"log/slog"
 	"strings"
 	"time"
 )
@@ -86,7 +88,7 @@ func (p *Presenter) ShowWorkoutProgress(chatID int64, progress *dto.WorkoutProgr
 	msg := tgbotapi.NewMessage(chatID, text.String())
 	msg.ParseMode = constants.HtmlParseMode
 	if needShowButtons {
-		keyboard := p.buildKeyboard(progress)
+		keyboard := p.buildKeyboard(progress, stats.WorkoutDay.StartedAt)
 		msg.ReplyMarkup = keyboard
 	}
 
@@ -115,9 +117,15 @@ func progressBar(percent int) string {
 	return b.String()
 }
 
-func (p *Presenter) buildKeyboard(data *dto.WorkoutProgress) tgbotapi.InlineKeyboardMarkup {
+func (p *Presenter) buildKeyboard(data *dto.WorkoutProgress, startedAt time.Time) tgbotapi.InlineKeyboardMarkup {
 	workoutID := data.Workout.ID
 
+	// дата начала нужна, чтобы вводить только часы и минуты
+	timesBtn := tgbotapi.NewInlineKeyboardButtonData(
+		"🕒 Время",
+		fmt.Sprintf("change_workout_times_%d_%s", workoutID, utils.FormatDate(startedAt)),
+	)
+
 	backTo := tgbotapi.NewInlineKeyboardButtonData(
 		messages.BackTo,
 		"workout_show_my",
@@ -141,11 +149,14 @@ func (p *Presenter) buildKeyboard(data *dto.WorkoutProgress) tgbotapi.InlineKeyb
 
 		return tgbotapi.NewInlineKeyboardMarkup(
 			tgbotapi.NewInlineKeyboardRow(addExerciseBtn, deleteBtn),
-			tgbotapi.NewInlineKeyboardRow(toWorkoutBtn),
+			tgbotapi.NewInlineKeyboardRow(toWorkoutBtn, timesBtn),
 		)
 	}
 
+	reopenBtn := tgbotapi.NewInlineKeyboardButtonData("✏️ Исправить", fmt.Sprintf("workout_reopen_%d", workoutID))
+
 	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(reopenBtn, timesBtn),
 		tgbotapi.NewInlineKeyboardRow(backTo, deleteBtn),
 	)
 }
@@ -224,7 +235,7 @@ func (p *Presenter) ShowMy(chatID int64, res *dto.ShowMyWorkoutsResult) {
 	text += "<b>Выберите тренировку для просмотра:</b>"
 
 	rows = append(rows, []tgbotapi.InlineKeyboardButton{})
-	fmt.Println("offset", offset, "limit", limit, "count", count)
+	slog.Debug("workouts page", "offset", offset, "limit", limit, "count", count)
 	if offset >= limit {
 		rows[len(rows)-1] = append(rows[len(rows)-1], tgbotapi.NewInlineKeyboardButtonData("⬅️ Предыдущие",
 			fmt.Sprintf("workout_show_my_%d", offset-limit)))
@@ -316,7 +327,7 @@ func (p *Presenter) ShowConfirmFinish(chatID, workoutID int64, res *dto.ConfirmF
 		"Вы уверены, что хотите завершить тренировку:\n"+
 		"*%s*?\n\n"+
 		"После завершения вы сможете просмотреть статистику, "+
-		"но не сможете добавлять новые подходы.", dayType.Name)
+		"а исправить подходы — через кнопку «✏️ Исправить».", dayType.Name)
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
@@ -352,8 +363,8 @@ func (p *Presenter) ShowByUserID(chatID int64, res *dto.ShowWorkoutByUserID) {
 
 		dayType := workout.WorkoutDayType
 
-		text += fmt.Sprintf("%d. <b>%s</b> %s\n   📆️ %s\n\n",
-			i+1, dayType.Name, status, date)
+		text += fmt.Sprintf("%d. <b>%s</b> %s #%d\n   📆️ %s\n\n",
+			i+1, dayType.Name, status, workout.ID, date)
 	}
 
 	msg := tgbotapi.NewMessage(chatID, text)
@@ -374,7 +385,31 @@ func (p *Presenter) ShowCreateWorkoutMenu(chatID int64, program *models.WorkoutP
 			tgbotapi.NewInlineKeyboardButtonData(day.Name, fmt.Sprintf("workout_create_%d", day.ID)),
 		)
 	}
-	buttons = append(buttons, []tgbotapi.InlineKeyboardButton{})
+	buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("📝 Записать прошедшую", "workout_log_past_menu"),
+	))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(buttons...)
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = keyboard
+	msg.ParseMode = constants.MarkdownParseMode
+	p.bot.Send(msg)
+}
+
+func (p *Presenter) ShowLogPastMenu(chatID int64, program *models.WorkoutProgram) {
+	text := "📝 *Какой день тренировки записать?*"
+
+	buttons := make([][]tgbotapi.InlineKeyboardButton, 0)
+
+	for i, day := range program.DayTypes {
+		if i%2 == 0 {
+			buttons = append(buttons, []tgbotapi.InlineKeyboardButton{})
+		}
+		buttons[len(buttons)-1] = append(buttons[len(buttons)-1],
+			tgbotapi.NewInlineKeyboardButtonData(day.Name, fmt.Sprintf("change_log_past_workout_%d", day.ID)),
+		)
+	}
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(buttons...)
 
@@ -383,3 +418,45 @@ func (p *Presenter) ShowCreateWorkoutMenu(chatID int64, program *models.WorkoutP
 	msg.ParseMode = constants.MarkdownParseMode
 	p.bot.Send(msg)
 }
+
+func (p *Presenter) ShowAutoFinished(workout dto.AutoFinishedWorkout) {
+	text := fmt.Sprintf("💤 <b>Тренировка «%s» завершена автоматически</b>\n\n"+
+		"Давно не было активности, поэтому мы закрыли ее по последнему выполненному подходу (%s).\n"+
+		"Если вы еще тренируетесь, верните ее обратно.",
+		workout.DayTypeName, workout.EndedAt.Add(3*time.Hour).Format("15:04"))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("↩️ Продолжить тренировку",
+				fmt.Sprintf("workout_undo_auto_finish_%d", workout.WorkoutID)),
+			tgbotapi.NewInlineKeyboardButtonData("👀 Посмотреть",
+				fmt.Sprintf("workout_show_progress_%d", workout.WorkoutID)),
+		),
+	)
+
+	msg := tgbotapi.NewMessage(workout.ChatID, text)
+	msg.ParseMode = constants.HtmlParseMode
+	msg.ReplyMarkup = keyboard
+	p.bot.Send(msg)
+}
+
+func (p *Presenter) ShowShareActivity(activity dto.ShareActivity) {
+	var text string
+	if activity.Kind == dto.ShareActivityComment {
+		text = fmt.Sprintf("💬 <b>%s</b> прокомментировал тренировку #%d по ссылке:\n\n%s",
+			html.EscapeString(activity.ActorName), activity.WorkoutID, html.EscapeString(activity.Text))
+	} else {
+		text = fmt.Sprintf("%s <b>%s</b> оценил тренировку #%d",
+			activity.Emoji, html.EscapeString(activity.ActorName), activity.WorkoutID)
+	}
+
+	msg := tgbotapi.NewMessage(activity.OwnerChatID, text)
+	msg.ParseMode = constants.HtmlParseMode
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("👀 Посмотреть",
+				fmt.Sprintf("workout_show_progress_%d", activity.WorkoutID)),
+		),
+	)
+	p.bot.Send(msg)
+}
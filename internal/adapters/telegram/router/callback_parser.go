@@ -1,9 +1,9 @@
 package router
 
 import (
-	"fmt"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"log/slog"
 	"strings"
 )
 
@@ -11,7 +11,12 @@ func (r *Router) routeCallback(callbackQuery *tgbotapi.CallbackQuery) {
 	chatID := callbackQuery.Message.Chat.ID
 	data := callbackQuery.Data
 
-	fmt.Println("HandleCallback:", data)
+	slog.Debug("telegram callback", "chat_id", chatID, "data", data)
+
+	if user, _ := r.getUserUC.Execute(chatID); user.IsDisabled() {
+		r.bot.Send(tgbotapi.NewMessage(chatID, messages.AccountDisabled))
+		return
+	}
 
 	switch {
 	case data == messages.BackToMenu || data == "/menu":
@@ -52,5 +57,17 @@ func (r *Router) routeCallback(callbackQuery *tgbotapi.CallbackQuery) {
 
 	case strings.HasPrefix(data, "day_type_"):
 		r.dayTypesHandler.RouteCallback(chatID, data)
+
+	case strings.HasPrefix(data, "coaching_"):
+		r.coachingHandler.RouteCallback(chatID, data)
+
+	case strings.HasPrefix(data, "social_"):
+		r.socialHandler.RouteCallback(chatID, data)
+
+	case strings.HasPrefix(data, "challenge_"):
+		r.challengesHandler.RouteCallback(chatID, data)
+
+	case strings.HasPrefix(data, "achievements_"):
+		r.achievementsHandler.RouteCallback(chatID, data)
 	}
 }
@@ -1,19 +1,24 @@
 package router
 
 import (
-	"fmt"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/constants"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"log/slog"
+	"strings"
 )
 
 func (r *Router) routeMessage(message *tgbotapi.Message) {
 	chatID := message.Chat.ID
 	text := message.Text
 
-	fmt.Println("HandleMessage:", text)
+	slog.Debug("telegram message", "chat_id", chatID, "text", text)
 	user, _ := r.getUserUC.Execute(chatID)
+	if user.IsDisabled() {
+		r.bot.Send(tgbotapi.NewMessage(chatID, messages.AccountDisabled))
+		return
+	}
 
 	switch {
 	case text == messages.BackToMenu || text == "/start" || text == "/menu":
@@ -46,6 +51,28 @@ func (r *Router) routeMessage(message *tgbotapi.Message) {
 	case text == messages.Admin || text == "/admin":
 		r.admin(chatID, user)
 
+	case text == messages.Coaching || text == "/coach" ||
+		strings.HasPrefix(text, "/accept_coach") || strings.HasPrefix(text, "/comment"):
+		if text == messages.Coaching {
+			text = "/coach"
+		}
+		r.coachingHandler.RouteMessage(chatID, text)
+
+	case text == messages.Feed || text == "/feed" || text == "/followers" || strings.HasPrefix(text, "/follow"):
+		if text == messages.Feed {
+			text = "/feed"
+		}
+		r.socialHandler.RouteMessage(chatID, text)
+
+	case text == messages.Challenges || text == "/challenges" || strings.HasPrefix(text, "/join_challenge"):
+		if text == messages.Challenges {
+			text = "/challenges"
+		}
+		r.challengesHandler.RouteMessage(chatID, text)
+
+	case text == messages.Achievements || text == "/achievements":
+		r.achievementsHandler.RouteMessage(chatID, "/achievements")
+
 	default:
 		r.changesHandler.RouteMessage(chatID, text)
 	}
@@ -102,6 +129,16 @@ func (r *Router) settings(chatID int64) {
 	buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
 		tgbotapi.NewInlineKeyboardButtonData(messages.LibraryOfExercises, "exercise_show_all_groups"),
 	))
+	buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(messages.Coaching, "coaching_overview"),
+	))
+	buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(messages.Feed, "social_feed_0"),
+		tgbotapi.NewInlineKeyboardButtonData(messages.Challenges, "challenge_list"),
+	))
+	buttons = append(buttons, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(messages.Achievements, "achievements_list"),
+	))
 	msg := tgbotapi.NewMessage(chatID, "<b>Выберите действие:</b>")
 	msg.ParseMode = constants.HtmlParseMode
 	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons...)
@@ -1,19 +1,27 @@
 package router
 
 import (
-	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/achievements"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/admins"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/challenges"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/changes"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/coaching"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/daytypes"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/exercises"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/exports"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/measurements"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/programs"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/sets"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/social"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/stats"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/timers"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/workouts"
 	userusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/metrics"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
@@ -31,6 +39,10 @@ type Router struct {
 	changesHandler      *changes.Handler
 	dayTypesHandler     *daytypes.Handler
 	adminsHandler       *admins.Handler
+	coachingHandler     *coaching.Handler
+	socialHandler       *social.Handler
+	challengesHandler   *challenges.Handler
+	achievementsHandler *achievements.Handler
 	createUserUC        *userusecases.CreateUseCase
 	getUserUC           *userusecases.GetUseCase
 
@@ -52,6 +64,10 @@ func New(
 	changesHandler *changes.Handler,
 	dayTypesHandler *daytypes.Handler,
 	measurementsHandler *measurements.Handler,
+	coachingHandler *coaching.Handler,
+	socialHandler *social.Handler,
+	challengesHandler *challenges.Handler,
+	achievementsHandler *achievements.Handler,
 ) *Router {
 	return &Router{
 		bot:                 bot,
@@ -68,14 +84,27 @@ func New(
 		changesHandler:      changesHandler,
 		dayTypesHandler:     dayTypesHandler,
 		measurementsHandler: measurementsHandler,
+		coachingHandler:     coachingHandler,
+		socialHandler:       socialHandler,
+		challengesHandler:   challengesHandler,
+		achievementsHandler: achievementsHandler,
 	}
 }
 
+// HandleUpdate обрабатывает обновление и учитывает его в метриках: паника в сценарии
+// не роняет бота, а считается ошибкой обработки
 func (r *Router) HandleUpdate(update tgbotapi.Update) {
+	kind := updateKind(update)
+	start := time.Now()
 	defer func() {
+		result := "ok"
 		if recovery := recover(); recovery != nil {
-			fmt.Printf("Recovered: %v\n", recovery)
+			result = "panic"
+			slog.Error("telegram update panic", "update_id", update.UpdateID, "kind", kind,
+				"panic", recovery, "stack", string(debug.Stack()))
 		}
+		metrics.TelegramUpdates.WithLabelValues(kind, result).Inc()
+		metrics.TelegramUpdateDuration.WithLabelValues(kind).Observe(time.Since(start).Seconds())
 	}()
 	switch {
 	case update.Message != nil:
@@ -85,3 +114,13 @@ func (r *Router) HandleUpdate(update tgbotapi.Update) {
 		r.routeCallback(update.CallbackQuery)
 	}
 }
+
+func updateKind(update tgbotapi.Update) string {
+	switch {
+	case update.Message != nil:
+		return "message"
+	case update.CallbackQuery != nil:
+		return "callback"
+	}
+	return "other"
+}
@@ -1,28 +1,38 @@
 package telegram
 
 import (
-	"fmt"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/measurements"
+	"log/slog"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/achievements"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/admins"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/challenges"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/changes"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/coaching"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/daytypes"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/exercises"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/exports"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/programs"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/sets"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/social"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/stats"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/timers"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/handlers/workouts"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/adapters/telegram/router"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase"
 )
 
 type App struct {
 	bot    *tgbotapi.BotAPI
 	router *router.Router
+
+	workoutsPresenter     *workouts.Presenter
+	socialPresenter       *social.Presenter
+	challengesPresenter   *challenges.Presenter
+	achievementsPresenter *achievements.Presenter
 }
 
 func New(token string, useCases *usecase.Container) (*App, error) {
@@ -30,7 +40,7 @@ func New(token string, useCases *usecase.Container) (*App, error) {
 	if err != nil {
 		return nil, err
 	}
-	fmt.Printf("Authorized on account %s\n", bot.Self.UserName)
+	slog.Info("telegram bot authorized", "account", bot.Self.UserName)
 
 	workoutsHandler := workouts.NewHandler(
 		bot,
@@ -45,8 +55,11 @@ func New(token string, useCases *usecase.Container) (*App, error) {
 		useCases.ShowCurrentExerciseSessionUC,
 		useCases.FindWorkoutsByUserUC,
 		useCases.StatsWorkoutUC,
+		useCases.ReopenWorkoutUC,
+		useCases.UndoAutoFinishUC,
 		useCases.GetByUserProgramUC,
 		useCases.GetUserUC,
+		useCases.CheckCoachAccessUC,
 	)
 
 	exercisesHandler := exercises.NewHandler(
@@ -93,11 +106,26 @@ func New(token string, useCases *usecase.Container) (*App, error) {
 		useCases.GetUserUC, useCases.ShowCurrentExerciseSessionUC, useCases.UpdateNextSetUC,
 		useCases.FindAllProgramsByUserUC, useCases.RenameProgramUC, useCases.GetAllGroupsUC, useCases.DayTypesCreateUC,
 		useCases.AddExPresetUC, useCases.UpdatePresetUC, useCases.GetDayTypeUC, useCases.ExerciseTypeListUC,
-		useCases.GetProgramUC, dayTypesHandler, useCases.CreateMeasurementUC)
+		useCases.GetProgramUC, dayTypesHandler, useCases.CreateMeasurementUC,
+		useCases.UpdateWorkoutTimesUC, useCases.LogPastWorkoutUC, workoutsHandler)
 
 	measurementsHandler := measurements.NewHandler(bot, useCases.FindAllMeasurementsUC, useCases.GetMeasurementByIDUC,
 		useCases.DeleteMeasurementByIDUC, useCases.GetUserUC)
 
+	coachingHandler := coaching.NewHandler(bot,
+		useCases.GetCoachingOverviewUC, useCases.CreateCoachInviteUC, useCases.AcceptCoachInviteUC,
+		useCases.RevokeCoachLinkUC, useCases.CheckCoachAccessUC, useCases.AddWorkoutCommentUC,
+		useCases.ShowWorkoutProgressUC, useCases.GetUserUC, useCases.GetUserByIDUC)
+
+	socialHandler := social.NewHandler(bot,
+		useCases.GetFeedUC, useCases.FollowUC, useCases.FindFollowersUC,
+		useCases.AcceptFollowerUC, useCases.RemoveFollowerUC, useCases.GetUserUC)
+
+	challengesHandler := challenges.NewHandler(bot,
+		useCases.FindMyChallengesUC, useCases.GetChallengeUC, useCases.JoinChallengeUC, useCases.GetUserUC)
+
+	achievementsHandler := achievements.NewHandler(bot, useCases.FindMyAchievementsUC, useCases.GetUserUC)
+
 	r := router.New(
 		bot,
 		useCases.CreateUserUC,
@@ -113,11 +141,20 @@ func New(token string, useCases *usecase.Container) (*App, error) {
 		changesHandler,
 		dayTypesHandler,
 		measurementsHandler,
+		coachingHandler,
+		socialHandler,
+		challengesHandler,
+		achievementsHandler,
 	)
 
 	return &App{
 		bot:    bot,
 		router: r,
+
+		workoutsPresenter:     workouts.NewPresenter(bot),
+		socialPresenter:       social.NewPresenter(bot),
+		challengesPresenter:   challenges.NewPresenter(bot),
+		achievementsPresenter: achievements.NewPresenter(bot),
 	}, nil
 }
 
@@ -133,3 +170,50 @@ func (a *App) Run() error {
 
 	return nil
 }
+
+// NotifyAutoFinished сообщает об автозавершении тренировки с кнопкой отмены
+func (a *App) NotifyAutoFinished(workout dto.AutoFinishedWorkout) error {
+	if workout.ChatID == 0 {
+		return nil
+	}
+	a.workoutsPresenter.ShowAutoFinished(workout)
+	return nil
+}
+
+// NotifyShareActivity сообщает владельцу о реакции или комментарии к расшаренной тренировке
+func (a *App) NotifyShareActivity(activity dto.ShareActivity) error {
+	if activity.OwnerChatID == 0 {
+		return nil
+	}
+	a.workoutsPresenter.ShowShareActivity(activity)
+	return nil
+}
+
+// NotifyFeedDigest отправляет сводку активности подписок
+func (a *App) NotifyFeedDigest(digest dto.FeedDigest) error {
+	if digest.ChatID == 0 {
+		return nil
+	}
+	a.socialPresenter.ShowDigest(digest)
+	return nil
+}
+
+// NotifyChallengeFinished рассылает итоги челленджа участникам с телеграмом
+func (a *App) NotifyChallengeFinished(result dto.ChallengeResult) error {
+	for _, r := range result.Recipients {
+		if r.ChatID == 0 {
+			continue
+		}
+		a.challengesPresenter.ShowResult(result, r)
+	}
+	return nil
+}
+
+// NotifyAchievementsUnlocked поздравляет с новыми значками
+func (a *App) NotifyAchievementsUnlocked(unlock dto.AchievementUnlock) error {
+	if unlock.ChatID == 0 {
+		return nil
+	}
+	a.achievementsPresenter.ShowUnlocked(unlock)
+	return nil
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	accesstokenusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/accesstokens"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/accesstokens"
+)
+
+func (s *serviceImpl) GetMyAccessTokens(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	result, err := s.container.FindMyAccessTokensUC.Execute(claims.UserID)
+	if err != nil {
+		writeAccessTokenError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *serviceImpl) GetAccessTokenScopes(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.container.FindAccessTokenScopesUC.Execute())
+}
+
+func (s *serviceImpl) CreateAccessToken(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	var body dto.CreatePersonalAccessToken
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	token, err := s.container.CreateAccessTokenUC.Execute(claims.UserID, body)
+	if err != nil {
+		writeAccessTokenError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(token)
+}
+
+func (s *serviceImpl) RevokeAccessToken(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	tokenID, err := helpers.ParseInt64Param("token_id", w, r)
+	if err != nil {
+		return
+	}
+
+	if err = s.container.RevokeAccessTokenUC.Execute(claims.UserID, tokenID); err != nil {
+		writeAccessTokenError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeAccessTokenError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, accesstokenusecases.InvalidNameErr):
+		err = apierrors.InvalidField(err, "name", apierrors.FieldOutOfRange)
+	case errors.Is(err, accesstokenusecases.InvalidScopesErr):
+		err = apierrors.InvalidField(err, "scopes", apierrors.FieldNotAllowed)
+	case errors.Is(err, accesstokenusecases.InvalidExpirationErr):
+		err = apierrors.InvalidField(err, "expires_in_days", apierrors.FieldOutOfRange)
+	case errors.Is(err, accesstokens.NotFoundTokenErr):
+		err = apierrors.Wrap(err, http.StatusNotFound, apierrors.CodeNotFound)
+	case errors.Is(err, accesstokenusecases.TooManyTokensErr):
+		err = apierrors.Wrap(err, http.StatusConflict, apierrors.CodeLimitReached)
+	}
+	apierrors.WriteError(w, r, err)
+}
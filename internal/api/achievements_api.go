@@ -0,0 +1,28 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+)
+
+func (s *serviceImpl) GetMyAchievements(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	result, err := s.container.FindMyAchievementsUC.Execute(claims.UserID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "find achievements failed", "err", err)
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
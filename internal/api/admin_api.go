@@ -0,0 +1,238 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/validator"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	adminusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/admin"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/auditlog"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisetypes"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/rbac"
+)
+
+// authorizeAdmin достает пользователя из токена и проверяет, что его роль дает нужное право
+func (s *serviceImpl) authorizeAdmin(w http.ResponseWriter, r *http.Request, permission rbac.Permission) (*middlewares.Claims, bool) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return nil, false
+	}
+	if err := validator.ValidatePermission(s.container, claims.UserID, permission); err != nil {
+		apierrors.WriteError(w, r, err)
+		return nil, false
+	}
+	return claims, true
+}
+
+func (s *serviceImpl) AdminGetUsers(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.authorizeAdmin(w, r, rbac.ViewUsers); !ok {
+		return
+	}
+
+	offset, limit := helpers.GetOffsetLimit(r, 20, 100)
+	result, err := s.container.AdminFindUsersUC.Execute(r.URL.Query().Get("q"), offset, limit)
+	if err != nil {
+		writeAdminError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *serviceImpl) AdminGetUsageStats(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.authorizeAdmin(w, r, rbac.ViewUsageStats); !ok {
+		return
+	}
+
+	stats, err := s.container.AdminGetUsageStatsUC.Execute()
+	if err != nil {
+		writeAdminError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
+
+type adminSetUserRoleRequest struct {
+	Role string `json:"role"`
+}
+
+func (s *serviceImpl) AdminSetUserRole(w http.ResponseWriter, r *http.Request) {
+	claims, ok := s.authorizeAdmin(w, r, rbac.ManageUsers)
+	if !ok {
+		return
+	}
+	userID, err := helpers.ParseInt64Param("user_id", w, r)
+	if err != nil {
+		return
+	}
+
+	var body adminSetUserRoleRequest
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	if err = s.container.AdminSetRoleUC.Execute(claims.UserID, userID, body.Role); err != nil {
+		writeAdminError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type adminDisableUserRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (s *serviceImpl) AdminDisableUser(w http.ResponseWriter, r *http.Request) {
+	claims, ok := s.authorizeAdmin(w, r, rbac.ManageUsers)
+	if !ok {
+		return
+	}
+	userID, err := helpers.ParseInt64Param("user_id", w, r)
+	if err != nil {
+		return
+	}
+
+	// причина необязательна, тело может быть пустым
+	var body adminDisableUserRequest
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	if err = s.container.AdminDisableUserUC.Execute(claims.UserID, userID, body.Reason); err != nil {
+		writeAdminError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *serviceImpl) AdminEnableUser(w http.ResponseWriter, r *http.Request) {
+	claims, ok := s.authorizeAdmin(w, r, rbac.ManageUsers)
+	if !ok {
+		return
+	}
+	userID, err := helpers.ParseInt64Param("user_id", w, r)
+	if err != nil {
+		return
+	}
+
+	if err = s.container.AdminEnableUserUC.Execute(claims.UserID, userID); err != nil {
+		writeAdminError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *serviceImpl) AdminCreateExerciseType(w http.ResponseWriter, r *http.Request) {
+	claims, ok := s.authorizeAdmin(w, r, rbac.EditExerciseLibrary)
+	if !ok {
+		return
+	}
+
+	var input dto.ExerciseTypeInput
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	exerciseType, err := s.container.AdminCreateExerciseTypeUC.Execute(claims.UserID, input)
+	if err != nil {
+		writeAdminError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(exerciseType)
+}
+
+func (s *serviceImpl) AdminUpdateExerciseType(w http.ResponseWriter, r *http.Request) {
+	claims, ok := s.authorizeAdmin(w, r, rbac.EditExerciseLibrary)
+	if !ok {
+		return
+	}
+	exerciseTypeID, err := helpers.ParseInt64Param("exercise_type_id", w, r)
+	if err != nil {
+		return
+	}
+
+	var input dto.ExerciseTypeInput
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	exerciseType, err := s.container.AdminUpdateExerciseTypeUC.Execute(claims.UserID, exerciseTypeID, input)
+	if err != nil {
+		writeAdminError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(exerciseType)
+}
+
+func (s *serviceImpl) AdminDeleteExerciseType(w http.ResponseWriter, r *http.Request) {
+	claims, ok := s.authorizeAdmin(w, r, rbac.EditExerciseLibrary)
+	if !ok {
+		return
+	}
+	exerciseTypeID, err := helpers.ParseInt64Param("exercise_type_id", w, r)
+	if err != nil {
+		return
+	}
+
+	if err = s.container.AdminDeleteExerciseTypeUC.Execute(claims.UserID, exerciseTypeID); err != nil {
+		writeAdminError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *serviceImpl) AdminGetAuditLog(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.authorizeAdmin(w, r, rbac.ViewAuditLog); !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	actorID, _ := strconv.ParseInt(q.Get("actor_id"), 10, 64)
+	filter := auditlog.Filter{
+		ActorID:    actorID,
+		Action:     q.Get("action"),
+		TargetType: q.Get("target_type"),
+		TargetID:   q.Get("target_id"),
+	}
+	offset, limit := helpers.GetOffsetLimit(r, 50, 200)
+
+	result, err := s.container.AdminFindAuditLogUC.Execute(filter, offset, limit)
+	if err != nil {
+		writeAdminError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func writeAdminError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, adminusecases.InvalidRoleErr):
+		err = apierrors.InvalidField(err, "role", apierrors.FieldNotAllowed)
+	case errors.Is(err, adminusecases.InvalidExerciseTypeErr):
+		err = apierrors.Wrap(err, http.StatusUnprocessableEntity, apierrors.CodeValidationFailed)
+	case errors.Is(err, adminusecases.SelfActionErr):
+		err = apierrors.Wrap(err, http.StatusUnprocessableEntity, apierrors.CodeSelfAction)
+	case errors.Is(err, users.NotFoundUserErr),
+		errors.Is(err, exercisetypes.NotFoundExerciseTypeErr):
+		err = apierrors.Wrap(err, http.StatusNotFound, apierrors.CodeNotFound)
+	case errors.Is(err, exercisetypes.ExerciseTypeInUseErr):
+		err = apierrors.Wrap(err, http.StatusConflict, apierrors.CodeExerciseTypeInUse)
+	}
+	apierrors.WriteError(w, r, err)
+}
@@ -0,0 +1,68 @@
+// Package apierrors описывает тело ошибки API: клиенты разбирают code и fields[].code,
+// message предназначен человеку, переведен под Accept-Language и может меняться
+package apierrors
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type Body struct {
+	Error Error `json:"error"`
+}
+
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Field дублирует единственное поле из Fields — так ошибку параметра отдавал /api/v1
+	Field     string       `json:"field,omitempty"`
+	Fields    []FieldError `json:"fields,omitempty"`
+	RequestID string       `json:"request_id,omitempty"` // ID из middleware.RequestID, по нему ищется запрос в логах
+}
+
+// FieldError — ошибка конкретного параметра или поля тела запроса
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Write отвечает ошибкой без исходной причины: нет токена, не хватает параметра и т.п.
+func Write(w http.ResponseWriter, r *http.Request, status int, code string) {
+	WriteError(w, r, New(status, code))
+}
+
+// WriteError приводит err к Problem и пишет ответ; необработанные ошибки
+// становятся 500 и попадают в лог с атрибутами запроса (request_id, user_id)
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	p := From(err)
+	requestID := middleware.GetReqID(r.Context())
+	if p.Status >= http.StatusInternalServerError {
+		slog.ErrorContext(r.Context(), "request failed", "method", r.Method, "path", r.URL.Path, "err", err)
+	}
+
+	lang := Language(r)
+	body := Body{Error: Error{Code: p.Code, Message: Message(lang, p.Code), RequestID: requestID}}
+	for _, f := range p.Fields {
+		// английская причина от валидатора точнее общего текста, русской нет
+		if lang != LangEN || f.Message == "" {
+			f.Message = FieldMessage(lang, f.Code)
+		}
+		body.Error.Fields = append(body.Error.Fields, f)
+	}
+	if len(body.Error.Fields) == 1 {
+		body.Error.Field = body.Error.Fields[0].Field
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", lang)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if requestID != "" {
+		w.Header().Set("X-Request-Id", requestID)
+	}
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(body)
+}
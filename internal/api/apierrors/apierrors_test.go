@@ -0,0 +1,131 @@
+package apierrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/errorslist"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/versioning"
+)
+
+func TestFrom(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"not found", fmt.Errorf("get program: %w", gorm.ErrRecordNotFound), http.StatusNotFound, CodeNotFound},
+		{"access denied", errorslist.ErrAccessDenied, http.StatusForbidden, CodeForbidden},
+		{"version conflict", versioning.ConflictErr, http.StatusConflict, CodeVersionConflict},
+		{"wrapped problem", fmt.Errorf("handler: %w", New(http.StatusTooManyRequests, CodeRateLimited)), http.StatusTooManyRequests, CodeRateLimited},
+		{"unknown", errors.New("boom"), http.StatusInternalServerError, CodeInternal},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := From(tc.err)
+			assert.Equal(t, tc.status, p.Status)
+			assert.Equal(t, tc.code, p.Code)
+		})
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	var fields Fields
+	fields.Range("weight", 900, 0, 500)
+	fields.Required("name", " ")
+
+	cases := []struct {
+		name, lang, message, fieldMessage string
+	}{
+		{"russian by default", "", "Проверьте введенные данные", "Значение вне допустимых границ"},
+		{"english keeps validator reason", "en-US,en;q=0.9", "Request validation failed", "must be from 0 to 500"},
+		{"q values", "en;q=0.3, ru;q=0.8", "Проверьте введенные данные", "Значение вне допустимых границ"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/api/measurements", nil)
+			r.Header.Set("Accept-Language", tc.lang)
+			rec := httptest.NewRecorder()
+			middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				WriteError(w, r, fields.Err())
+			})).ServeHTTP(rec, r)
+
+			require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+			var body Body
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, CodeValidationFailed, body.Error.Code)
+			assert.Equal(t, tc.message, body.Error.Message)
+			assert.NotEmpty(t, body.Error.RequestID)
+			assert.Equal(t, body.Error.RequestID, rec.Header().Get("X-Request-Id"))
+			// несколько полей — field не заполняется, клиент смотрит fields
+			assert.Empty(t, body.Error.Field)
+			require.Len(t, body.Error.Fields, 2)
+			assert.Equal(t, FieldError{Field: "weight", Code: FieldOutOfRange, Message: tc.fieldMessage}, body.Error.Fields[0])
+			assert.Equal(t, FieldRequired, body.Error.Fields[1].Code)
+		})
+	}
+}
+
+func TestWriteErrorHidesCause(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteError(rec, httptest.NewRequest(http.MethodGet, "/api/me", nil), errors.New("pq: connection refused"))
+
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "connection refused")
+	assert.JSONEq(t, `{"error": {"code": "internal", "message": "Серверная ошибка"}}`, rec.Body.String())
+}
+
+func TestInvalidParameterSetsField(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteError(rec, httptest.NewRequest(http.MethodGet, "/api/v1/workouts", nil), InvalidParameter("limit", FieldOutOfRange, "must be at most 100"))
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	var body Body
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, CodeInvalidParameter, body.Error.Code)
+	assert.Equal(t, "limit", body.Error.Field)
+}
+
+// TestMessagesCoverEveryCode — у каждого объявленного кода есть перевод, иначе клиент
+// увидит текст 500 у вполне штатной ошибки
+func TestMessagesCoverEveryCode(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "messages.go", nil, 0)
+	require.NoError(t, err)
+
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				code, err := strconv.Unquote(vs.Values[i].(*ast.BasicLit).Value)
+				require.NoError(t, err)
+				switch {
+				case strings.HasPrefix(name.Name, "Code"):
+					assert.Contains(t, messages, code, name.Name)
+				case strings.HasPrefix(name.Name, "Field"):
+					assert.Contains(t, fieldMessages, code, name.Name)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package apierrors
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Fields накапливает ошибки полей, чтобы клиент получил их все за один ответ
+type Fields []FieldError
+
+func (f *Fields) Add(field, code, reason string) {
+	*f = append(*f, FieldError{Field: field, Code: code, Message: reason})
+}
+
+func (f *Fields) Required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		f.Add(field, FieldRequired, "is required")
+	}
+}
+
+// Range проверяет min <= value <= max
+func (f *Fields) Range(field string, value, min, max float64) {
+	if value < min || value > max {
+		f.Add(field, FieldOutOfRange, fmt.Sprintf("must be from %v to %v", min, max))
+	}
+}
+
+// Positive — для идентификаторов: 0 означает, что поле не передали
+func (f *Fields) Positive(field string, value int64) {
+	if value <= 0 {
+		f.Add(field, FieldRequired, "must be a positive integer")
+	}
+}
+
+func (f *Fields) MaxLen(field, value string, max int) {
+	if utf8.RuneCountInString(value) > max {
+		f.Add(field, FieldTooLong, fmt.Sprintf("must be at most %d characters", max))
+	}
+}
+
+// Err — 422 со всеми накопленными ошибками или nil
+func (f Fields) Err() error {
+	if len(f) == 0 {
+		return nil
+	}
+	return Validation(f...)
+}
+
+// InvalidField — 422 по доменной ошибке, относящейся к одному полю тела
+func InvalidField(err error, field, code string) *Problem {
+	p := Validation(FieldError{Field: field, Code: code, Message: err.Error()})
+	p.Err = err
+	return p
+}
@@ -0,0 +1,190 @@
+package apierrors
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Коды ошибок стабильны: по ним клиенты решают, что показать и что делать дальше
+const (
+	CodeUnauthorized      = "unauthorized"
+	CodeInsufficientScope = "insufficient_scope"
+	CodeForbidden         = "forbidden"
+	CodeNotFound          = "not_found"
+	CodeInvalidParameter  = "invalid_parameter"
+	CodeInvalidBody       = "invalid_body"
+	CodeValidationFailed  = "validation_failed"
+	CodeVersionConflict   = "version_conflict"
+	CodeLimitReached      = "limit_reached"
+	CodeRateLimited       = "rate_limited"
+	CodeUpstream          = "upstream_error"
+	CodeInternal          = "internal"
+
+	// вход и привязка аккаунтов
+	CodeInvalidOrigin         = "invalid_origin"
+	CodeInvalidTelegramAuth   = "invalid_telegram_auth"
+	CodeInvalidRefreshToken   = "invalid_refresh_token"
+	CodeAccountDisabled       = "account_disabled"
+	CodeUnknownProvider       = "unknown_provider"
+	CodeInvalidOAuthState     = "invalid_oauth_state"
+	CodeInvalidIDToken        = "invalid_id_token"
+	CodeProviderAlreadyLinked = "provider_already_linked"
+	CodeAccountsConflict      = "accounts_conflict"
+	CodeLastProvider          = "last_provider"
+	CodeInvalidCredentials    = "invalid_credentials"
+	CodeEmailNotVerified      = "email_not_verified"
+	CodeEmailTaken            = "email_taken"
+	CodeEmailAlreadySet       = "email_already_set"
+	CodeInvalidEmailToken     = "invalid_email_token"
+	CodeTooManyAttempts       = "too_many_attempts"
+
+	// тренировки и программы
+	CodeNoPrograms             = "no_programs"
+	CodeCurrentProgram         = "current_program"
+	CodeProgramInUse           = "program_in_use"
+	CodeDayInUse               = "day_in_use"
+	CodeWorkoutNotCompleted    = "workout_not_completed"
+	CodeWorkoutNotAutoFinished = "workout_not_auto_finished"
+	CodeEmptyMeasurement       = "empty_measurement"
+	CodeExerciseTypeInUse      = "exercise_type_in_use"
+
+	// тренеры, подписки, челленджи
+	CodeSelfAction        = "self_action"
+	CodeNotCoach          = "not_coach"
+	CodeInvalidInvite     = "invalid_invite"
+	CodeAlreadyLinked     = "already_linked"
+	CodeCommentsDisabled  = "comments_disabled"
+	CodeOwnerCannotLeave  = "owner_cannot_leave"
+	CodeChallengeFinished = "challenge_finished"
+)
+
+// Коды ошибок полей
+const (
+	FieldRequired      = "required"
+	FieldInvalidType   = "invalid_type"
+	FieldInvalidFormat = "invalid_format"
+	FieldOutOfRange    = "out_of_range"
+	FieldTooLong       = "too_long"
+	FieldNotAllowed    = "not_allowed"
+)
+
+const (
+	LangRU = "ru"
+	LangEN = "en"
+)
+
+type message struct {
+	ru, en string
+}
+
+var messages = map[string]message{
+	CodeUnauthorized:      {"Требуется вход", "Authentication required"},
+	CodeInsufficientScope: {"У токена нет нужных прав", "Token lacks the required scope"},
+	CodeForbidden:         {"Доступ запрещен", "Access denied"},
+	CodeNotFound:          {"Не найдено", "Not found"},
+	CodeInvalidParameter:  {"Некорректный параметр запроса", "Invalid request parameter"},
+	CodeInvalidBody:       {"Некорректное тело запроса", "Malformed request body"},
+	CodeValidationFailed:  {"Проверьте введенные данные", "Request validation failed"},
+	CodeVersionConflict:   {"Данные уже изменены на другом устройстве, обновите страницу", "Data was changed on another device, reload the page"},
+	CodeLimitReached:      {"Достигнут лимит, удалите ненужное", "Limit reached, delete something first"},
+	CodeRateLimited:       {"Слишком много запросов. Подождите минуту", "Too many requests, try again in a minute"},
+	CodeUpstream:          {"Внешний сервис не ответил, попробуйте позже", "External service failed, try again later"},
+	CodeInternal:          {"Серверная ошибка", "Internal server error"},
+
+	CodeInvalidOrigin:         {"Недопустимый адрес сайта", "Origin is not allowed"},
+	CodeInvalidTelegramAuth:   {"Не удалось проверить вход через Telegram", "Telegram login could not be verified"},
+	CodeInvalidRefreshToken:   {"Сессия истекла, войдите снова", "Session expired, sign in again"},
+	CodeAccountDisabled:       {"Аккаунт заблокирован", "Account is disabled"},
+	CodeUnknownProvider:       {"Неизвестный способ входа", "Unknown login provider"},
+	CodeInvalidOAuthState:     {"Вход устарел, начните заново", "Login state is invalid or expired, start again"},
+	CodeInvalidIDToken:        {"Провайдер не подтвердил вход", "Provider did not confirm the login"},
+	CodeProviderAlreadyLinked: {"Другой аккаунт этого провайдера уже привязан", "Another account of this provider is already linked"},
+	CodeAccountsConflict:      {"Аккаунты привязаны к разным профилям и не могут быть объединены", "Accounts belong to different profiles and cannot be merged"},
+	CodeLastProvider:          {"Нельзя отвязать единственный способ входа", "Cannot unlink the only login provider"},
+	CodeInvalidCredentials:    {"Неверная почта или пароль", "Invalid email or password"},
+	CodeEmailNotVerified:      {"Почта не подтверждена", "Email is not verified"},
+	CodeEmailTaken:            {"Эта почта уже зарегистрирована", "Email is already registered"},
+	CodeEmailAlreadySet:       {"Вход по почте уже настроен", "Email login is already set up"},
+	CodeInvalidEmailToken:     {"Ссылка недействительна или устарела", "Link is invalid or expired"},
+	CodeTooManyAttempts:       {"Слишком много попыток, попробуйте позже", "Too many attempts, try again later"},
+
+	CodeNoPrograms:             {"У вас нет активных программ, создайте хотя бы одну", "No active programs, create one first"},
+	CodeCurrentProgram:         {"Не могу удалить активную программу", "Cannot delete the active program"},
+	CodeProgramInUse:           {"Не могу удалить программу, которая уже есть в истории тренировок", "Program is used in workout history"},
+	CodeDayInUse:               {"Не могу удалить день, который уже есть в истории тренировок", "Workout day is used in workout history"},
+	CodeWorkoutNotCompleted:    {"Тренировка еще не завершена", "Workout is not completed"},
+	CodeWorkoutNotAutoFinished: {"Тренировка не была завершена автоматически", "Workout was not finished automatically"},
+	CodeEmptyMeasurement:       {"Заполните хотя бы один замер", "Measurement must contain at least one value"},
+	CodeExerciseTypeInUse:      {"Упражнение уже используется в тренировках", "Exercise type is used in workouts"},
+
+	CodeSelfAction:        {"Нельзя применить действие к себе", "Action cannot be applied to yourself"},
+	CodeNotCoach:          {"Вы не тренер", "User is not a coach"},
+	CodeInvalidInvite:     {"Приглашение недействительно или устарело", "Invite is invalid or expired"},
+	CodeAlreadyLinked:     {"Тренер и спортсмен уже связаны", "Coach and athlete are already linked"},
+	CodeCommentsDisabled:  {"Владелец отключил комментарии", "Comments are disabled by the owner"},
+	CodeOwnerCannotLeave:  {"Создатель не может выйти из челленджа, удалите его", "Challenge owner cannot leave, delete the challenge instead"},
+	CodeChallengeFinished: {"Челлендж уже завершен", "Challenge is already finished"},
+}
+
+var fieldMessages = map[string]message{
+	FieldRequired:      {"Обязательное поле", "Field is required"},
+	FieldInvalidType:   {"Неверный тип значения", "Value has a wrong type"},
+	FieldInvalidFormat: {"Неверный формат", "Value has a wrong format"},
+	FieldOutOfRange:    {"Значение вне допустимых границ", "Value is out of range"},
+	FieldTooLong:       {"Слишком длинное значение", "Value is too long"},
+	FieldNotAllowed:    {"Недопустимое значение", "Value is not allowed"},
+}
+
+// Message — текст ошибки на языке lang; неизвестный код получает текст 500
+func Message(lang, code string) string {
+	m, ok := messages[code]
+	if !ok {
+		m = messages[CodeInternal]
+	}
+	return m.in(lang)
+}
+
+func FieldMessage(lang, code string) string {
+	m, ok := fieldMessages[code]
+	if !ok {
+		m = fieldMessages[FieldNotAllowed]
+	}
+	return m.in(lang)
+}
+
+func (m message) in(lang string) string {
+	if lang == LangEN {
+		return m.en
+	}
+	return m.ru
+}
+
+// Language выбирает язык по Accept-Language с учетом q; по умолчанию русский,
+// как и весь интерфейс бота
+func Language(r *http.Request) string {
+	type candidate struct {
+		lang string
+		q    float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		base, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if (base == LangRU || base == LangEN) && q > 0 {
+			candidates = append(candidates, candidate{lang: base, q: q})
+		}
+	}
+	if len(candidates) == 0 {
+		return LangRU
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].lang
+}
@@ -0,0 +1,77 @@
+package apierrors
+
+import (
+	"errors"
+	"net/http"
+
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/errorslist"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/versioning"
+)
+
+// Problem — ошибка, которую можно отдать клиенту: статус, код и ошибки полей.
+// Err — исходная причина, нужна для errors.Is и лога, клиенту не показывается
+type Problem struct {
+	Status int
+	Code   string
+	Fields []FieldError
+	Err    error
+}
+
+func New(status int, code string) *Problem {
+	return &Problem{Status: status, Code: code}
+}
+
+// Wrap сопоставляет доменную ошибку статусу и коду
+func Wrap(err error, status int, code string) *Problem {
+	return &Problem{Status: status, Code: code, Err: err}
+}
+
+// InvalidParameter — 400 по path- или query-параметру; reason — пояснение на английском
+func InvalidParameter(param, fieldCode, reason string) *Problem {
+	return &Problem{
+		Status: http.StatusBadRequest,
+		Code:   CodeInvalidParameter,
+		Fields: []FieldError{{Field: param, Code: fieldCode, Message: reason}},
+	}
+}
+
+// InvalidBody — 400, тело не разбирается как JSON нужной формы
+func InvalidBody(err error, fields ...FieldError) *Problem {
+	return &Problem{Status: http.StatusBadRequest, Code: CodeInvalidBody, Fields: fields, Err: err}
+}
+
+// Validation — 422, тело разобрано, но значения полей недопустимы
+func Validation(fields ...FieldError) *Problem {
+	return &Problem{Status: http.StatusUnprocessableEntity, Code: CodeValidationFailed, Fields: fields}
+}
+
+func (p *Problem) Error() string {
+	if p.Err != nil {
+		return p.Code + ": " + p.Err.Error()
+	}
+	return p.Code
+}
+
+func (p *Problem) Unwrap() error {
+	return p.Err
+}
+
+// From сопоставляет ошибку ответу: Problem отдается как есть, ошибки репозиториев
+// и проверки доступа — соответствующими статусами, остальное — 500
+func From(err error) *Problem {
+	var p *Problem
+	switch {
+	case errors.As(err, &p):
+		return p
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return Wrap(err, http.StatusNotFound, CodeNotFound)
+	case errors.Is(err, errorslist.ErrAccessDenied):
+		return Wrap(err, http.StatusForbidden, CodeForbidden)
+	case errors.Is(err, versioning.ConflictErr):
+		return Wrap(err, http.StatusConflict, CodeVersionConflict)
+	default:
+		return Wrap(err, http.StatusInternalServerError, CodeInternal)
+	}
+}
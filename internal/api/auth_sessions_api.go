@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	authsessionusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/authsessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/authsessions"
+	"github.com/go-chi/chi/v5"
+)
+
+type refreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (s *serviceImpl) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	var body refreshTokenRequest
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	tokens, err := s.container.RefreshAuthSessionUC.Execute(body.RefreshToken, clientMeta(r))
+	if err != nil {
+		if errors.Is(err, authsessionusecases.InvalidRefreshTokenErr) ||
+			errors.Is(err, authsessionusecases.RefreshTokenReusedErr) {
+			err = apierrors.Wrap(err, http.StatusUnauthorized, apierrors.CodeInvalidRefreshToken)
+		}
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	writeAuthTokens(w, tokens)
+}
+
+func (s *serviceImpl) GetAuthSessions(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	sessions, err := s.container.FindAuthSessionsUC.Execute(claims.UserID, claims.SessionID)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sessions)
+}
+
+func (s *serviceImpl) RevokeAuthSession(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	err := s.container.RevokeAuthSessionUC.Execute(claims.UserID, chi.URLParam(r, "session_id"))
+	if errors.Is(err, authsessions.NotFoundSessionErr) {
+		err = apierrors.Wrap(err, http.StatusNotFound, apierrors.CodeNotFound)
+	}
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *serviceImpl) RevokeOtherAuthSessions(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	revoked, err := s.container.RevokeOtherSessionUC.Execute(claims.UserID, claims.SessionID)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&RevokedSessionsDTO{Revoked: revoked})
+}
+
+type RevokedSessionsDTO struct {
+	Revoked int `json:"revoked"`
+}
+
+func (s *serviceImpl) startAuthSession(w http.ResponseWriter, r *http.Request, userID int64) {
+	tokens, err := s.container.StartAuthSessionUC.Execute(userID, clientMeta(r))
+	if errors.Is(err, authsessionusecases.UserDisabledErr) {
+		err = apierrors.Wrap(err, http.StatusForbidden, apierrors.CodeAccountDisabled)
+	}
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+	writeAuthTokens(w, tokens)
+}
+
+func writeAuthTokens(w http.ResponseWriter, tokens *dto.AuthTokens) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(tokens)
+}
+
+func clientMeta(r *http.Request) dto.ClientMeta {
+	return dto.ClientMeta{
+		UserAgent: r.UserAgent(),
+		IP:        middlewares.ClientIP(r),
+	}
+}
@@ -0,0 +1,70 @@
+// Package authz проверяет доступ к сущности из параметра пути до хендлера:
+// маршрут объявляет, чья это сущность и кому, кроме владельца, она доступна
+package authz
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase"
+	ownershipusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/ownership"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/ownership"
+)
+
+// Policy — кому доступна сущность: владельцу и, если задан CoachScope, его тренеру
+// с этой областью доступа
+type Policy struct {
+	Resource   ownership.Resource
+	CoachScope string
+}
+
+func Owner(resource ownership.Resource) Policy {
+	return Policy{Resource: resource}
+}
+
+func OwnerOrCoach(resource ownership.Resource, scope string) Policy {
+	return Policy{Resource: resource, CoachScope: scope}
+}
+
+type Authorizer struct {
+	container *usecase.Container
+}
+
+func New(container *usecase.Container) *Authorizer {
+	return &Authorizer{container: container}
+}
+
+// Require пропускает запрос к хендлеру, только если сущность из параметра пути param
+// доступна пользователю: чужая — 403, несуществующая — 404. Ставится после Auth
+func (a *Authorizer) Require(param string, policy Policy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := middlewares.FromContext(r.Context())
+			if !ok {
+				apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+				return
+			}
+			id, err := helpers.ParseInt64Param(param, w, r)
+			if err != nil {
+				return
+			}
+			if err = a.Check(claims.UserID, policy, id); err != nil {
+				apierrors.WriteError(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Check — та же проверка для идентификатора из тела запроса
+func (a *Authorizer) Check(userID int64, policy Policy, id int64) error {
+	err := a.container.CheckResourceAccessUC.Execute(userID, policy.Resource, id, policy.CoachScope)
+	if errors.Is(err, ownershipusecases.ForeignResourceErr) {
+		return apierrors.Wrap(err, http.StatusForbidden, apierrors.CodeForbidden)
+	}
+	return err
+}
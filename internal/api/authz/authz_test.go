@@ -0,0 +1,125 @@
+package authz
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase"
+	coachingusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/coaching"
+	ownershipusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/ownership"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/coaching"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/ownership"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+const (
+	ownerID    = 1
+	coachID    = 2
+	strangerID = 3
+	workoutID  = 10
+)
+
+type fakeOwnership map[int64]int64
+
+func (f fakeOwnership) OwnerID(_ ownership.Resource, id int64) (int64, error) {
+	owner, ok := f[id]
+	if !ok {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return owner, nil
+}
+
+// fakeCoaching — тренер coachID связан с ownerID и видит только его тренировки
+type fakeCoaching struct {
+	coaching.Repo
+}
+
+func (fakeCoaching) GetActive(coach, athlete int64) (*models.CoachLink, error) {
+	if coach != coachID || athlete != ownerID {
+		return nil, coaching.NotFoundLinkErr
+	}
+	return &models.CoachLink{Status: models.CoachLinkActive, Scopes: models.CoachScopeWorkouts}, nil
+}
+
+type fakeUsers struct {
+	users.Repo
+}
+
+func (fakeUsers) GetByID(id int64) (*models.User, error) {
+	return &models.User{ID: id, Role: models.RoleCoach}, nil
+}
+
+func newAuthorizer() *Authorizer {
+	coachAccessUC := coachingusecases.NewCheckAccessUseCase(fakeCoaching{}, fakeUsers{})
+	return New(&usecase.Container{
+		CheckResourceAccessUC: ownershipusecases.NewCheckAccessUseCase(fakeOwnership{workoutID: ownerID}, coachAccessUC),
+	})
+}
+
+func TestRequire(t *testing.T) {
+	az := newAuthorizer()
+
+	cases := []struct {
+		name   string
+		policy Policy
+		userID int64
+		path   string
+		status int
+		code   string
+	}{
+		{"owner", Owner(ownership.Workout), ownerID, "/workouts/10", http.StatusOK, ""},
+		{"stranger", Owner(ownership.Workout), strangerID, "/workouts/10", http.StatusForbidden, apierrors.CodeForbidden},
+		{"coach without policy scope", Owner(ownership.Workout), coachID, "/workouts/10", http.StatusForbidden, apierrors.CodeForbidden},
+		{"coach with scope", OwnerOrCoach(ownership.Workout, models.CoachScopeWorkouts), coachID, "/workouts/10", http.StatusOK, ""},
+		{"coach with another scope", OwnerOrCoach(ownership.Workout, models.CoachScopePrograms), coachID, "/workouts/10", http.StatusForbidden, apierrors.CodeForbidden},
+		{"stranger with coach policy", OwnerOrCoach(ownership.Workout, models.CoachScopeWorkouts), strangerID, "/workouts/10", http.StatusForbidden, apierrors.CodeForbidden},
+		{"missing", Owner(ownership.Workout), ownerID, "/workouts/11", http.StatusNotFound, apierrors.CodeNotFound},
+		{"bad id", Owner(ownership.Workout), ownerID, "/workouts/abc", http.StatusBadRequest, apierrors.CodeInvalidParameter},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reached := false
+			r := chi.NewRouter()
+			r.With(az.Require("workout_id", tc.policy)).Get("/workouts/{workout_id}", func(w http.ResponseWriter, r *http.Request) {
+				reached = true
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			req = req.WithContext(middlewares.WithClaims(req.Context(), jwt.MapClaims{"user_id": float64(tc.userID)}))
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.status, rec.Code)
+			assert.Equal(t, tc.status == http.StatusOK, reached, "handler must run only after a successful check")
+			if tc.code != "" {
+				var body apierrors.Body
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, tc.code, body.Error.Code)
+			}
+		})
+	}
+}
+
+func TestRequireWithoutClaims(t *testing.T) {
+	reached := false
+	h := newAuthorizer().Require("workout_id", Owner(ownership.Workout))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		reached = true
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/workouts/10", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.False(t, reached)
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	challengeusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/challenges"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/challenges"
+)
+
+func (s *serviceImpl) GetMyChallenges(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	result, err := s.container.FindMyChallengesUC.Execute(claims.UserID)
+	if err != nil {
+		writeChallengeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *serviceImpl) CreateChallenge(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	var body dto.CreateChallenge
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	challenge, err := s.container.CreateChallengeUC.Execute(claims.UserID, body)
+	if err != nil {
+		writeChallengeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(challenge)
+}
+
+func (s *serviceImpl) GetChallenge(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	challengeID, err := helpers.ParseInt64Param("challenge_id", w, r)
+	if err != nil {
+		return
+	}
+
+	details, err := s.container.GetChallengeUC.Execute(claims.UserID, challengeID)
+	if err != nil {
+		writeChallengeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(details)
+}
+
+func (s *serviceImpl) DeleteChallenge(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	challengeID, err := helpers.ParseInt64Param("challenge_id", w, r)
+	if err != nil {
+		return
+	}
+
+	if err = s.container.DeleteChallengeUC.Execute(claims.UserID, challengeID); err != nil {
+		writeChallengeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *serviceImpl) LeaveChallenge(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	challengeID, err := helpers.ParseInt64Param("challenge_id", w, r)
+	if err != nil {
+		return
+	}
+
+	if err = s.container.LeaveChallengeUC.Execute(claims.UserID, challengeID); err != nil {
+		writeChallengeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *serviceImpl) GetChallengeInvite(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	invite, err := s.container.GetChallengeInviteUC.Execute(claims.UserID, chi.URLParam(r, "code"))
+	if err != nil {
+		writeChallengeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(invite)
+}
+
+func (s *serviceImpl) JoinChallenge(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	challenge, err := s.container.JoinChallengeUC.Execute(claims.UserID, chi.URLParam(r, "code"))
+	if err != nil {
+		writeChallengeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(challenge)
+}
+
+func writeChallengeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, challengeusecases.EmptyTitleErr):
+		err = apierrors.InvalidField(err, "title", apierrors.FieldRequired)
+	case errors.Is(err, challengeusecases.InvalidMetricErr):
+		err = apierrors.InvalidField(err, "metric", apierrors.FieldNotAllowed)
+	case errors.Is(err, challengeusecases.ExerciseRequiredErr):
+		err = apierrors.InvalidField(err, "exercise_type_id", apierrors.FieldRequired)
+	case errors.Is(err, challengeusecases.InvalidPeriodErr):
+		err = apierrors.InvalidField(err, "ends_at", apierrors.FieldOutOfRange)
+	case errors.Is(err, challengeusecases.NotOwnerErr),
+		errors.Is(err, challengeusecases.NotParticipantErr):
+		err = apierrors.Wrap(err, http.StatusForbidden, apierrors.CodeForbidden)
+	case errors.Is(err, challenges.NotFoundChallengeErr),
+		errors.Is(err, challenges.NotFoundParticipantErr):
+		err = apierrors.Wrap(err, http.StatusNotFound, apierrors.CodeNotFound)
+	case errors.Is(err, challengeusecases.OwnerCannotLeaveErr):
+		err = apierrors.Wrap(err, http.StatusConflict, apierrors.CodeOwnerCannotLeave)
+	case errors.Is(err, challengeusecases.ChallengeFinishedErr):
+		err = apierrors.Wrap(err, http.StatusConflict, apierrors.CodeChallengeFinished)
+	}
+	apierrors.WriteError(w, r, err)
+}
@@ -0,0 +1,287 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/validator"
+	coachingusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/coaching"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/coaching"
+)
+
+func (s *serviceImpl) GetCoachingOverview(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	overview, err := s.container.GetCoachingOverviewUC.Execute(claims.UserID)
+	if err != nil {
+		writeCoachingError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(overview)
+}
+
+type createCoachInviteRequest struct {
+	Scopes []string `json:"scopes"`
+}
+
+func (s *serviceImpl) CreateCoachInvite(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	// без тела приглашение открывает все области доступа
+	var body createCoachInviteRequest
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	invite, err := s.container.CreateCoachInviteUC.Execute(claims.UserID, body.Scopes)
+	if err != nil {
+		writeCoachingError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(invite)
+}
+
+type acceptCoachInviteRequest struct {
+	Code string `json:"code"`
+}
+
+func (s *serviceImpl) AcceptCoachInvite(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	var body acceptCoachInviteRequest
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	coach, err := s.container.AcceptCoachInviteUC.Execute(claims.UserID, body.Code)
+	if err != nil {
+		writeCoachingError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(coach)
+}
+
+func (s *serviceImpl) RevokeCoachLink(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	linkID, err := helpers.ParseInt64Param("link_id", w, r)
+	if err != nil {
+		return
+	}
+
+	if err = s.container.RevokeCoachLinkUC.Execute(claims.UserID, linkID); err != nil {
+		writeCoachingError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type updateCoachScopesRequest struct {
+	Scopes []string `json:"scopes"`
+}
+
+func (s *serviceImpl) UpdateCoachScopes(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	linkID, err := helpers.ParseInt64Param("link_id", w, r)
+	if err != nil {
+		return
+	}
+
+	var body updateCoachScopesRequest
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	scopes, err := s.container.UpdateCoachScopesUC.Execute(claims.UserID, linkID, body.Scopes)
+	if err != nil {
+		writeCoachingError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&CoachScopesDTO{Scopes: scopes})
+}
+
+type CoachScopesDTO struct {
+	Scopes []string `json:"scopes"`
+}
+
+// authorizeAthlete разбирает athlete_id из пути и проверяет, что спортсмен открыл тренеру нужную область
+func (s *serviceImpl) authorizeAthlete(w http.ResponseWriter, r *http.Request, scope string) (*middlewares.Claims, int64, bool) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return nil, 0, false
+	}
+	athleteID, err := helpers.ParseInt64Param("athlete_id", w, r)
+	if err != nil {
+		return nil, 0, false
+	}
+	if err = validator.ValidateAccessToAthlete(s.container, claims.UserID, athleteID, scope); err != nil {
+		apierrors.WriteError(w, r, err)
+		return nil, 0, false
+	}
+	return claims, athleteID, true
+}
+
+func (s *serviceImpl) GetAthleteWorkouts(w http.ResponseWriter, r *http.Request) {
+	_, athleteID, ok := s.authorizeAthlete(w, r, models.CoachScopeWorkouts)
+	if !ok {
+		return
+	}
+
+	res, err := s.findWorkoutsPage(r, athleteID)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *serviceImpl) GetAthleteMeasurements(w http.ResponseWriter, r *http.Request) {
+	_, athleteID, ok := s.authorizeAthlete(w, r, models.CoachScopeMeasurements)
+	if !ok {
+		return
+	}
+
+	offset, limit := helpers.GetOffsetLimit(r, 10, 50)
+	result, err := s.container.FindAllMeasurementsUC.Execute(athleteID, limit, offset)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *serviceImpl) GetAthleteExerciseStats(w http.ResponseWriter, r *http.Request) {
+	_, athleteID, ok := s.authorizeAthlete(w, r, models.CoachScopeStats)
+	if !ok {
+		return
+	}
+	exerciseTypeID, err := helpers.ParseInt64Param("exercise_type_id", w, r)
+	if err != nil {
+		return
+	}
+
+	offset, limit := helpers.GetOffsetLimit(r, 10, 50)
+	result, err := s.container.ExerciseStatsUC.Execute(athleteID, exerciseTypeID, offset, limit)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *serviceImpl) GetAthletePrograms(w http.ResponseWriter, r *http.Request) {
+	_, athleteID, ok := s.authorizeAthlete(w, r, models.CoachScopePrograms)
+	if !ok {
+		return
+	}
+
+	result, err := s.container.FindAllProgramsByUserUC.Execute(athleteID)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result.Programs)
+}
+
+type createAthleteProgramRequest struct {
+	Name string `json:"name"`
+}
+
+func (s *serviceImpl) CreateAthleteProgram(w http.ResponseWriter, r *http.Request) {
+	claims, athleteID, ok := s.authorizeAthlete(w, r, models.CoachScopePrograms)
+	if !ok {
+		return
+	}
+
+	var body createAthleteProgramRequest
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	program, err := s.container.CreateAthleteProgramUC.Execute(claims.UserID, athleteID, body.Name)
+	if err != nil {
+		writeCoachingError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(program)
+}
+
+func (s *serviceImpl) AssignAthleteProgram(w http.ResponseWriter, r *http.Request) {
+	claims, athleteID, ok := s.authorizeAthlete(w, r, models.CoachScopePrograms)
+	if !ok {
+		return
+	}
+	programID, err := helpers.ParseInt64Param("program_id", w, r)
+	if err != nil {
+		return
+	}
+
+	if err = s.container.AssignAthleteProgramUC.Execute(claims.UserID, athleteID, programID); err != nil {
+		writeCoachingError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeCoachingError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, coachingusecases.InvalidScopesErr):
+		err = apierrors.InvalidField(err, "scopes", apierrors.FieldNotAllowed)
+	case errors.Is(err, coachingusecases.InvalidInviteErr):
+		err = apierrors.Wrap(err, http.StatusUnprocessableEntity, apierrors.CodeInvalidInvite)
+	case errors.Is(err, coachingusecases.SelfInviteErr):
+		err = apierrors.Wrap(err, http.StatusUnprocessableEntity, apierrors.CodeSelfAction)
+	case errors.Is(err, coachingusecases.NotCoachErr):
+		err = apierrors.Wrap(err, http.StatusForbidden, apierrors.CodeNotCoach)
+	case errors.Is(err, coachingusecases.NotLinkParticipantErr):
+		err = apierrors.Wrap(err, http.StatusForbidden, apierrors.CodeForbidden)
+	case errors.Is(err, coaching.NotFoundLinkErr):
+		err = apierrors.Wrap(err, http.StatusNotFound, apierrors.CodeNotFound)
+	case errors.Is(err, coaching.AlreadyLinkedErr):
+		err = apierrors.Wrap(err, http.StatusConflict, apierrors.CodeAlreadyLinked)
+	}
+	apierrors.WriteError(w, r, err)
+}
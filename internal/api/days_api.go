@@ -2,29 +2,25 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
-	"github.com/SaenkoDmitry/training-tg-bot/internal/api/validator"
-	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	daytypeusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/daytypes"
 )
 
-func (s *serviceImpl) CreateProgramDay(w http.ResponseWriter, r *http.Request) {
-	claims, ok := middlewares.FromContext(r.Context())
-	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
+type createProgramDayRequest struct {
+	Name string `json:"name"`
+}
 
+func (s *serviceImpl) CreateProgramDay(w http.ResponseWriter, r *http.Request) {
 	// Разбираем JSON из тела запроса
-	var input struct {
-		Name string `json:"name"`
-	}
+	var input createProgramDayRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+	if !decodeJSON(w, r, &input) {
 		return
 	}
 
@@ -33,20 +29,15 @@ func (s *serviceImpl) CreateProgramDay(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = validator.ValidateAccessToProgram(s.container, claims.UserID, programID); err != nil {
-		helpers.WriteError(w, err)
-		return
-	}
-
 	dayTypeID, err := s.container.DayTypesCreateUC.Execute(programID, input.Name)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
 	day, err := s.container.GetDayTypeUC.Execute(dayTypeID)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -55,40 +46,26 @@ func (s *serviceImpl) CreateProgramDay(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(day)
 }
 
-func (s *serviceImpl) UpdateProgramDay(w http.ResponseWriter, r *http.Request) {
-	claims, ok := middlewares.FromContext(r.Context())
-	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-
-	programID, err := helpers.ParseInt64Param("program_id", w, r)
-	if err != nil {
-		return
-	}
+type inputSet struct {
+	Reps    int     `json:"reps"`
+	Weight  float32 `json:"weight"`
+	Meters  int     `json:"meters"`
+	Minutes int     `json:"minutes"`
+}
 
-	if err = validator.ValidateAccessToProgram(s.container, claims.UserID, programID); err != nil {
-		helpers.WriteError(w, err)
-		return
-	}
+type updateProgramDayRequest struct {
+	ExerciseTypeID int64       `json:"exercise_type_id"`
+	Sets           []*inputSet `json:"sets"`
+}
 
+func (s *serviceImpl) UpdateProgramDay(w http.ResponseWriter, r *http.Request) {
 	dayTypeID, err := helpers.ParseInt64Param("day_type_id", w, r)
 	if err != nil {
 		return
 	}
 
-	type inputSet struct {
-		Reps    int     `json:"reps"`
-		Weight  float32 `json:"weight"`
-		Meters  int     `json:"meters"`
-		Minutes int     `json:"minutes"`
-	}
-
 	// Разбираем JSON из тела запроса
-	var input struct {
-		ExerciseTypeID int64       `json:"exercise_type_id"`
-		Sets           []*inputSet `json:"sets"`
-	}
+	var input updateProgramDayRequest
 
 	formatSets := func(sets []*inputSet) string {
 		arrayOfSets := make([]string, 0, len(sets))
@@ -105,14 +82,13 @@ func (s *serviceImpl) UpdateProgramDay(w http.ResponseWriter, r *http.Request) {
 		return strings.Join(arrayOfSets, ";")
 	}
 
-	if err = json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+	if !decodeJSON(w, r, &input) {
 		return
 	}
 
 	err = s.container.AddExPresetUC.Execute(dayTypeID, input.ExerciseTypeID, formatSets(input.Sets))
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -122,22 +98,6 @@ func (s *serviceImpl) UpdateProgramDay(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *serviceImpl) GetProgramDay(w http.ResponseWriter, r *http.Request) {
-	claims, ok := middlewares.FromContext(r.Context())
-	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-
-	programID, err := helpers.ParseInt64Param("program_id", w, r)
-	if err != nil {
-		return
-	}
-
-	if err = validator.ValidateAccessToProgram(s.container, claims.UserID, programID); err != nil {
-		helpers.WriteError(w, err)
-		return
-	}
-
 	dayTypeID, err := helpers.ParseInt64Param("day_type_id", w, r)
 	if err != nil {
 		return
@@ -145,7 +105,7 @@ func (s *serviceImpl) GetProgramDay(w http.ResponseWriter, r *http.Request) {
 
 	day, err := s.container.GetDayTypeUC.Execute(dayTypeID)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -155,30 +115,17 @@ func (s *serviceImpl) GetProgramDay(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *serviceImpl) DeleteProgramDay(w http.ResponseWriter, r *http.Request) {
-	claims, ok := middlewares.FromContext(r.Context())
-	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-
-	programID, err := helpers.ParseInt64Param("program_id", w, r)
-	if err != nil {
-		return
-	}
-
-	if err = validator.ValidateAccessToProgram(s.container, claims.UserID, programID); err != nil {
-		helpers.WriteError(w, err)
-		return
-	}
-
 	dayTypeID, err := helpers.ParseInt64Param("day_type_id", w, r)
 	if err != nil {
 		return
 	}
 
 	err = s.container.DeleteDayTypeUC.Execute(dayTypeID)
+	if errors.Is(err, daytypeusecases.CannotDeleteAlreadyUsedDay) {
+		err = apierrors.Wrap(err, http.StatusConflict, apierrors.CodeDayInUse)
+	}
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+)
+
+// validatable — тело запроса, которое после разбора проверяет значения своих полей
+// и возвращает apierrors.Fields.Err()
+type validatable interface {
+	Validate() error
+}
+
+// decodeJSON разбирает тело запроса в v и проверяет его. При ошибке ответ уже
+// записан: 400, если тело не разбирается, 422, если не прошла проверка полей
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			apierrors.WriteError(w, r, apierrors.InvalidBody(err, apierrors.FieldError{
+				Field: typeErr.Field, Code: apierrors.FieldInvalidType, Message: "must be " + typeErr.Type.String(),
+			}))
+			return false
+		}
+		apierrors.WriteError(w, r, apierrors.InvalidBody(err))
+		return false
+	}
+	if body, ok := v.(validatable); ok {
+		if err := body.Validate(); err != nil {
+			apierrors.WriteError(w, r, err)
+			return false
+		}
+	}
+	return true
+}
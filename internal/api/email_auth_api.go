@@ -0,0 +1,190 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	emailauthusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/emailauth"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+)
+
+type emailAuthRequest struct {
+	Email     string `json:"email"`
+	Password  string `json:"password"`
+	FirstName string `json:"first_name"`
+	Origin    string `json:"origin"` // куда вести ссылку из письма
+	Token     string `json:"token"`
+}
+
+func (s *serviceImpl) decodeEmailAuthRequest(w http.ResponseWriter, r *http.Request, needOrigin bool) (*emailAuthRequest, bool) {
+	var body emailAuthRequest
+	if !decodeJSON(w, r, &body) {
+		return nil, false
+	}
+	if needOrigin && !s.isAllowedOrigin(body.Origin) {
+		apierrors.Write(w, r, http.StatusForbidden, apierrors.CodeInvalidOrigin)
+		return nil, false
+	}
+	return &body, true
+}
+
+func (s *serviceImpl) RegisterByEmail(w http.ResponseWriter, r *http.Request) {
+	body, ok := s.decodeEmailAuthRequest(w, r, true)
+	if !ok {
+		return
+	}
+	if err := s.container.RegisterByEmailUC.Execute(body.Email, body.Password, body.FirstName, body.Origin); err != nil {
+		writeEmailAuthError(w, r, err)
+		return
+	}
+	writeAccepted(w)
+}
+
+func (s *serviceImpl) LoginByEmail(w http.ResponseWriter, r *http.Request) {
+	body, ok := s.decodeEmailAuthRequest(w, r, false)
+	if !ok {
+		return
+	}
+	userID, err := s.container.LoginByEmailUC.Execute(body.Email, body.Password, clientMeta(r).IP)
+	if err != nil {
+		writeEmailAuthError(w, r, err)
+		return
+	}
+	s.startAuthSession(w, r, userID)
+}
+
+func (s *serviceImpl) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	body, ok := s.decodeEmailAuthRequest(w, r, false)
+	if !ok {
+		return
+	}
+	// пароль сохранится, если его ввели на странице подтверждения или ссылку открыли из сессии этого аккаунта
+	var currentUserID int64
+	if claims, ok := middlewares.FromContext(r.Context()); ok {
+		currentUserID = claims.UserID
+	}
+	userID, err := s.container.VerifyEmailUC.Execute(body.Token, body.Password, currentUserID)
+	if err != nil {
+		writeEmailAuthError(w, r, err)
+		return
+	}
+	s.startAuthSession(w, r, userID)
+}
+
+func (s *serviceImpl) ResendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	body, ok := s.decodeEmailAuthRequest(w, r, true)
+	if !ok {
+		return
+	}
+	if err := s.container.SendEmailVerificationUC.Execute(body.Email, body.Origin); err != nil {
+		writeEmailAuthError(w, r, err)
+		return
+	}
+	writeAccepted(w)
+}
+
+func (s *serviceImpl) RequestMagicLink(w http.ResponseWriter, r *http.Request) {
+	body, ok := s.decodeEmailAuthRequest(w, r, true)
+	if !ok {
+		return
+	}
+	if err := s.container.RequestMagicLinkUC.Execute(body.Email, body.Origin); err != nil {
+		writeEmailAuthError(w, r, err)
+		return
+	}
+	writeAccepted(w)
+}
+
+func (s *serviceImpl) ConsumeMagicLink(w http.ResponseWriter, r *http.Request) {
+	body, ok := s.decodeEmailAuthRequest(w, r, false)
+	if !ok {
+		return
+	}
+	var currentUserID int64
+	if claims, ok := middlewares.FromContext(r.Context()); ok {
+		currentUserID = claims.UserID
+	}
+	userID, err := s.container.ConsumeMagicLinkUC.Execute(body.Token, currentUserID)
+	if err != nil {
+		writeEmailAuthError(w, r, err)
+		return
+	}
+	s.startAuthSession(w, r, userID)
+}
+
+func (s *serviceImpl) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	body, ok := s.decodeEmailAuthRequest(w, r, true)
+	if !ok {
+		return
+	}
+	if err := s.container.RequestPasswordResetUC.Execute(body.Email, body.Origin); err != nil {
+		writeEmailAuthError(w, r, err)
+		return
+	}
+	writeAccepted(w)
+}
+
+func (s *serviceImpl) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	body, ok := s.decodeEmailAuthRequest(w, r, false)
+	if !ok {
+		return
+	}
+	userID, err := s.container.ResetPasswordUC.Execute(body.Token, body.Password)
+	if err != nil {
+		writeEmailAuthError(w, r, err)
+		return
+	}
+	s.startAuthSession(w, r, userID)
+}
+
+func (s *serviceImpl) LinkEmail(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	body, ok := s.decodeEmailAuthRequest(w, r, true)
+	if !ok {
+		return
+	}
+	if err := s.container.LinkEmailUC.Execute(claims.UserID, body.Email, body.Password, body.Origin); err != nil {
+		writeEmailAuthError(w, r, err)
+		return
+	}
+	writeAccepted(w)
+}
+
+// OKDTO — ответ операций без данных: письмо поставлено в очередь, сессия закрыта
+type OKDTO struct {
+	OK bool `json:"ok"`
+}
+
+func writeAccepted(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(OKDTO{OK: true})
+}
+
+func writeEmailAuthError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, emailauthusecases.InvalidEmailErr):
+		err = apierrors.InvalidField(err, "email", apierrors.FieldInvalidFormat)
+	case errors.Is(err, emailauthusecases.WeakPasswordErr):
+		err = apierrors.InvalidField(err, "password", apierrors.FieldOutOfRange)
+	case errors.Is(err, emailauthusecases.InvalidTokenErr):
+		err = apierrors.Wrap(err, http.StatusUnprocessableEntity, apierrors.CodeInvalidEmailToken)
+	case errors.Is(err, emailauthusecases.InvalidCredentialsErr):
+		err = apierrors.Wrap(err, http.StatusUnauthorized, apierrors.CodeInvalidCredentials)
+	case errors.Is(err, emailauthusecases.EmailNotVerifiedErr):
+		err = apierrors.Wrap(err, http.StatusForbidden, apierrors.CodeEmailNotVerified)
+	case errors.Is(err, emailauthusecases.EmailTakenErr):
+		err = apierrors.Wrap(err, http.StatusConflict, apierrors.CodeEmailTaken)
+	case errors.Is(err, emailauthusecases.AlreadyHasEmailErr):
+		err = apierrors.Wrap(err, http.StatusConflict, apierrors.CodeEmailAlreadySet)
+	case errors.Is(err, emailauthusecases.TooManyAttemptsErr):
+		err = apierrors.Wrap(err, http.StatusTooManyRequests, apierrors.CodeTooManyAttempts)
+	}
+	apierrors.WriteError(w, r, err)
+}
@@ -4,28 +4,19 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/authz"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
-	"github.com/SaenkoDmitry/training-tg-bot/internal/api/validator"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/ownership"
 )
 
 func (s *serviceImpl) DeleteExercise(w http.ResponseWriter, r *http.Request) {
-	claims, ok := middlewares.FromContext(r.Context())
-	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-
 	exerciseID, err := helpers.ParseInt64Param("id", w, r)
 	if err != nil {
 		return
 	}
 
-	if err = validator.ValidateAccessToExercise(s.container, claims.UserID, exerciseID); err != nil {
-		helpers.WriteError(w, err)
-		return
-	}
-
 	_, err = s.container.DeleteExerciseUC.Execute(exerciseID)
 	if err != nil {
 		return
@@ -35,26 +26,34 @@ func (s *serviceImpl) DeleteExercise(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("{}"))
 }
 
+type addExerciseRequest struct {
+	WorkoutID      int64 `json:"workout_id"`
+	ExerciseTypeID int64 `json:"exercise_type_id"`
+}
+
+func (req *addExerciseRequest) Validate() error {
+	var fields apierrors.Fields
+	fields.Positive("workout_id", req.WorkoutID)
+	fields.Positive("exercise_type_id", req.ExerciseTypeID)
+	return fields.Err()
+}
+
 func (s *serviceImpl) AddExercise(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
 	// Разбираем JSON из тела запроса
-	var input struct {
-		WorkoutID      int64 `json:"workout_id"`
-		ExerciseTypeID int64 `json:"exercise_type_id"`
-	}
+	var input addExerciseRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+	if !decodeJSON(w, r, &input) {
 		return
 	}
 
-	if err := validator.ValidateAccessToWorkout(s.container, claims.UserID, input.WorkoutID); err != nil {
-		helpers.WriteError(w, err)
+	if err := s.authz.Check(claims.UserID, authz.Owner(ownership.Workout), input.WorkoutID); err != nil {
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -69,7 +68,7 @@ func (s *serviceImpl) AddExercise(w http.ResponseWriter, r *http.Request) {
 func (s *serviceImpl) GetExerciseStatsByUser(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
@@ -2,13 +2,14 @@ package api
 
 import (
 	"encoding/json"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
 	"net/http"
 )
 
-func (s *serviceImpl) GetExerciseGroups(w http.ResponseWriter, _ *http.Request) {
+func (s *serviceImpl) GetExerciseGroups(w http.ResponseWriter, r *http.Request) {
 	result, err := s.container.GetAllGroupsUC.Execute()
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -21,7 +22,7 @@ func (s *serviceImpl) GetExerciseTypesByGroup(w http.ResponseWriter, r *http.Req
 
 	result, err := s.container.FindTypesByGroupUC.Execute(groupCode)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
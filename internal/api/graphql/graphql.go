@@ -0,0 +1,181 @@
+// Package graphql — обвязка над github.com/graphql-go/graphql. Разбор, проверку и исполнение запроса
+// делает библиотека; здесь ограничение глубины, разделение ошибок запроса и резолверов, SDL и загрузчики пачками
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response — ответ по спецификации: при ошибке разбора или проверки data нет,
+// при ошибках резолверов data частичная, а ошибочные поля равны null
+type Response struct {
+	Data   any      `json:"data,omitempty"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// Error — ошибка в ответе. Err хранит исходную ошибку резолвера, чтобы слой API сам решил,
+// какой код и текст показать клиенту; у ошибок разбора и проверки запроса Err пустой
+type Error struct {
+	Message    string                    `json:"message"`
+	Locations  []location.SourceLocation `json:"locations,omitempty"`
+	Path       []any                     `json:"path,omitempty"`
+	Extensions map[string]any            `json:"extensions,omitempty"`
+	Err        error                     `json:"-"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Schema — схема только для чтения с ограничением глубины запроса
+type Schema struct {
+	schema   gql.Schema
+	maxDepth int
+}
+
+func NewSchema(query *gql.Object, maxDepth int) (*Schema, error) {
+	schema, err := gql.NewSchema(gql.SchemaConfig{Query: query})
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{schema: schema, maxDepth: maxDepth}, nil
+}
+
+// Execute разбирает, проверяет и выполняет запрос. Библиотека вычисляет Thunk резолверов по уровням,
+// поэтому загрузчики получают ключи всего уровня одной пачкой
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(req.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return &Response{Errors: queryErrors(gqlerrors.FormatErrors(err))}
+	}
+	// на цикле фрагментов правило о конфликтах полей библиотеки уходит в бесконечную рекурсию,
+	// поэтому циклы отклоняются до остальных проверок
+	for _, rules := range [][]gql.ValidationRuleFn{{gql.NoFragmentCyclesRule}, gql.SpecifiedRules} {
+		if result := gql.ValidateDocument(&s.schema, doc, rules); !result.IsValid {
+			return &Response{Errors: queryErrors(result.Errors)}
+		}
+	}
+	if err := checkDepth(doc, s.maxDepth); err != nil {
+		return &Response{Errors: []*Error{err}}
+	}
+
+	result := gql.Execute(gql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+	resp := &Response{Data: result.Data}
+	for _, e := range result.Errors {
+		resp.Errors = append(resp.Errors, &Error{
+			Message:   e.Message,
+			Locations: e.Locations,
+			Path:      e.Path,
+			Err:       resolverError(e),
+		})
+	}
+	return resp
+}
+
+func queryErrors(errs []gqlerrors.FormattedError) []*Error {
+	result := make([]*Error, len(errs))
+	for i, e := range errs {
+		result[i] = &Error{Message: e.Message, Locations: e.Locations}
+	}
+	return result
+}
+
+// resolverError достает ошибку резолвера из оберток библиотеки; nil — ошибка самого запроса,
+// например переменная не приводится к своему типу
+func resolverError(e gqlerrors.FormattedError) error {
+	var err error = e
+	for err != nil {
+		switch wrapped := err.(type) {
+		case gqlerrors.FormattedError:
+			err = wrapped.OriginalError()
+		case *gqlerrors.Error:
+			err = wrapped.OriginalError
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDepth ограничивает вложенность полей: библиотека ее не проверяет, а без ограничения
+// связи тренировка → упражнение → тренировка позволяют построить сколь угодно тяжелый запрос.
+// Служебные поля __schema и __type не учитываются
+func checkDepth(doc *ast.Document, maxDepth int) *Error {
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, def := range doc.Definitions {
+		if f, ok := def.(*ast.FragmentDefinition); ok {
+			fragments[f.Name.Value] = f
+		}
+	}
+
+	var depth func(set *ast.SelectionSet) int
+	depth = func(set *ast.SelectionSet) int {
+		if set == nil {
+			return 0
+		}
+		deepest := 0
+		for _, sel := range set.Selections {
+			var d int
+			switch sel := sel.(type) {
+			case *ast.Field:
+				if strings.HasPrefix(sel.Name.Value, "__") {
+					d = 1
+				} else {
+					d = 1 + depth(sel.SelectionSet)
+				}
+			case *ast.InlineFragment:
+				d = depth(sel.SelectionSet)
+			case *ast.FragmentSpread:
+				// циклы фрагментов уже отклонила проверка документа
+				if f, ok := fragments[sel.Name.Value]; ok {
+					d = depth(f.SelectionSet)
+				}
+			}
+			deepest = max(deepest, d)
+		}
+		return deepest
+	}
+
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if d := depth(op.SelectionSet); d > maxDepth {
+			loc := location.GetLocation(doc.Loc.Source, op.Loc.Start)
+			return &Error{
+				Message:   fmt.Sprintf("query depth %d exceeds the limit of %d", d, maxDepth),
+				Locations: []location.SourceLocation{loc},
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type author struct {
+	ID   int64
+	Name string
+}
+
+type book struct {
+	ID       int64
+	Title    string
+	AuthorID int64
+}
+
+type loadersKey struct{}
+
+type loaders struct {
+	authors *Loader[int64, *author]
+	calls   map[string]int
+}
+
+func newTestSchema(maxDepth int) *Schema {
+	books := []book{{1, "Война и мир", 1}, {2, "Каштанка", 2}, {3, "Анна Каренина", 1}}
+
+	authorType := gql.NewObject(gql.ObjectConfig{
+		Name:        "Author",
+		Description: "Автор",
+		Fields: gql.Fields{
+			"id": &gql.Field{Type: gql.ID, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*author).ID, nil
+			}},
+			"name": &gql.Field{Type: gql.String, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*author).Name, nil
+			}},
+		},
+	})
+	bookType := gql.NewObject(gql.ObjectConfig{
+		Name:        "Book",
+		Description: "Книга",
+		Fields: gql.Fields{
+			"id": &gql.Field{Type: gql.ID, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(book).ID, nil
+			}},
+			"title": &gql.Field{Type: gql.String, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(book).Title, nil
+			}},
+			"author": &gql.Field{Type: authorType, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Context.Value(loadersKey{}).(*loaders).authors.Load(p.Source.(book).AuthorID), nil
+			}},
+			"authorName": &gql.Field{Type: gql.String, Resolve: func(p gql.ResolveParams) (any, error) {
+				return Then(p.Context.Value(loadersKey{}).(*loaders).authors.Load(p.Source.(book).AuthorID), func(v any) (any, error) {
+					return v.(*author).Name, nil
+				}), nil
+			}},
+			"broken": &gql.Field{Type: gql.String, Resolve: func(p gql.ResolveParams) (any, error) {
+				return nil, errBoom
+			}},
+		},
+	})
+
+	query := gql.NewObject(gql.ObjectConfig{
+		Name: "Query",
+		Fields: gql.Fields{
+			"books": &gql.Field{
+				Type: gql.NewList(bookType),
+				Args: gql.FieldConfigArgument{"limit": {Type: gql.Int, DefaultValue: 10}},
+				Resolve: func(p gql.ResolveParams) (any, error) {
+					limit := min(p.Args["limit"].(int), len(books))
+					return books[:limit], nil
+				},
+			},
+			"book": &gql.Field{
+				Type: bookType,
+				Args: gql.FieldConfigArgument{"id": {Type: gql.NewNonNull(gql.ID)}},
+				Resolve: func(p gql.ResolveParams) (any, error) {
+					for _, b := range books {
+						if strconv.FormatInt(b.ID, 10) == p.Args["id"] {
+							return b, nil
+						}
+					}
+					return nil, nil
+				},
+			},
+		},
+	})
+	schema, err := NewSchema(query, maxDepth)
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+var errBoom = errors.New("boom")
+
+func execute(t *testing.T, s *Schema, query string, vars map[string]any) (string, *Response, *loaders) {
+	t.Helper()
+	authors := map[int64]*author{1: {ID: 1, Name: "Толстой"}, 2: {ID: 2, Name: "Чехов"}}
+	ld := &loaders{calls: map[string]int{}}
+	ld.authors = NewLoader(func(ids []int64) (map[int64]*author, error) {
+		ld.calls["authors"]++
+		out := map[int64]*author{}
+		for _, id := range ids {
+			if a, ok := authors[id]; ok {
+				out[id] = a
+			}
+		}
+		return out, nil
+	})
+	ctx := context.WithValue(context.Background(), loadersKey{}, ld)
+	resp := s.Execute(ctx, Request{Query: query, Variables: vars})
+	body, err := json.Marshal(resp.Data)
+	require.NoError(t, err)
+	return string(body), resp, ld
+}
+
+func TestExecuteBatchesLoadsPerLevel(t *testing.T) {
+	body, resp, ld := execute(t, newTestSchema(4), `{ books { title author { name } authorName } }`, nil)
+
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"books":[
+		{"title":"Война и мир","author":{"name":"Толстой"},"authorName":"Толстой"},
+		{"title":"Каштанка","author":{"name":"Чехов"},"authorName":"Чехов"},
+		{"title":"Анна Каренина","author":{"name":"Толстой"},"authorName":"Толстой"}
+	]}`, body)
+	assert.Equal(t, 1, ld.calls["authors"], "authors of all books must be loaded in one batch")
+}
+
+func TestExecuteAliasesFragmentsAndDirectives(t *testing.T) {
+	body, resp, _ := execute(t, newTestSchema(4), `
+		query One($id: ID!, $withTitle: Boolean = true) {
+			first: book(id: $id) { __typename title @include(if: $withTitle) id }
+			...Rest
+		}
+		fragment Rest on Query { books(limit: 1) { ... on Book { id } } }`,
+		map[string]any{"id": "2"})
+
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"first":{"__typename":"Book","title":"Каштанка","id":"2"},"books":[{"id":"1"}]}`, body)
+}
+
+func TestExecuteFieldErrorKeepsSiblings(t *testing.T) {
+	body, resp, _ := execute(t, newTestSchema(4), `{ book(id: 1) { title broken } }`, nil)
+
+	assert.JSONEq(t, `{"book":{"title":"Война и мир","broken":null}}`, body)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "boom", resp.Errors[0].Message)
+	assert.Equal(t, []any{"book", "broken"}, resp.Errors[0].Path)
+	assert.ErrorIs(t, resp.Errors[0], errBoom, "the resolver error must reach the API layer")
+}
+
+func TestValidationErrors(t *testing.T) {
+	cases := map[string]string{
+		"syntax":            `{ books { title }`,
+		"unknown field":     `{ books { isbn } }`,
+		"missing subfields": `{ books }`,
+		"leaf subfields":    `{ books { title { x } } }`,
+		"unknown argument":  `{ books(first: 1) { title } }`,
+		"required argument": `{ book { title } }`,
+		"wrong type":        `{ books(limit: "ten") { title } }`,
+		"conflict":          `{ books { x: title x: id } }`,
+		"too deep":          `{ book(id: 1) { author { name } } }`,
+		"too deep fragment": `{ ...A } fragment A on Query { book(id: 1) { author { name } } }`,
+		"mutation":          `mutation { books { title } }`,
+		"fragment cycle":    `{ ...A } fragment A on Query { ...B } fragment B on Query { ...A }`,
+		"fragment on type":  `{ ...A } fragment A on Book { title }`,
+		"undefined var":     `{ book(id: $id) { title } }`,
+		"unused var":        `query($x: Int) { books { title } }`,
+		"nullable var":      `query($id: ID) { book(id: $id) { title } }`,
+		"unknown directive": `{ books @defer { title } }`,
+	}
+	s := newTestSchema(2)
+	for name, query := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp := s.Execute(context.Background(), Request{Query: query})
+			assert.Nil(t, resp.Data)
+			require.NotEmpty(t, resp.Errors)
+			for _, e := range resp.Errors {
+				assert.Nil(t, e.Err, "query errors carry no resolver error")
+			}
+		})
+	}
+}
+
+func TestDepthIgnoresIntrospection(t *testing.T) {
+	_, resp, _ := execute(t, newTestSchema(2), `{ __schema { types { name fields { name type { name } } } } }`, nil)
+
+	assert.Empty(t, resp.Errors)
+}
+
+func TestVariablesFromJSON(t *testing.T) {
+	var vars map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{"limit": 2}`), &vars))
+
+	body, resp, _ := execute(t, newTestSchema(4), `query($limit: Int) { books(limit: $limit) { id } }`, vars)
+
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"books":[{"id":"1"},{"id":"2"}]}`, body)
+}
+
+func TestSDL(t *testing.T) {
+	sdl := newTestSchema(4).SDL()
+
+	assert.Contains(t, sdl, "type Query {\n  book(id: ID!): Book\n  books(limit: Int = 10): [Book]\n}")
+	assert.Contains(t, sdl, "\"Книга\"\ntype Book {\n  author: Author\n")
+	assert.Contains(t, sdl, "type Author {")
+}
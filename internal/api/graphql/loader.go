@@ -0,0 +1,102 @@
+package graphql
+
+// Thunk — отложенное значение поля. Резолвер возвращает его вместо значения, когда данные
+// догружаются пачкой: библиотека вычисляет Thunk после всех резолверов уровня. Это именно
+// func() (any, error), а не именованный тип: другие функции библиотека не распознает
+type Thunk = func() (any, error)
+
+// Then продолжает вычисление после загрузки. f должна вернуть значение: вложенный Thunk
+// библиотека не вычисляет, поэтому следующую пачку нужно грузить отдельным загрузчиком
+func Then(t Thunk, f func(v any) (any, error)) Thunk {
+	return func() (any, error) {
+		v, err := t()
+		if err != nil {
+			return nil, err
+		}
+		return f(v)
+	}
+}
+
+// Loader собирает ключи и загружает их одной пачкой при первом обращении к результату.
+// Загрузчик живёт один запрос и не потокобезопасен: библиотека исполняет запрос в одной горутине
+type Loader[K comparable, V any] struct {
+	fetch   func(keys []K) (map[K]V, error)
+	queue   []K
+	queued  map[K]bool // ключ когда-либо запрашивался
+	pending map[K]bool // ключ ждёт ближайшей пачки
+	results map[K]V
+	failed  map[K]error
+}
+
+// NewLoader создаёт загрузчик. Ключа, которого нет в ответе fetch, — нулевое значение V
+func NewLoader[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:   fetch,
+		queued:  map[K]bool{},
+		pending: map[K]bool{},
+		results: map[K]V{},
+		failed:  map[K]error{},
+	}
+}
+
+func (l *Loader[K, V]) Load(key K) Thunk {
+	l.enqueue(key)
+	return func() (any, error) {
+		return l.get(key)
+	}
+}
+
+// LoadMany загружает несколько ключей в той же пачке и возвращает []V в их порядке
+func (l *Loader[K, V]) LoadMany(keys []K) Thunk {
+	for _, key := range keys {
+		l.enqueue(key)
+	}
+	return func() (any, error) {
+		out := make([]V, 0, len(keys))
+		for _, key := range keys {
+			v, err := l.get(key)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	}
+}
+
+func (l *Loader[K, V]) enqueue(key K) {
+	if l.queued[key] {
+		return
+	}
+	l.queued[key] = true
+	l.pending[key] = true
+	l.queue = append(l.queue, key)
+}
+
+func (l *Loader[K, V]) get(key K) (V, error) {
+	if l.pending[key] {
+		l.dispatch()
+	}
+	if err, ok := l.failed[key]; ok {
+		var zero V
+		return zero, err
+	}
+	return l.results[key], nil
+}
+
+func (l *Loader[K, V]) dispatch() {
+	keys := l.queue
+	l.queue = nil
+	clear(l.pending)
+	if len(keys) == 0 {
+		return
+	}
+	found, err := l.fetch(keys)
+	for _, key := range keys {
+		if err != nil {
+			l.failed[key] = err
+			continue
+		}
+		l.results[key] = found[key]
+	}
+}
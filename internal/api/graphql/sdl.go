@@ -0,0 +1,85 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+)
+
+// SDL печатает схему на языке определения схем, чтобы клиенты могли сгенерировать типы.
+// Библиотека умеет только выполнять запросы, поэтому печать своя: поля и аргументы по алфавиту
+func (s *Schema) SDL() string {
+	query := s.schema.QueryType()
+
+	var b strings.Builder
+	b.WriteString("schema {\n  query: " + query.Name() + "\n}\n")
+
+	// остальные скаляры встроены в язык
+	b.WriteString("\n")
+	writeDescription(&b, "", gql.DateTime.Description())
+	b.WriteString("scalar " + gql.DateTime.Name() + "\n")
+
+	seen := map[*gql.Object]bool{query: true}
+	queue := []*gql.Object{query}
+	for len(queue) > 0 {
+		obj := queue[0]
+		queue = queue[1:]
+
+		b.WriteString("\n")
+		writeDescription(&b, "", obj.Description())
+		b.WriteString("type " + obj.Name() + " {\n")
+		fields := obj.Fields()
+		for _, name := range sortedNames(fields) {
+			f := fields[name]
+			writeDescription(&b, "  ", f.Description)
+			b.WriteString("  " + f.Name)
+			if len(f.Args) > 0 {
+				args := append([]*gql.Argument(nil), f.Args...)
+				sort.Slice(args, func(i, j int) bool { return args[i].Name() < args[j].Name() })
+				printed := make([]string, len(args))
+				for i, a := range args {
+					printed[i] = a.Name() + ": " + a.Type.String()
+					if a.DefaultValue != nil {
+						printed[i] += " = " + printValue(a.DefaultValue)
+					}
+				}
+				b.WriteString("(" + strings.Join(printed, ", ") + ")")
+			}
+			b.WriteString(": " + f.Type.String() + "\n")
+
+			if child, ok := gql.GetNamed(f.Type).(*gql.Object); ok && !seen[child] {
+				seen[child] = true
+				queue = append(queue, child)
+			}
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+func sortedNames(fields gql.FieldDefinitionMap) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// printValue печатает значение по умолчанию так, как оно выглядит в запросе
+func printValue(v any) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v)
+}
+
+func writeDescription(b *strings.Builder, indent, desc string) {
+	if desc == "" {
+		return
+	}
+	b.WriteString(indent + `"` + strings.ReplaceAll(desc, `"`, `\"`) + `"` + "\n")
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/graphql"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/validator"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+// graphqlInvalidQuery — код ошибок разбора и проверки запроса, их текст остается
+// английским сообщением библиотеки: он указывает на конкретное место в запросе
+const graphqlInvalidQuery = "invalid_query"
+
+type graphqlRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+func (r graphqlRequest) Validate() error {
+	var fields apierrors.Fields
+	fields.Required("query", r.Query)
+	return fields.Err()
+}
+
+func (s *serviceImpl) GraphQL(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	var req graphqlRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), graphContextKey{}, s.newGraphContext(claims.UserID))
+	resp := s.graphSchema.Execute(ctx, graphql.Request{
+		Query:         req.Query,
+		OperationName: req.OperationName,
+		Variables:     req.Variables,
+	})
+
+	lang := apierrors.Language(r)
+	for _, e := range resp.Errors {
+		if e.Err == nil {
+			e.Extensions = map[string]any{"code": graphqlInvalidQuery}
+			continue
+		}
+		// ошибки резолверов — те же, что у REST: код из apierrors, текст переведен
+		p := apierrors.From(e.Err)
+		if p.Status >= http.StatusInternalServerError {
+			slog.ErrorContext(r.Context(), "graphql resolver failed", "path", e.Path, "err", e.Err)
+		}
+		e.Message = apierrors.Message(lang, p.Code)
+		e.Extensions = map[string]any{"code": p.Code}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", lang)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *serviceImpl) GetGraphQLSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte(s.graphSchema.SDL()))
+}
+
+type graphContextKey struct{}
+
+// graphContext живет один запрос: загрузчики копят идентификаторы всего уровня
+// запроса, а проверки доступа к данным спортсмена не повторяются для каждого поля
+type graphContext struct {
+	viewerID int64
+	access   map[athleteScope]error
+
+	workouts        *graphql.Loader[int64, *models.WorkoutDay]
+	exercises       *graphql.Loader[int64, []models.Exercise]
+	sets            *graphql.Loader[int64, []models.Set]
+	exerciseTypes   *graphql.Loader[int64, *models.ExerciseType]
+	programs        *graphql.Loader[int64, *models.WorkoutProgram]
+	dayTypes        *graphql.Loader[int64, *models.WorkoutDayType]
+	programDayTypes *graphql.Loader[int64, []models.WorkoutDayType]
+	stats           *graphql.Loader[int64, *workoutStats]
+}
+
+type athleteScope struct {
+	athleteID int64
+	scope     string
+}
+
+func (s *serviceImpl) newGraphContext(viewerID int64) *graphContext {
+	c := s.container
+	gc := &graphContext{
+		viewerID:        viewerID,
+		access:          map[athleteScope]error{},
+		workouts:        graphql.NewLoader(c.GraphFindWorkoutsUC.Execute),
+		exercises:       graphql.NewLoader(c.GraphFindExercisesUC.Execute),
+		sets:            graphql.NewLoader(c.GraphFindSetsUC.Execute),
+		exerciseTypes:   graphql.NewLoader(c.GraphFindExerciseTypesUC.Execute),
+		programs:        graphql.NewLoader(c.GraphFindProgramsUC.Execute),
+		dayTypes:        graphql.NewLoader(c.GraphFindDayTypesUC.Execute),
+		programDayTypes: graphql.NewLoader(c.GraphFindProgramDayTypesUC.Execute),
+	}
+	gc.stats = graphql.NewLoader(gc.loadStats)
+	return gc
+}
+
+// loadStats считает итоги тренировок уровня: библиотека не вычисляет Thunk, вернувшийся из Thunk,
+// поэтому упражнения и их подходы догружаются внутри одной пачки — по запросу на каждый загрузчик
+func (gc *graphContext) loadStats(workoutIDs []int64) (map[int64]*workoutStats, error) {
+	v, err := gc.exercises.LoadMany(workoutIDs)()
+	if err != nil {
+		return nil, err
+	}
+	exercisesByWorkout := v.([][]models.Exercise)
+	var exerciseIDs []int64
+	for _, exercises := range exercisesByWorkout {
+		for i := range exercises {
+			exerciseIDs = append(exerciseIDs, exercises[i].ID)
+		}
+	}
+	v, err = gc.sets.LoadMany(exerciseIDs)()
+	if err != nil {
+		return nil, err
+	}
+	setsByExercise := v.([][]models.Set)
+
+	result := make(map[int64]*workoutStats, len(workoutIDs))
+	for i, workoutID := range workoutIDs {
+		n := len(exercisesByWorkout[i])
+		result[workoutID] = newWorkoutStats(setsByExercise[:n])
+		setsByExercise = setsByExercise[n:]
+	}
+	return result, nil
+}
+
+func graphContextFrom(ctx context.Context) *graphContext {
+	return ctx.Value(graphContextKey{}).(*graphContext)
+}
+
+// canSee — правило доступа к данным спортсмена, общее с REST: сам спортсмен
+// или тренер с областью доступа scope
+func (s *serviceImpl) canSee(gc *graphContext, athleteID int64, scope string) error {
+	key := athleteScope{athleteID: athleteID, scope: scope}
+	if err, done := gc.access[key]; done {
+		return err
+	}
+	err := validator.ValidateAccessToAthlete(s.container, gc.viewerID, athleteID, scope)
+	gc.access[key] = err
+	return err
+}
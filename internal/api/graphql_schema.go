@@ -0,0 +1,521 @@
+package api
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	gql "github.com/graphql-go/graphql"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/authz"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/errorslist"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/graphql"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/ownership"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+// размеры страниц вложенных списков GraphQL
+const (
+	graphDefaultLimit = 20
+	graphMaxLimit     = 100
+	graphMaxDepth     = 10
+)
+
+// newGraphSchema описывает схему GraphQL только для чтения. Связи догружаются
+// загрузчиками graphContext, а поля с данными спортсмена проверяют доступ так же,
+// как REST: корневые сущности — правилами authz, вложенные списки — областями тренера
+func newGraphSchema(s *serviceImpl) (*graphql.Schema, error) {
+	var userType, programType, dayTypeType, workoutType, exerciseType *gql.Object
+
+	pageArgs := func() gql.FieldConfigArgument {
+		return gql.FieldConfigArgument{
+			"limit":  {Type: gql.Int, DefaultValue: graphDefaultLimit},
+			"offset": {Type: gql.Int, DefaultValue: 0},
+		}
+	}
+	idArg := func() gql.FieldConfigArgument {
+		return gql.FieldConfigArgument{"id": {Type: gql.NewNonNull(gql.ID)}}
+	}
+
+	exerciseTypeType := gql.NewObject(gql.ObjectConfig{
+		Name:        "ExerciseType",
+		Description: "Упражнение из библиотеки",
+		Fields: gql.Fields{
+			"id": &gql.Field{Type: gql.ID, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*models.ExerciseType).ID, nil
+			}},
+			"name": &gql.Field{Type: gql.String, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*models.ExerciseType).Name, nil
+			}},
+			"group": &gql.Field{Type: gql.String, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*models.ExerciseType).ExerciseGroupTypeCode, nil
+			}},
+			"units": &gql.Field{Type: gql.String, Description: "Единицы через запятую: reps, weight, minutes, meters", Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*models.ExerciseType).Units, nil
+			}},
+			"restSeconds": &gql.Field{Type: gql.Int, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*models.ExerciseType).RestInSeconds, nil
+			}},
+			"url": &gql.Field{Type: gql.String, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*models.ExerciseType).Url, nil
+			}},
+			"description": &gql.Field{Type: gql.String, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*models.ExerciseType).Description, nil
+			}},
+		},
+	})
+
+	setType := gql.NewObject(gql.ObjectConfig{
+		Name:        "Set",
+		Description: "Подход; значения фактические, если введены, иначе плановые",
+		Fields: gql.Fields{
+			"id": &gql.Field{Type: gql.ID, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*models.Set).ID, nil
+			}},
+			"index": &gql.Field{Type: gql.Int, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*models.Set).Index, nil
+			}},
+			"reps": &gql.Field{Type: gql.Int, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*models.Set).GetRealReps(), nil
+			}},
+			"weightKg": &gql.Field{Type: gql.Float, Resolve: func(p gql.ResolveParams) (any, error) {
+				return graphFloat(p.Source.(*models.Set).GetRealWeight()), nil
+			}},
+			"minutes": &gql.Field{Type: gql.Int, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*models.Set).GetRealMinutes(), nil
+			}},
+			"meters": &gql.Field{Type: gql.Int, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*models.Set).GetRealMeters(), nil
+			}},
+			"completed": &gql.Field{Type: gql.Boolean, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*models.Set).Completed, nil
+			}},
+			"completedAt": &gql.Field{Type: gql.DateTime, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*models.Set).CompletedAt, nil
+			}},
+		},
+	})
+
+	workoutStatsType := gql.NewObject(gql.ObjectConfig{
+		Name:        "WorkoutStats",
+		Description: "Итоги тренировки по выполненным подходам",
+		Fields: gql.Fields{
+			"tonnageKg": &gql.Field{Type: gql.Float, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*workoutStats).TonnageKg, nil
+			}},
+			"completedSets": &gql.Field{Type: gql.Int, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*workoutStats).CompletedSets, nil
+			}},
+			"totalSets": &gql.Field{Type: gql.Int, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*workoutStats).TotalSets, nil
+			}},
+			"minutes": &gql.Field{Type: gql.Int, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*workoutStats).Minutes, nil
+			}},
+			"meters": &gql.Field{Type: gql.Int, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*workoutStats).Meters, nil
+			}},
+		},
+	})
+
+	// в базе окружности хранятся в миллиметрах, вес — в граммах
+	cm := func(get func(m *models.Measurement) int) *gql.Field {
+		return &gql.Field{Type: gql.Float, Resolve: func(p gql.ResolveParams) (any, error) {
+			return float64(get(p.Source.(*models.Measurement))) / 10, nil
+		}}
+	}
+	measurementType := gql.NewObject(gql.ObjectConfig{
+		Name:        "Measurement",
+		Description: "Измерения тела",
+		Fields: gql.Fields{
+			"id": &gql.Field{Type: gql.ID, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*models.Measurement).ID, nil
+			}},
+			"measuredAt": &gql.Field{Type: gql.DateTime, Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(*models.Measurement).CreatedAt, nil
+			}},
+			"weightKg": &gql.Field{Type: gql.Float, Resolve: func(p gql.ResolveParams) (any, error) {
+				return float64(p.Source.(*models.Measurement).Weight) / 1000, nil
+			}},
+			"shouldersCm": cm(func(m *models.Measurement) int { return m.Shoulders }),
+			"chestCm":     cm(func(m *models.Measurement) int { return m.Chest }),
+			"handLeftCm":  cm(func(m *models.Measurement) int { return m.HandLeft }),
+			"handRightCm": cm(func(m *models.Measurement) int { return m.HandRight }),
+			"waistCm":     cm(func(m *models.Measurement) int { return m.Waist }),
+			"buttocksCm":  cm(func(m *models.Measurement) int { return m.Buttocks }),
+			"hipLeftCm":   cm(func(m *models.Measurement) int { return m.HipLeft }),
+			"hipRightCm":  cm(func(m *models.Measurement) int { return m.HipRight }),
+			"calfLeftCm":  cm(func(m *models.Measurement) int { return m.CalfLeft }),
+			"calfRightCm": cm(func(m *models.Measurement) int { return m.CalfRight }),
+		},
+	})
+
+	// типы ниже ссылаются друг на друга, поэтому поля описаны отложенно
+	userType = gql.NewObject(gql.ObjectConfig{
+		Name:        "User",
+		Description: "Пользователь",
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			return gql.Fields{
+				"id": &gql.Field{Type: gql.ID, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.User).ID, nil
+				}},
+				"firstName": &gql.Field{Type: gql.String, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.User).FirstName, nil
+				}},
+				"lastName": &gql.Field{Type: gql.String, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.User).LastName, nil
+				}},
+				"username": &gql.Field{Type: gql.String, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.User).Username, nil
+				}},
+				"activeProgram": &gql.Field{Type: programType, Resolve: func(p gql.ResolveParams) (any, error) {
+					user := p.Source.(*models.User)
+					gc := graphContextFrom(p.Context)
+					if err := s.canSee(gc, user.ID, models.CoachScopePrograms); err != nil {
+						return nil, err
+					}
+					if user.ActiveProgramID == nil {
+						return nil, nil
+					}
+					return gc.programs.Load(*user.ActiveProgramID), nil
+				}},
+				"programs": &gql.Field{Type: gql.NewList(programType), Resolve: func(p gql.ResolveParams) (any, error) {
+					user := p.Source.(*models.User)
+					if err := s.canSee(graphContextFrom(p.Context), user.ID, models.CoachScopePrograms); err != nil {
+						return nil, err
+					}
+					return pointers(s.container.GraphFindUserProgramsUC.Execute(user.ID))
+				}},
+				"workouts": &gql.Field{
+					Type:        gql.NewList(workoutType),
+					Description: "Тренировки, новые первыми",
+					Args:        pageArgs(),
+					Resolve: func(p gql.ResolveParams) (any, error) {
+						user := p.Source.(*models.User)
+						if err := s.canSee(graphContextFrom(p.Context), user.ID, models.CoachScopeWorkouts); err != nil {
+							return nil, err
+						}
+						offset, limit := graphPage(p.Args)
+						return pointers(s.container.GraphFindUserWorkoutsUC.Execute(user.ID, offset, limit))
+					},
+				},
+				"measurements": &gql.Field{
+					Type:        gql.NewList(measurementType),
+					Description: "Измерения, новые первыми",
+					Args:        pageArgs(),
+					Resolve: func(p gql.ResolveParams) (any, error) {
+						user := p.Source.(*models.User)
+						if err := s.canSee(graphContextFrom(p.Context), user.ID, models.CoachScopeMeasurements); err != nil {
+							return nil, err
+						}
+						offset, limit := graphPage(p.Args)
+						return pointers(s.container.GraphFindUserMeasurementsUC.Execute(user.ID, offset, limit))
+					},
+				},
+				"exerciseHistory": &gql.Field{
+					Type:        gql.NewList(exerciseType),
+					Description: "Все выполнения упражнения, новые первыми",
+					Args: func() gql.FieldConfigArgument {
+						args := pageArgs()
+						args["exerciseTypeId"] = &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)}
+						return args
+					}(),
+					Resolve: func(p gql.ResolveParams) (any, error) {
+						user := p.Source.(*models.User)
+						if err := s.canSee(graphContextFrom(p.Context), user.ID, models.CoachScopeStats); err != nil {
+							return nil, err
+						}
+						exerciseTypeID, err := graphID(p.Args, "exerciseTypeId")
+						if err != nil {
+							return nil, err
+						}
+						offset, limit := graphPage(p.Args)
+						return pointers(s.container.GraphFindExerciseHistoryUC.Execute(user.ID, exerciseTypeID, offset, limit))
+					},
+				},
+			}
+		}),
+	})
+
+	programType = gql.NewObject(gql.ObjectConfig{
+		Name:        "Program",
+		Description: "Программа тренировок",
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			return gql.Fields{
+				"id": &gql.Field{Type: gql.ID, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.WorkoutProgram).ID, nil
+				}},
+				"name": &gql.Field{Type: gql.String, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.WorkoutProgram).Name, nil
+				}},
+				"createdAt": &gql.Field{Type: gql.DateTime, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.WorkoutProgram).CreatedAt, nil
+				}},
+				"dayTypes": &gql.Field{Type: gql.NewList(dayTypeType), Resolve: func(p gql.ResolveParams) (any, error) {
+					return graphql.Then(graphContextFrom(p.Context).programDayTypes.Load(p.Source.(*models.WorkoutProgram).ID), func(v any) (any, error) {
+						return pointers(v.([]models.WorkoutDayType), nil)
+					}), nil
+				}},
+			}
+		}),
+	})
+
+	dayTypeType = gql.NewObject(gql.ObjectConfig{
+		Name:        "DayType",
+		Description: "День программы",
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			return gql.Fields{
+				"id": &gql.Field{Type: gql.ID, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.WorkoutDayType).ID, nil
+				}},
+				"name": &gql.Field{Type: gql.String, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.WorkoutDayType).Name, nil
+				}},
+				"preset": &gql.Field{Type: gql.String, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.WorkoutDayType).Preset, nil
+				}},
+				"program": &gql.Field{Type: programType, Resolve: func(p gql.ResolveParams) (any, error) {
+					// день виден вместе с тренировкой, а программа целиком — только с доступом к программам
+					gc := graphContextFrom(p.Context)
+					return graphql.Then(gc.programs.Load(p.Source.(*models.WorkoutDayType).WorkoutProgramID), func(v any) (any, error) {
+						program := v.(*models.WorkoutProgram)
+						if program == nil {
+							return nil, nil
+						}
+						if err := s.canSee(gc, program.UserID, models.CoachScopePrograms); err != nil {
+							return nil, err
+						}
+						return program, nil
+					}), nil
+				}},
+			}
+		}),
+	})
+
+	workoutType = gql.NewObject(gql.ObjectConfig{
+		Name:        "Workout",
+		Description: "Тренировка",
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			return gql.Fields{
+				"id": &gql.Field{Type: gql.ID, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.WorkoutDay).ID, nil
+				}},
+				"status": &gql.Field{Type: gql.String, Description: "active, editing или completed", Resolve: func(p gql.ResolveParams) (any, error) {
+					w := p.Source.(*models.WorkoutDay)
+					switch {
+					case w.Completed:
+						return workouts.StatusCompleted, nil
+					case w.InEditMode():
+						return workouts.StatusEditing, nil
+					default:
+						return workouts.StatusActive, nil
+					}
+				}},
+				"startedAt": &gql.Field{Type: gql.DateTime, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.WorkoutDay).StartedAt, nil
+				}},
+				"endedAt": &gql.Field{Type: gql.DateTime, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.WorkoutDay).EndedAt, nil
+				}},
+				"completed": &gql.Field{Type: gql.Boolean, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.WorkoutDay).Completed, nil
+				}},
+				"autoFinished": &gql.Field{Type: gql.Boolean, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.WorkoutDay).AutoFinished, nil
+				}},
+				"durationSeconds": &gql.Field{Type: gql.Int, Resolve: func(p gql.ResolveParams) (any, error) {
+					w := p.Source.(*models.WorkoutDay)
+					if w.EndedAt == nil {
+						return nil, nil
+					}
+					return int64(w.EndedAt.Sub(w.StartedAt) / time.Second), nil
+				}},
+				"dayType": &gql.Field{Type: dayTypeType, Resolve: func(p gql.ResolveParams) (any, error) {
+					return graphContextFrom(p.Context).dayTypes.Load(p.Source.(*models.WorkoutDay).WorkoutDayTypeID), nil
+				}},
+				"exercises": &gql.Field{Type: gql.NewList(exerciseType), Resolve: func(p gql.ResolveParams) (any, error) {
+					return graphql.Then(graphContextFrom(p.Context).exercises.Load(p.Source.(*models.WorkoutDay).ID), func(v any) (any, error) {
+						return pointers(v.([]models.Exercise), nil)
+					}), nil
+				}},
+				"stats": &gql.Field{Type: workoutStatsType, Resolve: func(p gql.ResolveParams) (any, error) {
+					return graphContextFrom(p.Context).stats.Load(p.Source.(*models.WorkoutDay).ID), nil
+				}},
+			}
+		}),
+	})
+
+	exerciseType = gql.NewObject(gql.ObjectConfig{
+		Name:        "Exercise",
+		Description: "Упражнение тренировки",
+		Fields: gql.FieldsThunk(func() gql.Fields {
+			return gql.Fields{
+				"id": &gql.Field{Type: gql.ID, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.Exercise).ID, nil
+				}},
+				"index": &gql.Field{Type: gql.Int, Resolve: func(p gql.ResolveParams) (any, error) {
+					return p.Source.(*models.Exercise).Index, nil
+				}},
+				"exerciseType": &gql.Field{Type: exerciseTypeType, Resolve: func(p gql.ResolveParams) (any, error) {
+					return graphContextFrom(p.Context).exerciseTypes.Load(p.Source.(*models.Exercise).ExerciseTypeID), nil
+				}},
+				"sets": &gql.Field{Type: gql.NewList(setType), Resolve: func(p gql.ResolveParams) (any, error) {
+					return graphql.Then(graphContextFrom(p.Context).sets.Load(p.Source.(*models.Exercise).ID), func(v any) (any, error) {
+						return pointers(v.([]models.Set), nil)
+					}), nil
+				}},
+				"workout": &gql.Field{Type: workoutType, Resolve: func(p gql.ResolveParams) (any, error) {
+					// упражнение из истории видно с доступом к статистике, тренировка — только к тренировкам
+					gc := graphContextFrom(p.Context)
+					return graphql.Then(gc.workouts.Load(p.Source.(*models.Exercise).WorkoutDayID), func(v any) (any, error) {
+						workout := v.(*models.WorkoutDay)
+						if workout == nil {
+							return nil, nil
+						}
+						if err := s.canSee(gc, workout.UserID, models.CoachScopeWorkouts); err != nil {
+							return nil, err
+						}
+						return workout, nil
+					}), nil
+				}},
+			}
+		}),
+	})
+
+	query := gql.NewObject(gql.ObjectConfig{
+		Name: "Query",
+		Fields: gql.Fields{
+			"me": &gql.Field{Type: userType, Resolve: func(p gql.ResolveParams) (any, error) {
+				return s.container.GetUserByIDUC.Execute(graphContextFrom(p.Context).viewerID)
+			}},
+			"user": &gql.Field{
+				Type:        userType,
+				Description: "Сам пользователь или спортсмен тренера; списки спортсмена доступны по областям связи",
+				Args:        idArg(),
+				Resolve: func(p gql.ResolveParams) (any, error) {
+					gc := graphContextFrom(p.Context)
+					userID, err := graphID(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					if err := s.canSeeAny(gc, userID); err != nil {
+						return nil, err
+					}
+					return s.container.GetUserByIDUC.Execute(userID)
+				},
+			},
+			"workout": &gql.Field{
+				Type: workoutType,
+				Args: idArg(),
+				Resolve: func(p gql.ResolveParams) (any, error) {
+					gc := graphContextFrom(p.Context)
+					workoutID, err := graphID(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					if err := s.authz.Check(gc.viewerID, authz.OwnerOrCoach(ownership.Workout, models.CoachScopeWorkouts), workoutID); err != nil {
+						return nil, err
+					}
+					return gc.workouts.Load(workoutID), nil
+				},
+			},
+			"program": &gql.Field{
+				Type: programType,
+				Args: idArg(),
+				Resolve: func(p gql.ResolveParams) (any, error) {
+					gc := graphContextFrom(p.Context)
+					programID, err := graphID(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					if err := s.authz.Check(gc.viewerID, authz.OwnerOrCoach(ownership.Program, models.CoachScopePrograms), programID); err != nil {
+						return nil, err
+					}
+					return gc.programs.Load(programID), nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(query, graphMaxDepth)
+}
+
+// canSeeAny пускает к профилю спортсмена тренера с любой областью доступа
+func (s *serviceImpl) canSeeAny(gc *graphContext, athleteID int64) error {
+	for _, scope := range models.AllCoachScopes {
+		err := s.canSee(gc, athleteID, scope)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, errorslist.ErrAccessDenied) {
+			return err
+		}
+	}
+	return errorslist.ErrAccessDenied
+}
+
+type workoutStats struct {
+	TonnageKg     float64
+	CompletedSets int
+	TotalSets     int
+	Minutes       int
+	Meters        int
+}
+
+func newWorkoutStats(setsByExercise [][]models.Set) *workoutStats {
+	stats := &workoutStats{}
+	for _, sets := range setsByExercise {
+		for i := range sets {
+			set := &sets[i]
+			stats.TotalSets++
+			if !set.Completed {
+				continue
+			}
+			stats.CompletedSets++
+			stats.TonnageKg += float64(set.GetRealWeight()) * float64(set.GetRealReps())
+			stats.Minutes += set.GetRealMinutes()
+			stats.Meters += set.GetRealMeters()
+		}
+	}
+	return stats
+}
+
+// graphID — библиотека отдает ID строкой, а сущности адресуются числом
+func graphID(args map[string]any, name string) (int64, error) {
+	raw, _ := args[name].(string)
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0, apierrors.InvalidParameter(name, apierrors.FieldInvalidFormat, "must be a numeric id")
+	}
+	return id, nil
+}
+
+// graphFloat — float32 → float64 дает хвосты вроде 72.30000305, отдаем число так, как оно записано
+func graphFloat(f float32) float64 {
+	v, _ := strconv.ParseFloat(strconv.FormatFloat(float64(f), 'g', -1, 32), 64)
+	return v
+}
+
+// graphPage — явный null в аргументе равен значению по умолчанию
+func graphPage(args map[string]any) (offset, limit int) {
+	offset, _ = args["offset"].(int)
+	limit, ok := args["limit"].(int)
+	if !ok {
+		limit = graphDefaultLimit
+	}
+	return max(offset, 0), min(max(limit, 1), graphMaxLimit)
+}
+
+// pointers отдает элементы выборки указателями: резолверы полей ждут *models.X
+func pointers[T any](items []T, err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*T, len(items))
+	for i := range items {
+		result[i] = &items[i]
+	}
+	return result, nil
+}
@@ -0,0 +1,29 @@
+package helpers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+)
+
+// GetIfMatchVersion возвращает версию из заголовка If-Match, 0 — если заголовок не передан
+func GetIfMatchVersion(w http.ResponseWriter, r *http.Request) (int64, error) {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return 0, nil
+	}
+	ifMatch = strings.TrimPrefix(ifMatch, "W/")
+	version, err := strconv.ParseInt(strings.Trim(ifMatch, `"`), 10, 64)
+	if err != nil || version <= 0 {
+		problem := apierrors.InvalidParameter("If-Match", apierrors.FieldInvalidFormat, "must be a quoted positive version")
+		apierrors.WriteError(w, r, problem)
+		return 0, problem
+	}
+	return version, nil
+}
+
+func SetETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", `"`+strconv.FormatInt(version, 10)+`"`)
+}
@@ -3,6 +3,8 @@ package helpers
 import (
 	"net/http"
 	"strconv"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
 )
 
 func GetOffsetLimit(r *http.Request, defaultLimit, maxLimit int) (int, int) {
@@ -28,7 +30,7 @@ func ParseInt64Param(name string, w http.ResponseWriter, r *http.Request) (int64
 	entityIDStr := r.PathValue(name)
 	entityID, err := strconv.ParseInt(entityIDStr, 10, 64)
 	if err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+		apierrors.WriteError(w, r, apierrors.InvalidParameter(name, apierrors.FieldInvalidType, "must be an integer"))
 		return 0, err
 	}
 	return entityID, nil
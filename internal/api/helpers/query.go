@@ -0,0 +1,57 @@
+package helpers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+)
+
+// ParsePage разбирает offset и limit; отсутствующий limit равен defaultLimit, больше maxLimit — ошибка.
+// Строгие парсеры, в отличие от GetOffsetLimit, не подменяют некорректное значение значением
+// по умолчанию, а возвращают apierrors.InvalidParameter
+func ParsePage(r *http.Request, defaultLimit, maxLimit int) (offset, limit int, err error) {
+	q := r.URL.Query()
+
+	offset, limit = 0, defaultLimit
+	if raw := q.Get("offset"); raw != "" {
+		if offset, err = strconv.Atoi(raw); err != nil || offset < 0 {
+			return 0, 0, apierrors.InvalidParameter("offset", apierrors.FieldOutOfRange, "must be a non-negative integer")
+		}
+	}
+	if raw := q.Get("limit"); raw != "" {
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 1 || limit > maxLimit {
+			return 0, 0, apierrors.InvalidParameter("limit", apierrors.FieldOutOfRange, "must be an integer from 1 to "+strconv.Itoa(maxLimit))
+		}
+	}
+	return offset, limit, nil
+}
+
+// ParseTimeQuery принимает RFC 3339 или дату YYYY-MM-DD (начало дня по UTC); пустой параметр — nil
+func ParseTimeQuery(r *http.Request, name string) (*time.Time, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, raw); err == nil {
+		return &t, nil
+	}
+	return nil, apierrors.InvalidParameter(name, apierrors.FieldInvalidFormat, "must be an RFC 3339 timestamp or YYYY-MM-DD date")
+}
+
+// ParseInt64Query разбирает необязательный числовой параметр; пустой параметр — 0
+func ParseInt64Query(r *http.Request, name string) (int64, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || v <= 0 {
+		return 0, apierrors.InvalidParameter(name, apierrors.FieldInvalidFormat, "must be a positive integer")
+	}
+	return v, nil
+}
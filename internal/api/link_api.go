@@ -0,0 +1,114 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	userusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/go-chi/chi/v5"
+)
+
+func (s *serviceImpl) LinkTelegram(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	var tgUser dto.TelegramUser
+	if !decodeJSON(w, r, &tgUser) {
+		return
+	}
+
+	if !verifyTelegram(tgUser, botToken, time.Now()) {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeInvalidTelegramAuth)
+		return
+	}
+
+	result, err := s.container.LinkTelegramUC.Execute(claims.UserID, tgUser)
+	if err != nil {
+		writeLinkError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+type linkYandexRequest struct {
+	Code string `json:"code"`
+}
+
+func (req *linkYandexRequest) Validate() error {
+	var fields apierrors.Fields
+	fields.Required("code", req.Code)
+	return fields.Err()
+}
+
+func (s *serviceImpl) LinkYandex(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	var body linkYandexRequest
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	profile, err := s.yandexProfile(r, body.Code)
+	if err != nil {
+		apierrors.WriteError(w, r, apierrors.Wrap(err, http.StatusBadGateway, apierrors.CodeUpstream))
+		return
+	}
+
+	result, err := s.container.LinkYandexUC.Execute(claims.UserID, profile)
+	if err != nil {
+		writeLinkError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *serviceImpl) UnlinkProvider(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	providers, err := s.container.UnlinkUC.Execute(claims.UserID, chi.URLParam(r, "provider"))
+	if err != nil {
+		writeLinkError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&ProvidersDTO{Providers: providers})
+}
+
+type ProvidersDTO struct {
+	Providers []string `json:"providers"`
+}
+
+func writeLinkError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, userusecases.UnknownProviderErr):
+		err = apierrors.Wrap(err, http.StatusNotFound, apierrors.CodeUnknownProvider)
+	case errors.Is(err, userusecases.ProviderAlreadyLinkedErr):
+		err = apierrors.Wrap(err, http.StatusConflict, apierrors.CodeProviderAlreadyLinked)
+	case errors.Is(err, userusecases.AccountsConflictErr):
+		err = apierrors.Wrap(err, http.StatusConflict, apierrors.CodeAccountsConflict)
+	case errors.Is(err, userusecases.LastProviderErr):
+		err = apierrors.Wrap(err, http.StatusConflict, apierrors.CodeLastProvider)
+	}
+	apierrors.WriteError(w, r, err)
+}
@@ -1,8 +1,39 @@
 package api
 
-import "net/http"
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/authtokens"
+)
+
+// LogoutHandler не требует валидного access токена: выйти можно и с истекшим,
+// сессия тогда находится по refresh токену из тела
+type logoutHandlerRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (s *serviceImpl) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	var body logoutHandlerRequest
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	var sessionID, jti string
+	if tokenString := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "); tokenString != "" {
+		if claims, err := authtokens.Parse(tokenString); err == nil {
+			sessionID, _ = claims["sid"].(string)
+			jti, _ = claims["jti"].(string)
+		}
+	}
+
+	if err := s.container.LogoutUC.Execute(body.RefreshToken, sessionID, jti); err != nil {
+		slog.ErrorContext(r.Context(), "logout failed", "err", err)
+		apierrors.WriteError(w, r, err)
+		return
+	}
 
-func LogoutHandler(w http.ResponseWriter, r *http.Request) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     "session",
 		Value:    "",
@@ -13,5 +44,5 @@ func LogoutHandler(w http.ResponseWriter, r *http.Request) {
 		SameSite: http.SameSiteLaxMode,
 	})
 	w.Header().Set("Content-Type", "application/json")
-	w.Write([]byte(`{"ok": true}`))
+	json.NewEncoder(w).Encode(OKDTO{OK: true})
 }
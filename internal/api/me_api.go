@@ -4,13 +4,14 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
 )
 
 func (s *serviceImpl) MeHandler(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", 401)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
@@ -18,15 +19,31 @@ func (s *serviceImpl) MeHandler(w http.ResponseWriter, r *http.Request) {
 
 	user, err := s.container.GetUserByIDUC.Execute(userID) // из БД
 	if err != nil {
-		http.Error(w, "not found", 404)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
-	resp := map[string]interface{}{
-		"id":         user.ID,
-		"first_name": user.FirstName,
-		"last_name":  user.LastName,
+	providers, err := s.container.GetLinkedProvidersUC.Execute(userID)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	resp := &MeDTO{
+		ID:        user.ID,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Providers: providers,
+		Role:      user.Role,
 	}
 
 	json.NewEncoder(w).Encode(resp)
 }
+
+type MeDTO struct {
+	ID        int64    `json:"id"`
+	FirstName string   `json:"first_name"`
+	LastName  string   `json:"last_name"`
+	Providers []string `json:"providers"`
+	Role      string   `json:"role"`
+}
@@ -6,8 +6,8 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
-	"github.com/SaenkoDmitry/training-tg-bot/internal/api/validator"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 )
@@ -15,7 +15,7 @@ import (
 func (s *serviceImpl) GetMeasurements(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
@@ -23,7 +23,7 @@ func (s *serviceImpl) GetMeasurements(w http.ResponseWriter, r *http.Request) {
 
 	result, err := s.container.FindAllMeasurementsUC.Execute(claims.UserID, limit, offset)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -32,22 +32,11 @@ func (s *serviceImpl) GetMeasurements(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *serviceImpl) DeleteMeasurement(w http.ResponseWriter, r *http.Request) {
-	claims, ok := middlewares.FromContext(r.Context())
-	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-
 	measurementID, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
 
-	if err := validator.ValidateAccessToMeasurement(s.container, claims.UserID, measurementID); err != nil {
-		helpers.WriteError(w, err)
-		return
-	}
-
 	err := s.container.DeleteMeasurementByIDUC.Execute(measurementID)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -55,30 +44,69 @@ func (s *serviceImpl) DeleteMeasurement(w http.ResponseWriter, r *http.Request)
 	w.Write([]byte("{}"))
 }
 
+type createMeasurementRequest struct {
+	Shoulders int `json:"shoulders"`
+	Chest     int `json:"chest"`
+	HandLeft  int `json:"hand_left"`
+	HandRight int `json:"hand_right"`
+	Waist     int `json:"waist"`
+	Buttocks  int `json:"buttocks"`
+	HipLeft   int `json:"hip_left"`
+	HipRight  int `json:"hip_right"`
+	CalfLeft  int `json:"calf_left"`
+	CalfRight int `json:"calf_right"`
+	Weight    int `json:"weight"`
+}
+
+// границы те же, что у POST /api/v1/measurements
+const (
+	maxCircumferenceCm = 300
+	maxWeightKg        = 500
+)
+
+func (req *createMeasurementRequest) Validate() error {
+	var fields apierrors.Fields
+	circumferences := []struct {
+		field string
+		value int
+	}{
+		{"shoulders", req.Shoulders},
+		{"chest", req.Chest},
+		{"hand_left", req.HandLeft},
+		{"hand_right", req.HandRight},
+		{"waist", req.Waist},
+		{"buttocks", req.Buttocks},
+		{"hip_left", req.HipLeft},
+		{"hip_right", req.HipRight},
+		{"calf_left", req.CalfLeft},
+		{"calf_right", req.CalfRight},
+	}
+	empty := req.Weight == 0
+	for _, c := range circumferences {
+		fields.Range(c.field, float64(c.value), 0, maxCircumferenceCm)
+		empty = empty && c.value == 0
+	}
+	fields.Range("weight", float64(req.Weight), 0, maxWeightKg)
+	if err := fields.Err(); err != nil {
+		return err
+	}
+	if empty {
+		return apierrors.New(http.StatusUnprocessableEntity, apierrors.CodeEmptyMeasurement)
+	}
+	return nil
+}
+
 func (s *serviceImpl) CreateMeasurement(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
 	// Разбираем JSON из тела запроса
-	var input struct {
-		Shoulders int `json:"shoulders"`
-		Chest     int `json:"chest"`
-		HandLeft  int `json:"hand_left"`
-		HandRight int `json:"hand_right"`
-		Waist     int `json:"waist"`
-		Buttocks  int `json:"buttocks"`
-		HipLeft   int `json:"hip_left"`
-		HipRight  int `json:"hip_right"`
-		CalfLeft  int `json:"calf_left"`
-		CalfRight int `json:"calf_right"`
-		Weight    int `json:"weight"`
-	}
+	var input createMeasurementRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+	if !decodeJSON(w, r, &input) {
 		return
 	}
 
@@ -101,7 +129,7 @@ func (s *serviceImpl) CreateMeasurement(w http.ResponseWriter, r *http.Request)
 
 	result, err := s.container.CreateMeasurementUC.Execute(m)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -112,7 +140,7 @@ func (s *serviceImpl) CreateMeasurement(w http.ResponseWriter, r *http.Request)
 func (s *serviceImpl) GetMeasurementTypes(w http.ResponseWriter, r *http.Request) {
 	_, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	oauthusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/oauth"
+	userusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/oauth"
+	"github.com/go-chi/chi/v5"
+)
+
+func (s *serviceImpl) GetOAuthProviders(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.container.ListOAuthProvidersUC.Execute())
+}
+
+// OAuthRedirectHandler начинает вход: state, nonce и PKCE verifier генерирует и хранит сервер
+func (s *serviceImpl) OAuthRedirectHandler(w http.ResponseWriter, r *http.Request) {
+	origin := r.URL.Query().Get("origin")
+	if origin == "" {
+		apierrors.WriteError(w, r, apierrors.InvalidParameter("origin", apierrors.FieldRequired, "is required"))
+		return
+	}
+	if !s.isAllowedOrigin(origin) {
+		apierrors.Write(w, r, http.StatusForbidden, apierrors.CodeInvalidOrigin)
+		return
+	}
+
+	provider := chi.URLParam(r, "provider")
+	start, err := s.container.BeginOAuthUC.Execute(r.Context(), provider, oauthRedirectURI(origin, provider), 0)
+	if err != nil {
+		writeOAuthError(w, r, err)
+		return
+	}
+
+	setOAuthBinding(w, start.Binding)
+	http.Redirect(w, r, start.URL, http.StatusFound)
+}
+
+// OAuthLoginHandler принимает code и state, которые фронт получил на странице возврата
+type oAuthLoginHandlerRequest struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
+
+func (req *oAuthLoginHandlerRequest) Validate() error {
+	var fields apierrors.Fields
+	fields.Required("code", req.Code)
+	fields.Required("state", req.State)
+	return fields.Err()
+}
+
+func (s *serviceImpl) OAuthLoginHandler(w http.ResponseWriter, r *http.Request) {
+	var body oAuthLoginHandlerRequest
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	var binding string
+	if cookie, err := r.Cookie(oauthBindingCookie); err == nil {
+		binding = cookie.Value
+	}
+	// для привязки запрос должен прийти от того же пользователя, маршрут открыт с OptionalAuth
+	var currentUserID int64
+	if claims, ok := middlewares.FromContext(r.Context()); ok {
+		currentUserID = claims.UserID
+	}
+
+	result, err := s.container.CompleteOAuthUC.Execute(r.Context(), chi.URLParam(r, "provider"), body.Code, body.State, binding, currentUserID)
+	setOAuthBinding(w, "")
+	if err != nil {
+		writeOAuthError(w, r, err)
+		return
+	}
+
+	// привязку начинал уже вошедший пользователь, новая сессия ему не нужна
+	if result.Linked {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result.Link)
+		return
+	}
+
+	s.startAuthSession(w, r, result.UserID)
+}
+
+// LinkOAuth начинает привязку OIDC провайдера к текущему пользователю и отдает адрес для перехода
+type linkOAuthRequest struct {
+	Origin string `json:"origin"`
+}
+
+func (req *linkOAuthRequest) Validate() error {
+	var fields apierrors.Fields
+	fields.Required("origin", req.Origin)
+	return fields.Err()
+}
+
+func (s *serviceImpl) LinkOAuth(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	var body linkOAuthRequest
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	if !s.isAllowedOrigin(body.Origin) {
+		apierrors.Write(w, r, http.StatusForbidden, apierrors.CodeInvalidOrigin)
+		return
+	}
+
+	provider := chi.URLParam(r, "provider")
+	start, err := s.container.BeginOAuthUC.Execute(r.Context(), provider, oauthRedirectURI(body.Origin, provider), claims.UserID)
+	if err != nil {
+		writeOAuthError(w, r, err)
+		return
+	}
+
+	setOAuthBinding(w, start.Binding)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&URLDTO{URL: start.URL})
+}
+
+// oauthBindingCookie связывает вход с браузером, который его начал: без него state из чужой ссылки не примут
+const oauthBindingCookie = "oauth_binding"
+
+// setOAuthBinding ставит cookie на время входа; пустое значение удаляет ее
+func setOAuthBinding(w http.ResponseWriter, binding string) {
+	maxAge := int(oauthBindingTTL.Seconds())
+	if binding == "" {
+		maxAge = -1
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     oauthBindingCookie,
+		Value:    binding,
+		Path:     "/api/oauth",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// с запасом покрывает время жизни state
+const oauthBindingTTL = 15 * time.Minute
+
+func oauthRedirectURI(origin, provider string) string {
+	return origin + "/auth-oauth/" + provider
+}
+
+func writeOAuthError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, oauth.UnknownProviderErr):
+		err = apierrors.Wrap(err, http.StatusNotFound, apierrors.CodeUnknownProvider)
+	case errors.Is(err, oauth.InvalidStateErr):
+		err = apierrors.Wrap(err, http.StatusBadRequest, apierrors.CodeInvalidOAuthState)
+	case errors.Is(err, oauthusecases.ForeignLinkErr):
+		err = apierrors.Wrap(err, http.StatusForbidden, apierrors.CodeForbidden)
+	case errors.Is(err, oauth.InvalidIDTokenErr):
+		err = apierrors.Wrap(err, http.StatusUnauthorized, apierrors.CodeInvalidIDToken)
+	case errors.Is(err, userusecases.ProviderAlreadyLinkedErr),
+		errors.Is(err, userusecases.AccountsConflictErr):
+		writeLinkError(w, r, err)
+		return
+	default:
+		// остальное — сбой обмена кода у провайдера, 502 попадет в лог
+		err = apierrors.Wrap(err, http.StatusBadGateway, apierrors.CodeUpstream)
+	}
+	apierrors.WriteError(w, r, err)
+}
@@ -0,0 +1,484 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/graphql"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/openapi"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/authtokens"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/timermanager"
+)
+
+const (
+	bearerAuth    = "bearerAuth"
+	personalToken = "personalToken"
+)
+
+// OpenAPI — документ со всеми маршрутами initServer. Таблица ниже повторяет порядок
+// регистрации в cmd/main.go; контрактные тесты сверяют ее с роутером и с ответами хендлеров
+var OpenAPI = sync.OnceValue(func() *openapi.Document {
+	b := openapi.NewBuilder(openapi.Info{
+		Title:       "Training bot API",
+		Version:     "1.0.0",
+		Description: "HTTP API веб-приложения. Стабильный контракт для внешних клиентов — только /api/v1, остальные маршруты обслуживают фронтенд",
+	})
+	b.SecurityScheme(bearerAuth, &openapi.SecurityScheme{
+		Type:         "http",
+		Scheme:       "bearer",
+		BearerFormat: "JWT",
+		Description:  "Access токен из /api/*/login или /api/auth/refresh",
+	})
+	b.SecurityScheme(personalToken, &openapi.SecurityScheme{
+		Type:        "http",
+		Scheme:      "bearer",
+		Description: "Персональный токен tgb_pat_…; права перечислены в x-scopes операции. Access токен веб-приложения тоже подходит",
+	})
+
+	for _, tag := range [][2]string{
+		{"auth", "Вход, регистрация и сессии"},
+		{"account", "Профиль и привязанные способы входа"},
+		{"admin", "Админка"},
+		{"workouts", "Тренировки"},
+		{"sessions", "Текущая тренировка: упражнения, подходы, таймеры"},
+		{"programs", "Программы и дни программ"},
+		{"measurements", "Замеры тела"},
+		{"exercises", "Справочник упражнений и статистика"},
+		{"coaching", "Тренер и подопечные"},
+		{"social", "Подписки, лента, шаринг"},
+		{"challenges", "Челленджи и достижения"},
+		{"integrations", "Вебхуки, токены, push, синхронизация"},
+		{"public-v1", "Публичный API"},
+		{"meta", "Служебные маршруты"},
+	} {
+		b.Tag(tag[0], tag[1])
+	}
+
+	for _, op := range operations() {
+		b.Add(op)
+	}
+	return b.Document()
+})
+
+// openAPIJSON — документ сериализуется один раз, он не меняется до перезапуска
+var openAPIJSON = sync.OnceValues(func() ([]byte, error) {
+	return json.Marshal(OpenAPI())
+})
+
+func (s *serviceImpl) GetOpenAPI(w http.ResponseWriter, r *http.Request) {
+	body, err := openAPIJSON()
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+func operations() []openapi.Op {
+	origin := openapi.Required(openapi.QueryString("origin", "Адрес фронтенда, на который провайдер вернет пользователя"))
+	pageV1 := openapi.Page(v1DefaultLimit, v1MaxLimit)
+	// списки тренировок: offset оставлен для старых клиентов, cursor не съезжает при добавлении тренировок
+	workoutsPage := append(openapi.ClampedPage(10, 50),
+		openapi.QueryString("cursor", "next_cursor из предыдущего ответа; если задан, offset не учитывается"))
+
+	ops := []openapi.Op{
+		// авторизация через Telegram
+		{Method: http.MethodGet, Path: "/api/telegram/login", Handler: "TelegramRedirectHandler", Tag: "auth",
+			Summary: "Редирект на виджет входа Telegram", Params: []*openapi.Parameter{origin}, Status: http.StatusFound},
+		{Method: http.MethodPost, Path: "/api/telegram/login", Handler: "TelegramLoginHandler", Tag: "auth",
+			Summary: "Вход по данным виджета Telegram", Request: dto.TelegramUser{}, Response: dto.AuthTokens{}},
+
+		// авторизация через Yandex ID
+		{Method: http.MethodGet, Path: "/api/yandex/login", Handler: "YandexRedirectHandler", Tag: "auth",
+			Summary: "Редирект на Yandex ID", Status: http.StatusFound,
+			Params: []*openapi.Parameter{origin, openapi.Required(openapi.QueryString("state", "Значение, которое Yandex вернет без изменений"))}},
+		{Method: http.MethodPost, Path: "/api/yandex/login", Handler: "YandexLoginHandler", Tag: "auth",
+			Summary: "Вход по коду Yandex ID", Request: yandexLoginHandlerRequest{}, Response: dto.AuthTokens{}},
+
+		// OAuth / OIDC провайдеры
+		{Method: http.MethodGet, Path: "/api/oauth/providers", Handler: "GetOAuthProviders", Tag: "auth",
+			Summary: "Включенные OAuth провайдеры", Response: []dto.OAuthProvider{}},
+		{Method: http.MethodGet, Path: "/api/oauth/{provider}/login", Handler: "OAuthRedirectHandler", Tag: "auth",
+			Summary: "Редирект на страницу входа провайдера", Params: []*openapi.Parameter{origin}, Status: http.StatusFound},
+		{Method: http.MethodPost, Path: "/api/oauth/{provider}/login", Handler: "OAuthLoginHandler", Tag: "auth",
+			Summary: "Завершение входа или привязки по code и state", Request: oAuthLoginHandlerRequest{},
+			OneOf: []any{dto.AuthTokens{}, dto.LinkAccountResult{}}},
+
+		// email и пароль, magic link
+		{Method: http.MethodPost, Path: "/api/email/register", Handler: "RegisterByEmail", Tag: "auth",
+			Summary: "Регистрация по email; письмо с подтверждением", Request: emailAuthRequest{}, Status: http.StatusAccepted, Response: OKDTO{}},
+		{Method: http.MethodPost, Path: "/api/email/login", Handler: "LoginByEmail", Tag: "auth",
+			Summary: "Вход по email и паролю", Request: emailAuthRequest{}, Response: dto.AuthTokens{}},
+		{Method: http.MethodPost, Path: "/api/email/verify", Handler: "VerifyEmail", Tag: "auth",
+			Summary: "Подтверждение email по токену из письма", Request: emailAuthRequest{}, Response: dto.AuthTokens{}},
+		{Method: http.MethodPost, Path: "/api/email/verify/resend", Handler: "ResendEmailVerification", Tag: "auth",
+			Summary: "Повторное письмо с подтверждением", Request: emailAuthRequest{}, Status: http.StatusAccepted, Response: OKDTO{}},
+		{Method: http.MethodPost, Path: "/api/email/magic-link", Handler: "RequestMagicLink", Tag: "auth",
+			Summary: "Письмо со ссылкой для входа", Request: emailAuthRequest{}, Status: http.StatusAccepted, Response: OKDTO{}},
+		{Method: http.MethodPost, Path: "/api/email/magic-link/consume", Handler: "ConsumeMagicLink", Tag: "auth",
+			Summary: "Вход по ссылке из письма", Request: emailAuthRequest{}, Response: dto.AuthTokens{}},
+		{Method: http.MethodPost, Path: "/api/email/password/forgot", Handler: "ForgotPassword", Tag: "auth",
+			Summary: "Письмо для сброса пароля", Request: emailAuthRequest{}, Status: http.StatusAccepted, Response: OKDTO{}},
+		{Method: http.MethodPost, Path: "/api/email/password/reset", Handler: "ResetPassword", Tag: "auth",
+			Summary: "Новый пароль по токену из письма", Request: emailAuthRequest{}, Response: dto.AuthTokens{}},
+
+		// сессии
+		{Method: http.MethodPost, Path: "/api/auth/refresh", Handler: "RefreshToken", Tag: "auth",
+			Summary: "Новая пара токенов по refresh токену", Request: refreshTokenRequest{}, Response: dto.AuthTokens{}},
+		{Method: http.MethodPost, Path: "/api/logout", Handler: "LogoutHandler", Tag: "auth",
+			Summary: "Выход: сессия закрывается по access или refresh токену", Request: logoutHandlerRequest{}, RequestOptional: true, Response: OKDTO{}},
+
+		// текущий пользователь
+		{Method: http.MethodGet, Path: "/api/me", Handler: "MeHandler", Tag: "account", Security: bearerAuth,
+			Summary: "Текущий пользователь", Response: MeDTO{}},
+		{Method: http.MethodGet, Path: "/api/me/sessions", Handler: "GetAuthSessions", Tag: "auth", Security: bearerAuth,
+			Summary: "Активные сессии", Response: []dto.AuthSessionItem{}},
+		{Method: http.MethodDelete, Path: "/api/me/sessions/{session_id}", Handler: "RevokeAuthSession", Tag: "auth", Security: bearerAuth,
+			Summary: "Завершить сессию", Params: []*openapi.Parameter{openapi.PathString("session_id", "UUID сессии")}, Status: http.StatusNoContent},
+		{Method: http.MethodPost, Path: "/api/me/sessions/revoke-others", Handler: "RevokeOtherAuthSessions", Tag: "auth", Security: bearerAuth,
+			Summary: "Завершить все сессии, кроме текущей", Response: RevokedSessionsDTO{}},
+		{Method: http.MethodPost, Path: "/api/me/link/telegram", Handler: "LinkTelegram", Tag: "account", Security: bearerAuth,
+			Summary: "Привязать Telegram", Request: dto.TelegramUser{}, Response: dto.LinkAccountResult{}},
+		{Method: http.MethodPost, Path: "/api/me/link/yandex", Handler: "LinkYandex", Tag: "account", Security: bearerAuth,
+			Summary: "Привязать Yandex ID", Request: linkYandexRequest{}, Response: dto.LinkAccountResult{}},
+		{Method: http.MethodPost, Path: "/api/me/link/oauth/{provider}", Handler: "LinkOAuth", Tag: "account", Security: bearerAuth,
+			Summary: "Начать привязку OAuth провайдера", Request: linkOAuthRequest{}, Response: URLDTO{}},
+		{Method: http.MethodPost, Path: "/api/me/link/email", Handler: "LinkEmail", Tag: "account", Security: bearerAuth,
+			Summary: "Привязать email и пароль", Request: emailAuthRequest{}, Status: http.StatusAccepted, Response: OKDTO{}},
+		{Method: http.MethodDelete, Path: "/api/me/link/{provider}", Handler: "UnlinkProvider", Tag: "account", Security: bearerAuth,
+			Summary: "Отвязать способ входа", Response: ProvidersDTO{}},
+
+		// админка
+		{Method: http.MethodGet, Path: "/api/admin/users", Handler: "AdminGetUsers", Tag: "admin", Security: bearerAuth,
+			Summary: "Поиск пользователей", Response: dto.AdminUsersResult{},
+			Params: append([]*openapi.Parameter{openapi.QueryString("q", "Имя, username или email")}, openapi.ClampedPage(20, 100)...)},
+		{Method: http.MethodPut, Path: "/api/admin/users/{user_id}/role", Handler: "AdminSetUserRole", Tag: "admin", Security: bearerAuth,
+			Summary: "Сменить роль", Request: adminSetUserRoleRequest{}, Status: http.StatusNoContent},
+		{Method: http.MethodPost, Path: "/api/admin/users/{user_id}/disable", Handler: "AdminDisableUser", Tag: "admin", Security: bearerAuth,
+			Summary: "Заблокировать пользователя", Request: adminDisableUserRequest{}, RequestOptional: true, Status: http.StatusNoContent},
+		{Method: http.MethodPost, Path: "/api/admin/users/{user_id}/enable", Handler: "AdminEnableUser", Tag: "admin", Security: bearerAuth,
+			Summary: "Разблокировать пользователя", Status: http.StatusNoContent},
+		{Method: http.MethodGet, Path: "/api/admin/stats", Handler: "AdminGetUsageStats", Tag: "admin", Security: bearerAuth,
+			Summary: "Статистика использования", Response: dto.UsageStats{}},
+		{Method: http.MethodPost, Path: "/api/admin/exercise-types", Handler: "AdminCreateExerciseType", Tag: "admin", Security: bearerAuth,
+			Summary: "Добавить упражнение в справочник", Request: dto.ExerciseTypeInput{}, Status: http.StatusCreated, Response: models.ExerciseType{}},
+		{Method: http.MethodPut, Path: "/api/admin/exercise-types/{exercise_type_id}", Handler: "AdminUpdateExerciseType", Tag: "admin", Security: bearerAuth,
+			Summary: "Изменить упражнение справочника", Request: dto.ExerciseTypeInput{}, Response: models.ExerciseType{}},
+		{Method: http.MethodDelete, Path: "/api/admin/exercise-types/{exercise_type_id}", Handler: "AdminDeleteExerciseType", Tag: "admin", Security: bearerAuth,
+			Summary: "Удалить упражнение из справочника", Status: http.StatusNoContent},
+		{Method: http.MethodGet, Path: "/api/admin/audit-log", Handler: "AdminGetAuditLog", Tag: "admin", Security: bearerAuth,
+			Summary: "Журнал действий администраторов", Response: dto.AuditLogResult{},
+			Params: append([]*openapi.Parameter{
+				openapi.QueryInt("actor_id", "Кто выполнил действие"),
+				openapi.QueryString("action", "Тип действия"),
+				openapi.QueryString("target_type", "Тип объекта"),
+				openapi.QueryString("target_id", "Идентификатор объекта"),
+			}, openapi.ClampedPage(50, 200)...)},
+
+		// публичный ключ для push-уведомлений
+		{Method: http.MethodGet, Path: "/api/vapid-key", Handler: "GetVapidKey", Tag: "integrations",
+			Summary: "Публичный VAPID ключ", Response: VapidKeyDTO{}},
+
+		// видео
+		{Method: http.MethodGet, Path: "/api/video/link", Handler: "LinkVideo", Tag: "exercises", Security: bearerAuth,
+			Summary: "Подписанная ссылка на видео упражнения", Response: URLDTO{},
+			Params: []*openapi.Parameter{openapi.Required(openapi.QueryString("url", "Адрес видео на Яндекс Диске"))}},
+		{Method: http.MethodGet, Path: "/api/video/stream", Handler: "StreamVideo", Tag: "exercises",
+			Summary: "Проксирование видео по подписанной ссылке", ContentType: "video/*",
+			Params: []*openapi.Parameter{openapi.QueryString("token", "Токен из /api/video/link; без него ответ 401")}},
+
+		// excel
+		{Method: http.MethodGet, Path: "/api/excel/workouts", Handler: "DownloadExcelWorkoutsStats", Tag: "workouts", Security: bearerAuth,
+			Summary: "Выгрузка тренировок в Excel", ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+
+		// статистика
+		{Method: http.MethodGet, Path: "/api/stats/weekly", Handler: "GetWeeklyStats", Tag: "workouts", Security: bearerAuth,
+			Summary: "Итоги по неделям: группы мышц и упражнения", Response: dto.WeeklyStats{},
+			Params: []*openapi.Parameter{
+				openapi.QueryTime("from", "Недели, начавшиеся не раньше: RFC 3339 или YYYY-MM-DD; по умолчанию последние 12 недель"),
+				openapi.QueryTime("to", "Недели, начавшиеся раньше: RFC 3339 или YYYY-MM-DD"),
+			}},
+
+		// иконка профиля
+		{Method: http.MethodGet, Path: "/api/users/icon", Handler: "GetIcon", Tag: "account", Security: bearerAuth,
+			Summary: "Иконка профиля", Response: IconDTO{}},
+		{Method: http.MethodPost, Path: "/api/users/change-icon", Handler: "ChangeIcon", Tag: "account", Security: bearerAuth,
+			Summary: "Сменить иконку профиля", Request: changeIconRequest{}, Response: struct{}{}},
+
+		// тренировки
+		{Method: http.MethodGet, Path: "/api/workouts", Handler: "GetAllWorkouts", Tag: "workouts", Security: bearerAuth,
+			Summary: "Тренировки пользователя", Params: workoutsPage, Response: dto.ShowMyWorkoutsResult{}},
+		{Method: http.MethodPost, Path: "/api/workouts/start", Handler: "StartWorkout", Tag: "workouts", Security: bearerAuth,
+			Summary: "Начать тренировку по дню программы", Request: startWorkoutRequest{}, Response: StartWorkoutDTO{}},
+		{Method: http.MethodPost, Path: "/api/workouts/log", Handler: "LogPastWorkout", Tag: "workouts", Security: bearerAuth,
+			Summary: "Внести тренировку задним числом", Request: dto.LogPastWorkout{}, Response: StartWorkoutDTO{}},
+		{Method: http.MethodPost, Path: "/api/workouts/{workout_id}/finish", Handler: "FinishWorkout", Tag: "workouts", Security: bearerAuth,
+			Summary: "Завершить тренировку", Response: struct{}{}},
+		{Method: http.MethodGet, Path: "/api/workouts/{workout_id}", Handler: "ReadWorkout", Tag: "workouts", Security: bearerAuth,
+			Summary: "Тренировка с упражнениями и подходами", Response: ReadWorkoutDTO{}},
+		{Method: http.MethodDelete, Path: "/api/workouts/{workout_id}", Handler: "DeleteWorkout", Tag: "workouts", Security: bearerAuth,
+			Summary: "Удалить тренировку", Response: struct{}{}},
+		{Method: http.MethodPost, Path: "/api/workouts/{workout_id}/share", Handler: "CreateShareWorkout", Tag: "social", Security: bearerAuth,
+			Summary: "Публичная ссылка на завершенную тренировку", Response: dto.ShareResponse{}},
+		{Method: http.MethodPut, Path: "/api/workouts/{workout_id}/share/comments", Handler: "SetShareCommentsEnabled", Tag: "social", Security: bearerAuth,
+			Summary: "Включить или выключить комментарии к ссылке", Request: setShareCommentsEnabledRequest{}, Status: http.StatusNoContent},
+		{Method: http.MethodPost, Path: "/api/workouts/{workout_id}/reopen", Handler: "ReopenWorkout", Tag: "workouts", Security: bearerAuth,
+			Summary: "Открыть завершенную тренировку для правок", Response: dto.EditWorkoutResult{}},
+		{Method: http.MethodPatch, Path: "/api/workouts/{workout_id}/times", Handler: "UpdateWorkoutTimes", Tag: "workouts", Security: bearerAuth,
+			Summary: "Изменить время начала и конца", Request: dto.UpdateWorkoutTimes{}, Response: dto.EditWorkoutResult{}},
+		{Method: http.MethodGet, Path: "/api/workouts/{workout_id}/edits", Handler: "GetWorkoutEdits", Tag: "workouts", Security: bearerAuth,
+			Summary: "История правок тренировки", Response: []dto.WorkoutEditItem{}},
+		{Method: http.MethodPost, Path: "/api/workouts/{workout_id}/undo-auto-finish", Handler: "UndoAutoFinishWorkout", Tag: "workouts", Security: bearerAuth,
+			Summary: "Отменить автоматическое завершение", Response: dto.EditWorkoutResult{}},
+		{Method: http.MethodGet, Path: "/api/workouts/{workout_id}/comments", Handler: "GetWorkoutComments", Tag: "coaching", Security: bearerAuth,
+			Summary: "Комментарии к тренировке", Response: []dto.WorkoutCommentItem{}},
+		{Method: http.MethodPost, Path: "/api/workouts/{workout_id}/comments", Handler: "AddWorkoutComment", Tag: "coaching", Security: bearerAuth,
+			Summary: "Прокомментировать тренировку", Request: addWorkoutCommentRequest{}, Status: http.StatusCreated, Response: dto.WorkoutCommentItem{}},
+		{Method: http.MethodDelete, Path: "/api/workouts/{workout_id}/comments/{comment_id}", Handler: "DeleteWorkoutComment", Tag: "coaching", Security: bearerAuth,
+			Summary: "Удалить комментарий", Status: http.StatusNoContent},
+
+		// тренер и подопечные
+		{Method: http.MethodGet, Path: "/api/coaching", Handler: "GetCoachingOverview", Tag: "coaching", Security: bearerAuth,
+			Summary: "Тренеры, подопечные и приглашения", Response: dto.CoachingOverview{}},
+		{Method: http.MethodPost, Path: "/api/coaching/invites", Handler: "CreateCoachInvite", Tag: "coaching", Security: bearerAuth,
+			Summary: "Приглашение для подопечного", Request: createCoachInviteRequest{}, RequestOptional: true, Status: http.StatusCreated, Response: dto.CoachInvite{}},
+		{Method: http.MethodPost, Path: "/api/coaching/invites/accept", Handler: "AcceptCoachInvite", Tag: "coaching", Security: bearerAuth,
+			Summary: "Принять приглашение тренера", Request: acceptCoachInviteRequest{}, Response: dto.CoachLinkItem{}},
+		{Method: http.MethodDelete, Path: "/api/coaching/links/{link_id}", Handler: "RevokeCoachLink", Tag: "coaching", Security: bearerAuth,
+			Summary: "Разорвать связь тренера и подопечного", Status: http.StatusNoContent},
+		{Method: http.MethodPut, Path: "/api/coaching/links/{link_id}/scopes", Handler: "UpdateCoachScopes", Tag: "coaching", Security: bearerAuth,
+			Summary: "Изменить права тренера", Request: updateCoachScopesRequest{}, Response: CoachScopesDTO{}},
+		{Method: http.MethodGet, Path: "/api/coaching/athletes/{athlete_id}/workouts", Handler: "GetAthleteWorkouts", Tag: "coaching", Security: bearerAuth,
+			Summary: "Тренировки подопечного", Params: workoutsPage, Response: dto.ShowMyWorkoutsResult{}},
+		{Method: http.MethodGet, Path: "/api/coaching/athletes/{athlete_id}/measurements", Handler: "GetAthleteMeasurements", Tag: "coaching", Security: bearerAuth,
+			Summary: "Замеры подопечного", Params: openapi.ClampedPage(10, 50), Response: dto.FindWithOffsetLimitMeasurement{}},
+		{Method: http.MethodGet, Path: "/api/coaching/athletes/{athlete_id}/exercises/{exercise_type_id}/stats", Handler: "GetAthleteExerciseStats", Tag: "coaching", Security: bearerAuth,
+			Summary: "Прогресс подопечного в упражнении", Params: openapi.ClampedPage(10, 50), Response: dto.ExercisesStats{}},
+		{Method: http.MethodGet, Path: "/api/coaching/athletes/{athlete_id}/programs", Handler: "GetAthletePrograms", Tag: "coaching", Security: bearerAuth,
+			Summary: "Программы подопечного", Response: []*dto.ProgramDTO{}},
+		{Method: http.MethodPost, Path: "/api/coaching/athletes/{athlete_id}/programs", Handler: "CreateAthleteProgram", Tag: "coaching", Security: bearerAuth,
+			Summary: "Создать программу подопечному", Request: createAthleteProgramRequest{}, Status: http.StatusCreated, Response: dto.ProgramDTO{}},
+		{Method: http.MethodPost, Path: "/api/coaching/athletes/{athlete_id}/programs/{program_id}/assign", Handler: "AssignAthleteProgram", Tag: "coaching", Security: bearerAuth,
+			Summary: "Сделать программу активной у подопечного", Status: http.StatusNoContent},
+
+		// подписки, приватность и лента
+		{Method: http.MethodGet, Path: "/api/social/feed", Handler: "GetFeed", Tag: "social", Security: bearerAuth,
+			Summary: "Лента активности подписок", Params: openapi.ClampedPage(20, 50), Response: dto.FeedResult{}},
+		{Method: http.MethodGet, Path: "/api/social/privacy", Handler: "GetPrivacy", Tag: "social", Security: bearerAuth,
+			Summary: "Настройки приватности", Response: dto.PrivacySettings{}},
+		{Method: http.MethodPut, Path: "/api/social/privacy", Handler: "UpdatePrivacy", Tag: "social", Security: bearerAuth,
+			Summary: "Изменить настройки приватности", Request: dto.PrivacySettings{}, Response: dto.PrivacySettings{}},
+		{Method: http.MethodGet, Path: "/api/social/followers", Handler: "GetFollowers", Tag: "social", Security: bearerAuth,
+			Summary: "Подписчики или заявки на подписку", Response: dto.FollowList{},
+			Params: append([]*openapi.Parameter{openapi.QueryBool("pending", "true — неподтвержденные заявки")}, openapi.ClampedPage(20, 100)...)},
+		{Method: http.MethodPost, Path: "/api/social/followers/{user_id}/accept", Handler: "AcceptFollower", Tag: "social", Security: bearerAuth,
+			Summary: "Подтвердить заявку на подписку", Status: http.StatusNoContent},
+		{Method: http.MethodDelete, Path: "/api/social/followers/{user_id}", Handler: "RemoveFollower", Tag: "social", Security: bearerAuth,
+			Summary: "Удалить подписчика или отклонить заявку", Status: http.StatusNoContent},
+		{Method: http.MethodGet, Path: "/api/social/following", Handler: "GetFollowing", Tag: "social", Security: bearerAuth,
+			Summary: "Подписки пользователя", Params: openapi.ClampedPage(20, 100), Response: dto.FollowList{}},
+		{Method: http.MethodPost, Path: "/api/social/follow/{user_id}", Handler: "Follow", Tag: "social", Security: bearerAuth,
+			Summary: "Подписаться", Response: FollowStatusDTO{}},
+		{Method: http.MethodDelete, Path: "/api/social/follow/{user_id}", Handler: "Unfollow", Tag: "social", Security: bearerAuth,
+			Summary: "Отписаться", Status: http.StatusNoContent},
+		{Method: http.MethodGet, Path: "/api/social/users/{user_id}", Handler: "GetSocialProfile", Tag: "social", Security: bearerAuth,
+			Summary: "Профиль пользователя с учетом приватности", Response: dto.SocialProfile{}},
+
+		// челленджи
+		{Method: http.MethodGet, Path: "/api/challenges", Handler: "GetMyChallenges", Tag: "challenges", Security: bearerAuth,
+			Summary: "Челленджи пользователя", Response: []dto.ChallengeItem{}},
+		{Method: http.MethodPost, Path: "/api/challenges", Handler: "CreateChallenge", Tag: "challenges", Security: bearerAuth,
+			Summary: "Создать челлендж", Request: dto.CreateChallenge{}, Status: http.StatusCreated, Response: dto.ChallengeItem{}},
+		{Method: http.MethodGet, Path: "/api/challenges/invites/{code}", Handler: "GetChallengeInvite", Tag: "challenges", Security: bearerAuth,
+			Summary: "Челлендж по коду приглашения", Response: dto.ChallengeInvite{}},
+		{Method: http.MethodPost, Path: "/api/challenges/invites/{code}/join", Handler: "JoinChallenge", Tag: "challenges", Security: bearerAuth,
+			Summary: "Присоединиться по приглашению", Response: dto.ChallengeItem{}},
+		{Method: http.MethodGet, Path: "/api/challenges/{challenge_id}", Handler: "GetChallenge", Tag: "challenges", Security: bearerAuth,
+			Summary: "Челлендж с таблицей лидеров", Response: dto.ChallengeDetails{}},
+		{Method: http.MethodDelete, Path: "/api/challenges/{challenge_id}", Handler: "DeleteChallenge", Tag: "challenges", Security: bearerAuth,
+			Summary: "Удалить челлендж", Status: http.StatusNoContent},
+		{Method: http.MethodDelete, Path: "/api/challenges/{challenge_id}/participants/me", Handler: "LeaveChallenge", Tag: "challenges", Security: bearerAuth,
+			Summary: "Выйти из челленджа", Status: http.StatusNoContent},
+
+		// достижения
+		{Method: http.MethodGet, Path: "/api/achievements", Handler: "GetMyAchievements", Tag: "challenges", Security: bearerAuth,
+			Summary: "Достижения и серии", Response: dto.Achievements{}},
+
+		// вебхуки
+		{Method: http.MethodGet, Path: "/api/webhooks", Handler: "GetMyWebhooks", Tag: "integrations", Security: bearerAuth,
+			Summary: "Вебхуки пользователя", Response: []dto.Webhook{}},
+		{Method: http.MethodPost, Path: "/api/webhooks", Handler: "CreateWebhook", Tag: "integrations", Security: bearerAuth,
+			Summary: "Создать вебхук", Request: dto.CreateWebhook{}, Status: http.StatusCreated, Response: dto.Webhook{}},
+		{Method: http.MethodGet, Path: "/api/webhooks/event-types", Handler: "GetWebhookEventTypes", Tag: "integrations", Security: bearerAuth,
+			Summary: "Типы событий для подписки", Response: []dto.WebhookEventType{}},
+		{Method: http.MethodPut, Path: "/api/webhooks/{webhook_id}", Handler: "UpdateWebhook", Tag: "integrations", Security: bearerAuth,
+			Summary: "Изменить вебхук", Request: dto.UpdateWebhook{}, Response: dto.Webhook{}},
+		{Method: http.MethodDelete, Path: "/api/webhooks/{webhook_id}", Handler: "DeleteWebhook", Tag: "integrations", Security: bearerAuth,
+			Summary: "Удалить вебхук", Status: http.StatusNoContent},
+		{Method: http.MethodGet, Path: "/api/webhooks/{webhook_id}/deliveries", Handler: "GetWebhookDeliveries", Tag: "integrations", Security: bearerAuth,
+			Summary: "Журнал доставок", Params: openapi.ClampedPage(20, 100), Response: dto.WebhookDeliveries{}},
+		{Method: http.MethodPost, Path: "/api/webhooks/{webhook_id}/test", Handler: "SendTestWebhook", Tag: "integrations", Security: bearerAuth,
+			Summary: "Отправить тестовое событие", Response: dto.WebhookDelivery{}},
+
+		// персональные токены
+		{Method: http.MethodGet, Path: "/api/tokens", Handler: "GetMyAccessTokens", Tag: "integrations", Security: bearerAuth,
+			Summary: "Персональные токены", Response: []dto.PersonalAccessToken{}},
+		{Method: http.MethodPost, Path: "/api/tokens", Handler: "CreateAccessToken", Tag: "integrations", Security: bearerAuth,
+			Summary: "Выпустить токен; значение отдается один раз", Request: dto.CreatePersonalAccessToken{}, Status: http.StatusCreated, Response: dto.PersonalAccessToken{}},
+		{Method: http.MethodGet, Path: "/api/tokens/scopes", Handler: "GetAccessTokenScopes", Tag: "integrations", Security: bearerAuth,
+			Summary: "Доступные права токенов", Response: []dto.AccessTokenScope{}},
+		{Method: http.MethodDelete, Path: "/api/tokens/{token_id}", Handler: "RevokeAccessToken", Tag: "integrations", Security: bearerAuth,
+			Summary: "Отозвать токен", Status: http.StatusNoContent},
+
+		// публичный API
+		{Method: http.MethodGet, Path: "/api/v1/me", Handler: "V1GetMe", Tag: "public-v1", Security: personalToken,
+			Summary: "Владелец токена и его права", Response: dto.APIMe{}},
+		{Method: http.MethodGet, Path: "/api/v1/workouts", Handler: "V1GetWorkouts", Tag: "public-v1", Security: personalToken,
+			Scopes: []string{string(authtokens.ScopeWorkoutsRead)}, Summary: "Тренировки", Response: dto.APIWorkouts{},
+			Params: append([]*openapi.Parameter{
+				openapi.QueryEnum("status", "Статус тренировки", workouts.StatusActive, workouts.StatusEditing, workouts.StatusCompleted),
+				openapi.QueryTime("from", "Начало не раньше: RFC 3339 или YYYY-MM-DD"),
+				openapi.QueryTime("to", "Начало раньше: RFC 3339 или YYYY-MM-DD"),
+				positive(openapi.QueryInt("day_type_id", "День программы")),
+			}, pageV1...)},
+		{Method: http.MethodGet, Path: "/api/v1/workouts/{workout_id}", Handler: "V1GetWorkout", Tag: "public-v1", Security: personalToken,
+			Scopes: []string{string(authtokens.ScopeWorkoutsRead)}, Summary: "Тренировка с упражнениями и подходами", Response: dto.APIWorkout{}},
+		{Method: http.MethodGet, Path: "/api/v1/measurements", Handler: "V1GetMeasurements", Tag: "public-v1", Security: personalToken,
+			Scopes: []string{string(authtokens.ScopeMeasurementsRead)}, Summary: "Замеры", Response: dto.APIMeasurements{},
+			Params: append([]*openapi.Parameter{
+				openapi.QueryTime("from", "Замер не раньше: RFC 3339 или YYYY-MM-DD"),
+				openapi.QueryTime("to", "Замер раньше: RFC 3339 или YYYY-MM-DD"),
+			}, pageV1...)},
+		{Method: http.MethodPost, Path: "/api/v1/measurements", Handler: "V1CreateMeasurement", Tag: "public-v1", Security: personalToken,
+			Scopes: []string{string(authtokens.ScopeMeasurementsWrite)}, Summary: "Добавить замер",
+			Request: dto.CreateAPIMeasurement{}, Status: http.StatusCreated, Response: dto.APIMeasurement{}},
+
+		// публичная тренировка по ссылке
+		{Method: http.MethodGet, Path: "/api/public/workouts/{token}", Handler: "GetPublicWorkout", Tag: "social",
+			Summary: "Тренировка по публичной ссылке", Response: ReadWorkoutDTO{}},
+		{Method: http.MethodGet, Path: "/api/public/workouts/{token}/social", Handler: "GetShareSocial", Tag: "social",
+			Summary: "Реакции и комментарии; с токеном в ответе отмечены свои", Response: dto.ShareSocial{}},
+		{Method: http.MethodPut, Path: "/api/public/workouts/{token}/social/reaction", Handler: "ReactToShare", Tag: "social", Security: bearerAuth,
+			Summary: "Поставить реакцию", Request: reactToShareRequest{}, Response: dto.ShareSocial{}},
+		{Method: http.MethodDelete, Path: "/api/public/workouts/{token}/social/reaction", Handler: "RemoveShareReaction", Tag: "social", Security: bearerAuth,
+			Summary: "Убрать реакцию", Response: dto.ShareSocial{}},
+		{Method: http.MethodPost, Path: "/api/public/workouts/{token}/social/comments", Handler: "CommentShare", Tag: "social", Security: bearerAuth,
+			Summary: "Комментарий к публичной тренировке", Request: commentShareRequest{}, Status: http.StatusCreated, Response: dto.WorkoutCommentItem{}},
+		{Method: http.MethodDelete, Path: "/api/public/workouts/{token}/social/comments/{comment_id}", Handler: "DeleteShareComment", Tag: "social", Security: bearerAuth,
+			Summary: "Удалить комментарий", Status: http.StatusNoContent},
+
+		// текущая тренировка
+		{Method: http.MethodGet, Path: "/api/sessions/{workout_id}", Handler: "ShowCurrentExerciseSession", Tag: "sessions", Security: bearerAuth,
+			Summary: "Текущее упражнение", Response: dto.CurrentExerciseSession{}},
+		{Method: http.MethodPost, Path: "/api/sessions/{workout_id}", Handler: "MoveToExerciseSession", Tag: "sessions", Security: bearerAuth,
+			Summary: "Перейти к следующему или предыдущему упражнению", Request: moveToExerciseSessionRequest{}, Response: struct{}{}},
+		{Method: http.MethodPost, Path: "/api/sessions/{workout_id}/set-index/{index}", Handler: "MoveToCertainExerciseSession", Tag: "sessions", Security: bearerAuth,
+			Summary: "Перейти к упражнению по номеру", Response: struct{}{}},
+		{Method: http.MethodGet, Path: "/api/sessions/{workout_id}/events", Handler: "StreamSessionEvents", Tag: "sessions", Security: bearerAuth,
+			Summary: "SSE: изменения подходов, переходы, таймеры", ContentType: "text/event-stream"},
+
+		// замеры
+		{Method: http.MethodGet, Path: "/api/measurements", Handler: "GetMeasurements", Tag: "measurements", Security: bearerAuth,
+			Summary: "Замеры пользователя", Params: openapi.ClampedPage(10, 50), Response: dto.FindWithOffsetLimitMeasurement{}},
+		{Method: http.MethodGet, Path: "/api/measurements/types", Handler: "GetMeasurementTypes", Tag: "measurements", Security: bearerAuth,
+			Summary: "Виды замеров", Response: []*MeasurementTypeDTO{}},
+		{Method: http.MethodPost, Path: "/api/measurements", Handler: "CreateMeasurement", Tag: "measurements", Security: bearerAuth,
+			Summary: "Добавить замер", Request: createMeasurementRequest{}, Response: dto.Measurement{}},
+		{Method: http.MethodDelete, Path: "/api/measurements/{id}", Handler: "DeleteMeasurement", Tag: "measurements", Security: bearerAuth,
+			Summary: "Удалить замер", Response: struct{}{}},
+
+		// справочник упражнений
+		{Method: http.MethodGet, Path: "/api/exercise-groups", Handler: "GetExerciseGroups", Tag: "exercises", Security: bearerAuth,
+			Summary: "Группы мышц", Response: []dto.Group{}},
+		{Method: http.MethodGet, Path: "/api/exercise-groups/{group}", Handler: "GetExerciseTypesByGroup", Tag: "exercises", Security: bearerAuth,
+			Summary: "Упражнения группы", Response: []*dto.ExerciseTypeDTO{}},
+
+		// пресеты
+		{Method: http.MethodPost, Path: "/api/presets/parse", Handler: "ParsePreset", Tag: "programs", Security: bearerAuth,
+			Summary: "Разобрать текстовый пресет", Request: parsePresetRequest{}, Response: dto.PresetListDTO{}},
+		{Method: http.MethodPost, Path: "/api/presets/save", Handler: "SavePreset", Tag: "programs", Security: bearerAuth,
+			Summary: "Сохранить пресет в день программы", Request: savePresetRequest{}, Response: struct{}{}},
+
+		// программы
+		{Method: http.MethodGet, Path: "/api/programs", Handler: "GetUserPrograms", Tag: "programs", Security: bearerAuth,
+			Summary: "Программы пользователя", Response: []*dto.ProgramDTO{}},
+		{Method: http.MethodPost, Path: "/api/programs", Handler: "CreateProgram", Tag: "programs", Security: bearerAuth,
+			Summary: "Создать программу", Request: createProgramRequest{}, Response: struct{}{}},
+		{Method: http.MethodGet, Path: "/api/programs/active", Handler: "GetActiveProgramForUser", Tag: "programs", Security: bearerAuth,
+			Summary: "Активная программа", Response: dto.ProgramDTO{}},
+		{Method: http.MethodPost, Path: "/api/programs/{program_id}/choose", Handler: "ChooseProgram", Tag: "programs", Security: bearerAuth,
+			Summary: "Сделать программу активной", Response: struct{}{}},
+		{Method: http.MethodDelete, Path: "/api/programs/{program_id}", Handler: "DeleteProgram", Tag: "programs", Security: bearerAuth,
+			Summary: "Удалить программу", Response: struct{}{}},
+		{Method: http.MethodGet, Path: "/api/programs/{program_id}", Handler: "GetProgram", Tag: "programs", Security: bearerAuth,
+			Summary: "Программа с днями", Response: dto.ProgramDTO{}},
+		{Method: http.MethodPost, Path: "/api/programs/{program_id}/rename", Handler: "RenameProgram", Tag: "programs", Security: bearerAuth,
+			Summary: "Переименовать программу", Request: renameProgramRequest{}, Response: struct{}{}},
+		{Method: http.MethodPost, Path: "/api/programs/{program_id}/days", Handler: "CreateProgramDay", Tag: "programs", Security: bearerAuth,
+			Summary: "Добавить день программы", Request: createProgramDayRequest{}, Response: dto.WorkoutDayTypeDTO{}},
+		{Method: http.MethodDelete, Path: "/api/programs/{program_id}/days/{day_type_id}", Handler: "DeleteProgramDay", Tag: "programs", Security: bearerAuth,
+			Summary: "Удалить день программы", Response: struct{}{}},
+		{Method: http.MethodPost, Path: "/api/programs/{program_id}/days/{day_type_id}", Handler: "UpdateProgramDay", Tag: "programs", Security: bearerAuth,
+			Summary: "Изменить день программы", Request: updateProgramDayRequest{}, Response: struct{}{}},
+		{Method: http.MethodGet, Path: "/api/programs/{program_id}/days/{day_type_id}", Handler: "GetProgramDay", Tag: "programs", Security: bearerAuth,
+			Summary: "День программы", Response: dto.WorkoutDayTypeDTO{}},
+
+		// подходы
+		{Method: http.MethodPost, Path: "/api/sets/{exercise_id}", Handler: "AddSet", Tag: "sessions", Security: bearerAuth,
+			Summary: "Добавить подход", Response: SetVersionDTO{}},
+		{Method: http.MethodDelete, Path: "/api/sets/{id}", Handler: "DeleteSet", Tag: "sessions", Security: bearerAuth,
+			Summary: "Удалить подход", Response: struct{}{}},
+		{Method: http.MethodPost, Path: "/api/sets/{id}/complete", Handler: "CompleteSet", Tag: "sessions", Security: bearerAuth,
+			Summary: "Отметить подход выполненным", Response: SetVersionDTO{}},
+		{Method: http.MethodPost, Path: "/api/sets/{id}/change", Handler: "ChangeSet", Tag: "sessions", Security: bearerAuth,
+			Summary: "Изменить подход; версия передается в If-Match", Request: changeSetRequest{}, Response: SetVersionDTO{}},
+
+		// упражнения тренировки
+		{Method: http.MethodPost, Path: "/api/exercises", Handler: "AddExercise", Tag: "sessions", Security: bearerAuth,
+			Summary: "Добавить упражнение в тренировку", Request: addExerciseRequest{}, Response: struct{}{}},
+		{Method: http.MethodDelete, Path: "/api/exercises/{id}", Handler: "DeleteExercise", Tag: "sessions", Security: bearerAuth,
+			Summary: "Удалить упражнение из тренировки", Response: struct{}{}},
+		{Method: http.MethodGet, Path: "/api/exercises/{exercise_type_id}/stats", Handler: "GetExerciseStatsByUser", Tag: "exercises", Security: bearerAuth,
+			Summary: "Прогресс в упражнении", Params: openapi.ClampedPage(10, 50), Response: dto.ExercisesStats{}},
+
+		// push-уведомления
+		{Method: http.MethodPost, Path: "/api/push/subscribe", Handler: "PushSubscribe", Tag: "integrations", Security: bearerAuth,
+			Summary: "Подписать браузер на push", Request: dto.PushSubscription{}},
+		{Method: http.MethodPost, Path: "/api/push/unsubscribe", Handler: "PushUnsubscribe", Tag: "integrations", Security: bearerAuth,
+			Summary: "Отписать браузер от push", Request: dto.PushUnsubscribe{}},
+
+		// таймеры отдыха
+		{Method: http.MethodPost, Path: "/api/timers/start", Handler: "StartTimer", Tag: "sessions", Security: bearerAuth,
+			Summary: "Запустить таймер отдыха", Request: startTimerRequest{}, Response: timermanager.TimerDTO{}},
+		{Method: http.MethodPost, Path: "/api/timers/cancel/{id}", Handler: "CancelTimer", Tag: "sessions", Security: bearerAuth,
+			Summary: "Отменить таймер"},
+
+		// офлайн-синхронизация
+		{Method: http.MethodPost, Path: "/api/sync", Handler: "Sync", Tag: "integrations", Security: bearerAuth,
+			Summary: "Применить офлайн-мутации и получить изменения", Request: dto.SyncRequest{}, Response: dto.SyncResponse{}},
+
+		// graphql
+		{Method: http.MethodPost, Path: "/api/graphql", Handler: "GraphQL", Tag: "integrations", Security: bearerAuth,
+			Summary: "Запрос GraphQL; ошибки полей приходят в errors с кодом apierrors в extensions.code",
+			Request: graphqlRequest{}, Response: graphql.Response{}},
+		{Method: http.MethodGet, Path: "/api/graphql/schema", Handler: "GetGraphQLSchema", Tag: "integrations", Security: bearerAuth,
+			Summary: "Схема GraphQL в SDL", ContentType: "text/plain"},
+
+		// этот документ
+		{Method: http.MethodGet, Path: "/api/openapi.json", Handler: "GetOpenAPI", Tag: "meta",
+			Summary: "Спецификация OpenAPI", ContentType: "application/json"},
+	}
+	return ops
+}
+
+func positive(p *openapi.Parameter) *openapi.Parameter {
+	one := 1.0
+	p.Schema.Minimum = &one
+	return p
+}
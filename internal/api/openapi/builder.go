@@ -0,0 +1,252 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+)
+
+// Op — описание одной операции в таблице API; по нему Builder собирает Operation
+type Op struct {
+	Method  string
+	Path    string // шаблон в синтаксисе chi, без завершающего слэша
+	Handler string // имя метода api.serviceImpl, он же operationId
+	Tag     string
+	Summary string
+
+	// Security — схема авторизации из SecuritySchemes; пусто — операция публичная
+	Security string
+	Scopes   []string
+
+	// Params — query-параметры и path-параметры с нестандартным типом; остальные
+	// path-параметры выводятся из шаблона: id, *_id и index — целые, прочие — строки
+	Params []*Parameter
+
+	// Request — значение Go-типа, в который хендлер разбирает тело; nil — тела нет
+	Request         any
+	RequestOptional bool
+
+	// Status — код успешного ответа, по умолчанию 200
+	Status int
+	// Response — значение Go-типа успешного ответа; nil — ответ без тела
+	Response any
+	// OneOf — вместо Response, если хендлер отвечает одним из нескольких типов
+	OneOf []any
+	// ContentType успешного ответа, если это не JSON
+	ContentType string
+}
+
+type Builder struct {
+	doc *Document
+	gen *schemaGenerator
+}
+
+func NewBuilder(info Info) *Builder {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]*PathItem{},
+		Components: Components{
+			Schemas:         map[string]*Schema{},
+			SecuritySchemes: map[string]*SecurityScheme{},
+		},
+	}
+	return &Builder{doc: doc, gen: newSchemaGenerator(doc.Components.Schemas)}
+}
+
+func (b *Builder) SecurityScheme(name string, scheme *SecurityScheme) {
+	b.doc.Components.SecuritySchemes[name] = scheme
+}
+
+func (b *Builder) Tag(name, description string) {
+	b.doc.Tags = append(b.doc.Tags, Tag{Name: name, Description: description})
+}
+
+// Add регистрирует операцию; повтор метода и пути — ошибка в таблице, поэтому паника
+func (b *Builder) Add(op Op) {
+	method := lowerMethod(op.Method)
+	item, ok := b.doc.Paths[op.Path]
+	if !ok {
+		item = &PathItem{}
+		b.doc.Paths[op.Path] = item
+	}
+	if _, dup := (*item)[method]; dup {
+		panic(fmt.Sprintf("openapi: duplicate operation %s %s", op.Method, op.Path))
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	operation := &Operation{
+		OperationID:   op.Handler,
+		Summary:       op.Summary,
+		Handler:       op.Handler,
+		Scopes:        op.Scopes,
+		Responses:     map[string]*Response{},
+		Method:        strings.ToUpper(method),
+		Path:          op.Path,
+		SuccessStatus: status,
+	}
+	if op.Tag != "" {
+		operation.Tags = []string{op.Tag}
+	}
+	if op.Security != "" {
+		operation.Security = []map[string][]string{{op.Security: nonNil(op.Scopes)}}
+	}
+
+	operation.Parameters = b.parameters(op)
+
+	if op.Request != nil {
+		operation.RequestBody = &RequestBody{
+			Required: !op.RequestOptional,
+			Content: map[string]*MediaType{
+				"application/json": {Schema: b.gen.schemaOf(reflect.TypeOf(op.Request))},
+			},
+		}
+	}
+
+	success := &Response{Description: http.StatusText(status)}
+	switch {
+	case op.Response != nil:
+		success.Content = map[string]*MediaType{
+			"application/json": {Schema: b.gen.schemaOf(reflect.TypeOf(op.Response))},
+		}
+	case len(op.OneOf) > 0:
+		schema := &Schema{}
+		for _, v := range op.OneOf {
+			schema.AnyOf = append(schema.AnyOf, b.gen.schemaOf(reflect.TypeOf(v)))
+		}
+		success.Content = map[string]*MediaType{"application/json": {Schema: schema}}
+	case op.ContentType != "":
+		success.Content = map[string]*MediaType{op.ContentType: {}}
+	}
+	operation.Responses[strconv.Itoa(status)] = success
+
+	// все ошибки API отдаются одним телом apierrors.Body
+	errorContent := map[string]*MediaType{
+		"application/json": {Schema: b.gen.schemaOf(reflect.TypeOf(apierrors.Body{}))},
+	}
+	// 400 отдает и middleware валидации, и сами хендлеры
+	operation.Responses["400"] = &Response{Description: "Некорректный запрос", Content: errorContent}
+	if op.Security != "" {
+		operation.Responses["401"] = &Response{Description: "Не авторизован", Content: errorContent}
+	}
+	if op.Request != nil {
+		operation.Responses["422"] = &Response{Description: "Значения полей недопустимы", Content: errorContent}
+	}
+	operation.Responses["default"] = &Response{Description: "Ошибка", Content: errorContent}
+
+	(*item)[method] = operation
+	b.doc.operations = append(b.doc.operations, operation)
+}
+
+func (b *Builder) parameters(op Op) []*Parameter {
+	declared := map[string]*Parameter{}
+	for _, p := range op.Params {
+		declared[p.In+":"+p.Name] = p
+	}
+
+	var params []*Parameter
+	for _, name := range PathParams(op.Path) {
+		if p, ok := declared["path:"+name]; ok {
+			params = append(params, p)
+			continue
+		}
+		p := &Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}}
+		if name == "id" || name == "index" || strings.HasSuffix(name, "_id") {
+			p.Schema = &Schema{Type: "integer", Format: "int64"}
+		}
+		params = append(params, p)
+	}
+	for _, p := range op.Params {
+		if p.In == "query" {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+func (b *Builder) Document() *Document {
+	return b.doc
+}
+
+// PathParams возвращает имена параметров шаблона пути по порядку
+func PathParams(path string) []string {
+	var names []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			names = append(names, segment[1:len(segment)-1])
+		}
+	}
+	return names
+}
+
+func PathString(name, description string) *Parameter {
+	return &Parameter{Name: name, In: "path", Required: true, Description: description, Schema: &Schema{Type: "string"}}
+}
+
+func QueryString(name, description string) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "string"}}
+}
+
+func QueryInt(name, description string) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "integer", Format: "int64"}}
+}
+
+func QueryBool(name, description string) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "boolean"}}
+}
+
+// QueryTime — метка времени RFC 3339 или дата YYYY-MM-DD
+func QueryTime(name, description string) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "string", Format: "date-time"}}
+}
+
+func QueryEnum(name, description string, values ...string) *Parameter {
+	enum := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		enum = append(enum, v)
+	}
+	return &Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "string", Enum: enum}}
+}
+
+// Required помечает параметр обязательным
+func Required(p *Parameter) *Parameter {
+	p.Required = true
+	return p
+}
+
+// Page — offset и limit для строгой пагинации: limit вне [1, max] отклоняется
+func Page(defaultLimit, maxLimit int) []*Parameter {
+	zero, one, max := 0.0, 1.0, float64(maxLimit)
+	offset := QueryInt("offset", "Сколько записей пропустить")
+	offset.Schema.Minimum = &zero
+	limit := QueryInt("limit", fmt.Sprintf("Размер страницы, по умолчанию %d", defaultLimit))
+	limit.Schema.Minimum, limit.Schema.Maximum = &one, &max
+	return []*Parameter{offset, limit}
+}
+
+// ClampedPage — offset и limit старых списков: хендлер сам приводит значения
+// к допустимым, поэтому проверяется только то, что это числа
+func ClampedPage(defaultLimit, maxLimit int) []*Parameter {
+	return []*Parameter{
+		QueryInt("offset", "Сколько записей пропустить"),
+		QueryInt("limit", fmt.Sprintf("Размер страницы, по умолчанию %d, не больше %d", defaultLimit, maxLimit)),
+	}
+}
+
+func lowerMethod(method string) string {
+	return strings.ToLower(method)
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,99 @@
+// Package openapi описывает HTTP API документом OpenAPI 3 и проверяет по нему запросы и ответы.
+// Схемы строятся по Go-типам, которые хендлеры разбирают и отдают, поэтому документ
+// не расходится с DTO; соответствие хендлеров документу проверяют контрактные тесты
+package openapi
+
+import "sync"
+
+const Version = "3.0.3"
+
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Tags       []Tag                `json:"tags,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+
+	operations []*Operation
+	routesOnce sync.Once
+	routes     []route
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// PathItem — операции одного пути по HTTP-методам в нижнем регистре
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Handler     string                `json:"x-handler,omitempty"` // метод api.serviceImpl
+	Scopes      []string              `json:"x-scopes,omitempty"`  // права персонального токена
+
+	Method string `json:"-"`
+	Path   string `json:"-"`
+	// SuccessStatus — код успешного ответа хендлера
+	SuccessStatus int `json:"-"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // path или query
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Operations возвращает операции в порядке регистрации
+func (d *Document) Operations() []*Operation {
+	return d.operations
+}
+
+// Find ищет операцию по методу и шаблону пути из документа
+func (d *Document) Find(method, path string) *Operation {
+	item, ok := d.Paths[path]
+	if !ok {
+		return nil
+	}
+	return (*item)[lowerMethod(method)]
+}
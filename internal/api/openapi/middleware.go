@@ -0,0 +1,240 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+)
+
+// maxBodyBytes — больше этого тело не буферизуется для проверки; синхронизация
+// офлайн-очереди — самый крупный запрос и укладывается с запасом
+const maxBodyBytes = 10 << 20
+
+type Config struct {
+	// ValidateResponses включает сверку JSON-ответов со схемой; ответ буферизуется,
+	// поэтому в проде выключено
+	ValidateResponses bool
+	// OnResponseMismatch вызывается, когда ответ расходится со схемой; по умолчанию пишет в лог
+	OnResponseMismatch func(r *http.Request, op *Operation, status int, err error)
+}
+
+// ConfigFromEnv читает OPENAPI_VALIDATE_RESPONSES=true
+func ConfigFromEnv() Config {
+	return Config{ValidateResponses: os.Getenv("OPENAPI_VALIDATE_RESPONSES") == "true"}
+}
+
+// Middleware проверяет path- и query-параметры и тело запроса по документу и отвечает
+// 400 в формате apierrors; запросы к путям, которых нет в документе, пропускаются как есть
+func Middleware(doc *Document, cfg Config) func(http.Handler) http.Handler {
+	if cfg.OnResponseMismatch == nil {
+		cfg.OnResponseMismatch = func(r *http.Request, op *Operation, status int, err error) {
+			slog.WarnContext(r.Context(), "openapi response mismatch", "method", r.Method, "path", r.URL.Path,
+				"operation", op.OperationID, "status", status, "err", err)
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			op, pathValues := doc.Match(r.Method, r.URL.Path)
+			if op == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if err := validateParams(op, pathValues, r.URL.Query()); err != nil {
+				apierrors.WriteError(w, r, apierrors.InvalidParameter(err.Field, err.Code, err.Reason))
+				return
+			}
+			if op.RequestBody != nil {
+				body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes))
+				if err != nil {
+					apierrors.WriteError(w, r, apierrors.InvalidBody(err))
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+
+				if len(bytes.TrimSpace(body)) == 0 {
+					if op.RequestBody.Required {
+						apierrors.WriteError(w, r, apierrors.InvalidBody(nil, apierrors.FieldError{
+							Code: apierrors.FieldRequired, Message: "request body is required",
+						}))
+						return
+					}
+				} else if err := doc.ValidateRequest(op, body); err != nil {
+					var verr *ValidationError
+					errors.As(err, &verr)
+					apierrors.WriteError(w, r, invalidBody(verr))
+					return
+				}
+			}
+
+			if !cfg.ValidateResponses || !hasJSONResponse(op) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &responseRecorder{header: w.Header(), status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			if err := doc.ValidateResponse(op, rec.status, rec.header.Get("Content-Type"), rec.body.Bytes()); err != nil {
+				cfg.OnResponseMismatch(r, op, rec.status, err)
+			}
+			w.WriteHeader(rec.status)
+			w.Write(rec.body.Bytes())
+		})
+	}
+}
+
+func validateParams(op *Operation, pathValues map[string]string, query url.Values) *ValidationError {
+	for _, p := range op.Parameters {
+		var raw string
+		switch p.In {
+		case "path":
+			raw = pathValues[p.Name]
+		case "query":
+			if _, ok := query[p.Name]; !ok {
+				if p.Required {
+					return &ValidationError{Field: p.Name, Code: apierrors.FieldRequired, Reason: "is required"}
+				}
+				continue
+			}
+			raw = query.Get(p.Name)
+			// пустое значение хендлеры трактуют как отсутствие параметра
+			if raw == "" && !p.Required {
+				continue
+			}
+		}
+		if err := validateParam(p, raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasJSONResponse — операции со стримами (SSE, видео, xlsx) не буферизуются
+func hasJSONResponse(op *Operation) bool {
+	for _, resp := range op.Responses {
+		for mediaType := range resp.Content {
+			if mediaType != "application/json" && mediaType != "text/plain" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+type responseRecorder struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+	r.status = status
+	r.wroteHeader = true
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.body.Write(b)
+}
+
+// Match находит операцию по методу и фактическому пути запроса. Как и в chi,
+// литеральный сегмент важнее параметра: /api/programs/active не совпадет с /{program_id}
+func (d *Document) Match(method, path string) (*Operation, map[string]string) {
+	d.routesOnce.Do(d.buildRoutes)
+
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	segments := strings.Split(path, "/")
+	method = lowerMethod(method)
+
+	var best *route
+	for i := range d.routes {
+		rt := &d.routes[i]
+		if len(rt.segments) != len(segments) || (*rt.item)[method] == nil || !rt.matches(segments) {
+			continue
+		}
+		if best == nil || rt.moreSpecific(best) {
+			best = rt
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+
+	values := map[string]string{}
+	for i, segment := range best.segments {
+		if name, ok := paramName(segment); ok {
+			values[name], _ = url.PathUnescape(segments[i])
+		}
+	}
+	return (*best.item)[method], values
+}
+
+type route struct {
+	segments []string
+	item     *PathItem
+}
+
+func (d *Document) buildRoutes() {
+	for path, item := range d.Paths {
+		d.routes = append(d.routes, route{segments: strings.Split(path, "/"), item: item})
+	}
+}
+
+func (rt *route) matches(segments []string) bool {
+	for i, segment := range rt.segments {
+		if _, ok := paramName(segment); ok {
+			if segments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if segment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (rt *route) moreSpecific(other *route) bool {
+	for i, segment := range rt.segments {
+		_, param := paramName(segment)
+		_, otherParam := paramName(other.segments[i])
+		if param != otherParam {
+			return otherParam
+		}
+	}
+	return false
+}
+
+func paramName(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
+
+// invalidBody — 400 по расхождению тела со схемой; без поля (тело не JSON) — без fields
+func invalidBody(verr *ValidationError) *apierrors.Problem {
+	if verr.Field == "" {
+		return apierrors.InvalidBody(verr)
+	}
+	return apierrors.InvalidBody(verr, apierrors.FieldError{Field: verr.Field, Code: verr.Code, Message: verr.Reason})
+}
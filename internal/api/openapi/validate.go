@@ -0,0 +1,293 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+)
+
+// ValidationError — расхождение значения со схемой; Field — путь до поля через точку
+type ValidationError struct {
+	Field  string
+	Code   string // код ошибки поля из apierrors
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Reason
+	}
+	return e.Field + ": " + e.Reason
+}
+
+type mode int
+
+const (
+	// запросы проверяются снисходительно, как их разбирает encoding/json: поля можно
+	// не передавать, null допустим везде, лишние поля игнорируются
+	requestMode mode = iota
+	// ответы проверяются строго: обязательные поля должны быть, null — только у nullable
+	responseMode
+)
+
+type validator struct {
+	schemas map[string]*Schema
+	mode    mode
+}
+
+// ValidateRequest проверяет разобранное тело запроса по схеме операции
+func (d *Document) ValidateRequest(op *Operation, body []byte) error {
+	if op.RequestBody == nil {
+		return nil
+	}
+	schema := op.RequestBody.Content["application/json"].Schema
+	return d.validateJSON(schema, body, requestMode)
+}
+
+// ValidateResponse проверяет тело ответа с указанным кодом; коды и типы содержимого,
+// которых нет в документе, считаются ошибкой
+func (d *Document) ValidateResponse(op *Operation, status int, contentType string, body []byte) error {
+	resp, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		resp, ok = op.Responses["default"]
+	}
+	if !ok {
+		return &ValidationError{Reason: fmt.Sprintf("status %d is not declared", status)}
+	}
+	empty := len(strings.TrimSpace(string(body))) == 0
+	// ошибки вроде w.WriteHeader(http.StatusUnauthorized) приходят без тела
+	if empty && status >= http.StatusBadRequest {
+		return nil
+	}
+	if len(resp.Content) == 0 {
+		if !empty {
+			return &ValidationError{Reason: fmt.Sprintf("status %d must have an empty body", status)}
+		}
+		return nil
+	}
+
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	media, ok := resp.Content[strings.TrimSpace(mediaType)]
+	if !ok {
+		return &ValidationError{Reason: fmt.Sprintf("content type %q is not declared for status %d", contentType, status)}
+	}
+	if media.Schema == nil || mediaType != "application/json" {
+		return nil
+	}
+	return d.validateJSON(media.Schema, body, responseMode)
+}
+
+func (d *Document) validateJSON(schema *Schema, body []byte, m mode) error {
+	var value interface{}
+	decoder := json.NewDecoder(strings.NewReader(string(body)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return &ValidationError{Reason: "body must be valid JSON"}
+	}
+	v := validator{schemas: d.Components.Schemas, mode: m}
+	return v.validate(schema, value, "")
+}
+
+func (v validator) resolve(s *Schema) *Schema {
+	for s.Ref != "" {
+		s = v.schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+	return s
+}
+
+func (v validator) validate(s *Schema, value interface{}, field string) error {
+	s = v.resolve(s)
+
+	if value == nil {
+		if s.Nullable || v.mode == requestMode {
+			return nil
+		}
+		if len(s.AllOf) == 0 && len(s.AnyOf) == 0 && s.Type == "" {
+			return nil
+		}
+		return &ValidationError{Field: field, Code: apierrors.FieldRequired, Reason: "must not be null"}
+	}
+	for _, sub := range s.AllOf {
+		if err := v.validate(sub, value, field); err != nil {
+			return err
+		}
+	}
+	if len(s.AnyOf) > 0 {
+		var firstErr error
+		for _, sub := range s.AnyOf {
+			err := v.validate(sub, value, field)
+			if err == nil {
+				return nil
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		return &ValidationError{Field: field, Code: apierrors.FieldNotAllowed, Reason: "must be one of " + enumString(s.Enum)}
+	}
+
+	switch s.Type {
+	case "":
+		return nil
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return &ValidationError{Field: field, Code: apierrors.FieldInvalidType, Reason: "must be a boolean"}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return &ValidationError{Field: field, Code: apierrors.FieldInvalidType, Reason: "must be a string"}
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, str); err != nil {
+				return &ValidationError{Field: field, Code: apierrors.FieldInvalidFormat, Reason: "must be an RFC 3339 timestamp"}
+			}
+		}
+	case "integer", "number":
+		n, ok := value.(json.Number)
+		if !ok {
+			return &ValidationError{Field: field, Code: apierrors.FieldInvalidType, Reason: "must be a " + s.Type}
+		}
+		return checkNumber(s, n, field)
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return &ValidationError{Field: field, Code: apierrors.FieldInvalidType, Reason: "must be an array"}
+		}
+		for i, item := range items {
+			if err := v.validate(s.Items, item, field+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return &ValidationError{Field: field, Code: apierrors.FieldInvalidType, Reason: "must be an object"}
+		}
+		return v.validateObject(s, obj, field)
+	}
+	return nil
+}
+
+func (v validator) validateObject(s *Schema, obj map[string]interface{}, field string) error {
+	if v.mode == responseMode {
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				return &ValidationError{Field: join(field, name), Code: apierrors.FieldRequired, Reason: "is required"}
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		prop, ok := s.Properties[k]
+		if !ok {
+			prop = s.AdditionalProperties
+		}
+		if prop == nil {
+			if v.mode == responseMode && s.Properties != nil {
+				return &ValidationError{Field: join(field, k), Code: apierrors.FieldNotAllowed, Reason: "is not declared"}
+			}
+			continue
+		}
+		if err := v.validate(prop, obj[k], join(field, k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkNumber(s *Schema, n json.Number, field string) error {
+	f, err := n.Float64()
+	if err != nil {
+		return &ValidationError{Field: field, Code: apierrors.FieldInvalidType, Reason: "must be a number"}
+	}
+	if s.Type == "integer" {
+		if _, err := n.Int64(); err != nil || f != math.Trunc(f) {
+			return &ValidationError{Field: field, Code: apierrors.FieldInvalidType, Reason: "must be an integer"}
+		}
+	}
+	if s.Minimum != nil && f < *s.Minimum {
+		return &ValidationError{Field: field, Code: apierrors.FieldOutOfRange, Reason: "must be at least " + formatFloat(*s.Minimum)}
+	}
+	if s.Maximum != nil && f > *s.Maximum {
+		return &ValidationError{Field: field, Code: apierrors.FieldOutOfRange, Reason: "must be at most " + formatFloat(*s.Maximum)}
+	}
+	return nil
+}
+
+// validateParam проверяет строковое значение path- или query-параметра
+func validateParam(p *Parameter, raw string) *ValidationError {
+	s := p.Schema
+	if len(s.Enum) > 0 && !inEnum(s.Enum, raw) {
+		return &ValidationError{Field: p.Name, Code: apierrors.FieldNotAllowed, Reason: "must be one of " + enumString(s.Enum)}
+	}
+
+	switch s.Type {
+	case "integer":
+		if _, err := strconv.ParseInt(raw, 10, 64); err != nil {
+			return &ValidationError{Field: p.Name, Code: apierrors.FieldInvalidType, Reason: "must be an integer"}
+		}
+		if err := checkNumber(s, json.Number(raw), p.Name); err != nil {
+			return err.(*ValidationError)
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(raw); err != nil {
+			return &ValidationError{Field: p.Name, Code: apierrors.FieldInvalidType, Reason: "must be a boolean"}
+		}
+	case "string":
+		// в query даты принимаются и полной меткой времени, и просто датой — как в helpers.ParseTimeQuery
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, raw); err == nil {
+				return nil
+			}
+			if _, err := time.Parse(time.DateOnly, raw); err != nil {
+				return &ValidationError{Field: p.Name, Code: apierrors.FieldInvalidFormat, Reason: "must be an RFC 3339 timestamp or YYYY-MM-DD date"}
+			}
+		}
+	}
+	return nil
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func enumString(enum []interface{}) string {
+	parts := make([]string, 0, len(enum))
+	for _, e := range enum {
+		parts = append(parts, fmt.Sprint(e))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func join(field, name string) string {
+	if field == "" {
+		return name
+	}
+	return field + "." + name
+}
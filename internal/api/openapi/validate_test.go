@@ -0,0 +1,177 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+)
+
+type testItem struct {
+	ID       int64      `json:"id"`
+	Name     string     `json:"name"`
+	Note     string     `json:"note,omitempty"`
+	Weight   float32    `json:"weight"`
+	DoneAt   *time.Time `json:"done_at"`
+	Children []testItem `json:"children"`
+	Parent   *testItem  `json:"parent,omitempty"`
+}
+
+type testRequest struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func testDocument() *Document {
+	b := NewBuilder(Info{Title: "test", Version: "1"})
+	b.Add(Op{Method: http.MethodGet, Path: "/items/{item_id}", Handler: "GetItem", Response: testItem{}})
+	b.Add(Op{Method: http.MethodGet, Path: "/items/active", Handler: "GetActive", Response: []testItem{}})
+	b.Add(Op{Method: http.MethodPost, Path: "/items", Handler: "CreateItem", Request: testRequest{}, Status: http.StatusCreated, Response: testItem{},
+		Params: Page(20, 100)})
+	return b.Document()
+}
+
+func TestSchemaFromStruct(t *testing.T) {
+	doc := testDocument()
+
+	item := doc.Components.Schemas["TestItem"]
+	require.NotNil(t, item)
+	assert.Equal(t, "object", item.Type)
+	assert.ElementsMatch(t, []string{"id", "name", "weight", "done_at", "children"}, item.Required)
+	assert.Equal(t, "integer", item.Properties["id"].Type)
+	assert.Equal(t, "date-time", item.Properties["done_at"].Format)
+	assert.True(t, item.Properties["done_at"].Nullable)
+	// рекурсивный тип ссылается сам на себя
+	assert.Equal(t, "#/components/schemas/TestItem", item.Properties["children"].Items.Ref)
+	assert.Equal(t, "#/components/schemas/TestItem", item.Properties["parent"].AllOf[0].Ref)
+}
+
+func TestMatchPrefersLiteralSegments(t *testing.T) {
+	doc := testDocument()
+
+	op, values := doc.Match(http.MethodGet, "/items/active")
+	require.NotNil(t, op)
+	assert.Equal(t, "GetActive", op.OperationID)
+	assert.Empty(t, values)
+
+	op, values = doc.Match(http.MethodGet, "/items/42/")
+	require.NotNil(t, op)
+	assert.Equal(t, "GetItem", op.OperationID)
+	assert.Equal(t, "42", values["item_id"])
+
+	op, _ = doc.Match(http.MethodDelete, "/items/42")
+	assert.Nil(t, op)
+}
+
+func TestValidateRequest(t *testing.T) {
+	doc := testDocument()
+	op := doc.Find(http.MethodPost, "/items")
+
+	// как и encoding/json: поля можно пропускать, лишние игнорируются, null допустим
+	assert.NoError(t, doc.ValidateRequest(op, []byte(`{}`)))
+	assert.NoError(t, doc.ValidateRequest(op, []byte(`{"name": null, "extra": 1}`)))
+
+	err := doc.ValidateRequest(op, []byte(`{"name": "a", "count": 1.5}`))
+	require.Error(t, err)
+	assert.Equal(t, "count", err.(*ValidationError).Field)
+
+	err = doc.ValidateRequest(op, []byte(`{"name": 1}`))
+	require.Error(t, err)
+	assert.Equal(t, "name", err.(*ValidationError).Field)
+
+	assert.Error(t, doc.ValidateRequest(op, []byte(`[`)))
+}
+
+func TestValidateResponse(t *testing.T) {
+	doc := testDocument()
+	op := doc.Find(http.MethodPost, "/items")
+
+	valid := `{"id": 1, "name": "a", "weight": 1.5, "done_at": null, "children": [
+		{"id": 2, "name": "b", "weight": 0, "done_at": "2026-01-02T03:04:05Z", "children": null}
+	]}`
+	assert.NoError(t, doc.ValidateResponse(op, http.StatusCreated, "application/json", []byte(valid)))
+
+	cases := map[string]string{
+		"missing required":   `{"id": 1, "name": "a", "weight": 1, "done_at": null}`,
+		"undeclared field":   `{"id": 1, "name": "a", "weight": 1, "done_at": null, "children": null, "extra": true}`,
+		"nested type":        `{"id": 1, "name": "a", "weight": 1, "done_at": null, "children": [{"id": "2"}]}`,
+		"bad timestamp":      `{"id": 1, "name": "a", "weight": 1, "done_at": "yesterday", "children": null}`,
+		"null in non-null":   `{"id": null, "name": "a", "weight": 1, "done_at": null, "children": null}`,
+		"not an object":      `[]`,
+		"invalid json":       `{`,
+		"wrong content type": ``,
+	}
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			contentType := "application/json"
+			if name == "wrong content type" {
+				contentType, body = "text/html", "<p>"
+			}
+			assert.Error(t, doc.ValidateResponse(op, http.StatusCreated, contentType, []byte(body)))
+		})
+	}
+
+	// ошибки проверяются по default и всегда в формате apierrors, пустое тело допустимо
+	notFound := `{"error": {"code": "not_found", "message": "Не найдено", "request_id": "host/1"}}`
+	assert.NoError(t, doc.ValidateResponse(op, http.StatusNotFound, "application/json", []byte(notFound)))
+	assert.Error(t, doc.ValidateResponse(op, http.StatusNotFound, "text/plain; charset=utf-8", []byte("not found\n")))
+	assert.NoError(t, doc.ValidateResponse(op, http.StatusUnauthorized, "", nil))
+}
+
+func TestMiddleware(t *testing.T) {
+	doc := testDocument()
+
+	var mismatches []string
+	handler := Middleware(doc, Config{
+		ValidateResponses: true,
+		OnResponseMismatch: func(r *http.Request, op *Operation, status int, err error) {
+			mismatches = append(mismatches, op.OperationID+": "+err.Error())
+		},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		w.Write([]byte(`{"id": 1}`))
+	}))
+
+	cases := []struct {
+		name, method, target, body string
+		status                     int
+		field, fieldCode           string
+	}{
+		{"path param", http.MethodGet, "/items/abc", "", http.StatusBadRequest, "item_id", apierrors.FieldInvalidType},
+		{"limit above maximum", http.MethodPost, "/items?limit=101", `{}`, http.StatusBadRequest, "limit", apierrors.FieldOutOfRange},
+		{"negative offset", http.MethodPost, "/items?offset=-1", `{}`, http.StatusBadRequest, "offset", apierrors.FieldOutOfRange},
+		{"body type", http.MethodPost, "/items", `{"count": "many"}`, http.StatusBadRequest, "count", apierrors.FieldInvalidType},
+		{"body required", http.MethodPost, "/items", ``, http.StatusBadRequest, "", ""},
+		{"valid", http.MethodPost, "/items?limit=100", `{"name": "a"}`, http.StatusCreated, "", ""},
+		{"unknown path passes through", http.MethodGet, "/other/abc", "", http.StatusOK, "", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body)))
+
+			assert.Equal(t, tc.status, rec.Code)
+			if tc.field != "" {
+				var body apierrors.Body
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				require.Len(t, body.Error.Fields, 1)
+				assert.Equal(t, tc.field, body.Error.Fields[0].Field)
+				assert.Equal(t, tc.fieldCode, body.Error.Fields[0].Code)
+			}
+		})
+	}
+
+	// хендлер отдал неполный объект — ответ дошел до клиента, расхождение отмечено
+	require.Len(t, mismatches, 1)
+	assert.Contains(t, mismatches[0], "CreateItem: name: is required")
+}
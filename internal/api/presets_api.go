@@ -4,29 +4,31 @@ import (
 	"encoding/json"
 	"net/http"
 
-	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
-	"github.com/SaenkoDmitry/training-tg-bot/internal/api/validator"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/authz"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/ownership"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/utils"
 )
 
+type parsePresetRequest struct {
+	Preset string `json:"preset"`
+}
+
 func (s *serviceImpl) ParsePreset(w http.ResponseWriter, r *http.Request) {
 
 	// Разбираем JSON из тела запроса
-	var input struct {
-		Preset string `json:"preset"`
-	}
+	var input parsePresetRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+	if !decodeJSON(w, r, &input) {
 		return
 	}
 
 	exercisesList, err := s.container.ExerciseTypeListUC.Execute()
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -69,38 +71,39 @@ func (s *serviceImpl) ParsePreset(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(result)
 }
 
+type savePresetRequest struct {
+	DayTypeID int64  `json:"day_type_id"`
+	NewPreset string `json:"new_preset"`
+}
+
 func (s *serviceImpl) SavePreset(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
 	// Разбираем JSON из тела запроса
-	var input struct {
-		DayTypeID int64  `json:"day_type_id"`
-		NewPreset string `json:"new_preset"`
-	}
+	var input savePresetRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+	if !decodeJSON(w, r, &input) {
 		return
 	}
 
 	day, err := s.container.GetDayTypeUC.Execute(input.DayTypeID)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
-	if err = validator.ValidateAccessToProgram(s.container, claims.UserID, day.WorkoutProgramID); err != nil {
-		helpers.WriteError(w, err)
+	if err = s.authz.Check(claims.UserID, authz.OwnerOrCoach(ownership.Program, models.CoachScopePrograms), day.WorkoutProgramID); err != nil {
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
 	err = s.container.UpdatePresetUC.Execute(day.ID, input.NewPreset)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
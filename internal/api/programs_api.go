@@ -2,24 +2,25 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
-	"github.com/SaenkoDmitry/training-tg-bot/internal/api/validator"
-
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
+	programusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/programs"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
 )
 
 func (s *serviceImpl) GetUserPrograms(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
 	result, err := s.container.FindAllProgramsByUserUC.Execute(claims.UserID)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -31,24 +32,24 @@ func (s *serviceImpl) GetUserPrograms(w http.ResponseWriter, r *http.Request) {
 func (s *serviceImpl) GetActiveProgramForUser(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
 	user, err := s.container.GetUserByIDUC.Execute(claims.UserID)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
 	if user.ActiveProgramID == nil {
-		http.Error(w, "У вас нет активных программ, создайте хотя бы одну", http.StatusForbidden)
+		apierrors.Write(w, r, http.StatusForbidden, apierrors.CodeNoPrograms)
 		return
 	}
 
 	program, err := s.container.GetProgramUC.Execute(*user.ActiveProgramID, claims.UserID)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -57,26 +58,27 @@ func (s *serviceImpl) GetActiveProgramForUser(w http.ResponseWriter, r *http.Req
 	json.NewEncoder(w).Encode(program)
 }
 
+type createProgramRequest struct {
+	Name string `json:"name"`
+}
+
 func (s *serviceImpl) CreateProgram(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
 	// Разбираем JSON из тела запроса
-	var input struct {
-		Name string `json:"name"`
-	}
+	var input createProgramRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+	if !decodeJSON(w, r, &input) {
 		return
 	}
 
 	err := s.container.CreateProgramUC.Execute(claims.UserID, input.Name)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -88,7 +90,7 @@ func (s *serviceImpl) CreateProgram(w http.ResponseWriter, r *http.Request) {
 func (s *serviceImpl) ChooseProgram(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
@@ -97,14 +99,9 @@ func (s *serviceImpl) ChooseProgram(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = validator.ValidateAccessToProgram(s.container, claims.UserID, programID); err != nil {
-		helpers.WriteError(w, err)
-		return
-	}
-
 	err = s.container.ActivateProgramUC.Execute(claims.UserID, programID)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -116,7 +113,7 @@ func (s *serviceImpl) ChooseProgram(w http.ResponseWriter, r *http.Request) {
 func (s *serviceImpl) DeleteProgram(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
@@ -125,14 +122,15 @@ func (s *serviceImpl) DeleteProgram(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = validator.ValidateAccessToProgram(s.container, claims.UserID, programID); err != nil {
-		helpers.WriteError(w, err)
-		return
-	}
-
 	err = s.container.DeleteProgramUC.Execute(claims.UserID, programID)
+	switch {
+	case errors.Is(err, programusecases.CannotDeleteCurrentProgramErr):
+		err = apierrors.Wrap(err, http.StatusConflict, apierrors.CodeCurrentProgram)
+	case errors.Is(err, programusecases.CannotDeleteAlreadyUsedProgram):
+		err = apierrors.Wrap(err, http.StatusConflict, apierrors.CodeProgramInUse)
+	}
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -141,36 +139,26 @@ func (s *serviceImpl) DeleteProgram(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("{}"))
 }
 
-func (s *serviceImpl) RenameProgram(w http.ResponseWriter, r *http.Request) {
-	claims, ok := middlewares.FromContext(r.Context())
-	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
+type renameProgramRequest struct {
+	Name string `json:"name"`
+}
 
+func (s *serviceImpl) RenameProgram(w http.ResponseWriter, r *http.Request) {
 	programID, err := helpers.ParseInt64Param("program_id", w, r)
 	if err != nil {
 		return
 	}
 
-	if err = validator.ValidateAccessToProgram(s.container, claims.UserID, programID); err != nil {
-		helpers.WriteError(w, err)
-		return
-	}
-
 	// Разбираем JSON из тела запроса
-	var input struct {
-		Name string `json:"name"`
-	}
+	var input renameProgramRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+	if !decodeJSON(w, r, &input) {
 		return
 	}
 
 	err = s.container.RenameProgramUC.Execute(programID, input.Name)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -182,7 +170,7 @@ func (s *serviceImpl) RenameProgram(w http.ResponseWriter, r *http.Request) {
 func (s *serviceImpl) GetProgram(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
@@ -191,14 +179,9 @@ func (s *serviceImpl) GetProgram(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = validator.ValidateAccessToProgram(s.container, claims.UserID, programID); err != nil {
-		helpers.WriteError(w, err)
-		return
-	}
-
 	program, err := s.container.GetProgramUC.Execute(programID, claims.UserID)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
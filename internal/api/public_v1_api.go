@@ -0,0 +1,180 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	publicapiusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/publicapi"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/measurements"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+// единые для всех списков /api/v1 размеры страницы
+const (
+	v1DefaultLimit = 20
+	v1MaxLimit     = 100
+)
+
+func (s *serviceImpl) V1GetMe(w http.ResponseWriter, r *http.Request) {
+	claims, ok := v1Claims(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := s.container.GetUserByIDUC.Execute(claims.UserID)
+	if err != nil {
+		writeV1Error(w, r, err)
+		return
+	}
+
+	writeV1JSON(w, http.StatusOK, dto.APIMe{
+		ID:        user.ID,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		CreatedAt: user.CreatedAt,
+		Scopes:    claims.Scopes,
+	})
+}
+
+func (s *serviceImpl) V1GetWorkouts(w http.ResponseWriter, r *http.Request) {
+	claims, ok := v1Claims(w, r)
+	if !ok {
+		return
+	}
+	offset, limit, err := helpers.ParsePage(r, v1DefaultLimit, v1MaxLimit)
+	if err != nil {
+		writeV1Error(w, r, err)
+		return
+	}
+
+	var filter workouts.Filter
+	if filter.From, err = helpers.ParseTimeQuery(r, "from"); err != nil {
+		writeV1Error(w, r, err)
+		return
+	}
+	if filter.To, err = helpers.ParseTimeQuery(r, "to"); err != nil {
+		writeV1Error(w, r, err)
+		return
+	}
+	if filter.DayTypeID, err = helpers.ParseInt64Query(r, "day_type_id"); err != nil {
+		writeV1Error(w, r, err)
+		return
+	}
+	switch status := r.URL.Query().Get("status"); status {
+	case "", workouts.StatusActive, workouts.StatusEditing, workouts.StatusCompleted:
+		filter.Status = status
+	default:
+		writeV1Error(w, r, apierrors.InvalidParameter("status", apierrors.FieldNotAllowed, "must be one of active, editing, completed"))
+		return
+	}
+
+	result, err := s.container.APIFindWorkoutsUC.Execute(claims.UserID, filter, offset, limit)
+	if err != nil {
+		writeV1Error(w, r, err)
+		return
+	}
+	writeV1JSON(w, http.StatusOK, result)
+}
+
+func (s *serviceImpl) V1GetWorkout(w http.ResponseWriter, r *http.Request) {
+	claims, ok := v1Claims(w, r)
+	if !ok {
+		return
+	}
+	workoutID, err := strconv.ParseInt(r.PathValue("workout_id"), 10, 64)
+	if err != nil {
+		writeV1Error(w, r, publicapiusecases.NotFoundErr)
+		return
+	}
+
+	result, err := s.container.APIGetWorkoutUC.Execute(claims.UserID, workoutID)
+	if err != nil {
+		writeV1Error(w, r, err)
+		return
+	}
+	writeV1JSON(w, http.StatusOK, result)
+}
+
+func (s *serviceImpl) V1GetMeasurements(w http.ResponseWriter, r *http.Request) {
+	claims, ok := v1Claims(w, r)
+	if !ok {
+		return
+	}
+	offset, limit, err := helpers.ParsePage(r, v1DefaultLimit, v1MaxLimit)
+	if err != nil {
+		writeV1Error(w, r, err)
+		return
+	}
+
+	var filter measurements.Filter
+	if filter.From, err = helpers.ParseTimeQuery(r, "from"); err != nil {
+		writeV1Error(w, r, err)
+		return
+	}
+	if filter.To, err = helpers.ParseTimeQuery(r, "to"); err != nil {
+		writeV1Error(w, r, err)
+		return
+	}
+
+	result, err := s.container.APIFindMeasurementsUC.Execute(claims.UserID, filter, offset, limit)
+	if err != nil {
+		writeV1Error(w, r, err)
+		return
+	}
+	writeV1JSON(w, http.StatusOK, result)
+}
+
+func (s *serviceImpl) V1CreateMeasurement(w http.ResponseWriter, r *http.Request) {
+	claims, ok := v1Claims(w, r)
+	if !ok {
+		return
+	}
+
+	var body dto.CreateAPIMeasurement
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&body); err != nil {
+		apierrors.WriteError(w, r, apierrors.InvalidBody(err))
+		return
+	}
+
+	result, err := s.container.APICreateMeasurementUC.Execute(claims.UserID, body)
+	if err != nil {
+		writeV1Error(w, r, err)
+		return
+	}
+	writeV1JSON(w, http.StatusCreated, result)
+}
+
+func v1Claims(w http.ResponseWriter, r *http.Request) (*middlewares.Claims, bool) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+	}
+	return claims, ok
+}
+
+func writeV1JSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeV1Error(w http.ResponseWriter, r *http.Request, err error) {
+	var fieldErr *publicapiusecases.InvalidFieldErr
+	switch {
+	case errors.As(err, &fieldErr):
+		err = apierrors.InvalidField(err, fieldErr.Field, apierrors.FieldOutOfRange)
+	case errors.Is(err, publicapiusecases.EmptyMeasurementErr):
+		err = apierrors.Wrap(err, http.StatusUnprocessableEntity, apierrors.CodeEmptyMeasurement)
+	case errors.Is(err, publicapiusecases.NotFoundErr):
+		err = apierrors.Wrap(err, http.StatusNotFound, apierrors.CodeNotFound)
+	}
+	apierrors.WriteError(w, r, err)
+}
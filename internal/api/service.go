@@ -4,8 +4,9 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/authz"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/graphql"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase"
-	"github.com/SaenkoDmitry/training-tg-bot/internal/service/push"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/service/timermanager"
 	"gorm.io/gorm"
 )
@@ -27,6 +28,126 @@ type Service interface {
 	YandexRedirectHandler(w http.ResponseWriter, r *http.Request)
 	YandexLoginHandler(w http.ResponseWriter, r *http.Request)
 
+	// ----- oauth / oidc providers -----
+
+	GetOAuthProviders(w http.ResponseWriter, r *http.Request)
+	OAuthRedirectHandler(w http.ResponseWriter, r *http.Request)
+	OAuthLoginHandler(w http.ResponseWriter, r *http.Request)
+	LinkOAuth(w http.ResponseWriter, r *http.Request)
+
+	// ----- email / password and magic link -----
+
+	RegisterByEmail(w http.ResponseWriter, r *http.Request)
+	LoginByEmail(w http.ResponseWriter, r *http.Request)
+	VerifyEmail(w http.ResponseWriter, r *http.Request)
+	ResendEmailVerification(w http.ResponseWriter, r *http.Request)
+	RequestMagicLink(w http.ResponseWriter, r *http.Request)
+	ConsumeMagicLink(w http.ResponseWriter, r *http.Request)
+	ForgotPassword(w http.ResponseWriter, r *http.Request)
+	ResetPassword(w http.ResponseWriter, r *http.Request)
+	LinkEmail(w http.ResponseWriter, r *http.Request)
+
+	// ----- auth sessions -----
+
+	RefreshToken(w http.ResponseWriter, r *http.Request)
+	LogoutHandler(w http.ResponseWriter, r *http.Request)
+	GetAuthSessions(w http.ResponseWriter, r *http.Request)
+	RevokeAuthSession(w http.ResponseWriter, r *http.Request)
+	RevokeOtherAuthSessions(w http.ResponseWriter, r *http.Request)
+
+	// ----- account linking -----
+
+	LinkTelegram(w http.ResponseWriter, r *http.Request)
+	LinkYandex(w http.ResponseWriter, r *http.Request)
+	UnlinkProvider(w http.ResponseWriter, r *http.Request)
+
+	// ----- admin panel -----
+	AdminGetUsers(w http.ResponseWriter, r *http.Request)
+	AdminGetUsageStats(w http.ResponseWriter, r *http.Request)
+	AdminSetUserRole(w http.ResponseWriter, r *http.Request)
+	AdminDisableUser(w http.ResponseWriter, r *http.Request)
+	AdminEnableUser(w http.ResponseWriter, r *http.Request)
+	AdminCreateExerciseType(w http.ResponseWriter, r *http.Request)
+	AdminUpdateExerciseType(w http.ResponseWriter, r *http.Request)
+	AdminDeleteExerciseType(w http.ResponseWriter, r *http.Request)
+	AdminGetAuditLog(w http.ResponseWriter, r *http.Request)
+
+	// ----- reactions and comments on shared workouts -----
+	GetShareSocial(w http.ResponseWriter, r *http.Request)
+	ReactToShare(w http.ResponseWriter, r *http.Request)
+	RemoveShareReaction(w http.ResponseWriter, r *http.Request)
+	CommentShare(w http.ResponseWriter, r *http.Request)
+	DeleteShareComment(w http.ResponseWriter, r *http.Request)
+	SetShareCommentsEnabled(w http.ResponseWriter, r *http.Request)
+
+	// ----- coaching -----
+	GetCoachingOverview(w http.ResponseWriter, r *http.Request)
+	CreateCoachInvite(w http.ResponseWriter, r *http.Request)
+	AcceptCoachInvite(w http.ResponseWriter, r *http.Request)
+	RevokeCoachLink(w http.ResponseWriter, r *http.Request)
+	UpdateCoachScopes(w http.ResponseWriter, r *http.Request)
+	GetAthleteWorkouts(w http.ResponseWriter, r *http.Request)
+	GetAthleteMeasurements(w http.ResponseWriter, r *http.Request)
+	GetAthleteExerciseStats(w http.ResponseWriter, r *http.Request)
+	GetAthletePrograms(w http.ResponseWriter, r *http.Request)
+	CreateAthleteProgram(w http.ResponseWriter, r *http.Request)
+	AssignAthleteProgram(w http.ResponseWriter, r *http.Request)
+
+	// ----- workout comments -----
+	GetWorkoutComments(w http.ResponseWriter, r *http.Request)
+	AddWorkoutComment(w http.ResponseWriter, r *http.Request)
+	DeleteWorkoutComment(w http.ResponseWriter, r *http.Request)
+
+	// ----- followers, privacy and activity feed -----
+	GetFeed(w http.ResponseWriter, r *http.Request)
+	GetPrivacy(w http.ResponseWriter, r *http.Request)
+	UpdatePrivacy(w http.ResponseWriter, r *http.Request)
+	GetFollowers(w http.ResponseWriter, r *http.Request)
+	GetFollowing(w http.ResponseWriter, r *http.Request)
+	Follow(w http.ResponseWriter, r *http.Request)
+	Unfollow(w http.ResponseWriter, r *http.Request)
+	AcceptFollower(w http.ResponseWriter, r *http.Request)
+	RemoveFollower(w http.ResponseWriter, r *http.Request)
+	GetSocialProfile(w http.ResponseWriter, r *http.Request)
+
+	// ----- challenges and leaderboards -----
+	GetMyChallenges(w http.ResponseWriter, r *http.Request)
+	CreateChallenge(w http.ResponseWriter, r *http.Request)
+	GetChallenge(w http.ResponseWriter, r *http.Request)
+	DeleteChallenge(w http.ResponseWriter, r *http.Request)
+	LeaveChallenge(w http.ResponseWriter, r *http.Request)
+	GetChallengeInvite(w http.ResponseWriter, r *http.Request)
+	JoinChallenge(w http.ResponseWriter, r *http.Request)
+
+	// ----- achievements -----
+	GetMyAchievements(w http.ResponseWriter, r *http.Request)
+
+	// ----- webhooks -----
+	GetMyWebhooks(w http.ResponseWriter, r *http.Request)
+	GetWebhookEventTypes(w http.ResponseWriter, r *http.Request)
+	CreateWebhook(w http.ResponseWriter, r *http.Request)
+	UpdateWebhook(w http.ResponseWriter, r *http.Request)
+	DeleteWebhook(w http.ResponseWriter, r *http.Request)
+	GetWebhookDeliveries(w http.ResponseWriter, r *http.Request)
+	SendTestWebhook(w http.ResponseWriter, r *http.Request)
+
+	// ----- personal access tokens -----
+	GetMyAccessTokens(w http.ResponseWriter, r *http.Request)
+	GetAccessTokenScopes(w http.ResponseWriter, r *http.Request)
+	CreateAccessToken(w http.ResponseWriter, r *http.Request)
+	RevokeAccessToken(w http.ResponseWriter, r *http.Request)
+
+	// ----- public api v1 -----
+	V1GetMe(w http.ResponseWriter, r *http.Request)
+	V1GetWorkouts(w http.ResponseWriter, r *http.Request)
+	V1GetWorkout(w http.ResponseWriter, r *http.Request)
+	V1GetMeasurements(w http.ResponseWriter, r *http.Request)
+	V1CreateMeasurement(w http.ResponseWriter, r *http.Request)
+
+	// ----- graphql -----
+	GraphQL(w http.ResponseWriter, r *http.Request)
+	GetGraphQLSchema(w http.ResponseWriter, r *http.Request)
+
 	// ----- user profile icon -----
 
 	GetIcon(w http.ResponseWriter, r *http.Request)
@@ -39,6 +160,11 @@ type Service interface {
 	FinishWorkout(w http.ResponseWriter, r *http.Request)
 	ReadWorkout(w http.ResponseWriter, r *http.Request)
 	DeleteWorkout(w http.ResponseWriter, r *http.Request)
+	LogPastWorkout(w http.ResponseWriter, r *http.Request)
+	ReopenWorkout(w http.ResponseWriter, r *http.Request)
+	UpdateWorkoutTimes(w http.ResponseWriter, r *http.Request)
+	GetWorkoutEdits(w http.ResponseWriter, r *http.Request)
+	UndoAutoFinishWorkout(w http.ResponseWriter, r *http.Request)
 	CreateShareWorkout(w http.ResponseWriter, r *http.Request)
 	GetPublicWorkout(w http.ResponseWriter, r *http.Request)
 
@@ -51,7 +177,7 @@ type Service interface {
 
 	// ----- exercise group types -----
 
-	GetExerciseGroups(w http.ResponseWriter, _ *http.Request)
+	GetExerciseGroups(w http.ResponseWriter, r *http.Request)
 	GetExerciseTypesByGroup(w http.ResponseWriter, r *http.Request)
 
 	// ----- programs -----
@@ -78,6 +204,7 @@ type Service interface {
 	ShowCurrentExerciseSession(w http.ResponseWriter, r *http.Request)
 	MoveToExerciseSession(w http.ResponseWriter, r *http.Request)
 	MoveToCertainExerciseSession(w http.ResponseWriter, r *http.Request)
+	StreamSessionEvents(w http.ResponseWriter, r *http.Request)
 
 	// ----- sets -----
 
@@ -110,19 +237,40 @@ type Service interface {
 	// ----- excel -----
 
 	DownloadExcelWorkoutsStats(w http.ResponseWriter, r *http.Request)
+
+	// ----- stats -----
+
+	GetWeeklyStats(w http.ResponseWriter, r *http.Request)
+
+	// ----- offline sync -----
+
+	Sync(w http.ResponseWriter, r *http.Request)
+
+	// ----- openapi specification -----
+
+	GetOpenAPI(w http.ResponseWriter, r *http.Request)
 }
 
 type serviceImpl struct {
 	container    *usecase.Container
+	authz        *authz.Authorizer // для идентификаторов из тела; из пути проверяет middleware маршрута
 	timerManager *timermanager.TimerManager
+	graphSchema  *graphql.Schema
 }
 
 func New(container *usecase.Container, db *gorm.DB) Service {
-	pushService := push.NewService(db)
-	return &serviceImpl{
+	s := &serviceImpl{
 		container:    container,
-		timerManager: timermanager.NewTimerManager(db, pushService),
+		authz:        authz.New(container),
+		timerManager: timermanager.NewTimerManager(db, container.WorkoutEventsHub),
+	}
+	graphSchema, err := newGraphSchema(s)
+	if err != nil {
+		// схема собирается из кода, ошибка в ней — ошибка программиста
+		panic("graphql schema: " + err.Error())
 	}
+	s.graphSchema = graphSchema
+	return s
 }
 
 func (s *serviceImpl) isAllowedOrigin(origin string) bool {
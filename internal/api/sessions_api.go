@@ -2,35 +2,24 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
-	"strconv"
+	"time"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
-	"github.com/SaenkoDmitry/training-tg-bot/internal/api/validator"
-	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
 )
 
 func (s *serviceImpl) ShowCurrentExerciseSession(w http.ResponseWriter, r *http.Request) {
-	claims, ok := middlewares.FromContext(r.Context())
-	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-
-	workoutID, err := strconv.ParseInt(r.PathValue("workout_id"), 10, 64)
+	workoutID, err := helpers.ParseInt64Param("workout_id", w, r)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		return
-	}
-
-	if err = validator.ValidateAccessToWorkout(s.container, claims.UserID, workoutID); err != nil {
-		helpers.WriteError(w, err)
 		return
 	}
 
 	session, err := s.container.ShowCurrentExerciseSessionUC.Execute(workoutID)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -38,36 +27,26 @@ func (s *serviceImpl) ShowCurrentExerciseSession(w http.ResponseWriter, r *http.
 	json.NewEncoder(w).Encode(session)
 }
 
-func (s *serviceImpl) MoveToExerciseSession(w http.ResponseWriter, r *http.Request) {
-	claims, ok := middlewares.FromContext(r.Context())
-	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
+type moveToExerciseSessionRequest struct {
+	Next bool `json:"next"` // Если false, то двигаемся к предыдущему упражнению, если true – к следующему
+}
 
+func (s *serviceImpl) MoveToExerciseSession(w http.ResponseWriter, r *http.Request) {
 	workoutID, err := helpers.ParseInt64Param("workout_id", w, r)
 	if err != nil {
 		return
 	}
 
 	// Разбираем JSON из тела запроса
-	var input struct {
-		Next bool `json:"next"` // Если false, то двигаемся к предыдущему упражнению, если true – к следующему
-	}
+	var input moveToExerciseSessionRequest
 
-	if err = json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
-		return
-	}
-
-	if err = validator.ValidateAccessToWorkout(s.container, claims.UserID, workoutID); err != nil {
-		helpers.WriteError(w, err)
+	if !decodeJSON(w, r, &input) {
 		return
 	}
 
 	err = s.container.MoveSessionToExerciseUC.Execute(workoutID, input.Next)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -76,12 +55,6 @@ func (s *serviceImpl) MoveToExerciseSession(w http.ResponseWriter, r *http.Reque
 }
 
 func (s *serviceImpl) MoveToCertainExerciseSession(w http.ResponseWriter, r *http.Request) {
-	claims, ok := middlewares.FromContext(r.Context())
-	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-
 	workoutID, err := helpers.ParseInt64Param("workout_id", w, r)
 	if err != nil {
 		return
@@ -92,17 +65,60 @@ func (s *serviceImpl) MoveToCertainExerciseSession(w http.ResponseWriter, r *htt
 		return
 	}
 
-	if err = validator.ValidateAccessToWorkout(s.container, claims.UserID, workoutID); err != nil {
-		helpers.WriteError(w, err)
-		return
-	}
-
 	err = s.container.MoveToCertainUC.Execute(workoutID, int(index))
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Write([]byte("{}"))
 }
+
+// StreamSessionEvents отдает события тренировки через Server-Sent Events
+func (s *serviceImpl) StreamSessionEvents(w http.ResponseWriter, r *http.Request) {
+	workoutID, err := helpers.ParseInt64Param("workout_id", w, r)
+	if err != nil {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		apierrors.WriteError(w, r, errors.New("streaming unsupported"))
+		return
+	}
+
+	events, unsubscribe := s.container.WorkoutEventsHub.Subscribe(workoutID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // отключаем буферизацию в nginx
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+
+		case event, open := <-events:
+			if !open {
+				return
+			}
+			data, _ := json.Marshal(event)
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+			flusher.Flush()
+		}
+	}
+}
+
+const sseKeepAliveInterval = 25 * time.Second
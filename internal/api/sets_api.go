@@ -4,66 +4,54 @@ import (
 	"encoding/json"
 	"net/http"
 
-	"github.com/SaenkoDmitry/training-tg-bot/internal/api/validator"
-
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
-	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
 )
 
 func (s *serviceImpl) AddSet(w http.ResponseWriter, r *http.Request) {
-	claims, ok := middlewares.FromContext(r.Context())
-	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-
 	exerciseID, err := helpers.ParseInt64Param("exercise_id", w, r)
 	if err != nil {
 		return
 	}
 
-	_, err = s.container.AddOneMoreSetUC.Execute(exerciseID)
+	expectedVersion, err := helpers.GetIfMatchVersion(w, r)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
 		return
 	}
 
-	if err = validator.ValidateAccessToExercise(s.container, claims.UserID, exerciseID); err != nil {
-		helpers.WriteError(w, err)
+	res, err := s.container.AddOneMoreSetUC.Execute(exerciseID, expectedVersion)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
+	helpers.SetETag(w, res.Version)
 	w.Header().Set("Content-Type", "application/json")
-	w.Write([]byte("{}"))
+	json.NewEncoder(w).Encode(&SetVersionDTO{ID: res.SetID, Version: res.Version})
 }
 
 func (s *serviceImpl) DeleteSet(w http.ResponseWriter, r *http.Request) {
-	claims, ok := middlewares.FromContext(r.Context())
-	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-
 	setID, err := helpers.ParseInt64Param("id", w, r)
 	if err != nil {
 		return
 	}
 
-	set, err := s.container.GetSetByIDUC.Execute(setID)
+	expectedVersion, err := helpers.GetIfMatchVersion(w, r)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
 		return
 	}
 
-	if err = validator.ValidateAccessToExercise(s.container, claims.UserID, set.ExerciseID); err != nil {
-		helpers.WriteError(w, err)
+	set, err := s.container.GetSetByIDUC.Execute(setID)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
-	err = s.container.RemoveSetByIDUC.Execute(setID)
+	err = s.container.RemoveSetByIDUC.Execute(setID, expectedVersion)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		helpers.SetETag(w, set.Version)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -72,85 +60,93 @@ func (s *serviceImpl) DeleteSet(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *serviceImpl) CompleteSet(w http.ResponseWriter, r *http.Request) {
-	claims, ok := middlewares.FromContext(r.Context())
-	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-
 	setID, err := helpers.ParseInt64Param("id", w, r)
 	if err != nil {
 		return
 	}
 
-	set, err := s.container.GetSetByIDUC.Execute(setID)
+	expectedVersion, err := helpers.GetIfMatchVersion(w, r)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
-		return
-	}
-
-	if err = validator.ValidateAccessToExercise(s.container, claims.UserID, set.ExerciseID); err != nil {
-		helpers.WriteError(w, err)
 		return
 	}
 
-	err = s.container.CompleteByIDSetUC.Execute(setID)
+	set, err := s.container.CompleteByIDSetUC.Execute(setID, expectedVersion)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		if set != nil {
+			helpers.SetETag(w, set.Version)
+		}
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
+	helpers.SetETag(w, set.Version)
 	w.Header().Set("Content-Type", "application/json")
-	w.Write([]byte("{}"))
+	json.NewEncoder(w).Encode(&SetVersionDTO{ID: set.ID, Version: set.Version})
 }
 
-func (s *serviceImpl) ChangeSet(w http.ResponseWriter, r *http.Request) {
-	claims, ok := middlewares.FromContext(r.Context())
-	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
+type changeSetRequest struct {
+	FactReps    int     `json:"fact_reps"`
+	FactWeight  float32 `json:"fact_weight"`
+	FactMinutes int     `json:"fact_minutes"`
+	FactMeters  int     `json:"fact_meters"`
+}
+
+// верхние границы отсекают опечатки вроде лишнего нуля, а не реальные рекорды
+const (
+	maxSetReps    = 1000
+	maxSetWeight  = 1000
+	maxSetMinutes = 24 * 60
+	maxSetMeters  = 100_000
+)
+
+func (req *changeSetRequest) Validate() error {
+	var fields apierrors.Fields
+	fields.Range("fact_reps", float64(req.FactReps), 0, maxSetReps)
+	fields.Range("fact_weight", float64(req.FactWeight), 0, maxSetWeight)
+	fields.Range("fact_minutes", float64(req.FactMinutes), 0, maxSetMinutes)
+	fields.Range("fact_meters", float64(req.FactMeters), 0, maxSetMeters)
+	return fields.Err()
+}
 
+func (s *serviceImpl) ChangeSet(w http.ResponseWriter, r *http.Request) {
 	setID, err := helpers.ParseInt64Param("id", w, r)
 	if err != nil {
 		return
 	}
 
 	// Разбираем JSON из тела запроса
-	var input struct {
-		FactReps    int     `json:"fact_reps"`
-		FactWeight  float32 `json:"fact_weight"`
-		FactMinutes int     `json:"fact_minutes"`
-		FactMeters  int     `json:"fact_meters"`
-	}
+	var input changeSetRequest
 
-	if err = json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+	if !decodeJSON(w, r, &input) {
 		return
 	}
 
-	set, err := s.container.GetSetByIDUC.Execute(setID)
+	expectedVersion, err := helpers.GetIfMatchVersion(w, r)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
 		return
 	}
 
-	if err = validator.ValidateAccessToExercise(s.container, claims.UserID, set.ExerciseID); err != nil {
-		helpers.WriteError(w, err)
-		return
-	}
-
-	err = s.container.UpdateSetByIDUC.Execute(setID, &dto.NewSet{
-		NewReps:    int64(input.FactReps),
-		NewWeight:  float64(input.FactWeight),
-		NewMinutes: int64(input.FactMinutes),
-		NewMeters:  int64(input.FactMeters),
+	set, err := s.container.UpdateSetByIDUC.Execute(setID, &dto.NewSet{
+		NewReps:         int64(input.FactReps),
+		NewWeight:       float64(input.FactWeight),
+		NewMinutes:      int64(input.FactMinutes),
+		NewMeters:       int64(input.FactMeters),
+		ExpectedVersion: expectedVersion,
 	})
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		if set != nil {
+			helpers.SetETag(w, set.Version)
+		}
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
+	helpers.SetETag(w, set.Version)
 	w.Header().Set("Content-Type", "application/json")
-	w.Write([]byte("{}"))
+	json.NewEncoder(w).Encode(&SetVersionDTO{ID: set.ID, Version: set.Version})
+}
+
+type SetVersionDTO struct {
+	ID      int64 `json:"id"`
+	Version int64 `json:"version"`
 }
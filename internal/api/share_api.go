@@ -2,28 +2,32 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
-	"github.com/SaenkoDmitry/training-tg-bot/internal/api/validator"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/constants"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 )
 
+// noShareLimiterErr — маршрут подключен без ShareLimiterMiddleware
+var noShareLimiterErr = errors.New("share limiter is not configured")
+
 func (s *serviceImpl) CreateShareWorkout(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
 	rl, ok := middlewares.ShareLimiterFromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusInternalServerError)
+		apierrors.WriteError(w, r, noShareLimiterErr)
 		return
 	}
 
@@ -32,20 +36,15 @@ func (s *serviceImpl) CreateShareWorkout(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err = validator.ValidateAccessToWorkout(s.container, claims.UserID, workoutID); err != nil {
-		helpers.WriteError(w, err)
-		return
-	}
-
 	// Проверяем, что тренировка завершена
 	var workoutStat *dto.WorkoutProgress
 	workoutStat, err = s.container.ShowWorkoutProgressUC.Execute(workoutID)
 	if err != nil {
-		http.Error(w, "workout not found", http.StatusNotFound)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 	if workoutStat == nil || !workoutStat.Workout.Completed {
-		http.Error(w, "workout not completed", http.StatusBadRequest)
+		apierrors.Write(w, r, http.StatusConflict, apierrors.CodeWorkoutNotCompleted)
 		return
 	}
 
@@ -56,13 +55,13 @@ func (s *serviceImpl) CreateShareWorkout(w http.ResponseWriter, r *http.Request)
 	}
 
 	if !rl.Allow(claims.UserID) {
-		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		apierrors.Write(w, r, http.StatusTooManyRequests, apierrors.CodeRateLimited)
 		return
 	}
 
 	shareModel, err := s.container.CreateShareUC.Execute(workoutID)
 	if err != nil {
-		http.Error(w, "failed to create share", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 	json.NewEncoder(w).Encode(buildShareDTO(shareModel))
@@ -83,13 +82,13 @@ func getShareURL(domain, token string) string {
 func (s *serviceImpl) GetPublicWorkout(w http.ResponseWriter, r *http.Request) {
 	token := r.PathValue("token")
 	if token == "" {
-		http.Error(w, "token required", http.StatusBadRequest)
+		apierrors.WriteError(w, r, apierrors.InvalidParameter("token", apierrors.FieldRequired, "is required"))
 		return
 	}
 
 	shareDTO, err := s.container.GetShareUC.Execute(token)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		writeShareSocialError(w, r, err)
 		return
 	}
 
@@ -97,13 +96,13 @@ func (s *serviceImpl) GetPublicWorkout(w http.ResponseWriter, r *http.Request) {
 
 	progress, err := s.container.ShowWorkoutProgressUC.Execute(workoutID)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
 	stats, err := s.container.StatsWorkoutUC.Execute(workoutID)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
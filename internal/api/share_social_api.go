@@ -0,0 +1,177 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
+	commentusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/comments"
+	shareusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/share"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/comments"
+)
+
+func (s *serviceImpl) GetShareSocial(w http.ResponseWriter, r *http.Request) {
+	var viewerID int64
+	if claims, ok := middlewares.FromContext(r.Context()); ok {
+		viewerID = claims.UserID
+	}
+
+	social, err := s.container.GetShareSocialUC.Execute(r.PathValue("token"), viewerID)
+	if err != nil {
+		writeShareSocialError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(social)
+}
+
+type reactToShareRequest struct {
+	Emoji string `json:"emoji"`
+}
+
+func (s *serviceImpl) ReactToShare(w http.ResponseWriter, r *http.Request) {
+	claims, ok := s.allowShareActivity(w, r)
+	if !ok {
+		return
+	}
+
+	var body reactToShareRequest
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	social, err := s.container.ReactToShareUC.Execute(claims.UserID, r.PathValue("token"), body.Emoji)
+	if err != nil {
+		writeShareSocialError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(social)
+}
+
+func (s *serviceImpl) RemoveShareReaction(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	social, err := s.container.RemoveShareReactionUC.Execute(claims.UserID, r.PathValue("token"))
+	if err != nil {
+		writeShareSocialError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(social)
+}
+
+type commentShareRequest struct {
+	Text string `json:"text"`
+}
+
+func (s *serviceImpl) CommentShare(w http.ResponseWriter, r *http.Request) {
+	claims, ok := s.allowShareActivity(w, r)
+	if !ok {
+		return
+	}
+
+	var body commentShareRequest
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	item, err := s.container.CommentShareUC.Execute(claims.UserID, r.PathValue("token"), body.Text)
+	if err != nil {
+		writeShareSocialError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(item)
+}
+
+// DeleteShareComment — автор удаляет свой комментарий, владелец тренировки — любой
+func (s *serviceImpl) DeleteShareComment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	commentID, err := helpers.ParseInt64Param("comment_id", w, r)
+	if err != nil {
+		return
+	}
+
+	if err = s.container.DeleteWorkoutCommentUC.Execute(claims.UserID, commentID); err != nil {
+		writeShareSocialError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type setShareCommentsEnabledRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+func (s *serviceImpl) SetShareCommentsEnabled(w http.ResponseWriter, r *http.Request) {
+	workoutID, err := helpers.ParseInt64Param("workout_id", w, r)
+	if err != nil {
+		return
+	}
+
+	var body setShareCommentsEnabledRequest
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	if err = s.container.SetShareCommentsEnabledUC.Execute(workoutID, body.Enabled); err != nil {
+		writeShareSocialError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// allowShareActivity — реакции и комментарии ограничиваем тем же лимитером, что и создание ссылок
+func (s *serviceImpl) allowShareActivity(w http.ResponseWriter, r *http.Request) (*middlewares.Claims, bool) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return nil, false
+	}
+	rl, ok := middlewares.ShareLimiterFromContext(r.Context())
+	if !ok {
+		apierrors.WriteError(w, r, noShareLimiterErr)
+		return nil, false
+	}
+	if !rl.Allow(claims.UserID) {
+		apierrors.Write(w, r, http.StatusTooManyRequests, apierrors.CodeRateLimited)
+		return nil, false
+	}
+	return claims, true
+}
+
+func writeShareSocialError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, shareusecases.InvalidReactionErr):
+		err = apierrors.InvalidField(err, "emoji", apierrors.FieldNotAllowed)
+	case errors.Is(err, commentusecases.EmptyCommentErr):
+		err = apierrors.InvalidField(err, "text", apierrors.FieldRequired)
+	case errors.Is(err, commentusecases.TooLongCommentErr):
+		err = apierrors.InvalidField(err, "text", apierrors.FieldTooLong)
+	case errors.Is(err, shareusecases.CommentsDisabledErr):
+		err = apierrors.Wrap(err, http.StatusForbidden, apierrors.CodeCommentsDisabled)
+	case errors.Is(err, commentusecases.NotAllowedErr):
+		err = apierrors.Wrap(err, http.StatusForbidden, apierrors.CodeForbidden)
+	case errors.Is(err, shareusecases.NotFoundShareErr),
+		errors.Is(err, comments.NotFoundCommentErr):
+		err = apierrors.Wrap(err, http.StatusNotFound, apierrors.CodeNotFound)
+	}
+	apierrors.WriteError(w, r, err)
+}
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	socialusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/social"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/follows"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+func (s *serviceImpl) GetFeed(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	offset, limit := helpers.GetOffsetLimit(r, 20, 50)
+	result, err := s.container.GetFeedUC.Execute(claims.UserID, offset, limit)
+	if err != nil {
+		writeSocialError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *serviceImpl) GetPrivacy(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	settings, err := s.container.GetPrivacyUC.Execute(claims.UserID)
+	if err != nil {
+		writeSocialError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+func (s *serviceImpl) UpdatePrivacy(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	var body dto.PrivacySettings
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	if err := s.container.UpdatePrivacyUC.Execute(claims.UserID, body); err != nil {
+		writeSocialError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+func (s *serviceImpl) GetFollowers(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	offset, limit := helpers.GetOffsetLimit(r, 20, 100)
+	pending := r.URL.Query().Get("pending") == "true"
+	result, err := s.container.FindFollowersUC.Execute(claims.UserID, pending, offset, limit)
+	if err != nil {
+		writeSocialError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *serviceImpl) GetFollowing(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	offset, limit := helpers.GetOffsetLimit(r, 20, 100)
+	result, err := s.container.FindFollowingUC.Execute(claims.UserID, offset, limit)
+	if err != nil {
+		writeSocialError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *serviceImpl) Follow(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	userID, err := helpers.ParseInt64Param("user_id", w, r)
+	if err != nil {
+		return
+	}
+
+	status, err := s.container.FollowUC.Execute(claims.UserID, userID)
+	if err != nil {
+		writeSocialError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&FollowStatusDTO{Status: status})
+}
+
+type FollowStatusDTO struct {
+	Status string `json:"status"` // accepted или pending, если автор подтверждает подписчиков вручную
+}
+
+func (s *serviceImpl) Unfollow(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	userID, err := helpers.ParseInt64Param("user_id", w, r)
+	if err != nil {
+		return
+	}
+
+	if err = s.container.UnfollowUC.Execute(claims.UserID, userID); err != nil {
+		writeSocialError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *serviceImpl) AcceptFollower(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	followerID, err := helpers.ParseInt64Param("user_id", w, r)
+	if err != nil {
+		return
+	}
+
+	if err = s.container.AcceptFollowerUC.Execute(claims.UserID, followerID); err != nil {
+		writeSocialError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *serviceImpl) RemoveFollower(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	followerID, err := helpers.ParseInt64Param("user_id", w, r)
+	if err != nil {
+		return
+	}
+
+	if err = s.container.RemoveFollowerUC.Execute(claims.UserID, followerID); err != nil {
+		writeSocialError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *serviceImpl) GetSocialProfile(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	userID, err := helpers.ParseInt64Param("user_id", w, r)
+	if err != nil {
+		return
+	}
+
+	profile, err := s.container.GetSocialProfileUC.Execute(claims.UserID, userID)
+	if err != nil {
+		writeSocialError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profile)
+}
+
+func writeSocialError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, socialusecases.SelfFollowErr):
+		err = apierrors.Wrap(err, http.StatusUnprocessableEntity, apierrors.CodeSelfAction)
+	case errors.Is(err, socialusecases.InvalidVisibilityErr):
+		err = apierrors.Wrap(err, http.StatusUnprocessableEntity, apierrors.CodeValidationFailed)
+	case errors.Is(err, follows.NotFoundFollowErr),
+		errors.Is(err, users.NotFoundUserErr):
+		err = apierrors.Wrap(err, http.StatusNotFound, apierrors.CodeNotFound)
+	}
+	apierrors.WriteError(w, r, err)
+}
@@ -1,23 +1,26 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
 )
 
 func (s *serviceImpl) DownloadExcelWorkoutsStats(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
 	resp, err := s.container.ExportWorkoutsToExcelUC.Execute(claims.UserID)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -28,6 +31,34 @@ func (s *serviceImpl) DownloadExcelWorkoutsStats(w http.ResponseWriter, r *http.
 
 	// Записываем данные в ResponseWriter
 	if _, err = w.Write(resp.Bytes()); err != nil {
-		log.Printf("error writing response: %v", err)
+		slog.ErrorContext(r.Context(), "write excel response", "err", err)
 	}
 }
+
+func (s *serviceImpl) GetWeeklyStats(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	from, err := helpers.ParseTimeQuery(r, "from")
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+	to, err := helpers.ParseTimeQuery(r, "to")
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	result, err := s.container.WeeklyStatsUC.Execute(claims.UserID, from, to)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/offlinesync"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+)
+
+func (s *serviceImpl) Sync(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	var req dto.SyncRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// доступ к сущностям проверяется для каждой мутации отдельно внутри use case
+	res, err := s.container.ApplySyncUC.Execute(claims.UserID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, offlinesync.ErrInvalidCursor):
+			err = apierrors.InvalidField(err, "cursor", apierrors.FieldInvalidFormat)
+		case errors.Is(err, offlinesync.ErrTooManyMutations):
+			err = apierrors.InvalidField(err, "mutations", apierrors.FieldTooLong)
+		}
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
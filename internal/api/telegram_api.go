@@ -4,8 +4,9 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
 	"net/http"
 	"net/url"
@@ -14,50 +15,45 @@ import (
 	"strconv"
 	"strings"
 	"time"
-
-	"github.com/golang-jwt/jwt/v4"
 )
 
 var (
-	botToken  = os.Getenv("TELEGRAM_TOKEN")
-	jwtSecret = []byte(os.Getenv("JWT_SECRET"))
+	botToken = os.Getenv("TELEGRAM_TOKEN")
 )
 
+// telegramAuthMaxAge — сколько живут данные виджета входа: перехваченную подпись нельзя
+// предъявлять бесконечно, а привязка по ней еще и вливает чужой аккаунт
+const telegramAuthMaxAge = 5 * time.Minute
+
 func (s *serviceImpl) TelegramLoginHandler(w http.ResponseWriter, r *http.Request) {
 
 	var tgUser dto.TelegramUser
 
-	if err := json.NewDecoder(r.Body).Decode(&tgUser); err != nil {
-		http.Error(w, "bad request", 400)
+	if !decodeJSON(w, r, &tgUser) {
 		return
 	}
 
-	if !verifyTelegram(tgUser, botToken) {
-		http.Error(w, "invalid telegram hash", http.StatusUnauthorized)
+	if !verifyTelegram(tgUser, botToken, time.Now()) {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeInvalidTelegramAuth)
 		return
 	}
 
 	user, err := s.container.GetOrCreateUserByTelegramUC.Execute(tgUser)
 	if user == nil || err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
-	claims := jwt.MapClaims{
-		"user_id": user.ID,
-		"exp":     time.Now().Add(7 * 24 * time.Hour).Unix(),
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, _ := token.SignedString(jwtSecret)
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{
-		"token": signed,
-	})
+	s.startAuthSession(w, r, user.ID)
 }
 
-func verifyTelegram(user dto.TelegramUser, botToken string) bool {
+// verifyTelegram проверяет подпись данных виджета и их свежесть по auth_date
+func verifyTelegram(user dto.TelegramUser, botToken string, now time.Time) bool {
+	authAt := time.Unix(user.AuthDate, 0)
+	if now.Sub(authAt) > telegramAuthMaxAge || authAt.Sub(now) > time.Minute {
+		return false
+	}
+
 	data := map[string]string{
 		"id":         strconv.FormatInt(user.ID, 10),
 		"first_name": user.FirstName,
@@ -90,25 +86,25 @@ func verifyTelegram(user dto.TelegramUser, botToken string) bool {
 	mac.Write([]byte(checkString))
 	expected := hex.EncodeToString(mac.Sum(nil))
 
-	return expected == user.Hash
+	return hmac.Equal([]byte(expected), []byte(user.Hash))
 }
 
 func (s *serviceImpl) TelegramRedirectHandler(w http.ResponseWriter, r *http.Request) {
 	origin := r.URL.Query().Get("origin")
 
 	if origin == "" {
-		http.Error(w, "missing params", http.StatusBadRequest)
+		apierrors.WriteError(w, r, apierrors.InvalidParameter("origin", apierrors.FieldRequired, "is required"))
 		return
 	}
 
 	if !s.isAllowedOrigin(origin) {
-		http.Error(w, "invalid origin", http.StatusForbidden)
+		apierrors.Write(w, r, http.StatusForbidden, apierrors.CodeInvalidOrigin)
 		return
 	}
 
 	botID := os.Getenv("TELEGRAM_BOT_ID")
 	if botID == "" {
-		http.Error(w, "bot id not configured", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, errors.New("TELEGRAM_BOT_ID is not configured"))
 		return
 	}
 
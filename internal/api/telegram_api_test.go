@@ -0,0 +1,43 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+)
+
+const testBotToken = "123:token"
+
+// signTelegram подписывает данные так же, как виджет входа Telegram
+func signTelegram(user dto.TelegramUser) dto.TelegramUser {
+	check := fmt.Sprintf("auth_date=%d\nfirst_name=%s\nid=%d\nusername=%s", user.AuthDate, user.FirstName, user.ID, user.Username)
+	secret := sha256.Sum256([]byte(testBotToken))
+	mac := hmac.New(sha256.New, secret[:])
+	mac.Write([]byte(check))
+	user.Hash = hex.EncodeToString(mac.Sum(nil))
+	return user
+}
+
+func TestVerifyTelegram(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	user := func(authAt time.Time) dto.TelegramUser {
+		return signTelegram(dto.TelegramUser{ID: 42, FirstName: "Ivan", Username: "ivan", AuthDate: authAt.Unix()})
+	}
+
+	assert.True(t, verifyTelegram(user(now.Add(-time.Minute)), testBotToken, now))
+	assert.False(t, verifyTelegram(user(now.Add(-telegramAuthMaxAge-time.Second)), testBotToken, now),
+		"перехваченные данные нельзя предъявить позже")
+	assert.False(t, verifyTelegram(user(now.Add(time.Hour)), testBotToken, now), "auth_date из будущего")
+
+	forged := user(now)
+	forged.ID = 43
+	assert.False(t, verifyTelegram(forged, testBotToken, now), "подпись не сходится")
+	assert.False(t, verifyTelegram(user(now), "other:token", now), "чужой бот")
+}
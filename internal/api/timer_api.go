@@ -2,35 +2,51 @@ package api
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/authz"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
-	"gorm.io/gorm"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/ownership"
 )
 
+type startTimerRequest struct {
+	WorkoutID int64 `json:"workout_id"`
+	Seconds   int   `json:"seconds"`
+}
+
+// maxTimerSeconds — отдых дольше часа почти наверняка опечатка
+const maxTimerSeconds = 60 * 60
+
+func (req *startTimerRequest) Validate() error {
+	var fields apierrors.Fields
+	fields.Positive("workout_id", req.WorkoutID)
+	fields.Range("seconds", float64(req.Seconds), 1, maxTimerSeconds)
+	return fields.Err()
+}
+
 func (s *serviceImpl) StartTimer(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
-	var req struct {
-		WorkoutID int64 `json:"workout_id"`
-		Seconds   int   `json:"seconds"`
+	var req startTimerRequest
+	if !decodeJSON(w, r, &req) {
+		return
 	}
 
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+	// таймер публикует события в поток тренировки, поэтому чужая тренировка запрещена
+	if err := s.authz.Check(claims.UserID, authz.Owner(ownership.Workout), req.WorkoutID); err != nil {
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
 	timer, err := s.timerManager.Start(claims.UserID, req.WorkoutID, req.Seconds)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -40,7 +56,7 @@ func (s *serviceImpl) StartTimer(w http.ResponseWriter, r *http.Request) {
 func (s *serviceImpl) CancelTimer(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
@@ -51,14 +67,10 @@ func (s *serviceImpl) CancelTimer(w http.ResponseWriter, r *http.Request) {
 
 	err = s.timerManager.Cancel(timerID, claims.UserID)
 	if err != nil {
-		switch {
-		case errors.Is(err, gorm.ErrRecordNotFound):
-			w.WriteHeader(http.StatusNotFound)
-		case err.Error() == "forbidden":
-			w.WriteHeader(http.StatusForbidden)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
+		if err.Error() == "forbidden" {
+			err = apierrors.Wrap(err, http.StatusForbidden, apierrors.CodeForbidden)
 		}
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
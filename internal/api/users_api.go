@@ -4,49 +4,51 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
 )
 
 func (s *serviceImpl) GetIcon(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
 	user, err := s.container.GetUserByIDUC.Execute(claims.UserID)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
-	json.NewEncoder(w).Encode(struct {
-		Name string `json:"name"`
-	}{
-		Name: user.Icon,
-	})
+	json.NewEncoder(w).Encode(&IconDTO{Name: user.Icon})
+}
+
+type IconDTO struct {
+	Name string `json:"name"`
+}
+
+type changeIconRequest struct {
+	Name string `json:"name"`
 }
 
 func (s *serviceImpl) ChangeIcon(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
 	// Разбираем JSON из тела запроса
-	var input struct {
-		Name string `json:"name"`
-	}
+	var input changeIconRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+	if !decodeJSON(w, r, &input) {
 		return
 	}
 
 	err := s.container.ChangeIconUC.Execute(claims.UserID, input.Name)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -3,43 +3,33 @@ package validator
 import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/api/errorslist"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/rbac"
 )
 
-func checkAccess(userID, entityID int64) error {
-	if userID != entityID {
-		return errorslist.ErrAccessDenied
+// ValidateAccessToAthlete пускает к данным спортсмена его самого и тренера с действующей связью
+// и нужной областью доступа
+func ValidateAccessToAthlete(container *usecase.Container, userID, athleteID int64, scope string) error {
+	if userID == athleteID {
+		return nil
 	}
-	return nil
-}
-
-func ValidateAccessToProgram(container *usecase.Container, userID int64, programID int64) error {
-	program, err := container.GetProgramUC.Execute(programID, userID)
+	allowed, err := container.CheckCoachAccessUC.Execute(userID, athleteID, scope)
 	if err != nil {
-		return errorslist.ErrInternalMsg
+		return err
 	}
-	return checkAccess(program.UserID, userID)
-}
-
-func ValidateAccessToExercise(container *usecase.Container, userID int64, exerciseID int64) error {
-	ex, err := container.GetExerciseUC.Execute(exerciseID)
-	if err != nil {
-		return errorslist.ErrInternalMsg
+	if !allowed {
+		return errorslist.ErrAccessDenied
 	}
-	return checkAccess(ex.Exercise.WorkoutDay.UserID, userID)
+	return nil
 }
 
-func ValidateAccessToWorkout(container *usecase.Container, userID int64, workoutID int64) error {
-	progress, err := container.ShowWorkoutProgressUC.Execute(workoutID)
+// ValidatePermission проверяет право по роли пользователя, а не владение конкретной сущностью
+func ValidatePermission(container *usecase.Container, userID int64, permission rbac.Permission) error {
+	user, err := container.GetUserByIDUC.Execute(userID)
 	if err != nil {
-		return errorslist.ErrInternalMsg
+		return err
 	}
-	return checkAccess(progress.Workout.UserID, userID)
-}
-
-func ValidateAccessToMeasurement(container *usecase.Container, userID int64, measurementID int64) error {
-	measurement, err := container.GetMeasurementByIDUC.Execute(measurementID)
-	if err != nil {
-		return errorslist.ErrInternalMsg
+	if !rbac.Allowed(user, permission) {
+		return errorslist.ErrAccessDenied
 	}
-	return checkAccess(measurement.UserID, userID)
+	return nil
 }
@@ -8,7 +8,9 @@ import (
 
 func (s *serviceImpl) GetVapidKey(w http.ResponseWriter, r *http.Request) {
 	key := os.Getenv("VAPID_PUBLIC_KEY")
-	json.NewEncoder(w).Encode(map[string]string{
-		"public_key": key,
-	})
+	json.NewEncoder(w).Encode(&VapidKeyDTO{PublicKey: key})
+}
+
+type VapidKeyDTO struct {
+	PublicKey string `json:"public_key"`
 }
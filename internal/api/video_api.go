@@ -5,45 +5,51 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
 )
 
 func (s *serviceImpl) LinkVideo(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
 	url := r.URL.Query().Get("url")
 	if url == "" {
-		http.Error(w, "missing url", 400)
+		apierrors.WriteError(w, r, apierrors.InvalidParameter("url", apierrors.FieldRequired, "is required"))
 		return
 	}
 
 	token, _ := generateVideoToken(url, claims.UserID)
 
-	json.NewEncoder(w).Encode(map[string]string{
-		"url": "/api/video/stream?token=" + token,
-	})
+	json.NewEncoder(w).Encode(&URLDTO{URL: "/api/video/stream?token=" + token})
+}
+
+// URLDTO — ссылка, по которой клиент переходит дальше
+type URLDTO struct {
+	URL string `json:"url"`
 }
 
 func (s *serviceImpl) StreamVideo(w http.ResponseWriter, r *http.Request) {
 	token := r.URL.Query().Get("token")
 	if token == "" {
-		http.Error(w, "missing token", 401)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
 	parts := strings.Split(token, ".")
 	if len(parts) != 2 {
-		http.Error(w, "invalid token", 401)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
@@ -55,7 +61,7 @@ func (s *serviceImpl) StreamVideo(w http.ResponseWriter, r *http.Request) {
 	expected := mac.Sum(nil)
 
 	if !hmac.Equal(signature, expected) {
-		http.Error(w, "invalid signature", 401)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
@@ -63,12 +69,12 @@ func (s *serviceImpl) StreamVideo(w http.ResponseWriter, r *http.Request) {
 	json.Unmarshal(dataToken, &payload)
 
 	if time.Now().Unix() > payload.Expires {
-		http.Error(w, "expired", 401)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 	publicURL := payload.URL
 	if publicURL == "" {
-		http.Error(w, "missing url", http.StatusBadRequest)
+		apierrors.WriteError(w, r, apierrors.InvalidParameter("token", apierrors.FieldInvalidFormat, "token has no url"))
 		return
 	}
 
@@ -80,7 +86,7 @@ func (s *serviceImpl) StreamVideo(w http.ResponseWriter, r *http.Request) {
 
 	apiReq, err := http.NewRequest("GET", apiURL, nil)
 	if err != nil {
-		http.Error(w, err.Error(), 500)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -90,13 +96,14 @@ func (s *serviceImpl) StreamVideo(w http.ResponseWriter, r *http.Request) {
 
 	apiResp, err := client.Do(apiReq)
 	if err != nil {
-		http.Error(w, err.Error(), 500)
+		apierrors.WriteError(w, r, apierrors.Wrap(err, http.StatusBadGateway, apierrors.CodeUpstream))
 		return
 	}
 	defer apiResp.Body.Close()
 
 	if apiResp.StatusCode != http.StatusOK {
-		http.Error(w, "failed to get download link", apiResp.StatusCode)
+		apierrors.WriteError(w, r, apierrors.Wrap(fmt.Errorf("yandex disk download link: status %d", apiResp.StatusCode),
+			http.StatusBadGateway, apierrors.CodeUpstream))
 		return
 	}
 
@@ -105,12 +112,13 @@ func (s *serviceImpl) StreamVideo(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := json.NewDecoder(apiResp.Body).Decode(&data); err != nil {
-		http.Error(w, err.Error(), 500)
+		apierrors.WriteError(w, r, apierrors.Wrap(err, http.StatusBadGateway, apierrors.CodeUpstream))
 		return
 	}
 
 	if data.Href == "" {
-		http.Error(w, "empty download link", 500)
+		apierrors.WriteError(w, r, apierrors.Wrap(errors.New("yandex disk returned empty download link"),
+			http.StatusBadGateway, apierrors.CodeUpstream))
 		return
 	}
 
@@ -119,7 +127,7 @@ func (s *serviceImpl) StreamVideo(w http.ResponseWriter, r *http.Request) {
 	// ---------------------------
 	videoReq, err := http.NewRequest("GET", data.Href, nil)
 	if err != nil {
-		http.Error(w, err.Error(), 500)
+		apierrors.WriteError(w, r, apierrors.Wrap(err, http.StatusBadGateway, apierrors.CodeUpstream))
 		return
 	}
 
@@ -133,7 +141,7 @@ func (s *serviceImpl) StreamVideo(w http.ResponseWriter, r *http.Request) {
 
 	videoResp, err := client.Do(videoReq)
 	if err != nil {
-		http.Error(w, err.Error(), 500)
+		apierrors.WriteError(w, r, apierrors.Wrap(err, http.StatusBadGateway, apierrors.CodeUpstream))
 		return
 	}
 	defer videoResp.Body.Close()
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	webhookusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/webhooks"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/webhooks"
+)
+
+func (s *serviceImpl) GetMyWebhooks(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	result, err := s.container.FindMyWebhooksUC.Execute(claims.UserID)
+	if err != nil {
+		writeWebhookError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *serviceImpl) GetWebhookEventTypes(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.container.FindWebhookEventTypesUC.Execute())
+}
+
+func (s *serviceImpl) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	var body dto.CreateWebhook
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	webhook, err := s.container.CreateWebhookUC.Execute(claims.UserID, body)
+	if err != nil {
+		writeWebhookError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(webhook)
+}
+
+func (s *serviceImpl) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	webhookID, err := helpers.ParseInt64Param("webhook_id", w, r)
+	if err != nil {
+		return
+	}
+
+	var body dto.UpdateWebhook
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	webhook, err := s.container.UpdateWebhookUC.Execute(claims.UserID, webhookID, body)
+	if err != nil {
+		writeWebhookError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(webhook)
+}
+
+func (s *serviceImpl) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	webhookID, err := helpers.ParseInt64Param("webhook_id", w, r)
+	if err != nil {
+		return
+	}
+
+	if err = s.container.DeleteWebhookUC.Execute(claims.UserID, webhookID); err != nil {
+		writeWebhookError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *serviceImpl) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	webhookID, err := helpers.ParseInt64Param("webhook_id", w, r)
+	if err != nil {
+		return
+	}
+
+	offset, limit := helpers.GetOffsetLimit(r, 20, 100)
+	result, err := s.container.FindWebhookDeliveriesUC.Execute(claims.UserID, webhookID, offset, limit)
+	if err != nil {
+		writeWebhookError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *serviceImpl) SendTestWebhook(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	webhookID, err := helpers.ParseInt64Param("webhook_id", w, r)
+	if err != nil {
+		return
+	}
+
+	delivery, err := s.container.SendTestWebhookUC.Execute(claims.UserID, webhookID)
+	if err != nil {
+		writeWebhookError(w, r, err)
+		return
+	}
+
+	// неудачная доставка — тоже результат: статус и ошибка получателя в теле ответа
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(delivery)
+}
+
+func writeWebhookError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, webhookusecases.InvalidURLErr):
+		err = apierrors.InvalidField(err, "url", apierrors.FieldInvalidFormat)
+	case errors.Is(err, webhookusecases.InvalidEventTypesErr):
+		err = apierrors.InvalidField(err, "event_types", apierrors.FieldNotAllowed)
+	case errors.Is(err, webhookusecases.InvalidSecretErr):
+		err = apierrors.InvalidField(err, "secret", apierrors.FieldOutOfRange)
+	case errors.Is(err, webhookusecases.NotOwnerErr):
+		err = apierrors.Wrap(err, http.StatusForbidden, apierrors.CodeForbidden)
+	case errors.Is(err, webhooks.NotFoundWebhookErr):
+		err = apierrors.Wrap(err, http.StatusNotFound, apierrors.CodeNotFound)
+	case errors.Is(err, webhookusecases.TooManyWebhooksErr):
+		err = apierrors.Wrap(err, http.StatusConflict, apierrors.CodeLimitReached)
+	}
+	apierrors.WriteError(w, r, err)
+}
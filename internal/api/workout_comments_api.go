@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
+	commentusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/comments"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/comments"
+)
+
+func (s *serviceImpl) GetWorkoutComments(w http.ResponseWriter, r *http.Request) {
+	workoutID, err := helpers.ParseInt64Param("workout_id", w, r)
+	if err != nil {
+		return
+	}
+
+	items, err := s.container.FindWorkoutCommentsUC.Execute(workoutID)
+	if err != nil {
+		writeCommentError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+}
+
+type addWorkoutCommentRequest struct {
+	Text string `json:"text"`
+}
+
+func (s *serviceImpl) AddWorkoutComment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	workoutID, err := helpers.ParseInt64Param("workout_id", w, r)
+	if err != nil {
+		return
+	}
+
+	var body addWorkoutCommentRequest
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	item, err := s.container.AddWorkoutCommentUC.Execute(claims.UserID, workoutID, body.Text, false)
+	if err != nil {
+		writeCommentError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(item)
+}
+
+func (s *serviceImpl) DeleteWorkoutComment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+	commentID, err := helpers.ParseInt64Param("comment_id", w, r)
+	if err != nil {
+		return
+	}
+
+	if err = s.container.DeleteWorkoutCommentUC.Execute(claims.UserID, commentID); err != nil {
+		writeCommentError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeCommentError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, commentusecases.EmptyCommentErr):
+		err = apierrors.InvalidField(err, "text", apierrors.FieldRequired)
+	case errors.Is(err, commentusecases.TooLongCommentErr):
+		err = apierrors.InvalidField(err, "text", apierrors.FieldTooLong)
+	case errors.Is(err, commentusecases.NotAllowedErr):
+		err = apierrors.Wrap(err, http.StatusForbidden, apierrors.CodeForbidden)
+	case errors.Is(err, comments.NotFoundCommentErr):
+		err = apierrors.Wrap(err, http.StatusNotFound, apierrors.CodeNotFound)
+	}
+	apierrors.WriteError(w, r, err)
+}
@@ -2,27 +2,30 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/authz"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/api/helpers"
-	"github.com/SaenkoDmitry/training-tg-bot/internal/api/validator"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	workoutusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/workouts"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/middlewares"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/ownership"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
 )
 
 func (s *serviceImpl) GetAllWorkouts(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
-	offset, limit := helpers.GetOffsetLimit(r, 10, 50)
-
-	res, err := s.container.FindMyWorkoutsUC.Execute(claims.UserID, offset, limit)
+	res, err := s.findWorkoutsPage(r, claims.UserID)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -30,43 +33,65 @@ func (s *serviceImpl) GetAllWorkouts(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(res)
 }
 
+// findWorkoutsPage — страница списка тренировок по cursor, а без него по offset, как раньше
+func (s *serviceImpl) findWorkoutsPage(r *http.Request, userID int64) (*dto.ShowMyWorkoutsResult, error) {
+	offset, limit := helpers.GetOffsetLimit(r, 10, 50)
+
+	cursor := r.URL.Query().Get("cursor")
+	if cursor == "" {
+		return s.container.FindMyWorkoutsUC.Execute(userID, offset, limit)
+	}
+	res, err := s.container.FindMyWorkoutsUC.ExecuteAfter(userID, cursor, limit)
+	if errors.Is(err, workouts.InvalidCursorErr) {
+		return nil, apierrors.InvalidParameter("cursor", apierrors.FieldInvalidFormat, "must be next_cursor from a previous response")
+	}
+	return res, err
+}
+
+type startWorkoutRequest struct {
+	DayTypeID int64 `json:"day_type_id"`
+}
+
+func (req *startWorkoutRequest) Validate() error {
+	var fields apierrors.Fields
+	fields.Positive("day_type_id", req.DayTypeID)
+	return fields.Err()
+}
+
 func (s *serviceImpl) StartWorkout(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
 	// Разбираем JSON из тела запроса
-	var input struct {
-		DayTypeID int64 `json:"day_type_id"`
-	}
+	var input startWorkoutRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+	if !decodeJSON(w, r, &input) {
 		return
 	}
 
 	day, err := s.container.GetDayTypeUC.Execute(input.DayTypeID)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
-	if err = validator.ValidateAccessToProgram(s.container, claims.UserID, day.WorkoutProgramID); err != nil {
-		helpers.WriteError(w, err)
+	if err = s.authz.Check(claims.UserID, authz.Owner(ownership.Program), day.WorkoutProgramID); err != nil {
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
 	createdWorkout, err := s.container.CreateWorkoutUC.Execute(claims.UserID, input.DayTypeID) // создаем тренировку
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
 	_, err = s.container.StartWorkoutUC.Execute(createdWorkout.WorkoutID) // создаем сессию
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
@@ -79,32 +104,22 @@ type StartWorkoutDTO struct {
 }
 
 func (s *serviceImpl) ReadWorkout(w http.ResponseWriter, r *http.Request) {
-	claims, ok := middlewares.FromContext(r.Context())
-	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		return
-	}
-
 	workoutIDStr := r.PathValue("workout_id")
 	workoutID, _ := strconv.ParseInt(workoutIDStr, 10, 64)
 
-	if err := validator.ValidateAccessToWorkout(s.container, claims.UserID, workoutID); err != nil {
-		helpers.WriteError(w, err)
-		return
-	}
-
 	progress, err := s.container.ShowWorkoutProgressUC.Execute(workoutID)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
 	stats, err := s.container.StatsWorkoutUC.Execute(workoutID)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
+	helpers.SetETag(w, progress.Workout.Version)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(&ReadWorkoutDTO{Progress: progress, Stats: stats})
 }
@@ -116,9 +131,80 @@ type ReadWorkoutDTO struct {
 }
 
 func (s *serviceImpl) DeleteWorkout(w http.ResponseWriter, r *http.Request) {
+	workoutID, err := helpers.ParseInt64Param("workout_id", w, r)
+	if err != nil {
+		return
+	}
+
+	err = s.container.DeleteWorkoutUC.Execute(workoutID)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte("{}"))
+}
+
+func (s *serviceImpl) FinishWorkout(w http.ResponseWriter, r *http.Request) {
+	workoutID, err := helpers.ParseInt64Param("workout_id", w, r)
+	if err != nil {
+		return
+	}
+
+	expectedVersion, err := helpers.GetIfMatchVersion(w, r)
+	if err != nil {
+		return
+	}
+
+	res, err := s.container.FinishWorkoutUC.Execute(workoutID, expectedVersion)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	helpers.SetETag(w, res.Version)
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte("{}"))
+}
+
+func (s *serviceImpl) LogPastWorkout(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	var input dto.LogPastWorkout
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	day, err := s.container.GetDayTypeUC.Execute(input.DayTypeID)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	if err = s.authz.Check(claims.UserID, authz.Owner(ownership.Program), day.WorkoutProgramID); err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	createdWorkout, err := s.container.LogPastWorkoutUC.Execute(claims.UserID, input.DayTypeID, input.StartedAt, input.EndedAt)
+	if err != nil {
+		writeWorkoutEditError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&StartWorkoutDTO{WorkoutID: createdWorkout.WorkoutID})
+}
+
+func (s *serviceImpl) ReopenWorkout(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
@@ -127,25 +213,26 @@ func (s *serviceImpl) DeleteWorkout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = validator.ValidateAccessToWorkout(s.container, claims.UserID, workoutID); err != nil {
-		helpers.WriteError(w, err)
+	expectedVersion, err := helpers.GetIfMatchVersion(w, r)
+	if err != nil {
 		return
 	}
 
-	err = s.container.DeleteWorkoutUC.Execute(workoutID)
+	res, err := s.container.ReopenWorkoutUC.Execute(claims.UserID, workoutID, expectedVersion)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		writeWorkoutEditError(w, r, err)
 		return
 	}
 
+	helpers.SetETag(w, res.Version)
 	w.Header().Set("Content-Type", "application/json")
-	w.Write([]byte("{}"))
+	json.NewEncoder(w).Encode(res)
 }
 
-func (s *serviceImpl) FinishWorkout(w http.ResponseWriter, r *http.Request) {
+func (s *serviceImpl) UpdateWorkoutTimes(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middlewares.FromContext(r.Context())
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 		return
 	}
 
@@ -154,17 +241,76 @@ func (s *serviceImpl) FinishWorkout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = validator.ValidateAccessToWorkout(s.container, claims.UserID, workoutID); err != nil {
-		helpers.WriteError(w, err)
+	expectedVersion, err := helpers.GetIfMatchVersion(w, r)
+	if err != nil {
 		return
 	}
 
-	_, err = s.container.FinishWorkoutUC.Execute(workoutID)
+	var input dto.UpdateWorkoutTimes
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	res, err := s.container.UpdateWorkoutTimesUC.Execute(claims.UserID, workoutID, expectedVersion, &input)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		writeWorkoutEditError(w, r, err)
 		return
 	}
 
+	helpers.SetETag(w, res.Version)
 	w.Header().Set("Content-Type", "application/json")
-	w.Write([]byte("{}"))
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *serviceImpl) GetWorkoutEdits(w http.ResponseWriter, r *http.Request) {
+	workoutID, err := helpers.ParseInt64Param("workout_id", w, r)
+	if err != nil {
+		return
+	}
+
+	res, err := s.container.FindWorkoutEditsUC.Execute(workoutID)
+	if err != nil {
+		apierrors.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *serviceImpl) UndoAutoFinishWorkout(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middlewares.FromContext(r.Context())
+	if !ok {
+		apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
+		return
+	}
+
+	workoutID, err := helpers.ParseInt64Param("workout_id", w, r)
+	if err != nil {
+		return
+	}
+
+	res, err := s.container.UndoAutoFinishUC.Execute(claims.UserID, workoutID)
+	if err != nil {
+		writeWorkoutEditError(w, r, err)
+		return
+	}
+
+	helpers.SetETag(w, res.Version)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func writeWorkoutEditError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, workoutusecases.InvalidTimesErr):
+		err = apierrors.Wrap(err, http.StatusUnprocessableEntity, apierrors.CodeValidationFailed)
+	case errors.Is(err, workoutusecases.NotCompletedErr):
+		err = apierrors.Wrap(err, http.StatusConflict, apierrors.CodeWorkoutNotCompleted)
+	case errors.Is(err, workoutusecases.NotAutoFinishedErr):
+		err = apierrors.Wrap(err, http.StatusConflict, apierrors.CodeWorkoutNotAutoFinished)
+	case errors.Is(err, workoutusecases.NotFoundSpecificErr):
+		err = apierrors.Wrap(err, http.StatusNotFound, apierrors.CodeNotFound)
+	}
+	apierrors.WriteError(w, r, err)
 }
@@ -1,128 +1,75 @@
 package api
 
 import (
-	"encoding/json"
-	"fmt"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
-	"github.com/golang-jwt/jwt/v4"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/oauth"
 	"net/http"
-	"net/url"
-	"os"
-	"time"
 )
 
 func (s *serviceImpl) YandexRedirectHandler(w http.ResponseWriter, r *http.Request) {
 	origin := r.URL.Query().Get("origin")
 	state := r.URL.Query().Get("state")
 
-	if origin == "" || state == "" {
-		http.Error(w, "missing params", http.StatusBadRequest)
+	if origin == "" {
+		apierrors.WriteError(w, r, apierrors.InvalidParameter("origin", apierrors.FieldRequired, "is required"))
+		return
+	}
+	if state == "" {
+		apierrors.WriteError(w, r, apierrors.InvalidParameter("state", apierrors.FieldRequired, "is required"))
 		return
 	}
 
 	if !s.isAllowedOrigin(origin) {
-		http.Error(w, "invalid origin", http.StatusForbidden)
+		apierrors.Write(w, r, http.StatusForbidden, apierrors.CodeInvalidOrigin)
 		return
 	}
 
-	clientID := os.Getenv("YANDEX_CLIENT_ID")
-	redirectURI := origin + "/auth-yandex"
-
-	authURL := fmt.Sprintf(
-		"https://oauth.yandex.ru/authorize?response_type=code&client_id=%s&redirect_uri=%s&state=%s",
-		url.QueryEscape(clientID),
-		url.QueryEscape(redirectURI),
-		url.QueryEscape(state),
-	)
+	authURL, _ := s.container.YandexProvider.AuthCodeURL(r.Context(), oauth.AuthRequest{
+		State:       state,
+		RedirectURI: origin + "/auth-yandex",
+	})
 
 	http.Redirect(w, r, authURL, http.StatusFound)
 }
 
-func (s *serviceImpl) YandexLoginHandler(w http.ResponseWriter, r *http.Request) {
-	var body struct {
-		Code string `json:"code"`
-	}
+type yandexLoginHandlerRequest struct {
+	Code string `json:"code"`
+}
 
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
-		return
-	}
+func (s *serviceImpl) YandexLoginHandler(w http.ResponseWriter, r *http.Request) {
+	var body yandexLoginHandlerRequest
 
-	tokenResp, err := exchangeCodeForToken(body.Code)
-	if err != nil {
-		fmt.Println("yandex token exchange failed error:", err.Error())
-		http.Error(w, "token exchange failed", http.StatusInternalServerError)
+	if !decodeJSON(w, r, &body) {
 		return
 	}
 
-	profile, err := getYandexProfile(tokenResp.AccessToken)
+	profile, err := s.yandexProfile(r, body.Code)
 	if err != nil {
-		fmt.Println("get yandex profile error:", err.Error())
-		http.Error(w, "profile fetch failed", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, apierrors.Wrap(err, http.StatusBadGateway, apierrors.CodeUpstream))
 		return
 	}
 
 	user, err := s.container.GetOrCreateUserByYandexUC.Execute(profile)
 	if err != nil {
-		fmt.Println("create yandex user error:", err.Error())
-		http.Error(w, "user error", http.StatusInternalServerError)
+		apierrors.WriteError(w, r, err)
 		return
 	}
 
-	claims := jwt.MapClaims{
-		"user_id": user.ID,
-		"exp":     time.Now().Add(7 * 24 * time.Hour).Unix(),
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, _ := token.SignedString(jwtSecret)
-
-	json.NewEncoder(w).Encode(map[string]string{
-		"token": signed,
-	})
-}
-
-func exchangeCodeForToken(code string) (*TokenResponse, error) {
-	data := url.Values{}
-	data.Set("grant_type", "authorization_code")
-	data.Set("code", code)
-	data.Set("client_id", os.Getenv("YANDEX_CLIENT_ID"))
-	data.Set("client_secret", os.Getenv("YANDEX_CLIENT_SECRET"))
-
-	resp, err := http.PostForm("https://oauth.yandex.ru/token", data)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var result TokenResponse
-	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
-	}
-
-	return &result, nil
-}
-
-type TokenResponse struct {
-	AccessToken string `json:"access_token"`
-	ExpiresIn   int    `json:"expires_in"`
+	s.startAuthSession(w, r, user.ID)
 }
 
-func getYandexProfile(token string) (*dto.YandexProfile, error) {
-	req, _ := http.NewRequest("GET", "https://login.yandex.ru/info", nil)
-	req.Header.Set("Authorization", "OAuth "+token)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// yandexProfile меняет код на профиль через общий интерфейс провайдеров; state тут проверяет фронт
+func (s *serviceImpl) yandexProfile(r *http.Request, code string) (*dto.YandexProfile, error) {
+	identity, err := s.container.YandexProvider.Exchange(r.Context(), code, oauth.AuthRequest{})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	var profile dto.YandexProfile
-	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
-		return nil, err
-	}
-
-	return &profile, nil
+	return &dto.YandexProfile{
+		ID:           identity.Subject,
+		Login:        identity.Login,
+		FirstName:    identity.FirstName,
+		LastName:     identity.LastName,
+		DefaultEmail: identity.Email,
+	}, nil
 }
@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+type PersonalAccessToken struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"` // открытое начало токена
+	Scopes     []string   `json:"scopes"`
+	Token      string     `json:"token,omitempty"` // только в ответе на создание
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreatePersonalAccessToken struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"` // 0 — бессрочный
+}
+
+type AccessTokenScope struct {
+	Scope       string `json:"scope"`
+	Description string `json:"description"`
+}
+
+// PersonalTokenIdentity — кто и с какими правами пришел по персональному токену
+type PersonalTokenIdentity struct {
+	UserID  int64
+	TokenID int64
+	Scopes  []string
+}
@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+type AchievementItem struct {
+	Code        string     `json:"code"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Icon        string     `json:"icon"`
+	Kind        string     `json:"kind"`
+	Threshold   int        `json:"threshold"`
+	Progress    int        `json:"progress"` // не больше threshold
+	Earned      bool       `json:"earned"`
+	EarnedAt    *time.Time `json:"earned_at,omitempty"`
+	WorkoutID   *int64     `json:"workout_id,omitempty"`
+}
+
+type Streak struct {
+	Current int `json:"current"` // недель подряд с тренировками, включая текущую или прошлую
+	Best    int `json:"best"`
+}
+
+type Achievements struct {
+	Items  []AchievementItem `json:"items"`
+	Streak Streak            `json:"streak"`
+	Earned int               `json:"earned"`
+	Total  int               `json:"total"`
+}
+
+// AchievementUnlock — новые значки пользователя для рассылки
+type AchievementUnlock struct {
+	UserID       int64
+	ChatID       int64
+	Achievements []AchievementItem
+}
@@ -0,0 +1,58 @@
+package dto
+
+import "time"
+
+type AdminUserItem struct {
+	ID           int64      `json:"id"`
+	Username     string     `json:"username"`
+	FirstName    string     `json:"first_name"`
+	LastName     string     `json:"last_name"`
+	Email        string     `json:"email"`
+	Role         string     `json:"role"`
+	Providers    []string   `json:"providers"`
+	WorkoutCount int64      `json:"workout_count"`
+	CreatedAt    time.Time  `json:"created_at"`
+	DisabledAt   *time.Time `json:"disabled_at,omitempty"`
+}
+
+type AdminUsersResult struct {
+	Items      []AdminUserItem `json:"items"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+type UsageStats struct {
+	UsersTotal        int64            `json:"users_total"`
+	UsersNew30d       int64            `json:"users_new_30d"`
+	UsersDisabled     int64            `json:"users_disabled"`
+	ActiveUsers7d     int64            `json:"active_users_7d"`
+	ActiveUsers30d    int64            `json:"active_users_30d"`
+	WorkoutsTotal     int64            `json:"workouts_total"`
+	WorkoutsCompleted int64            `json:"workouts_completed"`
+	Workouts7d        int64            `json:"workouts_7d"`
+	UsersByRole       map[string]int64 `json:"users_by_role"`
+}
+
+type ExerciseTypeInput struct {
+	Name          string `json:"name"`
+	Url           string `json:"url"`
+	GroupCode     string `json:"exercise_group_type_code"`
+	RestInSeconds int    `json:"rest_in_seconds"`
+	Accent        string `json:"accent"`
+	Units         string `json:"units"`
+	Description   string `json:"description"`
+}
+
+type AuditLogItem struct {
+	ID         int64     `json:"id"`
+	ActorID    *int64    `json:"actor_id"`
+	Action     string    `json:"action"`
+	TargetType string    `json:"target_type"`
+	TargetID   string    `json:"target_id"`
+	Details    *string   `json:"details,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type AuditLogResult struct {
+	Items      []AuditLogItem `json:"items"`
+	Pagination Pagination     `json:"pagination"`
+}
@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+type ClientMeta struct {
+	UserAgent string
+	IP        string
+}
+
+type AuthTokens struct {
+	Token        string `json:"token"` // access токен, имя поля оставлено для совместимости со старым клиентом
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"` // секунды жизни access токена
+	SessionID    string `json:"session_id"`
+}
+
+type AuthSessionItem struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+type OAuthProvider struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+}
+
+// OAuthStart — адрес страницы провайдера и значение для cookie, которое привязывает вход к браузеру
+type OAuthStart struct {
+	URL     string
+	Binding string
+}
+
+type OAuthLoginResult struct {
+	UserID int64
+	Linked bool // вход завершил привязку провайдера к уже вошедшему пользователю
+	Link   *LinkAccountResult
+}
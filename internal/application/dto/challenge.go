@@ -0,0 +1,58 @@
+package dto
+
+import "time"
+
+type ChallengeItem struct {
+	ID             int64      `json:"id"`
+	Title          string     `json:"title"`
+	Metric         string     `json:"metric"`
+	ExerciseTypeID *int64     `json:"exercise_type_id,omitempty"`
+	ExerciseName   string     `json:"exercise_name,omitempty"`
+	InviteCode     string     `json:"invite_code,omitempty"` // только участникам
+	StartsAt       time.Time  `json:"starts_at"`
+	EndsAt         time.Time  `json:"ends_at"`
+	FinishedAt     *time.Time `json:"finished_at,omitempty"`
+	IsOwner        bool       `json:"is_owner"`
+}
+
+type CreateChallenge struct {
+	Title          string    `json:"title"`
+	Metric         string    `json:"metric"`
+	ExerciseTypeID *int64    `json:"exercise_type_id"`
+	StartsAt       time.Time `json:"starts_at"`
+	EndsAt         time.Time `json:"ends_at"`
+}
+
+type LeaderboardEntry struct {
+	Rank  int        `json:"rank"`
+	User  SocialUser `json:"user"`
+	Score float64    `json:"score"`
+	IsMe  bool       `json:"is_me"`
+}
+
+type ChallengeDetails struct {
+	Challenge   ChallengeItem      `json:"challenge"`
+	Leaderboard []LeaderboardEntry `json:"leaderboard"`
+}
+
+// ChallengeInvite — то, что видит получатель ссылки-приглашения до вступления
+type ChallengeInvite struct {
+	Challenge    ChallengeItem `json:"challenge"`
+	OwnerName    string        `json:"owner_name"`
+	Participants int           `json:"participants"`
+	Joined       bool          `json:"joined"`
+}
+
+// ChallengeResult — итоги завершившегося челленджа для рассылки участникам
+type ChallengeResult struct {
+	Challenge   ChallengeItem
+	Leaderboard []LeaderboardEntry
+	Recipients  []ChallengeRecipient
+}
+
+type ChallengeRecipient struct {
+	UserID int64
+	ChatID int64
+	Rank   int
+	Score  float64
+}
@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+type CoachInvite struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CoachLinkItem — связь с точки зрения одной из сторон: у тренера в ней спортсмен, у спортсмена — тренер
+type CoachLinkItem struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"user_id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+}
+
+type CoachingOverview struct {
+	Athletes []CoachLinkItem `json:"athletes"`
+	Coaches  []CoachLinkItem `json:"coaches"`
+	Invites  []CoachInvite   `json:"invites"`
+}
+
+type WorkoutCommentItem struct {
+	ID         int64     `json:"id"`
+	WorkoutID  int64     `json:"workout_id"`
+	AuthorID   int64     `json:"author_id"`
+	AuthorName string    `json:"author_name"`
+	Text       string    `json:"text"`
+	Public     bool      `json:"public"`
+	CreatedAt  time.Time `json:"created_at"`
+}
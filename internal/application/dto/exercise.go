@@ -64,5 +64,6 @@ type GetExercise struct {
 }
 
 type CreateExercise struct {
+	ExerciseID  int64
 	ExerciseObj models.ExerciseType
 }
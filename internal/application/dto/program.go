@@ -25,6 +25,8 @@ type ProgramDTO struct {
 	CreatedAt string               `json:"created_at"`
 	DayTypes  []*WorkoutDayTypeDTO `json:"day_types"`
 	IsActive  bool                 `json:"is_active"`
+
+	AssignedByID *int64 `json:"assigned_by_id,omitempty"`
 }
 
 func MapDayTypeDTO(obj models.WorkoutDayType) *WorkoutDayTypeDTO {
@@ -0,0 +1,85 @@
+package dto
+
+import "time"
+
+// Типы публичного /api/v1: числа без форматирования и единицы измерения в названиях полей,
+// контракт меняется только с новой версией API
+
+type APIMe struct {
+	ID        int64     `json:"id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	CreatedAt time.Time `json:"created_at"`
+	Scopes    []string  `json:"scopes,omitempty"` // права персонального токена, если запрос пришел с ним
+}
+
+type APIWorkout struct {
+	ID              int64                `json:"id"`
+	DayTypeID       int64                `json:"day_type_id"`
+	DayTypeName     string               `json:"day_type_name"`
+	Status          string               `json:"status"` // active, editing, completed
+	StartedAt       time.Time            `json:"started_at"`
+	EndedAt         *time.Time           `json:"ended_at"`
+	DurationSeconds int64                `json:"duration_seconds"`
+	AutoFinished    bool                 `json:"auto_finished"`
+	TonnageKg       float64              `json:"tonnage_kg"` // кг × повторы выполненных подходов
+	Exercises       []APIWorkoutExercise `json:"exercises"`
+}
+
+type APIWorkoutExercise struct {
+	ExerciseTypeID int64           `json:"exercise_type_id"`
+	Name           string          `json:"name"`
+	Units          string          `json:"units"`
+	Sets           []APIWorkoutSet `json:"sets"`
+}
+
+type APIWorkoutSet struct {
+	Reps        int        `json:"reps"`
+	WeightKg    float32    `json:"weight_kg"`
+	Minutes     int        `json:"minutes"`
+	Meters      int        `json:"meters"`
+	Completed   bool       `json:"completed"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+type APIWorkouts struct {
+	Items      []APIWorkout `json:"items"`
+	Pagination Pagination   `json:"pagination"`
+}
+
+type APIMeasurement struct {
+	ID          int64     `json:"id"`
+	MeasuredAt  time.Time `json:"measured_at"`
+	ShouldersCm float64   `json:"shoulders_cm"`
+	ChestCm     float64   `json:"chest_cm"`
+	HandLeftCm  float64   `json:"hand_left_cm"`
+	HandRightCm float64   `json:"hand_right_cm"`
+	WaistCm     float64   `json:"waist_cm"`
+	ButtocksCm  float64   `json:"buttocks_cm"`
+	HipLeftCm   float64   `json:"hip_left_cm"`
+	HipRightCm  float64   `json:"hip_right_cm"`
+	CalfLeftCm  float64   `json:"calf_left_cm"`
+	CalfRightCm float64   `json:"calf_right_cm"`
+	WeightKg    float64   `json:"weight_kg"`
+}
+
+type APIMeasurements struct {
+	Items      []APIMeasurement `json:"items"`
+	Pagination Pagination       `json:"pagination"`
+}
+
+// CreateAPIMeasurement — 0 или отсутствующее поле означает, что не измерялось
+type CreateAPIMeasurement struct {
+	MeasuredAt  *time.Time `json:"measured_at"` // по умолчанию — сейчас
+	ShouldersCm float64    `json:"shoulders_cm"`
+	ChestCm     float64    `json:"chest_cm"`
+	HandLeftCm  float64    `json:"hand_left_cm"`
+	HandRightCm float64    `json:"hand_right_cm"`
+	WaistCm     float64    `json:"waist_cm"`
+	ButtocksCm  float64    `json:"buttocks_cm"`
+	HipLeftCm   float64    `json:"hip_left_cm"`
+	HipRightCm  float64    `json:"hip_right_cm"`
+	CalfLeftCm  float64    `json:"calf_left_cm"`
+	CalfRightCm float64    `json:"calf_right_cm"`
+	WeightKg    float64    `json:"weight_kg"`
+}
@@ -10,6 +10,8 @@ type CompleteSet struct {
 
 type AddOneMoreSet struct {
 	WorkoutID int64
+	SetID     int64
+	Version   int64
 }
 
 type RemoveLastSet struct {
@@ -21,6 +23,8 @@ type NewSet struct {
 	NewWeight  float64
 	NewMinutes int64
 	NewMeters  int64
+
+	ExpectedVersion int64 // версия из If-Match, 0 — без проверки
 }
 
 type SetResult struct {
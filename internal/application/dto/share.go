@@ -14,3 +14,33 @@ type PublicWorkoutDTO struct {
 	Progress *WorkoutProgress  `json:"progress"`
 	Stats    *WorkoutStatistic `json:"stats"`
 }
+
+type ReactionCount struct {
+	Emoji string `json:"emoji"`
+	Count int64  `json:"count"`
+}
+
+type ShareSocial struct {
+	Reactions       []ReactionCount      `json:"reactions"`
+	AllowedEmoji    []string             `json:"allowed_emoji"`
+	MyReaction      string               `json:"my_reaction,omitempty"`
+	Comments        []WorkoutCommentItem `json:"comments"`
+	CommentsEnabled bool                 `json:"comments_enabled"`
+	IsOwner         bool                 `json:"is_owner"`
+}
+
+const (
+	ShareActivityReaction = "reaction"
+	ShareActivityComment  = "comment"
+)
+
+// ShareActivity — реакция или комментарий к расшаренной тренировке, о которых сообщаем владельцу
+type ShareActivity struct {
+	Kind        string
+	OwnerID     int64
+	OwnerChatID int64
+	WorkoutID   int64
+	ActorName   string
+	Emoji       string
+	Text        string
+}
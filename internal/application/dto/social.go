@@ -0,0 +1,61 @@
+package dto
+
+import "time"
+
+type SocialUser struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Icon string `json:"icon,omitempty"`
+}
+
+type FollowItem struct {
+	User      SocialUser `json:"user"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type FollowList struct {
+	Items      []FollowItem `json:"items"`
+	Pagination Pagination   `json:"pagination"`
+}
+
+type PrivacySettings struct {
+	WorkoutsVisibility string `json:"workouts_visibility"`
+	RecordsVisibility  string `json:"records_visibility"`
+	FeedDigest         bool   `json:"feed_digest"`
+}
+
+// FeedItem — завершенная тренировка (kind=workout) или рекорд в упражнении (kind=record)
+type FeedItem struct {
+	Kind         string     `json:"kind"`
+	User         SocialUser `json:"user"`
+	WorkoutID    int64      `json:"workout_id"`
+	DayTypeName  string     `json:"day_type_name,omitempty"`
+	StartedAt    time.Time  `json:"started_at"`
+	EndedAt      *time.Time `json:"ended_at,omitempty"`
+	ExerciseName string     `json:"exercise_name,omitempty"`
+	Weight       float64    `json:"weight,omitempty"`
+	PrevWeight   float64    `json:"prev_weight,omitempty"`
+}
+
+type FeedResult struct {
+	Items      []FeedItem `json:"items"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type SocialProfile struct {
+	User         SocialUser `json:"user"`
+	Followers    int64      `json:"followers"`
+	Following    int64      `json:"following"`
+	FollowStatus string     `json:"follow_status,omitempty"` // подписка зрителя: pending / accepted
+	FollowsYou   bool       `json:"follows_you"`
+	Activity     []FeedItem `json:"activity"`
+}
+
+// FeedDigest — сводка активности подписок для отправки в телеграм
+type FeedDigest struct {
+	UserID int64
+	ChatID int64
+	Items  []FeedItem
+	Total  int64
+}
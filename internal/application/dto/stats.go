@@ -21,3 +21,38 @@ type ExerciseStat struct {
 	Date string          `json:"date"`
 	Sets []*FormattedSet `json:"sets"`
 }
+
+// WeeklyStats — недельная статистика из агрегатов, по возрастанию недель
+type WeeklyStats struct {
+	Weeks []*WeekStats `json:"weeks"`
+}
+
+type WeekStats struct {
+	Week      string               `json:"week"` // понедельник недели, YYYY-MM-DD
+	Groups    []*GroupWeekStats    `json:"groups"`
+	Exercises []*ExerciseWeekStats `json:"exercises"`
+}
+
+type GroupWeekStats struct {
+	Code    string  `json:"code"`
+	Name    string  `json:"name"`
+	Sets    int     `json:"sets"`
+	Reps    int     `json:"reps"`
+	Tonnage float64 `json:"tonnage"`
+	Minutes int     `json:"minutes"`
+	Meters  int     `json:"meters"`
+}
+
+type ExerciseWeekStats struct {
+	ExerciseTypeID int64   `json:"exercise_type_id"`
+	Name           string  `json:"name"`
+	GroupCode      string  `json:"group_code"`
+	Workouts       int     `json:"workouts"`
+	Sets           int     `json:"sets"`
+	Reps           int     `json:"reps"`
+	Tonnage        float64 `json:"tonnage"`
+	Minutes        int     `json:"minutes"`
+	Meters         int     `json:"meters"`
+	MaxWeight      float64 `json:"max_weight"`
+	BestE1RM       float64 `json:"best_e1rm"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type SyncRequest struct {
+	Cursor    string         `json:"cursor"` // курсор из предыдущего ответа, пустой при первой синхронизации
+	Mutations []SyncMutation `json:"mutations"`
+}
+
+type SyncMutation struct {
+	ClientID  string          `json:"client_id"`  // uuid, генерируется клиентом и служит ключом идемпотентности
+	Type      string          `json:"type"`       // create_exercise, add_set, change_set, complete_set, finish_workout
+	CreatedAt time.Time       `json:"created_at"` // время действия на устройстве
+	Payload   json.RawMessage `json:"payload"`
+}
+
+type SyncMutationResult struct {
+	ClientID string `json:"client_id"`
+	Status   string `json:"status"` // applied, duplicate, conflict, rejected
+	EntityID int64  `json:"entity_id,omitempty"`
+	Version  int64  `json:"version,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+type SyncResponse struct {
+	Cursor   string               `json:"cursor"`
+	Results  []SyncMutationResult `json:"results"`
+	Workouts []*FormattedWorkout  `json:"workouts"`
+}
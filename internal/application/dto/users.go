@@ -18,3 +18,10 @@ type YandexProfile struct {
 	LastName     string `json:"last_name"`
 	DefaultEmail string `json:"default_email"`
 }
+
+type LinkAccountResult struct {
+	UserID       int64    `json:"user_id"`
+	Merged       bool     `json:"merged"`
+	MergedUserID int64    `json:"merged_user_id,omitempty"`
+	Providers    []string `json:"providers"`
+}
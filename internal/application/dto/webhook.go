@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Webhook struct {
+	ID          int64     `json:"id"`
+	URL         string    `json:"url"`
+	EventTypes  []string  `json:"event_types"`
+	Description string    `json:"description"`
+	Active      bool      `json:"active"`
+	Secret      string    `json:"secret,omitempty"` // только при создании и смене секрета
+	SecretHint  string    `json:"secret_hint"`      // последние символы секрета
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CreateWebhook struct {
+	URL         string   `json:"url"`
+	Secret      string   `json:"secret"` // если пусто — сгенерируем
+	EventTypes  []string `json:"event_types"`
+	Description string   `json:"description"`
+}
+
+type UpdateWebhook struct {
+	URL          *string   `json:"url"`
+	EventTypes   *[]string `json:"event_types"`
+	Description  *string   `json:"description"`
+	Active       *bool     `json:"active"`
+	RotateSecret bool      `json:"rotate_secret"`
+}
+
+type WebhookEventType struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"` // только для ожидающих
+	ResponseStatus *int            `json:"response_status,omitempty"`
+	ResponseBody   *string         `json:"response_body,omitempty"`
+	Error          *string         `json:"error,omitempty"`
+	DurationMs     *int            `json:"duration_ms,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+type WebhookDeliveries struct {
+	Items      []WebhookDelivery `json:"items"`
+	Pagination Pagination        `json:"pagination"`
+}
+
+// WebhookPayload — тело запроса к получателю
+type WebhookPayload struct {
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	UserID    int64       `json:"user_id"`
+	Data      interface{} `json:"data"`
+}
+
+type WebhookWorkout struct {
+	WorkoutID       int64                    `json:"workout_id"`
+	DayTypeName     string                   `json:"day_type_name"`
+	StartedAt       time.Time                `json:"started_at"`
+	EndedAt         *time.Time               `json:"ended_at"`
+	DurationSeconds int64                    `json:"duration_seconds"`
+	AutoFinished    bool                     `json:"auto_finished"`
+	Tonnage         float64                  `json:"tonnage"` // кг × повторы выполненных подходов
+	Exercises       []WebhookWorkoutExercise `json:"exercises"`
+}
+
+type WebhookWorkoutExercise struct {
+	Name  string              `json:"name"`
+	Units string              `json:"units"`
+	Sets  []WebhookWorkoutSet `json:"sets"`
+}
+
+type WebhookWorkoutSet struct {
+	Reps      int        `json:"reps,omitempty"`
+	Weight    float32    `json:"weight,omitempty"`
+	Minutes   int        `json:"minutes,omitempty"`
+	Meters    int        `json:"meters,omitempty"`
+	Completed bool       `json:"completed"`
+	At        *time.Time `json:"completed_at,omitempty"`
+}
+
+type WebhookMeasurement struct {
+	MeasurementID int64     `json:"measurement_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	// окружности в сантиметрах, вес в килограммах; 0 — не измерялось
+	Shoulders float64 `json:"shoulders"`
+	Chest     float64 `json:"chest"`
+	HandLeft  float64 `json:"hand_left"`
+	HandRight float64 `json:"hand_right"`
+	Waist     float64 `json:"waist"`
+	Buttocks  float64 `json:"buttocks"`
+	HipLeft   float64 `json:"hip_left"`
+	HipRight  float64 `json:"hip_right"`
+	CalfLeft  float64 `json:"calf_left"`
+	CalfRight float64 `json:"calf_right"`
+	Weight    float64 `json:"weight"`
+}
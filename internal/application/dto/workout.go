@@ -25,6 +25,7 @@ type Pagination struct {
 type ShowMyWorkoutsResult struct {
 	Items      []WorkoutItem `json:"items"`
 	Pagination Pagination    `json:"pagination"`
+	NextCursor string        `json:"next_cursor,omitempty"` // передать в cursor за следующей страницей; нет — страница последняя
 }
 
 type ConfirmDeleteWorkout struct {
@@ -41,6 +42,7 @@ type ConfirmFinishWorkout struct {
 
 type FinishWorkout struct {
 	WorkoutID int64
+	Version   int64
 }
 
 type CreateWorkout struct {
@@ -59,6 +61,7 @@ type FormattedWorkout struct {
 	EndedAt     string               `json:"ended_at"`
 	DayTypeName string               `json:"day_type_name"`
 	Completed   bool                 `json:"completed"`
+	Version     int64                `json:"version"`
 	Exercises   []*FormattedExercise `json:"exercises"`
 }
 
@@ -71,6 +74,7 @@ func MapToFormattedWorkout(w models.WorkoutDay, groupsMap map[string]string) *Fo
 		Duration:    utils.BetweenTimes(w.StartedAt, w.EndedAt),
 		DayTypeName: w.WorkoutDayType.Name,
 		Completed:   w.Completed,
+		Version:     w.Version,
 	}
 	for _, ex := range w.Exercises {
 		res.Exercises = append(res.Exercises, MapToFormattedExercise(ex, groupsMap))
@@ -101,6 +105,7 @@ func MapToFormattedExercise(ex models.Exercise, groupsMap map[string]string) *Fo
 		Url:           ex.ExerciseType.Url,
 		SumWeight:     sumWeight,
 		Index:         ex.Index,
+		Version:       ex.Version,
 		Sets:          sets,
 	}
 }
@@ -119,6 +124,7 @@ func MapToFormattedSet(s models.Set, ex models.Exercise) *FormattedSet {
 		FormattedString: s.String(ex.WorkoutDay.Completed),
 		Completed:       s.Completed,
 		Index:           s.Index,
+		Version:         s.Version,
 	}
 	if s.CompletedAt != nil {
 		newSet.CompletedAt = s.CompletedAt.Add(3 * time.Hour).Format("15:04:05")
@@ -136,6 +142,7 @@ type FormattedExercise struct {
 	Units         string          `json:"units"`
 	Description   string          `json:"description"`
 	Index         int             `json:"index"`
+	Version       int64           `json:"version"`
 	Sets          []*FormattedSet `json:"sets"`
 	SumWeight     float32         `json:"sum_weight"`
 }
@@ -154,6 +161,7 @@ type FormattedSet struct {
 	Completed       bool    `json:"completed"`
 	CompletedAt     string  `json:"completed_at"`
 	Index           int     `json:"index"`
+	Version         int64   `json:"version"`
 }
 
 type WorkoutProgress struct {
@@ -186,3 +194,44 @@ type ShowWorkoutByUserID struct {
 	Workouts []models.WorkoutDay
 	User     *models.User
 }
+
+type LogPastWorkout struct {
+	DayTypeID int64     `json:"day_type_id"`
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+}
+
+type UpdateWorkoutTimes struct {
+	StartedAt *time.Time `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+}
+
+type EditWorkoutResult struct {
+	WorkoutID int64 `json:"workout_id"`
+	Version   int64 `json:"version"`
+	Completed bool  `json:"completed"`
+}
+
+type WorkoutChange struct {
+	Field    string `json:"field"`
+	SetID    int64  `json:"set_id,omitempty"`
+	Exercise string `json:"exercise,omitempty"`
+	Old      string `json:"old"`
+	New      string `json:"new"`
+}
+
+type WorkoutEditItem struct {
+	ID        int64           `json:"id"`
+	UserID    int64           `json:"user_id"`
+	Action    string          `json:"action"`
+	Changes   []WorkoutChange `json:"changes"`
+	CreatedAt string          `json:"created_at"`
+}
+
+type AutoFinishedWorkout struct {
+	WorkoutID   int64
+	UserID      int64
+	ChatID      int64
+	DayTypeName string
+	EndedAt     time.Time
+}
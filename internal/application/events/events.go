@@ -0,0 +1,161 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Type — имя события, под ним событие хранится в outbox_events
+type Type string
+
+const (
+	TypeWorkoutStarted     Type = "workout_started"
+	TypeSetCompleted       Type = "set_completed"
+	TypeWorkoutFinished    Type = "workout_finished"
+	TypeMeasurementCreated Type = "measurement_created"
+	TypeProgramActivated   Type = "program_activated"
+	TypeRestTimerFinished  Type = "rest_timer_finished"
+	TypePersonalRecord     Type = "personal_record"
+	TypeWorkoutChanged     Type = "workout_changed"
+	TypeUsersMerged        Type = "users_merged"
+)
+
+// Event — доменное событие. Публикуется в той же транзакции, что и изменение, через outbox
+// и доставляется подписчикам уже после коммита
+type Event interface {
+	Type() Type
+}
+
+type WorkoutStarted struct {
+	WorkoutID int64     `json:"workout_id"`
+	UserID    int64     `json:"user_id"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+type SetCompleted struct {
+	SetID       int64     `json:"set_id"`
+	ExerciseID  int64     `json:"exercise_id"`
+	WorkoutID   int64     `json:"workout_id"`
+	UserID      int64     `json:"user_id"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+type WorkoutFinished struct {
+	WorkoutID    int64     `json:"workout_id"`
+	UserID       int64     `json:"user_id"`
+	EndedAt      time.Time `json:"ended_at"`
+	AutoFinished bool      `json:"auto_finished"`
+}
+
+type MeasurementCreated struct {
+	MeasurementID int64     `json:"measurement_id"`
+	UserID        int64     `json:"user_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ProgramActivated struct {
+	ProgramID   int64 `json:"program_id"`
+	UserID      int64 `json:"user_id"`
+	ActivatedBy int64 `json:"activated_by"` // сам пользователь или его тренер
+}
+
+type RestTimerFinished struct {
+	TimerID   int64 `json:"timer_id"`
+	WorkoutID int64 `json:"workout_id"`
+	UserID    int64 `json:"user_id"`
+}
+
+// PersonalRecord — максимальный вес в упражнении выше, чем во всех прошлых тренировках
+type PersonalRecord struct {
+	WorkoutID      int64     `json:"workout_id"`
+	UserID         int64     `json:"user_id"`
+	ExerciseTypeID int64     `json:"exercise_type_id"`
+	ExerciseName   string    `json:"exercise_name"`
+	Weight         float64   `json:"weight"`
+	PrevWeight     float64   `json:"prev_weight"`
+	At             time.Time `json:"at"`
+}
+
+// WorkoutChanged — подходы или упражнения тренировки изменены, удалены или сама тренировка
+// перенесена либо снова открыта: производные данные (агрегаты, рекорды) нужно пересчитать
+type WorkoutChanged struct {
+	WorkoutID     int64      `json:"workout_id"`
+	UserID        int64      `json:"user_id"`
+	PrevStartedAt *time.Time `json:"prev_started_at,omitempty"` // прежнее начало, если тренировку перенесли
+}
+
+// UsersMerged — аккаунт SourceID влит в UserID: история объединена, производные данные
+// основного аккаунта нужно пересчитать целиком
+type UsersMerged struct {
+	UserID   int64 `json:"user_id"`
+	SourceID int64 `json:"source_id"`
+}
+
+func (WorkoutStarted) Type() Type     { return TypeWorkoutStarted }
+func (SetCompleted) Type() Type       { return TypeSetCompleted }
+func (WorkoutFinished) Type() Type    { return TypeWorkoutFinished }
+func (MeasurementCreated) Type() Type { return TypeMeasurementCreated }
+func (ProgramActivated) Type() Type   { return TypeProgramActivated }
+func (RestTimerFinished) Type() Type  { return TypeRestTimerFinished }
+func (PersonalRecord) Type() Type     { return TypePersonalRecord }
+func (WorkoutChanged) Type() Type     { return TypeWorkoutChanged }
+func (UsersMerged) Type() Type        { return TypeUsersMerged }
+
+// UserOf возвращает пользователя, к данным которого относится событие
+func UserOf(event Event) int64 {
+	switch e := event.(type) {
+	case WorkoutStarted:
+		return e.UserID
+	case SetCompleted:
+		return e.UserID
+	case WorkoutFinished:
+		return e.UserID
+	case MeasurementCreated:
+		return e.UserID
+	case ProgramActivated:
+		return e.UserID
+	case RestTimerFinished:
+		return e.UserID
+	case PersonalRecord:
+		return e.UserID
+	case WorkoutChanged:
+		return e.UserID
+	case UsersMerged:
+		return e.UserID
+	}
+	return 0
+}
+
+// Decode восстанавливает типизированное событие из outbox
+func Decode(t Type, payload []byte) (Event, error) {
+	switch t {
+	case TypeWorkoutStarted:
+		return decode[WorkoutStarted](payload)
+	case TypeSetCompleted:
+		return decode[SetCompleted](payload)
+	case TypeWorkoutFinished:
+		return decode[WorkoutFinished](payload)
+	case TypeMeasurementCreated:
+		return decode[MeasurementCreated](payload)
+	case TypeProgramActivated:
+		return decode[ProgramActivated](payload)
+	case TypeRestTimerFinished:
+		return decode[RestTimerFinished](payload)
+	case TypePersonalRecord:
+		return decode[PersonalRecord](payload)
+	case TypeWorkoutChanged:
+		return decode[WorkoutChanged](payload)
+	case TypeUsersMerged:
+		return decode[UsersMerged](payload)
+	}
+	return nil, fmt.Errorf("unknown event type %q", t)
+}
+
+func decode[T Event](payload []byte) (Event, error) {
+	var event T
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,64 @@
+package accesstokens
+
+import (
+	"errors"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/accesstokens"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/authtokens"
+)
+
+// touchInterval — last_used_at обновляем не чаще, чтобы не писать в базу на каждый запрос
+const touchInterval = time.Minute
+
+type AuthenticateUseCase struct {
+	tokensRepo accesstokens.Repo
+	usersRepo  users.Repo
+}
+
+func NewAuthenticateUseCase(tokensRepo accesstokens.Repo, usersRepo users.Repo) *AuthenticateUseCase {
+	return &AuthenticateUseCase{
+		tokensRepo: tokensRepo,
+		usersRepo:  usersRepo,
+	}
+}
+
+func (uc *AuthenticateUseCase) Name() string {
+	return "Проверить персональный токен"
+}
+
+// Execute находит активный токен по хешу; заблокированный аккаунт токеном не пользуется
+func (uc *AuthenticateUseCase) Execute(token string) (*dto.PersonalTokenIdentity, error) {
+	pat, err := uc.tokensRepo.GetByHash(authtokens.HashRefresh(token))
+	if errors.Is(err, accesstokens.NotFoundTokenErr) {
+		return nil, InvalidTokenErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !pat.IsActive(now) {
+		return nil, InvalidTokenErr
+	}
+
+	user, err := uc.usersRepo.GetByID(pat.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsDisabled() {
+		return nil, InvalidTokenErr
+	}
+
+	if pat.LastUsedAt == nil || now.Sub(*pat.LastUsedAt) > touchInterval {
+		_ = uc.tokensRepo.Touch(pat.ID, now)
+	}
+
+	return &dto.PersonalTokenIdentity{
+		UserID:  pat.UserID,
+		TokenID: pat.ID,
+		Scopes:  decodeScopes(pat.Scopes),
+	}, nil
+}
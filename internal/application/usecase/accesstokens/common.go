@@ -0,0 +1,84 @@
+package accesstokens
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/authtokens"
+)
+
+var (
+	InvalidNameErr       = errors.New("token name must be 1 to 64 characters")
+	InvalidScopesErr     = errors.New("unknown or empty token scopes")
+	InvalidExpirationErr = errors.New("token expiration must be 0 to 365 days")
+	TooManyTokensErr     = errors.New("too many personal access tokens")
+	InvalidTokenErr      = errors.New("invalid personal access token")
+)
+
+const (
+	maxTokensPerUser  = 20
+	maxNameLength     = 64
+	maxExpirationDays = 365
+)
+
+// ScopeCatalog — описания прав для экрана выпуска токена, в порядке authtokens.Scopes
+var ScopeCatalog = []dto.AccessTokenScope{
+	{Scope: string(authtokens.ScopeWorkoutsRead), Description: "Чтение тренировок"},
+	{Scope: string(authtokens.ScopeMeasurementsRead), Description: "Чтение измерений тела"},
+	{Scope: string(authtokens.ScopeMeasurementsWrite), Description: "Добавление измерений тела"},
+}
+
+func normalizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return "", InvalidNameErr
+	}
+	return name, nil
+}
+
+// normalizeScopes проверяет права и убирает повторы, сохраняя порядок каталога
+func normalizeScopes(scopes []string) (string, error) {
+	requested := make(map[string]bool, len(scopes))
+	for _, s := range scopes {
+		requested[s] = true
+	}
+
+	result := make([]string, 0, len(scopes))
+	for _, s := range authtokens.Scopes {
+		if requested[string(s)] {
+			result = append(result, string(s))
+			delete(requested, string(s))
+		}
+	}
+	if len(result) == 0 || len(requested) > 0 {
+		return "", InvalidScopesErr
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+func decodeScopes(raw string) []string {
+	var scopes []string
+	_ = json.Unmarshal([]byte(raw), &scopes)
+	return scopes
+}
+
+func mapToken(token *models.PersonalAccessToken) dto.PersonalAccessToken {
+	return dto.PersonalAccessToken{
+		ID:         token.ID,
+		Name:       token.Name,
+		Prefix:     token.TokenPrefix,
+		Scopes:     decodeScopes(token.Scopes),
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
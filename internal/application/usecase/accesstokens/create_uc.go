@@ -0,0 +1,73 @@
+package accesstokens
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/accesstokens"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/authtokens"
+)
+
+type CreateUseCase struct {
+	tokensRepo accesstokens.Repo
+}
+
+func NewCreateUseCase(tokensRepo accesstokens.Repo) *CreateUseCase {
+	return &CreateUseCase{
+		tokensRepo: tokensRepo,
+	}
+}
+
+func (uc *CreateUseCase) Name() string {
+	return "Выпустить персональный токен"
+}
+
+// Execute выпускает токен; целиком он возвращается только в этом ответе
+func (uc *CreateUseCase) Execute(userID int64, req dto.CreatePersonalAccessToken) (*dto.PersonalAccessToken, error) {
+	name, err := normalizeName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if req.ExpiresInDays < 0 || req.ExpiresInDays > maxExpirationDays {
+		return nil, InvalidExpirationErr
+	}
+
+	now := time.Now()
+	count, err := uc.tokensRepo.CountActiveByUser(userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxTokensPerUser {
+		return nil, TooManyTokensErr
+	}
+
+	token, hash, prefix, err := authtokens.NewPersonal()
+	if err != nil {
+		return nil, err
+	}
+
+	pat := &models.PersonalAccessToken{
+		UserID:      userID,
+		Name:        name,
+		TokenHash:   hash,
+		TokenPrefix: prefix,
+		Scopes:      scopes,
+		CreatedAt:   now,
+	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := now.AddDate(0, 0, req.ExpiresInDays)
+		pat.ExpiresAt = &expiresAt
+	}
+	if err = uc.tokensRepo.Create(pat); err != nil {
+		return nil, err
+	}
+
+	item := mapToken(pat)
+	item.Token = token
+	return &item, nil
+}
@@ -0,0 +1,35 @@
+package accesstokens
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/accesstokens"
+)
+
+type FindMyUseCase struct {
+	tokensRepo accesstokens.Repo
+}
+
+func NewFindMyUseCase(tokensRepo accesstokens.Repo) *FindMyUseCase {
+	return &FindMyUseCase{
+		tokensRepo: tokensRepo,
+	}
+}
+
+func (uc *FindMyUseCase) Name() string {
+	return "Показать персональные токены"
+}
+
+func (uc *FindMyUseCase) Execute(userID int64) ([]dto.PersonalAccessToken, error) {
+	tokens, err := uc.tokensRepo.FindActiveByUser(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.PersonalAccessToken, 0, len(tokens))
+	for i := range tokens {
+		res = append(res, mapToken(&tokens[i]))
+	}
+	return res, nil
+}
@@ -0,0 +1,17 @@
+package accesstokens
+
+import "github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+
+type FindScopesUseCase struct{}
+
+func NewFindScopesUseCase() *FindScopesUseCase {
+	return &FindScopesUseCase{}
+}
+
+func (uc *FindScopesUseCase) Name() string {
+	return "Права персональных токенов"
+}
+
+func (uc *FindScopesUseCase) Execute() []dto.AccessTokenScope {
+	return ScopeCatalog
+}
@@ -0,0 +1,35 @@
+package accesstokens
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/accesstokens"
+)
+
+type RevokeUseCase struct {
+	tokensRepo accesstokens.Repo
+}
+
+func NewRevokeUseCase(tokensRepo accesstokens.Repo) *RevokeUseCase {
+	return &RevokeUseCase{
+		tokensRepo: tokensRepo,
+	}
+}
+
+func (uc *RevokeUseCase) Name() string {
+	return "Отозвать персональный токен"
+}
+
+// Execute отзывает токен; проверка идет на каждом запросе, поэтому действует сразу
+func (uc *RevokeUseCase) Execute(userID, tokenID int64) error {
+	token, err := uc.tokensRepo.Get(tokenID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	// чужой токен не раскрываем, отвечаем как на несуществующий
+	if token.UserID != userID || !token.IsActive(now) {
+		return accesstokens.NotFoundTokenErr
+	}
+	return uc.tokensRepo.Revoke(tokenID, now)
+}
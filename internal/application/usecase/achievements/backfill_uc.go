@@ -0,0 +1,70 @@
+package achievements
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/achievements"
+	achievementsservice "github.com/SaenkoDmitry/training-tg-bot/internal/service/achievements"
+)
+
+const backfillBatchSize = 100
+
+type BackfillUseCase struct {
+	achievementsRepo achievements.Repo
+}
+
+func NewBackfillUseCase(achievementsRepo achievements.Repo) *BackfillUseCase {
+	return &BackfillUseCase{
+		achievementsRepo: achievementsRepo,
+	}
+}
+
+func (uc *BackfillUseCase) Name() string {
+	return "Выдать достижения за прошлую историю"
+}
+
+// Execute проверяет все значки всех пользователей по накопленной истории. Выдача идемпотентна,
+// поэтому запуск безопасно повторять, например после добавления значков в каталог. Уведомления не отправляются
+func (uc *BackfillUseCase) Execute() (int, error) {
+	var awarded int
+	var lastID int64
+	for {
+		userIDs, err := uc.achievementsRepo.FindUserIDs(lastID, backfillBatchSize)
+		if err != nil {
+			return awarded, err
+		}
+		for _, userID := range userIDs {
+			created, err := uc.ExecuteForUser(userID)
+			if err != nil {
+				return awarded, err
+			}
+			awarded += created
+			lastID = userID
+		}
+		if len(userIDs) < backfillBatchSize {
+			return awarded, nil
+		}
+	}
+}
+
+// ExecuteForUser проверяет значки одного пользователя и возвращает число новых
+func (uc *BackfillUseCase) ExecuteForUser(userID int64) (int, error) {
+	history, err := uc.achievementsRepo.LoadHistory(userID)
+	if err != nil {
+		return 0, err
+	}
+	created, err := uc.achievementsRepo.Award(userID, achievementsservice.Evaluate(history))
+	if err != nil {
+		return 0, err
+	}
+	return len(created), nil
+}
+
+// Handle — подписчик шины событий: после слияния аккаунтов значки проверяются по объединенной
+// истории. Как и при обычном заполнении, уведомления не отправляются
+func (uc *BackfillUseCase) Handle(event events.Event) error {
+	if userID := events.UserOf(event); userID != 0 {
+		_, err := uc.ExecuteForUser(userID)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package achievements
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	achievementsservice "github.com/SaenkoDmitry/training-tg-bot/internal/service/achievements"
+)
+
+func mapAchievement(badge achievementsservice.Badge, earned *models.UserAchievement, progress int) dto.AchievementItem {
+	item := dto.AchievementItem{
+		Code:        badge.Code,
+		Title:       badge.Title,
+		Description: badge.Description,
+		Icon:        badge.Icon,
+		Kind:        string(badge.Kind),
+		Threshold:   badge.Threshold,
+		Progress:    progress,
+	}
+	if earned != nil {
+		earnedAt := earned.EarnedAt
+		item.Earned = true
+		item.EarnedAt = &earnedAt
+		item.WorkoutID = earned.WorkoutDayID
+		item.Progress = badge.Threshold
+	}
+	return item
+}
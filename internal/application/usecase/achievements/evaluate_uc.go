@@ -0,0 +1,57 @@
+package achievements
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/achievements"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	achievementsservice "github.com/SaenkoDmitry/training-tg-bot/internal/service/achievements"
+)
+
+type EvaluateUseCase struct {
+	achievementsRepo achievements.Repo
+	usersRepo        users.Repo
+}
+
+func NewEvaluateUseCase(achievementsRepo achievements.Repo, usersRepo users.Repo) *EvaluateUseCase {
+	return &EvaluateUseCase{
+		achievementsRepo: achievementsRepo,
+		usersRepo:        usersRepo,
+	}
+}
+
+func (uc *EvaluateUseCase) Name() string {
+	return "Проверить достижения"
+}
+
+// Execute проверяет значки, которые могло изменить событие, и возвращает только что выданные
+func (uc *EvaluateUseCase) Execute(event achievementsservice.Event) (*dto.AchievementUnlock, error) {
+	history, err := uc.achievementsRepo.LoadHistory(event.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := uc.achievementsRepo.Award(event.UserID, achievementsservice.Evaluate(history, event.Kinds()...))
+	if err != nil {
+		return nil, err
+	}
+	if len(created) == 0 {
+		return nil, nil
+	}
+
+	user, err := uc.usersRepo.GetByID(event.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock := &dto.AchievementUnlock{
+		UserID:       event.UserID,
+		ChatID:       user.ChatID,
+		Achievements: make([]dto.AchievementItem, 0, len(created)),
+	}
+	for i := range created {
+		if badge, ok := achievementsservice.Get(created[i].Code); ok {
+			unlock.Achievements = append(unlock.Achievements, mapAchievement(badge, &created[i], badge.Threshold))
+		}
+	}
+	return unlock, nil
+}
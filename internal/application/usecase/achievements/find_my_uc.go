@@ -0,0 +1,56 @@
+package achievements
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/achievements"
+	achievementsservice "github.com/SaenkoDmitry/training-tg-bot/internal/service/achievements"
+)
+
+type FindMyUseCase struct {
+	achievementsRepo achievements.Repo
+}
+
+func NewFindMyUseCase(achievementsRepo achievements.Repo) *FindMyUseCase {
+	return &FindMyUseCase{
+		achievementsRepo: achievementsRepo,
+	}
+}
+
+func (uc *FindMyUseCase) Name() string {
+	return "Мои достижения"
+}
+
+// Execute возвращает весь каталог значков с отметкой полученных и прогрессом к остальным
+func (uc *FindMyUseCase) Execute(userID int64) (*dto.Achievements, error) {
+	history, err := uc.achievementsRepo.LoadHistory(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := uc.achievementsRepo.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	earned := make(map[string]*models.UserAchievement, len(found))
+	for i := range found {
+		earned[found[i].Code] = &found[i]
+	}
+
+	progress := achievementsservice.CalcProgress(history, time.Now())
+	result := &dto.Achievements{
+		Items:  make([]dto.AchievementItem, 0, len(achievementsservice.Catalog)),
+		Streak: dto.Streak{Current: progress.Streak, Best: progress.BestStreak},
+		Total:  len(achievementsservice.Catalog),
+	}
+	for _, badge := range achievementsservice.Catalog {
+		item := mapAchievement(badge, earned[badge.Code], progress.Of(badge))
+		if item.Earned {
+			result.Earned++
+		}
+		result.Items = append(result.Items, item)
+	}
+	return result, nil
+}
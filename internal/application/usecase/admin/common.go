@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/auditlog"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisegrouptypes"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/utils"
+)
+
+var (
+	SelfActionErr          = errors.New("admin can not apply this action to himself")
+	InvalidRoleErr         = errors.New("invalid role")
+	InvalidExerciseTypeErr = errors.New("invalid exercise type")
+)
+
+// writeAudit пишет действие администратора в журнал. Само действие к этому моменту уже выполнено,
+// поэтому ошибка записи только возвращается наверх
+func writeAudit(repo auditlog.Repo, actorID int64, action, targetType string, targetID int64, details any) error {
+	entry := &models.AuditLogEntry{
+		ActorID:    &actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   strconv.FormatInt(targetID, 10),
+		CreatedAt:  time.Now(),
+	}
+	if details != nil {
+		raw, err := json.Marshal(details)
+		if err != nil {
+			return err
+		}
+		str := string(raw)
+		entry.Details = &str
+	}
+	return repo.Create(entry)
+}
+
+// buildExerciseType проверяет ввод админки: упражнение без группы или с неизвестными единицами
+// не сможет отобразиться ни в боте, ни во фронте
+func buildExerciseType(groupsRepo exercisegrouptypes.Repo, input dto.ExerciseTypeInput) (*models.ExerciseType, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || input.RestInSeconds < 0 {
+		return nil, InvalidExerciseTypeErr
+	}
+	if _, ok := utils.SplitUnits(input.Units); !ok {
+		return nil, InvalidExerciseTypeErr
+	}
+	if _, err := groupsRepo.Get(input.GroupCode); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, InvalidExerciseTypeErr
+		}
+		return nil, err
+	}
+	return &models.ExerciseType{
+		Name:                  name,
+		Url:                   strings.TrimSpace(input.Url),
+		ExerciseGroupTypeCode: input.GroupCode,
+		RestInSeconds:         input.RestInSeconds,
+		Accent:                input.Accent,
+		Units:                 input.Units,
+		Description:           input.Description,
+	}, nil
+}
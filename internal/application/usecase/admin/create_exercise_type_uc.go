@@ -0,0 +1,39 @@
+package admin
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/auditlog"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisegrouptypes"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisetypes"
+)
+
+type CreateExerciseTypeUseCase struct {
+	exerciseTypesRepo exercisetypes.Repo
+	groupsRepo        exercisegrouptypes.Repo
+	auditRepo         auditlog.Repo
+}
+
+func NewCreateExerciseTypeUseCase(exerciseTypesRepo exercisetypes.Repo, groupsRepo exercisegrouptypes.Repo, auditRepo auditlog.Repo) *CreateExerciseTypeUseCase {
+	return &CreateExerciseTypeUseCase{
+		exerciseTypesRepo: exerciseTypesRepo,
+		groupsRepo:        groupsRepo,
+		auditRepo:         auditRepo,
+	}
+}
+
+func (uc *CreateExerciseTypeUseCase) Name() string {
+	return "Добавить упражнение в библиотеку"
+}
+
+func (uc *CreateExerciseTypeUseCase) Execute(actorID int64, input dto.ExerciseTypeInput) (*models.ExerciseType, error) {
+	exerciseType, err := buildExerciseType(uc.groupsRepo, input)
+	if err != nil {
+		return nil, err
+	}
+	if err = uc.exerciseTypesRepo.Create(exerciseType); err != nil {
+		return nil, err
+	}
+
+	return exerciseType, writeAudit(uc.auditRepo, actorID, models.AuditActionCreateExerciseType, models.AuditTargetExerciseType, exerciseType.ID, exerciseType)
+}
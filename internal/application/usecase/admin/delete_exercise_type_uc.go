@@ -0,0 +1,42 @@
+package admin
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/auditlog"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisetypes"
+)
+
+type DeleteExerciseTypeUseCase struct {
+	exerciseTypesRepo exercisetypes.Repo
+	auditRepo         auditlog.Repo
+}
+
+func NewDeleteExerciseTypeUseCase(exerciseTypesRepo exercisetypes.Repo, auditRepo auditlog.Repo) *DeleteExerciseTypeUseCase {
+	return &DeleteExerciseTypeUseCase{
+		exerciseTypesRepo: exerciseTypesRepo,
+		auditRepo:         auditRepo,
+	}
+}
+
+func (uc *DeleteExerciseTypeUseCase) Name() string {
+	return "Удалить упражнение из библиотеки"
+}
+
+func (uc *DeleteExerciseTypeUseCase) Execute(actorID, exerciseTypeID int64) error {
+	before, err := uc.exerciseTypesRepo.Get(exerciseTypeID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return exercisetypes.NotFoundExerciseTypeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err = uc.exerciseTypesRepo.Delete(exerciseTypeID); err != nil {
+		return err
+	}
+
+	return writeAudit(uc.auditRepo, actorID, models.AuditActionDeleteExerciseType, models.AuditTargetExerciseType, exerciseTypeID, before)
+}
@@ -0,0 +1,54 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/authsessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/auditlog"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type DisableUserUseCase struct {
+	usersRepo   users.Repo
+	auditRepo   auditlog.Repo
+	revokeAllUC *authsessions.RevokeAllUseCase
+}
+
+func NewDisableUserUseCase(usersRepo users.Repo, auditRepo auditlog.Repo, revokeAllUC *authsessions.RevokeAllUseCase) *DisableUserUseCase {
+	return &DisableUserUseCase{
+		usersRepo:   usersRepo,
+		auditRepo:   auditRepo,
+		revokeAllUC: revokeAllUC,
+	}
+}
+
+func (uc *DisableUserUseCase) Name() string {
+	return "Заблокировать аккаунт"
+}
+
+func (uc *DisableUserUseCase) Execute(actorID, userID int64, reason string) error {
+	if actorID == userID {
+		return SelfActionErr
+	}
+	user, err := uc.usersRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.IsDisabled() {
+		return nil
+	}
+
+	now := time.Now()
+	if err = uc.usersRepo.SetDisabled(userID, &now); err != nil {
+		return err
+	}
+	// выданные access токены перестают приниматься сразу, а не через 15 минут
+	if err = uc.revokeAllUC.Execute(userID); err != nil {
+		return err
+	}
+
+	return writeAudit(uc.auditRepo, actorID, models.AuditActionDisableUser, models.AuditTargetUser, userID, map[string]string{
+		"reason": reason,
+	})
+}
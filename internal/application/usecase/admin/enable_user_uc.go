@@ -0,0 +1,38 @@
+package admin
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/auditlog"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type EnableUserUseCase struct {
+	usersRepo users.Repo
+	auditRepo auditlog.Repo
+}
+
+func NewEnableUserUseCase(usersRepo users.Repo, auditRepo auditlog.Repo) *EnableUserUseCase {
+	return &EnableUserUseCase{
+		usersRepo: usersRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+func (uc *EnableUserUseCase) Name() string {
+	return "Разблокировать аккаунт"
+}
+
+func (uc *EnableUserUseCase) Execute(actorID, userID int64) error {
+	user, err := uc.usersRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsDisabled() {
+		return nil
+	}
+	if err = uc.usersRepo.SetDisabled(userID, nil); err != nil {
+		return err
+	}
+
+	return writeAudit(uc.auditRepo, actorID, models.AuditActionEnableUser, models.AuditTargetUser, userID, nil)
+}
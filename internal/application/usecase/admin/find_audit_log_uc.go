@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/auditlog"
+)
+
+type FindAuditLogUseCase struct {
+	auditRepo auditlog.Repo
+}
+
+func NewFindAuditLogUseCase(auditRepo auditlog.Repo) *FindAuditLogUseCase {
+	return &FindAuditLogUseCase{
+		auditRepo: auditRepo,
+	}
+}
+
+func (uc *FindAuditLogUseCase) Name() string {
+	return "Показать журнал действий администраторов"
+}
+
+func (uc *FindAuditLogUseCase) Execute(filter auditlog.Filter, offset, limit int) (*dto.AuditLogResult, error) {
+	entries, total, err := uc.auditRepo.Find(filter, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.AuditLogItem, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, dto.AuditLogItem{
+			ID:         e.ID,
+			ActorID:    e.ActorID,
+			Action:     e.Action,
+			TargetType: e.TargetType,
+			TargetID:   e.TargetID,
+			Details:    e.Details,
+			CreatedAt:  e.CreatedAt,
+		})
+	}
+
+	return &dto.AuditLogResult{
+		Items: items,
+		Pagination: dto.Pagination{
+			Offset: offset,
+			Limit:  limit,
+			Total:  int(total),
+		},
+	}, nil
+}
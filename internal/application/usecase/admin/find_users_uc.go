@@ -0,0 +1,52 @@
+package admin
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type FindUsersUseCase struct {
+	usersRepo users.Repo
+}
+
+func NewFindUsersUseCase(usersRepo users.Repo) *FindUsersUseCase {
+	return &FindUsersUseCase{
+		usersRepo: usersRepo,
+	}
+}
+
+func (uc *FindUsersUseCase) Name() string {
+	return "Найти пользователей (админка)"
+}
+
+func (uc *FindUsersUseCase) Execute(query string, offset, limit int) (*dto.AdminUsersResult, error) {
+	found, total, err := uc.usersRepo.Search(query, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.AdminUserItem, 0, len(found))
+	for _, u := range found {
+		items = append(items, dto.AdminUserItem{
+			ID:           u.ID,
+			Username:     u.Username,
+			FirstName:    u.FirstName,
+			LastName:     u.LastName,
+			Email:        u.Email,
+			Role:         u.Role,
+			Providers:    u.LinkedProviders(),
+			WorkoutCount: u.WorkoutCount,
+			CreatedAt:    u.CreatedAt,
+			DisabledAt:   u.DisabledAt,
+		})
+	}
+
+	return &dto.AdminUsersResult{
+		Items: items,
+		Pagination: dto.Pagination{
+			Offset: offset,
+			Limit:  limit,
+			Total:  int(total),
+		},
+	}, nil
+}
@@ -0,0 +1,40 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type GetUsageStatsUseCase struct {
+	usersRepo users.Repo
+}
+
+func NewGetUsageStatsUseCase(usersRepo users.Repo) *GetUsageStatsUseCase {
+	return &GetUsageStatsUseCase{
+		usersRepo: usersRepo,
+	}
+}
+
+func (uc *GetUsageStatsUseCase) Name() string {
+	return "Показать статистику использования сервиса"
+}
+
+func (uc *GetUsageStatsUseCase) Execute() (*dto.UsageStats, error) {
+	stats, err := uc.usersRepo.UsageStats(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &dto.UsageStats{
+		UsersTotal:        stats.UsersTotal,
+		UsersNew30d:       stats.UsersNew30d,
+		UsersDisabled:     stats.UsersDisabled,
+		ActiveUsers7d:     stats.ActiveUsers7d,
+		ActiveUsers30d:    stats.ActiveUsers30d,
+		WorkoutsTotal:     stats.WorkoutsTotal,
+		WorkoutsCompleted: stats.WorkoutsCompleted,
+		Workouts7d:        stats.Workouts7d,
+		UsersByRole:       stats.UsersByRole,
+	}, nil
+}
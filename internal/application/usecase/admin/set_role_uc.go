@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/auditlog"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type SetRoleUseCase struct {
+	usersRepo users.Repo
+	auditRepo auditlog.Repo
+}
+
+func NewSetRoleUseCase(usersRepo users.Repo, auditRepo auditlog.Repo) *SetRoleUseCase {
+	return &SetRoleUseCase{
+		usersRepo: usersRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+func (uc *SetRoleUseCase) Name() string {
+	return "Изменить роль пользователя"
+}
+
+func (uc *SetRoleUseCase) Execute(actorID, userID int64, role string) error {
+	if !models.IsValidRole(role) {
+		return InvalidRoleErr
+	}
+	// снять с себя админку можно случайно, а вернуть будет уже некому
+	if actorID == userID {
+		return SelfActionErr
+	}
+
+	user, err := uc.usersRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.Role == role {
+		return nil
+	}
+	if err = uc.usersRepo.SetRole(userID, role); err != nil {
+		return err
+	}
+
+	return writeAudit(uc.auditRepo, actorID, models.AuditActionChangeRole, models.AuditTargetUser, userID, map[string]string{
+		"from": user.Role,
+		"to":   role,
+	})
+}
@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/auditlog"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisegrouptypes"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisetypes"
+)
+
+type UpdateExerciseTypeUseCase struct {
+	exerciseTypesRepo exercisetypes.Repo
+	groupsRepo        exercisegrouptypes.Repo
+	auditRepo         auditlog.Repo
+}
+
+func NewUpdateExerciseTypeUseCase(exerciseTypesRepo exercisetypes.Repo, groupsRepo exercisegrouptypes.Repo, auditRepo auditlog.Repo) *UpdateExerciseTypeUseCase {
+	return &UpdateExerciseTypeUseCase{
+		exerciseTypesRepo: exerciseTypesRepo,
+		groupsRepo:        groupsRepo,
+		auditRepo:         auditRepo,
+	}
+}
+
+func (uc *UpdateExerciseTypeUseCase) Name() string {
+	return "Изменить упражнение в библиотеке"
+}
+
+func (uc *UpdateExerciseTypeUseCase) Execute(actorID, exerciseTypeID int64, input dto.ExerciseTypeInput) (*models.ExerciseType, error) {
+	before, err := uc.exerciseTypesRepo.Get(exerciseTypeID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, exercisetypes.NotFoundExerciseTypeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	exerciseType, err := buildExerciseType(uc.groupsRepo, input)
+	if err != nil {
+		return nil, err
+	}
+	exerciseType.ID = exerciseTypeID
+	if err = uc.exerciseTypesRepo.Update(exerciseType); err != nil {
+		return nil, err
+	}
+
+	return exerciseType, writeAudit(uc.auditRepo, actorID, models.AuditActionUpdateExerciseType, models.AuditTargetExerciseType, exerciseTypeID, map[string]any{
+		"before": before,
+		"after":  exerciseType,
+	})
+}
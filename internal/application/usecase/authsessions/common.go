@@ -0,0 +1,56 @@
+package authsessions
+
+import (
+	"errors"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/authsessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/authtokens"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/denylist"
+)
+
+var (
+	InvalidRefreshTokenErr = errors.New("invalid refresh token")
+	RefreshTokenReusedErr  = errors.New("refresh token reuse detected, session revoked")
+	UserDisabledErr        = errors.New("user account is disabled")
+)
+
+// в пределах этого окна старый refresh токен считается гонкой параллельных вкладок, а не кражей
+const reuseGracePeriod = 10 * time.Second
+
+// maxUserAgentLength ограничивает мусор в заголовке, который сохраняем как название устройства
+const maxUserAgentLength = 256
+
+func issueTokens(userID int64, sessionID, refreshToken string) (*dto.AuthTokens, error) {
+	access, err := authtokens.IssueAccess(userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.AuthTokens{
+		Token:        access.Token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(authtokens.AccessTTL.Seconds()),
+		SessionID:    sessionID,
+	}, nil
+}
+
+// revokeSessions отзывает сессии и вносит их id в denylist, чтобы уже выданные access токены
+// перестали приниматься, не дожидаясь истечения
+func revokeSessions(repo authsessions.Repo, tokens *denylist.Denylist, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	now := time.Now()
+	if err := repo.Revoke(ids, now); err != nil {
+		return err
+	}
+	return tokens.Add(ids, now.Add(authtokens.AccessTTL))
+}
+
+func truncateUserAgent(userAgent string) string {
+	if len(userAgent) > maxUserAgentLength {
+		return userAgent[:maxUserAgentLength]
+	}
+	return userAgent
+}
@@ -0,0 +1,41 @@
+package authsessions
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/authsessions"
+)
+
+type FindUseCase struct {
+	sessionsRepo authsessions.Repo
+}
+
+func NewFindUseCase(sessionsRepo authsessions.Repo) *FindUseCase {
+	return &FindUseCase{
+		sessionsRepo: sessionsRepo,
+	}
+}
+
+func (uc *FindUseCase) Name() string {
+	return "Показать активные сессии пользователя"
+}
+
+func (uc *FindUseCase) Execute(userID int64, currentSessionID string) ([]dto.AuthSessionItem, error) {
+	sessions, err := uc.sessionsRepo.FindActiveByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.AuthSessionItem, 0, len(sessions))
+	for _, s := range sessions {
+		res = append(res, dto.AuthSessionItem{
+			ID:         s.ID,
+			UserAgent:  s.UserAgent,
+			IP:         s.IP,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+			Current:    s.ID == currentSessionID,
+		})
+	}
+	return res, nil
+}
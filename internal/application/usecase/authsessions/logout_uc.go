@@ -0,0 +1,50 @@
+package authsessions
+
+import (
+	"errors"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/authsessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/authtokens"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/denylist"
+)
+
+type LogoutUseCase struct {
+	sessionsRepo authsessions.Repo
+	denylist     *denylist.Denylist
+}
+
+func NewLogoutUseCase(sessionsRepo authsessions.Repo, denylist *denylist.Denylist) *LogoutUseCase {
+	return &LogoutUseCase{
+		sessionsRepo: sessionsRepo,
+		denylist:     denylist,
+	}
+}
+
+func (uc *LogoutUseCase) Name() string {
+	return "Выйти из аккаунта"
+}
+
+// Execute отзывает сессию по refresh токену или по sid из access токена, а сам access токен
+// (jti) вносит в denylist; пустые аргументы пропускаются
+func (uc *LogoutUseCase) Execute(refreshToken, sessionID, jti string) error {
+	if refreshToken != "" {
+		session, err := uc.sessionsRepo.GetByRefreshHash(authtokens.HashRefresh(refreshToken))
+		if err != nil && !errors.Is(err, authsessions.NotFoundSessionErr) {
+			return err
+		}
+		if session != nil {
+			sessionID = session.ID
+		}
+	}
+
+	if sessionID != "" {
+		if err := revokeSessions(uc.sessionsRepo, uc.denylist, []string{sessionID}); err != nil {
+			return err
+		}
+	}
+	if jti != "" {
+		return uc.denylist.Add([]string{jti}, time.Now().Add(authtokens.AccessTTL))
+	}
+	return nil
+}
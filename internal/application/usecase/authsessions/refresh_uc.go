@@ -0,0 +1,80 @@
+package authsessions
+
+import (
+	"errors"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/authsessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/authtokens"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/denylist"
+)
+
+type RefreshUseCase struct {
+	sessionsRepo authsessions.Repo
+	denylist     *denylist.Denylist
+}
+
+func NewRefreshUseCase(sessionsRepo authsessions.Repo, denylist *denylist.Denylist) *RefreshUseCase {
+	return &RefreshUseCase{
+		sessionsRepo: sessionsRepo,
+		denylist:     denylist,
+	}
+}
+
+func (uc *RefreshUseCase) Name() string {
+	return "Обновить access токен по refresh токену"
+}
+
+func (uc *RefreshUseCase) Execute(refreshToken string, meta dto.ClientMeta) (*dto.AuthTokens, error) {
+	if refreshToken == "" {
+		return nil, InvalidRefreshTokenErr
+	}
+	hash := authtokens.HashRefresh(refreshToken)
+	now := time.Now()
+
+	session, err := uc.sessionsRepo.GetByRefreshHash(hash)
+	if errors.Is(err, authsessions.NotFoundSessionErr) {
+		return nil, uc.handleUnknown(hash, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsActive(now) {
+		return nil, InvalidRefreshTokenErr
+	}
+
+	newToken, newHash, err := authtokens.NewRefresh()
+	if err != nil {
+		return nil, err
+	}
+	err = uc.sessionsRepo.Rotate(session.ID, hash, newHash, now, now.Add(authtokens.RefreshTTL))
+	if errors.Is(err, authsessions.AlreadyRotatedErr) {
+		return nil, InvalidRefreshTokenErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return issueTokens(session.UserID, session.ID, newToken)
+}
+
+// handleUnknown разбирает токен, которого нет среди действующих: если это предыдущий токен
+// сессии, его кто-то сохранил после ротации — сессию считаем скомпрометированной
+func (uc *RefreshUseCase) handleUnknown(hash string, now time.Time) error {
+	session, err := uc.sessionsRepo.GetByPrevRefreshHash(hash)
+	if errors.Is(err, authsessions.NotFoundSessionErr) {
+		return InvalidRefreshTokenErr
+	}
+	if err != nil {
+		return err
+	}
+	if !session.IsActive(now) || now.Sub(session.LastUsedAt) < reuseGracePeriod {
+		return InvalidRefreshTokenErr
+	}
+
+	if err = revokeSessions(uc.sessionsRepo, uc.denylist, []string{session.ID}); err != nil {
+		return err
+	}
+	return RefreshTokenReusedErr
+}
@@ -0,0 +1,35 @@
+package authsessions
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/authsessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/denylist"
+)
+
+type RevokeAllUseCase struct {
+	sessionsRepo authsessions.Repo
+	denylist     *denylist.Denylist
+}
+
+func NewRevokeAllUseCase(sessionsRepo authsessions.Repo, denylist *denylist.Denylist) *RevokeAllUseCase {
+	return &RevokeAllUseCase{
+		sessionsRepo: sessionsRepo,
+		denylist:     denylist,
+	}
+}
+
+func (uc *RevokeAllUseCase) Name() string {
+	return "Завершить все сессии пользователя"
+}
+
+func (uc *RevokeAllUseCase) Execute(userID int64) error {
+	sessions, err := uc.sessionsRepo.FindActiveByUser(userID)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(sessions))
+	for _, s := range sessions {
+		ids = append(ids, s.ID)
+	}
+	return revokeSessions(uc.sessionsRepo, uc.denylist, ids)
+}
@@ -0,0 +1,45 @@
+package authsessions
+
+import (
+	"errors"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/authsessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/denylist"
+)
+
+type RevokeOthersUseCase struct {
+	sessionsRepo authsessions.Repo
+	denylist     *denylist.Denylist
+}
+
+func NewRevokeOthersUseCase(sessionsRepo authsessions.Repo, denylist *denylist.Denylist) *RevokeOthersUseCase {
+	return &RevokeOthersUseCase{
+		sessionsRepo: sessionsRepo,
+		denylist:     denylist,
+	}
+}
+
+func (uc *RevokeOthersUseCase) Name() string {
+	return "Завершить все сессии, кроме текущей"
+}
+
+func (uc *RevokeOthersUseCase) Execute(userID int64, currentSessionID string) (int, error) {
+	if currentSessionID == "" {
+		return 0, errors.New("current session is unknown")
+	}
+	sessions, err := uc.sessionsRepo.FindActiveByUser(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	ids := make([]string, 0, len(sessions))
+	for _, s := range sessions {
+		if s.ID != currentSessionID {
+			ids = append(ids, s.ID)
+		}
+	}
+	if err = revokeSessions(uc.sessionsRepo, uc.denylist, ids); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
@@ -0,0 +1,36 @@
+package authsessions
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/authsessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/denylist"
+)
+
+type RevokeUseCase struct {
+	sessionsRepo authsessions.Repo
+	denylist     *denylist.Denylist
+}
+
+func NewRevokeUseCase(sessionsRepo authsessions.Repo, denylist *denylist.Denylist) *RevokeUseCase {
+	return &RevokeUseCase{
+		sessionsRepo: sessionsRepo,
+		denylist:     denylist,
+	}
+}
+
+func (uc *RevokeUseCase) Name() string {
+	return "Завершить сессию на другом устройстве"
+}
+
+func (uc *RevokeUseCase) Execute(userID int64, sessionID string) error {
+	session, err := uc.sessionsRepo.GetByID(sessionID)
+	if err != nil {
+		return err
+	}
+	// чужую сессию не раскрываем, отвечаем как на несуществующую
+	if session.UserID != userID || !session.IsActive(time.Now()) {
+		return authsessions.NotFoundSessionErr
+	}
+	return revokeSessions(uc.sessionsRepo, uc.denylist, []string{session.ID})
+}
@@ -0,0 +1,62 @@
+package authsessions
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/authsessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/authtokens"
+)
+
+type StartUseCase struct {
+	sessionsRepo authsessions.Repo
+	usersRepo    users.Repo
+}
+
+func NewStartUseCase(sessionsRepo authsessions.Repo, usersRepo users.Repo) *StartUseCase {
+	return &StartUseCase{
+		sessionsRepo: sessionsRepo,
+		usersRepo:    usersRepo,
+	}
+}
+
+func (uc *StartUseCase) Name() string {
+	return "Начать сессию после входа"
+}
+
+func (uc *StartUseCase) Execute(userID int64, meta dto.ClientMeta) (*dto.AuthTokens, error) {
+	// все способы входа сходятся здесь, поэтому блокировку аккаунта достаточно проверить один раз
+	user, err := uc.usersRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsDisabled() {
+		return nil, UserDisabledErr
+	}
+
+	refreshToken, refreshHash, err := authtokens.NewRefresh()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &models.AuthSession{
+		ID:               uuid.NewString(),
+		UserID:           userID,
+		RefreshTokenHash: refreshHash,
+		UserAgent:        truncateUserAgent(meta.UserAgent),
+		IP:               meta.IP,
+		CreatedAt:        now,
+		LastUsedAt:       now,
+		ExpiresAt:        now.Add(authtokens.RefreshTTL),
+	}
+	if err = uc.sessionsRepo.Create(session); err != nil {
+		return nil, err
+	}
+
+	return issueTokens(userID, session.ID, refreshToken)
+}
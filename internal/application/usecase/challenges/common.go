@@ -0,0 +1,92 @@
+package challenges
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+var (
+	EmptyTitleErr        = errors.New("challenge title is empty")
+	InvalidMetricErr     = errors.New("invalid challenge metric")
+	ExerciseRequiredErr  = errors.New("exercise is required for e1rm challenge")
+	InvalidPeriodErr     = errors.New("challenge must end after it starts and in the future")
+	NotOwnerErr          = errors.New("user is not the challenge owner")
+	NotParticipantErr    = errors.New("user is not a challenge participant")
+	OwnerCannotLeaveErr  = errors.New("challenge owner can not leave, delete the challenge instead")
+	ChallengeFinishedErr = errors.New("challenge is already finished")
+)
+
+const maxTitleLength = 128
+
+// newInviteCode генерирует код для ссылки-приглашения
+func newInviteCode() (string, error) {
+	buf := make([]byte, 5)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.EncodeToString(buf), nil
+}
+
+func displayName(user *models.User) string {
+	if user == nil {
+		return ""
+	}
+	if name := user.ShortName(); name != "" {
+		return name
+	}
+	return user.Username
+}
+
+// mapChallenge: код приглашения показываем только участникам
+func mapChallenge(challenge *models.Challenge, viewerID int64, participant bool) dto.ChallengeItem {
+	item := dto.ChallengeItem{
+		ID:             challenge.ID,
+		Title:          challenge.Title,
+		Metric:         challenge.Metric,
+		ExerciseTypeID: challenge.ExerciseTypeID,
+		StartsAt:       challenge.StartsAt,
+		EndsAt:         challenge.EndsAt,
+		FinishedAt:     challenge.FinishedAt,
+		IsOwner:        challenge.OwnerID == viewerID,
+	}
+	if challenge.ExerciseType != nil {
+		item.ExerciseName = challenge.ExerciseType.Name
+	}
+	if participant {
+		item.InviteCode = challenge.InviteCode
+	}
+	return item
+}
+
+// buildLeaderboard нумерует участников, одинаковый результат — одно место
+func buildLeaderboard(participants []models.ChallengeParticipant, viewerID int64) []dto.LeaderboardEntry {
+	result := make([]dto.LeaderboardEntry, 0, len(participants))
+	for i, p := range participants {
+		rank := i + 1
+		if i > 0 && p.Score == participants[i-1].Score {
+			rank = result[i-1].Rank
+		}
+		result = append(result, dto.LeaderboardEntry{
+			Rank: rank,
+			User: dto.SocialUser{
+				ID:   p.UserID,
+				Name: displayName(p.User),
+				Icon: iconOf(p.User),
+			},
+			Score: p.Score,
+			IsMe:  p.UserID == viewerID,
+		})
+	}
+	return result
+}
+
+func iconOf(user *models.User) string {
+	if user == nil {
+		return ""
+	}
+	return user.Icon
+}
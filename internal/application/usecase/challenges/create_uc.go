@@ -0,0 +1,88 @@
+package challenges
+
+import (
+	"strings"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/challenges"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisetypes"
+)
+
+type CreateUseCase struct {
+	challengesRepo    challenges.Repo
+	exerciseTypesRepo exercisetypes.Repo
+}
+
+func NewCreateUseCase(challengesRepo challenges.Repo, exerciseTypesRepo exercisetypes.Repo) *CreateUseCase {
+	return &CreateUseCase{
+		challengesRepo:    challengesRepo,
+		exerciseTypesRepo: exerciseTypesRepo,
+	}
+}
+
+func (uc *CreateUseCase) Name() string {
+	return "Создать челлендж"
+}
+
+// Execute создает челлендж и сразу записывает в него автора
+func (uc *CreateUseCase) Execute(userID int64, req dto.CreateChallenge) (*dto.ChallengeItem, error) {
+	title := strings.TrimSpace(req.Title)
+	if title == "" || len([]rune(title)) > maxTitleLength {
+		return nil, EmptyTitleErr
+	}
+	if !models.IsValidChallengeMetric(req.Metric) {
+		return nil, InvalidMetricErr
+	}
+	var exerciseTypeID *int64
+	if req.Metric == models.ChallengeMetricE1RM {
+		if req.ExerciseTypeID == nil {
+			return nil, ExerciseRequiredErr
+		}
+		if _, err := uc.exerciseTypesRepo.Get(*req.ExerciseTypeID); err != nil {
+			return nil, ExerciseRequiredErr
+		}
+		exerciseTypeID = req.ExerciseTypeID
+	}
+	now := time.Now()
+	if !req.EndsAt.After(req.StartsAt) || !req.EndsAt.After(now) {
+		return nil, InvalidPeriodErr
+	}
+
+	code, err := newInviteCode()
+	if err != nil {
+		return nil, err
+	}
+	challenge := &models.Challenge{
+		OwnerID:        userID,
+		Title:          title,
+		Metric:         req.Metric,
+		ExerciseTypeID: exerciseTypeID,
+		InviteCode:     code,
+		StartsAt:       req.StartsAt,
+		EndsAt:         req.EndsAt,
+		CreatedAt:      now,
+	}
+	if err = uc.challengesRepo.Create(challenge); err != nil {
+		return nil, err
+	}
+	if _, err = uc.challengesRepo.Join(&models.ChallengeParticipant{
+		ChallengeID: challenge.ID,
+		UserID:      userID,
+		JoinedAt:    now,
+		UpdatedAt:   now,
+	}); err != nil {
+		return nil, err
+	}
+	if err = uc.challengesRepo.RecalculateParticipant(challenge.ID, userID); err != nil {
+		return nil, err
+	}
+
+	created, err := uc.challengesRepo.Get(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+	item := mapChallenge(created, userID, true)
+	return &item, nil
+}
@@ -0,0 +1,30 @@
+package challenges
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/challenges"
+)
+
+type DeleteUseCase struct {
+	challengesRepo challenges.Repo
+}
+
+func NewDeleteUseCase(challengesRepo challenges.Repo) *DeleteUseCase {
+	return &DeleteUseCase{
+		challengesRepo: challengesRepo,
+	}
+}
+
+func (uc *DeleteUseCase) Name() string {
+	return "Удалить челлендж"
+}
+
+func (uc *DeleteUseCase) Execute(userID, challengeID int64) error {
+	challenge, err := uc.challengesRepo.Get(challengeID)
+	if err != nil {
+		return err
+	}
+	if challenge.OwnerID != userID {
+		return NotOwnerErr
+	}
+	return uc.challengesRepo.Delete(challengeID)
+}
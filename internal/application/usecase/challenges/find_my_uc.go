@@ -0,0 +1,32 @@
+package challenges
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/challenges"
+)
+
+type FindMyUseCase struct {
+	challengesRepo challenges.Repo
+}
+
+func NewFindMyUseCase(challengesRepo challenges.Repo) *FindMyUseCase {
+	return &FindMyUseCase{
+		challengesRepo: challengesRepo,
+	}
+}
+
+func (uc *FindMyUseCase) Name() string {
+	return "Мои челленджи"
+}
+
+func (uc *FindMyUseCase) Execute(userID int64) ([]dto.ChallengeItem, error) {
+	found, err := uc.challengesRepo.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dto.ChallengeItem, 0, len(found))
+	for i := range found {
+		result = append(result, mapChallenge(&found[i], userID, true))
+	}
+	return result, nil
+}
@@ -0,0 +1,71 @@
+package challenges
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/challenges"
+)
+
+const finishBatchSize = 20
+
+type FinishUseCase struct {
+	challengesRepo challenges.Repo
+}
+
+func NewFinishUseCase(challengesRepo challenges.Repo) *FinishUseCase {
+	return &FinishUseCase{
+		challengesRepo: challengesRepo,
+	}
+}
+
+func (uc *FinishUseCase) Name() string {
+	return "Подвести итоги челленджей"
+}
+
+// Execute делает финальный полный пересчет закончившихся челленджей, фиксирует результаты
+// и возвращает итоги для рассылки
+func (uc *FinishUseCase) Execute(now time.Time) ([]dto.ChallengeResult, error) {
+	due, err := uc.challengesRepo.FindDue(now, finishBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.ChallengeResult, 0, len(due))
+	for i := range due {
+		challenge := &due[i]
+		if err = uc.challengesRepo.RecalculateChallenge(challenge.ID); err != nil {
+			return nil, err
+		}
+		if err = uc.challengesRepo.MarkFinished(challenge.ID, now); err != nil {
+			return nil, err
+		}
+		challenge.FinishedAt = &now
+
+		participants, err := uc.challengesRepo.FindParticipants(challenge.ID)
+		if err != nil {
+			return nil, err
+		}
+		leaderboard := buildLeaderboard(participants, 0)
+
+		recipients := make([]dto.ChallengeRecipient, 0, len(participants))
+		for j, p := range participants {
+			recipient := dto.ChallengeRecipient{
+				UserID: p.UserID,
+				Rank:   leaderboard[j].Rank,
+				Score:  p.Score,
+			}
+			if p.User != nil {
+				recipient.ChatID = p.User.ChatID
+			}
+			recipients = append(recipients, recipient)
+		}
+
+		result = append(result, dto.ChallengeResult{
+			Challenge:   mapChallenge(challenge, 0, false),
+			Leaderboard: leaderboard,
+			Recipients:  recipients,
+		})
+	}
+	return result, nil
+}
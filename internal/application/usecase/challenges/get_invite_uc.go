@@ -0,0 +1,47 @@
+package challenges
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/challenges"
+)
+
+type GetInviteUseCase struct {
+	challengesRepo challenges.Repo
+}
+
+func NewGetInviteUseCase(challengesRepo challenges.Repo) *GetInviteUseCase {
+	return &GetInviteUseCase{
+		challengesRepo: challengesRepo,
+	}
+}
+
+func (uc *GetInviteUseCase) Name() string {
+	return "Показать приглашение в челлендж"
+}
+
+func (uc *GetInviteUseCase) Execute(userID int64, code string) (*dto.ChallengeInvite, error) {
+	challenge, err := uc.challengesRepo.GetByInviteCode(strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, err
+	}
+	participants, err := uc.challengesRepo.FindParticipants(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = uc.challengesRepo.GetParticipant(challenge.ID, userID)
+	joined := err == nil
+	if err != nil && !errors.Is(err, challenges.NotFoundParticipantErr) {
+		return nil, err
+	}
+
+	return &dto.ChallengeInvite{
+		Challenge:    mapChallenge(challenge, userID, false),
+		OwnerName:    displayName(challenge.Owner),
+		Participants: len(participants),
+		Joined:       joined,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package challenges
+
+import (
+	"errors"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/challenges"
+)
+
+type GetUseCase struct {
+	challengesRepo challenges.Repo
+}
+
+func NewGetUseCase(challengesRepo challenges.Repo) *GetUseCase {
+	return &GetUseCase{
+		challengesRepo: challengesRepo,
+	}
+}
+
+func (uc *GetUseCase) Name() string {
+	return "Таблица лидеров челленджа"
+}
+
+// Execute показывает челлендж с таблицей лидеров; доступно только участникам
+func (uc *GetUseCase) Execute(userID, challengeID int64) (*dto.ChallengeDetails, error) {
+	challenge, err := uc.challengesRepo.Get(challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = uc.challengesRepo.GetParticipant(challengeID, userID); err != nil {
+		if errors.Is(err, challenges.NotFoundParticipantErr) {
+			return nil, NotParticipantErr
+		}
+		return nil, err
+	}
+
+	participants, err := uc.challengesRepo.FindParticipants(challengeID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ChallengeDetails{
+		Challenge:   mapChallenge(challenge, userID, true),
+		Leaderboard: buildLeaderboard(participants, userID),
+	}, nil
+}
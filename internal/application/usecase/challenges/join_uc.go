@@ -0,0 +1,55 @@
+package challenges
+
+import (
+	"strings"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/challenges"
+)
+
+type JoinUseCase struct {
+	challengesRepo challenges.Repo
+}
+
+func NewJoinUseCase(challengesRepo challenges.Repo) *JoinUseCase {
+	return &JoinUseCase{
+		challengesRepo: challengesRepo,
+	}
+}
+
+func (uc *JoinUseCase) Name() string {
+	return "Вступить в челлендж"
+}
+
+// Execute вступает по коду из ссылки; повторное вступление ничего не меняет
+func (uc *JoinUseCase) Execute(userID int64, code string) (*dto.ChallengeItem, error) {
+	challenge, err := uc.challengesRepo.GetByInviteCode(strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, err
+	}
+	if challenge.IsFinished() || !challenge.EndsAt.After(time.Now()) {
+		return nil, ChallengeFinishedErr
+	}
+
+	now := time.Now()
+	created, err := uc.challengesRepo.Join(&models.ChallengeParticipant{
+		ChallengeID: challenge.ID,
+		UserID:      userID,
+		JoinedAt:    now,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// тренировки, сделанные с начала челленджа до вступления, тоже засчитываются
+	if created {
+		if err = uc.challengesRepo.RecalculateParticipant(challenge.ID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	item := mapChallenge(challenge, userID, true)
+	return &item, nil
+}
@@ -0,0 +1,33 @@
+package challenges
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/challenges"
+)
+
+type LeaveUseCase struct {
+	challengesRepo challenges.Repo
+}
+
+func NewLeaveUseCase(challengesRepo challenges.Repo) *LeaveUseCase {
+	return &LeaveUseCase{
+		challengesRepo: challengesRepo,
+	}
+}
+
+func (uc *LeaveUseCase) Name() string {
+	return "Покинуть челлендж"
+}
+
+func (uc *LeaveUseCase) Execute(userID, challengeID int64) error {
+	challenge, err := uc.challengesRepo.Get(challengeID)
+	if err != nil {
+		return err
+	}
+	if challenge.OwnerID == userID {
+		return OwnerCannotLeaveErr
+	}
+	if challenge.IsFinished() {
+		return ChallengeFinishedErr
+	}
+	return uc.challengesRepo.Leave(challengeID, userID)
+}
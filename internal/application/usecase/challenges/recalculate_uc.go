@@ -0,0 +1,24 @@
+package challenges
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/challenges"
+)
+
+type RecalculateUseCase struct {
+	challengesRepo challenges.Repo
+}
+
+func NewRecalculateUseCase(challengesRepo challenges.Repo) *RecalculateUseCase {
+	return &RecalculateUseCase{
+		challengesRepo: challengesRepo,
+	}
+}
+
+func (uc *RecalculateUseCase) Name() string {
+	return "Пересчитать таблицы лидеров"
+}
+
+// Execute пересчитывает вклад изменившихся тренировок во все идущие челленджи их владельцев
+func (uc *RecalculateUseCase) Execute(workoutIDs []int64) error {
+	return uc.challengesRepo.RecalculateWorkouts(workoutIDs)
+}
@@ -0,0 +1,63 @@
+package coaching
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/coaching"
+)
+
+type AcceptInviteUseCase struct {
+	coachingRepo coaching.Repo
+}
+
+func NewAcceptInviteUseCase(coachingRepo coaching.Repo) *AcceptInviteUseCase {
+	return &AcceptInviteUseCase{
+		coachingRepo: coachingRepo,
+	}
+}
+
+func (uc *AcceptInviteUseCase) Name() string {
+	return "Принять приглашение тренера"
+}
+
+// Execute — согласие спортсмена: только после него тренер получает доступ к данным
+func (uc *AcceptInviteUseCase) Execute(athleteID int64, code string) (*dto.CoachLinkItem, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return nil, InvalidInviteErr
+	}
+
+	link, err := uc.coachingRepo.GetByInviteCode(code)
+	if errors.Is(err, coaching.NotFoundInviteErr) {
+		return nil, InvalidInviteErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if now.After(link.ExpiresAt) {
+		return nil, InvalidInviteErr
+	}
+	if link.CoachID == athleteID {
+		return nil, SelfInviteErr
+	}
+
+	err = uc.coachingRepo.Accept(link.ID, athleteID, now)
+	if errors.Is(err, coaching.InviteAlreadyUsedErr) {
+		return nil, InvalidInviteErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CoachLinkItem{
+		ID:         link.ID,
+		UserID:     link.CoachID,
+		Name:       displayName(link.Coach),
+		Scopes:     link.ScopeList(),
+		AcceptedAt: &now,
+	}, nil
+}
@@ -0,0 +1,48 @@
+package coaching
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/programs"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type AssignProgramUseCase struct {
+	programsRepo programs.Repo
+	usersRepo    users.Repo
+}
+
+func NewAssignProgramUseCase(programsRepo programs.Repo, usersRepo users.Repo) *AssignProgramUseCase {
+	return &AssignProgramUseCase{
+		programsRepo: programsRepo,
+		usersRepo:    usersRepo,
+	}
+}
+
+func (uc *AssignProgramUseCase) Name() string {
+	return "Назначить спортсмену программу"
+}
+
+// Execute делает программу спортсмена активной; принадлежность программы спортсмену проверяет validator
+func (uc *AssignProgramUseCase) Execute(coachID, athleteID, programID int64) error {
+	program, err := uc.programsRepo.Get(programID)
+	if err != nil {
+		return err
+	}
+	if program.UserID != athleteID {
+		return NotLinkParticipantErr
+	}
+	if program.AssignedByID == nil {
+		program.AssignedByID = &coachID
+		program.DayTypes = nil // Save не должен трогать дни программы
+		if err = uc.programsRepo.Save(&program); err != nil {
+			return err
+		}
+	}
+
+	athlete, err := uc.usersRepo.GetByID(athleteID)
+	if err != nil {
+		return err
+	}
+	athlete.ActiveProgramID = &programID
+	return uc.usersRepo.Save(athlete, events.ProgramActivated{ProgramID: programID, UserID: athleteID, ActivatedBy: coachID})
+}
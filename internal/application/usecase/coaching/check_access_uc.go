@@ -0,0 +1,46 @@
+package coaching
+
+import (
+	"errors"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/coaching"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/rbac"
+)
+
+type CheckAccessUseCase struct {
+	coachingRepo coaching.Repo
+	usersRepo    users.Repo
+}
+
+func NewCheckAccessUseCase(coachingRepo coaching.Repo, usersRepo users.Repo) *CheckAccessUseCase {
+	return &CheckAccessUseCase{
+		coachingRepo: coachingRepo,
+		usersRepo:    usersRepo,
+	}
+}
+
+func (uc *CheckAccessUseCase) Name() string {
+	return "Проверить доступ тренера к данным спортсмена"
+}
+
+// Execute проверяет и связь со спортсменом, и роль: тренер, у которого забрали роль
+// или заблокировали аккаунт, теряет доступ без отзыва связей
+func (uc *CheckAccessUseCase) Execute(coachID, athleteID int64, scope string) (bool, error) {
+	link, err := uc.coachingRepo.GetActive(coachID, athleteID)
+	if errors.Is(err, coaching.NotFoundLinkErr) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !link.HasScope(scope) {
+		return false, nil
+	}
+
+	coach, err := uc.usersRepo.GetByID(coachID)
+	if err != nil {
+		return false, err
+	}
+	return rbac.Allowed(coach, rbac.CoachAthletes), nil
+}
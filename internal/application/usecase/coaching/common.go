@@ -0,0 +1,76 @@
+package coaching
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+var (
+	NotCoachErr           = errors.New("user is not a coach")
+	InvalidScopesErr      = errors.New("invalid coach scopes")
+	InvalidInviteErr      = errors.New("coach invite is invalid or expired")
+	SelfInviteErr         = errors.New("coach can not accept his own invite")
+	NotLinkParticipantErr = errors.New("user is not a participant of the coach link")
+)
+
+// приглашение живет неделю: код обычно пересылают в мессенджере и принимают не сразу
+const inviteTTL = 7 * 24 * time.Hour
+
+// normalizeScopes проверяет и упорядочивает области доступа; пустой список означает все области
+func normalizeScopes(scopes []string) (string, error) {
+	if len(scopes) == 0 {
+		return strings.Join(models.AllCoachScopes, ","), nil
+	}
+	requested := make(map[string]bool, len(scopes))
+	for _, s := range scopes {
+		if !models.IsValidCoachScope(s) {
+			return "", InvalidScopesErr
+		}
+		requested[s] = true
+	}
+	result := make([]string, 0, len(requested))
+	for _, s := range models.AllCoachScopes {
+		if requested[s] {
+			result = append(result, s)
+		}
+	}
+	return strings.Join(result, ","), nil
+}
+
+// newInviteCode генерирует короткий код, который удобно продиктовать или ввести в боте
+func newInviteCode() (string, error) {
+	buf := make([]byte, 5)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.EncodeToString(buf), nil
+}
+
+func displayName(user *models.User) string {
+	if user == nil {
+		return ""
+	}
+	if name := user.ShortName(); name != "" {
+		return name
+	}
+	return user.Username
+}
+
+func mapInvite(link models.CoachLink) dto.CoachInvite {
+	code := ""
+	if link.InviteCode != nil {
+		code = *link.InviteCode
+	}
+	return dto.CoachInvite{
+		ID:        link.ID,
+		Code:      code,
+		Scopes:    link.ScopeList(),
+		ExpiresAt: link.ExpiresAt,
+	}
+}
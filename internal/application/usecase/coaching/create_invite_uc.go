@@ -0,0 +1,62 @@
+package coaching
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/coaching"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/rbac"
+)
+
+type CreateInviteUseCase struct {
+	coachingRepo coaching.Repo
+	usersRepo    users.Repo
+}
+
+func NewCreateInviteUseCase(coachingRepo coaching.Repo, usersRepo users.Repo) *CreateInviteUseCase {
+	return &CreateInviteUseCase{
+		coachingRepo: coachingRepo,
+		usersRepo:    usersRepo,
+	}
+}
+
+func (uc *CreateInviteUseCase) Name() string {
+	return "Пригласить спортсмена"
+}
+
+func (uc *CreateInviteUseCase) Execute(coachID int64, scopes []string) (*dto.CoachInvite, error) {
+	coach, err := uc.usersRepo.GetByID(coachID)
+	if err != nil {
+		return nil, err
+	}
+	if !rbac.Allowed(coach, rbac.CoachAthletes) {
+		return nil, NotCoachErr
+	}
+
+	scopeList, err := normalizeScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+	code, err := newInviteCode()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	link := &models.CoachLink{
+		CoachID:    coachID,
+		InviteCode: &code,
+		Status:     models.CoachLinkPending,
+		Scopes:     scopeList,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(inviteTTL),
+	}
+	if err = uc.coachingRepo.Create(link); err != nil {
+		return nil, err
+	}
+
+	invite := mapInvite(*link)
+	return &invite, nil
+}
@@ -0,0 +1,69 @@
+package coaching
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/programs"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type CreateProgramUseCase struct {
+	programsRepo programs.Repo
+	usersRepo    users.Repo
+}
+
+func NewCreateProgramUseCase(programsRepo programs.Repo, usersRepo users.Repo) *CreateProgramUseCase {
+	return &CreateProgramUseCase{
+		programsRepo: programsRepo,
+		usersRepo:    usersRepo,
+	}
+}
+
+func (uc *CreateProgramUseCase) Name() string {
+	return "Составить программу для спортсмена"
+}
+
+// Execute создает программу от имени спортсмена. Дни и упражнения тренер затем заполняет
+// обычными ручками программ — validator пускает его туда по scope programs
+func (uc *CreateProgramUseCase) Execute(coachID, athleteID int64, name string) (*dto.ProgramDTO, error) {
+	athlete, err := uc.usersRepo.GetByID(athleteID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := uc.programsRepo.FindAll(athleteID)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = fmt.Sprintf("#%d", len(existing)+1)
+	}
+
+	program, err := uc.programsRepo.Create(athleteID, name)
+	if err != nil {
+		return nil, err
+	}
+	program.AssignedByID = &coachID
+	if err = uc.programsRepo.Save(program); err != nil {
+		return nil, err
+	}
+
+	// как и при обычном создании, первая программа сразу становится активной
+	if athlete.ActiveProgramID == nil {
+		athlete.ActiveProgramID = &program.ID
+		if err = uc.usersRepo.Save(athlete); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.ProgramDTO{
+		ID:           program.ID,
+		UserID:       program.UserID,
+		Name:         program.Name,
+		CreatedAt:    program.CreatedAt.Add(time.Hour * 3).Format("02.01.2006 15:04"),
+		DayTypes:     []*dto.WorkoutDayTypeDTO{},
+		IsActive:     athlete.ActiveProgramID != nil && *athlete.ActiveProgramID == program.ID,
+		AssignedByID: program.AssignedByID,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package coaching
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/coaching"
+)
+
+type GetOverviewUseCase struct {
+	coachingRepo coaching.Repo
+}
+
+func NewGetOverviewUseCase(coachingRepo coaching.Repo) *GetOverviewUseCase {
+	return &GetOverviewUseCase{
+		coachingRepo: coachingRepo,
+	}
+}
+
+func (uc *GetOverviewUseCase) Name() string {
+	return "Показать тренеров, спортсменов и приглашения"
+}
+
+func (uc *GetOverviewUseCase) Execute(userID int64) (*dto.CoachingOverview, error) {
+	asCoach, err := uc.coachingRepo.FindByCoach(userID)
+	if err != nil {
+		return nil, err
+	}
+	asAthlete, err := uc.coachingRepo.FindByAthlete(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.CoachingOverview{
+		Athletes: make([]dto.CoachLinkItem, 0),
+		Coaches:  make([]dto.CoachLinkItem, 0, len(asAthlete)),
+		Invites:  make([]dto.CoachInvite, 0),
+	}
+	for _, link := range asCoach {
+		if link.Status == models.CoachLinkPending {
+			result.Invites = append(result.Invites, mapInvite(link))
+			continue
+		}
+		result.Athletes = append(result.Athletes, dto.CoachLinkItem{
+			ID:         link.ID,
+			UserID:     *link.AthleteID,
+			Name:       displayName(link.Athlete),
+			Scopes:     link.ScopeList(),
+			AcceptedAt: link.AcceptedAt,
+		})
+	}
+	for _, link := range asAthlete {
+		result.Coaches = append(result.Coaches, dto.CoachLinkItem{
+			ID:         link.ID,
+			UserID:     link.CoachID,
+			Name:       displayName(link.Coach),
+			Scopes:     link.ScopeList(),
+			AcceptedAt: link.AcceptedAt,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,34 @@
+package coaching
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/coaching"
+)
+
+type RevokeLinkUseCase struct {
+	coachingRepo coaching.Repo
+}
+
+func NewRevokeLinkUseCase(coachingRepo coaching.Repo) *RevokeLinkUseCase {
+	return &RevokeLinkUseCase{
+		coachingRepo: coachingRepo,
+	}
+}
+
+func (uc *RevokeLinkUseCase) Name() string {
+	return "Прекратить работу с тренером"
+}
+
+// Execute разрывает связь или отменяет приглашение; сделать это может любая из сторон
+func (uc *RevokeLinkUseCase) Execute(userID, linkID int64) error {
+	link, err := uc.coachingRepo.GetByID(linkID)
+	if err != nil {
+		return err
+	}
+	isAthlete := link.AthleteID != nil && *link.AthleteID == userID
+	if link.CoachID != userID && !isAthlete {
+		return NotLinkParticipantErr
+	}
+	return uc.coachingRepo.Revoke(linkID, time.Now())
+}
@@ -0,0 +1,44 @@
+package coaching
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/coaching"
+)
+
+type UpdateScopesUseCase struct {
+	coachingRepo coaching.Repo
+}
+
+func NewUpdateScopesUseCase(coachingRepo coaching.Repo) *UpdateScopesUseCase {
+	return &UpdateScopesUseCase{
+		coachingRepo: coachingRepo,
+	}
+}
+
+func (uc *UpdateScopesUseCase) Name() string {
+	return "Изменить доступ тренера"
+}
+
+// Execute меняет области доступа тренера. Решает только спортсмен — это его данные
+func (uc *UpdateScopesUseCase) Execute(athleteID, linkID int64, scopes []string) ([]string, error) {
+	link, err := uc.coachingRepo.GetByID(linkID)
+	if err != nil {
+		return nil, err
+	}
+	if link.AthleteID == nil || *link.AthleteID != athleteID || !link.IsActive() {
+		return nil, NotLinkParticipantErr
+	}
+	// пустой список здесь не значит «все»: чтобы закрыть доступ полностью, связь отзывают
+	if len(scopes) == 0 {
+		return nil, InvalidScopesErr
+	}
+
+	scopeList, err := normalizeScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+	if err = uc.coachingRepo.UpdateScopes(linkID, scopeList); err != nil {
+		return nil, err
+	}
+	link.Scopes = scopeList
+	return link.ScopeList(), nil
+}
@@ -0,0 +1,54 @@
+package comments
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/comments"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type AddUseCase struct {
+	commentsRepo comments.Repo
+	usersRepo    users.Repo
+}
+
+func NewAddUseCase(commentsRepo comments.Repo, usersRepo users.Repo) *AddUseCase {
+	return &AddUseCase{
+		commentsRepo: commentsRepo,
+		usersRepo:    usersRepo,
+	}
+}
+
+func (uc *AddUseCase) Name() string {
+	return "Оставить комментарий к тренировке"
+}
+
+// Execute сохраняет комментарий; право комментировать проверяет вызывающая сторона.
+// public — комментарий оставлен по публичной ссылке и виден всем, у кого она есть
+func (uc *AddUseCase) Execute(authorID, workoutID int64, text string, public bool) (*dto.WorkoutCommentItem, error) {
+	text, err := normalizeText(text)
+	if err != nil {
+		return nil, err
+	}
+	author, err := uc.usersRepo.GetByID(authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &models.WorkoutComment{
+		WorkoutDayID: workoutID,
+		AuthorID:     authorID,
+		Text:         text,
+		Public:       public,
+		CreatedAt:    time.Now(),
+	}
+	if err = uc.commentsRepo.Create(comment); err != nil {
+		return nil, err
+	}
+	comment.Author = author
+
+	item := mapComment(*comment)
+	return &item, nil
+}
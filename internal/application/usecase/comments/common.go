@@ -0,0 +1,47 @@
+package comments
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+var (
+	EmptyCommentErr   = errors.New("comment text is empty")
+	TooLongCommentErr = errors.New("comment text is too long")
+	NotAllowedErr     = errors.New("user is not allowed to delete this comment")
+)
+
+const maxCommentLength = 2000
+
+func normalizeText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", EmptyCommentErr
+	}
+	if utf8.RuneCountInString(text) > maxCommentLength {
+		return "", TooLongCommentErr
+	}
+	return text, nil
+}
+
+func mapComment(c models.WorkoutComment) dto.WorkoutCommentItem {
+	item := dto.WorkoutCommentItem{
+		ID:        c.ID,
+		WorkoutID: c.WorkoutDayID,
+		AuthorID:  c.AuthorID,
+		Text:      c.Text,
+		Public:    c.Public,
+		CreatedAt: c.CreatedAt,
+	}
+	if c.Author != nil {
+		item.AuthorName = c.Author.ShortName()
+		if item.AuthorName == "" {
+			item.AuthorName = c.Author.Username
+		}
+	}
+	return item
+}
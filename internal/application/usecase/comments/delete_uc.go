@@ -0,0 +1,40 @@
+package comments
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/comments"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+type DeleteUseCase struct {
+	commentsRepo comments.Repo
+	workoutsRepo workouts.Repo
+}
+
+func NewDeleteUseCase(commentsRepo comments.Repo, workoutsRepo workouts.Repo) *DeleteUseCase {
+	return &DeleteUseCase{
+		commentsRepo: commentsRepo,
+		workoutsRepo: workoutsRepo,
+	}
+}
+
+func (uc *DeleteUseCase) Name() string {
+	return "Удалить комментарий к тренировке"
+}
+
+// Execute удаляет комментарий: свой — автор, любой — владелец тренировки
+func (uc *DeleteUseCase) Execute(userID, commentID int64) error {
+	comment, err := uc.commentsRepo.Get(commentID)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != userID {
+		workout, err := uc.workoutsRepo.Get(comment.WorkoutDayID)
+		if err != nil {
+			return err
+		}
+		if workout.UserID != userID {
+			return NotAllowedErr
+		}
+	}
+	return uc.commentsRepo.Delete(commentID)
+}
@@ -0,0 +1,32 @@
+package comments
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/comments"
+)
+
+type FindByWorkoutUseCase struct {
+	commentsRepo comments.Repo
+}
+
+func NewFindByWorkoutUseCase(commentsRepo comments.Repo) *FindByWorkoutUseCase {
+	return &FindByWorkoutUseCase{
+		commentsRepo: commentsRepo,
+	}
+}
+
+func (uc *FindByWorkoutUseCase) Name() string {
+	return "Показать комментарии к тренировке"
+}
+
+func (uc *FindByWorkoutUseCase) Execute(workoutID int64) ([]dto.WorkoutCommentItem, error) {
+	found, err := uc.commentsRepo.FindByWorkout(workoutID, false)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]dto.WorkoutCommentItem, 0, len(found))
+	for _, c := range found {
+		items = append(items, mapComment(c))
+	}
+	return items, nil
+}
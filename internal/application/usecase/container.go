@@ -1,25 +1,75 @@
 package usecase
 
 import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/accesstokens"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/achievements"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/auditlog"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/authsessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/challenges"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/coaching"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/comments"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/credentials"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/feed"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/follows"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/identities"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/measurements"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/oauthlogins"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/outbox"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/ownership"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/pushsubscriptions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/reactions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/records"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/revokedtokens"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/rollups"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/share"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/syncmutations"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/webhooks"
+	achievementsservice "github.com/SaenkoDmitry/training-tg-bot/internal/service/achievements"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/denylist"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/eventbus"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/leaderboard"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/limiter"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/mailer"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/oauth"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/push"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/realtime"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/sharenotify"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/service/timer"
+	webhookservice "github.com/SaenkoDmitry/training-tg-bot/internal/service/webhooks"
 	"gorm.io/gorm"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	accesstokenusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/accesstokens"
+	achievementusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/achievements"
+	adminusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/admin"
+	authsessionusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/authsessions"
+	challengeusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/challenges"
+	coachingusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/coaching"
+	commentusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/comments"
 	daytypeusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/daytypes"
+	emailauthusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/emailauth"
 	exerciseusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/exercises"
 	exportusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/exports"
+	graphusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/graph"
 	groupusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/groups"
 	measurementsusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/measurements"
+	oauthusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/oauth"
+	offlinesyncusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/offlinesync"
+	ownershipusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/ownership"
 	programusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/programs"
+	publicapiusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/publicapi"
 	pushsubscriptionsusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/pushsubscriptions"
+	recordusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/records"
 	sessionusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/session"
 	setusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/sets"
 	shareusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/share"
+	socialusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/social"
 	statsusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/stats"
 	timerusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/timers"
 	userusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/users"
+	webhookusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/webhooks"
 	workoutusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/workouts"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/daytypes"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisegrouptypes"
@@ -29,6 +79,7 @@ import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sessions"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sets"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workoutedits"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/service/docgenerator"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/service/summary"
@@ -47,6 +98,12 @@ type Container struct {
 	FinishWorkoutUC        *workoutusecases.FinishUseCase
 	FindWorkoutsByUserUC   *workoutusecases.FindByUserIDUseCase
 	StatsWorkoutUC         *workoutusecases.StatsUseCase
+	LogPastWorkoutUC       *workoutusecases.LogPastUseCase
+	ReopenWorkoutUC        *workoutusecases.ReopenUseCase
+	UpdateWorkoutTimesUC   *workoutusecases.UpdateTimesUseCase
+	FindWorkoutEditsUC     *workoutusecases.FindEditsUseCase
+	AutoFinishWorkoutsUC   *workoutusecases.AutoFinishUseCase
+	UndoAutoFinishUC       *workoutusecases.UndoAutoFinishUseCase
 
 	// exercises
 	ShowCurrentExerciseSessionUC *sessionusecases.ShowCurrentExerciseSessionUseCase
@@ -66,8 +123,10 @@ type Container struct {
 	ExportMeasurementsToExcelUC *exportusecases.ExportMeasurementsToExcelUseCase
 
 	// stats
-	PeriodStatsUC   *statsusecases.GetPeriodStatsUseCase
-	ExerciseStatsUC *statsusecases.GetExercisesStatsUseCase
+	PeriodStatsUC     *statsusecases.GetPeriodStatsUseCase
+	ExerciseStatsUC   *statsusecases.GetExercisesStatsUseCase
+	WeeklyStatsUC     *statsusecases.GetWeeklyStatsUseCase
+	BackfillRollupsUC *statsusecases.BackfillRollupsUseCase
 
 	// sets
 	CompleteLastSetUC *setusecases.CompleteLastUseCase
@@ -111,10 +170,71 @@ type Container struct {
 	FindUserUC    *userusecases.FindUseCase
 	ChangeIconUC  *userusecases.ChangeIconUseCase
 
+	// admin
+	AdminFindUsersUC          *adminusecases.FindUsersUseCase
+	AdminGetUsageStatsUC      *adminusecases.GetUsageStatsUseCase
+	AdminSetRoleUC            *adminusecases.SetRoleUseCase
+	AdminDisableUserUC        *adminusecases.DisableUserUseCase
+	AdminEnableUserUC         *adminusecases.EnableUserUseCase
+	AdminCreateExerciseTypeUC *adminusecases.CreateExerciseTypeUseCase
+	AdminUpdateExerciseTypeUC *adminusecases.UpdateExerciseTypeUseCase
+	AdminDeleteExerciseTypeUC *adminusecases.DeleteExerciseTypeUseCase
+	AdminFindAuditLogUC       *adminusecases.FindAuditLogUseCase
+
+	// coaching
+	CreateCoachInviteUC    *coachingusecases.CreateInviteUseCase
+	AcceptCoachInviteUC    *coachingusecases.AcceptInviteUseCase
+	GetCoachingOverviewUC  *coachingusecases.GetOverviewUseCase
+	RevokeCoachLinkUC      *coachingusecases.RevokeLinkUseCase
+	UpdateCoachScopesUC    *coachingusecases.UpdateScopesUseCase
+	CheckCoachAccessUC     *coachingusecases.CheckAccessUseCase
+	CreateAthleteProgramUC *coachingusecases.CreateProgramUseCase
+	AssignAthleteProgramUC *coachingusecases.AssignProgramUseCase
+
+	// доступ к тренировкам, программам и замерам по владельцу
+	CheckResourceAccessUC *ownershipusecases.CheckAccessUseCase
+
+	// workout comments
+	AddWorkoutCommentUC    *commentusecases.AddUseCase
+	FindWorkoutCommentsUC  *commentusecases.FindByWorkoutUseCase
+	DeleteWorkoutCommentUC *commentusecases.DeleteUseCase
+
 	// auth
 	GetOrCreateUserByTelegramUC *userusecases.GetOrCreateUserByTelegramUseCase
 	GetOrCreateUserByYandexUC   *userusecases.GetOrCreateUserByYandexUseCase
 
+	// auth sessions
+	StartAuthSessionUC   *authsessionusecases.StartUseCase
+	RefreshAuthSessionUC *authsessionusecases.RefreshUseCase
+	LogoutUC             *authsessionusecases.LogoutUseCase
+	FindAuthSessionsUC   *authsessionusecases.FindUseCase
+	RevokeAuthSessionUC  *authsessionusecases.RevokeUseCase
+	RevokeOtherSessionUC *authsessionusecases.RevokeOthersUseCase
+	TokenDenylist        *denylist.Denylist
+
+	// email / password and magic link login
+	RegisterByEmailUC       *emailauthusecases.RegisterUseCase
+	LoginByEmailUC          *emailauthusecases.LoginUseCase
+	SendEmailVerificationUC *emailauthusecases.SendVerificationUseCase
+	VerifyEmailUC           *emailauthusecases.VerifyEmailUseCase
+	RequestMagicLinkUC      *emailauthusecases.RequestMagicLinkUseCase
+	ConsumeMagicLinkUC      *emailauthusecases.ConsumeMagicLinkUseCase
+	RequestPasswordResetUC  *emailauthusecases.RequestPasswordResetUseCase
+	ResetPasswordUC         *emailauthusecases.ResetPasswordUseCase
+	LinkEmailUC             *emailauthusecases.LinkEmailUseCase
+
+	// account linking
+	LinkTelegramUC       *userusecases.LinkTelegramUseCase
+	LinkYandexUC         *userusecases.LinkYandexUseCase
+	UnlinkUC             *userusecases.UnlinkUseCase
+	GetLinkedProvidersUC *userusecases.GetLinkedProvidersUseCase
+
+	// oauth / oidc providers
+	ListOAuthProvidersUC *oauthusecases.ListProvidersUseCase
+	BeginOAuthUC         *oauthusecases.BeginUseCase
+	CompleteOAuthUC      *oauthusecases.CompleteUseCase
+	YandexProvider       *oauth.YandexProvider
+
 	// measurements
 	CreateMeasurementUC     *measurementsusecases.CreateUseCase
 	FindAllMeasurementsUC   *measurementsusecases.FindAllByUserUseCase
@@ -129,6 +249,89 @@ type Container struct {
 	CreateShareUC              *shareusecases.CreateShareUC
 	GetShareUC                 *shareusecases.GetShareUC
 	GetShareByWorkoutUC        *shareusecases.GetShareByWorkoutUC
+
+	// reactions and comments on shared workouts
+	GetShareSocialUC          *shareusecases.GetSocialUC
+	ReactToShareUC            *shareusecases.ReactUC
+	RemoveShareReactionUC     *shareusecases.RemoveReactionUC
+	CommentShareUC            *shareusecases.CommentUC
+	SetShareCommentsEnabledUC *shareusecases.SetCommentsEnabledUC
+	ShareNotifications        *sharenotify.Dispatcher
+
+	// followers, privacy and activity feed
+	FollowUC           *socialusecases.FollowUseCase
+	UnfollowUC         *socialusecases.UnfollowUseCase
+	AcceptFollowerUC   *socialusecases.AcceptFollowerUseCase
+	RemoveFollowerUC   *socialusecases.RemoveFollowerUseCase
+	FindFollowersUC    *socialusecases.FindFollowersUseCase
+	FindFollowingUC    *socialusecases.FindFollowingUseCase
+	GetPrivacyUC       *socialusecases.GetPrivacyUseCase
+	UpdatePrivacyUC    *socialusecases.UpdatePrivacyUseCase
+	GetFeedUC          *socialusecases.GetFeedUseCase
+	GetSocialProfileUC *socialusecases.GetProfileUseCase
+	BuildFeedDigestsUC *socialusecases.BuildDigestsUseCase
+
+	// challenges and leaderboards
+	CreateChallengeUC    *challengeusecases.CreateUseCase
+	GetChallengeInviteUC *challengeusecases.GetInviteUseCase
+	JoinChallengeUC      *challengeusecases.JoinUseCase
+	LeaveChallengeUC     *challengeusecases.LeaveUseCase
+	DeleteChallengeUC    *challengeusecases.DeleteUseCase
+	FindMyChallengesUC   *challengeusecases.FindMyUseCase
+	GetChallengeUC       *challengeusecases.GetUseCase
+	FinishChallengesUC   *challengeusecases.FinishUseCase
+	ChallengeTracker     *leaderboard.Tracker
+
+	// achievements and streaks
+	FindMyAchievementsUC   *achievementusecases.FindMyUseCase
+	BackfillAchievementsUC *achievementusecases.BackfillUseCase
+	Achievements           *achievementsservice.Engine
+
+	// domain events
+	EventBus    *eventbus.Bus
+	OutboxRelay *eventbus.Relay
+
+	// webhooks
+	CreateWebhookUC         *webhookusecases.CreateUseCase
+	FindMyWebhooksUC        *webhookusecases.FindMyUseCase
+	UpdateWebhookUC         *webhookusecases.UpdateUseCase
+	DeleteWebhookUC         *webhookusecases.DeleteUseCase
+	FindWebhookDeliveriesUC *webhookusecases.FindDeliveriesUseCase
+	SendTestWebhookUC       *webhookusecases.SendTestUseCase
+	FindWebhookEventTypesUC *webhookusecases.FindEventTypesUseCase
+	WebhookDispatcher       *webhookservice.Dispatcher
+
+	// personal access tokens
+	CreateAccessTokenUC       *accesstokenusecases.CreateUseCase
+	FindMyAccessTokensUC      *accesstokenusecases.FindMyUseCase
+	RevokeAccessTokenUC       *accesstokenusecases.RevokeUseCase
+	FindAccessTokenScopesUC   *accesstokenusecases.FindScopesUseCase
+	AuthenticateAccessTokenUC *accesstokenusecases.AuthenticateUseCase
+
+	// public api v1
+	APIFindWorkoutsUC      *publicapiusecases.FindWorkoutsUseCase
+	APIGetWorkoutUC        *publicapiusecases.GetWorkoutUseCase
+	APIFindMeasurementsUC  *publicapiusecases.FindMeasurementsUseCase
+	APICreateMeasurementUC *publicapiusecases.CreateMeasurementUseCase
+
+	// graphql
+	GraphFindWorkoutsUC         *graphusecases.FindWorkoutsUseCase
+	GraphFindUserWorkoutsUC     *graphusecases.FindUserWorkoutsUseCase
+	GraphFindExercisesUC        *graphusecases.FindExercisesUseCase
+	GraphFindExerciseHistoryUC  *graphusecases.FindExerciseHistoryUseCase
+	GraphFindExerciseTypesUC    *graphusecases.FindExerciseTypesUseCase
+	GraphFindSetsUC             *graphusecases.FindSetsUseCase
+	GraphFindProgramsUC         *graphusecases.FindProgramsUseCase
+	GraphFindUserProgramsUC     *graphusecases.FindUserProgramsUseCase
+	GraphFindDayTypesUC         *graphusecases.FindDayTypesUseCase
+	GraphFindProgramDayTypesUC  *graphusecases.FindProgramDayTypesUseCase
+	GraphFindUserMeasurementsUC *graphusecases.FindUserMeasurementsUseCase
+
+	// offline sync
+	ApplySyncUC *offlinesyncusecases.ApplyUseCase
+
+	// realtime
+	WorkoutEventsHub *realtime.Hub
 }
 
 func NewContainer(db *gorm.DB) *Container {
@@ -144,24 +347,79 @@ func NewContainer(db *gorm.DB) *Container {
 	measurementsRepo := measurements.NewRepo(db)
 	pushSubscriptionsRepo := pushsubscriptions.NewRepo(db)
 	shareRepo := share.NewRepo(db)
+	syncMutationsRepo := syncmutations.NewRepo(db)
+	workoutEditsRepo := workoutedits.NewRepo(db)
+	rollupsRepo := rollups.NewRepo(db)
 
 	timerStore := timer.NewStore()
+	workoutEventsHub := realtime.NewHub()
+	authSessionsRepo := authsessions.NewRepo(db)
+	accessTokensRepo := accesstokens.NewRepo(db)
+	tokenDenylist := denylist.New(revokedtokens.NewRepo(db))
+	revokeAllSessionsUC := authsessionusecases.NewRevokeAllUseCase(authSessionsRepo, tokenDenylist)
+	auditLogRepo := auditlog.NewRepo(db)
+	coachingRepo := coaching.NewRepo(db)
+	checkCoachAccessUC := coachingusecases.NewCheckAccessUseCase(coachingRepo, usersRepo)
+	commentsRepo := comments.NewRepo(db)
+	reactionsRepo := reactions.NewRepo(db)
+	addCommentUC := commentusecases.NewAddUseCase(commentsRepo, usersRepo)
+	shareNotifications := sharenotify.NewDispatcher(sharenotify.NewPushNotifier(push.NewService(db)))
+	getShareSocialUC := shareusecases.NewGetSocialUC(shareRepo, reactionsRepo, commentsRepo, workoutsRepo)
+	followsRepo := follows.NewRepo(db)
+	feedRepo := feed.NewRepo(db)
+	challengesRepo := challenges.NewRepo(db)
+	challengeTracker := leaderboard.NewTracker(challengeusecases.NewRecalculateUseCase(challengesRepo))
+	workoutEventsHub.AddListener(challengeTracker.Listen)
+	achievementsRepo := achievements.NewRepo(db)
+	achievementsEngine := achievementsservice.NewEngine(achievementusecases.NewEvaluateUseCase(achievementsRepo, usersRepo),
+		achievementsservice.NewPushNotifier(push.NewService(db)))
+	outboxRepo := outbox.NewRepo(db)
+	webhooksRepo := webhooks.NewRepo(db)
+	webhookSender := webhookservice.NewSender(webhookservice.ConfigFromEnv().AllowPrivate)
+	eventBus := eventbus.NewBus()
+	eventBus.Subscribe("achievements", achievementsEngine.Handle, achievementsservice.Subscriptions()...)
+	eventBus.Subscribe("push.rest_timer", push.NewService(db).HandleRestTimerFinished, events.TypeRestTimerFinished)
+	eventBus.Subscribe("stats.rollups", statsusecases.NewRecalculateRollupsUseCase(workoutsRepo, rollupsRepo).Handle,
+		events.TypeSetCompleted, events.TypeWorkoutFinished, events.TypeWorkoutChanged, events.TypeUsersMerged)
+	recordsRepo := records.NewRepo(db)
+	eventBus.Subscribe("records", recordusecases.NewDetectUseCase(recordsRepo, outboxRepo).Handle, events.TypeWorkoutFinished)
+	eventBus.Subscribe("feed.records", recordusecases.NewRecalculateUseCase(recordsRepo).Handle,
+		events.TypeWorkoutFinished, events.TypeWorkoutChanged, events.TypeUsersMerged)
+	eventBus.Subscribe("achievements.merge", achievementusecases.NewBackfillUseCase(achievementsRepo).Handle, events.TypeUsersMerged)
+	eventBus.Subscribe("webhooks", webhookusecases.NewEnqueueUseCase(webhooksRepo, workoutsRepo, measurementsRepo).Handle,
+		webhookusecases.Subscriptions()...)
+	identitiesRepo := identities.NewRepo(db)
+	oauthRegistry := oauth.RegistryFromEnv()
+	oauthStates := oauth.NewStateStore(oauthlogins.NewRepo(db))
+	credentialsRepo := credentials.NewRepo(db)
+	emailMailer := mailer.FromEnv()
+	mailLimiter := limiter.NewRateLimiter(3, 15*time.Minute)
+	loginEmailLimiter := limiter.NewRateLimiter(5, 15*time.Minute)
+	loginIPLimiter := limiter.NewRateLimiter(30, 15*time.Minute)
 	summaryService := summary.NewService()
 	docGeneratorService := docgenerator.NewService(summaryService)
 
+	createWorkoutUC := workoutusecases.NewCreateUseCase(workoutsRepo, exercisesRepo, usersRepo, dayTypesRepo)
+
 	return &Container{
 
 		// workouts
-		DeleteWorkoutUC:        workoutusecases.NewDeleteUseCase(workoutsRepo, setsRepo, exercisesRepo),
+		DeleteWorkoutUC:        workoutusecases.NewDeleteUseCase(workoutsRepo, setsRepo, exercisesRepo, rollupsRepo),
 		ConfirmDeleteWorkoutUC: workoutusecases.NewConfirmDeleteUseCase(workoutsRepo, dayTypesRepo),
-		CreateWorkoutUC:        workoutusecases.NewCreateUseCase(workoutsRepo, exercisesRepo, usersRepo, dayTypesRepo),
+		CreateWorkoutUC:        createWorkoutUC,
 		StartWorkoutUC:         workoutusecases.NewStartUseCase(workoutsRepo, sessionsRepo),
 		FindMyWorkoutsUC:       workoutusecases.NewFindMyUseCase(workoutsRepo, usersRepo),
 		ShowWorkoutProgressUC:  workoutusecases.NewShowProgressUseCase(workoutsRepo, sessionsRepo, exerciseGroupTypesRepo),
 		ConfirmFinishWorkoutUC: workoutusecases.NewConfirmFinishUseCase(workoutsRepo, dayTypesRepo),
-		FinishWorkoutUC:        workoutusecases.NewFinishUseCase(workoutsRepo, sessionsRepo),
+		FinishWorkoutUC:        workoutusecases.NewFinishUseCase(workoutsRepo, sessionsRepo, workoutEditsRepo),
 		FindWorkoutsByUserUC:   workoutusecases.NewFindByUserUseCase(workoutsRepo, usersRepo),
 		StatsWorkoutUC:         workoutusecases.NewStatsUseCase(workoutsRepo, dayTypesRepo, exerciseTypesRepo, exerciseGroupTypesRepo),
+		LogPastWorkoutUC:       workoutusecases.NewLogPastUseCase(createWorkoutUC, newLogPastTx(db)),
+		ReopenWorkoutUC:        workoutusecases.NewReopenUseCase(workoutsRepo, sessionsRepo, workoutEditsRepo),
+		UpdateWorkoutTimesUC:   workoutusecases.NewUpdateTimesUseCase(workoutsRepo, sessionsRepo, workoutEditsRepo),
+		FindWorkoutEditsUC:     workoutusecases.NewFindEditsUseCase(workoutEditsRepo),
+		AutoFinishWorkoutsUC:   workoutusecases.NewAutoFinishUseCase(workoutsRepo, sessionsRepo, workoutEditsRepo),
+		UndoAutoFinishUC:       workoutusecases.NewUndoAutoFinishUseCase(workoutsRepo, sessionsRepo, workoutEditsRepo),
 
 		// exercises
 		ExerciseTypeListUC:      exerciseusecases.NewExerciseTypeListUseCase(exerciseTypesRepo),
@@ -178,23 +436,25 @@ func NewContainer(db *gorm.DB) *Container {
 
 		// exports
 		ExportWorkoutsToExcelUC: exportusecases.NewExportWorkoutsToExcelUseCase(usersRepo, exerciseGroupTypesRepo, workoutsRepo,
-			exercisesRepo, summaryService, docGeneratorService),
+			exercisesRepo, rollupsRepo, summaryService, docGeneratorService),
 		ExportMeasurementsToExcelUC: exportusecases.NewExportMeasurementsToExcelUseCase(usersRepo, measurementsRepo, docGeneratorService),
 
 		// stats
-		PeriodStatsUC:   statsusecases.NewGetPeriodStatsUseCase(usersRepo, workoutsRepo),
-		ExerciseStatsUC: statsusecases.NewGetExercisesStatsUseCase(usersRepo, exercisesRepo),
+		PeriodStatsUC:     statsusecases.NewGetPeriodStatsUseCase(usersRepo, workoutsRepo),
+		ExerciseStatsUC:   statsusecases.NewGetExercisesStatsUseCase(usersRepo, exercisesRepo),
+		WeeklyStatsUC:     statsusecases.NewGetWeeklyStatsUseCase(rollupsRepo, exerciseGroupTypesRepo),
+		BackfillRollupsUC: statsusecases.NewBackfillRollupsUseCase(rollupsRepo),
 
 		// sets
-		CompleteLastSetUC: setusecases.NewCompleteLastUseCase(setsRepo, exercisesRepo, exerciseTypesRepo),
-		AddOneMoreSetUC:   setusecases.NewAddOneMoreUseCase(setsRepo, exercisesRepo),
-		RemoveLastSetUC:   setusecases.NewRemoveLastUseCase(setsRepo, exercisesRepo),
-		UpdateNextSetUC:   setusecases.NewUpdateNextUseCase(setsRepo, exercisesRepo),
+		CompleteLastSetUC: setusecases.NewCompleteLastUseCase(setsRepo, exercisesRepo, exerciseTypesRepo, workoutEventsHub),
+		AddOneMoreSetUC:   setusecases.NewAddOneMoreUseCase(setsRepo, exercisesRepo, workoutEventsHub),
+		RemoveLastSetUC:   setusecases.NewRemoveLastUseCase(setsRepo, exercisesRepo, workoutEventsHub),
+		UpdateNextSetUC:   setusecases.NewUpdateNextUseCase(setsRepo, exercisesRepo, workoutEventsHub),
 
-		CompleteByIDSetUC: setusecases.NewCompleteByIDUseCase(setsRepo),
+		CompleteByIDSetUC: setusecases.NewCompleteByIDUseCase(setsRepo, workoutEventsHub),
 		GetSetByIDUC:      setusecases.NewGetByIDUseCase(setsRepo),
-		RemoveSetByIDUC:   setusecases.NewRemoveByIDUseCase(setsRepo),
-		UpdateSetByIDUC:   setusecases.NewUpdateByIDUseCase(setsRepo),
+		RemoveSetByIDUC:   setusecases.NewRemoveByIDUseCase(setsRepo, workoutEventsHub),
+		UpdateSetByIDUC:   setusecases.NewUpdateByIDUseCase(setsRepo, workoutEventsHub),
 
 		// programs
 		DeleteProgramUC:         programusecases.NewDeleteUseCase(programsRepo, usersRepo),
@@ -219,8 +479,8 @@ func NewContainer(db *gorm.DB) *Container {
 		// sessions
 		ShowCurrentExerciseSessionUC: sessionusecases.NewShowCurrentExerciseUseCase(
 			workoutsRepo, sessionsRepo, exerciseTypesRepo, dayTypesRepo, exerciseGroupTypesRepo),
-		MoveSessionToExerciseUC: sessionusecases.NewMoveToExerciseUseCase(sessionsRepo, exercisesRepo),
-		MoveToCertainUC:         sessionusecases.NewMoveToCertainUseCase(sessionsRepo, exercisesRepo),
+		MoveSessionToExerciseUC: sessionusecases.NewMoveToExerciseUseCase(sessionsRepo, exercisesRepo, workoutEventsHub),
+		MoveToCertainUC:         sessionusecases.NewMoveToCertainUseCase(sessionsRepo, exercisesRepo, workoutEventsHub),
 
 		// users
 		CreateUserUC:  userusecases.NewCreateUseCase(usersRepo, programsRepo),
@@ -229,10 +489,72 @@ func NewContainer(db *gorm.DB) *Container {
 		GetUserByIDUC: userusecases.NewGetByIDUseCase(usersRepo),
 		ChangeIconUC:  userusecases.NewChangeIconUseCase(usersRepo),
 
+		// admin
+		AdminFindUsersUC:          adminusecases.NewFindUsersUseCase(usersRepo),
+		AdminGetUsageStatsUC:      adminusecases.NewGetUsageStatsUseCase(usersRepo),
+		AdminSetRoleUC:            adminusecases.NewSetRoleUseCase(usersRepo, auditLogRepo),
+		AdminDisableUserUC:        adminusecases.NewDisableUserUseCase(usersRepo, auditLogRepo, revokeAllSessionsUC),
+		AdminEnableUserUC:         adminusecases.NewEnableUserUseCase(usersRepo, auditLogRepo),
+		AdminCreateExerciseTypeUC: adminusecases.NewCreateExerciseTypeUseCase(exerciseTypesRepo, exerciseGroupTypesRepo, auditLogRepo),
+		AdminUpdateExerciseTypeUC: adminusecases.NewUpdateExerciseTypeUseCase(exerciseTypesRepo, exerciseGroupTypesRepo, auditLogRepo),
+		AdminDeleteExerciseTypeUC: adminusecases.NewDeleteExerciseTypeUseCase(exerciseTypesRepo, auditLogRepo),
+		AdminFindAuditLogUC:       adminusecases.NewFindAuditLogUseCase(auditLogRepo),
+
+		// coaching
+		CreateCoachInviteUC:    coachingusecases.NewCreateInviteUseCase(coachingRepo, usersRepo),
+		AcceptCoachInviteUC:    coachingusecases.NewAcceptInviteUseCase(coachingRepo),
+		GetCoachingOverviewUC:  coachingusecases.NewGetOverviewUseCase(coachingRepo),
+		RevokeCoachLinkUC:      coachingusecases.NewRevokeLinkUseCase(coachingRepo),
+		UpdateCoachScopesUC:    coachingusecases.NewUpdateScopesUseCase(coachingRepo),
+		CheckCoachAccessUC:     checkCoachAccessUC,
+		CreateAthleteProgramUC: coachingusecases.NewCreateProgramUseCase(programsRepo, usersRepo),
+		AssignAthleteProgramUC: coachingusecases.NewAssignProgramUseCase(programsRepo, usersRepo),
+
+		CheckResourceAccessUC: ownershipusecases.NewCheckAccessUseCase(ownership.NewRepo(db), checkCoachAccessUC),
+
+		// workout comments
+		AddWorkoutCommentUC:    addCommentUC,
+		FindWorkoutCommentsUC:  commentusecases.NewFindByWorkoutUseCase(commentsRepo),
+		DeleteWorkoutCommentUC: commentusecases.NewDeleteUseCase(commentsRepo, workoutsRepo),
+
 		// auth
 		GetOrCreateUserByTelegramUC: userusecases.NewGetOrCreateUserByTelegramUseCase(usersRepo),
 		GetOrCreateUserByYandexUC:   userusecases.NewGetOrCreateUserByYandexUseCase(usersRepo),
 
+		// auth sessions
+		StartAuthSessionUC:   authsessionusecases.NewStartUseCase(authSessionsRepo, usersRepo),
+		RefreshAuthSessionUC: authsessionusecases.NewRefreshUseCase(authSessionsRepo, tokenDenylist),
+		LogoutUC:             authsessionusecases.NewLogoutUseCase(authSessionsRepo, tokenDenylist),
+		FindAuthSessionsUC:   authsessionusecases.NewFindUseCase(authSessionsRepo),
+		RevokeAuthSessionUC:  authsessionusecases.NewRevokeUseCase(authSessionsRepo, tokenDenylist),
+		RevokeOtherSessionUC: authsessionusecases.NewRevokeOthersUseCase(authSessionsRepo, tokenDenylist),
+		TokenDenylist:        tokenDenylist,
+
+		// email / password and magic link login
+		RegisterByEmailUC:       emailauthusecases.NewRegisterUseCase(credentialsRepo, emailMailer, mailLimiter),
+		LoginByEmailUC:          emailauthusecases.NewLoginUseCase(credentialsRepo, loginEmailLimiter, loginIPLimiter),
+		SendEmailVerificationUC: emailauthusecases.NewSendVerificationUseCase(credentialsRepo, emailMailer, mailLimiter),
+		VerifyEmailUC:           emailauthusecases.NewVerifyEmailUseCase(credentialsRepo, usersRepo, identitiesRepo, revokeAllSessionsUC),
+		RequestMagicLinkUC:      emailauthusecases.NewRequestMagicLinkUseCase(credentialsRepo, emailMailer, mailLimiter),
+		ConsumeMagicLinkUC:      emailauthusecases.NewConsumeMagicLinkUseCase(credentialsRepo, usersRepo, identitiesRepo, revokeAllSessionsUC),
+		RequestPasswordResetUC:  emailauthusecases.NewRequestPasswordResetUseCase(credentialsRepo, emailMailer, mailLimiter),
+		ResetPasswordUC:         emailauthusecases.NewResetPasswordUseCase(credentialsRepo, usersRepo, identitiesRepo, revokeAllSessionsUC),
+		LinkEmailUC:             emailauthusecases.NewLinkEmailUseCase(credentialsRepo, emailMailer, mailLimiter),
+
+		// account linking
+		LinkTelegramUC:       userusecases.NewLinkTelegramUseCase(usersRepo, identitiesRepo),
+		LinkYandexUC:         userusecases.NewLinkYandexUseCase(usersRepo, identitiesRepo),
+		UnlinkUC:             userusecases.NewUnlinkUseCase(usersRepo, identitiesRepo),
+		GetLinkedProvidersUC: userusecases.NewGetLinkedProvidersUseCase(usersRepo, identitiesRepo),
+
+		// oauth / oidc providers
+		ListOAuthProvidersUC: oauthusecases.NewListProvidersUseCase(oauthRegistry),
+		BeginOAuthUC:         oauthusecases.NewBeginUseCase(oauthRegistry, oauthStates),
+		CompleteOAuthUC: oauthusecases.NewCompleteUseCase(oauthRegistry, oauthStates,
+			userusecases.NewGetOrCreateUserByIdentityUseCase(usersRepo, identitiesRepo),
+			userusecases.NewLinkIdentityUseCase(usersRepo, identitiesRepo)),
+		YandexProvider: oauth.NewYandexProviderFromEnv(),
+
 		// measurements
 		CreateMeasurementUC:     measurementsusecases.NewCreateUseCase(measurementsRepo),
 		FindAllMeasurementsUC:   measurementsusecases.NewFindAllByUserUseCase(measurementsRepo, usersRepo),
@@ -248,5 +570,126 @@ func NewContainer(db *gorm.DB) *Container {
 		CreateShareUC:       shareusecases.NewCreateShareUC(shareRepo),
 		GetShareUC:          shareusecases.NewGetShareUC(shareRepo),
 		GetShareByWorkoutUC: shareusecases.NewGetShareByWorkoutUC(shareRepo),
+
+		// reactions and comments on shared workouts
+		GetShareSocialUC: getShareSocialUC,
+		ReactToShareUC: shareusecases.NewReactUC(shareRepo, reactionsRepo, workoutsRepo, usersRepo,
+			getShareSocialUC, shareNotifications),
+		RemoveShareReactionUC:     shareusecases.NewRemoveReactionUC(shareRepo, reactionsRepo, getShareSocialUC),
+		CommentShareUC:            shareusecases.NewCommentUC(shareRepo, workoutsRepo, addCommentUC, shareNotifications),
+		SetShareCommentsEnabledUC: shareusecases.NewSetCommentsEnabledUC(shareRepo),
+		ShareNotifications:        shareNotifications,
+
+		// followers, privacy and activity feed
+		FollowUC:           socialusecases.NewFollowUseCase(followsRepo, usersRepo),
+		UnfollowUC:         socialusecases.NewUnfollowUseCase(followsRepo),
+		AcceptFollowerUC:   socialusecases.NewAcceptFollowerUseCase(followsRepo),
+		RemoveFollowerUC:   socialusecases.NewRemoveFollowerUseCase(followsRepo),
+		FindFollowersUC:    socialusecases.NewFindFollowersUseCase(followsRepo),
+		FindFollowingUC:    socialusecases.NewFindFollowingUseCase(followsRepo),
+		GetPrivacyUC:       socialusecases.NewGetPrivacyUseCase(usersRepo),
+		UpdatePrivacyUC:    socialusecases.NewUpdatePrivacyUseCase(usersRepo),
+		GetFeedUC:          socialusecases.NewGetFeedUseCase(feedRepo, usersRepo),
+		GetSocialProfileUC: socialusecases.NewGetProfileUseCase(usersRepo, followsRepo, feedRepo),
+		BuildFeedDigestsUC: socialusecases.NewBuildDigestsUseCase(usersRepo, feedRepo),
+
+		// challenges and leaderboards
+		CreateChallengeUC:    challengeusecases.NewCreateUseCase(challengesRepo, exerciseTypesRepo),
+		GetChallengeInviteUC: challengeusecases.NewGetInviteUseCase(challengesRepo),
+		JoinChallengeUC:      challengeusecases.NewJoinUseCase(challengesRepo),
+		LeaveChallengeUC:     challengeusecases.NewLeaveUseCase(challengesRepo),
+		DeleteChallengeUC:    challengeusecases.NewDeleteUseCase(challengesRepo),
+		FindMyChallengesUC:   challengeusecases.NewFindMyUseCase(challengesRepo),
+		GetChallengeUC:       challengeusecases.NewGetUseCase(challengesRepo),
+		FinishChallengesUC:   challengeusecases.NewFinishUseCase(challengesRepo),
+		ChallengeTracker:     challengeTracker,
+
+		// achievements and streaks
+		FindMyAchievementsUC:   achievementusecases.NewFindMyUseCase(achievementsRepo),
+		BackfillAchievementsUC: achievementusecases.NewBackfillUseCase(achievementsRepo),
+		Achievements:           achievementsEngine,
+
+		// domain events
+		EventBus:    eventBus,
+		OutboxRelay: eventbus.NewRelay(outboxRepo, eventBus),
+
+		// webhooks
+		CreateWebhookUC:         webhookusecases.NewCreateUseCase(webhooksRepo),
+		FindMyWebhooksUC:        webhookusecases.NewFindMyUseCase(webhooksRepo),
+		UpdateWebhookUC:         webhookusecases.NewUpdateUseCase(webhooksRepo),
+		DeleteWebhookUC:         webhookusecases.NewDeleteUseCase(webhooksRepo),
+		FindWebhookDeliveriesUC: webhookusecases.NewFindDeliveriesUseCase(webhooksRepo),
+		SendTestWebhookUC:       webhookusecases.NewSendTestUseCase(webhooksRepo, webhookSender),
+		FindWebhookEventTypesUC: webhookusecases.NewFindEventTypesUseCase(),
+		WebhookDispatcher:       webhookservice.NewDispatcher(webhooksRepo, webhookSender),
+
+		// personal access tokens
+		CreateAccessTokenUC:       accesstokenusecases.NewCreateUseCase(accessTokensRepo),
+		FindMyAccessTokensUC:      accesstokenusecases.NewFindMyUseCase(accessTokensRepo),
+		RevokeAccessTokenUC:       accesstokenusecases.NewRevokeUseCase(accessTokensRepo),
+		FindAccessTokenScopesUC:   accesstokenusecases.NewFindScopesUseCase(),
+		AuthenticateAccessTokenUC: accesstokenusecases.NewAuthenticateUseCase(accessTokensRepo, usersRepo),
+
+		// public api v1
+		APIFindWorkoutsUC:      publicapiusecases.NewFindWorkoutsUseCase(workoutsRepo),
+		APIGetWorkoutUC:        publicapiusecases.NewGetWorkoutUseCase(workoutsRepo),
+		APIFindMeasurementsUC:  publicapiusecases.NewFindMeasurementsUseCase(measurementsRepo),
+		APICreateMeasurementUC: publicapiusecases.NewCreateMeasurementUseCase(measurementsRepo),
+
+		// graphql
+		GraphFindWorkoutsUC:         graphusecases.NewFindWorkoutsUseCase(workoutsRepo),
+		GraphFindUserWorkoutsUC:     graphusecases.NewFindUserWorkoutsUseCase(workoutsRepo),
+		GraphFindExercisesUC:        graphusecases.NewFindExercisesUseCase(exercisesRepo),
+		GraphFindExerciseHistoryUC:  graphusecases.NewFindExerciseHistoryUseCase(exercisesRepo),
+		GraphFindExerciseTypesUC:    graphusecases.NewFindExerciseTypesUseCase(exerciseTypesRepo),
+		GraphFindSetsUC:             graphusecases.NewFindSetsUseCase(setsRepo),
+		GraphFindProgramsUC:         graphusecases.NewFindProgramsUseCase(programsRepo),
+		GraphFindUserProgramsUC:     graphusecases.NewFindUserProgramsUseCase(programsRepo),
+		GraphFindDayTypesUC:         graphusecases.NewFindDayTypesUseCase(dayTypesRepo),
+		GraphFindProgramDayTypesUC:  graphusecases.NewFindProgramDayTypesUseCase(dayTypesRepo),
+		GraphFindUserMeasurementsUC: graphusecases.NewFindUserMeasurementsUseCase(measurementsRepo),
+
+		// offline sync
+		ApplySyncUC: offlinesyncusecases.NewApplyUseCase(
+			syncMutationsRepo, workoutsRepo, exerciseGroupTypesRepo, newSyncTx(db), workoutEventsHub,
+		),
+
+		// realtime
+		WorkoutEventsHub: workoutEventsHub,
+	}
+}
+
+// newLogPastTx собирает репозитории записи прошедшей тренировки поверх одной транзакции
+func newLogPastTx(db *gorm.DB) workoutusecases.LogPastTx {
+	return func(fn func(s workoutusecases.LogPastStore) error) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			return fn(workoutusecases.LogPastStore{
+				Workouts:  workouts.NewRepo(tx),
+				Exercises: exercises.NewRepo(tx),
+				Sessions:  sessions.NewRepo(tx),
+				Edits:     workoutedits.NewRepo(tx),
+			})
+		})
+	}
+}
+
+// newSyncTx собирает репозитории офлайн-синхронизации поверх транзакции; их собственные транзакции
+// становятся точками сохранения внутри нее
+func newSyncTx(db *gorm.DB) offlinesyncusecases.InTx {
+	return func(fn func(s offlinesyncusecases.Store) error) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			workoutsRepo := workouts.NewRepo(tx)
+			exercisesRepo := exercises.NewRepo(tx)
+			setsRepo := sets.NewRepo(tx)
+			return fn(offlinesyncusecases.Store{
+				SyncMutations:  syncmutations.NewRepo(tx),
+				Workouts:       workoutsRepo,
+				Exercises:      exercisesRepo,
+				Sets:           setsRepo,
+				Sessions:       sessions.NewRepo(tx),
+				CreateExercise: exerciseusecases.NewCreateUseCase(exercisesRepo, workoutsRepo, exercisetypes.NewRepo(tx)),
+				AddOneMoreSet:  setusecases.NewAddOneMoreUseCase(setsRepo, exercisesRepo, nil),
+			})
+		})
 	}
 }
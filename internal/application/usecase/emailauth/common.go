@@ -0,0 +1,180 @@
+package emailauth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	authsessionusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/authsessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/credentials"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/identities"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/mailer"
+)
+
+var (
+	InvalidEmailErr       = errors.New("invalid email")
+	WeakPasswordErr       = errors.New("password must be 8-72 bytes long")
+	EmailTakenErr         = errors.New("email is already registered")
+	InvalidCredentialsErr = errors.New("invalid email or password")
+	EmailNotVerifiedErr   = errors.New("email is not verified")
+	TooManyAttemptsErr    = errors.New("too many attempts, try again later")
+	InvalidTokenErr       = errors.New("invalid or expired link")
+	AlreadyHasEmailErr    = errors.New("email login is already set up")
+)
+
+const (
+	minPasswordLength = 8
+	// bcrypt молча обрезает пароль длиннее 72 байт, такие не принимаем
+	maxPasswordLength = 72
+
+	verifyTokenTTL = 24 * time.Hour
+	magicTokenTTL  = 15 * time.Minute
+	resetTokenTTL  = time.Hour
+)
+
+// хеш для сравнения, когда пользователь не найден: время ответа не должно выдавать, есть ли такой email
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || strings.ContainsAny(email, "\r\n") {
+		return "", InvalidEmailErr
+	}
+	return email, nil
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return "", WeakPasswordErr
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// sendLink создает одноразовый токен и отправляет письмо со ссылкой на страницу /auth-email фронта
+func sendLink(repo credentials.Repo, m mailer.Mailer, email, purpose, origin string) error {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+
+	ttl, subject, text := linkMail(purpose)
+	if err := repo.CreateToken(&models.EmailToken{
+		Email:     email,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+		CreatedAt: time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	link := origin + "/auth-email?" + url.Values{"action": {purpose}, "token": {token}}.Encode()
+	return m.Send(mailer.Message{
+		To:      email,
+		Subject: subject,
+		Text:    text + "\n\n" + link + "\n\nЕсли вы ничего не запрашивали, просто проигнорируйте это письмо.",
+	})
+}
+
+func linkMail(purpose string) (time.Duration, string, string) {
+	switch purpose {
+	case models.EmailTokenMagic:
+		return magicTokenTTL, "Вход в Form Journey", "Чтобы войти, перейдите по ссылке (действует 15 минут):"
+	case models.EmailTokenReset:
+		return resetTokenTTL, "Сброс пароля Form Journey", "Чтобы задать новый пароль, перейдите по ссылке (действует 1 час):"
+	default:
+		return verifyTokenTTL, "Подтвердите email для Form Journey", "Чтобы подтвердить email, перейдите по ссылке (действует 24 часа):"
+	}
+}
+
+// passwordMatches сравнивает пароль с хешем; пустой хеш не совпадает ни с чем
+func passwordMatches(passwordHash *string, password string) bool {
+	if passwordHash == nil || password == "" {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(*passwordHash), []byte(password)) == nil
+}
+
+// claimer отдает вход по email тому, кто перешел по ссылке из письма, то есть владельцу адреса
+type claimer struct {
+	credentialsRepo credentials.Repo
+	usersRepo       users.Repo
+	identitiesRepo  identities.Repo
+	revokeAllUC     *authsessionusecases.RevokeAllUseCase
+}
+
+// claim возвращает пользователя, которого нужно впустить по ссылке. Пока адрес не подтвержден, вход по нему
+// мог завести кто угодно (pre-hijack). Если email добавлен к аккаунту с другими способами входа, владелец адреса
+// не доказал, что аккаунт его: email отвязывается и для адреса заводится новый аккаунт. Иначе стирается пароль,
+// который владелец адреса не задавал, и завершаются все сессии аккаунта.
+// trusted — запрос пришел из сессии этого же аккаунта или с его паролем
+func (c *claimer) claim(credential *models.UserCredential, trusted bool, now time.Time) (int64, error) {
+	if credential.IsVerified() || trusted {
+		return credential.UserID, c.credentialsRepo.MarkVerified(credential.UserID, now)
+	}
+
+	attached, err := c.hasOtherProviders(credential.UserID)
+	if err != nil {
+		return 0, err
+	}
+	if attached {
+		user := &models.User{
+			Email:     credential.Email,
+			CreatedAt: now,
+		}
+		if err = c.credentialsRepo.Reassign(credential.UserID, user, &models.UserCredential{
+			Email:           credential.Email,
+			EmailVerifiedAt: &now,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}); err != nil {
+			return 0, err
+		}
+		return user.ID, nil
+	}
+
+	if err = c.credentialsRepo.ClearPassword(credential.UserID); err != nil {
+		return 0, err
+	}
+	if err = c.revokeAllUC.Execute(credential.UserID); err != nil {
+		return 0, err
+	}
+	return credential.UserID, c.credentialsRepo.MarkVerified(credential.UserID, now)
+}
+
+// hasOtherProviders: кроме email, у пользователя есть Telegram, Яндекс или OIDC провайдер
+func (c *claimer) hasOtherProviders(userID int64) (bool, error) {
+	user, err := c.usersRepo.GetByID(userID)
+	if err != nil {
+		return false, err
+	}
+	if len(user.LinkedProviders()) > 0 {
+		return true, nil
+	}
+	userIdentities, err := c.identitiesRepo.FindByUser(userID)
+	if err != nil {
+		return false, err
+	}
+	return len(userIdentities) > 0, nil
+}
@@ -0,0 +1,253 @@
+package emailauth
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authsessionusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/authsessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/authsessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/credentials"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/identities"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/denylist"
+)
+
+const (
+	victimEmail  = "victim@example.com"
+	attackerPass = "attacker-password"
+	ownerPass    = "owner-password"
+	emailUserID  = 1 // аккаунт, заведенный регистрацией по email
+	tgUserID     = 2 // аккаунт из Telegram, к которому добавили email
+	newUserID    = 100
+)
+
+// fakeCredentials хранит один вход по email и токены писем
+type fakeCredentials struct {
+	credentials.Repo
+	credential *models.UserCredential
+	tokens     map[string]models.EmailToken
+	createdFor *models.User
+}
+
+func (f *fakeCredentials) GetByEmail(email string) (*models.UserCredential, error) {
+	if f.credential == nil || f.credential.Email != email {
+		return nil, credentials.NotFoundCredentialErr
+	}
+	c := *f.credential
+	return &c, nil
+}
+
+func (f *fakeCredentials) UseToken(tokenHash, purpose string, _ time.Time) (*models.EmailToken, error) {
+	token, ok := f.tokens[tokenHash]
+	if !ok || token.Purpose != purpose {
+		return nil, credentials.NotFoundTokenErr
+	}
+	delete(f.tokens, tokenHash)
+	return &token, nil
+}
+
+func (f *fakeCredentials) MarkVerified(userID int64, at time.Time) error {
+	if f.credential.UserID == userID && f.credential.EmailVerifiedAt == nil {
+		f.credential.EmailVerifiedAt = &at
+	}
+	return nil
+}
+
+func (f *fakeCredentials) ClearPassword(userID int64) error {
+	if f.credential.UserID == userID {
+		f.credential.PasswordHash = nil
+	}
+	return nil
+}
+
+func (f *fakeCredentials) Reassign(fromUserID int64, user *models.User, credential *models.UserCredential) error {
+	if f.credential.UserID != fromUserID {
+		return credentials.NotFoundCredentialErr
+	}
+	user.ID = newUserID
+	credential.UserID = user.ID
+	f.credential = credential
+	f.createdFor = user
+	return nil
+}
+
+func (f *fakeCredentials) CreateToken(token *models.EmailToken) error {
+	f.tokens[token.TokenHash] = *token
+	return nil
+}
+
+func (f *fakeCredentials) issue(purpose string) string {
+	token := purpose + "-token"
+	f.tokens[hashToken(token)] = models.EmailToken{Email: victimEmail, Purpose: purpose}
+	return token
+}
+
+type fakeUsers struct {
+	users.Repo
+}
+
+func (fakeUsers) GetByID(id int64) (*models.User, error) {
+	user := &models.User{ID: id}
+	if id == tgUserID {
+		user.ChatID = 42
+	}
+	return user, nil
+}
+
+type fakeIdentities struct {
+	identities.Repo
+}
+
+func (fakeIdentities) FindByUser(int64) ([]models.UserIdentity, error) {
+	return nil, nil
+}
+
+// fakeSessions — у каждого пользователя одна активная сессия
+type fakeSessions struct {
+	authsessions.Repo
+	revoked []string
+}
+
+func (f *fakeSessions) FindActiveByUser(userID int64) ([]models.AuthSession, error) {
+	return []models.AuthSession{{ID: fmt.Sprintf("session-%d", userID)}}, nil
+}
+
+func (f *fakeSessions) Revoke(ids []string, _ time.Time) error {
+	f.revoked = append(f.revoked, ids...)
+	return nil
+}
+
+type fakeRevokedTokens struct{}
+
+func (fakeRevokedTokens) Add([]models.RevokedToken) error { return nil }
+func (fakeRevokedTokens) FindCreatedSince(time.Time) ([]models.RevokedToken, error) {
+	return nil, nil
+}
+func (fakeRevokedTokens) DeleteExpired(time.Time) error { return nil }
+
+type fixture struct {
+	creds    *fakeCredentials
+	sessions *fakeSessions
+	claimer  claimer
+}
+
+// newFixture: на адрес жертвы уже заведен неподтвержденный вход с паролем, владельцем которого стал userID
+func newFixture(t *testing.T, userID int64, password string) *fixture {
+	hash, err := hashPassword(password)
+	require.NoError(t, err)
+
+	creds := &fakeCredentials{
+		credential: &models.UserCredential{UserID: userID, Email: victimEmail, PasswordHash: &hash},
+		tokens:     map[string]models.EmailToken{},
+	}
+	sessions := &fakeSessions{}
+	return &fixture{
+		creds:    creds,
+		sessions: sessions,
+		claimer: claimer{
+			credentialsRepo: creds,
+			usersRepo:       fakeUsers{},
+			identitiesRepo:  fakeIdentities{},
+			revokeAllUC:     authsessionusecases.NewRevokeAllUseCase(sessions, denylist.New(fakeRevokedTokens{})),
+		},
+	}
+}
+
+func (f *fixture) magicLink() *ConsumeMagicLinkUseCase {
+	return &ConsumeMagicLinkUseCase{claimer: f.claimer}
+}
+
+func (f *fixture) verify() *VerifyEmailUseCase {
+	return &VerifyEmailUseCase{claimer: f.claimer}
+}
+
+// злоумышленник зарегистрировался на чужой адрес; жертва входит по ссылке и получает аккаунт без его пароля
+func TestMagicLinkDropsPasswordOfUnverifiedRegistration(t *testing.T) {
+	f := newFixture(t, emailUserID, attackerPass)
+
+	userID, err := f.magicLink().Execute(f.creds.issue(models.EmailTokenMagic), 0)
+	require.NoError(t, err)
+
+	assert.EqualValues(t, emailUserID, userID)
+	assert.Nil(t, f.creds.credential.PasswordHash)
+	assert.True(t, f.creds.credential.IsVerified())
+	assert.Equal(t, []string{"session-1"}, f.sessions.revoked)
+}
+
+// злоумышленник добавил чужой адрес к своему аккаунту; жертва получает отдельный аккаунт, а не его
+func TestMagicLinkDetachesEmailFromAnotherAccount(t *testing.T) {
+	f := newFixture(t, tgUserID, attackerPass)
+
+	userID, err := f.magicLink().Execute(f.creds.issue(models.EmailTokenMagic), 0)
+	require.NoError(t, err)
+
+	assert.EqualValues(t, newUserID, userID)
+	require.NotNil(t, f.creds.createdFor)
+	assert.Equal(t, victimEmail, f.creds.createdFor.Email)
+	assert.Nil(t, f.creds.credential.PasswordHash)
+	assert.True(t, f.creds.credential.IsVerified())
+	assert.Empty(t, f.sessions.revoked, "сессии аккаунта злоумышленника не трогаем")
+}
+
+func TestVerifyEmail(t *testing.T) {
+	t.Run("владелец ввел свой пароль", func(t *testing.T) {
+		f := newFixture(t, emailUserID, ownerPass)
+
+		userID, err := f.verify().Execute(f.creds.issue(models.EmailTokenVerify), ownerPass, 0)
+		require.NoError(t, err)
+
+		assert.EqualValues(t, emailUserID, userID)
+		assert.True(t, passwordMatches(f.creds.credential.PasswordHash, ownerPass))
+		assert.Empty(t, f.sessions.revoked)
+	})
+
+	t.Run("без пароля", func(t *testing.T) {
+		f := newFixture(t, emailUserID, attackerPass)
+
+		userID, err := f.verify().Execute(f.creds.issue(models.EmailTokenVerify), "", 0)
+		require.NoError(t, err)
+
+		assert.EqualValues(t, emailUserID, userID)
+		assert.Nil(t, f.creds.credential.PasswordHash)
+		assert.Equal(t, []string{"session-1"}, f.sessions.revoked)
+	})
+
+	t.Run("привязку подтверждает ее автор из своей сессии", func(t *testing.T) {
+		f := newFixture(t, tgUserID, ownerPass)
+
+		userID, err := f.verify().Execute(f.creds.issue(models.EmailTokenVerify), "", tgUserID)
+		require.NoError(t, err)
+
+		assert.EqualValues(t, tgUserID, userID)
+		assert.True(t, passwordMatches(f.creds.credential.PasswordHash, ownerPass))
+		assert.Nil(t, f.creds.createdFor)
+	})
+
+	t.Run("чужую привязку открыли без сессии", func(t *testing.T) {
+		f := newFixture(t, tgUserID, attackerPass)
+
+		userID, err := f.verify().Execute(f.creds.issue(models.EmailTokenVerify), "", 0)
+		require.NoError(t, err)
+
+		assert.EqualValues(t, newUserID, userID)
+		assert.Nil(t, f.creds.credential.PasswordHash)
+	})
+}
+
+func TestVerifiedCredentialIsUntouched(t *testing.T) {
+	f := newFixture(t, emailUserID, ownerPass)
+	verifiedAt := time.Now().Add(-time.Hour)
+	f.creds.credential.EmailVerifiedAt = &verifiedAt
+
+	userID, err := f.magicLink().Execute(f.creds.issue(models.EmailTokenMagic), 0)
+	require.NoError(t, err)
+
+	assert.EqualValues(t, emailUserID, userID)
+	assert.True(t, passwordMatches(f.creds.credential.PasswordHash, ownerPass))
+	assert.Empty(t, f.sessions.revoked)
+}
@@ -0,0 +1,72 @@
+package emailauth
+
+import (
+	"errors"
+	"time"
+
+	authsessionusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/authsessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/credentials"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/identities"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type ConsumeMagicLinkUseCase struct {
+	claimer
+}
+
+func NewConsumeMagicLinkUseCase(
+	credentialsRepo credentials.Repo,
+	usersRepo users.Repo,
+	identitiesRepo identities.Repo,
+	revokeAllUC *authsessionusecases.RevokeAllUseCase,
+) *ConsumeMagicLinkUseCase {
+	return &ConsumeMagicLinkUseCase{
+		claimer: claimer{
+			credentialsRepo: credentialsRepo,
+			usersRepo:       usersRepo,
+			identitiesRepo:  identitiesRepo,
+			revokeAllUC:     revokeAllUC,
+		},
+	}
+}
+
+func (uc *ConsumeMagicLinkUseCase) Name() string {
+	return "Войти по ссылке из письма"
+}
+
+// Execute впускает владельца адреса; currentUserID — пользователь сессии, из которой открыта ссылка, или 0
+func (uc *ConsumeMagicLinkUseCase) Execute(token string, currentUserID int64) (int64, error) {
+	now := time.Now()
+	emailToken, err := uc.credentialsRepo.UseToken(hashToken(token), models.EmailTokenMagic, now)
+	if errors.Is(err, credentials.NotFoundTokenErr) {
+		return 0, InvalidTokenErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	credential, err := uc.credentialsRepo.GetByEmail(emailToken.Email)
+	if err != nil && !errors.Is(err, credentials.NotFoundCredentialErr) {
+		return 0, err
+	}
+
+	// переход по ссылке сам по себе подтверждает владение адресом
+	if credential != nil {
+		return uc.claim(credential, currentUserID == credential.UserID, now)
+	}
+
+	user := &models.User{
+		Email:     emailToken.Email,
+		CreatedAt: now,
+	}
+	if err = uc.credentialsRepo.CreateWithUser(user, &models.UserCredential{
+		Email:           emailToken.Email,
+		EmailVerifiedAt: &now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}); err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
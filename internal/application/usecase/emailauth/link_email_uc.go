@@ -0,0 +1,68 @@
+package emailauth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/credentials"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/limiter"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/mailer"
+)
+
+type LinkEmailUseCase struct {
+	credentialsRepo credentials.Repo
+	mailer          mailer.Mailer
+	mailLimiter     *limiter.RateLimiter
+}
+
+func NewLinkEmailUseCase(credentialsRepo credentials.Repo, mailer mailer.Mailer, mailLimiter *limiter.RateLimiter) *LinkEmailUseCase {
+	return &LinkEmailUseCase{
+		credentialsRepo: credentialsRepo,
+		mailer:          mailer,
+		mailLimiter:     mailLimiter,
+	}
+}
+
+func (uc *LinkEmailUseCase) Name() string {
+	return "Добавить вход по email и паролю к аккаунту"
+}
+
+func (uc *LinkEmailUseCase) Execute(userID int64, email, password, origin string) error {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+	if !uc.mailLimiter.AllowKey(email) {
+		return TooManyAttemptsErr
+	}
+
+	if _, err = uc.credentialsRepo.GetByUserID(userID); err == nil {
+		return AlreadyHasEmailErr
+	} else if !errors.Is(err, credentials.NotFoundCredentialErr) {
+		return err
+	}
+
+	if _, err = uc.credentialsRepo.GetByEmail(email); err == nil {
+		return EmailTakenErr
+	} else if !errors.Is(err, credentials.NotFoundCredentialErr) {
+		return err
+	}
+
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err = uc.credentialsRepo.Create(&models.UserCredential{
+		UserID:       userID,
+		Email:        email,
+		PasswordHash: &passwordHash,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}); err != nil {
+		return err
+	}
+	return sendLink(uc.credentialsRepo, uc.mailer, email, models.EmailTokenVerify, origin)
+}
@@ -0,0 +1,57 @@
+package emailauth
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/credentials"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/limiter"
+)
+
+type LoginUseCase struct {
+	credentialsRepo credentials.Repo
+	emailLimiter    *limiter.RateLimiter // подбор пароля к одному аккаунту
+	ipLimiter       *limiter.RateLimiter // перебор аккаунтов с одного адреса, лимит мягче из-за NAT
+}
+
+func NewLoginUseCase(credentialsRepo credentials.Repo, emailLimiter, ipLimiter *limiter.RateLimiter) *LoginUseCase {
+	return &LoginUseCase{
+		credentialsRepo: credentialsRepo,
+		emailLimiter:    emailLimiter,
+		ipLimiter:       ipLimiter,
+	}
+}
+
+func (uc *LoginUseCase) Name() string {
+	return "Войти по email и паролю"
+}
+
+// Execute возвращает id пользователя; попытки ограничиваются и по email, и по ip
+func (uc *LoginUseCase) Execute(email, password, ip string) (int64, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return 0, InvalidCredentialsErr
+	}
+	if !uc.ipLimiter.AllowKey(ip) || !uc.emailLimiter.AllowKey(email) {
+		return 0, TooManyAttemptsErr
+	}
+
+	credential, err := uc.credentialsRepo.GetByEmail(email)
+	if err != nil && !errors.Is(err, credentials.NotFoundCredentialErr) {
+		return 0, err
+	}
+
+	hash := dummyPasswordHash
+	if credential != nil && credential.PasswordHash != nil {
+		hash = []byte(*credential.PasswordHash)
+	}
+	if bcrypt.CompareHashAndPassword(hash, []byte(password)) != nil || credential == nil || credential.PasswordHash == nil {
+		return 0, InvalidCredentialsErr
+	}
+
+	if !credential.IsVerified() {
+		return 0, EmailNotVerifiedErr
+	}
+	return credential.UserID, nil
+}
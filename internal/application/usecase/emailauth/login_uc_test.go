@@ -0,0 +1,97 @@
+package emailauth
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/limiter"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/mailer"
+)
+
+type countingMailer struct {
+	sent []mailer.Message
+}
+
+func (m *countingMailer) Send(msg mailer.Message) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func verifiedCredentials(t *testing.T) *fakeCredentials {
+	f := newFixture(t, emailUserID, ownerPass)
+	verifiedAt := time.Now()
+	f.creds.credential.EmailVerifiedAt = &verifiedAt
+	return f.creds
+}
+
+func TestLoginIsThrottledByEmail(t *testing.T) {
+	uc := NewLoginUseCase(verifiedCredentials(t), limiter.NewRateLimiter(5, 15*time.Minute), limiter.NewRateLimiter(30, 15*time.Minute))
+
+	for i := range 5 {
+		_, err := uc.Execute(victimEmail, fmt.Sprintf("guess-%d", i), fmt.Sprintf("198.51.100.%d", i))
+		assert.ErrorIs(t, err, InvalidCredentialsErr)
+	}
+
+	// подбор с разных адресов упирается в лимит аккаунта, даже верный пароль уже не проходит
+	_, err := uc.Execute(victimEmail, ownerPass, "198.51.100.99")
+	assert.ErrorIs(t, err, TooManyAttemptsErr)
+}
+
+func TestLoginIsThrottledByIP(t *testing.T) {
+	uc := NewLoginUseCase(verifiedCredentials(t), limiter.NewRateLimiter(5, 15*time.Minute), limiter.NewRateLimiter(3, 15*time.Minute))
+
+	for i := range 3 {
+		_, err := uc.Execute(fmt.Sprintf("user%d@example.com", i), "guess", "203.0.113.7")
+		assert.ErrorIs(t, err, InvalidCredentialsErr)
+	}
+	_, err := uc.Execute(victimEmail, ownerPass, "203.0.113.7")
+	assert.ErrorIs(t, err, TooManyAttemptsErr)
+
+	userID, err := uc.Execute(victimEmail, ownerPass, "203.0.113.8")
+	require.NoError(t, err)
+	assert.EqualValues(t, emailUserID, userID)
+}
+
+func TestPasswordResetMailsAreThrottled(t *testing.T) {
+	m := &countingMailer{}
+	uc := NewRequestPasswordResetUseCase(verifiedCredentials(t), m, limiter.NewRateLimiter(3, 15*time.Minute))
+
+	for range 3 {
+		require.NoError(t, uc.Execute(victimEmail, "https://app.example.com"))
+	}
+	assert.ErrorIs(t, uc.Execute(" Victim@Example.com ", "https://app.example.com"), TooManyAttemptsErr,
+		"лимит считается по нормализованному адресу")
+	assert.Len(t, m.sent, 3)
+}
+
+func TestVerificationMailsAreThrottled(t *testing.T) {
+	m := &countingMailer{}
+	f := newFixture(t, emailUserID, ownerPass)
+	uc := NewSendVerificationUseCase(f.creds, m, limiter.NewRateLimiter(3, 15*time.Minute))
+
+	for range 3 {
+		require.NoError(t, uc.Execute(victimEmail, "https://app.example.com"))
+	}
+	assert.ErrorIs(t, uc.Execute(victimEmail, "https://app.example.com"), TooManyAttemptsErr)
+	assert.Len(t, m.sent, 3)
+}
+
+// ответ регистрации не выдает, есть ли адрес: владельцу уходит письмо, пароль не меняется
+func TestRegisterExistingEmailMailsOwner(t *testing.T) {
+	m := &countingMailer{}
+	creds := verifiedCredentials(t)
+	uc := NewRegisterUseCase(creds, m, limiter.NewRateLimiter(5, 15*time.Minute))
+
+	assert.ErrorIs(t, uc.Execute(victimEmail, "short", "Ivan", "https://app.example.com"), WeakPasswordErr,
+		"слабый пароль отклоняется до поиска адреса")
+	require.NoError(t, uc.Execute(victimEmail, attackerPass, "Ivan", "https://app.example.com"))
+
+	require.Len(t, m.sent, 1)
+	assert.Equal(t, victimEmail, m.sent[0].To)
+	assert.Equal(t, "Вы уже зарегистрированы в Form Journey", m.sent[0].Subject)
+	assert.True(t, passwordMatches(creds.credential.PasswordHash, ownerPass))
+}
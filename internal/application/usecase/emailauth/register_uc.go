@@ -0,0 +1,86 @@
+package emailauth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/credentials"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/limiter"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/mailer"
+)
+
+type RegisterUseCase struct {
+	credentialsRepo credentials.Repo
+	mailer          mailer.Mailer
+	mailLimiter     *limiter.RateLimiter
+}
+
+func NewRegisterUseCase(credentialsRepo credentials.Repo, mailer mailer.Mailer, mailLimiter *limiter.RateLimiter) *RegisterUseCase {
+	return &RegisterUseCase{
+		credentialsRepo: credentialsRepo,
+		mailer:          mailer,
+		mailLimiter:     mailLimiter,
+	}
+}
+
+func (uc *RegisterUseCase) Name() string {
+	return "Зарегистрироваться по email и паролю"
+}
+
+// Execute не сообщает, зарегистрирован ли адрес: пароль проверяется до поиска, а владельцу
+// существующего аккаунта вместо ошибки уходит письмо
+func (uc *RegisterUseCase) Execute(email, password, firstName, origin string) error {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+	if !uc.mailLimiter.AllowKey(email) {
+		return TooManyAttemptsErr
+	}
+
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	credential, err := uc.credentialsRepo.GetByEmail(email)
+	if err == nil {
+		return uc.notifyExisting(credential, origin)
+	}
+	if !errors.Is(err, credentials.NotFoundCredentialErr) {
+		return err
+	}
+
+	now := time.Now()
+	user := &models.User{
+		FirstName: firstName,
+		Email:     email,
+		CreatedAt: now,
+	}
+	if err = uc.credentialsRepo.CreateWithUser(user, &models.UserCredential{
+		Email:        email,
+		PasswordHash: &passwordHash,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}); err != nil {
+		return err
+	}
+
+	return sendLink(uc.credentialsRepo, uc.mailer, email, models.EmailTokenVerify, origin)
+}
+
+// notifyExisting: неподтвержденному адресу повторно уходит ссылка подтверждения,
+// подтвержденному — напоминание, что аккаунт уже есть
+func (uc *RegisterUseCase) notifyExisting(credential *models.UserCredential, origin string) error {
+	if !credential.IsVerified() {
+		return sendLink(uc.credentialsRepo, uc.mailer, credential.Email, models.EmailTokenVerify, origin)
+	}
+	return uc.mailer.Send(mailer.Message{
+		To:      credential.Email,
+		Subject: "Вы уже зарегистрированы в Form Journey",
+		Text: "Кто-то, возможно вы, попытался зарегистрироваться с этим адресом, но аккаунт на него уже есть. " +
+			"Войдите по паролю или по ссылке из письма, а если забыли пароль, сбросьте его:\n\n" + origin + "/profile" +
+			"\n\nЕсли вы ничего не запрашивали, просто проигнорируйте это письмо.",
+	})
+}
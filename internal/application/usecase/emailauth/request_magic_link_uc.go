@@ -0,0 +1,38 @@
+package emailauth
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/credentials"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/limiter"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/mailer"
+)
+
+type RequestMagicLinkUseCase struct {
+	credentialsRepo credentials.Repo
+	mailer          mailer.Mailer
+	mailLimiter     *limiter.RateLimiter
+}
+
+func NewRequestMagicLinkUseCase(credentialsRepo credentials.Repo, mailer mailer.Mailer, mailLimiter *limiter.RateLimiter) *RequestMagicLinkUseCase {
+	return &RequestMagicLinkUseCase{
+		credentialsRepo: credentialsRepo,
+		mailer:          mailer,
+		mailLimiter:     mailLimiter,
+	}
+}
+
+func (uc *RequestMagicLinkUseCase) Name() string {
+	return "Отправить ссылку для входа без пароля"
+}
+
+// Execute отправляет ссылку на любой корректный адрес: для нового адреса аккаунт создастся при переходе
+func (uc *RequestMagicLinkUseCase) Execute(email, origin string) error {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+	if !uc.mailLimiter.AllowKey(email) {
+		return TooManyAttemptsErr
+	}
+	return sendLink(uc.credentialsRepo, uc.mailer, email, models.EmailTokenMagic, origin)
+}
@@ -0,0 +1,48 @@
+package emailauth
+
+import (
+	"errors"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/credentials"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/limiter"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/mailer"
+)
+
+type RequestPasswordResetUseCase struct {
+	credentialsRepo credentials.Repo
+	mailer          mailer.Mailer
+	mailLimiter     *limiter.RateLimiter
+}
+
+func NewRequestPasswordResetUseCase(credentialsRepo credentials.Repo, mailer mailer.Mailer, mailLimiter *limiter.RateLimiter) *RequestPasswordResetUseCase {
+	return &RequestPasswordResetUseCase{
+		credentialsRepo: credentialsRepo,
+		mailer:          mailer,
+		mailLimiter:     mailLimiter,
+	}
+}
+
+func (uc *RequestPasswordResetUseCase) Name() string {
+	return "Отправить ссылку для сброса пароля"
+}
+
+// Execute не сообщает, зарегистрирован ли адрес
+func (uc *RequestPasswordResetUseCase) Execute(email, origin string) error {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+	if !uc.mailLimiter.AllowKey(email) {
+		return TooManyAttemptsErr
+	}
+
+	_, err = uc.credentialsRepo.GetByEmail(email)
+	if errors.Is(err, credentials.NotFoundCredentialErr) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return sendLink(uc.credentialsRepo, uc.mailer, email, models.EmailTokenReset, origin)
+}
@@ -0,0 +1,74 @@
+package emailauth
+
+import (
+	"errors"
+	"time"
+
+	authsessionusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/authsessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/credentials"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/identities"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type ResetPasswordUseCase struct {
+	claimer
+}
+
+func NewResetPasswordUseCase(
+	credentialsRepo credentials.Repo,
+	usersRepo users.Repo,
+	identitiesRepo identities.Repo,
+	revokeAllUC *authsessionusecases.RevokeAllUseCase,
+) *ResetPasswordUseCase {
+	return &ResetPasswordUseCase{
+		claimer: claimer{
+			credentialsRepo: credentialsRepo,
+			usersRepo:       usersRepo,
+			identitiesRepo:  identitiesRepo,
+			revokeAllUC:     revokeAllUC,
+		},
+	}
+}
+
+func (uc *ResetPasswordUseCase) Name() string {
+	return "Задать новый пароль по ссылке из письма"
+}
+
+// Execute меняет пароль и завершает все сессии: сброс обычно делают, когда доступ мог утечь
+func (uc *ResetPasswordUseCase) Execute(token, newPassword string) (int64, error) {
+	passwordHash, err := hashPassword(newPassword)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	emailToken, err := uc.credentialsRepo.UseToken(hashToken(token), models.EmailTokenReset, now)
+	if errors.Is(err, credentials.NotFoundTokenErr) {
+		return 0, InvalidTokenErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	credential, err := uc.credentialsRepo.GetByEmail(emailToken.Email)
+	if errors.Is(err, credentials.NotFoundCredentialErr) {
+		return 0, InvalidTokenErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// новый пароль задает владелец адреса, поэтому неподтвержденный вход сначала переходит к нему
+	userID, err := uc.claim(credential, false, now)
+	if err != nil {
+		return 0, err
+	}
+	if err = uc.credentialsRepo.SetPassword(userID, passwordHash); err != nil {
+		return 0, err
+	}
+	if err = uc.revokeAllUC.Execute(userID); err != nil {
+		return 0, err
+	}
+	return userID, nil
+}
@@ -0,0 +1,51 @@
+package emailauth
+
+import (
+	"errors"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/credentials"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/limiter"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/mailer"
+)
+
+type SendVerificationUseCase struct {
+	credentialsRepo credentials.Repo
+	mailer          mailer.Mailer
+	mailLimiter     *limiter.RateLimiter
+}
+
+func NewSendVerificationUseCase(credentialsRepo credentials.Repo, mailer mailer.Mailer, mailLimiter *limiter.RateLimiter) *SendVerificationUseCase {
+	return &SendVerificationUseCase{
+		credentialsRepo: credentialsRepo,
+		mailer:          mailer,
+		mailLimiter:     mailLimiter,
+	}
+}
+
+func (uc *SendVerificationUseCase) Name() string {
+	return "Повторно отправить письмо для подтверждения email"
+}
+
+// Execute молча ничего не делает для неизвестных и уже подтвержденных адресов
+func (uc *SendVerificationUseCase) Execute(email, origin string) error {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+	if !uc.mailLimiter.AllowKey(email) {
+		return TooManyAttemptsErr
+	}
+
+	credential, err := uc.credentialsRepo.GetByEmail(email)
+	if errors.Is(err, credentials.NotFoundCredentialErr) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if credential.IsVerified() {
+		return nil
+	}
+	return sendLink(uc.credentialsRepo, uc.mailer, email, models.EmailTokenVerify, origin)
+}
@@ -0,0 +1,60 @@
+package emailauth
+
+import (
+	"errors"
+	"time"
+
+	authsessionusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/authsessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/credentials"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/identities"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type VerifyEmailUseCase struct {
+	claimer
+}
+
+func NewVerifyEmailUseCase(
+	credentialsRepo credentials.Repo,
+	usersRepo users.Repo,
+	identitiesRepo identities.Repo,
+	revokeAllUC *authsessionusecases.RevokeAllUseCase,
+) *VerifyEmailUseCase {
+	return &VerifyEmailUseCase{
+		claimer: claimer{
+			credentialsRepo: credentialsRepo,
+			usersRepo:       usersRepo,
+			identitiesRepo:  identitiesRepo,
+			revokeAllUC:     revokeAllUC,
+		},
+	}
+}
+
+func (uc *VerifyEmailUseCase) Name() string {
+	return "Подтвердить email по ссылке из письма"
+}
+
+// Execute подтверждает email и возвращает id пользователя, чтобы сразу его впустить.
+// Пароль сохраняется, только если его ввели при подтверждении или ссылку открыли из сессии этого же аккаунта
+func (uc *VerifyEmailUseCase) Execute(token, password string, currentUserID int64) (int64, error) {
+	now := time.Now()
+	emailToken, err := uc.credentialsRepo.UseToken(hashToken(token), models.EmailTokenVerify, now)
+	if errors.Is(err, credentials.NotFoundTokenErr) {
+		return 0, InvalidTokenErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	credential, err := uc.credentialsRepo.GetByEmail(emailToken.Email)
+	if errors.Is(err, credentials.NotFoundCredentialErr) {
+		return 0, InvalidTokenErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	trusted := currentUserID == credential.UserID || passwordMatches(credential.PasswordHash, password)
+	return uc.claim(credential, trusted, now)
+}
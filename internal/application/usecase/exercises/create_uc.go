@@ -78,6 +78,7 @@ func (uc *CreateUseCase) Execute(workoutID, exerciseTypeID int64) (*dto.CreateEx
 	}
 
 	return &dto.CreateExercise{
+		ExerciseID:  newExercise.ID,
 		ExerciseObj: exerciseObj,
 	}, nil
 }
@@ -1,6 +1,7 @@
 package exercises
 
 import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercises"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sessions"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
@@ -32,7 +33,12 @@ func (uc *DeleteUseCase) Execute(exerciseID int64) (int64, error) {
 	workoutID := exercise.WorkoutDayID
 	deleteExIndex := exercise.Index
 
-	err = uc.exercisesRepo.Delete(exerciseID)
+	var evts []events.Event
+	if exercise.WorkoutDay != nil {
+		// вместе с упражнением уходят его подходы: агрегаты и рекорды пересчитывают подписчики
+		evts = append(evts, events.WorkoutChanged{WorkoutID: workoutID, UserID: exercise.WorkoutDay.UserID})
+	}
+	err = uc.exercisesRepo.Delete(exerciseID, evts...)
 	if err != nil {
 		return 0, err
 	}
@@ -3,19 +3,25 @@ package exports
 import (
 	"bytes"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisegrouptypes"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercises"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/rollups"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/service/docgenerator"
 	summarysvc "github.com/SaenkoDmitry/training-tg-bot/internal/service/summary"
 )
 
+// exportBatchSize — сколько тренировок с подходами держим в памяти при выгрузке
+const exportBatchSize = 100
+
 type ExportWorkoutsToExcelUseCase struct {
 	usersRepo              users.Repo
 	exerciseGroupTypesRepo exercisegrouptypes.Repo
 	workoutsRepo           workouts.Repo
 	exercisesRepo          exercises.Repo
+	rollupsRepo            rollups.Repo
 	summaryService         summarysvc.Service
 	docGeneratorService    docgenerator.Service
 }
@@ -25,6 +31,7 @@ func NewExportWorkoutsToExcelUseCase(
 	exerciseGroupTypesRepo exercisegrouptypes.Repo,
 	workoutsRepo workouts.Repo,
 	exercisesRepo exercises.Repo,
+	rollupsRepo rollups.Repo,
 	summaryService summarysvc.Service,
 	docGeneratorService docgenerator.Service,
 ) *ExportWorkoutsToExcelUseCase {
@@ -33,6 +40,7 @@ func NewExportWorkoutsToExcelUseCase(
 		exerciseGroupTypesRepo: exerciseGroupTypesRepo,
 		workoutsRepo:           workoutsRepo,
 		exercisesRepo:          exercisesRepo,
+		rollupsRepo:            rollupsRepo,
 		summaryService:         summaryService,
 		docGeneratorService:    docGeneratorService,
 	}
@@ -52,20 +60,24 @@ func (uc *ExportWorkoutsToExcelUseCase) Execute(userID int64) (*bytes.Buffer, er
 		groupCodesMap[code.Code] = code.Name
 	}
 
-	workoutObjs, err := uc.workoutsRepo.FindAll(userID)
+	// сводки считаются по агрегатам, а не по всем подходам истории
+	daily, err := uc.rollupsRepo.FindDaily(userID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	weekly, err := uc.rollupsRepo.FindWeekly(userID, nil, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	totalSummary := uc.summaryService.BuildTotal(workoutObjs, groupCodesMap)
-	byDateSummary := uc.summaryService.BuildByDate(workoutObjs)
-	weekExerciseTypeSummary := uc.summaryService.BuildByWeekAndExType(workoutObjs, groupCodesMap)
-	exerciseProgressByDates := uc.summaryService.BuildExerciseProgressByDates(workoutObjs)
-
-	file, err := uc.docGeneratorService.ExportWorkoutsToFile(workoutObjs, totalSummary, byDateSummary, exerciseProgressByDates, groupCodesMap, weekExerciseTypeSummary)
+	batches := func(yield func(batch []models.WorkoutDay) error) error {
+		return uc.workoutsRepo.Iterate(userID, exportBatchSize, yield)
+	}
+	file, err := uc.docGeneratorService.ExportWorkoutsToFile(batches, daily, weekly, groupCodesMap)
 	if err != nil {
 		return nil, err
 	}
+	defer file.Close()
 
 	buf, err := file.WriteToBuffer()
 	if err != nil {
@@ -0,0 +1,83 @@
+package exports_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/exports"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisegrouptypes"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercises"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/repotest"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/rollups"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/docgenerator"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/summary"
+)
+
+const fiveYears = 5 * 365
+
+// exportHeapLimit — предел живой кучи выгрузки пяти лет. На той же истории без базы
+// (BenchmarkExportWorkoutsToFile) выгрузка держит ~25 МБ, из них 16 МБ — буфер строк excelize
+const exportHeapLimit = 64 << 20
+
+// sampledRepo замеряет живую кучу после каждой пачки, пока пачка еще не отпущена
+type sampledRepo struct {
+	workouts.Repo
+	base int64
+	peak int64
+}
+
+func (r *sampledRepo) Iterate(userID int64, batchSize int, fn func(batch []models.WorkoutDay) error) error {
+	return r.Repo.Iterate(userID, batchSize, func(batch []models.WorkoutDay) error {
+		err := fn(batch)
+		r.peak = max(r.peak, liveHeap()-r.base)
+		runtime.KeepAlive(batch)
+		return err
+	})
+}
+
+func liveHeap() int64 {
+	var stats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	return int64(stats.HeapAlloc)
+}
+
+// TestExportWorkoutsFiveYears выгружает пятилетнюю историю из базы: в файле все подходы,
+// а живая куча во время выгрузки ограничена пачкой и буфером excelize, а не длиной истории
+func TestExportWorkoutsFiveYears(t *testing.T) {
+	db := repotest.Open(t)
+	h := repotest.SeedHistory(t, db, fiveYears)
+	rollupsRepo := rollups.NewRepo(db)
+	require.NoError(t, rollupsRepo.RecalculateUser(h.UserID))
+
+	repo := &sampledRepo{Repo: workouts.NewRepo(db)}
+	uc := exports.NewExportWorkoutsToExcelUseCase(
+		users.NewRepo(db),
+		exercisegrouptypes.NewRepo(db),
+		repo,
+		exercises.NewRepo(db),
+		rollupsRepo,
+		summary.NewService(),
+		docgenerator.NewService(summary.NewService()),
+	)
+
+	repo.base = liveHeap()
+	buf, err := uc.Execute(h.UserID)
+	require.NoError(t, err)
+	t.Logf("live heap during export: %.1f MB", float64(repo.peak)/(1<<20))
+	assert.Less(t, repo.peak, int64(exportHeapLimit))
+
+	f, err := excelize.OpenReader(buf)
+	require.NoError(t, err)
+	defer f.Close()
+	rows, err := f.GetRows(docgenerator.WorkoutSheet)
+	require.NoError(t, err)
+	assert.Len(t, rows, 1+fiveYears*repotest.HistoryExercises*repotest.HistorySets)
+}
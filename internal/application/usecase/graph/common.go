@@ -0,0 +1,26 @@
+// Package graph — выборки для GraphQL. Загрузчики API запрашивают сущности пачками
+// по идентификаторам, поэтому здесь модели без связей, а не готовые DTO
+package graph
+
+// byID раскладывает сущности по идентификатору
+func byID[T any](items []T, id func(*T) int64) map[int64]*T {
+	result := make(map[int64]*T, len(items))
+	for i := range items {
+		result[id(&items[i])] = &items[i]
+	}
+	return result
+}
+
+// groupBy раскладывает сущности по родителю, сохраняя порядок выборки.
+// У каждого запрошенного родителя есть запись, хотя бы пустая
+func groupBy[T any](parentIDs []int64, items []T, parentID func(*T) int64) map[int64][]T {
+	result := make(map[int64][]T, len(parentIDs))
+	for _, id := range parentIDs {
+		result[id] = []T{}
+	}
+	for _, item := range items {
+		id := parentID(&item)
+		result[id] = append(result[id], item)
+	}
+	return result
+}
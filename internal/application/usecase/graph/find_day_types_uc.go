@@ -0,0 +1,28 @@
+package graph
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/daytypes"
+)
+
+type FindDayTypesUseCase struct {
+	dayTypesRepo daytypes.Repo
+}
+
+func NewFindDayTypesUseCase(dayTypesRepo daytypes.Repo) *FindDayTypesUseCase {
+	return &FindDayTypesUseCase{
+		dayTypesRepo: dayTypesRepo,
+	}
+}
+
+func (uc *FindDayTypesUseCase) Name() string {
+	return "GraphQL: дни программ по идентификаторам"
+}
+
+func (uc *FindDayTypesUseCase) Execute(ids []int64) (map[int64]*models.WorkoutDayType, error) {
+	items, err := uc.dayTypesRepo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	return byID(items, func(d *models.WorkoutDayType) int64 { return d.ID }), nil
+}
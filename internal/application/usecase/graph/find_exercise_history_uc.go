@@ -0,0 +1,24 @@
+package graph
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercises"
+)
+
+type FindExerciseHistoryUseCase struct {
+	exercisesRepo exercises.Repo
+}
+
+func NewFindExerciseHistoryUseCase(exercisesRepo exercises.Repo) *FindExerciseHistoryUseCase {
+	return &FindExerciseHistoryUseCase{
+		exercisesRepo: exercisesRepo,
+	}
+}
+
+func (uc *FindExerciseHistoryUseCase) Name() string {
+	return "GraphQL: история упражнения"
+}
+
+func (uc *FindExerciseHistoryUseCase) Execute(userID, exerciseTypeID int64, offset, limit int) ([]models.Exercise, error) {
+	return uc.exercisesRepo.FindAllByUserIDAndExTypeID(userID, exerciseTypeID, offset, limit)
+}
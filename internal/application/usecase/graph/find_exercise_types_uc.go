@@ -0,0 +1,28 @@
+package graph
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisetypes"
+)
+
+type FindExerciseTypesUseCase struct {
+	exerciseTypesRepo exercisetypes.Repo
+}
+
+func NewFindExerciseTypesUseCase(exerciseTypesRepo exercisetypes.Repo) *FindExerciseTypesUseCase {
+	return &FindExerciseTypesUseCase{
+		exerciseTypesRepo: exerciseTypesRepo,
+	}
+}
+
+func (uc *FindExerciseTypesUseCase) Name() string {
+	return "GraphQL: типы упражнений"
+}
+
+func (uc *FindExerciseTypesUseCase) Execute(ids []int64) (map[int64]*models.ExerciseType, error) {
+	items, err := uc.exerciseTypesRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	return byID(items, func(t *models.ExerciseType) int64 { return t.ID }), nil
+}
@@ -0,0 +1,28 @@
+package graph
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercises"
+)
+
+type FindExercisesUseCase struct {
+	exercisesRepo exercises.Repo
+}
+
+func NewFindExercisesUseCase(exercisesRepo exercises.Repo) *FindExercisesUseCase {
+	return &FindExercisesUseCase{
+		exercisesRepo: exercisesRepo,
+	}
+}
+
+func (uc *FindExercisesUseCase) Name() string {
+	return "GraphQL: упражнения тренировок"
+}
+
+func (uc *FindExercisesUseCase) Execute(workoutIDs []int64) (map[int64][]models.Exercise, error) {
+	items, err := uc.exercisesRepo.FindByWorkoutIDs(workoutIDs)
+	if err != nil {
+		return nil, err
+	}
+	return groupBy(workoutIDs, items, func(e *models.Exercise) int64 { return e.WorkoutDayID }), nil
+}
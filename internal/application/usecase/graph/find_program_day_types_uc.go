@@ -0,0 +1,28 @@
+package graph
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/daytypes"
+)
+
+type FindProgramDayTypesUseCase struct {
+	dayTypesRepo daytypes.Repo
+}
+
+func NewFindProgramDayTypesUseCase(dayTypesRepo daytypes.Repo) *FindProgramDayTypesUseCase {
+	return &FindProgramDayTypesUseCase{
+		dayTypesRepo: dayTypesRepo,
+	}
+}
+
+func (uc *FindProgramDayTypesUseCase) Name() string {
+	return "GraphQL: дни программ"
+}
+
+func (uc *FindProgramDayTypesUseCase) Execute(programIDs []int64) (map[int64][]models.WorkoutDayType, error) {
+	items, err := uc.dayTypesRepo.FindByProgramIDs(programIDs)
+	if err != nil {
+		return nil, err
+	}
+	return groupBy(programIDs, items, func(d *models.WorkoutDayType) int64 { return d.WorkoutProgramID }), nil
+}
@@ -0,0 +1,28 @@
+package graph
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/programs"
+)
+
+type FindProgramsUseCase struct {
+	programsRepo programs.Repo
+}
+
+func NewFindProgramsUseCase(programsRepo programs.Repo) *FindProgramsUseCase {
+	return &FindProgramsUseCase{
+		programsRepo: programsRepo,
+	}
+}
+
+func (uc *FindProgramsUseCase) Name() string {
+	return "GraphQL: программы по идентификаторам"
+}
+
+func (uc *FindProgramsUseCase) Execute(ids []int64) (map[int64]*models.WorkoutProgram, error) {
+	items, err := uc.programsRepo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	return byID(items, func(p *models.WorkoutProgram) int64 { return p.ID }), nil
+}
@@ -0,0 +1,28 @@
+package graph
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sets"
+)
+
+type FindSetsUseCase struct {
+	setsRepo sets.Repo
+}
+
+func NewFindSetsUseCase(setsRepo sets.Repo) *FindSetsUseCase {
+	return &FindSetsUseCase{
+		setsRepo: setsRepo,
+	}
+}
+
+func (uc *FindSetsUseCase) Name() string {
+	return "GraphQL: подходы упражнений"
+}
+
+func (uc *FindSetsUseCase) Execute(exerciseIDs []int64) (map[int64][]models.Set, error) {
+	items, err := uc.setsRepo.FindByExerciseIDs(exerciseIDs)
+	if err != nil {
+		return nil, err
+	}
+	return groupBy(exerciseIDs, items, func(s *models.Set) int64 { return s.ExerciseID }), nil
+}
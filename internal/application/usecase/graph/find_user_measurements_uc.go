@@ -0,0 +1,24 @@
+package graph
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/measurements"
+)
+
+type FindUserMeasurementsUseCase struct {
+	measurementsRepo measurements.Repo
+}
+
+func NewFindUserMeasurementsUseCase(measurementsRepo measurements.Repo) *FindUserMeasurementsUseCase {
+	return &FindUserMeasurementsUseCase{
+		measurementsRepo: measurementsRepo,
+	}
+}
+
+func (uc *FindUserMeasurementsUseCase) Name() string {
+	return "GraphQL: измерения тела пользователя"
+}
+
+func (uc *FindUserMeasurementsUseCase) Execute(userID int64, offset, limit int) ([]models.Measurement, error) {
+	return uc.measurementsRepo.FindAllLimitOffset(userID, limit, offset)
+}
@@ -0,0 +1,24 @@
+package graph
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/programs"
+)
+
+type FindUserProgramsUseCase struct {
+	programsRepo programs.Repo
+}
+
+func NewFindUserProgramsUseCase(programsRepo programs.Repo) *FindUserProgramsUseCase {
+	return &FindUserProgramsUseCase{
+		programsRepo: programsRepo,
+	}
+}
+
+func (uc *FindUserProgramsUseCase) Name() string {
+	return "GraphQL: программы пользователя"
+}
+
+func (uc *FindUserProgramsUseCase) Execute(userID int64) ([]models.WorkoutProgram, error) {
+	return uc.programsRepo.FindAll(userID)
+}
@@ -0,0 +1,24 @@
+package graph
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+type FindUserWorkoutsUseCase struct {
+	workoutsRepo workouts.Repo
+}
+
+func NewFindUserWorkoutsUseCase(workoutsRepo workouts.Repo) *FindUserWorkoutsUseCase {
+	return &FindUserWorkoutsUseCase{
+		workoutsRepo: workoutsRepo,
+	}
+}
+
+func (uc *FindUserWorkoutsUseCase) Name() string {
+	return "GraphQL: тренировки пользователя"
+}
+
+func (uc *FindUserWorkoutsUseCase) Execute(userID int64, offset, limit int) ([]models.WorkoutDay, error) {
+	return uc.workoutsRepo.FindPage(userID, offset, limit)
+}
@@ -0,0 +1,28 @@
+package graph
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+type FindWorkoutsUseCase struct {
+	workoutsRepo workouts.Repo
+}
+
+func NewFindWorkoutsUseCase(workoutsRepo workouts.Repo) *FindWorkoutsUseCase {
+	return &FindWorkoutsUseCase{
+		workoutsRepo: workoutsRepo,
+	}
+}
+
+func (uc *FindWorkoutsUseCase) Name() string {
+	return "GraphQL: тренировки по идентификаторам"
+}
+
+func (uc *FindWorkoutsUseCase) Execute(ids []int64) (map[int64]*models.WorkoutDay, error) {
+	items, err := uc.workoutsRepo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	return byID(items, func(w *models.WorkoutDay) int64 { return w.ID }), nil
+}
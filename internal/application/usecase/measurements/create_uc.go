@@ -22,7 +22,7 @@ func (uc *CreateUseCase) Name() string {
 }
 
 func (uc *CreateUseCase) Execute(measurement *models.Measurement) (*dto.Measurement, error) {
-	err := uc.measurementsRepo.Save(measurement)
+	err := uc.measurementsRepo.Create(measurement)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,53 @@
+package oauth
+
+import (
+	"context"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/oauth"
+)
+
+type BeginUseCase struct {
+	registry *oauth.Registry
+	states   *oauth.StateStore
+}
+
+func NewBeginUseCase(registry *oauth.Registry, states *oauth.StateStore) *BeginUseCase {
+	return &BeginUseCase{
+		registry: registry,
+		states:   states,
+	}
+}
+
+func (uc *BeginUseCase) Name() string {
+	return "Начать вход через внешнего провайдера"
+}
+
+// Execute возвращает адрес страницы входа провайдера и binding для cookie браузера;
+// linkUserID не 0 — привязка к вошедшему пользователю
+func (uc *BeginUseCase) Execute(ctx context.Context, providerName, redirectURI string, linkUserID int64) (*dto.OAuthStart, error) {
+	provider, err := uc.registry.Get(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := oauth.NewAuthRequest(redirectURI)
+	if err != nil {
+		return nil, err
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	binding, err := uc.states.Put(oauth.PendingLogin{
+		Provider:   providerName,
+		Request:    req,
+		LinkUserID: linkUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.OAuthStart{URL: authURL, Binding: binding}, nil
+}
@@ -0,0 +1,74 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	userusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/oauth"
+)
+
+var ForeignLinkErr = errors.New("oauth link was started by another user")
+
+type CompleteUseCase struct {
+	registry      *oauth.Registry
+	states        *oauth.StateStore
+	getOrCreateUC *userusecases.GetOrCreateUserByIdentityUseCase
+	linkUC        *userusecases.LinkIdentityUseCase
+}
+
+func NewCompleteUseCase(
+	registry *oauth.Registry,
+	states *oauth.StateStore,
+	getOrCreateUC *userusecases.GetOrCreateUserByIdentityUseCase,
+	linkUC *userusecases.LinkIdentityUseCase,
+) *CompleteUseCase {
+	return &CompleteUseCase{
+		registry:      registry,
+		states:        states,
+		getOrCreateUC: getOrCreateUC,
+		linkUC:        linkUC,
+	}
+}
+
+func (uc *CompleteUseCase) Name() string {
+	return "Завершить вход через внешнего провайдера"
+}
+
+// Execute завершает вход; binding — значение из cookie браузера, currentUserID — вошедший пользователь или 0
+func (uc *CompleteUseCase) Execute(ctx context.Context, providerName, code, state, binding string, currentUserID int64) (*dto.OAuthLoginResult, error) {
+	provider, err := uc.registry.Get(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	// state одноразовый: повторная отправка того же кода не пройдет
+	pending, err := uc.states.Take(providerName, state, binding)
+	if err != nil {
+		return nil, err
+	}
+	// привязку завершает только тот, кто ее начал, иначе чужой провайдер окажется в его аккаунте
+	if pending.LinkUserID != 0 && pending.LinkUserID != currentUserID {
+		return nil, ForeignLinkErr
+	}
+
+	identity, err := provider.Exchange(ctx, code, pending.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	if pending.LinkUserID != 0 {
+		link, linkErr := uc.linkUC.Execute(pending.LinkUserID, identity)
+		if linkErr != nil {
+			return nil, linkErr
+		}
+		return &dto.OAuthLoginResult{UserID: pending.LinkUserID, Linked: true, Link: link}, nil
+	}
+
+	user, err := uc.getOrCreateUC.Execute(identity)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.OAuthLoginResult{UserID: user.ID}, nil
+}
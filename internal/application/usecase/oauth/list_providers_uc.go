@@ -0,0 +1,29 @@
+package oauth
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/oauth"
+)
+
+type ListProvidersUseCase struct {
+	registry *oauth.Registry
+}
+
+func NewListProvidersUseCase(registry *oauth.Registry) *ListProvidersUseCase {
+	return &ListProvidersUseCase{
+		registry: registry,
+	}
+}
+
+func (uc *ListProvidersUseCase) Name() string {
+	return "Показать доступных провайдеров входа"
+}
+
+func (uc *ListProvidersUseCase) Execute() []dto.OAuthProvider {
+	providers := uc.registry.List()
+	res := make([]dto.OAuthProvider, 0, len(providers))
+	for _, p := range providers {
+		res = append(res, dto.OAuthProvider{Name: p.Name(), Title: p.Title()})
+	}
+	return res
+}
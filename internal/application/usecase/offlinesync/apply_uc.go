@@ -0,0 +1,575 @@
+package offlinesync
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	exerciseusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/exercises"
+	setusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/sets"
+	workoutusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/workouts"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisegrouptypes"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercises"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sets"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/syncmutations"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/realtime"
+)
+
+const (
+	MutationCreateExercise = "create_exercise"
+	MutationAddSet         = "add_set"
+	MutationChangeSet      = "change_set"
+	MutationCompleteSet    = "complete_set"
+	MutationFinishWorkout  = "finish_workout"
+)
+
+const (
+	StatusApplied   = "applied"
+	StatusDuplicate = "duplicate"
+	StatusConflict  = "conflict"
+	StatusRejected  = "rejected"
+
+	// занятая, но еще не примененная мутация; видна только внутри своей транзакции
+	statusPending = "pending"
+)
+
+const (
+	// за один запрос принимаем ограниченную очередь, остальное клиент досылает следующим запросом
+	maxMutationsPerRequest = 500
+	// при первой синхронизации отдаем только недавние тренировки
+	initialSyncWindow = 30 * 24 * time.Hour
+)
+
+var (
+	ErrTooManyMutations = fmt.Errorf("too many mutations, max %d per request", maxMutationsPerRequest)
+	ErrInvalidCursor    = errors.New("invalid cursor")
+
+	errNotFound    = errors.New("not found")
+	errAccess      = errors.New("access denied")
+	errUnknownType = errors.New("unknown mutation type")
+	errBadRef      = errors.New("unknown reference")
+	errRejected    = errors.New("mutation rejected")
+)
+
+type createExercisePayload struct {
+	WorkoutID      int64 `json:"workout_id"`
+	ExerciseTypeID int64 `json:"exercise_type_id"`
+}
+
+// ссылки *_ref указывают на client_id мутации, создавшей сущность в этой или прошлой синхронизации
+type addSetPayload struct {
+	ExerciseID  int64  `json:"exercise_id"`
+	ExerciseRef string `json:"exercise_ref"`
+}
+
+type changeSetPayload struct {
+	SetID       int64   `json:"set_id"`
+	SetRef      string  `json:"set_ref"`
+	BaseVersion int64   `json:"base_version"`
+	FactReps    int     `json:"fact_reps"`
+	FactWeight  float32 `json:"fact_weight"`
+	FactMinutes int     `json:"fact_minutes"`
+	FactMeters  int     `json:"fact_meters"`
+}
+
+type completeSetPayload struct {
+	SetID       int64  `json:"set_id"`
+	SetRef      string `json:"set_ref"`
+	BaseVersion int64  `json:"base_version"`
+	Completed   bool   `json:"completed"`
+}
+
+type finishWorkoutPayload struct {
+	WorkoutID   int64      `json:"workout_id"`
+	BaseVersion int64      `json:"base_version"`
+	EndedAt     *time.Time `json:"ended_at"`
+}
+
+// Store — репозитории и сценарии, через которые применяется одна мутация; все они работают в одной транзакции
+type Store struct {
+	SyncMutations  syncmutations.Repo
+	Workouts       workouts.Repo
+	Exercises      exercises.Repo
+	Sets           sets.Repo
+	Sessions       sessions.Repo
+	CreateExercise *exerciseusecases.CreateUseCase
+	AddOneMoreSet  *setusecases.AddOneMoreUseCase
+}
+
+// InTx выполняет fn в транзакции; ошибка fn откатывает все, что было сделано через Store
+type InTx func(fn func(s Store) error) error
+
+type ApplyUseCase struct {
+	syncMutationsRepo      syncmutations.Repo
+	workoutsRepo           workouts.Repo
+	exerciseGroupTypesRepo exercisegrouptypes.Repo
+	inTx                   InTx
+	hub                    *realtime.Hub
+}
+
+func NewApplyUseCase(
+	syncMutationsRepo syncmutations.Repo,
+	workoutsRepo workouts.Repo,
+	exerciseGroupTypesRepo exercisegrouptypes.Repo,
+	inTx InTx,
+	hub *realtime.Hub,
+) *ApplyUseCase {
+	return &ApplyUseCase{
+		syncMutationsRepo:      syncMutationsRepo,
+		workoutsRepo:           workoutsRepo,
+		exerciseGroupTypesRepo: exerciseGroupTypesRepo,
+		inTx:                   inTx,
+		hub:                    hub,
+	}
+}
+
+func (uc *ApplyUseCase) Name() string {
+	return "Синхронизация офлайн-изменений"
+}
+
+// Execute применяет очередь мутаций по порядку и возвращает тренировки, измененные после курсора.
+// Конфликт: если сущность изменилась после base_version, побеждает более позднее по времени изменение
+func (uc *ApplyUseCase) Execute(userID int64, req *dto.SyncRequest) (*dto.SyncResponse, error) {
+	if len(req.Mutations) > maxMutationsPerRequest {
+		return nil, ErrTooManyMutations
+	}
+
+	// курсор фиксируем до применения, чтобы не потерять параллельные изменения
+	syncStartedAt := time.Now()
+
+	since := syncStartedAt.Add(-initialSyncWindow)
+	if req.Cursor != "" {
+		cursor, err := time.Parse(time.RFC3339Nano, req.Cursor)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		since = cursor
+	}
+
+	// кроме самих мутаций ищем те, на которые они ссылаются: сущность могла быть создана
+	// в прошлой синхронизации, если очередь не поместилась в один запрос
+	clientIDs := make([]string, 0, len(req.Mutations))
+	for _, m := range req.Mutations {
+		clientIDs = append(clientIDs, m.ClientID)
+		if ref := payloadRef(m.Payload); ref != "" {
+			clientIDs = append(clientIDs, ref)
+		}
+	}
+	applied, err := uc.syncMutationsRepo.FindByClientIDs(userID, clientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]models.SyncMutation, len(applied))
+	refs := make(map[string]int64, len(applied))
+	for _, m := range applied {
+		known[m.ClientID] = m
+		refs[m.ClientID] = m.EntityID
+	}
+
+	results := make([]dto.SyncMutationResult, 0, len(req.Mutations))
+	for _, m := range req.Mutations {
+		if prev, ok := known[m.ClientID]; ok {
+			results = append(results, dto.SyncMutationResult{
+				ClientID: m.ClientID,
+				Status:   StatusDuplicate,
+				EntityID: prev.EntityID,
+			})
+			continue
+		}
+
+		res, err := uc.applyOnce(userID, m, refs)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+		if res.Status == StatusRejected {
+			continue
+		}
+		known[m.ClientID] = models.SyncMutation{ClientID: m.ClientID, Status: res.Status, EntityID: res.EntityID}
+		refs[m.ClientID] = res.EntityID
+	}
+
+	changed, err := uc.workoutsRepo.FindUpdatedSince(userID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := uc.exerciseGroupTypesRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	groupsMap := make(map[string]string)
+	for _, v := range groups {
+		groupsMap[v.Code] = v.Name
+	}
+
+	formatted := make([]*dto.FormattedWorkout, 0, len(changed))
+	for _, w := range changed {
+		formatted = append(formatted, dto.MapToFormattedWorkout(w, groupsMap))
+	}
+
+	return &dto.SyncResponse{
+		Cursor:   syncStartedAt.UTC().Format(time.RFC3339Nano),
+		Results:  results,
+		Workouts: formatted,
+	}, nil
+}
+
+// applyOnce занимает client_id и применяет мутацию в одной транзакции. Повтор, в том числе параллельный,
+// упирается в уникальный индекс sync_mutations и получает duplicate, не применяясь второй раз
+func (uc *ApplyUseCase) applyOnce(userID int64, m dto.SyncMutation, refs map[string]int64) (dto.SyncMutationResult, error) {
+	res := dto.SyncMutationResult{ClientID: m.ClientID}
+
+	if _, err := uuid.Parse(m.ClientID); err != nil {
+		res.Status = StatusRejected
+		res.Error = "invalid client_id"
+		return res, nil
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+
+	var published []realtime.Event
+	err := uc.inTx(func(s Store) error {
+		record := &models.SyncMutation{
+			UserID:     userID,
+			ClientID:   m.ClientID,
+			Type:       m.Type,
+			Status:     statusPending,
+			ClientTime: m.CreatedAt,
+			AppliedAt:  time.Now(),
+		}
+		claimed, err := s.SyncMutations.Claim(record)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			prev, err := s.SyncMutations.FindByClientIDs(userID, []string{m.ClientID})
+			if err != nil {
+				return err
+			}
+			res.Status = StatusDuplicate
+			if len(prev) > 0 {
+				res.EntityID = prev[0].EntityID
+			}
+			return nil
+		}
+
+		t := &mutationTx{Store: s, userID: userID, refs: refs}
+		if err = t.apply(m, &res); err != nil {
+			// отклоненную мутацию не запоминаем, вместе с ней откатываются и ее частичные изменения
+			res.Status = StatusRejected
+			res.Error = err.Error()
+			return errRejected
+		}
+
+		record.Status = res.Status
+		record.EntityID = res.EntityID
+		if err = s.SyncMutations.SetResult(record); err != nil {
+			return err
+		}
+		published = t.published
+		return nil
+	})
+	if err != nil && !errors.Is(err, errRejected) {
+		return dto.SyncMutationResult{}, err
+	}
+
+	// клиенты узнают об изменениях только после коммита
+	for _, event := range published {
+		uc.hub.Publish(event)
+	}
+	return res, nil
+}
+
+// mutationTx — применение одной мутации внутри транзакции
+type mutationTx struct {
+	Store
+	userID    int64
+	refs      map[string]int64
+	published []realtime.Event
+}
+
+func (t *mutationTx) apply(m dto.SyncMutation, res *dto.SyncMutationResult) error {
+	switch m.Type {
+	case MutationCreateExercise:
+		return t.createExercise(m, res)
+	case MutationAddSet:
+		return t.addSet(m, res)
+	case MutationChangeSet:
+		return t.changeSet(m, res)
+	case MutationCompleteSet:
+		return t.completeSet(m, res)
+	case MutationFinishWorkout:
+		return t.finishWorkout(m, res)
+	default:
+		return errUnknownType
+	}
+}
+
+func (t *mutationTx) createExercise(m dto.SyncMutation, res *dto.SyncMutationResult) error {
+	var p createExercisePayload
+	if err := json.Unmarshal(m.Payload, &p); err != nil {
+		return err
+	}
+	if _, err := t.ownWorkout(p.WorkoutID); err != nil {
+		return err
+	}
+
+	created, err := t.CreateExercise.Execute(p.WorkoutID, p.ExerciseTypeID)
+	if err != nil {
+		return err
+	}
+
+	res.Status = StatusApplied
+	res.EntityID = created.ExerciseID
+	return nil
+}
+
+func (t *mutationTx) addSet(m dto.SyncMutation, res *dto.SyncMutationResult) error {
+	var p addSetPayload
+	if err := json.Unmarshal(m.Payload, &p); err != nil {
+		return err
+	}
+	exerciseID, err := resolveRef(p.ExerciseID, p.ExerciseRef, t.refs)
+	if err != nil {
+		return err
+	}
+	if _, err = t.ownExercise(exerciseID); err != nil {
+		return err
+	}
+
+	// сценарий внутри транзакции собран без hub, событие отправляется после коммита
+	added, err := t.AddOneMoreSet.Execute(exerciseID, 0)
+	if err != nil {
+		return err
+	}
+	t.published = append(t.published, realtime.Event{
+		Type:       realtime.SetAdded,
+		WorkoutID:  added.WorkoutID,
+		ExerciseID: exerciseID,
+		SetID:      added.SetID,
+		Version:    added.Version,
+	})
+
+	res.Status = StatusApplied
+	res.EntityID = added.SetID
+	res.Version = added.Version
+	return nil
+}
+
+func (t *mutationTx) changeSet(m dto.SyncMutation, res *dto.SyncMutationResult) error {
+	var p changeSetPayload
+	if err := json.Unmarshal(m.Payload, &p); err != nil {
+		return err
+	}
+	set, err := t.ownSet(p.SetID, p.SetRef)
+	if err != nil {
+		return err
+	}
+
+	res.EntityID = set.ID
+	if isConflict(p.BaseVersion, set.Version, set.UpdatedAt, m.CreatedAt) {
+		res.Status = StatusConflict
+		res.Version = set.Version
+		return nil
+	}
+
+	set.FactReps = p.FactReps
+	set.FactWeight = p.FactWeight
+	set.FactMinutes = p.FactMinutes
+	set.FactMeters = p.FactMeters
+	changed := events.WorkoutChanged{WorkoutID: set.Exercise.GetWorkoutDayID(), UserID: t.userID}
+	if err = t.Sets.Save(set, changed); err != nil {
+		return err
+	}
+
+	t.published = append(t.published, realtime.Event{
+		Type:       realtime.SetChanged,
+		WorkoutID:  set.Exercise.GetWorkoutDayID(),
+		ExerciseID: set.ExerciseID,
+		SetID:      set.ID,
+		Version:    set.Version,
+	})
+
+	res.Status = StatusApplied
+	res.Version = set.Version
+	return nil
+}
+
+func (t *mutationTx) completeSet(m dto.SyncMutation, res *dto.SyncMutationResult) error {
+	var p completeSetPayload
+	if err := json.Unmarshal(m.Payload, &p); err != nil {
+		return err
+	}
+	set, err := t.ownSet(p.SetID, p.SetRef)
+	if err != nil {
+		return err
+	}
+
+	res.EntityID = set.ID
+	if isConflict(p.BaseVersion, set.Version, set.UpdatedAt, m.CreatedAt) {
+		res.Status = StatusConflict
+		res.Version = set.Version
+		return nil
+	}
+
+	// в отличие от кнопки в боте состояние задается явно, повтор мутации не переключает его обратно
+	set.Completed = p.Completed
+	if p.Completed {
+		completedAt := m.CreatedAt
+		set.CompletedAt = &completedAt
+	} else {
+		set.CompletedAt = nil
+	}
+	// снятая отметка убирает подход из агрегатов
+	evts := []events.Event{events.WorkoutChanged{WorkoutID: set.Exercise.GetWorkoutDayID(), UserID: t.userID}}
+	if set.Completed {
+		evts = []events.Event{events.SetCompleted{
+			SetID:       set.ID,
+			ExerciseID:  set.ExerciseID,
+			WorkoutID:   set.Exercise.GetWorkoutDayID(),
+			UserID:      t.userID,
+			CompletedAt: m.CreatedAt,
+		}}
+	}
+	if err = t.Sets.Save(set, evts...); err != nil {
+		return err
+	}
+
+	eventType := realtime.SetCompleted
+	if !set.Completed {
+		eventType = realtime.SetChanged
+	}
+	t.published = append(t.published, realtime.Event{
+		Type:       eventType,
+		WorkoutID:  set.Exercise.GetWorkoutDayID(),
+		ExerciseID: set.ExerciseID,
+		SetID:      set.ID,
+		Version:    set.Version,
+	})
+
+	res.Status = StatusApplied
+	res.Version = set.Version
+	return nil
+}
+
+func (t *mutationTx) finishWorkout(m dto.SyncMutation, res *dto.SyncMutationResult) error {
+	var p finishWorkoutPayload
+	if err := json.Unmarshal(m.Payload, &p); err != nil {
+		return err
+	}
+	workout, err := t.ownWorkout(p.WorkoutID)
+	if err != nil {
+		return err
+	}
+
+	res.EntityID = workout.ID
+	if isConflict(p.BaseVersion, workout.Version, workout.UpdatedAt, m.CreatedAt) {
+		res.Status = StatusConflict
+		res.Version = workout.Version
+		return nil
+	}
+
+	endedAt := m.CreatedAt
+	if p.EndedAt != nil {
+		endedAt = *p.EndedAt
+	}
+	// время окончания пришло с клиента, проверяем его как при ручной правке
+	if err = workoutusecases.ValidateTimes(workout.StartedAt, &endedAt); err != nil {
+		return err
+	}
+	workout.Completed = true
+	workout.EndedAt = &endedAt
+	finished := events.WorkoutFinished{WorkoutID: workout.ID, UserID: workout.UserID, EndedAt: endedAt}
+	if err = t.Workouts.Save(workout, finished); err != nil {
+		return err
+	}
+	if err = t.Sessions.UpdateIsActive(workout.ID, false); err != nil {
+		return err
+	}
+
+	res.Status = StatusApplied
+	res.Version = workout.Version
+	return nil
+}
+
+func (t *mutationTx) ownWorkout(workoutID int64) (*models.WorkoutDay, error) {
+	workout, err := t.Workouts.Get(workoutID)
+	if err != nil || workout.ID == 0 {
+		return nil, errNotFound
+	}
+	if workout.UserID != t.userID {
+		return nil, errAccess
+	}
+	return &workout, nil
+}
+
+func (t *mutationTx) ownExercise(exerciseID int64) (*models.Exercise, error) {
+	ex, err := t.Exercises.Get(exerciseID)
+	if err != nil || ex.ID == 0 {
+		return nil, errNotFound
+	}
+	if ex.WorkoutDay.UserID != t.userID {
+		return nil, errAccess
+	}
+	return &ex, nil
+}
+
+func (t *mutationTx) ownSet(setID int64, setRef string) (*models.Set, error) {
+	id, err := resolveRef(setID, setRef, t.refs)
+	if err != nil {
+		return nil, err
+	}
+	set, err := t.Sets.Get(id)
+	if err != nil || set.ID == 0 {
+		return nil, errNotFound
+	}
+	if _, err = t.ownExercise(set.ExerciseID); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// payloadRef — client_id мутации, на сущность которой ссылается payload; ошибку разбора
+// здесь не разбираем, ее вернет применение мутации
+func payloadRef(payload json.RawMessage) string {
+	var p struct {
+		ExerciseRef string `json:"exercise_ref"`
+		SetRef      string `json:"set_ref"`
+	}
+	if json.Unmarshal(payload, &p) != nil {
+		return ""
+	}
+	if p.ExerciseRef != "" {
+		return p.ExerciseRef
+	}
+	return p.SetRef
+}
+
+func resolveRef(id int64, ref string, refs map[string]int64) (int64, error) {
+	if id != 0 {
+		return id, nil
+	}
+	if resolved, ok := refs[ref]; ok && resolved != 0 {
+		return resolved, nil
+	}
+	return 0, errBadRef
+}
+
+// isConflict: сущность менялась с момента base_version, и серверное изменение новее клиентского
+func isConflict(baseVersion, currentVersion int64, updatedAt, clientTime time.Time) bool {
+	if baseVersion == 0 || baseVersion == currentVersion {
+		return false
+	}
+	return !clientTime.After(updatedAt)
+}
@@ -0,0 +1,440 @@
+package offlinesync
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	setusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/sets"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisegrouptypes"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercises"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sets"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+const (
+	userID     = 1
+	exerciseID = 10
+	workoutID  = 100
+	clientID   = "8c1f6a2e-4c1b-4f5e-9a57-3b1d2a0c9e11"
+)
+
+// state — содержимое базы: тренировка workoutID, подходы упражнения exerciseID и занятые client_id
+type state struct {
+	workout   models.WorkoutDay
+	sets      []models.Set
+	mutations map[string]models.SyncMutation
+	nextID    int64
+}
+
+func (s state) clone() *state {
+	c := &state{workout: s.workout, sets: append([]models.Set(nil), s.sets...), mutations: make(map[string]models.SyncMutation), nextID: s.nextID}
+	for k, v := range s.mutations {
+		c.mutations[k] = v
+	}
+	return c
+}
+
+// fakeDB выполняет транзакции по очереди, как это делает уникальный индекс для одного client_id:
+// второй запрос ждет, пока первый закоммитит или откатит свою строку
+type fakeDB struct {
+	mu        sync.Mutex
+	committed *state
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{committed: &state{
+		workout:   models.WorkoutDay{ID: workoutID, UserID: userID, StartedAt: time.Now().Add(-time.Hour), Version: 1},
+		mutations: make(map[string]models.SyncMutation),
+		nextID:    1,
+	}}
+}
+
+func (db *fakeDB) inTx(fn func(s Store) error) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	work := db.committed.clone()
+	setsRepo := fakeSets{st: work}
+	exercisesRepo := fakeExercises{st: work}
+	err := fn(Store{
+		SyncMutations: fakeSyncMutations{st: work},
+		Workouts:      fakeWorkouts{st: work},
+		Exercises:     exercisesRepo,
+		Sessions:      fakeSessions{},
+		Sets:          setsRepo,
+		AddOneMoreSet: setusecases.NewAddOneMoreUseCase(setsRepo, exercisesRepo, nil),
+	})
+	if err == nil {
+		db.committed = work
+	}
+	return err
+}
+
+func (db *fakeDB) setCount() int {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return len(db.committed.sets)
+}
+
+func (db *fakeDB) state() *state {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.committed.clone()
+}
+
+// lockedSyncMutations читает закоммиченные мутации вне транзакции
+type lockedSyncMutations struct {
+	fakeSyncMutations
+	db *fakeDB
+}
+
+func (r lockedSyncMutations) FindByClientIDs(userID int64, clientIDs []string) ([]models.SyncMutation, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	return fakeSyncMutations{st: r.db.committed}.FindByClientIDs(userID, clientIDs)
+}
+
+type fakeSyncMutations struct {
+	st *state
+}
+
+func (r fakeSyncMutations) Claim(m *models.SyncMutation) (bool, error) {
+	if _, ok := r.st.mutations[m.ClientID]; ok {
+		return false, nil
+	}
+	m.ID = r.st.nextID
+	r.st.nextID++
+	r.st.mutations[m.ClientID] = *m
+	return true, nil
+}
+
+func (r fakeSyncMutations) SetResult(m *models.SyncMutation) error {
+	r.st.mutations[m.ClientID] = *m
+	return nil
+}
+
+func (r fakeSyncMutations) FindByClientIDs(_ int64, clientIDs []string) ([]models.SyncMutation, error) {
+	var result []models.SyncMutation
+	for _, id := range clientIDs {
+		if m, ok := r.st.mutations[id]; ok {
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+type fakeExercises struct {
+	exercises.Repo
+	st *state
+}
+
+func (r fakeExercises) Get(id int64) (models.Exercise, error) {
+	if id != exerciseID {
+		return models.Exercise{}, nil
+	}
+	return models.Exercise{
+		ID:           exerciseID,
+		WorkoutDayID: workoutID,
+		WorkoutDay:   &models.WorkoutDay{ID: workoutID, UserID: userID},
+		ExerciseType: &models.ExerciseType{Units: "reps,weight"},
+		Sets:         append([]models.Set(nil), r.st.sets...),
+	}, nil
+}
+
+type fakeSets struct {
+	sets.Repo
+	st *state
+}
+
+func (r fakeSets) Get(id int64) (*models.Set, error) {
+	for _, set := range r.st.sets {
+		if set.ID == id {
+			set.Exercise = &models.Exercise{ID: set.ExerciseID, WorkoutDayID: workoutID}
+			return &set, nil
+		}
+	}
+	return &models.Set{}, nil
+}
+
+// Save повторяет versioning: новая запись получает версию 1, обновление увеличивает ее
+func (r fakeSets) Save(set *models.Set, _ ...events.Event) error {
+	set.UpdatedAt = time.Now()
+	if set.ID == 0 {
+		set.ID = r.st.nextID
+		set.Version = 1
+		r.st.nextID++
+		r.st.sets = append(r.st.sets, *set)
+		return nil
+	}
+	for i := range r.st.sets {
+		if r.st.sets[i].ID == set.ID {
+			set.Version++
+			r.st.sets[i] = *set
+		}
+	}
+	return nil
+}
+
+type fakeWorkouts struct {
+	workouts.Repo
+	st *state
+}
+
+func (r fakeWorkouts) Get(id int64) (models.WorkoutDay, error) {
+	if id != workoutID {
+		return models.WorkoutDay{}, nil
+	}
+	return r.st.workout, nil
+}
+
+func (r fakeWorkouts) Save(workout *models.WorkoutDay, _ ...events.Event) error {
+	workout.Version++
+	workout.UpdatedAt = time.Now()
+	r.st.workout = *workout
+	return nil
+}
+
+type fakeSessions struct {
+	sessions.Repo
+}
+
+func (fakeSessions) UpdateIsActive(int64, bool) error {
+	return nil
+}
+
+// changedWorkouts запоминает в since, если он задан, с какого момента запрошены измененные тренировки
+type changedWorkouts struct {
+	workouts.Repo
+	since *time.Time
+}
+
+func (r changedWorkouts) FindUpdatedSince(_ int64, since time.Time) ([]models.WorkoutDay, error) {
+	if r.since != nil {
+		*r.since = since
+	}
+	return nil, nil
+}
+
+type fakeGroups struct {
+	exercisegrouptypes.Repo
+}
+
+func (fakeGroups) GetAll() ([]models.ExerciseGroupType, error) {
+	return nil, nil
+}
+
+func newApplyUseCase(db *fakeDB) *ApplyUseCase {
+	return NewApplyUseCase(lockedSyncMutations{db: db}, changedWorkouts{}, fakeGroups{}, db.inTx, nil)
+}
+
+func addSetRequest(exercise int64) *dto.SyncRequest {
+	payload, _ := json.Marshal(addSetPayload{ExerciseID: exercise})
+	return &dto.SyncRequest{Mutations: []dto.SyncMutation{{
+		ClientID:  clientID,
+		Type:      MutationAddSet,
+		CreatedAt: time.Now(),
+		Payload:   payload,
+	}}}
+}
+
+func TestApplyReplayIsDuplicate(t *testing.T) {
+	db := newFakeDB()
+	uc := newApplyUseCase(db)
+
+	first, err := uc.Execute(userID, addSetRequest(exerciseID))
+	require.NoError(t, err)
+	require.Len(t, first.Results, 1)
+	assert.Equal(t, StatusApplied, first.Results[0].Status)
+
+	// ответ потерялся, клиент отправляет очередь повторно
+	second, err := uc.Execute(userID, addSetRequest(exerciseID))
+	require.NoError(t, err)
+	require.Len(t, second.Results, 1)
+	assert.Equal(t, StatusDuplicate, second.Results[0].Status)
+	assert.Equal(t, first.Results[0].EntityID, second.Results[0].EntityID)
+
+	assert.Equal(t, 1, db.setCount())
+}
+
+func TestApplyConcurrentReplayAppliesOnce(t *testing.T) {
+	db := newFakeDB()
+	uc := newApplyUseCase(db)
+
+	const replays = 8
+	results := make([]dto.SyncMutationResult, replays)
+	var wg sync.WaitGroup
+	for i := range replays {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := uc.Execute(userID, addSetRequest(exerciseID))
+			if assert.NoError(t, err) && assert.Len(t, resp.Results, 1) {
+				results[i] = resp.Results[0]
+			}
+		}()
+	}
+	wg.Wait()
+
+	statuses := map[string]int{}
+	for _, res := range results {
+		statuses[res.Status]++
+		assert.Equal(t, results[0].EntityID, res.EntityID)
+	}
+	assert.Equal(t, map[string]int{StatusApplied: 1, StatusDuplicate: replays - 1}, statuses)
+	assert.Equal(t, 1, db.setCount())
+}
+
+func TestApplyRejectedIsNotRemembered(t *testing.T) {
+	db := newFakeDB()
+	uc := newApplyUseCase(db)
+
+	// чужое или несуществующее упражнение: мутация отклоняется, client_id остается свободным
+	resp, err := uc.Execute(userID, addSetRequest(exerciseID+1))
+	require.NoError(t, err)
+	assert.Equal(t, StatusRejected, resp.Results[0].Status)
+
+	resp, err = uc.Execute(userID, addSetRequest(exerciseID))
+	require.NoError(t, err)
+	assert.Equal(t, StatusApplied, resp.Results[0].Status)
+	assert.Equal(t, 1, db.setCount())
+}
+
+func mutation(clientID, kind string, createdAt time.Time, payload any) dto.SyncMutation {
+	raw, _ := json.Marshal(payload)
+	return dto.SyncMutation{ClientID: clientID, Type: kind, CreatedAt: createdAt, Payload: raw}
+}
+
+func TestApplyResolvesRefFromEarlierSync(t *testing.T) {
+	db := newFakeDB()
+	// упражнение создано прошлой синхронизацией, подход к нему клиент досылает следующим запросом
+	const exerciseClientID = "0b7d4c8e-2f1a-4d6b-8c3e-5a9f1e2d7b40"
+	db.committed.mutations[exerciseClientID] = models.SyncMutation{
+		UserID: userID, ClientID: exerciseClientID, Type: MutationCreateExercise, Status: StatusApplied, EntityID: exerciseID,
+	}
+	uc := newApplyUseCase(db)
+
+	resp, err := uc.Execute(userID, &dto.SyncRequest{Mutations: []dto.SyncMutation{
+		mutation(clientID, MutationAddSet, time.Now(), addSetPayload{ExerciseRef: exerciseClientID}),
+	}})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	assert.Equal(t, StatusApplied, resp.Results[0].Status, resp.Results[0].Error)
+	assert.Equal(t, 1, db.setCount())
+}
+
+func TestIsConflict(t *testing.T) {
+	server := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name        string
+		baseVersion int64
+		clientTime  time.Time
+		conflict    bool
+	}{
+		{"без base_version клиент не ждет конкретной версии", 0, server.Add(-time.Hour), false},
+		{"base_version совпадает с текущей", 3, server.Add(-time.Hour), false},
+		{"сущность менялась, но клиентское изменение позже", 2, server.Add(time.Minute), false},
+		{"сущность менялась позже клиентского изменения", 2, server.Add(-time.Minute), true},
+		{"одновременные изменения решаются в пользу сервера", 2, server, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.conflict, isConflict(c.baseVersion, 3, server, c.clientTime))
+		})
+	}
+}
+
+func TestApplyChangeSetBaseVersion(t *testing.T) {
+	serverTime := time.Now().Add(-time.Minute)
+	seed := func() *fakeDB {
+		db := newFakeDB()
+		// с версии 2, которую видел клиент, подход успели изменить на сервере
+		db.committed.sets = []models.Set{{ID: 5, ExerciseID: exerciseID, FactReps: 8, Version: 3, UpdatedAt: serverTime}}
+		db.committed.nextID = 6
+		return db
+	}
+	change := func(baseVersion int64, at time.Time) *dto.SyncRequest {
+		return &dto.SyncRequest{Mutations: []dto.SyncMutation{
+			mutation(clientID, MutationChangeSet, at, changeSetPayload{SetID: 5, BaseVersion: baseVersion, FactReps: 12}),
+		}}
+	}
+
+	t.Run("серверное изменение новее", func(t *testing.T) {
+		db := seed()
+		resp, err := newApplyUseCase(db).Execute(userID, change(2, serverTime.Add(-time.Minute)))
+		require.NoError(t, err)
+		assert.Equal(t, dto.SyncMutationResult{ClientID: clientID, Status: StatusConflict, EntityID: 5, Version: 3}, resp.Results[0])
+		assert.Equal(t, 8, db.state().sets[0].FactReps, "серверное значение остается")
+	})
+	t.Run("клиентское изменение новее", func(t *testing.T) {
+		db := seed()
+		resp, err := newApplyUseCase(db).Execute(userID, change(2, serverTime.Add(30*time.Second)))
+		require.NoError(t, err)
+		assert.Equal(t, StatusApplied, resp.Results[0].Status)
+		assert.EqualValues(t, 4, resp.Results[0].Version)
+		assert.Equal(t, 12, db.state().sets[0].FactReps)
+	})
+	t.Run("клиент видел текущую версию", func(t *testing.T) {
+		db := seed()
+		resp, err := newApplyUseCase(db).Execute(userID, change(3, serverTime.Add(-time.Hour)))
+		require.NoError(t, err)
+		assert.Equal(t, StatusApplied, resp.Results[0].Status)
+		assert.Equal(t, 12, db.state().sets[0].FactReps)
+	})
+}
+
+func TestApplyCursorDelta(t *testing.T) {
+	db := newFakeDB()
+	since := new(time.Time)
+	uc := NewApplyUseCase(lockedSyncMutations{db: db}, changedWorkouts{since: since}, fakeGroups{}, db.inTx, nil)
+
+	// первая синхронизация отдает недавние тренировки
+	before := time.Now()
+	resp, err := uc.Execute(userID, addSetRequest(exerciseID))
+	require.NoError(t, err)
+	assert.WithinRange(t, *since, before.Add(-initialSyncWindow), time.Now().Add(-initialSyncWindow))
+
+	// курсор взят до применения мутаций: изменение из этого же запроса попадет и в следующую дельту
+	cursor, err := time.Parse(time.RFC3339Nano, resp.Cursor)
+	require.NoError(t, err)
+	assert.False(t, cursor.After(db.state().sets[0].UpdatedAt))
+
+	_, err = uc.Execute(userID, &dto.SyncRequest{Cursor: resp.Cursor})
+	require.NoError(t, err)
+	assert.True(t, since.Equal(cursor), "следующая синхронизация начинается с курсора")
+
+	_, err = uc.Execute(userID, &dto.SyncRequest{Cursor: "yesterday"})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestApplyFinishWorkoutValidatesEndedAt(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name    string
+		endedAt time.Time
+		status  string
+	}{
+		{"окончание раньше начала", now.Add(-2 * time.Hour), StatusRejected},
+		{"окончание в будущем", now.Add(time.Hour), StatusRejected},
+		{"корректное окончание", now.Add(-time.Minute), StatusApplied},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			db := newFakeDB()
+			resp, err := newApplyUseCase(db).Execute(userID, &dto.SyncRequest{Mutations: []dto.SyncMutation{
+				mutation(clientID, MutationFinishWorkout, now, finishWorkoutPayload{WorkoutID: workoutID, EndedAt: &c.endedAt}),
+			}})
+			require.NoError(t, err)
+			assert.Equal(t, c.status, resp.Results[0].Status)
+			assert.Equal(t, c.status == StatusApplied, db.state().workout.Completed)
+		})
+	}
+}
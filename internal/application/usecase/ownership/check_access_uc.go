@@ -0,0 +1,50 @@
+package ownership
+
+import (
+	"errors"
+
+	coachingusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/coaching"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/ownership"
+)
+
+var ForeignResourceErr = errors.New("resource belongs to another user")
+
+type CheckAccessUseCase struct {
+	ownershipRepo ownership.Repo
+	coachAccessUC *coachingusecases.CheckAccessUseCase
+}
+
+func NewCheckAccessUseCase(ownershipRepo ownership.Repo, coachAccessUC *coachingusecases.CheckAccessUseCase) *CheckAccessUseCase {
+	return &CheckAccessUseCase{
+		ownershipRepo: ownershipRepo,
+		coachAccessUC: coachAccessUC,
+	}
+}
+
+func (uc *CheckAccessUseCase) Name() string {
+	return "Проверить доступ к сущности"
+}
+
+// Execute пускает владельца сущности, а если задан coachScope — еще и тренера
+// владельца с этой областью доступа. Несуществующая сущность — gorm.ErrRecordNotFound
+func (uc *CheckAccessUseCase) Execute(userID int64, resource ownership.Resource, id int64, coachScope string) error {
+	ownerID, err := uc.ownershipRepo.OwnerID(resource, id)
+	if err != nil {
+		return err
+	}
+	if ownerID == userID {
+		return nil
+	}
+	if coachScope == "" {
+		return ForeignResourceErr
+	}
+
+	allowed, err := uc.coachAccessUC.Execute(userID, ownerID, coachScope)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ForeignResourceErr
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package programs
 
 import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
 )
 
@@ -26,8 +27,8 @@ func (uc *ActivateUseCase) Execute(userID, programID int64) error {
 		return err
 	}
 
-	*user.ActiveProgramID = programID
-	err = uc.usersRepo.Save(user)
+	user.ActiveProgramID = &programID
+	err = uc.usersRepo.Save(user, events.ProgramActivated{ProgramID: programID, UserID: userID, ActivatedBy: userID})
 	if err != nil {
 		return err
 	}
@@ -85,5 +85,7 @@ func mapProgramDTO(obj models.WorkoutProgram, user *models.User) *dto.ProgramDTO
 		CreatedAt: obj.CreatedAt.Add(time.Hour * 3).Format("02.01.2006 15:04"),
 		DayTypes:  dayTypes,
 		IsActive:  isActive,
+
+		AssignedByID: obj.AssignedByID,
 	}
 }
@@ -0,0 +1,103 @@
+package publicapi
+
+import (
+	"errors"
+	"math"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+var (
+	NotFoundErr         = errors.New("not found")
+	EmptyMeasurementErr = errors.New("measurement must contain at least one value")
+)
+
+// InvalidFieldErr — значение поля тела запроса вне допустимых границ
+type InvalidFieldErr struct {
+	Field string
+}
+
+func (e *InvalidFieldErr) Error() string {
+	return "invalid value of field " + e.Field
+}
+
+func workoutStatus(w *models.WorkoutDay) string {
+	switch {
+	case w.Completed:
+		return workouts.StatusCompleted
+	case w.InEditMode():
+		return workouts.StatusEditing
+	default:
+		return workouts.StatusActive
+	}
+}
+
+func mapWorkout(workoutDay *models.WorkoutDay) dto.APIWorkout {
+	result := dto.APIWorkout{
+		ID:           workoutDay.ID,
+		DayTypeID:    workoutDay.WorkoutDayTypeID,
+		Status:       workoutStatus(workoutDay),
+		StartedAt:    workoutDay.StartedAt,
+		EndedAt:      workoutDay.EndedAt,
+		AutoFinished: workoutDay.AutoFinished,
+		Exercises:    make([]dto.APIWorkoutExercise, 0, len(workoutDay.Exercises)),
+	}
+	if workoutDay.WorkoutDayType != nil {
+		result.DayTypeName = workoutDay.WorkoutDayType.Name
+	}
+	if workoutDay.EndedAt != nil {
+		result.DurationSeconds = int64(workoutDay.EndedAt.Sub(workoutDay.StartedAt).Seconds())
+	}
+
+	for _, e := range workoutDay.Exercises {
+		exercise := dto.APIWorkoutExercise{
+			ExerciseTypeID: e.ExerciseTypeID,
+			Sets:           make([]dto.APIWorkoutSet, 0, len(e.Sets)),
+		}
+		if e.ExerciseType != nil {
+			exercise.Name = e.ExerciseType.Name
+			exercise.Units = e.ExerciseType.Units
+		}
+		for _, s := range e.Sets {
+			exercise.Sets = append(exercise.Sets, dto.APIWorkoutSet{
+				Reps:        s.GetRealReps(),
+				WeightKg:    s.GetRealWeight(),
+				Minutes:     s.GetRealMinutes(),
+				Meters:      s.GetRealMeters(),
+				Completed:   s.Completed,
+				CompletedAt: s.CompletedAt,
+			})
+			if s.Completed {
+				result.TonnageKg += float64(s.GetRealWeight()) * float64(s.GetRealReps())
+			}
+		}
+		result.Exercises = append(result.Exercises, exercise)
+	}
+	return result
+}
+
+// в базе окружности хранятся в миллиметрах, вес — в граммах
+func mapMeasurement(m *models.Measurement) dto.APIMeasurement {
+	cm := func(mm int) float64 { return float64(mm) / 10 }
+	return dto.APIMeasurement{
+		ID:          m.ID,
+		MeasuredAt:  m.CreatedAt,
+		ShouldersCm: cm(m.Shoulders),
+		ChestCm:     cm(m.Chest),
+		HandLeftCm:  cm(m.HandLeft),
+		HandRightCm: cm(m.HandRight),
+		WaistCm:     cm(m.Waist),
+		ButtocksCm:  cm(m.Buttocks),
+		HipLeftCm:   cm(m.HipLeft),
+		HipRightCm:  cm(m.HipRight),
+		CalfLeftCm:  cm(m.CalfLeft),
+		CalfRightCm: cm(m.CalfRight),
+		WeightKg:    float64(m.Weight) / 1000,
+	}
+}
+
+func round(v float64) int {
+	return int(math.Round(v))
+}
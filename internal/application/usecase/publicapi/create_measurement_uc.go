@@ -0,0 +1,93 @@
+package publicapi
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/measurements"
+)
+
+const (
+	maxCircumferenceCm = 300
+	maxWeightKg        = 500
+	// допускаем небольшое расхождение часов клиента и сервера
+	maxMeasuredAtSkew = 24 * time.Hour
+)
+
+type CreateMeasurementUseCase struct {
+	measurementsRepo measurements.Repo
+}
+
+func NewCreateMeasurementUseCase(measurementsRepo measurements.Repo) *CreateMeasurementUseCase {
+	return &CreateMeasurementUseCase{
+		measurementsRepo: measurementsRepo,
+	}
+}
+
+func (uc *CreateMeasurementUseCase) Name() string {
+	return "API: добавить измерение тела"
+}
+
+func (uc *CreateMeasurementUseCase) Execute(userID int64, req dto.CreateAPIMeasurement) (*dto.APIMeasurement, error) {
+	now := time.Now()
+	measuredAt := now
+	if req.MeasuredAt != nil {
+		if req.MeasuredAt.After(now.Add(maxMeasuredAtSkew)) {
+			return nil, &InvalidFieldErr{Field: "measured_at"}
+		}
+		measuredAt = *req.MeasuredAt
+	}
+
+	circumferences := []struct {
+		field string
+		value float64
+	}{
+		{"shoulders_cm", req.ShouldersCm},
+		{"chest_cm", req.ChestCm},
+		{"hand_left_cm", req.HandLeftCm},
+		{"hand_right_cm", req.HandRightCm},
+		{"waist_cm", req.WaistCm},
+		{"buttocks_cm", req.ButtocksCm},
+		{"hip_left_cm", req.HipLeftCm},
+		{"hip_right_cm", req.HipRightCm},
+		{"calf_left_cm", req.CalfLeftCm},
+		{"calf_right_cm", req.CalfRightCm},
+	}
+	empty := req.WeightKg == 0
+	for _, c := range circumferences {
+		if c.value < 0 || c.value > maxCircumferenceCm {
+			return nil, &InvalidFieldErr{Field: c.field}
+		}
+		empty = empty && c.value == 0
+	}
+	if req.WeightKg < 0 || req.WeightKg > maxWeightKg {
+		return nil, &InvalidFieldErr{Field: "weight_kg"}
+	}
+	if empty {
+		return nil, EmptyMeasurementErr
+	}
+
+	mm := func(cm float64) int { return round(cm * 10) }
+	measurement := &models.Measurement{
+		UserID:    userID,
+		CreatedAt: measuredAt,
+		Shoulders: mm(req.ShouldersCm),
+		Chest:     mm(req.ChestCm),
+		HandLeft:  mm(req.HandLeftCm),
+		HandRight: mm(req.HandRightCm),
+		Waist:     mm(req.WaistCm),
+		Buttocks:  mm(req.ButtocksCm),
+		HipLeft:   mm(req.HipLeftCm),
+		HipRight:  mm(req.HipRightCm),
+		CalfLeft:  mm(req.CalfLeftCm),
+		CalfRight: mm(req.CalfRightCm),
+		Weight:    round(req.WeightKg * 1000),
+	}
+	if err := uc.measurementsRepo.Create(measurement); err != nil {
+		return nil, err
+	}
+
+	result := mapMeasurement(measurement)
+	return &result, nil
+}
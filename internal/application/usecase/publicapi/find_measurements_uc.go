@@ -0,0 +1,36 @@
+package publicapi
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/measurements"
+)
+
+type FindMeasurementsUseCase struct {
+	measurementsRepo measurements.Repo
+}
+
+func NewFindMeasurementsUseCase(measurementsRepo measurements.Repo) *FindMeasurementsUseCase {
+	return &FindMeasurementsUseCase{
+		measurementsRepo: measurementsRepo,
+	}
+}
+
+func (uc *FindMeasurementsUseCase) Name() string {
+	return "API: список измерений тела"
+}
+
+func (uc *FindMeasurementsUseCase) Execute(userID int64, filter measurements.Filter, offset, limit int) (*dto.APIMeasurements, error) {
+	items, total, err := uc.measurementsRepo.FindFiltered(userID, filter, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.APIMeasurements{
+		Items:      make([]dto.APIMeasurement, 0, len(items)),
+		Pagination: dto.Pagination{Offset: offset, Limit: limit, Total: int(total)},
+	}
+	for i := range items {
+		result.Items = append(result.Items, mapMeasurement(&items[i]))
+	}
+	return result, nil
+}
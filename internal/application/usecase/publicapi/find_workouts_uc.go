@@ -0,0 +1,36 @@
+package publicapi
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+type FindWorkoutsUseCase struct {
+	workoutsRepo workouts.Repo
+}
+
+func NewFindWorkoutsUseCase(workoutsRepo workouts.Repo) *FindWorkoutsUseCase {
+	return &FindWorkoutsUseCase{
+		workoutsRepo: workoutsRepo,
+	}
+}
+
+func (uc *FindWorkoutsUseCase) Name() string {
+	return "API: список тренировок"
+}
+
+func (uc *FindWorkoutsUseCase) Execute(userID int64, filter workouts.Filter, offset, limit int) (*dto.APIWorkouts, error) {
+	items, total, err := uc.workoutsRepo.FindFiltered(userID, filter, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.APIWorkouts{
+		Items:      make([]dto.APIWorkout, 0, len(items)),
+		Pagination: dto.Pagination{Offset: offset, Limit: limit, Total: int(total)},
+	}
+	for i := range items {
+		result.Items = append(result.Items, mapWorkout(&items[i]))
+	}
+	return result, nil
+}
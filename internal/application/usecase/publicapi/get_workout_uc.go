@@ -0,0 +1,41 @@
+package publicapi
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+type GetWorkoutUseCase struct {
+	workoutsRepo workouts.Repo
+}
+
+func NewGetWorkoutUseCase(workoutsRepo workouts.Repo) *GetWorkoutUseCase {
+	return &GetWorkoutUseCase{
+		workoutsRepo: workoutsRepo,
+	}
+}
+
+func (uc *GetWorkoutUseCase) Name() string {
+	return "API: тренировка"
+}
+
+func (uc *GetWorkoutUseCase) Execute(userID, workoutID int64) (*dto.APIWorkout, error) {
+	workoutDay, err := uc.workoutsRepo.Get(workoutID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, NotFoundErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	// чужую тренировку не раскрываем, отвечаем как на несуществующую
+	if workoutDay.UserID != userID {
+		return nil, NotFoundErr
+	}
+
+	result := mapWorkout(&workoutDay)
+	return &result, nil
+}
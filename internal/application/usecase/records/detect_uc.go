@@ -0,0 +1,63 @@
+package records
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/outbox"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/records"
+)
+
+// DetectUseCase ищет рекорды завершенной тренировки и публикует по событию на каждый
+type DetectUseCase struct {
+	recordsRepo records.Repo
+	outboxRepo  outbox.Repo
+}
+
+func NewDetectUseCase(recordsRepo records.Repo, outboxRepo outbox.Repo) *DetectUseCase {
+	return &DetectUseCase{
+		recordsRepo: recordsRepo,
+		outboxRepo:  outboxRepo,
+	}
+}
+
+func (uc *DetectUseCase) Name() string {
+	return "Найти рекорды тренировки"
+}
+
+func (uc *DetectUseCase) Execute(finished events.WorkoutFinished) ([]events.PersonalRecord, error) {
+	found, err := uc.recordsRepo.FindInWorkout(finished.WorkoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]events.PersonalRecord, 0, len(found))
+	for _, r := range found {
+		result = append(result, events.PersonalRecord{
+			WorkoutID:      finished.WorkoutID,
+			UserID:         finished.UserID,
+			ExerciseTypeID: r.ExerciseTypeID,
+			ExerciseName:   r.ExerciseName,
+			Weight:         r.Weight,
+			PrevWeight:     r.PrevWeight,
+			At:             finished.EndedAt,
+		})
+	}
+	return result, nil
+}
+
+// Handle — подписчик шины событий на завершение тренировки
+func (uc *DetectUseCase) Handle(event events.Event) error {
+	finished, ok := event.(events.WorkoutFinished)
+	if !ok {
+		return nil
+	}
+	found, err := uc.Execute(finished)
+	if err != nil || len(found) == 0 {
+		return err
+	}
+
+	evts := make([]events.Event, 0, len(found))
+	for _, r := range found {
+		evts = append(evts, r)
+	}
+	return uc.outboxRepo.Add(evts...)
+}
@@ -0,0 +1,33 @@
+package records
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/records"
+)
+
+// RecalculateUseCase обновляет сохраненные рекорды пользователя, которые показывает лента
+type RecalculateUseCase struct {
+	recordsRepo records.Repo
+}
+
+func NewRecalculateUseCase(recordsRepo records.Repo) *RecalculateUseCase {
+	return &RecalculateUseCase{
+		recordsRepo: recordsRepo,
+	}
+}
+
+func (uc *RecalculateUseCase) Name() string {
+	return "Пересчитать рекорды пользователя"
+}
+
+func (uc *RecalculateUseCase) Execute(userID int64) error {
+	return uc.recordsRepo.RecalculateUser(userID)
+}
+
+// Handle — подписчик шины событий; рекорды собираются из подходов целиком, поэтому повторная доставка безопасна
+func (uc *RecalculateUseCase) Handle(event events.Event) error {
+	if userID := events.UserOf(event); userID != 0 {
+		return uc.Execute(userID)
+	}
+	return nil
+}
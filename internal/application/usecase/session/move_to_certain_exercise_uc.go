@@ -6,20 +6,24 @@ import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercises"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/realtime"
 )
 
 type MoveToCertainUseCase struct {
 	sessionsRepo  sessions.Repo
 	exercisesRepo exercises.Repo
+	hub           *realtime.Hub
 }
 
 func NewMoveToCertainUseCase(
 	sessionsRepo sessions.Repo,
 	exercisesRepo exercises.Repo,
+	hub *realtime.Hub,
 ) *MoveToCertainUseCase {
 	return &MoveToCertainUseCase{
 		sessionsRepo:  sessionsRepo,
 		exercisesRepo: exercisesRepo,
+		hub:           hub,
 	}
 }
 
@@ -60,5 +64,11 @@ func (uc *MoveToCertainUseCase) Execute(workoutID int64, exerciseIndex int) erro
 		return err
 	}
 
+	uc.hub.Publish(realtime.Event{
+		Type:      realtime.ExerciseMoved,
+		WorkoutID: workoutID,
+		Index:     session.CurrentExerciseIndex,
+	})
+
 	return nil
 }
@@ -7,20 +7,24 @@ import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercises"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/realtime"
 )
 
 type MoveToUseCase struct {
 	sessionsRepo  sessions.Repo
 	exercisesRepo exercises.Repo
+	hub           *realtime.Hub
 }
 
 func NewMoveToExerciseUseCase(
 	sessionsRepo sessions.Repo,
 	exercisesRepo exercises.Repo,
+	hub *realtime.Hub,
 ) *MoveToUseCase {
 	return &MoveToUseCase{
 		sessionsRepo:  sessionsRepo,
 		exercisesRepo: exercisesRepo,
+		hub:           hub,
 	}
 }
 
@@ -78,5 +82,11 @@ func (uc *MoveToUseCase) Execute(workoutID int64, next bool) error {
 		return err
 	}
 
+	uc.hub.Publish(realtime.Event{
+		Type:      realtime.ExerciseMoved,
+		WorkoutID: workoutID,
+		Index:     session.CurrentExerciseIndex,
+	})
+
 	return nil
 }
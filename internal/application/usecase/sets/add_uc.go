@@ -1,16 +1,18 @@
 package sets
 
 import (
-	"fmt"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisegrouptypes"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercises"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sets"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/versioning"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/service/docgenerator"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/realtime"
 	summarysvc "github.com/SaenkoDmitry/training-tg-bot/internal/service/summary"
+	"log/slog"
 )
 
 type AddOneMoreUseCase struct {
@@ -21,15 +23,18 @@ type AddOneMoreUseCase struct {
 	summaryService         summarysvc.Service
 	docGeneratorService    docgenerator.Service
 	setsRepo               sets.Repo
+	hub                    *realtime.Hub
 }
 
 func NewAddOneMoreUseCase(
 	setsRepo sets.Repo,
 	exercisesRepo exercises.Repo,
+	hub *realtime.Hub,
 ) *AddOneMoreUseCase {
 	return &AddOneMoreUseCase{
 		setsRepo:      setsRepo,
 		exercisesRepo: exercisesRepo,
+		hub:           hub,
 	}
 }
 
@@ -37,11 +42,14 @@ func (uc *AddOneMoreUseCase) Name() string {
 	return "Добавить подход"
 }
 
-func (uc *AddOneMoreUseCase) Execute(exerciseID int64) (*dto.AddOneMoreSet, error) {
+func (uc *AddOneMoreUseCase) Execute(exerciseID, expectedVersion int64) (*dto.AddOneMoreSet, error) {
 	ex, err := uc.exercisesRepo.Get(exerciseID)
 	if err != nil {
 		return nil, err
 	}
+	if err = versioning.Check(expectedVersion, ex.Version); err != nil {
+		return &dto.AddOneMoreSet{WorkoutID: ex.WorkoutDayID}, err
+	}
 	nextSet := &models.Set{
 		ExerciseID: ex.ID,
 	}
@@ -88,11 +96,21 @@ func (uc *AddOneMoreUseCase) Execute(exerciseID int64) (*dto.AddOneMoreSet, erro
 
 	err = uc.setsRepo.Save(nextSet)
 	if err != nil {
-		fmt.Println("cannot create set:", err.Error())
+		slog.Error("cannot create set", "err", err)
 		return nil, err
 	}
 
+	uc.hub.Publish(realtime.Event{
+		Type:       realtime.SetAdded,
+		WorkoutID:  ex.WorkoutDayID,
+		ExerciseID: ex.ID,
+		SetID:      nextSet.ID,
+		Version:    nextSet.Version,
+	})
+
 	return &dto.AddOneMoreSet{
 		WorkoutID: ex.WorkoutDayID,
+		SetID:     nextSet.ID,
+		Version:   nextSet.Version,
 	}, nil
 }
@@ -1,19 +1,26 @@
 package sets
 
 import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sets"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/versioning"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/realtime"
 	"time"
 )
 
 type CompleteByIDUseCase struct {
 	setsRepo sets.Repo
+	hub      *realtime.Hub
 }
 
 func NewCompleteByIDUseCase(
 	setsRepo sets.Repo,
+	hub *realtime.Hub,
 ) *CompleteByIDUseCase {
 	return &CompleteByIDUseCase{
 		setsRepo: setsRepo,
+		hub:      hub,
 	}
 }
 
@@ -21,19 +28,46 @@ func (uc *CompleteByIDUseCase) Name() string {
 	return "Завершить/отменить подход"
 }
 
-func (uc *CompleteByIDUseCase) Execute(setID int64) error {
+func (uc *CompleteByIDUseCase) Execute(setID, expectedVersion int64) (*models.Set, error) {
 	set, err := uc.setsRepo.Get(setID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err = versioning.Check(expectedVersion, set.Version); err != nil {
+		return set, err
 	}
 	set.Completed = !set.Completed
 	now := time.Now()
 	set.CompletedAt = &now
 
-	err = uc.setsRepo.Save(set)
+	// снятая отметка убирает подход из агрегатов так же, как удаление
+	evts := workoutChanged(set.Exercise)
+	if set.Completed && set.Exercise != nil && set.Exercise.WorkoutDay != nil {
+		evts = []events.Event{events.SetCompleted{
+			SetID:       set.ID,
+			ExerciseID:  set.ExerciseID,
+			WorkoutID:   set.Exercise.WorkoutDayID,
+			UserID:      set.Exercise.WorkoutDay.UserID,
+			CompletedAt: now,
+		}}
+	}
+
+	err = uc.setsRepo.Save(set, evts...)
 	if err != nil {
-		return err
+		return set, err
+	}
+
+	eventType := realtime.SetCompleted
+	if !set.Completed {
+		eventType = realtime.SetChanged
 	}
+	uc.hub.Publish(realtime.Event{
+		Type:       eventType,
+		WorkoutID:  set.Exercise.GetWorkoutDayID(),
+		ExerciseID: set.ExerciseID,
+		SetID:      set.ID,
+		Version:    set.Version,
+	})
 
-	return nil
+	return set, nil
 }
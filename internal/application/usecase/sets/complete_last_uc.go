@@ -3,9 +3,12 @@ package sets
 import (
 	"errors"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercises"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisetypes"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sets"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/versioning"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/realtime"
 	"time"
 )
 
@@ -13,17 +16,20 @@ type CompleteLastUseCase struct {
 	setsRepo          sets.Repo
 	exercisesRepo     exercises.Repo
 	exerciseTypesRepo exercisetypes.Repo
+	hub               *realtime.Hub
 }
 
 func NewCompleteLastUseCase(
 	setsRepo sets.Repo,
 	exercisesRepo exercises.Repo,
 	exerciseTypesRepo exercisetypes.Repo,
+	hub *realtime.Hub,
 ) *CompleteLastUseCase {
 	return &CompleteLastUseCase{
 		setsRepo:          setsRepo,
 		exercisesRepo:     exercisesRepo,
 		exerciseTypesRepo: exerciseTypesRepo,
+		hub:               hub,
 	}
 }
 
@@ -35,11 +41,14 @@ var (
 	DoNothingErr = errors.New("do nothing")
 )
 
-func (uc *CompleteLastUseCase) Execute(exerciseID int64) (*dto.CompleteSet, error) {
+func (uc *CompleteLastUseCase) Execute(exerciseID, expectedVersion int64) (*dto.CompleteSet, error) {
 	exercise, err := uc.exercisesRepo.Get(exerciseID)
 	if err != nil {
 		return nil, err
 	}
+	if err = versioning.Check(expectedVersion, exercise.Version); err != nil {
+		return &dto.CompleteSet{WorkoutID: exercise.WorkoutDayID}, err
+	}
 
 	nextSet := exercise.NextSet()
 
@@ -47,7 +56,25 @@ func (uc *CompleteLastUseCase) Execute(exerciseID int64) (*dto.CompleteSet, erro
 		nextSet.Completed = true
 		now := time.Now()
 		nextSet.CompletedAt = &now
-		uc.setsRepo.Save(&nextSet)
+		completed := events.SetCompleted{
+			SetID:       nextSet.ID,
+			ExerciseID:  exercise.ID,
+			WorkoutID:   exercise.WorkoutDayID,
+			CompletedAt: now,
+		}
+		if exercise.WorkoutDay != nil {
+			completed.UserID = exercise.WorkoutDay.UserID
+		}
+		if err = uc.setsRepo.Save(&nextSet, completed); err != nil {
+			return &dto.CompleteSet{WorkoutID: exercise.WorkoutDayID}, err
+		}
+		uc.hub.Publish(realtime.Event{
+			Type:       realtime.SetCompleted,
+			WorkoutID:  exercise.WorkoutDayID,
+			ExerciseID: exercise.ID,
+			SetID:      nextSet.ID,
+			Version:    nextSet.Version,
+		})
 	} else {
 		return nil, DoNothingErr
 	}
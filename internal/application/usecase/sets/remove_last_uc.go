@@ -2,25 +2,30 @@ package sets
 
 import (
 	"errors"
-	"fmt"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercises"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sets"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/versioning"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/realtime"
+	"log/slog"
 )
 
 type RemoveLastUseCase struct {
 	setsRepo      sets.Repo
 	exercisesRepo exercises.Repo
+	hub           *realtime.Hub
 }
 
 func NewRemoveLastUseCase(
 	setsRepo sets.Repo,
 	exercisesRepo exercises.Repo,
+	hub *realtime.Hub,
 ) *RemoveLastUseCase {
 	return &RemoveLastUseCase{
 		setsRepo:      setsRepo,
 		exercisesRepo: exercisesRepo,
+		hub:           hub,
 	}
 }
 
@@ -32,18 +37,28 @@ var (
 	AddOneMoreExerciseToDeleteErr = errors.New(messages.AddOneMoreExerciseToDelete)
 )
 
-func (uc *RemoveLastUseCase) Execute(exerciseID int64) (*dto.RemoveLastSet, error) {
+func (uc *RemoveLastUseCase) Execute(exerciseID, expectedVersion int64) (*dto.RemoveLastSet, error) {
 	exercise, err := uc.exercisesRepo.Get(exerciseID)
 	if err != nil || len(exercise.Sets) == 0 {
 		return nil, AddOneMoreExerciseToDeleteErr
 	}
+	if err = versioning.Check(expectedVersion, exercise.Version); err != nil {
+		return &dto.RemoveLastSet{WorkoutID: exercise.WorkoutDayID}, err
+	}
 
 	lastSet := exercise.Sets[len(exercise.Sets)-1]
-	err = uc.setsRepo.Delete(lastSet.ID)
+	err = uc.setsRepo.Delete(lastSet.ID, workoutChanged(&exercise)...)
 	if err != nil {
-		fmt.Println("cannot remove set:", err.Error())
+		slog.Error("cannot remove set", "err", err)
 		return nil, err
 	}
 
+	uc.hub.Publish(realtime.Event{
+		Type:       realtime.SetRemoved,
+		WorkoutID:  exercise.WorkoutDayID,
+		ExerciseID: exercise.ID,
+		SetID:      lastSet.ID,
+	})
+
 	return &dto.RemoveLastSet{WorkoutID: exercise.WorkoutDayID}, nil
 }
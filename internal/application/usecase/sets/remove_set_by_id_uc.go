@@ -2,17 +2,22 @@ package sets
 
 import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sets"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/versioning"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/realtime"
 )
 
 type RemoveByIDUseCase struct {
 	setsRepo sets.Repo
+	hub      *realtime.Hub
 }
 
 func NewRemoveByIDUseCase(
 	setsRepo sets.Repo,
+	hub *realtime.Hub,
 ) *RemoveByIDUseCase {
 	return &RemoveByIDUseCase{
 		setsRepo: setsRepo,
+		hub:      hub,
 	}
 }
 
@@ -20,11 +25,26 @@ func (uc *RemoveByIDUseCase) Name() string {
 	return "Удалить подход"
 }
 
-func (uc *RemoveByIDUseCase) Execute(setID int64) error {
-	err := uc.setsRepo.Delete(setID)
+func (uc *RemoveByIDUseCase) Execute(setID, expectedVersion int64) error {
+	set, err := uc.setsRepo.Get(setID)
 	if err != nil {
 		return err
 	}
+	if err = versioning.Check(expectedVersion, set.Version); err != nil {
+		return err
+	}
+
+	err = uc.setsRepo.Delete(setID, workoutChanged(set.Exercise)...)
+	if err != nil {
+		return err
+	}
+
+	uc.hub.Publish(realtime.Event{
+		Type:       realtime.SetRemoved,
+		WorkoutID:  set.Exercise.GetWorkoutDayID(),
+		ExerciseID: set.ExerciseID,
+		SetID:      setID,
+	})
 
 	return nil
 }
@@ -2,18 +2,25 @@ package sets
 
 import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sets"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/versioning"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/realtime"
 )
 
 type UpdateByIDUseCase struct {
 	setsRepo sets.Repo
+	hub      *realtime.Hub
 }
 
 func NewUpdateByIDUseCase(
 	setsRepo sets.Repo,
+	hub *realtime.Hub,
 ) *UpdateByIDUseCase {
 	return &UpdateByIDUseCase{
 		setsRepo: setsRepo,
+		hub:      hub,
 	}
 }
 
@@ -21,10 +28,13 @@ func (uc *UpdateByIDUseCase) Name() string {
 	return "Изменить подход"
 }
 
-func (uc *UpdateByIDUseCase) Execute(setID int64, newSetDTO *dto.NewSet) error {
+func (uc *UpdateByIDUseCase) Execute(setID int64, newSetDTO *dto.NewSet) (*models.Set, error) {
 	set, err := uc.setsRepo.Get(setID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err = versioning.Check(newSetDTO.ExpectedVersion, set.Version); err != nil {
+		return set, err
 	}
 
 	set.FactReps = int(newSetDTO.NewReps)
@@ -32,10 +42,30 @@ func (uc *UpdateByIDUseCase) Execute(setID int64, newSetDTO *dto.NewSet) error {
 	set.FactMinutes = int(newSetDTO.NewMinutes)
 	set.FactMeters = int(newSetDTO.NewMeters)
 
-	err = uc.setsRepo.Save(set)
+	err = uc.setsRepo.Save(set, workoutChanged(set.Exercise)...)
 	if err != nil {
-		return err
+		return set, err
 	}
 
-	return nil
+	uc.hub.Publish(realtime.Event{
+		Type:       realtime.SetChanged,
+		WorkoutID:  set.Exercise.GetWorkoutDayID(),
+		ExerciseID: set.ExerciseID,
+		SetID:      set.ID,
+		Version:    set.Version,
+	})
+
+	return set, nil
+}
+
+// workoutChanged — событие о правке подходов упражнения: агрегаты статистики и рекорды
+// пересчитывают подписчики. Без загруженной тренировки пользователь неизвестен
+func workoutChanged(exercise *models.Exercise) []events.Event {
+	if exercise == nil || exercise.WorkoutDay == nil {
+		return nil
+	}
+	return []events.Event{events.WorkoutChanged{
+		WorkoutID: exercise.WorkoutDayID,
+		UserID:    exercise.WorkoutDay.UserID,
+	}}
 }
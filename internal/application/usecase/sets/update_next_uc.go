@@ -9,6 +9,7 @@ import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/service/docgenerator"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/realtime"
 	summarysvc "github.com/SaenkoDmitry/training-tg-bot/internal/service/summary"
 )
 
@@ -20,15 +21,18 @@ type UpdateNextUseCase struct {
 	summaryService         summarysvc.Service
 	docGeneratorService    docgenerator.Service
 	setsRepo               sets.Repo
+	hub                    *realtime.Hub
 }
 
 func NewUpdateNextUseCase(
 	setsRepo sets.Repo,
 	exercisesRepo exercises.Repo,
+	hub *realtime.Hub,
 ) *UpdateNextUseCase {
 	return &UpdateNextUseCase{
 		setsRepo:      setsRepo,
 		exercisesRepo: exercisesRepo,
+		hub:           hub,
 	}
 }
 
@@ -84,5 +88,13 @@ func (uc *UpdateNextUseCase) Execute(exerciseID int64, newSetDTO *dto.NewSet) (i
 		return 0, err
 	}
 
+	uc.hub.Publish(realtime.Event{
+		Type:       realtime.SetChanged,
+		WorkoutID:  exercise.WorkoutDayID,
+		ExerciseID: exercise.ID,
+		SetID:      nextSet.ID,
+		Version:    nextSet.Version,
+	})
+
 	return exercise.WorkoutDayID, nil
 }
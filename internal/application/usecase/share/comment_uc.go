@@ -0,0 +1,67 @@
+package share
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	commentusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/comments"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/share"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/sharenotify"
+)
+
+type CommentUC struct {
+	shareRepo     share.Repo
+	workoutsRepo  workouts.Repo
+	addCommentUC  *commentusecases.AddUseCase
+	notifications *sharenotify.Dispatcher
+}
+
+func NewCommentUC(
+	shareRepo share.Repo,
+	workoutsRepo workouts.Repo,
+	addCommentUC *commentusecases.AddUseCase,
+	notifications *sharenotify.Dispatcher,
+) *CommentUC {
+	return &CommentUC{
+		shareRepo:     shareRepo,
+		workoutsRepo:  workoutsRepo,
+		addCommentUC:  addCommentUC,
+		notifications: notifications,
+	}
+}
+
+// Execute оставляет публичный комментарий по ссылке; если владелец закрыл комментарии,
+// писать может только он сам
+func (uc *CommentUC) Execute(userID int64, token, text string) (*dto.WorkoutCommentItem, error) {
+	shareModel, err := getActiveShare(uc.shareRepo, token)
+	if err != nil {
+		return nil, err
+	}
+	workout, err := uc.workoutsRepo.Get(shareModel.WorkoutDayID)
+	if err != nil {
+		return nil, err
+	}
+	isOwner := workout.UserID == userID
+	if !shareModel.CommentsEnabled && !isOwner {
+		return nil, CommentsDisabledErr
+	}
+
+	item, err := uc.addCommentUC.Execute(userID, workout.ID, text, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isOwner {
+		activity := dto.ShareActivity{
+			Kind:      dto.ShareActivityComment,
+			OwnerID:   workout.UserID,
+			WorkoutID: workout.ID,
+			ActorName: item.AuthorName,
+			Text:      item.Text,
+		}
+		if workout.User != nil {
+			activity.OwnerChatID = workout.User.ChatID
+		}
+		uc.notifications.Notify(activity)
+	}
+	return item, nil
+}
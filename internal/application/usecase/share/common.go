@@ -0,0 +1,41 @@
+package share
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/share"
+)
+
+var (
+	NotFoundShareErr    = errors.New("share not found or expired")
+	InvalidReactionErr  = errors.New("reaction is not allowed")
+	CommentsDisabledErr = errors.New("comments are disabled by the owner")
+)
+
+func getActiveShare(shareRepo share.Repo, token string) (*models.WorkoutShare, error) {
+	shareModel, err := shareRepo.Get(token)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, NotFoundShareErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if shareModel.ExpiresAt != nil && time.Now().After(*shareModel.ExpiresAt) {
+		return nil, NotFoundShareErr
+	}
+	return &shareModel, nil
+}
+
+func actorName(user *models.User) string {
+	if user == nil {
+		return ""
+	}
+	if name := user.ShortName(); name != "" {
+		return name
+	}
+	return user.Username
+}
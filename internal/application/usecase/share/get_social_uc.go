@@ -0,0 +1,82 @@
+package share
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/comments"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/reactions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/share"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+type GetSocialUC struct {
+	shareRepo     share.Repo
+	reactionsRepo reactions.Repo
+	commentsRepo  comments.Repo
+	workoutsRepo  workouts.Repo
+}
+
+func NewGetSocialUC(shareRepo share.Repo, reactionsRepo reactions.Repo, commentsRepo comments.Repo, workoutsRepo workouts.Repo) *GetSocialUC {
+	return &GetSocialUC{
+		shareRepo:     shareRepo,
+		reactionsRepo: reactionsRepo,
+		commentsRepo:  commentsRepo,
+		workoutsRepo:  workoutsRepo,
+	}
+}
+
+// Execute собирает реакции и публичные комментарии; viewerID == 0 для анонимного просмотра
+func (uc *GetSocialUC) Execute(token string, viewerID int64) (*dto.ShareSocial, error) {
+	shareModel, err := getActiveShare(uc.shareRepo, token)
+	if err != nil {
+		return nil, err
+	}
+	return uc.build(shareModel, viewerID)
+}
+
+func (uc *GetSocialUC) build(shareModel *models.WorkoutShare, viewerID int64) (*dto.ShareSocial, error) {
+	workoutID := shareModel.WorkoutDayID
+
+	counts, err := uc.reactionsRepo.Count(workoutID)
+	if err != nil {
+		return nil, err
+	}
+	found, err := uc.commentsRepo.FindByWorkout(workoutID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.ShareSocial{
+		Reactions:       make([]dto.ReactionCount, 0, len(counts)),
+		AllowedEmoji:    models.AllowedReactions,
+		Comments:        make([]dto.WorkoutCommentItem, 0, len(found)),
+		CommentsEnabled: shareModel.CommentsEnabled,
+	}
+	for _, c := range counts {
+		result.Reactions = append(result.Reactions, dto.ReactionCount{Emoji: c.Emoji, Count: c.Count})
+	}
+	for _, c := range found {
+		item := dto.WorkoutCommentItem{
+			ID:        c.ID,
+			WorkoutID: c.WorkoutDayID,
+			AuthorID:  c.AuthorID,
+			Text:      c.Text,
+			Public:    c.Public,
+			CreatedAt: c.CreatedAt,
+		}
+		item.AuthorName = actorName(c.Author)
+		result.Comments = append(result.Comments, item)
+	}
+
+	if viewerID != 0 {
+		if result.MyReaction, err = uc.reactionsRepo.GetEmoji(workoutID, viewerID); err != nil {
+			return nil, err
+		}
+		workout, err := uc.workoutsRepo.Get(workoutID)
+		if err != nil {
+			return nil, err
+		}
+		result.IsOwner = workout.UserID == viewerID
+	}
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package share
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/reactions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/share"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/sharenotify"
+)
+
+type ReactUC struct {
+	shareRepo     share.Repo
+	reactionsRepo reactions.Repo
+	workoutsRepo  workouts.Repo
+	usersRepo     users.Repo
+	getSocialUC   *GetSocialUC
+	notifications *sharenotify.Dispatcher
+}
+
+func NewReactUC(
+	shareRepo share.Repo,
+	reactionsRepo reactions.Repo,
+	workoutsRepo workouts.Repo,
+	usersRepo users.Repo,
+	getSocialUC *GetSocialUC,
+	notifications *sharenotify.Dispatcher,
+) *ReactUC {
+	return &ReactUC{
+		shareRepo:     shareRepo,
+		reactionsRepo: reactionsRepo,
+		workoutsRepo:  workoutsRepo,
+		usersRepo:     usersRepo,
+		getSocialUC:   getSocialUC,
+		notifications: notifications,
+	}
+}
+
+// Execute ставит или меняет реакцию; владельца уведомляем только о новой реакции,
+// чтобы перебор эмодзи не превращался в поток уведомлений
+func (uc *ReactUC) Execute(userID int64, token, emoji string) (*dto.ShareSocial, error) {
+	if !models.IsValidReaction(emoji) {
+		return nil, InvalidReactionErr
+	}
+	shareModel, err := getActiveShare(uc.shareRepo, token)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := uc.reactionsRepo.Set(&models.WorkoutReaction{
+		WorkoutDayID: shareModel.WorkoutDayID,
+		UserID:       userID,
+		Emoji:        emoji,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if created {
+		workout, err := uc.workoutsRepo.Get(shareModel.WorkoutDayID)
+		if err != nil {
+			return nil, err
+		}
+		if workout.UserID != userID {
+			actor, err := uc.usersRepo.GetByID(userID)
+			if err != nil {
+				return nil, err
+			}
+			activity := dto.ShareActivity{
+				Kind:      dto.ShareActivityReaction,
+				OwnerID:   workout.UserID,
+				WorkoutID: workout.ID,
+				ActorName: actorName(actor),
+				Emoji:     emoji,
+			}
+			if workout.User != nil {
+				activity.OwnerChatID = workout.User.ChatID
+			}
+			uc.notifications.Notify(activity)
+		}
+	}
+
+	return uc.getSocialUC.build(shareModel, userID)
+}
@@ -0,0 +1,32 @@
+package share
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/reactions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/share"
+)
+
+type RemoveReactionUC struct {
+	shareRepo     share.Repo
+	reactionsRepo reactions.Repo
+	getSocialUC   *GetSocialUC
+}
+
+func NewRemoveReactionUC(shareRepo share.Repo, reactionsRepo reactions.Repo, getSocialUC *GetSocialUC) *RemoveReactionUC {
+	return &RemoveReactionUC{
+		shareRepo:     shareRepo,
+		reactionsRepo: reactionsRepo,
+		getSocialUC:   getSocialUC,
+	}
+}
+
+func (uc *RemoveReactionUC) Execute(userID int64, token string) (*dto.ShareSocial, error) {
+	shareModel, err := getActiveShare(uc.shareRepo, token)
+	if err != nil {
+		return nil, err
+	}
+	if err = uc.reactionsRepo.Delete(shareModel.WorkoutDayID, userID); err != nil {
+		return nil, err
+	}
+	return uc.getSocialUC.build(shareModel, userID)
+}
@@ -0,0 +1,18 @@
+package share
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/share"
+)
+
+type SetCommentsEnabledUC struct {
+	shareRepo share.Repo
+}
+
+func NewSetCommentsEnabledUC(shareRepo share.Repo) *SetCommentsEnabledUC {
+	return &SetCommentsEnabledUC{shareRepo: shareRepo}
+}
+
+// Execute — модерация владельцем: закрыть или снова открыть комментарии по ссылке
+func (uc *SetCommentsEnabledUC) Execute(workoutID int64, enabled bool) error {
+	return uc.shareRepo.SetCommentsEnabled(workoutID, enabled)
+}
@@ -0,0 +1,25 @@
+package social
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/follows"
+)
+
+type AcceptFollowerUseCase struct {
+	followsRepo follows.Repo
+}
+
+func NewAcceptFollowerUseCase(followsRepo follows.Repo) *AcceptFollowerUseCase {
+	return &AcceptFollowerUseCase{
+		followsRepo: followsRepo,
+	}
+}
+
+func (uc *AcceptFollowerUseCase) Name() string {
+	return "Одобрить подписчика"
+}
+
+func (uc *AcceptFollowerUseCase) Execute(userID, followerID int64) error {
+	return uc.followsRepo.Accept(followerID, userID, time.Now())
+}
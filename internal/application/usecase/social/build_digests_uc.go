@@ -0,0 +1,86 @@
+package social
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/feed"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+const (
+	digestBatchSize = 100
+	digestMaxItems  = 10
+)
+
+type BuildDigestsUseCase struct {
+	usersRepo users.Repo
+	feedRepo  feed.Repo
+}
+
+func NewBuildDigestsUseCase(usersRepo users.Repo, feedRepo feed.Repo) *BuildDigestsUseCase {
+	return &BuildDigestsUseCase{
+		usersRepo: usersRepo,
+		feedRepo:  feedRepo,
+	}
+}
+
+func (uc *BuildDigestsUseCase) Name() string {
+	return "Собрать сводки активности подписок"
+}
+
+// Execute собирает сводки для тех, кому они не уходили дольше period, и сразу отмечает отправку:
+// пустая сводка тоже сдвигает окно, чтобы не перебирать одних и тех же пользователей каждый тик
+func (uc *BuildDigestsUseCase) Execute(now time.Time, period time.Duration) ([]dto.FeedDigest, error) {
+	result := make([]dto.FeedDigest, 0)
+	for {
+		recipients, err := uc.usersRepo.FindDigestRecipients(now.Add(-period), digestBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range recipients {
+			digest, err := uc.build(user, now, period)
+			if err != nil {
+				return nil, err
+			}
+			if digest != nil {
+				result = append(result, *digest)
+			}
+		}
+
+		if len(recipients) < digestBatchSize {
+			return result, nil
+		}
+	}
+}
+
+func (uc *BuildDigestsUseCase) build(user models.User, now time.Time, period time.Duration) (*dto.FeedDigest, error) {
+	since := now.Add(-period)
+	if user.FeedDigestSentAt != nil && user.FeedDigestSentAt.After(since) {
+		since = *user.FeedDigestSentAt
+	}
+
+	found, total, err := uc.feedRepo.Find(feed.Filter{ViewerID: user.ID, Since: &since}, 0, digestMaxItems)
+	if err != nil {
+		return nil, err
+	}
+	if err = uc.usersRepo.MarkDigestSent(user.ID, now); err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, nil
+	}
+
+	items, err := mapFeedItems(uc.usersRepo, found)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.FeedDigest{
+		UserID: user.ID,
+		ChatID: user.ChatID,
+		Items:  items,
+		Total:  total,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package social
+
+import (
+	"errors"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/feed"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+var (
+	SelfFollowErr        = errors.New("user can not follow himself")
+	InvalidVisibilityErr = errors.New("invalid visibility")
+)
+
+func mapSocialUser(user *models.User) dto.SocialUser {
+	if user == nil {
+		return dto.SocialUser{}
+	}
+	name := user.ShortName()
+	if name == "" {
+		name = user.Username
+	}
+	return dto.SocialUser{
+		ID:   user.ID,
+		Name: name,
+		Icon: user.Icon,
+	}
+}
+
+// mapFeedItems подставляет авторов; usersRepo кэшируется в пределах одной страницы
+func mapFeedItems(usersRepo users.Repo, items []feed.Item) ([]dto.FeedItem, error) {
+	authors := make(map[int64]dto.SocialUser)
+	result := make([]dto.FeedItem, 0, len(items))
+	for _, item := range items {
+		author, ok := authors[item.UserID]
+		if !ok {
+			user, err := usersRepo.GetByID(item.UserID)
+			if err != nil {
+				return nil, err
+			}
+			author = mapSocialUser(user)
+			authors[item.UserID] = author
+		}
+		result = append(result, dto.FeedItem{
+			Kind:         item.Kind,
+			User:         author,
+			WorkoutID:    item.WorkoutID,
+			DayTypeName:  item.DayTypeName,
+			StartedAt:    item.StartedAt,
+			EndedAt:      item.EndedAt,
+			ExerciseName: item.ExerciseName,
+			Weight:       item.Weight,
+			PrevWeight:   item.PrevWeight,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,46 @@
+package social
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/follows"
+)
+
+type FindFollowersUseCase struct {
+	followsRepo follows.Repo
+}
+
+func NewFindFollowersUseCase(followsRepo follows.Repo) *FindFollowersUseCase {
+	return &FindFollowersUseCase{
+		followsRepo: followsRepo,
+	}
+}
+
+func (uc *FindFollowersUseCase) Name() string {
+	return "Показать подписчиков"
+}
+
+// Execute: pending == true — заявки, ожидающие одобрения
+func (uc *FindFollowersUseCase) Execute(userID int64, pending bool, offset, limit int) (*dto.FollowList, error) {
+	status := models.FollowAccepted
+	if pending {
+		status = models.FollowPending
+	}
+	found, total, err := uc.followsRepo.FindFollowers(userID, status, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.FollowList{
+		Items:      make([]dto.FollowItem, 0, len(found)),
+		Pagination: dto.Pagination{Offset: offset, Limit: limit, Total: int(total)},
+	}
+	for _, f := range found {
+		result.Items = append(result.Items, dto.FollowItem{
+			User:      mapSocialUser(f.Follower),
+			Status:    f.Status,
+			CreatedAt: f.CreatedAt,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,40 @@
+package social
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/follows"
+)
+
+type FindFollowingUseCase struct {
+	followsRepo follows.Repo
+}
+
+func NewFindFollowingUseCase(followsRepo follows.Repo) *FindFollowingUseCase {
+	return &FindFollowingUseCase{
+		followsRepo: followsRepo,
+	}
+}
+
+func (uc *FindFollowingUseCase) Name() string {
+	return "Показать подписки"
+}
+
+func (uc *FindFollowingUseCase) Execute(userID int64, offset, limit int) (*dto.FollowList, error) {
+	found, total, err := uc.followsRepo.FindFollowing(userID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.FollowList{
+		Items:      make([]dto.FollowItem, 0, len(found)),
+		Pagination: dto.Pagination{Offset: offset, Limit: limit, Total: int(total)},
+	}
+	for _, f := range found {
+		result.Items = append(result.Items, dto.FollowItem{
+			User:      mapSocialUser(f.Followee),
+			Status:    f.Status,
+			CreatedAt: f.CreatedAt,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package social
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/follows"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type FollowUseCase struct {
+	followsRepo follows.Repo
+	usersRepo   users.Repo
+}
+
+func NewFollowUseCase(followsRepo follows.Repo, usersRepo users.Repo) *FollowUseCase {
+	return &FollowUseCase{
+		followsRepo: followsRepo,
+		usersRepo:   usersRepo,
+	}
+}
+
+func (uc *FollowUseCase) Name() string {
+	return "Подписаться"
+}
+
+// Execute возвращает статус подписки: на публичный профиль подписка сразу одобрена,
+// иначе ее должен одобрить владелец
+func (uc *FollowUseCase) Execute(followerID, followeeID int64) (string, error) {
+	if followerID == followeeID {
+		return "", SelfFollowErr
+	}
+	followee, err := uc.usersRepo.GetByID(followeeID)
+	if err != nil {
+		return "", err
+	}
+	if followee.IsDisabled() {
+		return "", users.NotFoundUserErr
+	}
+
+	now := time.Now()
+	follow := &models.Follow{
+		FollowerID: followerID,
+		FolloweeID: followeeID,
+		Status:     models.FollowPending,
+		CreatedAt:  now,
+	}
+	if followee.WorkoutsVisibility == models.VisibilityPublic {
+		follow.Status = models.FollowAccepted
+		follow.AcceptedAt = &now
+	}
+	if err = uc.followsRepo.Create(follow); err != nil {
+		return "", err
+	}
+
+	existing, err := uc.followsRepo.Get(followerID, followeeID)
+	if err != nil {
+		return "", err
+	}
+	return existing.Status, nil
+}
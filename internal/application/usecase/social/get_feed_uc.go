@@ -0,0 +1,38 @@
+package social
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/feed"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type GetFeedUseCase struct {
+	feedRepo  feed.Repo
+	usersRepo users.Repo
+}
+
+func NewGetFeedUseCase(feedRepo feed.Repo, usersRepo users.Repo) *GetFeedUseCase {
+	return &GetFeedUseCase{
+		feedRepo:  feedRepo,
+		usersRepo: usersRepo,
+	}
+}
+
+func (uc *GetFeedUseCase) Name() string {
+	return "Показать ленту подписок"
+}
+
+func (uc *GetFeedUseCase) Execute(userID int64, offset, limit int) (*dto.FeedResult, error) {
+	found, total, err := uc.feedRepo.Find(feed.Filter{ViewerID: userID}, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	items, err := mapFeedItems(uc.usersRepo, found)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.FeedResult{
+		Items:      items,
+		Pagination: dto.Pagination{Offset: offset, Limit: limit, Total: int(total)},
+	}, nil
+}
@@ -0,0 +1,32 @@
+package social
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type GetPrivacyUseCase struct {
+	usersRepo users.Repo
+}
+
+func NewGetPrivacyUseCase(usersRepo users.Repo) *GetPrivacyUseCase {
+	return &GetPrivacyUseCase{
+		usersRepo: usersRepo,
+	}
+}
+
+func (uc *GetPrivacyUseCase) Name() string {
+	return "Показать настройки приватности"
+}
+
+func (uc *GetPrivacyUseCase) Execute(userID int64) (*dto.PrivacySettings, error) {
+	user, err := uc.usersRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.PrivacySettings{
+		WorkoutsVisibility: user.WorkoutsVisibility,
+		RecordsVisibility:  user.RecordsVisibility,
+		FeedDigest:         user.FeedDigest,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package social
+
+import (
+	"errors"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/feed"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/follows"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+const profileActivityLimit = 10
+
+type GetProfileUseCase struct {
+	usersRepo   users.Repo
+	followsRepo follows.Repo
+	feedRepo    feed.Repo
+}
+
+func NewGetProfileUseCase(usersRepo users.Repo, followsRepo follows.Repo, feedRepo feed.Repo) *GetProfileUseCase {
+	return &GetProfileUseCase{
+		usersRepo:   usersRepo,
+		followsRepo: followsRepo,
+		feedRepo:    feedRepo,
+	}
+}
+
+func (uc *GetProfileUseCase) Name() string {
+	return "Показать профиль пользователя"
+}
+
+// Execute показывает профиль глазами viewerID: последняя активность — только то, что разрешено приватностью
+func (uc *GetProfileUseCase) Execute(viewerID, userID int64) (*dto.SocialProfile, error) {
+	user, err := uc.usersRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsDisabled() {
+		return nil, users.NotFoundUserErr
+	}
+
+	followers, following, err := uc.followsRepo.Count(userID)
+	if err != nil {
+		return nil, err
+	}
+	profile := &dto.SocialProfile{
+		User:      mapSocialUser(user),
+		Followers: followers,
+		Following: following,
+	}
+
+	if viewerID != userID {
+		follow, err := uc.followsRepo.Get(viewerID, userID)
+		switch {
+		case err == nil:
+			profile.FollowStatus = follow.Status
+		case !errors.Is(err, follows.NotFoundFollowErr):
+			return nil, err
+		}
+		back, err := uc.followsRepo.Get(userID, viewerID)
+		switch {
+		case err == nil:
+			profile.FollowsYou = back.IsAccepted()
+		case !errors.Is(err, follows.NotFoundFollowErr):
+			return nil, err
+		}
+	}
+
+	found, _, err := uc.feedRepo.Find(feed.Filter{ViewerID: viewerID, AuthorID: userID}, 0, profileActivityLimit)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Activity, err = mapFeedItems(uc.usersRepo, found); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
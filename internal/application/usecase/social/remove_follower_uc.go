@@ -0,0 +1,24 @@
+package social
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/follows"
+)
+
+type RemoveFollowerUseCase struct {
+	followsRepo follows.Repo
+}
+
+func NewRemoveFollowerUseCase(followsRepo follows.Repo) *RemoveFollowerUseCase {
+	return &RemoveFollowerUseCase{
+		followsRepo: followsRepo,
+	}
+}
+
+func (uc *RemoveFollowerUseCase) Name() string {
+	return "Удалить подписчика"
+}
+
+// Execute отклоняет заявку или удаляет уже одобренного подписчика
+func (uc *RemoveFollowerUseCase) Execute(userID, followerID int64) error {
+	return uc.followsRepo.Delete(followerID, userID)
+}
@@ -0,0 +1,23 @@
+package social
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/follows"
+)
+
+type UnfollowUseCase struct {
+	followsRepo follows.Repo
+}
+
+func NewUnfollowUseCase(followsRepo follows.Repo) *UnfollowUseCase {
+	return &UnfollowUseCase{
+		followsRepo: followsRepo,
+	}
+}
+
+func (uc *UnfollowUseCase) Name() string {
+	return "Отписаться"
+}
+
+func (uc *UnfollowUseCase) Execute(followerID, followeeID int64) error {
+	return uc.followsRepo.Delete(followerID, followeeID)
+}
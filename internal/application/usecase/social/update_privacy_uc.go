@@ -0,0 +1,28 @@
+package social
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type UpdatePrivacyUseCase struct {
+	usersRepo users.Repo
+}
+
+func NewUpdatePrivacyUseCase(usersRepo users.Repo) *UpdatePrivacyUseCase {
+	return &UpdatePrivacyUseCase{
+		usersRepo: usersRepo,
+	}
+}
+
+func (uc *UpdatePrivacyUseCase) Name() string {
+	return "Изменить настройки приватности"
+}
+
+func (uc *UpdatePrivacyUseCase) Execute(userID int64, settings dto.PrivacySettings) error {
+	if !models.IsValidVisibility(settings.WorkoutsVisibility) || !models.IsValidVisibility(settings.RecordsVisibility) {
+		return InvalidVisibilityErr
+	}
+	return uc.usersRepo.SetPrivacy(userID, settings.WorkoutsVisibility, settings.RecordsVisibility, settings.FeedDigest)
+}
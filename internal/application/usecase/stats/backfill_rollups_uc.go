@@ -0,0 +1,50 @@
+package stats
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/rollups"
+)
+
+const backfillBatchSize = 100
+
+type BackfillRollupsUseCase struct {
+	rollupsRepo rollups.Repo
+}
+
+func NewBackfillRollupsUseCase(rollupsRepo rollups.Repo) *BackfillRollupsUseCase {
+	return &BackfillRollupsUseCase{
+		rollupsRepo: rollupsRepo,
+	}
+}
+
+func (uc *BackfillRollupsUseCase) Name() string {
+	return "Пересобрать агрегаты статистики"
+}
+
+// Execute пересобирает агрегаты всех пользователей с тренировками и возвращает их число.
+// Строки каждого пользователя заменяются целиком, поэтому запуск безопасно повторять,
+// например после правок тренировок задним числом или изменения формул
+func (uc *BackfillRollupsUseCase) Execute() (int, error) {
+	var rebuilt int
+	var lastID int64
+	for {
+		userIDs, err := uc.rollupsRepo.FindUserIDs(lastID, backfillBatchSize)
+		if err != nil {
+			return rebuilt, err
+		}
+		for _, userID := range userIDs {
+			if err = uc.rollupsRepo.RecalculateUser(userID); err != nil {
+				return rebuilt, err
+			}
+			rebuilt++
+			lastID = userID
+		}
+		if len(userIDs) < backfillBatchSize {
+			return rebuilt, nil
+		}
+	}
+}
+
+// ExecuteForUser пересобирает агрегаты одного пользователя
+func (uc *BackfillRollupsUseCase) ExecuteForUser(userID int64) error {
+	return uc.rollupsRepo.RecalculateUser(userID)
+}
@@ -25,63 +25,39 @@ func (uc *GetPeriodStatsUseCase) Name() string {
 	return "Статистика за период"
 }
 
+// Execute считает итоги завершенных тренировок за скользящие 7 или 30 дней. Агрегаты здесь не подходят:
+// в них нет длительности и числа тренировок, дни в них московские, а подходы незавершенных тренировок
+// уже учтены. Итоги считает SQL по тренировкам периода, подходы читаются только для кардио
 func (uc *GetPeriodStatsUseCase) Execute(chatID int64, period string) (*dto.PeriodStats, error) {
 	user, err := uc.usersRepo.GetByChatID(chatID)
 	if err != nil {
 		return nil, err
 	}
 
-	workoutObjs, err := uc.workoutsRepo.FindAll(user.ID)
-	if err != nil {
-		return nil, err
+	var since *time.Time
+	switch period {
+	case week:
+		t := time.Now().Add(-7 * 24 * time.Hour)
+		since = &t
+	case month:
+		t := time.Now().Add(-30 * 24 * time.Hour)
+		since = &t
 	}
 
-	completedWorkouts := 0
-	sumTime := time.Duration(0)
-	cardioTime := 0
-
-	for _, w := range workoutObjs {
-		if !w.Completed {
-			continue
-		}
-		switch period {
-		case week:
-			if time.Since(w.StartedAt).Hours() > 7*24 {
-				continue
-			}
-		case month:
-			if time.Since(w.StartedAt).Hours() > 30*24 {
-				continue
-			}
-		default:
-		}
-
-		completedWorkouts++
-		sumTime += w.EndedAt.Sub(*&w.StartedAt)
-		for _, e := range w.Exercises {
-			if len(e.Sets) == 0 {
-				continue
-			}
-			for _, s := range e.Sets {
-				if !s.Completed {
-					continue
-				}
-				if s.GetRealMinutes() > 0 {
-					cardioTime += s.GetRealMinutes()
-				}
-			}
-		}
+	totals, err := uc.workoutsRepo.SumCompleted(user.ID, since)
+	if err != nil {
+		return nil, err
 	}
 
 	avgTime := time.Duration(0)
-	if completedWorkouts != 0 {
-		avgTime = sumTime / time.Duration(completedWorkouts)
+	if totals.Workouts != 0 {
+		avgTime = totals.Duration / time.Duration(totals.Workouts)
 	}
 
 	return &dto.PeriodStats{
-		CompletedWorkouts: completedWorkouts,
-		CardioTime:        cardioTime,
-		SumTime:           sumTime,
+		CompletedWorkouts: totals.Workouts,
+		CardioTime:        totals.CardioMinutes,
+		SumTime:           totals.Duration,
 		AvgTime:           avgTime,
 		IsWeek:            strings.EqualFold(period, week),
 		IsMonth:           strings.EqualFold(period, month),
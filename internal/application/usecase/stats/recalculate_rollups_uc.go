@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/rollups"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+// RecalculateRollupsUseCase обновляет дневные и недельные агрегаты статистики после
+// выполнения подхода, завершения тренировки, любой правки уже записанных подходов и слияния аккаунтов
+type RecalculateRollupsUseCase struct {
+	workoutsRepo workouts.Repo
+	rollupsRepo  rollups.Repo
+}
+
+func NewRecalculateRollupsUseCase(workoutsRepo workouts.Repo, rollupsRepo rollups.Repo) *RecalculateRollupsUseCase {
+	return &RecalculateRollupsUseCase{
+		workoutsRepo: workoutsRepo,
+		rollupsRepo:  rollupsRepo,
+	}
+}
+
+func (uc *RecalculateRollupsUseCase) Name() string {
+	return "Пересчитать агрегаты статистики"
+}
+
+// Execute пересчитывает день тренировки и его неделю. Удаленную тренировку пропускаем:
+// ее день пересчитывает DeleteUseCase
+func (uc *RecalculateRollupsUseCase) Execute(workoutID int64) error {
+	workoutDay, err := uc.workoutsRepo.Get(workoutID)
+	if err != nil {
+		return err
+	}
+	if workoutDay.ID == 0 {
+		return nil
+	}
+	return uc.rollupsRepo.RecalculateDay(workoutDay.UserID, workoutDay.StartedAt)
+}
+
+// Handle — подписчик шины событий; пересчет идет из подходов целиком, поэтому повторная доставка безопасна
+func (uc *RecalculateRollupsUseCase) Handle(event events.Event) error {
+	switch e := event.(type) {
+	case events.SetCompleted:
+		return uc.Execute(e.WorkoutID)
+	case events.WorkoutFinished:
+		return uc.Execute(e.WorkoutID)
+	case events.WorkoutChanged:
+		// перенесенная тренировка оставила подходы в агрегатах прежнего дня
+		if e.PrevStartedAt != nil {
+			if err := uc.rollupsRepo.RecalculateDay(e.UserID, *e.PrevStartedAt); err != nil {
+				return err
+			}
+		}
+		return uc.Execute(e.WorkoutID)
+	case events.UsersMerged:
+		// агрегаты влитого аккаунта удалены вместе с ним, а его тренировки теперь у основного
+		return uc.rollupsRepo.RecalculateUser(e.UserID)
+	}
+	return nil
+}
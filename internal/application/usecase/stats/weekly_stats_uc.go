@@ -0,0 +1,111 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercisegrouptypes"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/rollups"
+)
+
+// defaultWeeks — сколько последних недель отдаем, если начало периода не задано
+const defaultWeeks = 12
+
+// GetWeeklyStatsUseCase отдает недельные итоги по группам и упражнениям из агрегатов,
+// поэтому стоимость запроса зависит от числа недель, а не подходов
+type GetWeeklyStatsUseCase struct {
+	rollupsRepo            rollups.Repo
+	exerciseGroupTypesRepo exercisegrouptypes.Repo
+}
+
+func NewGetWeeklyStatsUseCase(rollupsRepo rollups.Repo, exerciseGroupTypesRepo exercisegrouptypes.Repo) *GetWeeklyStatsUseCase {
+	return &GetWeeklyStatsUseCase{
+		rollupsRepo:            rollupsRepo,
+		exerciseGroupTypesRepo: exerciseGroupTypesRepo,
+	}
+}
+
+func (uc *GetWeeklyStatsUseCase) Name() string {
+	return "Статистика по неделям"
+}
+
+// Execute возвращает недели, начавшиеся в [from, to); без from — последние defaultWeeks недель
+func (uc *GetWeeklyStatsUseCase) Execute(userID int64, from, to *time.Time) (*dto.WeeklyStats, error) {
+	if from == nil {
+		t := rollups.WeekStart(rollups.LocalDay(time.Now())).AddDate(0, 0, -7*(defaultWeeks-1))
+		from = &t
+	}
+	rows, err := uc.rollupsRepo.FindWeekly(userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := uc.exerciseGroupTypesRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	groupNames := make(map[string]string, len(groups))
+	for _, g := range groups {
+		groupNames[g.Code] = g.Name
+	}
+
+	return buildWeeklyStats(rows, groupNames), nil
+}
+
+// buildWeeklyStats ожидает строки, упорядоченные по неделе, как их отдает rollups.Repo
+func buildWeeklyStats(rows []models.StatsRollup, groupNames map[string]string) *dto.WeeklyStats {
+	result := &dto.WeeklyStats{Weeks: make([]*dto.WeekStats, 0)}
+
+	var current *dto.WeekStats
+	groupsByCode := make(map[string]*dto.GroupWeekStats)
+	for _, r := range rows {
+		weekStart := r.Period.Format(time.DateOnly)
+		if current == nil || current.Week != weekStart {
+			current = &dto.WeekStats{
+				Week:      weekStart,
+				Groups:    make([]*dto.GroupWeekStats, 0),
+				Exercises: make([]*dto.ExerciseWeekStats, 0),
+			}
+			result.Weeks = append(result.Weeks, current)
+			clear(groupsByCode)
+		}
+
+		group, ok := groupsByCode[r.GroupCode]
+		if !ok {
+			group = &dto.GroupWeekStats{Code: r.GroupCode, Name: groupNames[r.GroupCode]}
+			groupsByCode[r.GroupCode] = group
+			current.Groups = append(current.Groups, group)
+		}
+		group.Sets += r.Sets
+		group.Reps += r.Reps
+		group.Tonnage += r.Tonnage
+		group.Minutes += r.Minutes
+		group.Meters += r.Meters
+
+		exercise := &dto.ExerciseWeekStats{
+			ExerciseTypeID: r.ExerciseTypeID,
+			GroupCode:      r.GroupCode,
+			Workouts:       r.Workouts,
+			Sets:           r.Sets,
+			Reps:           r.Reps,
+			Tonnage:        r.Tonnage,
+			Minutes:        r.Minutes,
+			Meters:         r.Meters,
+			MaxWeight:      r.MaxWeight,
+			BestE1RM:       r.BestE1RM,
+		}
+		if r.ExerciseType != nil {
+			exercise.Name = r.ExerciseType.Name
+		}
+		current.Exercises = append(current.Exercises, exercise)
+	}
+
+	for _, w := range result.Weeks {
+		sort.Slice(w.Groups, func(i, j int) bool {
+			return w.Groups[i].Code < w.Groups[j].Code
+		})
+	}
+	return result
+}
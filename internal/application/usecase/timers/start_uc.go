@@ -2,11 +2,12 @@ package timers
 
 import (
 	"errors"
-	"fmt"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercises"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/metrics"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/service/timer"
+	"log/slog"
 	"time"
 )
 
@@ -40,15 +41,18 @@ func (uc *StartUseCase) Execute(chatID, exerciseID int64, seconds int) (*dto.Sta
 	remainingCh := make(chan int)
 
 	go func() {
-
+		endsAt := time.Now().Add(time.Duration(seconds) * time.Second)
 		for remaining := seconds; remaining > 0; remaining-- {
 			time.Sleep(1 * time.Second)
 			if !uc.timerStore.HasTimer(chatID, newTimerID) {
-				fmt.Println("stopped timer by user:", newTimerID)
-				break
+				slog.Debug("timer stopped by user", "timer_id", newTimerID)
+				close(remainingCh)
+				return
 			}
 			remainingCh <- remaining
 		}
+		// отсчет идет секундными шагами вместе с правкой сообщения, поэтому копит опоздание
+		metrics.TimerLag.WithLabelValues("telegram").Observe(time.Since(endsAt).Seconds())
 		close(remainingCh)
 	}()
 
@@ -62,7 +66,7 @@ func (uc *StartUseCase) Execute(chatID, exerciseID int64, seconds int) (*dto.Sta
 			return !uc.timerStore.HasTimer(chatID, newTimerID)
 		},
 		StopTimer: func(chatID int64, newTimerID string) {
-			fmt.Println("stopped timer by ending period:", newTimerID)
+			slog.Debug("timer stopped by ending period", "timer_id", newTimerID)
 			uc.timerStore.StopTimer(chatID, newTimerID)
 		},
 	}, nil
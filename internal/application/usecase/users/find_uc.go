@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/programs"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/rbac"
 )
 
 type FindUseCase struct {
@@ -31,7 +32,7 @@ func (uc *FindUseCase) Execute(chatID int64, offset int) ([]users.UserWithCount,
 	if err != nil {
 		return nil, err
 	}
-	if !user.IsAdmin() {
+	if !rbac.Allowed(user, rbac.ViewUsers) {
 		return nil, errors.New("not allowed")
 	}
 	usersWithCounts, err := uc.usersRepo.FindTopN(offset, pageLimit)
@@ -0,0 +1,26 @@
+package users
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/identities"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type GetLinkedProvidersUseCase struct {
+	usersRepo      users.Repo
+	identitiesRepo identities.Repo
+}
+
+func NewGetLinkedProvidersUseCase(usersRepo users.Repo, identitiesRepo identities.Repo) *GetLinkedProvidersUseCase {
+	return &GetLinkedProvidersUseCase{
+		usersRepo:      usersRepo,
+		identitiesRepo: identitiesRepo,
+	}
+}
+
+func (uc *GetLinkedProvidersUseCase) Name() string {
+	return "Показать привязанные провайдеры входа"
+}
+
+func (uc *GetLinkedProvidersUseCase) Execute(userID int64) ([]string, error) {
+	return linkedProviders(uc.usersRepo, uc.identitiesRepo, userID)
+}
@@ -0,0 +1,59 @@
+package users
+
+import (
+	"errors"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/identities"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/oauth"
+)
+
+type GetOrCreateUserByIdentityUseCase struct {
+	usersRepo      users.Repo
+	identitiesRepo identities.Repo
+}
+
+func NewGetOrCreateUserByIdentityUseCase(usersRepo users.Repo, identitiesRepo identities.Repo) *GetOrCreateUserByIdentityUseCase {
+	return &GetOrCreateUserByIdentityUseCase{
+		usersRepo:      usersRepo,
+		identitiesRepo: identitiesRepo,
+	}
+}
+
+func (uc *GetOrCreateUserByIdentityUseCase) Name() string {
+	return "Создать аккаунт внешнего провайдера в системе или найти существующий"
+}
+
+func (uc *GetOrCreateUserByIdentityUseCase) Execute(identity *oauth.Identity) (*models.User, error) {
+	existing, err := uc.identitiesRepo.GetByProviderSubject(identity.Provider, identity.Subject)
+	if err == nil {
+		return uc.usersRepo.GetByID(existing.UserID)
+	}
+	if !errors.Is(err, identities.NotFoundIdentityErr) {
+		return nil, err
+	}
+
+	// по email аккаунты не склеиваем: это делается только явной привязкой из профиля
+	user := &models.User{
+		FirstName: identity.FirstName,
+		LastName:  identity.LastName,
+		Email:     identity.Email,
+		CreatedAt: time.Now(),
+	}
+	if err = uc.usersRepo.CreateWithIdentity(user, newIdentity(identity)); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func newIdentity(identity *oauth.Identity) *models.UserIdentity {
+	return &models.UserIdentity{
+		Provider:  identity.Provider,
+		Subject:   identity.Subject,
+		Email:     identity.Email,
+		Login:     identity.Login,
+		CreatedAt: time.Now(),
+	}
+}
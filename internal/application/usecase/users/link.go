@@ -0,0 +1,78 @@
+package users
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/identities"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+var (
+	ProviderAlreadyLinkedErr = errors.New("another account of this provider is already linked")
+	AccountsConflictErr      = errors.New("accounts are linked to different identities and cannot be merged")
+	LastProviderErr          = errors.New("cannot unlink the only login provider")
+	UnknownProviderErr       = errors.New("unknown provider")
+)
+
+// linkOrMerge привязывает найденного по провайдеру пользователя other к current:
+// если other не найден — привязка делается через link, иначе other вливается в current
+func linkOrMerge(repo users.Repo, identitiesRepo identities.Repo, current, other *models.User, conflicts func(current, other *models.User) bool, link func() error) (*dto.LinkAccountResult, error) {
+	result := &dto.LinkAccountResult{UserID: current.ID}
+
+	switch {
+	case other == nil:
+		if err := link(); err != nil {
+			return nil, err
+		}
+	case other.ID == current.ID:
+		// уже привязан
+	default:
+		if conflicts(current, other) {
+			return nil, AccountsConflictErr
+		}
+		// агрегаты, рекорды и значки основного аккаунта пересчитают подписчики события
+		if err := repo.Merge(other.ID, current.ID, events.UsersMerged{UserID: current.ID, SourceID: other.ID}); err != nil {
+			return nil, err
+		}
+		result.Merged = true
+		result.MergedUserID = other.ID
+	}
+
+	providers, err := linkedProviders(repo, identitiesRepo, current.ID)
+	if err != nil {
+		return nil, err
+	}
+	result.Providers = providers
+	return result, nil
+}
+
+// linkedProviders — встроенные провайдеры (Telegram, Яндекс, email) и привязанные OIDC провайдеры
+func linkedProviders(repo users.Repo, identitiesRepo identities.Repo, userID int64) ([]string, error) {
+	user, err := repo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	userIdentities, err := identitiesRepo.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := user.LinkedProviders()
+	hasEmail, err := repo.HasEmailCredential(userID)
+	if err != nil {
+		return nil, err
+	}
+	if hasEmail {
+		providers = append(providers, models.ProviderEmail)
+	}
+	for _, identity := range userIdentities {
+		if !slices.Contains(providers, identity.Provider) {
+			providers = append(providers, identity.Provider)
+		}
+	}
+	return providers, nil
+}
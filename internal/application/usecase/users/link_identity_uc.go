@@ -0,0 +1,67 @@
+package users
+
+import (
+	"errors"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/identities"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/oauth"
+)
+
+type LinkIdentityUseCase struct {
+	usersRepo      users.Repo
+	identitiesRepo identities.Repo
+}
+
+func NewLinkIdentityUseCase(usersRepo users.Repo, identitiesRepo identities.Repo) *LinkIdentityUseCase {
+	return &LinkIdentityUseCase{
+		usersRepo:      usersRepo,
+		identitiesRepo: identitiesRepo,
+	}
+}
+
+func (uc *LinkIdentityUseCase) Name() string {
+	return "Привязать аккаунт внешнего провайдера к пользователю"
+}
+
+func (uc *LinkIdentityUseCase) Execute(userID int64, identity *oauth.Identity) (*dto.LinkAccountResult, error) {
+	current, err := uc.usersRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentIdentities, err := uc.identitiesRepo.FindByUser(current.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range currentIdentities {
+		if i.Provider == identity.Provider && i.Subject != identity.Subject {
+			return nil, ProviderAlreadyLinkedErr
+		}
+	}
+
+	var other *models.User
+	existing, err := uc.identitiesRepo.GetByProviderSubject(identity.Provider, identity.Subject)
+	switch {
+	case err == nil:
+		if other, err = uc.usersRepo.GetByID(existing.UserID); err != nil {
+			return nil, err
+		}
+	case !errors.Is(err, identities.NotFoundIdentityErr):
+		return nil, err
+	}
+
+	return linkOrMerge(uc.usersRepo, uc.identitiesRepo, current, other,
+		func(current, other *models.User) bool {
+			return current.HasTelegram() && other.HasTelegram() && current.ChatID != other.ChatID ||
+				current.HasYandex() && other.HasYandex() && current.YandexID != other.YandexID
+		},
+		func() error {
+			linked := newIdentity(identity)
+			linked.UserID = current.ID
+			return uc.identitiesRepo.Create(linked)
+		},
+	)
+}
@@ -0,0 +1,50 @@
+package users
+
+import (
+	"errors"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/identities"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type LinkTelegramUseCase struct {
+	usersRepo      users.Repo
+	identitiesRepo identities.Repo
+}
+
+func NewLinkTelegramUseCase(usersRepo users.Repo, identitiesRepo identities.Repo) *LinkTelegramUseCase {
+	return &LinkTelegramUseCase{
+		usersRepo:      usersRepo,
+		identitiesRepo: identitiesRepo,
+	}
+}
+
+func (uc *LinkTelegramUseCase) Name() string {
+	return "Привязать телеграм аккаунт к пользователю"
+}
+
+func (uc *LinkTelegramUseCase) Execute(userID int64, tgUser dto.TelegramUser) (*dto.LinkAccountResult, error) {
+	current, err := uc.usersRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if current.HasTelegram() && current.ChatID != tgUser.ID {
+		return nil, ProviderAlreadyLinkedErr
+	}
+
+	other, err := uc.usersRepo.GetByChatID(tgUser.ID)
+	if err != nil && !errors.Is(err, users.NotFoundUserErr) {
+		return nil, err
+	}
+
+	return linkOrMerge(uc.usersRepo, uc.identitiesRepo, current, other,
+		func(current, other *models.User) bool {
+			return current.HasYandex() && other.HasYandex() && current.YandexID != other.YandexID
+		},
+		func() error {
+			return uc.usersRepo.LinkTelegram(current.ID, tgUser)
+		},
+	)
+}
@@ -0,0 +1,50 @@
+package users
+
+import (
+	"errors"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/identities"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type LinkYandexUseCase struct {
+	usersRepo      users.Repo
+	identitiesRepo identities.Repo
+}
+
+func NewLinkYandexUseCase(usersRepo users.Repo, identitiesRepo identities.Repo) *LinkYandexUseCase {
+	return &LinkYandexUseCase{
+		usersRepo:      usersRepo,
+		identitiesRepo: identitiesRepo,
+	}
+}
+
+func (uc *LinkYandexUseCase) Name() string {
+	return "Привязать яндекс аккаунт к пользователю"
+}
+
+func (uc *LinkYandexUseCase) Execute(userID int64, profile *dto.YandexProfile) (*dto.LinkAccountResult, error) {
+	current, err := uc.usersRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if current.HasYandex() && current.YandexID != profile.ID {
+		return nil, ProviderAlreadyLinkedErr
+	}
+
+	other, err := uc.usersRepo.GetByYandexID(profile.ID)
+	if err != nil && !errors.Is(err, users.NotFoundUserErr) {
+		return nil, err
+	}
+
+	return linkOrMerge(uc.usersRepo, uc.identitiesRepo, current, other,
+		func(current, other *models.User) bool {
+			return current.HasTelegram() && other.HasTelegram() && current.ChatID != other.ChatID
+		},
+		func() error {
+			return uc.usersRepo.LinkYandex(current.ID, profile)
+		},
+	)
+}
@@ -0,0 +1,58 @@
+package users
+
+import (
+	"slices"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/identities"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type UnlinkUseCase struct {
+	usersRepo      users.Repo
+	identitiesRepo identities.Repo
+}
+
+func NewUnlinkUseCase(usersRepo users.Repo, identitiesRepo identities.Repo) *UnlinkUseCase {
+	return &UnlinkUseCase{
+		usersRepo:      usersRepo,
+		identitiesRepo: identitiesRepo,
+	}
+}
+
+func (uc *UnlinkUseCase) Name() string {
+	return "Отвязать провайдера входа от пользователя"
+}
+
+func (uc *UnlinkUseCase) Execute(userID int64, provider string) ([]string, error) {
+	providers, err := linkedProviders(uc.usersRepo, uc.identitiesRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(providers, provider) {
+		if provider != models.ProviderTelegram && provider != models.ProviderYandex && provider != models.ProviderEmail {
+			return nil, UnknownProviderErr
+		}
+		return providers, nil
+	}
+	// без единого провайдера в аккаунт будет не войти
+	if len(providers) < 2 {
+		return nil, LastProviderErr
+	}
+
+	switch provider {
+	case models.ProviderTelegram:
+		err = uc.usersRepo.UnlinkTelegram(userID)
+	case models.ProviderYandex:
+		err = uc.usersRepo.UnlinkYandex(userID)
+	case models.ProviderEmail:
+		err = uc.usersRepo.UnlinkEmail(userID)
+	default:
+		err = uc.identitiesRepo.DeleteByUserProvider(userID, provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return linkedProviders(uc.usersRepo, uc.identitiesRepo, userID)
+}
@@ -0,0 +1,150 @@
+package webhooks
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/webhooks"
+)
+
+var (
+	InvalidURLErr        = errors.New("webhook url must be an absolute http(s) url")
+	InvalidEventTypesErr = errors.New("unknown or empty webhook event types")
+	InvalidSecretErr     = errors.New("webhook secret must be 16 to 128 characters")
+	TooManyWebhooksErr   = errors.New("too many webhooks")
+	NotOwnerErr          = errors.New("user is not the webhook owner")
+)
+
+const (
+	maxWebhooksPerUser   = 10
+	maxDescriptionLength = 128
+	maxURLLength         = 2048
+	minSecretLength      = 16
+	maxSecretLength      = 128
+	secretHintLength     = 4
+
+	// EventTest — тип тестового события, на него нельзя подписаться
+	EventTest = "test"
+)
+
+// EventTypes — события, на которые можно подписать вебхук
+var EventTypes = []dto.WebhookEventType{
+	{Type: string(events.TypeWorkoutFinished), Description: "Тренировка завершена"},
+	{Type: string(events.TypePersonalRecord), Description: "Новый рекорд в упражнении"},
+	{Type: string(events.TypeMeasurementCreated), Description: "Добавлено измерение тела"},
+}
+
+func validateURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || len(raw) > maxURLLength {
+		return "", InvalidURLErr
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil {
+		return "", InvalidURLErr
+	}
+	return u.String(), nil
+}
+
+// normalizeEventTypes проверяет типы и убирает повторы, сохраняя порядок каталога
+func normalizeEventTypes(types []string) (string, error) {
+	requested := make(map[string]bool, len(types))
+	for _, t := range types {
+		requested[t] = true
+	}
+
+	result := make([]string, 0, len(types))
+	for _, t := range EventTypes {
+		if requested[t.Type] {
+			result = append(result, t.Type)
+			delete(requested, t.Type)
+		}
+	}
+	if len(result) == 0 || len(requested) > 0 {
+		return "", InvalidEventTypesErr
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+func normalizeDescription(description string) string {
+	description = strings.TrimSpace(description)
+	if runes := []rune(description); len(runes) > maxDescriptionLength {
+		description = string(runes[:maxDescriptionLength])
+	}
+	return description
+}
+
+func newSecret() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+// getOwn загружает вебхук пользователя
+func getOwn(repo webhooks.Repo, userID, webhookID int64) (*models.Webhook, error) {
+	webhook, err := repo.Get(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.UserID != userID {
+		return nil, NotOwnerErr
+	}
+	return webhook, nil
+}
+
+// mapWebhook: секрет целиком показываем только сразу после создания или смены
+func mapWebhook(webhook *models.Webhook, withSecret bool) dto.Webhook {
+	var types []string
+	_ = json.Unmarshal([]byte(webhook.EventTypes), &types)
+
+	item := dto.Webhook{
+		ID:          webhook.ID,
+		URL:         webhook.URL,
+		EventTypes:  types,
+		Description: webhook.Description,
+		Active:      webhook.Active,
+		CreatedAt:   webhook.CreatedAt,
+	}
+	if len(webhook.Secret) > secretHintLength {
+		item.SecretHint = "…" + webhook.Secret[len(webhook.Secret)-secretHintLength:]
+	}
+	if withSecret {
+		item.Secret = webhook.Secret
+	}
+	return item
+}
+
+func mapDelivery(delivery *models.WebhookDelivery) dto.WebhookDelivery {
+	item := dto.WebhookDelivery{
+		ID:             delivery.ID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+		DurationMs:     delivery.DurationMs,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+		Payload:        json.RawMessage(delivery.Payload),
+	}
+	if delivery.Status == models.WebhookDeliveryPending {
+		next := delivery.NextAttemptAt
+		item.NextAttemptAt = &next
+	}
+	return item
+}
@@ -0,0 +1,64 @@
+package webhooks
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/webhooks"
+)
+
+type CreateUseCase struct {
+	webhooksRepo webhooks.Repo
+}
+
+func NewCreateUseCase(webhooksRepo webhooks.Repo) *CreateUseCase {
+	return &CreateUseCase{
+		webhooksRepo: webhooksRepo,
+	}
+}
+
+func (uc *CreateUseCase) Name() string {
+	return "Добавить вебхук"
+}
+
+// Execute регистрирует вебхук; секрет возвращается целиком только в этом ответе
+func (uc *CreateUseCase) Execute(userID int64, req dto.CreateWebhook) (*dto.Webhook, error) {
+	url, err := validateURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	eventTypes, err := normalizeEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = newSecret(); err != nil {
+			return nil, err
+		}
+	} else if len(secret) < minSecretLength || len(secret) > maxSecretLength {
+		return nil, InvalidSecretErr
+	}
+
+	count, err := uc.webhooksRepo.CountByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxWebhooksPerUser {
+		return nil, TooManyWebhooksErr
+	}
+
+	webhook := &models.Webhook{
+		UserID:      userID,
+		URL:         url,
+		Secret:      secret,
+		EventTypes:  eventTypes,
+		Description: normalizeDescription(req.Description),
+		Active:      true,
+	}
+	if err = uc.webhooksRepo.Create(webhook); err != nil {
+		return nil, err
+	}
+
+	item := mapWebhook(webhook, true)
+	return &item, nil
+}
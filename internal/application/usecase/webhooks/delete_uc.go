@@ -0,0 +1,27 @@
+package webhooks
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/webhooks"
+)
+
+type DeleteUseCase struct {
+	webhooksRepo webhooks.Repo
+}
+
+func NewDeleteUseCase(webhooksRepo webhooks.Repo) *DeleteUseCase {
+	return &DeleteUseCase{
+		webhooksRepo: webhooksRepo,
+	}
+}
+
+func (uc *DeleteUseCase) Name() string {
+	return "Удалить вебхук"
+}
+
+// Execute удаляет вебхук вместе с журналом доставок
+func (uc *DeleteUseCase) Execute(userID, webhookID int64) error {
+	if _, err := getOwn(uc.webhooksRepo, userID, webhookID); err != nil {
+		return err
+	}
+	return uc.webhooksRepo.Delete(webhookID)
+}
@@ -0,0 +1,167 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/measurements"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/webhooks"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+// EnqueueUseCase ставит доменное событие в очередь доставки всем подписанным вебхукам пользователя
+type EnqueueUseCase struct {
+	webhooksRepo     webhooks.Repo
+	workoutsRepo     workouts.Repo
+	measurementsRepo measurements.Repo
+}
+
+func NewEnqueueUseCase(webhooksRepo webhooks.Repo, workoutsRepo workouts.Repo, measurementsRepo measurements.Repo) *EnqueueUseCase {
+	return &EnqueueUseCase{
+		webhooksRepo:     webhooksRepo,
+		workoutsRepo:     workoutsRepo,
+		measurementsRepo: measurementsRepo,
+	}
+}
+
+func (uc *EnqueueUseCase) Name() string {
+	return "Поставить событие в очередь вебхуков"
+}
+
+// Subscriptions — доменные события, которые можно получить вебхуком
+func Subscriptions() []events.Type {
+	result := make([]events.Type, 0, len(EventTypes))
+	for _, t := range EventTypes {
+		result = append(result, events.Type(t.Type))
+	}
+	return result
+}
+
+// Execute возвращает число созданных доставок
+func (uc *EnqueueUseCase) Execute(event events.Event) (int, error) {
+	userID := events.UserOf(event)
+	if userID == 0 {
+		return 0, nil
+	}
+	subscribed, err := uc.webhooksRepo.FindSubscribed(userID, string(event.Type()))
+	if err != nil || len(subscribed) == 0 {
+		return 0, err
+	}
+
+	data, err := uc.buildData(event)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	payload, err := json.Marshal(dto.WebhookPayload{
+		Event:     string(event.Type()),
+		CreatedAt: now,
+		UserID:    userID,
+		Data:      data,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0, len(subscribed))
+	for _, w := range subscribed {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			WebhookID:     w.ID,
+			EventType:     string(event.Type()),
+			Payload:       string(payload),
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+	if err = uc.webhooksRepo.CreateDeliveries(deliveries); err != nil {
+		return 0, err
+	}
+	return len(deliveries), nil
+}
+
+// Handle — подписчик шины событий
+func (uc *EnqueueUseCase) Handle(event events.Event) error {
+	_, err := uc.Execute(event)
+	return err
+}
+
+// buildData дополняет событие данными, которые нужны получателю без обращения к нашему API
+func (uc *EnqueueUseCase) buildData(event events.Event) (interface{}, error) {
+	switch e := event.(type) {
+	case events.WorkoutFinished:
+		workoutDay, err := uc.workoutsRepo.Get(e.WorkoutID)
+		if err != nil {
+			return nil, err
+		}
+		return mapWorkout(workoutDay), nil
+	case events.MeasurementCreated:
+		measurement, err := uc.measurementsRepo.Get(e.MeasurementID)
+		if err != nil {
+			return nil, err
+		}
+		return mapMeasurement(measurement), nil
+	}
+	return event, nil
+}
+
+func mapWorkout(workoutDay models.WorkoutDay) dto.WebhookWorkout {
+	result := dto.WebhookWorkout{
+		WorkoutID:    workoutDay.ID,
+		StartedAt:    workoutDay.StartedAt,
+		EndedAt:      workoutDay.EndedAt,
+		AutoFinished: workoutDay.AutoFinished,
+		Exercises:    make([]dto.WebhookWorkoutExercise, 0, len(workoutDay.Exercises)),
+	}
+	if workoutDay.WorkoutDayType != nil {
+		result.DayTypeName = workoutDay.WorkoutDayType.Name
+	}
+	if workoutDay.EndedAt != nil {
+		result.DurationSeconds = int64(workoutDay.EndedAt.Sub(workoutDay.StartedAt).Seconds())
+	}
+
+	for _, e := range workoutDay.Exercises {
+		exercise := dto.WebhookWorkoutExercise{Sets: make([]dto.WebhookWorkoutSet, 0, len(e.Sets))}
+		if e.ExerciseType != nil {
+			exercise.Name = e.ExerciseType.Name
+			exercise.Units = e.ExerciseType.Units
+		}
+		for _, s := range e.Sets {
+			exercise.Sets = append(exercise.Sets, dto.WebhookWorkoutSet{
+				Reps:      s.GetRealReps(),
+				Weight:    s.GetRealWeight(),
+				Minutes:   s.GetRealMinutes(),
+				Meters:    s.GetRealMeters(),
+				Completed: s.Completed,
+				At:        s.CompletedAt,
+			})
+			if s.Completed {
+				result.Tonnage += float64(s.GetRealWeight()) * float64(s.GetRealReps())
+			}
+		}
+		result.Exercises = append(result.Exercises, exercise)
+	}
+	return result
+}
+
+func mapMeasurement(m models.Measurement) dto.WebhookMeasurement {
+	cm := func(mm int) float64 { return float64(mm) / 10 }
+	return dto.WebhookMeasurement{
+		MeasurementID: m.ID,
+		CreatedAt:     m.CreatedAt,
+		Shoulders:     cm(m.Shoulders),
+		Chest:         cm(m.Chest),
+		HandLeft:      cm(m.HandLeft),
+		HandRight:     cm(m.HandRight),
+		Waist:         cm(m.Waist),
+		Buttocks:      cm(m.Buttocks),
+		HipLeft:       cm(m.HipLeft),
+		HipRight:      cm(m.HipRight),
+		CalfLeft:      cm(m.CalfLeft),
+		CalfRight:     cm(m.CalfRight),
+		Weight:        float64(m.Weight) / 1000,
+	}
+}
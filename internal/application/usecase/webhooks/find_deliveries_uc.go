@@ -0,0 +1,40 @@
+package webhooks
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/webhooks"
+)
+
+type FindDeliveriesUseCase struct {
+	webhooksRepo webhooks.Repo
+}
+
+func NewFindDeliveriesUseCase(webhooksRepo webhooks.Repo) *FindDeliveriesUseCase {
+	return &FindDeliveriesUseCase{
+		webhooksRepo: webhooksRepo,
+	}
+}
+
+func (uc *FindDeliveriesUseCase) Name() string {
+	return "Журнал доставок вебхука"
+}
+
+// Execute — доставки вебхука, новые первыми
+func (uc *FindDeliveriesUseCase) Execute(userID, webhookID int64, offset, limit int) (*dto.WebhookDeliveries, error) {
+	if _, err := getOwn(uc.webhooksRepo, userID, webhookID); err != nil {
+		return nil, err
+	}
+
+	found, total, err := uc.webhooksRepo.FindDeliveries(webhookID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]dto.WebhookDelivery, 0, len(found))
+	for i := range found {
+		items = append(items, mapDelivery(&found[i]))
+	}
+	return &dto.WebhookDeliveries{
+		Items:      items,
+		Pagination: dto.Pagination{Offset: offset, Limit: limit, Total: int(total)},
+	}, nil
+}
@@ -0,0 +1,17 @@
+package webhooks
+
+import "github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+
+type FindEventTypesUseCase struct{}
+
+func NewFindEventTypesUseCase() *FindEventTypesUseCase {
+	return &FindEventTypesUseCase{}
+}
+
+func (uc *FindEventTypesUseCase) Name() string {
+	return "Типы событий вебхуков"
+}
+
+func (uc *FindEventTypesUseCase) Execute() []dto.WebhookEventType {
+	return EventTypes
+}
@@ -0,0 +1,33 @@
+package webhooks
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/webhooks"
+)
+
+type FindMyUseCase struct {
+	webhooksRepo webhooks.Repo
+}
+
+func NewFindMyUseCase(webhooksRepo webhooks.Repo) *FindMyUseCase {
+	return &FindMyUseCase{
+		webhooksRepo: webhooksRepo,
+	}
+}
+
+func (uc *FindMyUseCase) Name() string {
+	return "Мои вебхуки"
+}
+
+func (uc *FindMyUseCase) Execute(userID int64) ([]dto.Webhook, error) {
+	found, err := uc.webhooksRepo.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.Webhook, 0, len(found))
+	for i := range found {
+		result = append(result, mapWebhook(&found[i], false))
+	}
+	return result, nil
+}
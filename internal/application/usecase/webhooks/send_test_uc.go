@@ -0,0 +1,70 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/webhooks"
+	webhookservice "github.com/SaenkoDmitry/training-tg-bot/internal/service/webhooks"
+)
+
+type SendTestUseCase struct {
+	webhooksRepo webhooks.Repo
+	sender       *webhookservice.Sender
+}
+
+func NewSendTestUseCase(webhooksRepo webhooks.Repo, sender *webhookservice.Sender) *SendTestUseCase {
+	return &SendTestUseCase{
+		webhooksRepo: webhooksRepo,
+		sender:       sender,
+	}
+}
+
+func (uc *SendTestUseCase) Name() string {
+	return "Отправить тестовое событие вебхука"
+}
+
+// Execute сразу отправляет тестовое событие, даже выключенному вебхуку, и пишет попытку в журнал без повторов
+func (uc *SendTestUseCase) Execute(userID, webhookID int64) (*dto.WebhookDelivery, error) {
+	webhook, err := getOwn(uc.webhooksRepo, userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(dto.WebhookPayload{
+		Event:     EventTest,
+		CreatedAt: now,
+		UserID:    userID,
+		Data:      map[string]interface{}{"webhook_id": webhook.ID, "message": "Тестовое событие"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// доставку пишем до отправки, чтобы получатель увидел настоящий id; попытка назначена на будущее,
+	// поэтому диспетчер не отправит ее параллельно, а подхватит, только если процесс упадет до записи результата
+	deliveries := []models.WebhookDelivery{{
+		WebhookID:     webhook.ID,
+		EventType:     EventTest,
+		Payload:       string(payload),
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: now.Add(time.Hour),
+		CreatedAt:     now,
+	}}
+	if err = uc.webhooksRepo.CreateDeliveries(deliveries); err != nil {
+		return nil, err
+	}
+
+	delivery := &deliveries[0]
+	delivery.Webhook = webhook
+	webhookservice.Apply(delivery, uc.sender.Send(webhookservice.RequestFor(delivery)), time.Now(), true)
+	if err = uc.webhooksRepo.SaveDelivery(delivery); err != nil {
+		return nil, err
+	}
+
+	item := mapDelivery(delivery)
+	return &item, nil
+}
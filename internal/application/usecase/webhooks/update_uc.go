@@ -0,0 +1,56 @@
+package webhooks
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/webhooks"
+)
+
+type UpdateUseCase struct {
+	webhooksRepo webhooks.Repo
+}
+
+func NewUpdateUseCase(webhooksRepo webhooks.Repo) *UpdateUseCase {
+	return &UpdateUseCase{
+		webhooksRepo: webhooksRepo,
+	}
+}
+
+func (uc *UpdateUseCase) Name() string {
+	return "Изменить вебхук"
+}
+
+// Execute меняет только переданные поля; при смене секрета новый секрет возвращается в ответе
+func (uc *UpdateUseCase) Execute(userID, webhookID int64, req dto.UpdateWebhook) (*dto.Webhook, error) {
+	webhook, err := getOwn(uc.webhooksRepo, userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if webhook.URL, err = validateURL(*req.URL); err != nil {
+			return nil, err
+		}
+	}
+	if req.EventTypes != nil {
+		if webhook.EventTypes, err = normalizeEventTypes(*req.EventTypes); err != nil {
+			return nil, err
+		}
+	}
+	if req.Description != nil {
+		webhook.Description = normalizeDescription(*req.Description)
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+	if req.RotateSecret {
+		if webhook.Secret, err = newSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = uc.webhooksRepo.Save(webhook); err != nil {
+		return nil, err
+	}
+	item := mapWebhook(webhook, req.RotateSecret)
+	return &item, nil
+}
@@ -0,0 +1,99 @@
+package workouts
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/versioning"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workoutedits"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+// за один проход закрываем ограниченное число тренировок, остальные подберет следующий
+const autoFinishBatchSize = 100
+
+// AutoFinishUseCase завершает брошенные тренировки: время окончания — последний выполненный подход
+type AutoFinishUseCase struct {
+	workoutsRepo workouts.Repo
+	sessionsRepo sessions.Repo
+	editsRepo    workoutedits.Repo
+}
+
+func NewAutoFinishUseCase(workoutsRepo workouts.Repo, sessionsRepo sessions.Repo, editsRepo workoutedits.Repo) *AutoFinishUseCase {
+	return &AutoFinishUseCase{workoutsRepo: workoutsRepo, sessionsRepo: sessionsRepo, editsRepo: editsRepo}
+}
+
+func (uc *AutoFinishUseCase) Name() string {
+	return "Автозавершение брошенных тренировок"
+}
+
+func (uc *AutoFinishUseCase) Execute(inactiveFor time.Duration) ([]dto.AutoFinishedWorkout, error) {
+	abandoned, err := uc.workoutsRepo.FindAbandoned(time.Now().Add(-inactiveFor), autoFinishBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.AutoFinishedWorkout, 0, len(abandoned))
+	for _, a := range abandoned {
+		finished, finishErr := uc.finish(a)
+		if finishErr != nil {
+			// тренировку успели изменить между выборкой и сохранением, проверим в следующий раз
+			if !errors.Is(finishErr, versioning.ConflictErr) {
+				slog.Error("auto finish workout failed", "workout_id", a.WorkoutDayID, "err", finishErr)
+			}
+			continue
+		}
+		res = append(res, *finished)
+	}
+	return res, nil
+}
+
+func (uc *AutoFinishUseCase) finish(a workouts.Abandoned) (*dto.AutoFinishedWorkout, error) {
+	workoutDay, err := uc.workoutsRepo.Get(a.WorkoutDayID)
+	if err != nil {
+		return nil, err
+	}
+
+	endedAt := workoutDay.StartedAt
+	if a.LastCompletedAt != nil && a.LastCompletedAt.After(endedAt) {
+		endedAt = *a.LastCompletedAt
+	}
+
+	workoutDay.Completed = true
+	workoutDay.AutoFinished = true
+	workoutDay.EndedAt = &endedAt
+	finished := events.WorkoutFinished{WorkoutID: workoutDay.ID, UserID: workoutDay.UserID, EndedAt: endedAt, AutoFinished: true}
+	if err = uc.workoutsRepo.Save(&workoutDay, finished); err != nil {
+		return nil, err
+	}
+
+	if err = uc.sessionsRepo.UpdateIsActive(workoutDay.ID, false); err != nil {
+		return nil, err
+	}
+
+	changes := []dto.WorkoutChange{
+		{Field: "completed", Old: "false", New: "true"},
+		{Field: "ended_at", New: formatTime(&endedAt)},
+	}
+	if err = recordEdit(uc.editsRepo, workoutDay.ID, workoutDay.UserID, models.WorkoutEditAutoFinish, changes, nil); err != nil {
+		return nil, err
+	}
+
+	res := &dto.AutoFinishedWorkout{
+		WorkoutID: workoutDay.ID,
+		UserID:    workoutDay.UserID,
+		EndedAt:   endedAt,
+	}
+	if workoutDay.User != nil {
+		res.ChatID = workoutDay.User.ChatID
+	}
+	if workoutDay.WorkoutDayType != nil {
+		res.DayTypeName = workoutDay.WorkoutDayType.Name
+	}
+	return res, nil
+}
@@ -1,7 +1,6 @@
 package workouts
 
 import (
-	"fmt"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/constants"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
@@ -10,6 +9,7 @@ import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/utils"
+	"log/slog"
 	"time"
 )
 
@@ -66,7 +66,7 @@ func (uc *CreateUseCase) buildExercises(workoutID int64, dayTypeID int64, user *
 
 func (uc *CreateUseCase) createExercisesFromPresets(workoutDayID, dayTypeID, activeProgramID int64) ([]models.Exercise, error) {
 	method := "createExercisesFromPresets"
-	fmt.Printf("%s: берем настройки количества повторений и веса из preset-ов\n", method)
+	slog.Debug("берем настройки количества повторений и веса из preset-ов", "method", method)
 
 	objs := make([]models.Exercise, 0)
 	dayType, err := uc.dayTypesRepo.Get(dayTypeID)
@@ -104,7 +104,7 @@ func (uc *CreateUseCase) createExercisesFromPresets(workoutDayID, dayTypeID, act
 
 func (uc *CreateUseCase) createExercisesFromLastWorkout(workoutDayID, previousWorkoutID, activeProgramID int64) ([]models.Exercise, error) {
 	method := "createExercisesFromLastWorkout"
-	fmt.Printf("%s: берем настройки количества повторений и веса из последней тренировки: %d\n", method, previousWorkoutID)
+	slog.Debug("берем настройки количества повторений и веса из последней тренировки", "method", method, "workout_id", previousWorkoutID)
 
 	previousExercises, err := uc.exercisesRepo.FindAllByWorkoutID(previousWorkoutID)
 	if err != nil {
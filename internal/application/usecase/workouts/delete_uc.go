@@ -2,6 +2,7 @@ package workouts
 
 import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercises"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/rollups"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sets"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
 )
@@ -10,10 +11,11 @@ type DeleteUseCase struct {
 	workoutsRepo  workouts.Repo
 	setsRepo      sets.Repo
 	exercisesRepo exercises.Repo
+	rollupsRepo   rollups.Repo
 }
 
-func NewDeleteUseCase(workoutsRepo workouts.Repo, setsRepo sets.Repo, exercisesRepo exercises.Repo) *DeleteUseCase {
-	return &DeleteUseCase{workoutsRepo: workoutsRepo, setsRepo: setsRepo, exercisesRepo: exercisesRepo}
+func NewDeleteUseCase(workoutsRepo workouts.Repo, setsRepo sets.Repo, exercisesRepo exercises.Repo, rollupsRepo rollups.Repo) *DeleteUseCase {
+	return &DeleteUseCase{workoutsRepo: workoutsRepo, setsRepo: setsRepo, exercisesRepo: exercisesRepo, rollupsRepo: rollupsRepo}
 }
 
 func (uc *DeleteUseCase) Name() string {
@@ -42,5 +44,7 @@ func (uc *DeleteUseCase) Execute(workoutID int64) error {
 	if err != nil {
 		return err
 	}
-	return nil
+
+	// подходы удаленной тренировки больше не должны попадать в статистику
+	return uc.rollupsRepo.RecalculateDay(workoutDay.UserID, workoutDay.StartedAt)
 }
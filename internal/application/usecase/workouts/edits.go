@@ -0,0 +1,137 @@
+package workouts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workoutedits"
+)
+
+var (
+	InvalidTimesErr = errors.New("invalid workout times")
+	NotCompletedErr = errors.New("workout is not completed")
+)
+
+// тренировка длиннее суток почти наверняка забытое завершение, такие времена не принимаем
+const maxWorkoutDuration = 24 * time.Hour
+
+// ValidateTimes проверяет времена тренировки, которые задал пользователь: начало не в будущем,
+// окончание после начала, не в будущем и не дальше суток от начала
+func ValidateTimes(startedAt time.Time, endedAt *time.Time) error {
+	now := time.Now()
+	if startedAt.IsZero() || startedAt.After(now) {
+		return InvalidTimesErr
+	}
+	if endedAt == nil {
+		return nil
+	}
+	if !endedAt.After(startedAt) || endedAt.After(now) || endedAt.Sub(startedAt) > maxWorkoutDuration {
+		return InvalidTimesErr
+	}
+	return nil
+}
+
+type setSnapshot struct {
+	SetID       int64   `json:"set_id"`
+	Exercise    string  `json:"exercise"`
+	Index       int     `json:"index"`
+	FactReps    int     `json:"fact_reps"`
+	FactWeight  float32 `json:"fact_weight"`
+	FactMinutes int     `json:"fact_minutes"`
+	FactMeters  int     `json:"fact_meters"`
+	Completed   bool    `json:"completed"`
+}
+
+func takeSnapshot(w models.WorkoutDay) []setSnapshot {
+	res := make([]setSnapshot, 0)
+	for _, ex := range w.Exercises {
+		for _, s := range ex.Sets {
+			res = append(res, setSnapshot{
+				SetID:       s.ID,
+				Exercise:    ex.ExerciseType.Name,
+				Index:       s.Index,
+				FactReps:    s.FactReps,
+				FactWeight:  s.FactWeight,
+				FactMinutes: s.FactMinutes,
+				FactMeters:  s.FactMeters,
+				Completed:   s.Completed,
+			})
+		}
+	}
+	return res
+}
+
+// diffSnapshots сравнивает подходы до переоткрытия и после повторного завершения
+func diffSnapshots(before, after []setSnapshot) []dto.WorkoutChange {
+	changes := make([]dto.WorkoutChange, 0)
+
+	afterByID := make(map[int64]setSnapshot, len(after))
+	for _, s := range after {
+		afterByID[s.SetID] = s
+	}
+
+	for _, old := range before {
+		cur, ok := afterByID[old.SetID]
+		if !ok {
+			changes = append(changes, dto.WorkoutChange{Field: "set", SetID: old.SetID, Exercise: old.Exercise, Old: "exists", New: "removed"})
+			continue
+		}
+		delete(afterByID, old.SetID)
+
+		add := func(field string, oldValue, newValue any) {
+			o, n := fmt.Sprint(oldValue), fmt.Sprint(newValue)
+			if o != n {
+				changes = append(changes, dto.WorkoutChange{Field: field, SetID: old.SetID, Exercise: old.Exercise, Old: o, New: n})
+			}
+		}
+		add("fact_reps", old.FactReps, cur.FactReps)
+		add("fact_weight", old.FactWeight, cur.FactWeight)
+		add("fact_minutes", old.FactMinutes, cur.FactMinutes)
+		add("fact_meters", old.FactMeters, cur.FactMeters)
+		add("completed", old.Completed, cur.Completed)
+	}
+
+	for _, s := range after {
+		if _, ok := afterByID[s.SetID]; ok {
+			changes = append(changes, dto.WorkoutChange{Field: "set", SetID: s.SetID, Exercise: s.Exercise, Old: "", New: "added"})
+		}
+	}
+	return changes
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func recordEdit(repo workoutedits.Repo, workoutID, userID int64, action string, changes []dto.WorkoutChange, snapshot []setSnapshot) error {
+	if changes == nil {
+		changes = []dto.WorkoutChange{}
+	}
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	edit := &models.WorkoutEdit{
+		WorkoutDayID: workoutID,
+		UserID:       userID,
+		Action:       action,
+		Changes:      string(changesJSON),
+	}
+	if snapshot != nil {
+		snapshotJSON, marshalErr := json.Marshal(snapshot)
+		if marshalErr != nil {
+			return marshalErr
+		}
+		s := string(snapshotJSON)
+		edit.Snapshot = &s
+	}
+	return repo.Create(edit)
+}
@@ -24,13 +24,16 @@ var (
 	EmptyWorkoutsErr = errors.New("empty workouts found for user")
 )
 
+// byUserLimit — сколько последних тренировок показывать: больше не влезет в одно сообщение
+const byUserLimit = 50
+
 func (uc *FindByUserIDUseCase) Execute(userID int64) (*dto.ShowWorkoutByUserID, error) {
 	user, err := uc.usersRepo.GetByID(userID)
 	if err != nil {
 		return nil, err
 	}
 
-	workoutObjs, err := uc.workoutsRepo.FindAll(user.ID)
+	workoutObjs, err := uc.workoutsRepo.FindBefore(user.ID, nil, byUserLimit)
 	if err != nil {
 		return nil, err
 	}
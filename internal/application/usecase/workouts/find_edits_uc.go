@@ -0,0 +1,44 @@
+package workouts
+
+import (
+	"encoding/json"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workoutedits"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/utils"
+)
+
+type FindEditsUseCase struct {
+	editsRepo workoutedits.Repo
+}
+
+func NewFindEditsUseCase(editsRepo workoutedits.Repo) *FindEditsUseCase {
+	return &FindEditsUseCase{editsRepo: editsRepo}
+}
+
+func (uc *FindEditsUseCase) Name() string {
+	return "История изменений тренировки"
+}
+
+func (uc *FindEditsUseCase) Execute(workoutID int64) ([]dto.WorkoutEditItem, error) {
+	edits, err := uc.editsRepo.FindByWorkoutID(workoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.WorkoutEditItem, 0, len(edits))
+	for _, e := range edits {
+		var changes []dto.WorkoutChange
+		if err = json.Unmarshal([]byte(e.Changes), &changes); err != nil {
+			return nil, err
+		}
+		res = append(res, dto.WorkoutEditItem{
+			ID:        e.ID,
+			UserID:    e.UserID,
+			Action:    e.Action,
+			Changes:   changes,
+			CreatedAt: utils.FormatDateTimeWithDayOfWeek(e.CreatedAt),
+		})
+	}
+	return res, nil
+}
@@ -1,9 +1,15 @@
 package workouts
 
 import (
+	"encoding/json"
+
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/daytypes"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/versioning"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workoutedits"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
 	"time"
 )
@@ -12,27 +18,40 @@ type FinishUseCase struct {
 	workoutsRepo workouts.Repo
 	dayTypesRepo daytypes.Repo
 	sessionsRepo sessions.Repo
+	editsRepo    workoutedits.Repo
 }
 
-func NewFinishUseCase(workoutsRepo workouts.Repo, sessionsRepo sessions.Repo) *FinishUseCase {
-	return &FinishUseCase{workoutsRepo: workoutsRepo, sessionsRepo: sessionsRepo}
+func NewFinishUseCase(workoutsRepo workouts.Repo, sessionsRepo sessions.Repo, editsRepo workoutedits.Repo) *FinishUseCase {
+	return &FinishUseCase{workoutsRepo: workoutsRepo, sessionsRepo: sessionsRepo, editsRepo: editsRepo}
 }
 
 func (uc *FinishUseCase) Name() string {
 	return "Завершение тренировки"
 }
 
-func (uc *FinishUseCase) Execute(workoutID int64) (*dto.FinishWorkout, error) {
+func (uc *FinishUseCase) Execute(workoutID, expectedVersion int64) (*dto.FinishWorkout, error) {
 	workoutDay, err := uc.workoutsRepo.Get(workoutID)
 	if err != nil {
 		return nil, err
 	}
+	// повторное нажатие в боте или повтор запроса без If-Match: тренировка уже завершена,
+	// второе событие продублировало бы вебхуки, значки и объявления челленджей
+	if workoutDay.Completed {
+		return &dto.FinishWorkout{WorkoutID: workoutDay.ID, Version: workoutDay.Version}, nil
+	}
+	if err = versioning.Check(expectedVersion, workoutDay.Version); err != nil {
+		return nil, err
+	}
 
-	now := time.Now()
+	// у переоткрытой или записанной задним числом тренировки время окончания уже задано
+	if workoutDay.EndedAt == nil {
+		now := time.Now()
+		workoutDay.EndedAt = &now
+	}
 	workoutDay.Completed = true
-	workoutDay.EndedAt = &now
 
-	if err = uc.workoutsRepo.Save(&workoutDay); err != nil {
+	finished := events.WorkoutFinished{WorkoutID: workoutDay.ID, UserID: workoutDay.UserID, EndedAt: *workoutDay.EndedAt}
+	if err = uc.workoutsRepo.Save(&workoutDay, finished); err != nil {
 		return nil, err
 	}
 
@@ -41,5 +60,42 @@ func (uc *FinishUseCase) Execute(workoutID int64) (*dto.FinishWorkout, error) {
 		return nil, err
 	}
 
-	return &dto.FinishWorkout{WorkoutID: workoutDay.ID}, nil
+	if err = uc.recordSetChanges(workoutDay); err != nil {
+		return nil, err
+	}
+
+	return &dto.FinishWorkout{WorkoutID: workoutDay.ID, Version: workoutDay.Version}, nil
+}
+
+// recordSetChanges пишет в журнал правки подходов, сделанные после переоткрытия
+func (uc *FinishUseCase) recordSetChanges(workoutDay models.WorkoutDay) error {
+	edits, err := uc.editsRepo.FindByWorkoutID(workoutDay.ID)
+	if err != nil {
+		return err
+	}
+
+	// ищем снимок последнего переоткрытия, по которому разница еще не записана
+	var last *models.WorkoutEdit
+	for i := len(edits) - 1; i >= 0 && last == nil; i-- {
+		switch {
+		case edits[i].Action == models.WorkoutEditSets:
+			return nil
+		case edits[i].Snapshot != nil:
+			last = &edits[i]
+		}
+	}
+	if last == nil {
+		return nil
+	}
+
+	var before []setSnapshot
+	if err = json.Unmarshal([]byte(*last.Snapshot), &before); err != nil {
+		return err
+	}
+
+	changes := diffSnapshots(before, takeSnapshot(workoutDay))
+	if len(changes) == 0 {
+		return nil
+	}
+	return recordEdit(uc.editsRepo, workoutDay.ID, last.UserID, models.WorkoutEditSets, changes, nil)
 }
@@ -0,0 +1,30 @@
+package workouts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+func TestFinishTwiceEmitsOneEvent(t *testing.T) {
+	workoutsRepo := &fakeWorkouts{workout: models.WorkoutDay{ID: 7, UserID: 3, Version: 1}}
+	sessionsRepo := &fakeSessions{session: models.WorkoutSession{WorkoutDayID: 7, IsActive: true}}
+	uc := NewFinishUseCase(workoutsRepo, sessionsRepo, &fakeEdits{})
+
+	first, err := uc.Execute(7, 0)
+	require.NoError(t, err)
+	assert.True(t, workoutsRepo.workout.Completed)
+	assert.False(t, sessionsRepo.session.IsActive)
+	require.Len(t, workoutsRepo.events, 1)
+	assert.IsType(t, events.WorkoutFinished{}, workoutsRepo.events[0])
+
+	// двойное нажатие, в том числе с версией, которую клиент видел до первого завершения
+	second, err := uc.Execute(7, 1)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Len(t, workoutsRepo.events, 1, "повтор не публикует событие")
+}
@@ -0,0 +1,97 @@
+package workouts
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercises"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workoutedits"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+// LogPastStore — репозитории, через которые записывается прошедшая тренировка; все они работают в одной транзакции
+type LogPastStore struct {
+	Workouts  workouts.Repo
+	Exercises exercises.Repo
+	Sessions  sessions.Repo
+	Edits     workoutedits.Repo
+}
+
+// LogPastTx выполняет fn в транзакции; ошибка fn откатывает все, что было сделано через LogPastStore
+type LogPastTx func(fn func(s LogPastStore) error) error
+
+// LogPastUseCase создает тренировку задним числом. Тренировка открывается в режиме правки
+// с уже заданным временем окончания: пользователь отмечает подходы и завершает ее, время при этом не меняется
+type LogPastUseCase struct {
+	createUC *CreateUseCase
+	inTx     LogPastTx
+}
+
+func NewLogPastUseCase(createUC *CreateUseCase, inTx LogPastTx) *LogPastUseCase {
+	return &LogPastUseCase{createUC: createUC, inTx: inTx}
+}
+
+func (uc *LogPastUseCase) Name() string {
+	return "Записать прошедшую тренировку"
+}
+
+func (uc *LogPastUseCase) Execute(userID, dayTypeID int64, startedAt, endedAt time.Time) (*dto.CreateWorkout, error) {
+	if err := ValidateTimes(startedAt, &endedAt); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.createUC.usersRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	workout := &models.WorkoutDay{
+		UserID:           userID,
+		WorkoutDayTypeID: dayTypeID,
+		StartedAt:        startedAt,
+		EndedAt:          &endedAt,
+		Completed:        false,
+	}
+	// тренировка, упражнения, сессия, журнал и событие пишутся вместе: без событий подписчиков
+	// или наполовину созданной тренировки не остается
+	err = uc.inTx(func(s LogPastStore) error {
+		if err := s.Workouts.Create(workout); err != nil {
+			return err
+		}
+
+		exerciseObjs, err := uc.createUC.buildExercises(workout.ID, dayTypeID, user)
+		if err != nil {
+			return err
+		}
+		if err = s.Exercises.CreateBatch(exerciseObjs); err != nil {
+			return err
+		}
+
+		// тренировка встает в середину истории: агрегаты ее дня и рекорды пересчитывают подписчики
+		session := models.WorkoutSession{
+			WorkoutDayID: workout.ID,
+			StartedAt:    time.Now(),
+			IsActive:     true,
+		}
+		if err = s.Sessions.Create(&session, events.WorkoutChanged{WorkoutID: workout.ID, UserID: userID}); err != nil {
+			return err
+		}
+
+		changes := []dto.WorkoutChange{
+			{Field: "started_at", New: formatTime(&startedAt)},
+			{Field: "ended_at", New: formatTime(&endedAt)},
+		}
+		// пустой снимок: при завершении в журнал попадут все заполненные подходы
+		return recordEdit(s.Edits, workout.ID, userID, models.WorkoutEditLogPast, changes, []setSnapshot{})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CreateWorkout{
+		WorkoutID: workout.ID,
+	}, nil
+}
@@ -0,0 +1,72 @@
+package workouts
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/versioning"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workoutedits"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+// ReopenUseCase снимает отметку о завершении, время окончания сохраняется,
+// чтобы правка старой тренировки не растягивала ее до текущего момента
+type ReopenUseCase struct {
+	workoutsRepo workouts.Repo
+	sessionsRepo sessions.Repo
+	editsRepo    workoutedits.Repo
+}
+
+func NewReopenUseCase(workoutsRepo workouts.Repo, sessionsRepo sessions.Repo, editsRepo workoutedits.Repo) *ReopenUseCase {
+	return &ReopenUseCase{workoutsRepo: workoutsRepo, sessionsRepo: sessionsRepo, editsRepo: editsRepo}
+}
+
+func (uc *ReopenUseCase) Name() string {
+	return "Редактирование завершенной тренировки"
+}
+
+func (uc *ReopenUseCase) Execute(userID, workoutID, expectedVersion int64) (*dto.EditWorkoutResult, error) {
+	workoutDay, err := uc.workoutsRepo.Get(workoutID)
+	if err != nil {
+		return nil, err
+	}
+	if workoutDay.ID == 0 {
+		return nil, NotFoundSpecificErr
+	}
+	if err = versioning.Check(expectedVersion, workoutDay.Version); err != nil {
+		return nil, err
+	}
+	if !workoutDay.Completed {
+		return nil, NotCompletedErr
+	}
+
+	snapshot := takeSnapshot(workoutDay)
+
+	// незавершенная тренировка выпадает из рекордов до повторного завершения
+	workoutDay.Completed = false
+	changed := events.WorkoutChanged{WorkoutID: workoutDay.ID, UserID: workoutDay.UserID}
+	if err = uc.workoutsRepo.Save(&workoutDay, changed); err != nil {
+		return nil, err
+	}
+
+	session, err := uc.sessionsRepo.GetByWorkoutID(workoutID)
+	if err != nil {
+		session = models.WorkoutSession{WorkoutDayID: workoutID, StartedAt: time.Now(), IsActive: true}
+		err = uc.sessionsRepo.Create(&session)
+	} else {
+		session.IsActive = true
+		err = uc.sessionsRepo.Save(&session)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = recordEdit(uc.editsRepo, workoutID, userID, models.WorkoutEditReopen, nil, snapshot); err != nil {
+		return nil, err
+	}
+
+	return &dto.EditWorkoutResult{WorkoutID: workoutID, Version: workoutDay.Version, Completed: false}, nil
+}
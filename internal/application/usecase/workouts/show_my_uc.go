@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/utils"
@@ -45,6 +46,48 @@ func (uc *FindMyUseCase) Execute(userID int64, offset, limit int) (*dto.ShowMyWo
 		return nil, err
 	}
 
+	res := newShowMyWorkoutsResult(workoutObjs, offset+len(workoutObjs) < int(total))
+	res.Pagination = dto.Pagination{
+		Offset: offset,
+		Limit:  limit,
+		Total:  int(total),
+	}
+	return res, nil
+}
+
+// ExecuteAfter — страница по курсору из NextCursor предыдущего ответа; пустой курсор — первая страница.
+// Offset в ответе всегда 0: позиция задается курсором
+func (uc *FindMyUseCase) ExecuteAfter(userID int64, cursor string, limit int) (*dto.ShowMyWorkoutsResult, error) {
+	after, err := workouts.DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := uc.workoutsRepo.Count(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// лишняя запись показывает, есть ли следующая страница
+	workoutObjs, err := uc.workoutsRepo.FindBefore(userID, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	hasMore := len(workoutObjs) > limit
+	if hasMore {
+		workoutObjs = workoutObjs[:limit]
+	}
+
+	res := newShowMyWorkoutsResult(workoutObjs, hasMore)
+	res.Pagination = dto.Pagination{
+		Limit: limit,
+		Total: int(total),
+	}
+	return res, nil
+}
+
+// newShowMyWorkoutsResult собирает элементы списка; курсор на следующую страницу ставится, только если она есть
+func newShowMyWorkoutsResult(workoutObjs []models.WorkoutDay, hasMore bool) *dto.ShowMyWorkoutsResult {
 	items := make([]dto.WorkoutItem, 0, len(workoutObjs))
 	for _, w := range workoutObjs {
 		duration := ""
@@ -53,8 +96,14 @@ func (uc *FindMyUseCase) Execute(userID int64, offset, limit int) (*dto.ShowMyWo
 		}
 
 		status := "🟡"
+		if w.InEditMode() {
+			status = "✏️"
+		}
 		if w.Completed {
 			status = "✅"
+			if w.AutoFinished {
+				status = "💤"
+			}
 			if w.EndedAt != nil {
 				status += fmt.Sprintf(" ~ %s", duration)
 			}
@@ -70,12 +119,9 @@ func (uc *FindMyUseCase) Execute(userID int64, offset, limit int) (*dto.ShowMyWo
 		})
 	}
 
-	return &dto.ShowMyWorkoutsResult{
-		Items: items,
-		Pagination: dto.Pagination{
-			Offset: offset,
-			Limit:  limit,
-			Total:  int(total),
-		},
-	}, nil
+	res := &dto.ShowMyWorkoutsResult{Items: items}
+	if hasMore && len(workoutObjs) > 0 {
+		res.NextCursor = workouts.CursorOf(workoutObjs[len(workoutObjs)-1]).Encode()
+	}
+	return res
 }
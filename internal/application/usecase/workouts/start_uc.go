@@ -3,6 +3,7 @@ package workouts
 import (
 	"errors"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/exercises"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sessions"
@@ -51,7 +52,8 @@ func (uc *StartUseCase) Execute(workoutID int64) (*dto.StartWorkout, error) {
 		IsActive:             true,
 		CurrentExerciseIndex: 0,
 	}
-	if err = uc.sessionsRepo.Create(&session); err != nil {
+	started := events.WorkoutStarted{WorkoutID: workoutDay.ID, UserID: workoutDay.UserID, StartedAt: session.StartedAt}
+	if err = uc.sessionsRepo.Create(&session, started); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,71 @@
+package workouts
+
+import (
+	"errors"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workoutedits"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+var NotAutoFinishedErr = errors.New("workout was not finished automatically")
+
+// UndoAutoFinishUseCase возвращает автоматически завершенную тренировку в активное состояние
+type UndoAutoFinishUseCase struct {
+	workoutsRepo workouts.Repo
+	sessionsRepo sessions.Repo
+	editsRepo    workoutedits.Repo
+}
+
+func NewUndoAutoFinishUseCase(workoutsRepo workouts.Repo, sessionsRepo sessions.Repo, editsRepo workoutedits.Repo) *UndoAutoFinishUseCase {
+	return &UndoAutoFinishUseCase{workoutsRepo: workoutsRepo, sessionsRepo: sessionsRepo, editsRepo: editsRepo}
+}
+
+func (uc *UndoAutoFinishUseCase) Name() string {
+	return "Отмена автозавершения тренировки"
+}
+
+func (uc *UndoAutoFinishUseCase) Execute(userID, workoutID int64) (*dto.EditWorkoutResult, error) {
+	workoutDay, err := uc.workoutsRepo.Get(workoutID)
+	if err != nil {
+		return nil, err
+	}
+	if workoutDay.ID == 0 {
+		return nil, NotFoundSpecificErr
+	}
+	if !workoutDay.Completed || !workoutDay.AutoFinished {
+		return nil, NotAutoFinishedErr
+	}
+
+	changes := []dto.WorkoutChange{
+		{Field: "completed", Old: "true", New: "false"},
+		{Field: "ended_at", Old: formatTime(workoutDay.EndedAt)},
+	}
+
+	// сохранение обновляет updated_at, поэтому фоновая задача не закроет тренировку сразу же снова
+	workoutDay.Completed = false
+	workoutDay.AutoFinished = false
+	workoutDay.EndedAt = nil
+	// как и при переоткрытии, незавершенная тренировка выпадает из агрегатов и рекордов
+	changed := events.WorkoutChanged{WorkoutID: workoutDay.ID, UserID: workoutDay.UserID}
+	if err = uc.workoutsRepo.Save(&workoutDay, changed); err != nil {
+		return nil, err
+	}
+
+	session, err := uc.sessionsRepo.GetByWorkoutID(workoutID)
+	if err == nil {
+		session.IsActive = true
+		if err = uc.sessionsRepo.Save(&session); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = recordEdit(uc.editsRepo, workoutID, userID, models.WorkoutEditUndoAutoFinish, changes, nil); err != nil {
+		return nil, err
+	}
+
+	return &dto.EditWorkoutResult{WorkoutID: workoutID, Version: workoutDay.Version, Completed: false}, nil
+}
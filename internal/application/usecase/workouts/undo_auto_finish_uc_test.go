@@ -0,0 +1,96 @@
+package workouts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workoutedits"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+// fakeWorkouts хранит одну тренировку и события, записанные вместе с ее сохранением
+type fakeWorkouts struct {
+	workouts.Repo
+	workout models.WorkoutDay
+	events  []events.Event
+}
+
+func (r *fakeWorkouts) Get(id int64) (models.WorkoutDay, error) {
+	if id != r.workout.ID {
+		return models.WorkoutDay{}, nil
+	}
+	return r.workout, nil
+}
+
+func (r *fakeWorkouts) Save(workout *models.WorkoutDay, evts ...events.Event) error {
+	workout.Version++
+	r.workout = *workout
+	r.events = append(r.events, evts...)
+	return nil
+}
+
+type fakeSessions struct {
+	sessions.Repo
+	session models.WorkoutSession
+}
+
+func (r *fakeSessions) GetByWorkoutID(int64) (models.WorkoutSession, error) {
+	return r.session, nil
+}
+
+func (r *fakeSessions) Save(session *models.WorkoutSession) error {
+	r.session = *session
+	return nil
+}
+
+func (r *fakeSessions) UpdateIsActive(_ int64, isActive bool) error {
+	r.session.IsActive = isActive
+	return nil
+}
+
+type fakeEdits struct {
+	workoutedits.Repo
+	edits []models.WorkoutEdit
+}
+
+func (r *fakeEdits) Create(edit *models.WorkoutEdit) error {
+	r.edits = append(r.edits, *edit)
+	return nil
+}
+
+func (r *fakeEdits) FindByWorkoutID(int64) ([]models.WorkoutEdit, error) {
+	return r.edits, nil
+}
+
+func TestUndoAutoFinishEmitsWorkoutChanged(t *testing.T) {
+	endedAt := time.Now().Add(-time.Hour)
+	workoutsRepo := &fakeWorkouts{workout: models.WorkoutDay{
+		ID: 7, UserID: 3, Completed: true, AutoFinished: true, EndedAt: &endedAt, Version: 2,
+	}}
+	sessionsRepo := &fakeSessions{session: models.WorkoutSession{WorkoutDayID: 7}}
+	editsRepo := &fakeEdits{}
+	uc := NewUndoAutoFinishUseCase(workoutsRepo, sessionsRepo, editsRepo)
+
+	res, err := uc.Execute(3, 7)
+	require.NoError(t, err)
+	assert.False(t, res.Completed)
+	assert.EqualValues(t, 3, res.Version)
+
+	assert.False(t, workoutsRepo.workout.Completed)
+	assert.Nil(t, workoutsRepo.workout.EndedAt)
+	assert.Equal(t, []events.Event{events.WorkoutChanged{WorkoutID: 7, UserID: 3}}, workoutsRepo.events,
+		"агрегаты и рекорды пересчитываются без тренировки")
+	assert.True(t, sessionsRepo.session.IsActive)
+	require.Len(t, editsRepo.edits, 1)
+	assert.Equal(t, models.WorkoutEditUndoAutoFinish, editsRepo.edits[0].Action)
+
+	_, err = uc.Execute(3, 7)
+	assert.ErrorIs(t, err, NotAutoFinishedErr, "повторная отмена")
+	assert.Len(t, workoutsRepo.events, 1)
+}
@@ -0,0 +1,94 @@
+package workouts
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/sessions"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/versioning"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workoutedits"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+// UpdateTimesUseCase правит время начала и окончания. Если активная тренировка получает
+// время окончания, значит ее забыли завершить: завершаем задним числом
+type UpdateTimesUseCase struct {
+	workoutsRepo workouts.Repo
+	sessionsRepo sessions.Repo
+	editsRepo    workoutedits.Repo
+}
+
+func NewUpdateTimesUseCase(workoutsRepo workouts.Repo, sessionsRepo sessions.Repo, editsRepo workoutedits.Repo) *UpdateTimesUseCase {
+	return &UpdateTimesUseCase{workoutsRepo: workoutsRepo, sessionsRepo: sessionsRepo, editsRepo: editsRepo}
+}
+
+func (uc *UpdateTimesUseCase) Name() string {
+	return "Изменить время тренировки"
+}
+
+func (uc *UpdateTimesUseCase) Execute(userID, workoutID, expectedVersion int64, input *dto.UpdateWorkoutTimes) (*dto.EditWorkoutResult, error) {
+	workoutDay, err := uc.workoutsRepo.Get(workoutID)
+	if err != nil {
+		return nil, err
+	}
+	if workoutDay.ID == 0 {
+		return nil, NotFoundSpecificErr
+	}
+	if err = versioning.Check(expectedVersion, workoutDay.Version); err != nil {
+		return nil, err
+	}
+
+	startedAt := workoutDay.StartedAt
+	if input.StartedAt != nil {
+		startedAt = *input.StartedAt
+	}
+	endedAt := workoutDay.EndedAt
+	if input.EndedAt != nil {
+		endedAt = input.EndedAt
+	}
+	if err = ValidateTimes(startedAt, endedAt); err != nil {
+		return nil, err
+	}
+
+	changes := make([]dto.WorkoutChange, 0, 2)
+	if !startedAt.Equal(workoutDay.StartedAt) {
+		changes = append(changes, dto.WorkoutChange{Field: "started_at", Old: formatTime(&workoutDay.StartedAt), New: formatTime(&startedAt)})
+	}
+	if formatTime(endedAt) != formatTime(workoutDay.EndedAt) {
+		changes = append(changes, dto.WorkoutChange{Field: "ended_at", Old: formatTime(workoutDay.EndedAt), New: formatTime(endedAt)})
+	}
+
+	// активная тренировка без времени окончания: забытое завершение
+	forgottenFinish := !workoutDay.Completed && workoutDay.EndedAt == nil && endedAt != nil
+
+	prevStartedAt := workoutDay.StartedAt
+	workoutDay.StartedAt = startedAt
+	workoutDay.EndedAt = endedAt
+	var evts []events.Event
+	if forgottenFinish {
+		workoutDay.Completed = true
+		changes = append(changes, dto.WorkoutChange{Field: "completed", Old: "false", New: "true"})
+		evts = append(evts, events.WorkoutFinished{WorkoutID: workoutDay.ID, UserID: workoutDay.UserID, EndedAt: *endedAt})
+	}
+	// подходы могли переехать в другой день, а рекорды зависят от порядка тренировок
+	if !startedAt.Equal(prevStartedAt) {
+		evts = append(evts, events.WorkoutChanged{WorkoutID: workoutDay.ID, UserID: workoutDay.UserID, PrevStartedAt: &prevStartedAt})
+	}
+	if err = uc.workoutsRepo.Save(&workoutDay, evts...); err != nil {
+		return nil, err
+	}
+
+	if forgottenFinish {
+		if err = uc.sessionsRepo.UpdateIsActive(workoutID, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(changes) > 0 {
+		if err = recordEdit(uc.editsRepo, workoutID, userID, models.WorkoutEditTimes, changes, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.EditWorkoutResult{WorkoutID: workoutID, Version: workoutDay.Version, Completed: workoutDay.Completed}, nil
+}
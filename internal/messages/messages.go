@@ -57,6 +57,11 @@ const (
 	LibraryOfExercises = "🤓 Библиотека упражнений"
 	Admin              = "👨🏻‍💻 Админка"
 	Users              = "👥 Пользователи"
+	AccountDisabled    = "⛔️ Аккаунт заблокирован администратором"
+	Coaching           = "🏋️ Тренер и спортсмены"
+	Feed               = "👥 Лента друзей"
+	Challenges         = "🏆 Челленджи"
+	Achievements       = "🎖 Достижения"
 	BackTo             = "🔙 Назад"
 )
 
@@ -103,6 +108,13 @@ const (
 	EnterNewMeters      = "📐 <b>Введите новую дистанцию (метры):</b>"
 	EnterWorkoutDayName = "<b>Введите имя тренировочного дня:</b>"
 	EnterNewProgramName = "<b>Введите новое имя программы:</b>"
+	EnterWorkoutTimes   = "🕒 <b>Введите время тренировки по Москве:</b>\n\n" +
+		"• <u><b>19:45</b></u> — <i>только время окончания</i>\n" +
+		"• <u><b>18:30-19:45</b></u> — <i>начало и окончание</i>\n" +
+		"• <u><b>18.10.2026 18:30-19:45</b></u> — <i>с другой датой</i>"
+	EnterPastWorkoutTimes = "📝 <b>Когда была тренировка?</b> Введите дату и время по Москве:\n\n" +
+		"• <u><b>18.10.2026 18:30-19:45</b></u>\n" +
+		"• <u><b>18:30-19:45</b></u> — <i>если сегодня</i>"
 
 	EnterPreset = "<b>Введите пресет в одном из следующих форматов:</b>" +
 		"\n\n• <u><b>reps,weight:17*100,15*160,12*200</b></u> — <i>что означает 3 подхода: первый - 17 повторений по 100 кг, второй - 15 повторений на 160 кг, а третий - 12 повторений на 200 кг</i>" +
@@ -125,6 +137,7 @@ const (
 	SetDeleted                 = "✅ <b>Подход удален!</b>"
 	SetAdded                   = "✅ <b>Еще один подход добавлен!</b>"
 	SetCompleted               = "✅ <b>Подход завершен!</b>"
+	SetStale                   = "🔄 <b>Подходы уже изменили на другом устройстве</b>, вот актуальное состояние:"
 
 	NoProgramsFound            = "🥲 У вас нет тренировочных программ, создайте первую!"
 	SuccessfullyChangedProgram = "✅ Программа выбрана!"
@@ -140,12 +153,19 @@ const (
 	IncorrectFormatWeight        = "❌ Неверный формат веса. Введите число (например: 42.5)"
 	IncorrectFormatMinutes       = "❌ Неверный формат минут. Введите число (например: 42)"
 	IncorrectFormatMeters        = "❌ Неверный формат дистанции. Введите число (например: 42)"
+	IncorrectFormatWorkoutTimes  = "❌ Неверный формат времени. Введите, например: 18:30-19:45"
+	IncorrectWorkoutTimes        = "❌ Время не подходит: окончание должно быть позже начала, не в будущем и не дальше суток от начала"
 
 	RepsUpdated    = "✅ Количество повторений обновлено"
 	WeightUpdated  = "✅ Вес обновлен"
 	MinutesUpdated = "✅ Время обновлено"
 	MetersUpdated  = "✅ Дистанция обновлена"
 
+	WorkoutTimesUpdated    = "✅ Время тренировки обновлено"
+	WorkoutReopened        = "✏️ <b>Тренировка открыта для правки.</b> Время окончания сохранено, после исправлений завершите ее снова"
+	WorkoutNotAutoFinished = "🗿 Эта тренировка уже не в автозавершении: ее продолжили или завершили вручную"
+	PastWorkoutLogged      = "📝 <b>Тренировка записана задним числом!</b> Отметьте выполненные подходы и завершите ее"
+
 	CannotDeleteDayTypeAlreadyUsedInWorkoutDays = "🗿 Нельзя удалить день, который уже был использован на тренировках. Сначала удалите их"
 
 	Earlier = "⬅️ Раньше"
@@ -164,3 +184,44 @@ const (
 const (
 	MeasurementImage = "<a href=\"https://disk.yandex.ru/i/6lUhXPu8_FnKnw\">image</a>"
 )
+
+// Экран тренера и спортсменов
+const (
+	CoachInvite        = "➕ Пригласить спортсмена"
+	CoachingEmpty      = "Пока нет связей. Тренер может выдать код приглашения, спортсмен принимает его командой <code>/accept_coach КОД</code>"
+	CoachOnly          = "⛔️ Приглашать спортсменов может только тренер"
+	CoachInviteInvalid = "❌ Код приглашения недействителен или устарел"
+	CoachLinkRevoked   = "✅ Доступ отключён"
+	CommentUsage       = "Формат: <code>/comment ID_тренировки текст</code>"
+	CommentNotAllowed  = "⛔️ Нет доступа к этой тренировке"
+	CommentSaved       = "💬 Комментарий сохранён"
+)
+
+// Экран подписок и ленты
+const (
+	FeedEmpty          = "В ленте пока пусто. Подпишитесь на друга командой <code>/follow ID</code> — свой ID можно посмотреть здесь же"
+	FollowUsage        = "Формат: <code>/follow ID_пользователя</code>"
+	FollowSelf         = "🙃 Нельзя подписаться на себя"
+	FollowNotFound     = "❌ Пользователь не найден"
+	FollowRequested    = "📨 Заявка отправлена, подписка начнёт работать после одобрения"
+	FollowAccepted     = "✅ Вы подписались"
+	FollowersEmpty     = "Новых заявок на подписку нет"
+	FollowerAccepted   = "✅ Подписчик одобрен"
+	FollowerRemoved    = "✅ Заявка отклонена"
+	FollowRequests     = "📨 Заявки на подписку"
+	FeedDigestSettings = "Отключить сводку можно в настройках приватности в приложении"
+)
+
+// Экран челленджей
+const (
+	ChallengesEmpty       = "Вы пока не участвуете в челленджах. Создайте челлендж в приложении и отправьте друзьям ссылку-приглашение"
+	ChallengeJoinUsage    = "Формат: <code>/join_challenge КОД</code>"
+	ChallengeInvalidCode  = "❌ Челлендж с таким кодом не найден"
+	ChallengeFinished     = "🏁 Челлендж уже завершён"
+	ChallengeNotAvailable = "⛔️ Таблица лидеров доступна только участникам"
+)
+
+// Экран достижений
+const (
+	AchievementsUnlocked = "🎉 Новое достижение!"
+)
@@ -1,39 +1,161 @@
 package middlewares
 
 import (
-	"github.com/golang-jwt/jwt/v4"
 	"net/http"
-	"os"
 	"strings"
-)
 
-var (
-	jwtSecret = []byte(os.Getenv("JWT_SECRET"))
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/authtokens"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/denylist"
 )
 
-func Auth(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+// Authenticator проверяет токен запроса: подпись, срок и отзыв по списку в памяти
+type Authenticator struct {
+	denylist *denylist.Denylist
+}
+
+// NewAuthenticator без списка отзыва не создается: иначе отозванные токены проходили бы до истечения срока
+func NewAuthenticator(d *denylist.Denylist) *Authenticator {
+	if d == nil {
+		panic("middlewares: token denylist is required")
+	}
+	return &Authenticator{denylist: d}
+}
+
+type PersonalTokenAuthenticator interface {
+	Execute(token string) (*dto.PersonalTokenIdentity, error)
+}
+
+// personalTokens задается при старте сервера; пока он не задан, персональные токены не принимаются
+var personalTokens PersonalTokenAuthenticator
 
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			http.Error(w, "unauthorized", 401)
+func SetPersonalTokens(a PersonalTokenAuthenticator) {
+	personalTokens = a
+}
+
+func (a *Authenticator) Auth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := a.parseRequestClaims(r)
+		if !ok {
+			apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 			return
 		}
 
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+		ctx := WithClaims(r.Context(), claims)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
 
-		token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
-			return jwtSecret, nil
-		})
-		if err != nil || !token.Valid {
-			http.Error(w, "unauthorized", 401)
+// APIAuth — Auth для публичного /api/v1: та же проверка и заголовок WWW-Authenticate по RFC 6750
+func (a *Authenticator) APIAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := a.parseRequestClaims(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			apierrors.Write(w, r, http.StatusUnauthorized, apierrors.CodeUnauthorized)
 			return
 		}
 
-		claims := token.Claims.(jwt.MapClaims)
-
 		ctx := WithClaims(r.Context(), claims)
 		next.ServeHTTP(w, r.WithContext(ctx))
-		//next.ServeHTTP(w, r)
 	})
 }
+
+// OptionalAuth для публичных страниц: анонимный запрос проходит без claims,
+// а с валидным токеном хендлер узнает пользователя
+func (a *Authenticator) OptionalAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if claims, ok := a.parseRequestClaims(r); ok {
+			r = r.WithContext(WithClaims(r.Context(), claims))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireScopes открывает маршрут персональным токенам с нужными правами.
+// Без этого middleware FromContext не признает персональный токен, поэтому
+// остальные маршруты доступны только по сессии; JWT проходит без проверки прав
+func RequireScopes(scopes ...authtokens.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, _ := r.Context().Value(userCtxKey).(jwt.MapClaims)
+			if _, personal := claims[personalTokenClaim]; personal {
+				granted, _ := claims[scopesClaim].([]string)
+				for _, scope := range scopes {
+					if !hasScope(granted, scope) {
+						w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+string(scope)+`"`)
+						apierrors.Write(w, r, http.StatusForbidden, apierrors.CodeInsufficientScope)
+						return
+					}
+				}
+				r = r.WithContext(withScopesChecked(r.Context()))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func hasScope(granted []string, scope authtokens.Scope) bool {
+	for _, s := range granted {
+		if s == string(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Authenticator) parseRequestClaims(r *http.Request) (jwt.MapClaims, bool) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		// EventSource в браузере не умеет передавать заголовки, поэтому для SSE токен приходит в query
+		if queryToken := r.URL.Query().Get("access_token"); queryToken != "" && isEventStream(r) {
+			authHeader = "Bearer " + queryToken
+		}
+	}
+	if authHeader == "" {
+		return nil, false
+	}
+
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+
+	if authtokens.IsPersonal(tokenString) {
+		return parsePersonalToken(tokenString)
+	}
+
+	claims, err := authtokens.Parse(tokenString)
+	if err != nil {
+		return nil, false
+	}
+
+	// отозван сам токен (logout) или вся сессия (удаленное завершение, кража refresh токена)
+	jti, _ := claims["jti"].(string)
+	sid, _ := claims["sid"].(string)
+	if a.denylist.Contains(jti, sid) {
+		return nil, false
+	}
+	return claims, true
+}
+
+// parsePersonalToken приводит персональный токен к тем же claims, что и JWT,
+// чтобы хендлеры не различали способ входа
+func parsePersonalToken(token string) (jwt.MapClaims, bool) {
+	if personalTokens == nil {
+		return nil, false
+	}
+	identity, err := personalTokens.Execute(token)
+	if err != nil {
+		return nil, false
+	}
+	return jwt.MapClaims{
+		"user_id":          float64(identity.UserID),
+		personalTokenClaim: float64(identity.TokenID),
+		scopesClaim:        identity.Scopes,
+	}, true
+}
+
+func isEventStream(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/authtokens"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/denylist"
+)
+
+type memoryRevokedTokens struct{}
+
+func (memoryRevokedTokens) Add([]models.RevokedToken) error { return nil }
+func (memoryRevokedTokens) FindCreatedSince(time.Time) ([]models.RevokedToken, error) {
+	return nil, nil
+}
+func (memoryRevokedTokens) DeleteExpired(time.Time) error { return nil }
+
+func TestAuthRejectsRevokedSession(t *testing.T) {
+	revoked := denylist.New(memoryRevokedTokens{})
+	handler := NewAuthenticator(revoked).Auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	access, err := authtokens.IssueAccess(1, "session-1")
+	require.NoError(t, err)
+	status := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+		req.Header.Set("Authorization", "Bearer "+access.Token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, status())
+	require.NoError(t, revoked.Add([]string{"session-1"}, access.ExpiresAt))
+	assert.Equal(t, http.StatusUnauthorized, status(), "сессия завершена, токен еще не истек")
+}
+
+func TestAuthenticatorRequiresDenylist(t *testing.T) {
+	assert.Panics(t, func() { NewAuthenticator(nil) }, "без списка отзыв токенов молча отключился бы")
+}
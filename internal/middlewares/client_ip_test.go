@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies("10.0.0.0/8, 127.0.0.1")
+	require.NoError(t, err)
+	SetTrustedProxies(proxies)
+	defer SetTrustedProxies(nil)
+
+	cases := []struct {
+		name   string
+		remote string
+		xff    []string
+		want   string
+	}{
+		{"без прокси", "203.0.113.7:5000", nil, "203.0.113.7"},
+		{"заголовок от клиента напрямую игнорируется", "203.0.113.7:5000", []string{"198.51.100.1"}, "203.0.113.7"},
+		{"через доверенный прокси", "10.0.0.2:443", []string{"203.0.113.7"}, "203.0.113.7"},
+		{"поддельное начало цепочки", "10.0.0.2:443", []string{"198.51.100.1, 203.0.113.7"}, "203.0.113.7"},
+		{"цепочка прокси", "127.0.0.1:80", []string{"203.0.113.7, 10.1.2.3", "10.0.0.9"}, "203.0.113.7"},
+		{"мусор в заголовке", "10.0.0.2:443", []string{"not-an-ip"}, "10.0.0.2"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/api/email/login", nil)
+			r.RemoteAddr = tc.remote
+			for _, v := range tc.xff {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			assert.Equal(t, tc.want, ClientIP(r))
+		})
+	}
+}
+
+func TestParseTrustedProxiesRejectsGarbage(t *testing.T) {
+	_, err := ParseTrustedProxies("10.0.0.0/8, proxy.local")
+	assert.Error(t, err)
+}
@@ -2,17 +2,38 @@ package middlewares
 
 import (
 	"context"
+	"log/slog"
+
 	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/logging"
 )
 
 type contextKey string
 
-const userCtxKey = contextKey("userClaims")
+const (
+	userCtxKey          = contextKey("userClaims")
+	scopesCheckedCtxKey = contextKey("scopesChecked")
+)
 
+// claims, которые появляются только у персонального токена
+const (
+	personalTokenClaim = "pat_id"
+	scopesClaim        = "scopes"
+)
+
+// WithClaims заодно дописывает пользователя в логи запроса (см. Observe)
 func WithClaims(ctx context.Context, claims jwt.MapClaims) context.Context {
+	if userID, ok := claims["user_id"].(float64); ok {
+		logging.AddAttrs(ctx, slog.Int64("user_id", int64(userID)))
+	}
 	return context.WithValue(ctx, userCtxKey, claims)
 }
 
+func withScopesChecked(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scopesCheckedCtxKey, true)
+}
+
 func FromContext(ctx context.Context) (*Claims, bool) {
 	claims, ok := ctx.Value(userCtxKey).(jwt.MapClaims)
 	if !ok {
@@ -24,11 +45,31 @@ func FromContext(ctx context.Context) (*Claims, bool) {
 		return &Claims{}, false
 	}
 
-	return &Claims{
-		UserID: int64(userID),
-	}, ok
+	sessionID, _ := claims["sid"].(string)
+	tokenID, _ := claims["jti"].(string)
+
+	result := &Claims{
+		UserID:    int64(userID),
+		SessionID: sessionID,
+		TokenID:   tokenID,
+	}
+
+	// персональный токен годится только там, где маршрут явно проверил его права
+	if patID, personal := claims[personalTokenClaim].(float64); personal {
+		if checked, _ := ctx.Value(scopesCheckedCtxKey).(bool); !checked {
+			return &Claims{}, false
+		}
+		result.PersonalTokenID = int64(patID)
+		result.Scopes, _ = claims[scopesClaim].([]string)
+	}
+
+	return result, ok
 }
 
 type Claims struct {
-	UserID int64
+	UserID          int64
+	SessionID       string   // id сессии, к которой привязан access токен
+	TokenID         string   // jti
+	PersonalTokenID int64    // заполнен, если запрос пришел с персональным токеном
+	Scopes          []string // права персонального токена
 }
@@ -0,0 +1,75 @@
+package middlewares
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/api/apierrors"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/logging"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/metrics"
+)
+
+// Observe заменяет middleware.Logger и middleware.Recoverer: пишет JSON-строку на каждый запрос
+// с request_id и user_id (его добавляет Auth), учитывает время ответа по шаблону маршрута chi
+// и превращает панику в 500. Ставится сразу после middleware.RequestID
+func Observe(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ctx := logging.NewContext(r.Context(), slog.String("request_id", middleware.GetReqID(r.Context())))
+		r = r.WithContext(ctx)
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		defer func() {
+			if rvr := recover(); rvr != nil {
+				if rvr == http.ErrAbortHandler {
+					panic(rvr)
+				}
+				slog.ErrorContext(ctx, "panic", "panic", rvr, "stack", string(debug.Stack()))
+				if ww.Status() == 0 {
+					apierrors.Write(ww, r, http.StatusInternalServerError, apierrors.CodeInternal)
+				}
+			}
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			elapsed := time.Since(start)
+			route := routePattern(r)
+			metrics.HTTPRequestDuration.WithLabelValues(r.Method, route, strconv.Itoa(status)).Observe(elapsed.Seconds())
+
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			slog.LogAttrs(ctx, level, "http request",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("route", route),
+				slog.Int("status", status),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Float64("duration_ms", float64(elapsed.Microseconds())/1000),
+				slog.String("remote_ip", ClientIP(r)),
+			)
+		}()
+
+		next.ServeHTTP(ww, r)
+	})
+}
+
+// routePattern — шаблон сработавшего маршрута; chi заполняет его по ходу маршрутизации,
+// поэтому читать можно только после обработки запроса
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return "unmatched"
+}
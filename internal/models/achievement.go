@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// UserAchievement — полученный пользователем значок. Описание значков — в каталоге service/achievements
+type UserAchievement struct {
+	ID           int64 `gorm:"primaryKey;autoIncrement"`
+	UserID       int64
+	Code         string
+	EarnedAt     time.Time
+	WorkoutDayID *int64
+	CreatedAt    time.Time
+}
+
+func (*UserAchievement) TableName() string {
+	return "user_achievements"
+}
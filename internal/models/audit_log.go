@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// AuditLogEntry — запись о действии администратора
+type AuditLogEntry struct {
+	ID         int64 `gorm:"primaryKey;autoIncrement"`
+	ActorID    *int64
+	Action     string
+	TargetType string
+	TargetID   string
+	Details    *string `gorm:"type:jsonb"`
+	CreatedAt  time.Time
+}
+
+func (*AuditLogEntry) TableName() string {
+	return "audit_log"
+}
+
+const (
+	AuditTargetUser         = "user"
+	AuditTargetExerciseType = "exercise_type"
+)
+
+const (
+	AuditActionDisableUser        = "user.disable"
+	AuditActionEnableUser         = "user.enable"
+	AuditActionChangeRole         = "user.change_role"
+	AuditActionCreateExerciseType = "exercise_type.create"
+	AuditActionUpdateExerciseType = "exercise_type.update"
+	AuditActionDeleteExerciseType = "exercise_type.delete"
+)
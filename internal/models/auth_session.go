@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+type AuthSession struct {
+	ID                   string `gorm:"type:uuid;primaryKey"`
+	UserID               int64
+	RefreshTokenHash     string
+	PrevRefreshTokenHash *string // нужен, чтобы распознать повторное использование старого refresh токена
+	UserAgent            string
+	IP                   string
+	CreatedAt            time.Time
+	LastUsedAt           time.Time
+	ExpiresAt            time.Time
+	RevokedAt            *time.Time
+}
+
+func (*AuthSession) TableName() string {
+	return "auth_sessions"
+}
+
+func (s *AuthSession) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+type RevokedToken struct {
+	ID        string `gorm:"primaryKey"`
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+func (*RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// метрики челленджа
+const (
+	ChallengeMetricWorkouts      = "workouts"       // тренировок с выполненными подходами
+	ChallengeMetricTonnage       = "tonnage"        // суммарный тоннаж, кг
+	ChallengeMetricCardioMinutes = "cardio_minutes" // минут кардио
+	ChallengeMetricMeters        = "meters"         // пройдено/пробежано метров
+	ChallengeMetricE1RM          = "e1rm"           // расчетный разовый максимум в одном упражнении (формула Эпли)
+)
+
+var AllChallengeMetrics = []string{
+	ChallengeMetricWorkouts,
+	ChallengeMetricTonnage,
+	ChallengeMetricCardioMinutes,
+	ChallengeMetricMeters,
+	ChallengeMetricE1RM,
+}
+
+func IsValidChallengeMetric(metric string) bool {
+	for _, m := range AllChallengeMetrics {
+		if m == metric {
+			return true
+		}
+	}
+	return false
+}
+
+type Challenge struct {
+	ID             int64 `gorm:"primaryKey;autoIncrement"`
+	OwnerID        int64
+	Title          string
+	Metric         string
+	ExerciseTypeID *int64
+	InviteCode     string
+	StartsAt       time.Time
+	EndsAt         time.Time
+	FinishedAt     *time.Time
+	CreatedAt      time.Time
+
+	Owner        *User         `gorm:"foreignKey:OwnerID;references:ID"`
+	ExerciseType *ExerciseType `gorm:"foreignKey:ExerciseTypeID;references:ID"`
+}
+
+func (*Challenge) TableName() string {
+	return "challenges"
+}
+
+func (c *Challenge) IsFinished() bool {
+	return c.FinishedAt != nil
+}
+
+type ChallengeParticipant struct {
+	ChallengeID int64 `gorm:"primaryKey"`
+	UserID      int64 `gorm:"primaryKey"`
+	Score       float64
+	JoinedAt    time.Time
+	UpdatedAt   time.Time
+
+	User *User `gorm:"foreignKey:UserID;references:ID"`
+}
+
+func (*ChallengeParticipant) TableName() string {
+	return "challenge_participants"
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	CoachLinkPending = "pending"
+	CoachLinkActive  = "active"
+	CoachLinkRevoked = "revoked"
+)
+
+// области доступа тренера к данным спортсмена; спортсмен соглашается на них при принятии приглашения
+// и может сузить или отозвать их в любой момент
+const (
+	CoachScopeWorkouts     = "workouts"
+	CoachScopeStats        = "stats"
+	CoachScopeMeasurements = "measurements"
+	CoachScopePrograms     = "programs"
+	CoachScopeComments     = "comments"
+)
+
+var AllCoachScopes = []string{
+	CoachScopeWorkouts,
+	CoachScopeStats,
+	CoachScopeMeasurements,
+	CoachScopePrograms,
+	CoachScopeComments,
+}
+
+func IsValidCoachScope(scope string) bool {
+	for _, s := range AllCoachScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type CoachLink struct {
+	ID         int64 `gorm:"primaryKey;autoIncrement"`
+	CoachID    int64
+	AthleteID  *int64
+	InviteCode *string
+	Status     string
+	Scopes     string
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	AcceptedAt *time.Time
+	RevokedAt  *time.Time
+
+	Coach   *User `gorm:"foreignKey:CoachID;references:ID"`
+	Athlete *User `gorm:"foreignKey:AthleteID;references:ID"`
+}
+
+func (*CoachLink) TableName() string {
+	return "coach_links"
+}
+
+func (l *CoachLink) ScopeList() []string {
+	if l.Scopes == "" {
+		return []string{}
+	}
+	return strings.Split(l.Scopes, ",")
+}
+
+func (l *CoachLink) HasScope(scope string) bool {
+	for _, s := range l.ScopeList() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *CoachLink) IsActive() bool {
+	return l.Status == CoachLinkActive
+}
+
+type WorkoutComment struct {
+	ID           int64 `gorm:"primaryKey;autoIncrement"`
+	WorkoutDayID int64
+	AuthorID     int64
+	Text         string
+	Public       bool // оставлен по публичной ссылке
+	CreatedAt    time.Time
+
+	Author *User `gorm:"foreignKey:AuthorID;references:ID"`
+}
+
+func (*WorkoutComment) TableName() string {
+	return "workout_comments"
+}
@@ -1,6 +1,10 @@
 package models
 
-import "github.com/SaenkoDmitry/training-tg-bot/internal/constants"
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/constants"
+)
 
 type Exercise struct {
 	ID int64 `gorm:"primaryKey;autoIncrement"`
@@ -11,8 +15,10 @@ type Exercise struct {
 	ExerciseTypeID int64
 	ExerciseType   *ExerciseType `gorm:"foreignKey:ExerciseTypeID;references:ID"` // join
 
-	Sets  []Set `gorm:"foreignKey:ExerciseID;constraint:OnDelete:CASCADE"`
-	Index int
+	Sets      []Set `gorm:"foreignKey:ExerciseID;constraint:OnDelete:CASCADE"`
+	Index     int
+	Version   int64 `gorm:"default:1"`
+	UpdatedAt time.Time
 }
 
 func (*Exercise) TableName() string {
@@ -53,6 +59,13 @@ func (e *Exercise) GetExerciseType() *ExerciseType {
 	return e.ExerciseType
 }
 
+func (e *Exercise) GetWorkoutDayID() int64 {
+	if e == nil {
+		return 0
+	}
+	return e.WorkoutDayID
+}
+
 func (e *Exercise) Status() string {
 	completedExerciseSets := e.CompletedSets()
 	allSets := len(e.Sets)
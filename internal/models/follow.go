@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+const (
+	FollowPending  = "pending"
+	FollowAccepted = "accepted"
+)
+
+// Видимость тренировок и рекордов для других пользователей
+const (
+	VisibilityPrivate   = "private"
+	VisibilityFollowers = "followers"
+	VisibilityPublic    = "public"
+)
+
+func IsValidVisibility(v string) bool {
+	switch v {
+	case VisibilityPrivate, VisibilityFollowers, VisibilityPublic:
+		return true
+	}
+	return false
+}
+
+// Follow — подписка follower на followee; пока владелец закрытого профиля не одобрил ее, она pending
+type Follow struct {
+	FollowerID int64 `gorm:"primaryKey"`
+	FolloweeID int64 `gorm:"primaryKey"`
+	Status     string
+	CreatedAt  time.Time
+	AcceptedAt *time.Time
+
+	Follower *User `gorm:"foreignKey:FollowerID;references:ID"`
+	Followee *User `gorm:"foreignKey:FolloweeID;references:ID"`
+}
+
+func (*Follow) TableName() string {
+	return "follows"
+}
+
+func (f *Follow) IsAccepted() bool {
+	return f != nil && f.Status == FollowAccepted
+}
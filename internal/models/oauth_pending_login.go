@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// OAuthPendingLogin — вход через OIDC провайдера, начатый, но еще не завершенный
+type OAuthPendingLogin struct {
+	State        string `gorm:"primaryKey"`
+	Provider     string
+	BindingHash  string
+	Nonce        string
+	CodeVerifier string
+	RedirectURI  string
+	LinkUserID   *int64
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+}
+
+func (*OAuthPendingLogin) TableName() string {
+	return "oauth_pending_logins"
+}
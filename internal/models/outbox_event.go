@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+type OutboxEvent struct {
+	ID            int64 `gorm:"primaryKey;autoIncrement"`
+	Type          string
+	UserID        *int64
+	Payload       string `gorm:"type:jsonb;not null"`
+	DeliveredTo   string `gorm:"type:jsonb;not null;default:'[]'"`
+	Attempts      int
+	LastError     *string
+	NextAttemptAt time.Time
+	ProcessedAt   *time.Time
+	FailedAt      *time.Time
+	CreatedAt     time.Time
+}
+
+func (*OutboxEvent) TableName() string {
+	return "outbox_events"
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+type PersonalAccessToken struct {
+	ID          int64 `gorm:"primaryKey;autoIncrement"`
+	UserID      int64
+	Name        string
+	TokenHash   string
+	TokenPrefix string
+	Scopes      string `gorm:"type:jsonb;not null;default:'[]'"` // JSON-массив scope
+	ExpiresAt   *time.Time
+	LastUsedAt  *time.Time
+	RevokedAt   *time.Time
+	CreatedAt   time.Time
+}
+
+func (*PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
+
+func (t *PersonalAccessToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && (t.ExpiresAt == nil || now.Before(*t.ExpiresAt))
+}
@@ -25,6 +25,8 @@ type Set struct {
 	Completed   bool
 	CompletedAt *time.Time
 	Index       int
+	Version     int64 `gorm:"default:1"`
+	UpdatedAt   time.Time
 }
 
 func (*Set) TableName() string {
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// StatsRollup — агрегаты выполненных подходов пользователя по типу упражнения за день или неделю.
+// Значения фактические, если они заданы, иначе плановые. Строки пересчитываются целиком
+// по событиям подходов и тренировок, поэтому хранят только то, что нельзя сложить из соседних строк
+type StatsRollup struct {
+	UserID         int64     `gorm:"primaryKey"`
+	Period         time.Time `gorm:"primaryKey"` // день или понедельник недели, дата по Москве
+	ExerciseTypeID int64     `gorm:"primaryKey"`
+	GroupCode      string    // группа типа упражнения на момент пересчета
+
+	Workouts      int
+	Sets          int
+	Reps          int
+	Tonnage       float64 // сумма вес * повторения
+	Minutes       int
+	Meters        int
+	MaxWeight     float64
+	MaxWeightReps int     // повторения в подходе с максимальным весом
+	BestE1RM      float64 `gorm:"column:best_e1rm"` // расчетный разовый максимум по формуле Эпли
+	MinMinutes    int     // минимальное ненулевое значение в подходе
+	MaxMinutes    int
+	MinMeters     int
+	MaxMeters     int
+	UpdatedAt     time.Time
+
+	ExerciseType *ExerciseType `gorm:"foreignKey:ExerciseTypeID;references:ID"`
+}
+
+const (
+	StatsDailyRollupsTable  = "stats_daily_rollups"
+	StatsWeeklyRollupsTable = "stats_weekly_rollups"
+)
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type SyncMutation struct {
+	ID         int64  `gorm:"primaryKey;autoIncrement"`
+	UserID     int64  `gorm:"not null;uniqueIndex:idx_sync_mutations_user_client"`
+	ClientID   string `gorm:"type:uuid;not null;uniqueIndex:idx_sync_mutations_user_client"`
+	Type       string
+	Status     string
+	EntityID   int64 // id созданной/измененной сущности, нужен для ссылок из следующих мутаций
+	ClientTime time.Time
+	AppliedAt  time.Time
+}
+
+func (*SyncMutation) TableName() string {
+	return "sync_mutations"
+}
@@ -23,6 +23,14 @@ type User struct {
 
 	YandexID    string // yandex
 	YandexLogin string // yandex
+
+	Role       string     `gorm:"default:athlete"`
+	DisabledAt *time.Time // аккаунт заблокирован администратором
+
+	WorkoutsVisibility string `gorm:"default:followers"`
+	RecordsVisibility  string `gorm:"default:followers"`
+	FeedDigest         bool   `gorm:"default:true"` // присылать в телеграм сводку активности подписок
+	FeedDigestSentAt   *time.Time
 }
 
 func (u *User) GetFirstName() string {
@@ -32,12 +40,41 @@ func (u *User) GetFirstName() string {
 	return u.FirstName
 }
 
+// CanBeSeenBy — правило видимости для тренировок и рекордов: isFollower означает одобренную подписку
+func CanBeSeenBy(visibility string, isFollower bool) bool {
+	switch visibility {
+	case VisibilityPublic:
+		return true
+	case VisibilityFollowers:
+		return isFollower
+	}
+	return false
+}
+
 func (u *User) TableName() string {
 	return "users"
 }
 
+const (
+	RoleAdmin   = "admin"
+	RoleCoach   = "coach"
+	RoleAthlete = "athlete"
+)
+
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleAdmin, RoleCoach, RoleAthlete:
+		return true
+	}
+	return false
+}
+
 func (u *User) IsAdmin() bool {
-	return u.ID == 1 && u.Username == "dsaenko"
+	return u != nil && u.Role == RoleAdmin
+}
+
+func (u *User) IsDisabled() bool {
+	return u != nil && u.DisabledAt != nil
 }
 
 func (u *User) FullName() string {
@@ -61,3 +98,27 @@ func (u *User) ShortName() string {
 	}
 	return fmt.Sprintf("%s", strings.Join(arr, " "))
 }
+
+const (
+	ProviderTelegram = "telegram"
+	ProviderYandex   = "yandex"
+)
+
+func (u *User) HasTelegram() bool {
+	return u.ChatID != 0
+}
+
+func (u *User) HasYandex() bool {
+	return u.YandexID != ""
+}
+
+func (u *User) LinkedProviders() []string {
+	providers := make([]string, 0, 2)
+	if u.HasTelegram() {
+		providers = append(providers, ProviderTelegram)
+	}
+	if u.HasYandex() {
+		providers = append(providers, ProviderYandex)
+	}
+	return providers
+}
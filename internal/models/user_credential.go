@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+const ProviderEmail = "email"
+
+// UserCredential — локальный вход по email: паролем или magic link
+type UserCredential struct {
+	UserID          int64 `gorm:"primaryKey"`
+	Email           string
+	PasswordHash    *string
+	EmailVerifiedAt *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (*UserCredential) TableName() string {
+	return "user_credentials"
+}
+
+func (c *UserCredential) IsVerified() bool {
+	return c.EmailVerifiedAt != nil
+}
+
+const (
+	EmailTokenVerify = "verify"
+	EmailTokenMagic  = "magic"
+	EmailTokenReset  = "reset"
+)
+
+// EmailToken — одноразовая ссылка из письма; хранится только хеш
+type EmailToken struct {
+	ID        int64 `gorm:"primaryKey;autoIncrement"`
+	Email     string
+	Purpose   string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (*EmailToken) TableName() string {
+	return "email_tokens"
+}
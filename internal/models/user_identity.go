@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// UserIdentity — привязка пользователя к внешнему OIDC провайдеру (Google, VK ID, Keycloak и т.п.).
+// Telegram и Яндекс исторически хранятся прямо в users
+type UserIdentity struct {
+	ID        int64 `gorm:"primaryKey;autoIncrement"`
+	UserID    int64
+	Provider  string
+	Subject   string
+	Email     string
+	Login     string
+	CreatedAt time.Time
+}
+
+func (*UserIdentity) TableName() string {
+	return "user_identities"
+}
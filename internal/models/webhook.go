@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// статусы доставки вебхука
+const (
+	WebhookDeliveryPending = "pending"
+	WebhookDeliverySuccess = "success"
+	WebhookDeliveryFailed  = "failed" // попытки исчерпаны
+)
+
+type Webhook struct {
+	ID          int64 `gorm:"primaryKey;autoIncrement"`
+	UserID      int64
+	URL         string
+	Secret      string
+	EventTypes  string `gorm:"type:jsonb;not null;default:'[]'"` // JSON-массив типов событий
+	Description string
+	Active      bool `gorm:"default:true"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (*Webhook) TableName() string {
+	return "webhooks"
+}
+
+type WebhookDelivery struct {
+	ID             int64 `gorm:"primaryKey;autoIncrement"`
+	WebhookID      int64
+	EventType      string
+	Payload        string `gorm:"type:jsonb;not null"`
+	Status         string `gorm:"default:pending"`
+	Attempts       int
+	NextAttemptAt  time.Time
+	ResponseStatus *int
+	ResponseBody   *string
+	Error          *string
+	DurationMs     *int
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+
+	Webhook *Webhook `gorm:"foreignKey:WebhookID;references:ID"`
+}
+
+func (*WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
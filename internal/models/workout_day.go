@@ -16,6 +16,9 @@ type WorkoutDay struct {
 	StartedAt        time.Time
 	EndedAt          *time.Time
 	Completed        bool
+	AutoFinished     bool  // завершена фоновой задачей из-за бездействия
+	Version          int64 `gorm:"default:1"`
+	UpdatedAt        time.Time
 
 	User           *User           `gorm:"foreignKey:UserID;references:ID"`
 	WorkoutDayType *WorkoutDayType `gorm:"foreignKey:WorkoutDayTypeID;references:ID"`
@@ -28,14 +31,25 @@ func (w *WorkoutDay) GetUser() *User {
 	return w.User
 }
 
+// InEditMode: тренировка переоткрыта для правки или записана задним числом и еще не завершена
+func (w *WorkoutDay) InEditMode() bool {
+	return !w.Completed && w.EndedAt != nil
+}
+
 func (*WorkoutDay) TableName() string {
 	return "workout_days"
 }
 
 func (w *WorkoutDay) Status() string {
+	if w.InEditMode() {
+		return fmt.Sprintf("✏️ Редактируется")
+	}
 	if !w.Completed {
 		return fmt.Sprintf("🟡 Активна")
 	}
+	if w.EndedAt != nil && w.AutoFinished {
+		return fmt.Sprintf("💤 Завершена автоматически в %s", w.EndedAt.Add(3*time.Hour).Format("15:04"))
+	}
 	if w.EndedAt != nil {
 		return fmt.Sprintf("✅ Завершена в %s", w.EndedAt.Add(3*time.Hour).Format("15:04"))
 	}
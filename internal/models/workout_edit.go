@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+const (
+	WorkoutEditLogPast = "log_past"
+	WorkoutEditReopen  = "reopen"
+	WorkoutEditSets    = "edit_sets"
+	WorkoutEditTimes   = "edit_times"
+
+	WorkoutEditAutoFinish     = "auto_finish"
+	WorkoutEditUndoAutoFinish = "undo_auto_finish"
+)
+
+type WorkoutEdit struct {
+	ID           int64  `gorm:"primaryKey;autoIncrement"`
+	WorkoutDayID int64  `gorm:"not null;index"`
+	UserID       int64  `gorm:"not null"`
+	Action       string `gorm:"type:varchar(32);not null"`
+	Changes      string `gorm:"type:jsonb;not null;default:'[]'"`
+	// Snapshot хранит подходы на момент переоткрытия, чтобы при повторном завершении посчитать разницу
+	Snapshot  *string   `gorm:"type:jsonb"`
+	CreatedAt time.Time `gorm:"not null;default:now()"`
+}
+
+func (*WorkoutEdit) TableName() string {
+	return "workout_edits"
+}
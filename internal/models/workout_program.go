@@ -7,7 +7,9 @@ type WorkoutProgram struct {
 	UserID    int64
 	Name      string
 	CreatedAt time.Time
-	DayTypes  []WorkoutDayType `gorm:"foreignKey:WorkoutProgramID;constraint:OnDelete:CASCADE"`
+
+	AssignedByID *int64           // тренер, который составил программу для спортсмена
+	DayTypes     []WorkoutDayType `gorm:"foreignKey:WorkoutProgramID;constraint:OnDelete:CASCADE"`
 }
 
 func (*WorkoutProgram) TableName() string {
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// AllowedReactions — набор реакций на расшаренную тренировку; произвольные эмодзи не принимаем
+var AllowedReactions = []string{"💪", "🔥", "👏", "👍", "❤️"}
+
+func IsValidReaction(emoji string) bool {
+	for _, r := range AllowedReactions {
+		if r == emoji {
+			return true
+		}
+	}
+	return false
+}
+
+type WorkoutReaction struct {
+	ID           int64 `gorm:"primaryKey;autoIncrement"`
+	WorkoutDayID int64
+	UserID       int64
+	Emoji        string
+	CreatedAt    time.Time
+}
+
+func (*WorkoutReaction) TableName() string {
+	return "workout_reactions"
+}
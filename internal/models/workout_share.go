@@ -8,6 +8,8 @@ type WorkoutShare struct {
 	Token        string    `gorm:"type:varchar(64);not null;uniqueIndex"`
 	CreatedAt    time.Time `gorm:"not null;default:now()"`
 	ExpiresAt    *time.Time
+
+	CommentsEnabled bool `gorm:"not null;default:true"`
 }
 
 func (*WorkoutShare) TableName() string {
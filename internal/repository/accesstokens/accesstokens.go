@@ -0,0 +1,83 @@
+package accesstokens
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+var NotFoundTokenErr = errors.New("not found personal access token")
+
+type Repo interface {
+	Create(token *models.PersonalAccessToken) error
+	Get(tokenID int64) (*models.PersonalAccessToken, error)
+	GetByHash(hash string) (*models.PersonalAccessToken, error)
+	FindActiveByUser(userID int64, now time.Time) ([]models.PersonalAccessToken, error)
+	CountActiveByUser(userID int64, now time.Time) (int64, error)
+	Revoke(tokenID int64, revokedAt time.Time) error
+	Touch(tokenID int64, usedAt time.Time) error
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+func (u *repoImpl) Create(token *models.PersonalAccessToken) error {
+	return u.db.Create(token).Error
+}
+
+func (u *repoImpl) Get(tokenID int64) (*models.PersonalAccessToken, error) {
+	return u.getBy("id = ?", tokenID)
+}
+
+func (u *repoImpl) GetByHash(hash string) (*models.PersonalAccessToken, error) {
+	return u.getBy("token_hash = ?", hash)
+}
+
+func (u *repoImpl) getBy(query string, arg any) (*models.PersonalAccessToken, error) {
+	var token models.PersonalAccessToken
+	err := u.db.Where(query, arg).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, NotFoundTokenErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (u *repoImpl) FindActiveByUser(userID int64, now time.Time) ([]models.PersonalAccessToken, error) {
+	var result []models.PersonalAccessToken
+	err := u.active(userID, now).Order("id DESC").Find(&result).Error
+	return result, err
+}
+
+func (u *repoImpl) CountActiveByUser(userID int64, now time.Time) (count int64, err error) {
+	err = u.active(userID, now).Model(&models.PersonalAccessToken{}).Count(&count).Error
+	return count, err
+}
+
+func (u *repoImpl) active(userID int64, now time.Time) *gorm.DB {
+	return u.db.Where("user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", userID, now)
+}
+
+func (u *repoImpl) Revoke(tokenID int64, revokedAt time.Time) error {
+	return u.db.Model(&models.PersonalAccessToken{}).
+		Where("id = ? AND revoked_at IS NULL", tokenID).
+		Update("revoked_at", revokedAt).Error
+}
+
+func (u *repoImpl) Touch(tokenID int64, usedAt time.Time) error {
+	return u.db.Model(&models.PersonalAccessToken{}).
+		Where("id = ?", tokenID).
+		Update("last_used_at", usedAt).Error
+}
@@ -0,0 +1,126 @@
+package achievements
+
+import (
+	"database/sql"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+// Fact — одно событие из истории пользователя, по которому считаются значки
+type Fact struct {
+	WorkoutID *int64
+	At        time.Time
+}
+
+// History — история пользователя по возрастанию времени
+type History struct {
+	Workouts     []Fact // завершенные тренировки
+	Records      []Fact // рекорды: максимальный вес в упражнении выше всех прошлых
+	Measurements []Fact // замеры тела
+}
+
+type Repo interface {
+	LoadHistory(userID int64) (History, error)
+	FindByUser(userID int64) ([]models.UserAchievement, error)
+	// Award сохраняет значки, которых у пользователя еще нет, и возвращает только новые
+	Award(userID int64, awards []models.UserAchievement) ([]models.UserAchievement, error)
+	FindUserIDs(afterID int64, limit int) ([]int64, error)
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+// рекорды считаются так же, как в ленте: лучший вес тренировки сравнивается с максимумом всех прошлых
+const recordsQuery = `
+WITH per_workout AS (
+	SELECT wd.id AS workout_id, wd.started_at, COALESCE(wd.ended_at, wd.started_at) AS at, e.exercise_type_id,
+		MAX(COALESCE(NULLIF(s.fact_weight, 0), s.weight))::FLOAT8 AS weight
+	FROM workout_days wd
+	JOIN exercises e ON e.workout_day_id = wd.id
+	JOIN sets s ON s.exercise_id = e.id AND s.completed
+	WHERE wd.user_id = @user AND wd.completed
+	GROUP BY wd.id, wd.started_at, wd.ended_at, e.exercise_type_id
+)
+SELECT workout_id, at
+FROM (
+	SELECT p.*, MAX(p.weight) OVER (
+		PARTITION BY p.exercise_type_id
+		ORDER BY p.started_at, p.workout_id
+		ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) AS prev_weight
+	FROM per_workout p
+) r
+WHERE r.prev_weight > 0 AND r.weight > r.prev_weight
+ORDER BY at, workout_id, exercise_type_id`
+
+func (u *repoImpl) LoadHistory(userID int64) (History, error) {
+	var history History
+	user := sql.Named("user", userID)
+
+	err := u.db.Raw(`
+		SELECT id AS workout_id, COALESCE(ended_at, started_at) AS at
+		FROM workout_days
+		WHERE user_id = @user AND completed
+		ORDER BY at, id`, user).
+		Scan(&history.Workouts).Error
+	if err != nil {
+		return History{}, err
+	}
+
+	if err = u.db.Raw(recordsQuery, user).Scan(&history.Records).Error; err != nil {
+		return History{}, err
+	}
+
+	err = u.db.Raw(`
+		SELECT NULL::BIGINT AS workout_id, created_at AS at
+		FROM measurements
+		WHERE user_id = @user
+		ORDER BY created_at, id`, user).
+		Scan(&history.Measurements).Error
+	if err != nil {
+		return History{}, err
+	}
+	return history, nil
+}
+
+func (u *repoImpl) FindByUser(userID int64) ([]models.UserAchievement, error) {
+	var result []models.UserAchievement
+	err := u.db.Where("user_id = ?", userID).Order("earned_at, id").Find(&result).Error
+	return result, err
+}
+
+func (u *repoImpl) Award(userID int64, awards []models.UserAchievement) ([]models.UserAchievement, error) {
+	created := make([]models.UserAchievement, 0, len(awards))
+	for _, award := range awards {
+		award.UserID = userID
+		// одновременная проверка по двум событиям не выдаст значок дважды: второй вставке помешает UNIQUE (user_id, code)
+		res := u.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&award)
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		if res.RowsAffected == 1 {
+			created = append(created, award)
+		}
+	}
+	return created, nil
+}
+
+func (u *repoImpl) FindUserIDs(afterID int64, limit int) ([]int64, error) {
+	var ids []int64
+	err := u.db.Table("users").
+		Where("id > ? AND disabled_at IS NULL", afterID).
+		Order("id").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
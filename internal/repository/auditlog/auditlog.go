@@ -0,0 +1,56 @@
+package auditlog
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+type Repo interface {
+	Create(entry *models.AuditLogEntry) error
+	Find(filter Filter, offset, limit int) ([]models.AuditLogEntry, int64, error)
+}
+
+// Filter — необязательные условия выборки журнала, пустые поля не учитываются
+type Filter struct {
+	ActorID    int64
+	Action     string
+	TargetType string
+	TargetID   string
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+func (u *repoImpl) Create(entry *models.AuditLogEntry) error {
+	return u.db.Create(entry).Error
+}
+
+func (u *repoImpl) Find(filter Filter, offset, limit int) (entries []models.AuditLogEntry, total int64, err error) {
+	query := u.db.Model(&models.AuditLogEntry{})
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+
+	if err = query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err = query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&entries).Error
+	return entries, total, err
+}
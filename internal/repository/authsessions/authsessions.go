@@ -0,0 +1,99 @@
+package authsessions
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+var (
+	NotFoundSessionErr = errors.New("not found auth session")
+	AlreadyRotatedErr  = errors.New("refresh token already rotated")
+)
+
+type Repo interface {
+	Create(session *models.AuthSession) error
+	GetByID(id string) (*models.AuthSession, error)
+	GetByRefreshHash(hash string) (*models.AuthSession, error)
+	GetByPrevRefreshHash(hash string) (*models.AuthSession, error)
+	Rotate(id, oldHash, newHash string, usedAt, expiresAt time.Time) error
+	FindActiveByUser(userID int64) ([]models.AuthSession, error)
+	Revoke(ids []string, revokedAt time.Time) error
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+func (u *repoImpl) Create(session *models.AuthSession) error {
+	return u.db.Create(session).Error
+}
+
+func (u *repoImpl) GetByID(id string) (*models.AuthSession, error) {
+	return u.getBy("id = ?", id)
+}
+
+func (u *repoImpl) GetByRefreshHash(hash string) (*models.AuthSession, error) {
+	return u.getBy("refresh_token_hash = ?", hash)
+}
+
+func (u *repoImpl) GetByPrevRefreshHash(hash string) (*models.AuthSession, error) {
+	return u.getBy("prev_refresh_token_hash = ?", hash)
+}
+
+func (u *repoImpl) getBy(query string, arg any) (*models.AuthSession, error) {
+	var session models.AuthSession
+	err := u.db.Where(query, arg).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, NotFoundSessionErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Rotate меняет refresh токен, только если он еще не был заменен параллельным запросом
+func (u *repoImpl) Rotate(id, oldHash, newHash string, usedAt, expiresAt time.Time) error {
+	res := u.db.Model(&models.AuthSession{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", id, oldHash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash":      newHash,
+			"prev_refresh_token_hash": oldHash,
+			"last_used_at":            usedAt,
+			"expires_at":              expiresAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return AlreadyRotatedErr
+	}
+	return nil
+}
+
+func (u *repoImpl) FindActiveByUser(userID int64) (sessions []models.AuthSession, err error) {
+	err = u.db.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (u *repoImpl) Revoke(ids []string, revokedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return u.db.Model(&models.AuthSession{}).
+		Where("id IN ? AND revoked_at IS NULL", ids).
+		Update("revoked_at", revokedAt).Error
+}
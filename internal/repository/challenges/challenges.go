@@ -0,0 +1,257 @@
+package challenges
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+var (
+	NotFoundChallengeErr   = errors.New("not found challenge")
+	NotFoundParticipantErr = errors.New("not found challenge participant")
+)
+
+type Repo interface {
+	Create(challenge *models.Challenge) error
+	Get(challengeID int64) (*models.Challenge, error)
+	GetByInviteCode(code string) (*models.Challenge, error)
+	Delete(challengeID int64) error
+	FindByUser(userID int64) ([]models.Challenge, error)
+	FindDue(now time.Time, limit int) ([]models.Challenge, error)
+	MarkFinished(challengeID int64, at time.Time) error
+
+	// ----- participants -----
+
+	Join(participant *models.ChallengeParticipant) (bool, error)
+	Leave(challengeID, userID int64) error
+	GetParticipant(challengeID, userID int64) (*models.ChallengeParticipant, error)
+	FindParticipants(challengeID int64) ([]models.ChallengeParticipant, error)
+
+	// ----- leaderboard -----
+
+	RecalculateWorkouts(workoutIDs []int64) error
+	RecalculateParticipant(challengeID, userID int64) error
+	RecalculateChallenge(challengeID int64) error
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+func (u *repoImpl) Create(challenge *models.Challenge) error {
+	return u.db.Create(challenge).Error
+}
+
+func (u *repoImpl) Get(challengeID int64) (*models.Challenge, error) {
+	var challenge models.Challenge
+	err := u.db.Preload("Owner").Preload("ExerciseType").First(&challenge, challengeID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, NotFoundChallengeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+func (u *repoImpl) GetByInviteCode(code string) (*models.Challenge, error) {
+	var challenge models.Challenge
+	err := u.db.Preload("Owner").Preload("ExerciseType").Where("invite_code = ?", code).First(&challenge).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, NotFoundChallengeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+func (u *repoImpl) Delete(challengeID int64) error {
+	res := u.db.Delete(&models.Challenge{}, challengeID)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return NotFoundChallengeErr
+	}
+	return nil
+}
+
+// FindByUser — челленджи, в которых пользователь участвует: сначала идущие, потом завершенные
+func (u *repoImpl) FindByUser(userID int64) ([]models.Challenge, error) {
+	var result []models.Challenge
+	err := u.db.
+		Preload("ExerciseType").
+		Joins("JOIN challenge_participants p ON p.challenge_id = challenges.id AND p.user_id = ?", userID).
+		Order("challenges.finished_at IS NOT NULL, challenges.ends_at DESC").
+		Find(&result).Error
+	return result, err
+}
+
+// FindDue — закончившиеся челленджи, итоги которых еще не подведены
+func (u *repoImpl) FindDue(now time.Time, limit int) ([]models.Challenge, error) {
+	var result []models.Challenge
+	err := u.db.
+		Preload("ExerciseType").
+		Where("finished_at IS NULL AND ends_at <= ?", now).
+		Order("ends_at").
+		Limit(limit).
+		Find(&result).Error
+	return result, err
+}
+
+func (u *repoImpl) MarkFinished(challengeID int64, at time.Time) error {
+	return u.db.Model(&models.Challenge{}).
+		Where("id = ? AND finished_at IS NULL", challengeID).
+		Update("finished_at", at).Error
+}
+
+// Join возвращает false, если пользователь уже участвует
+func (u *repoImpl) Join(participant *models.ChallengeParticipant) (bool, error) {
+	res := u.db.Clauses(clause.OnConflict{DoNothing: true}).Create(participant)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (u *repoImpl) Leave(challengeID, userID int64) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("challenge_id = ? AND user_id = ?", challengeID, userID).
+			Delete(&models.ChallengeParticipant{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return NotFoundParticipantErr
+		}
+		return tx.Exec("DELETE FROM challenge_contributions WHERE challenge_id = ? AND user_id = ?",
+			challengeID, userID).Error
+	})
+}
+
+func (u *repoImpl) GetParticipant(challengeID, userID int64) (*models.ChallengeParticipant, error) {
+	var participant models.ChallengeParticipant
+	err := u.db.Where("challenge_id = ? AND user_id = ?", challengeID, userID).First(&participant).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, NotFoundParticipantErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &participant, nil
+}
+
+// FindParticipants возвращает участников в порядке таблицы лидеров; при равенстве выше тот, кто раньше вступил
+func (u *repoImpl) FindParticipants(challengeID int64) ([]models.ChallengeParticipant, error) {
+	var result []models.ChallengeParticipant
+	err := u.db.
+		Preload("User").
+		Where("challenge_id = ?", challengeID).
+		Order("score DESC, joined_at, user_id").
+		Find(&result).Error
+	return result, err
+}
+
+// вклад тренировки: только выполненные в рамках челленджа подходы, фактические значения важнее плановых.
+// e1RM считается по формуле Эпли, для одного повторения — сам вес
+const upsertContributionsQuery = `
+INSERT INTO challenge_contributions (challenge_id, workout_day_id, user_id, value)
+SELECT c.id, wd.id, wd.user_id,
+	CASE c.metric
+		WHEN 'workouts' THEN 1
+		WHEN 'tonnage' THEN COALESCE(SUM(
+			COALESCE(NULLIF(s.fact_reps, 0), s.reps) * COALESCE(NULLIF(s.fact_weight, 0), s.weight)), 0)
+		WHEN 'cardio_minutes' THEN COALESCE(SUM(COALESCE(NULLIF(s.fact_minutes, 0), s.minutes)), 0)
+		WHEN 'meters' THEN COALESCE(SUM(COALESCE(NULLIF(s.fact_meters, 0), s.meters)), 0)
+		WHEN 'e1rm' THEN COALESCE(MAX(
+			CASE WHEN e.exercise_type_id = c.exercise_type_id AND COALESCE(NULLIF(s.fact_reps, 0), s.reps) > 0 THEN
+				COALESCE(NULLIF(s.fact_weight, 0), s.weight) * CASE WHEN COALESCE(NULLIF(s.fact_reps, 0), s.reps) = 1
+					THEN 1 ELSE 1 + COALESCE(NULLIF(s.fact_reps, 0), s.reps) / 30.0 END
+			END), 0)
+		ELSE 0
+	END
+FROM challenges c
+JOIN challenge_participants p ON p.challenge_id = c.id
+JOIN workout_days wd ON wd.user_id = p.user_id
+JOIN exercises e ON e.workout_day_id = wd.id
+JOIN sets s ON s.exercise_id = e.id AND s.completed
+	AND s.completed_at >= c.starts_at AND s.completed_at < c.ends_at
+WHERE c.finished_at IS NULL AND %s
+GROUP BY c.id, c.metric, c.exercise_type_id, wd.id, wd.user_id
+ON CONFLICT (challenge_id, workout_day_id) DO UPDATE SET value = EXCLUDED.value`
+
+const deleteContributionsQuery = `
+DELETE FROM challenge_contributions cc
+USING challenges c
+WHERE c.id = cc.challenge_id AND c.finished_at IS NULL AND %s`
+
+const updateScoresQuery = `
+UPDATE challenge_participants p
+SET score = COALESCE((
+		SELECT CASE WHEN c.metric = 'e1rm' THEN MAX(cc.value) ELSE SUM(cc.value) END
+		FROM challenge_contributions cc
+		WHERE cc.challenge_id = p.challenge_id AND cc.user_id = p.user_id), 0),
+	updated_at = NOW()
+FROM challenges c
+WHERE c.id = p.challenge_id AND c.finished_at IS NULL AND %s`
+
+type recalculateFilter struct {
+	contributions string // условие на cc (challenge_contributions) для удаления вкладов
+	workouts      string // условие на c, wd для новых вкладов
+	participants  string // условие на p для пересчета результатов
+}
+
+// recalculate пересчитывает вклады тренировок и результаты участников, попавших под фильтр.
+// Вклады сначала удаляются: у тренировки могли отменить все подходы, и тогда новой строки не будет
+func (u *repoImpl) recalculate(filter recalculateFilter, args ...interface{}) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(fmt.Sprintf(deleteContributionsQuery, filter.contributions), args...).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(fmt.Sprintf(upsertContributionsQuery, filter.workouts), args...).Error; err != nil {
+			return err
+		}
+		return tx.Exec(fmt.Sprintf(updateScoresQuery, filter.participants), args...).Error
+	})
+}
+
+// RecalculateWorkouts — инкрементальный пересчет после изменения подходов в тренировках
+func (u *repoImpl) RecalculateWorkouts(workoutIDs []int64) error {
+	if len(workoutIDs) == 0 {
+		return nil
+	}
+	return u.recalculate(recalculateFilter{
+		contributions: "cc.workout_day_id IN @workouts",
+		workouts:      "wd.id IN @workouts",
+		participants:  "p.user_id IN (SELECT user_id FROM workout_days WHERE id IN @workouts)",
+	}, sql.Named("workouts", workoutIDs))
+}
+
+// RecalculateParticipant учитывает тренировки, сделанные до вступления в челлендж
+func (u *repoImpl) RecalculateParticipant(challengeID, userID int64) error {
+	return u.recalculate(recalculateFilter{
+		contributions: "cc.challenge_id = @challenge AND cc.user_id = @user",
+		workouts:      "c.id = @challenge AND wd.user_id = @user",
+		participants:  "p.challenge_id = @challenge AND p.user_id = @user",
+	}, sql.Named("challenge", challengeID), sql.Named("user", userID))
+}
+
+// RecalculateChallenge — полный пересчет перед подведением итогов: ловит удаленные упражнения и тренировки,
+// о которых не приходит событий
+func (u *repoImpl) RecalculateChallenge(challengeID int64) error {
+	return u.recalculate(recalculateFilter{
+		contributions: "cc.challenge_id = @challenge",
+		workouts:      "c.id = @challenge",
+		participants:  "p.challenge_id = @challenge",
+	}, sql.Named("challenge", challengeID))
+}
@@ -0,0 +1,153 @@
+package coaching
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+var (
+	NotFoundLinkErr      = errors.New("not found coach link")
+	NotFoundInviteErr    = errors.New("not found coach invite")
+	AlreadyLinkedErr     = errors.New("coach and athlete are already linked")
+	InviteAlreadyUsedErr = errors.New("coach invite is already used")
+)
+
+type Repo interface {
+	Create(link *models.CoachLink) error
+	GetByID(linkID int64) (*models.CoachLink, error)
+	GetByInviteCode(code string) (*models.CoachLink, error)
+	GetActive(coachID, athleteID int64) (*models.CoachLink, error)
+	Accept(linkID, athleteID int64, at time.Time) error
+	Revoke(linkID int64, at time.Time) error
+	UpdateScopes(linkID int64, scopes string) error
+	FindByCoach(coachID int64) ([]models.CoachLink, error)
+	FindByAthlete(athleteID int64) ([]models.CoachLink, error)
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+func (u *repoImpl) Create(link *models.CoachLink) error {
+	return u.db.Create(link).Error
+}
+
+func (u *repoImpl) GetByID(linkID int64) (*models.CoachLink, error) {
+	var link models.CoachLink
+	err := u.db.Preload("Coach").Preload("Athlete").First(&link, linkID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, NotFoundLinkErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (u *repoImpl) GetByInviteCode(code string) (*models.CoachLink, error) {
+	var link models.CoachLink
+	err := u.db.Preload("Coach").Where("invite_code = ?", code).First(&link).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, NotFoundInviteErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (u *repoImpl) GetActive(coachID, athleteID int64) (*models.CoachLink, error) {
+	var link models.CoachLink
+	err := u.db.
+		Where("coach_id = ? AND athlete_id = ? AND status = ?", coachID, athleteID, models.CoachLinkActive).
+		First(&link).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, NotFoundLinkErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// Accept привязывает спортсмена к приглашению. Условие на статус делает принятие одноразовым
+// даже при гонке двух запросов с одним кодом
+func (u *repoImpl) Accept(linkID, athleteID int64, at time.Time) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		var link models.CoachLink
+		if err := tx.First(&link, linkID).Error; err != nil {
+			return err
+		}
+
+		var existing int64
+		err := tx.Model(&models.CoachLink{}).
+			Where("coach_id = ? AND athlete_id = ? AND status = ?", link.CoachID, athleteID, models.CoachLinkActive).
+			Count(&existing).Error
+		if err != nil {
+			return err
+		}
+		if existing > 0 {
+			return AlreadyLinkedErr
+		}
+
+		res := tx.Model(&models.CoachLink{}).
+			Where("id = ? AND status = ?", linkID, models.CoachLinkPending).
+			Updates(map[string]interface{}{
+				"athlete_id":  athleteID,
+				"status":      models.CoachLinkActive,
+				"invite_code": nil,
+				"accepted_at": at,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return InviteAlreadyUsedErr
+		}
+		return nil
+	})
+}
+
+func (u *repoImpl) Revoke(linkID int64, at time.Time) error {
+	return u.db.Model(&models.CoachLink{}).
+		Where("id = ? AND status <> ?", linkID, models.CoachLinkRevoked).
+		Updates(map[string]interface{}{
+			"status":      models.CoachLinkRevoked,
+			"invite_code": nil,
+			"revoked_at":  at,
+		}).Error
+}
+
+func (u *repoImpl) UpdateScopes(linkID int64, scopes string) error {
+	return u.db.Model(&models.CoachLink{}).
+		Where("id = ?", linkID).
+		Update("scopes", scopes).Error
+}
+
+// FindByCoach возвращает действующие связи и неистекшие приглашения тренера
+func (u *repoImpl) FindByCoach(coachID int64) (links []models.CoachLink, err error) {
+	err = u.db.Preload("Athlete").
+		Where("coach_id = ?", coachID).
+		Where("status = ? OR (status = ? AND expires_at > ?)", models.CoachLinkActive, models.CoachLinkPending, time.Now()).
+		Order("id").
+		Find(&links).Error
+	return links, err
+}
+
+func (u *repoImpl) FindByAthlete(athleteID int64) (links []models.CoachLink, err error) {
+	err = u.db.Preload("Coach").
+		Where("athlete_id = ? AND status = ?", athleteID, models.CoachLinkActive).
+		Order("id").
+		Find(&links).Error
+	return links, err
+}
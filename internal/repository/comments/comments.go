@@ -0,0 +1,59 @@
+package comments
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+var (
+	NotFoundCommentErr = errors.New("not found workout comment")
+)
+
+type Repo interface {
+	Create(comment *models.WorkoutComment) error
+	Get(commentID int64) (*models.WorkoutComment, error)
+	FindByWorkout(workoutID int64, onlyPublic bool) ([]models.WorkoutComment, error)
+	Delete(commentID int64) error
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+func (u *repoImpl) Create(comment *models.WorkoutComment) error {
+	return u.db.Create(comment).Error
+}
+
+func (u *repoImpl) Get(commentID int64) (*models.WorkoutComment, error) {
+	var comment models.WorkoutComment
+	err := u.db.First(&comment, commentID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, NotFoundCommentErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (u *repoImpl) FindByWorkout(workoutID int64, onlyPublic bool) (comments []models.WorkoutComment, err error) {
+	query := u.db.Preload("Author").Where("workout_day_id = ?", workoutID)
+	if onlyPublic {
+		query = query.Where("public")
+	}
+	err = query.Order("created_at ASC, id ASC").Find(&comments).Error
+	return comments, err
+}
+
+func (u *repoImpl) Delete(commentID int64) error {
+	return u.db.Delete(&models.WorkoutComment{}, commentID).Error
+}
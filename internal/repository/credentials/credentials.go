@@ -0,0 +1,146 @@
+package credentials
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+var (
+	NotFoundCredentialErr = errors.New("not found credential")
+	NotFoundTokenErr      = errors.New("not found email token")
+)
+
+type Repo interface {
+	Create(credential *models.UserCredential) error
+	CreateWithUser(user *models.User, credential *models.UserCredential) error
+	GetByEmail(email string) (*models.UserCredential, error)
+	GetByUserID(userID int64) (*models.UserCredential, error)
+	SetPassword(userID int64, passwordHash string) error
+	ClearPassword(userID int64) error
+	MarkVerified(userID int64, at time.Time) error
+	DeleteByUserID(userID int64) error
+	// Reassign отвязывает вход по email от fromUserID и переносит адрес на нового пользователя
+	Reassign(fromUserID int64, user *models.User, credential *models.UserCredential) error
+
+	// ----- email tokens -----
+
+	CreateToken(token *models.EmailToken) error
+	// UseToken помечает токен использованным; повторно тот же токен не сработает
+	UseToken(tokenHash, purpose string, at time.Time) (*models.EmailToken, error)
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+func (u *repoImpl) Create(credential *models.UserCredential) error {
+	return u.db.Create(credential).Error
+}
+
+func (u *repoImpl) CreateWithUser(user *models.User, credential *models.UserCredential) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		credential.UserID = user.ID
+		return tx.Create(credential).Error
+	})
+}
+
+func (u *repoImpl) GetByEmail(email string) (*models.UserCredential, error) {
+	return u.getBy("email = ?", email)
+}
+
+func (u *repoImpl) GetByUserID(userID int64) (*models.UserCredential, error) {
+	return u.getBy("user_id = ?", userID)
+}
+
+func (u *repoImpl) getBy(query string, arg any) (*models.UserCredential, error) {
+	var credential models.UserCredential
+	err := u.db.Where(query, arg).First(&credential).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, NotFoundCredentialErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &credential, nil
+}
+
+func (u *repoImpl) SetPassword(userID int64, passwordHash string) error {
+	return u.db.Model(&models.UserCredential{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"password_hash": passwordHash,
+			"updated_at":    time.Now(),
+		}).Error
+}
+
+func (u *repoImpl) ClearPassword(userID int64) error {
+	return u.db.Model(&models.UserCredential{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"password_hash": nil,
+			"updated_at":    time.Now(),
+		}).Error
+}
+
+func (u *repoImpl) MarkVerified(userID int64, at time.Time) error {
+	return u.db.Model(&models.UserCredential{}).
+		Where("user_id = ? AND email_verified_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"email_verified_at": at,
+			"updated_at":        at,
+		}).Error
+}
+
+func (u *repoImpl) DeleteByUserID(userID int64) error {
+	return u.db.Where("user_id = ?", userID).Delete(&models.UserCredential{}).Error
+}
+
+func (u *repoImpl) Reassign(fromUserID int64, user *models.User, credential *models.UserCredential) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", fromUserID).Delete(&models.UserCredential{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		credential.UserID = user.ID
+		return tx.Create(credential).Error
+	})
+}
+
+func (u *repoImpl) CreateToken(token *models.EmailToken) error {
+	return u.db.Create(token).Error
+}
+
+func (u *repoImpl) UseToken(tokenHash, purpose string, at time.Time) (*models.EmailToken, error) {
+	var token models.EmailToken
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.EmailToken{}).
+			Where("token_hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", tokenHash, purpose, at).
+			Update("used_at", at)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return NotFoundTokenErr
+		}
+		return tx.Where("token_hash = ?", tokenHash).First(&token).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
@@ -11,6 +11,8 @@ type Repo interface {
 	Save(day *models.WorkoutDayType) error
 	Get(dayTypeID int64) (models.WorkoutDayType, error)
 	FindAll(programID int64) ([]models.WorkoutDayType, error)
+	FindByIDs(ids []int64) ([]models.WorkoutDayType, error)
+	FindByProgramIDs(programIDs []int64) ([]models.WorkoutDayType, error)
 }
 
 type repoImpl struct {
@@ -52,3 +54,16 @@ func (u *repoImpl) FindAll(programID int64) (days []models.WorkoutDayType, err e
 
 	return days, nil
 }
+
+func (u *repoImpl) FindByIDs(ids []int64) (days []models.WorkoutDayType, err error) {
+	err = u.db.Where("id IN ?", ids).Find(&days).Error
+	return days, err
+}
+
+// FindByProgramIDs возвращает дни нескольких программ в том же порядке, что и FindAll
+func (u *repoImpl) FindByProgramIDs(programIDs []int64) (days []models.WorkoutDayType, err error) {
+	err = u.db.Where("workout_program_id IN ?", programIDs).
+		Order("created_at DESC").
+		Find(&days).Error
+	return days, err
+}
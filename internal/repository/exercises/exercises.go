@@ -1,19 +1,21 @@
 package exercises
 
 import (
-	"fmt"
-
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/outbox"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/versioning"
 	"gorm.io/gorm"
 )
 
 type Repo interface {
 	Get(exerciseID int64) (models.Exercise, error)
 	FindAllByWorkoutID(workoutDayID int64) ([]models.Exercise, error)
+	FindByWorkoutIDs(workoutDayIDs []int64) ([]models.Exercise, error)
 	FindAllByUserIDAndExTypeID(userID int64, exerciseTypeID int64, offset, limit int) ([]models.Exercise, error)
 	CountByUserIDAndExTypeID(userID int64, exerciseTypeID int64) (int64, error)
 	DeleteByWorkout(workoutID int64) error
-	Delete(exerciseID int64) error
+	Delete(exerciseID int64, evts ...events.Event) error
 	CreateBatch(exercises []models.Exercise) error
 	Save(exercise *models.Exercise) error
 	FindPreviousByType(exerciseTypeID, activeProgramID int64) (models.Exercise, error)
@@ -42,19 +44,30 @@ func (u *repoImpl) DeleteByWorkout(workoutID int64) error {
 	return nil
 }
 
-func (u *repoImpl) Delete(exerciseID int64) error {
+func (u *repoImpl) Delete(exerciseID int64, evts ...events.Event) error {
 	var exercise models.Exercise
 	if err := u.db.Preload("Sets").First(&exercise, exerciseID).Error; err != nil {
 		return err
 	}
 
-	// Удаляем с помощью Select
-	return u.db.Select("Sets").Delete(&exercise).Error
+	// Удаляем с помощью Select, тренировку помечаем измененной для синхронизации
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Select("Sets").Delete(&exercise).Error; err != nil {
+			return err
+		}
+		if err := versioning.Touch(tx, &models.WorkoutDay{}, exercise.WorkoutDayID); err != nil {
+			return err
+		}
+		return outbox.Append(tx, evts...)
+	})
 }
 
 func (u *repoImpl) Save(exercise *models.Exercise) error {
 	return u.db.Transaction(func(tx *gorm.DB) error {
-		return tx.Save(exercise).Error
+		if exercise.ID == 0 {
+			return tx.Create(exercise).Error
+		}
+		return versioning.Update(tx, exercise, exercise.ID, &exercise.Version)
 	})
 }
 
@@ -66,7 +79,6 @@ func (u *repoImpl) CreateBatch(exercises []models.Exercise) error {
 
 func (u *repoImpl) FindAllByWorkoutID(workoutDayID int64) ([]models.Exercise, error) {
 	var exercises []models.Exercise
-	fmt.Println("FindAllByWorkoutID")
 
 	u.db.Where("workout_day_id = ?", workoutDayID).
 		Preload("Sets", func(db *gorm.DB) *gorm.DB {
@@ -122,3 +134,12 @@ func (u *repoImpl) FindPreviousByType(exerciseTypeID, activeProgramID int64) (mo
 		First(&exercise).Error
 	return exercise, err
 }
+
+// FindByWorkoutIDs возвращает упражнения нескольких тренировок без подходов и типов
+func (u *repoImpl) FindByWorkoutIDs(workoutDayIDs []int64) (exercises []models.Exercise, err error) {
+	err = u.db.Where("workout_day_id IN ?", workoutDayIDs).
+		Order("workout_day_id ASC").
+		Order("index ASC").
+		Find(&exercises).Error
+	return exercises, err
+}
@@ -1,14 +1,29 @@
 package exercisetypes
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 	"gorm.io/gorm"
 )
 
+var (
+	NotFoundExerciseTypeErr = errors.New("not found exercise type")
+	ExerciseTypeInUseErr    = errors.New("exercise type is used in workouts")
+)
+
 type Repo interface {
 	Get(exerciseTypeID int64) (models.ExerciseType, error)
 	GetAll() ([]models.ExerciseType, error)
 	GetAllByGroup(code string) ([]models.ExerciseType, error)
+	GetByIDs(ids []int64) ([]models.ExerciseType, error)
+
+	// ----- библиотека упражнений (админка) -----
+
+	Create(exerciseType *models.ExerciseType) error
+	Update(exerciseType *models.ExerciseType) error
+	Delete(exerciseTypeID int64) error
 }
 
 type repoImpl struct {
@@ -35,3 +50,56 @@ func (u *repoImpl) GetAllByGroup(code string) (exerciseTypes []models.ExerciseTy
 	err = u.db.Where("exercise_group_type_code = ?", code).Order("id ASC").Find(&exerciseTypes).Error
 	return exerciseTypes, nil
 }
+
+func (u *repoImpl) Create(exerciseType *models.ExerciseType) error {
+	return u.db.Create(exerciseType).Error
+}
+
+func (u *repoImpl) Update(exerciseType *models.ExerciseType) error {
+	res := u.db.Model(&models.ExerciseType{}).
+		Where("id = ?", exerciseType.ID).
+		Select("name", "url", "exercise_group_type_code", "rest_in_seconds", "accent", "units", "description").
+		Updates(exerciseType)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return NotFoundExerciseTypeErr
+	}
+	return nil
+}
+
+// Delete удаляет упражнение из библиотеки, только если оно ни разу не использовалось:
+// иначе пропали бы выполненные подходы в истории тренировок и сломались бы пресеты программ
+func (u *repoImpl) Delete(exerciseTypeID int64) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		var used int64
+		if err := tx.Table("exercises").Where("exercise_type_id = ?", exerciseTypeID).Count(&used).Error; err != nil {
+			return err
+		}
+		if used > 0 {
+			return ExerciseTypeInUseErr
+		}
+		// пресет хранится строкой вида "12:[10*50,10*50];7:[15]"
+		presetPattern := fmt.Sprintf("(^|;)%d:", exerciseTypeID)
+		if err := tx.Table("workout_day_types").Where("preset ~ ?", presetPattern).Count(&used).Error; err != nil {
+			return err
+		}
+		if used > 0 {
+			return ExerciseTypeInUseErr
+		}
+		res := tx.Delete(&models.ExerciseType{}, exerciseTypeID)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return NotFoundExerciseTypeErr
+		}
+		return nil
+	})
+}
+
+func (u *repoImpl) GetByIDs(ids []int64) (exerciseTypes []models.ExerciseType, err error) {
+	err = u.db.Where("id IN ?", ids).Find(&exerciseTypes).Error
+	return exerciseTypes, err
+}
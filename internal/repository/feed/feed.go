@@ -0,0 +1,123 @@
+package feed
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	KindWorkout = "workout"
+	KindRecord  = "record"
+)
+
+// Item — завершенная тренировка или рекорд (максимальный вес в упражнении выше всех прошлых)
+type Item struct {
+	Kind           string
+	UserID         int64
+	WorkoutID      int64
+	DayTypeName    string
+	StartedAt      time.Time
+	EndedAt        *time.Time
+	At             time.Time
+	ExerciseTypeID int64
+	ExerciseName   string
+	Weight         float64
+	PrevWeight     float64
+}
+
+// Filter: без AuthorID — лента подписок зрителя, с AuthorID — активность одного пользователя
+type Filter struct {
+	ViewerID int64
+	AuthorID int64
+	Since    *time.Time
+}
+
+type Repo interface {
+	Find(filter Filter, offset, limit int) ([]Item, int64, error)
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+// видимость считается в SQL, чтобы пагинация не ломалась из-за отфильтрованных в коде записей;
+// рекорды уже посчитаны в personal_records (см. records.Repo.RecalculateUser), поэтому обе части
+// ленты — выборки по индексам без пересчета истории подходов
+const feedQuery = `
+WITH authors AS (
+	SELECT u.id AS user_id,
+		(u.id = @viewer OR u.workouts_visibility = 'public'
+			OR (u.workouts_visibility = 'followers' AND f.status = 'accepted')) AS workouts_visible,
+		(u.id = @viewer OR u.records_visibility = 'public'
+			OR (u.records_visibility = 'followers' AND f.status = 'accepted')) AS records_visible
+	FROM users u
+	LEFT JOIN follows f ON f.followee_id = u.id AND f.follower_id = @viewer
+	WHERE u.disabled_at IS NULL AND %s
+),
+workout_items AS (
+	SELECT 'workout' AS kind, wd.user_id, wd.id AS workout_id, wdt.name AS day_type_name,
+		wd.started_at, wd.ended_at, COALESCE(wd.ended_at, wd.started_at) AS at,
+		0::BIGINT AS exercise_type_id, '' AS exercise_name, 0::FLOAT8 AS weight, 0::FLOAT8 AS prev_weight
+	FROM workout_days wd
+	JOIN authors a ON a.user_id = wd.user_id AND a.workouts_visible
+	JOIN workout_day_types wdt ON wdt.id = wd.workout_day_type_id
+	WHERE wd.completed
+),
+record_items AS (
+	SELECT 'record' AS kind, pr.user_id, pr.workout_id, '' AS day_type_name,
+		wd.started_at, wd.ended_at, COALESCE(wd.ended_at, wd.started_at) AS at,
+		pr.exercise_type_id, et.name AS exercise_name, pr.weight, pr.prev_weight
+	FROM personal_records pr
+	JOIN authors a ON a.user_id = pr.user_id AND a.records_visible
+	JOIN workout_days wd ON wd.id = pr.workout_id AND wd.completed
+	JOIN exercise_types et ON et.id = pr.exercise_type_id
+),
+items AS (
+	SELECT * FROM workout_items
+	UNION ALL
+	SELECT * FROM record_items
+)
+`
+
+func (u *repoImpl) Find(filter Filter, offset, limit int) ([]Item, int64, error) {
+	authorCond := "f.status = 'accepted'"
+	if filter.AuthorID != 0 {
+		authorCond = "u.id = @author"
+	}
+	where := "TRUE"
+	if filter.Since != nil {
+		where = "at > @since"
+	}
+
+	args := []interface{}{
+		sql.Named("viewer", filter.ViewerID),
+		sql.Named("author", filter.AuthorID),
+		sql.Named("since", filter.Since),
+		sql.Named("limit", limit),
+		sql.Named("offset", offset),
+	}
+	base := fmt.Sprintf(feedQuery, authorCond)
+
+	var total int64
+	if err := u.db.Raw(base+"SELECT COUNT(*) FROM items WHERE "+where, args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []Item
+	err := u.db.Raw(base+`
+		SELECT * FROM items
+		WHERE `+where+`
+		ORDER BY at DESC, workout_id DESC, kind DESC, exercise_type_id
+		LIMIT @limit OFFSET @offset`, args...).
+		Scan(&items).Error
+	return items, total, err
+}
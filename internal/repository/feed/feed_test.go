@@ -0,0 +1,164 @@
+package feed_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/feed"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/records"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/repotest"
+)
+
+type seeder struct {
+	t              *testing.T
+	db             *gorm.DB
+	exerciseTypeID int64
+}
+
+// author заводит пользователя с двумя завершенными тренировками: 50 кг, затем 60 кг — во второй рекорд
+func (s seeder) author(workoutsVisibility, recordsVisibility string) int64 {
+	user := &models.User{FirstName: workoutsVisibility + "/" + recordsVisibility,
+		WorkoutsVisibility: workoutsVisibility, RecordsVisibility: recordsVisibility}
+	require.NoError(s.t, s.db.Create(user).Error)
+	program := &models.WorkoutProgram{UserID: user.ID, Name: "program"}
+	require.NoError(s.t, s.db.Create(program).Error)
+	dayType := &models.WorkoutDayType{WorkoutProgramID: program.ID, Name: "day"}
+	require.NoError(s.t, s.db.Create(dayType).Error)
+
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	for i, weight := range []float32{50, 60} {
+		startedAt := start.AddDate(0, 0, i)
+		endedAt := startedAt.Add(time.Hour)
+		workout := &models.WorkoutDay{
+			UserID:           user.ID,
+			WorkoutDayTypeID: dayType.ID,
+			StartedAt:        startedAt,
+			EndedAt:          &endedAt,
+			Completed:        true,
+			Exercises: []models.Exercise{{
+				ExerciseTypeID: s.exerciseTypeID,
+				Sets:           []models.Set{{Reps: 5, Weight: weight, Completed: true, CompletedAt: &endedAt}},
+			}},
+		}
+		require.NoError(s.t, s.db.Create(workout).Error)
+	}
+	require.NoError(s.t, records.NewRepo(s.db).RecalculateUser(user.ID))
+	return user.ID
+}
+
+func (s seeder) viewer() int64 {
+	user := &models.User{FirstName: "viewer"}
+	require.NoError(s.t, s.db.Create(user).Error)
+	return user.ID
+}
+
+func (s seeder) follow(follower, followee int64, status string) {
+	require.NoError(s.t, s.db.Create(&models.Follow{FollowerID: follower, FolloweeID: followee, Status: status}).Error)
+}
+
+// kinds — сколько элементов каждого вида лента показала по авторам
+func kinds(items []feed.Item) map[int64]map[string]int {
+	result := map[int64]map[string]int{}
+	for _, item := range items {
+		if result[item.UserID] == nil {
+			result[item.UserID] = map[string]int{}
+		}
+		result[item.UserID][item.Kind]++
+	}
+	return result
+}
+
+func newSeeder(t *testing.T) seeder {
+	db := repotest.Open(t)
+	s := seeder{t: t, db: db}
+	require.NoError(t, db.Raw("SELECT id FROM exercise_types ORDER BY id LIMIT 1").Scan(&s.exerciseTypeID).Error)
+	require.NotZero(t, s.exerciseTypeID)
+	return s
+}
+
+func TestFindVisibility(t *testing.T) {
+	s := newSeeder(t)
+	repo := feed.NewRepo(s.db)
+
+	viewer := s.viewer()
+	public := s.author(models.VisibilityPublic, models.VisibilityPublic)
+	followers := s.author(models.VisibilityFollowers, models.VisibilityFollowers)
+	private := s.author(models.VisibilityPrivate, models.VisibilityPrivate)
+	recordsOnly := s.author(models.VisibilityPrivate, models.VisibilityFollowers)
+	pending := s.author(models.VisibilityFollowers, models.VisibilityFollowers)
+	stranger := s.author(models.VisibilityPublic, models.VisibilityPublic)
+	for _, followee := range []int64{public, followers, private, recordsOnly} {
+		s.follow(viewer, followee, models.FollowAccepted)
+	}
+	s.follow(viewer, pending, models.FollowPending)
+
+	t.Run("лента подписок", func(t *testing.T) {
+		items, total, err := repo.Find(feed.Filter{ViewerID: viewer}, 0, 100)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[int64]map[string]int{
+			public:      {feed.KindWorkout: 2, feed.KindRecord: 1},
+			followers:   {feed.KindWorkout: 2, feed.KindRecord: 1},
+			recordsOnly: {feed.KindRecord: 1},
+		}, kinds(items))
+		assert.EqualValues(t, len(items), total)
+	})
+
+	t.Run("рекорд несет прошлый вес", func(t *testing.T) {
+		items, _, err := repo.Find(feed.Filter{ViewerID: viewer, AuthorID: recordsOnly}, 0, 100)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, 60.0, items[0].Weight)
+		assert.Equal(t, 50.0, items[0].PrevWeight)
+	})
+
+	t.Run("профиль", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			viewer int64
+			author int64
+			want   map[string]int
+		}{
+			{"публичный без подписки", viewer, stranger, map[string]int{feed.KindWorkout: 2, feed.KindRecord: 1}},
+			{"для подписчиков, подписка не одобрена", viewer, pending, nil},
+			{"закрытый у подписчика", viewer, private, nil},
+			{"свой закрытый", private, private, map[string]int{feed.KindWorkout: 2, feed.KindRecord: 1}},
+		}
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				items, total, err := repo.Find(feed.Filter{ViewerID: c.viewer, AuthorID: c.author}, 0, 100)
+				require.NoError(t, err)
+				assert.Equal(t, c.want, kinds(items)[c.author])
+				assert.EqualValues(t, len(items), total)
+			})
+		}
+	})
+}
+
+func TestFindPagination(t *testing.T) {
+	s := newSeeder(t)
+	repo := feed.NewRepo(s.db)
+
+	viewer := s.viewer()
+	for range 3 {
+		s.follow(viewer, s.author(models.VisibilityPublic, models.VisibilityPublic), models.FollowAccepted)
+	}
+
+	all, total, err := repo.Find(feed.Filter{ViewerID: viewer}, 0, 100)
+	require.NoError(t, err)
+	require.EqualValues(t, 9, total)
+
+	var paged []feed.Item
+	for offset := 0; offset < int(total); offset += 4 {
+		page, pageTotal, err := repo.Find(feed.Filter{ViewerID: viewer}, offset, 4)
+		require.NoError(t, err)
+		assert.Equal(t, total, pageTotal)
+		paged = append(paged, page...)
+	}
+	assert.Equal(t, all, paged)
+}
@@ -0,0 +1,129 @@
+package follows
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+var (
+	NotFoundFollowErr = errors.New("not found follow")
+)
+
+type Repo interface {
+	// Create не трогает уже существующую подписку, чтобы повторный запрос не сбрасывал одобрение
+	Create(follow *models.Follow) error
+	Get(followerID, followeeID int64) (*models.Follow, error)
+	Accept(followerID, followeeID int64, at time.Time) error
+	Delete(followerID, followeeID int64) error
+	FindFollowers(userID int64, status string, offset, limit int) ([]models.Follow, int64, error)
+	FindFollowing(userID int64, offset, limit int) ([]models.Follow, int64, error)
+	Count(userID int64) (followers, following int64, err error)
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+func (u *repoImpl) Create(follow *models.Follow) error {
+	return u.db.Clauses(clause.OnConflict{DoNothing: true}).Create(follow).Error
+}
+
+func (u *repoImpl) Get(followerID, followeeID int64) (*models.Follow, error) {
+	var follow models.Follow
+	err := u.db.Where("follower_id = ? AND followee_id = ?", followerID, followeeID).First(&follow).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, NotFoundFollowErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &follow, nil
+}
+
+func (u *repoImpl) Accept(followerID, followeeID int64, at time.Time) error {
+	tx := u.db.Model(&models.Follow{}).
+		Where("follower_id = ? AND followee_id = ? AND status = ?", followerID, followeeID, models.FollowPending).
+		Updates(map[string]interface{}{
+			"status":      models.FollowAccepted,
+			"accepted_at": at,
+		})
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return NotFoundFollowErr
+	}
+	return nil
+}
+
+func (u *repoImpl) Delete(followerID, followeeID int64) error {
+	tx := u.db.Where("follower_id = ? AND followee_id = ?", followerID, followeeID).Delete(&models.Follow{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return NotFoundFollowErr
+	}
+	return nil
+}
+
+func (u *repoImpl) FindFollowers(userID int64, status string, offset, limit int) ([]models.Follow, int64, error) {
+	tx := u.db.Model(&models.Follow{}).Where("followee_id = ? AND status = ?", userID, status)
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var result []models.Follow
+	err := tx.Preload("Follower").
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&result).Error
+	return result, total, err
+}
+
+func (u *repoImpl) FindFollowing(userID int64, offset, limit int) ([]models.Follow, int64, error) {
+	tx := u.db.Model(&models.Follow{}).Where("follower_id = ?", userID)
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var result []models.Follow
+	err := tx.Preload("Followee").
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&result).Error
+	return result, total, err
+}
+
+func (u *repoImpl) Count(userID int64) (followers, following int64, err error) {
+	var counts struct {
+		Followers int64
+		Following int64
+	}
+	err = u.db.Raw(`
+		SELECT
+			COUNT(*) FILTER (WHERE followee_id = ?) AS followers,
+			COUNT(*) FILTER (WHERE follower_id = ?) AS following
+		FROM follows
+		WHERE status = ? AND (followee_id = ? OR follower_id = ?)`,
+		userID, userID, models.FollowAccepted, userID, userID).
+		Scan(&counts).Error
+	return counts.Followers, counts.Following, err
+}
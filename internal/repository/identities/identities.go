@@ -0,0 +1,55 @@
+package identities
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+var (
+	NotFoundIdentityErr = errors.New("not found user identity")
+)
+
+type Repo interface {
+	Create(identity *models.UserIdentity) error
+	GetByProviderSubject(provider, subject string) (*models.UserIdentity, error)
+	FindByUser(userID int64) ([]models.UserIdentity, error)
+	DeleteByUserProvider(userID int64, provider string) error
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+func (u *repoImpl) Create(identity *models.UserIdentity) error {
+	return u.db.Create(identity).Error
+}
+
+func (u *repoImpl) GetByProviderSubject(provider, subject string) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	err := u.db.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, NotFoundIdentityErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (u *repoImpl) FindByUser(userID int64) (identities []models.UserIdentity, err error) {
+	err = u.db.Where("user_id = ?", userID).Order("id").Find(&identities).Error
+	return identities, err
+}
+
+func (u *repoImpl) DeleteByUserProvider(userID int64, provider string) error {
+	return u.db.Where("user_id = ? AND provider = ?", userID, provider).Delete(&models.UserIdentity{}).Error
+}
@@ -1,21 +1,34 @@
 package measurements
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/outbox"
 )
 
 type Repo interface {
 	Save(measurement *models.Measurement) error
+	// Create добавляет новое измерение и публикует MeasurementCreated
+	Create(measurement *models.Measurement) error
 	Get(measurementID int64) (models.Measurement, error)
 	Delete(measurement *models.Measurement) error
 	FindAll(userID int64) ([]models.Measurement, error)
 	FindAllLimitOffset(userID int64, limit, offset int) ([]models.Measurement, error)
 	Count(userID int64) (int64, error)
+	FindFiltered(userID int64, filter Filter, offset, limit int) ([]models.Measurement, int64, error)
 	DeleteByID(id int64) error
 }
 
+// Filter ограничивает выборку по дате измерения; нулевые поля не фильтруют
+type Filter struct {
+	From *time.Time // created_at >= From
+	To   *time.Time // created_at < To
+}
+
 type repoImpl struct {
 	db *gorm.DB
 }
@@ -39,6 +52,19 @@ func (u *repoImpl) Save(measurement *models.Measurement) error {
 	return u.db.Save(measurement).Error
 }
 
+func (u *repoImpl) Create(measurement *models.Measurement) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(measurement).Error; err != nil {
+			return err
+		}
+		return outbox.Append(tx, events.MeasurementCreated{
+			MeasurementID: measurement.ID,
+			UserID:        measurement.UserID,
+			CreatedAt:     measurement.CreatedAt,
+		})
+	})
+}
+
 func (u *repoImpl) Get(measurementID int64) (measurement models.Measurement, err error) {
 	tx := u.db.
 		First(&measurement, measurementID)
@@ -81,3 +107,21 @@ func (u *repoImpl) Count(userID int64) (count int64, err error) {
 	err = u.db.Model(&models.Measurement{}).Where("user_id = ?", userID).Count(&count).Error
 	return count, err
 }
+
+func (u *repoImpl) FindFiltered(userID int64, filter Filter, offset, limit int) (measurements []models.Measurement, total int64, err error) {
+	query := u.db.Model(&models.Measurement{}).Where("user_id = ?", userID)
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	// Session позволяет переиспользовать условия для Count и Find
+	query = query.Session(&gorm.Session{})
+
+	if err = query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err = query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&measurements).Error
+	return measurements, total, err
+}
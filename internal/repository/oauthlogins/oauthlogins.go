@@ -0,0 +1,52 @@
+package oauthlogins
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+var NotFoundLoginErr = errors.New("not found oauth login")
+
+type Repo interface {
+	Create(login *models.OAuthPendingLogin) error
+	// Take удаляет вход и возвращает его; из двух одновременных запросов с одним state вход получит только один
+	Take(state string) (*models.OAuthPendingLogin, error)
+	DeleteExpired(now time.Time) error
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+func (u *repoImpl) Create(login *models.OAuthPendingLogin) error {
+	return u.db.Create(login).Error
+}
+
+func (u *repoImpl) Take(state string) (*models.OAuthPendingLogin, error) {
+	var logins []models.OAuthPendingLogin
+	err := u.db.Clauses(clause.Returning{}).
+		Where("state = ?", state).
+		Delete(&logins).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(logins) == 0 {
+		return nil, NotFoundLoginErr
+	}
+	return &logins[0], nil
+}
+
+func (u *repoImpl) DeleteExpired(now time.Time) error {
+	return u.db.Where("expires_at <= ?", now).Delete(&models.OAuthPendingLogin{}).Error
+}
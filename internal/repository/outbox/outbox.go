@@ -0,0 +1,113 @@
+package outbox
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+// Append пишет события в outbox внутри транзакции изменения: событие появится тогда и только тогда,
+// когда закоммитится само изменение
+func Append(tx *gorm.DB, evts ...events.Event) error {
+	if len(evts) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	rows := make([]models.OutboxEvent, 0, len(evts))
+	for _, e := range evts {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		row := models.OutboxEvent{
+			Type:          string(e.Type()),
+			Payload:       string(payload),
+			DeliveredTo:   "[]",
+			NextAttemptAt: now,
+		}
+		if userID := events.UserOf(e); userID != 0 {
+			row.UserID = &userID
+		}
+		rows = append(rows, row)
+	}
+	return tx.Create(&rows).Error
+}
+
+type Repo interface {
+	// Add публикует события без собственного изменения (например, из фоновых задач)
+	Add(evts ...events.Event) error
+	FindPending(now time.Time, limit int) ([]models.OutboxEvent, error)
+	MarkDelivered(eventID int64, subscriber string) error
+	MarkProcessed(eventID int64, at time.Time) error
+	MarkRetry(eventID int64, attempts int, next time.Time, lastError string) error
+	MarkFailed(eventID int64, at time.Time, lastError string) error
+	DeleteProcessedBefore(t time.Time) (int64, error)
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+func (u *repoImpl) Add(evts ...events.Event) error {
+	return Append(u.db, evts...)
+}
+
+func (u *repoImpl) FindPending(now time.Time, limit int) ([]models.OutboxEvent, error) {
+	var result []models.OutboxEvent
+	err := u.db.
+		Where("processed_at IS NULL AND failed_at IS NULL AND next_attempt_at <= ?", now).
+		Order("id").
+		Limit(limit).
+		Find(&result).Error
+	return result, err
+}
+
+func (u *repoImpl) MarkDelivered(eventID int64, subscriber string) error {
+	return u.db.Exec(`
+		UPDATE outbox_events SET delivered_to = delivered_to || jsonb_build_array(@subscriber::TEXT)
+		WHERE id = @id AND NOT delivered_to @> jsonb_build_array(@subscriber::TEXT)`,
+		sql.Named("subscriber", subscriber), sql.Named("id", eventID)).Error
+}
+
+func (u *repoImpl) MarkProcessed(eventID int64, at time.Time) error {
+	return u.db.Model(&models.OutboxEvent{}).
+		Where("id = ?", eventID).
+		Update("processed_at", at).Error
+}
+
+func (u *repoImpl) MarkRetry(eventID int64, attempts int, next time.Time, lastError string) error {
+	return u.db.Model(&models.OutboxEvent{}).
+		Where("id = ?", eventID).
+		Updates(map[string]interface{}{
+			"attempts":        attempts,
+			"next_attempt_at": next,
+			"last_error":      lastError,
+		}).Error
+}
+
+func (u *repoImpl) MarkFailed(eventID int64, at time.Time, lastError string) error {
+	return u.db.Model(&models.OutboxEvent{}).
+		Where("id = ?", eventID).
+		Updates(map[string]interface{}{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"failed_at":  at,
+			"last_error": lastError,
+		}).Error
+}
+
+func (u *repoImpl) DeleteProcessedBefore(t time.Time) (int64, error) {
+	res := u.db.Where("processed_at < ?", t).Delete(&models.OutboxEvent{})
+	return res.RowsAffected, res.Error
+}
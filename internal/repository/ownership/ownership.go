@@ -0,0 +1,68 @@
+package ownership
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Resource — тип сущности, у которой есть владелец
+type Resource string
+
+const (
+	Workout     Resource = "workout"
+	Exercise    Resource = "exercise"
+	Set         Resource = "set"
+	Program     Resource = "program"
+	DayType     Resource = "day_type"
+	Measurement Resource = "measurement"
+)
+
+// ownerQueries выбирают только user_id владельца: проверка доступа идет на каждый
+// запрос, поднимать ради нее тренировку со всеми упражнениями незачем
+var ownerQueries = map[Resource]string{
+	Workout: `SELECT user_id FROM workout_days WHERE id = ?`,
+	Exercise: `SELECT wd.user_id FROM exercises e
+		JOIN workout_days wd ON wd.id = e.workout_day_id
+		WHERE e.id = ?`,
+	Set: `SELECT wd.user_id FROM sets s
+		JOIN exercises e ON e.id = s.exercise_id
+		JOIN workout_days wd ON wd.id = e.workout_day_id
+		WHERE s.id = ?`,
+	Program: `SELECT user_id FROM workout_programs WHERE id = ?`,
+	DayType: `SELECT wp.user_id FROM workout_day_types dt
+		JOIN workout_programs wp ON wp.id = dt.workout_program_id
+		WHERE dt.id = ?`,
+	Measurement: `SELECT user_id FROM measurements WHERE id = ?`,
+}
+
+type Repo interface {
+	// OwnerID возвращает владельца сущности или gorm.ErrRecordNotFound, если ее нет
+	OwnerID(resource Resource, id int64) (int64, error)
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+func (u *repoImpl) OwnerID(resource Resource, id int64) (int64, error) {
+	query, ok := ownerQueries[resource]
+	if !ok {
+		return 0, fmt.Errorf("unknown resource %q", resource)
+	}
+
+	var ownerIDs []int64
+	if err := u.db.Raw(query, id).Scan(&ownerIDs).Error; err != nil {
+		return 0, err
+	}
+	if len(ownerIDs) == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return ownerIDs[0], nil
+}
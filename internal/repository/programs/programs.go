@@ -13,6 +13,7 @@ type Repo interface {
 	Get(programID int64) (models.WorkoutProgram, error)
 	Delete(program *models.WorkoutProgram) error
 	FindAll(userID int64) ([]models.WorkoutProgram, error)
+	FindByIDs(ids []int64) ([]models.WorkoutProgram, error)
 }
 
 type repoImpl struct {
@@ -72,3 +73,9 @@ func (u *repoImpl) FindAll(userID int64) (programs []models.WorkoutProgram, err
 
 	return programs, nil
 }
+
+// FindByIDs возвращает программы без дней
+func (u *repoImpl) FindByIDs(ids []int64) (programs []models.WorkoutProgram, err error) {
+	err = u.db.Where("id IN ?", ids).Find(&programs).Error
+	return programs, err
+}
@@ -0,0 +1,77 @@
+package reactions
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+type ReactionCount struct {
+	Emoji string
+	Count int64
+}
+
+type Repo interface {
+	// Set ставит или заменяет реакцию пользователя; created == true, если реакции раньше не было
+	Set(reaction *models.WorkoutReaction) (created bool, err error)
+	Delete(workoutID, userID int64) error
+	GetEmoji(workoutID, userID int64) (string, error)
+	Count(workoutID int64) ([]ReactionCount, error)
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+func (u *repoImpl) Set(reaction *models.WorkoutReaction) (created bool, err error) {
+	err = u.db.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&models.WorkoutReaction{}).
+			Where("workout_day_id = ? AND user_id = ?", reaction.WorkoutDayID, reaction.UserID).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		created = existing == 0
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "workout_day_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"emoji", "created_at"}),
+		}).Create(reaction).Error
+	})
+	return created, err
+}
+
+func (u *repoImpl) Delete(workoutID, userID int64) error {
+	return u.db.Where("workout_day_id = ? AND user_id = ?", workoutID, userID).
+		Delete(&models.WorkoutReaction{}).Error
+}
+
+func (u *repoImpl) GetEmoji(workoutID, userID int64) (string, error) {
+	var reaction models.WorkoutReaction
+	err := u.db.Where("workout_day_id = ? AND user_id = ?", workoutID, userID).First(&reaction).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return reaction.Emoji, nil
+}
+
+func (u *repoImpl) Count(workoutID int64) (counts []ReactionCount, err error) {
+	err = u.db.Model(&models.WorkoutReaction{}).
+		Select("emoji, COUNT(*) AS count").
+		Where("workout_day_id = ?", workoutID).
+		Group("emoji").
+		Order("count DESC, emoji").
+		Scan(&counts).Error
+	return counts, err
+}
@@ -0,0 +1,101 @@
+package records
+
+import (
+	"database/sql"
+
+	"gorm.io/gorm"
+)
+
+// Record — упражнение, в котором тренировка побила лучший вес всех прошлых тренировок пользователя
+type Record struct {
+	ExerciseTypeID int64
+	ExerciseName   string
+	Weight         float64
+	PrevWeight     float64
+}
+
+type Repo interface {
+	FindInWorkout(workoutID int64) ([]Record, error)
+	RecalculateUser(userID int64) error
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+// то же определение рекорда, что и в ленте: первый вес в упражнении рекордом не считается,
+// прошлыми считаются завершенные тренировки, начатые раньше
+const findInWorkoutQuery = `
+WITH target AS (
+	SELECT id, user_id, started_at FROM workout_days WHERE id = @workout AND completed
+),
+current AS (
+	SELECT e.exercise_type_id, MAX(COALESCE(NULLIF(s.fact_weight, 0), s.weight))::FLOAT8 AS weight
+	FROM target t
+	JOIN exercises e ON e.workout_day_id = t.id
+	JOIN sets s ON s.exercise_id = e.id AND s.completed
+	GROUP BY e.exercise_type_id
+),
+previous AS (
+	SELECT e.exercise_type_id, MAX(COALESCE(NULLIF(s.fact_weight, 0), s.weight))::FLOAT8 AS weight
+	FROM target t
+	JOIN workout_days wd ON wd.user_id = t.user_id AND wd.completed
+		AND (wd.started_at, wd.id) < (t.started_at, t.id)
+	JOIN exercises e ON e.workout_day_id = wd.id
+	JOIN sets s ON s.exercise_id = e.id AND s.completed
+	WHERE e.exercise_type_id IN (SELECT exercise_type_id FROM current)
+	GROUP BY e.exercise_type_id
+)
+SELECT c.exercise_type_id, et.name AS exercise_name, c.weight, p.weight AS prev_weight
+FROM current c
+JOIN previous p ON p.exercise_type_id = c.exercise_type_id
+JOIN exercise_types et ON et.id = c.exercise_type_id
+WHERE p.weight > 0 AND c.weight > p.weight
+ORDER BY et.name
+`
+
+func (u *repoImpl) FindInWorkout(workoutID int64) ([]Record, error) {
+	var result []Record
+	err := u.db.Raw(findInWorkoutQuery, sql.Named("workout", workoutID)).Scan(&result).Error
+	return result, err
+}
+
+// рекорды всех тренировок пользователя одним окном по его истории; результат хранится в personal_records,
+// чтобы лента не пересчитывала его на каждый запрос
+const recalculateUserQuery = `
+INSERT INTO personal_records (workout_id, exercise_type_id, user_id, weight, prev_weight)
+SELECT r.workout_id, r.exercise_type_id, r.user_id, r.weight, r.prev_weight
+FROM (
+	SELECT p.*, MAX(p.weight) OVER (
+		PARTITION BY p.exercise_type_id
+		ORDER BY p.started_at, p.workout_id
+		ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) AS prev_weight
+	FROM (
+		SELECT wd.user_id, wd.id AS workout_id, wd.started_at, e.exercise_type_id,
+			MAX(COALESCE(NULLIF(s.fact_weight, 0), s.weight))::FLOAT8 AS weight
+		FROM workout_days wd
+		JOIN exercises e ON e.workout_day_id = wd.id
+		JOIN sets s ON s.exercise_id = e.id AND s.completed
+		WHERE wd.user_id = @user AND wd.completed
+		GROUP BY wd.user_id, wd.id, wd.started_at, e.exercise_type_id
+	) p
+) r
+WHERE r.prev_weight > 0 AND r.weight > r.prev_weight
+`
+
+// RecalculateUser заново собирает рекорды пользователя: правка старой тренировки или запись задним числом
+// меняют прошлый лучший вес для всех следующих
+func (u *repoImpl) RecalculateUser(userID int64) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM personal_records WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		return tx.Exec(recalculateUserQuery, sql.Named("user", userID)).Error
+	})
+}
@@ -0,0 +1,99 @@
+package repotest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+// параметры истории SeedHistory
+const (
+	HistoryExercises     = 6  // упражнений в тренировке: 5 силовых и последнее кардио
+	HistorySets          = 5  // подходов в упражнении
+	HistoryReps          = 10 // повторений в силовом подходе
+	HistoryWeight        = 50 // вес силового подхода, кг
+	HistoryCardioMinutes = 10 // минут в кардио-подходе
+	HistoryCardioMeters  = 1500
+)
+
+// History — ежедневные завершенные часовые тренировки за Days дней, последняя начата в End
+type History struct {
+	UserID    int64
+	DayTypeID int64
+	Days      int
+	End       time.Time
+}
+
+// SeedHistory заводит пользователя с историей за days дней. Строки вставляются запросами
+// над generate_series, поэтому пять лет (~55 тыс. подходов) заливаются за секунды
+func SeedHistory(t testing.TB, db *gorm.DB, days int) History {
+	t.Helper()
+
+	h := History{Days: days, End: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)}
+	user := &models.User{FirstName: "history"}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	h.UserID = user.ID
+	program := &models.WorkoutProgram{UserID: user.ID, Name: "history"}
+	if err := db.Create(program).Error; err != nil {
+		t.Fatalf("seed program: %v", err)
+	}
+	dayType := &models.WorkoutDayType{WorkoutProgramID: program.ID, Name: "history"}
+	if err := db.Create(dayType).Error; err != nil {
+		t.Fatalf("seed day type: %v", err)
+	}
+	h.DayTypeID = dayType.ID
+
+	var types, cardio []int64
+	if err := db.Raw("SELECT id FROM exercise_types WHERE units LIKE '%weight%' ORDER BY id LIMIT ?", HistoryExercises-1).
+		Scan(&types).Error; err != nil || len(types) < HistoryExercises-1 {
+		t.Fatalf("strength exercise types: %v (found %d)", err, len(types))
+	}
+	if err := db.Raw("SELECT id FROM exercise_types WHERE units LIKE '%minutes%' ORDER BY id LIMIT 1").
+		Scan(&cardio).Error; err != nil || len(cardio) == 0 {
+		t.Fatalf("cardio exercise type: %v", err)
+	}
+	types = append(types, cardio[0])
+
+	steps := []struct {
+		name  string
+		query string
+		args  []any
+	}{
+		{"workouts", `INSERT INTO workout_days (user_id, workout_day_type_id, started_at, ended_at, completed)
+			SELECT ?, ?, ts, ts + INTERVAL '1 hour', TRUE
+			FROM generate_series(?::timestamptz, ?::timestamptz, INTERVAL '1 day') AS ts`,
+			[]any{user.ID, dayType.ID, h.End.AddDate(0, 0, 1-days), h.End}},
+		{"exercises", `INSERT INTO exercises (workout_day_id, exercise_type_id, index)
+			SELECT wd.id, t.id, t.ord - 1
+			FROM workout_days wd, unnest(?::bigint[]) WITH ORDINALITY AS t(id, ord)
+			WHERE wd.user_id = ?`,
+			[]any{pq.Array(types), user.ID}},
+		{"sets", `INSERT INTO sets (exercise_id, reps, weight, minutes, meters, completed, completed_at, index)
+			SELECT e.id,
+				CASE WHEN e.exercise_type_id = @cardio THEN 0 ELSE @reps END,
+				CASE WHEN e.exercise_type_id = @cardio THEN 0 ELSE @weight END,
+				CASE WHEN e.exercise_type_id = @cardio THEN @minutes ELSE 0 END,
+				CASE WHEN e.exercise_type_id = @cardio THEN @meters ELSE 0 END,
+				TRUE, wd.ended_at, s.i
+			FROM exercises e
+			JOIN workout_days wd ON wd.id = e.workout_day_id
+			CROSS JOIN generate_series(0, @sets - 1) AS s(i)
+			WHERE wd.user_id = @user`,
+			[]any{map[string]any{
+				"cardio": cardio[0], "reps": HistoryReps, "weight": HistoryWeight, "minutes": HistoryCardioMinutes,
+				"meters": HistoryCardioMeters, "sets": HistorySets, "user": user.ID,
+			}}},
+	}
+	for _, step := range steps {
+		if err := db.Exec(step.query, step.args...).Error; err != nil {
+			t.Fatalf("seed %s: %v", step.name, err)
+		}
+	}
+	return h
+}
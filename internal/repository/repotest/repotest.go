@@ -0,0 +1,97 @@
+// Package repotest поднимает для тестов репозиториев чистую схему в настоящем postgres.
+// База берется из TEST_DATABASE_URL; без нее тесты пропускаются
+package repotest
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/pressly/goose/v3"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Open создает отдельную схему, накатывает в нее все миграции и удаляет ее после теста
+func Open(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL не задан")
+	}
+
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open %s: %v", dsn, err)
+	}
+	defer admin.Close()
+	if _, err = admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		cleanup, err := sql.Open("postgres", dsn)
+		if err != nil {
+			return
+		}
+		defer cleanup.Close()
+		_, _ = cleanup.Exec("DROP SCHEMA " + schema + " CASCADE")
+	})
+
+	scoped, err := withSearchPath(dsn, schema)
+	if err != nil {
+		t.Fatalf("dsn: %v", err)
+	}
+	migrateDB, err := sql.Open("postgres", scoped)
+	if err != nil {
+		t.Fatalf("open %s: %v", scoped, err)
+	}
+	defer migrateDB.Close()
+	if err = goose.SetDialect("postgres"); err != nil {
+		t.Fatalf("goose dialect: %v", err)
+	}
+	goose.SetLogger(goose.NopLogger())
+	if err = goose.Up(migrateDB, migrationsDir()); err != nil {
+		t.Fatalf("goose up: %v", err)
+	}
+
+	db, err := gorm.Open(postgres.Open(scoped), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("gorm open: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// withSearchPath добавляет search_path к dsn в любом из двух форматов: url и key=value
+func withSearchPath(dsn, schema string) (string, error) {
+	if !strings.Contains(dsn, "://") {
+		return dsn + " search_path=" + schema, nil
+	}
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+func migrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "..", "db", "migrations")
+}
@@ -0,0 +1,44 @@
+package revokedtokens
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+type Repo interface {
+	Add(tokens []models.RevokedToken) error
+	FindCreatedSince(since time.Time) ([]models.RevokedToken, error)
+	DeleteExpired(now time.Time) error
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+func (u *repoImpl) Add(tokens []models.RevokedToken) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	return u.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&tokens).Error
+}
+
+func (u *repoImpl) FindCreatedSince(since time.Time) (tokens []models.RevokedToken, err error) {
+	err = u.db.
+		Where("created_at >= ? AND expires_at > ?", since, time.Now()).
+		Find(&tokens).Error
+	return tokens, err
+}
+
+func (u *repoImpl) DeleteExpired(now time.Time) error {
+	return u.db.Where("expires_at <= ?", now).Delete(&models.RevokedToken{}).Error
+}
@@ -0,0 +1,167 @@
+package rollups
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"gorm.io/gorm"
+)
+
+type Repo interface {
+	RecalculateDay(userID int64, at time.Time) error
+	RecalculateUser(userID int64) error
+	FindDaily(userID int64, from, to *time.Time) ([]models.StatsRollup, error)
+	FindWeekly(userID int64, from, to *time.Time) ([]models.StatsRollup, error)
+	FindUserIDs(afterID int64, limit int) ([]int64, error)
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+// день считается по Москве, как и во всей статистике бота
+const localDay = `(wd.started_at AT TIME ZONE 'Europe/Moscow')::DATE`
+
+// дневные строки из подходов: фактические значения важнее плановых, e1RM по формуле Эпли, как в челленджах.
+// @from и @to — даты YYYY-MM-DD, включительно и исключительно, NULL — без границы
+const insertDailyQuery = `
+INSERT INTO stats_daily_rollups (user_id, period, exercise_type_id, group_code,
+	workouts, sets, reps, tonnage, minutes, meters, max_weight, max_weight_reps, best_e1rm,
+	min_minutes, max_minutes, min_meters, max_meters, updated_at)
+SELECT wd.user_id, ` + localDay + `, e.exercise_type_id, COALESCE(et.exercise_group_type_code, ''),
+	COUNT(DISTINCT wd.id), COUNT(*), SUM(v.reps), SUM(v.reps * v.weight), SUM(v.minutes), SUM(v.meters),
+	MAX(v.weight), (ARRAY_AGG(v.reps ORDER BY v.weight DESC, v.reps DESC))[1],
+	MAX(CASE WHEN v.reps > 0 THEN v.weight * CASE WHEN v.reps = 1 THEN 1 ELSE 1 + v.reps / 30.0 END ELSE 0 END),
+	COALESCE(MIN(NULLIF(v.minutes, 0)), 0), MAX(v.minutes), COALESCE(MIN(NULLIF(v.meters, 0)), 0), MAX(v.meters), NOW()
+FROM workout_days wd
+JOIN exercises e ON e.workout_day_id = wd.id
+JOIN exercise_types et ON et.id = e.exercise_type_id
+JOIN sets s ON s.exercise_id = e.id AND s.completed
+CROSS JOIN LATERAL (SELECT
+	COALESCE(NULLIF(s.fact_reps, 0), s.reps, 0) AS reps,
+	COALESCE(NULLIF(s.fact_weight, 0), s.weight, 0) AS weight,
+	COALESCE(NULLIF(s.fact_minutes, 0), s.minutes, 0) AS minutes,
+	COALESCE(NULLIF(s.fact_meters, 0), s.meters, 0) AS meters) v
+WHERE wd.user_id = @user
+	AND (CAST(@from AS DATE) IS NULL OR ` + localDay + ` >= CAST(@from AS DATE))
+	AND (CAST(@to AS DATE) IS NULL OR ` + localDay + ` < CAST(@to AS DATE))
+GROUP BY wd.user_id, ` + localDay + `, e.exercise_type_id, et.exercise_group_type_code`
+
+// недельные строки складываются из дневных; неделя начинается в понедельник
+const insertWeeklyQuery = `
+INSERT INTO stats_weekly_rollups (user_id, period, exercise_type_id, group_code,
+	workouts, sets, reps, tonnage, minutes, meters, max_weight, max_weight_reps, best_e1rm,
+	min_minutes, max_minutes, min_meters, max_meters, updated_at)
+SELECT user_id, DATE_TRUNC('week', period)::DATE, exercise_type_id, MAX(group_code),
+	SUM(workouts), SUM(sets), SUM(reps), SUM(tonnage), SUM(minutes), SUM(meters),
+	MAX(max_weight), (ARRAY_AGG(max_weight_reps ORDER BY max_weight DESC, max_weight_reps DESC))[1], MAX(best_e1rm),
+	COALESCE(MIN(NULLIF(min_minutes, 0)), 0), MAX(max_minutes), COALESCE(MIN(NULLIF(min_meters, 0)), 0), MAX(max_meters), NOW()
+FROM stats_daily_rollups
+WHERE user_id = @user
+	AND (CAST(@from AS DATE) IS NULL OR period >= CAST(@from AS DATE))
+	AND (CAST(@to AS DATE) IS NULL OR period < CAST(@to AS DATE))
+GROUP BY user_id, DATE_TRUNC('week', period), exercise_type_id`
+
+// RecalculateDay пересчитывает день по Москве, в который попадает at, и всю его неделю.
+// Пересчет идет из подходов целиком, поэтому повторный вызов безопасен
+func (u *repoImpl) RecalculateDay(userID int64, at time.Time) error {
+	day := LocalDay(at)
+	week := WeekStart(day)
+	return u.recalculate(userID,
+		period{from: date(day), to: date(day.AddDate(0, 0, 1))},
+		period{from: date(week), to: date(week.AddDate(0, 0, 7))})
+}
+
+// RecalculateUser пересобирает все строки пользователя — для backfill и после правок задним числом
+func (u *repoImpl) RecalculateUser(userID int64) error {
+	return u.recalculate(userID, period{}, period{})
+}
+
+// period — полуинтервал дат YYYY-MM-DD; nil — без границы
+type period struct {
+	from, to any
+}
+
+func date(t time.Time) any {
+	return t.Format(time.DateOnly)
+}
+
+// recalculate сначала удаляет строки периода: если подходы отменили, новой строки не будет
+func (u *repoImpl) recalculate(userID int64, days, weeks period) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteRange(tx, models.StatsDailyRollupsTable, userID, days); err != nil {
+			return err
+		}
+		if err := tx.Exec(insertDailyQuery, sql.Named("user", userID), sql.Named("from", days.from), sql.Named("to", days.to)).Error; err != nil {
+			return err
+		}
+		if err := deleteRange(tx, models.StatsWeeklyRollupsTable, userID, weeks); err != nil {
+			return err
+		}
+		return tx.Exec(insertWeeklyQuery, sql.Named("user", userID), sql.Named("from", weeks.from), sql.Named("to", weeks.to)).Error
+	})
+}
+
+func deleteRange(tx *gorm.DB, table string, userID int64, p period) error {
+	query := tx.Table(table).Where("user_id = ?", userID)
+	if p.from != nil {
+		query = query.Where("period >= CAST(? AS DATE) AND period < CAST(? AS DATE)", p.from, p.to)
+	}
+	return query.Delete(&models.StatsRollup{}).Error
+}
+
+// FindDaily возвращает дневные строки пользователя с типом упражнения, по возрастанию даты; границы как в FindWeekly
+func (u *repoImpl) FindDaily(userID int64, from, to *time.Time) ([]models.StatsRollup, error) {
+	return u.find(models.StatsDailyRollupsTable, userID, from, to)
+}
+
+// FindWeekly возвращает недельные строки пользователя, from включительно, to исключительно, nil — без границы
+func (u *repoImpl) FindWeekly(userID int64, from, to *time.Time) ([]models.StatsRollup, error) {
+	return u.find(models.StatsWeeklyRollupsTable, userID, from, to)
+}
+
+func (u *repoImpl) find(table string, userID int64, from, to *time.Time) (result []models.StatsRollup, err error) {
+	query := u.db.Table(table).Where("user_id = ?", userID)
+	if from != nil {
+		query = query.Where("period >= CAST(? AS DATE)", date(LocalDay(*from)))
+	}
+	if to != nil {
+		query = query.Where("period < CAST(? AS DATE)", date(LocalDay(*to)))
+	}
+	err = query.
+		Preload("ExerciseType").
+		Order("period ASC, exercise_type_id ASC").
+		Find(&result).Error
+	return result, err
+}
+
+// FindUserIDs — пользователи с тренировками, по возрастанию id, после afterID
+func (u *repoImpl) FindUserIDs(afterID int64, limit int) (ids []int64, err error) {
+	err = u.db.Model(&models.WorkoutDay{}).
+		Distinct("user_id").
+		Where("user_id > ?", afterID).
+		Order("user_id ASC").
+		Limit(limit).
+		Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+var moscow = time.FixedZone("MSK", 3*60*60)
+
+// LocalDay — дата по Москве в виде полуночи UTC, как ее возвращает колонка DATE
+func LocalDay(t time.Time) time.Time {
+	y, m, d := t.In(moscow).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// WeekStart — понедельник недели дня day
+func WeekStart(day time.Time) time.Time {
+	return day.AddDate(0, 0, -int((day.Weekday()+6)%7))
+}
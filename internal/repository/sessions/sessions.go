@@ -1,12 +1,14 @@
 package sessions
 
 import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/outbox"
 	"gorm.io/gorm"
 )
 
 type Repo interface {
-	Create(session *models.WorkoutSession) error
+	Create(session *models.WorkoutSession, evts ...events.Event) error
 	GetByWorkoutID(workoutID int64) (models.WorkoutSession, error)
 	Save(session *models.WorkoutSession) error
 	UpdateIsActive(workoutID int64, isActive bool) error
@@ -22,9 +24,12 @@ func NewRepo(db *gorm.DB) Repo {
 	}
 }
 
-func (u *repoImpl) Create(session *models.WorkoutSession) error {
+func (u *repoImpl) Create(session *models.WorkoutSession, evts ...events.Event) error {
 	return u.db.Transaction(func(tx *gorm.DB) error {
-		return tx.Create(&session).Error
+		if err := tx.Create(&session).Error; err != nil {
+			return err
+		}
+		return outbox.Append(tx, evts...)
 	})
 }
 
@@ -1,15 +1,19 @@
 package sets
 
 import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/outbox"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/versioning"
 	"gorm.io/gorm"
 )
 
 type Repo interface {
 	Get(id int64) (*models.Set, error)
-	Delete(id int64) error
+	FindByExerciseIDs(exerciseIDs []int64) ([]models.Set, error)
+	Delete(id int64, evts ...events.Event) error
 	DeleteAllBy(exerciseID int64) error
-	Save(set *models.Set) error
+	Save(set *models.Set, evts ...events.Event) error
 }
 
 type repoImpl struct {
@@ -24,13 +28,23 @@ func NewRepo(db *gorm.DB) Repo {
 
 func (u *repoImpl) Get(id int64) (*models.Set, error) {
 	var set models.Set
-	u.db.Preload("Exercise").Preload("Exercise.ExerciseType").First(&set, id)
+	u.db.Preload("Exercise").Preload("Exercise.ExerciseType").Preload("Exercise.WorkoutDay").First(&set, id)
 	return &set, nil
 }
 
-func (u *repoImpl) Delete(id int64) error {
+func (u *repoImpl) Delete(id int64, evts ...events.Event) error {
 	return u.db.Transaction(func(tx *gorm.DB) error {
-		return tx.Where("id = ?", id).Delete(&models.Set{}).Error
+		var set models.Set
+		if err := tx.First(&set, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id = ?", id).Delete(&models.Set{}).Error; err != nil {
+			return err
+		}
+		if err := versioning.Touch(tx, &models.Exercise{}, set.ExerciseID); err != nil {
+			return err
+		}
+		return outbox.Append(tx, evts...)
 	})
 }
 
@@ -40,8 +54,29 @@ func (u *repoImpl) DeleteAllBy(exerciseID int64) error {
 	})
 }
 
-func (u *repoImpl) Save(set *models.Set) error {
+// Save создает подход или обновляет его с проверкой версии,
+// любое изменение подхода также увеличивает версию упражнения
+func (u *repoImpl) Save(set *models.Set, evts ...events.Event) error {
 	return u.db.Transaction(func(tx *gorm.DB) error {
-		return tx.Save(&set).Error
+		if set.ID == 0 {
+			if err := tx.Create(set).Error; err != nil {
+				return err
+			}
+		} else if err := versioning.Update(tx, set, set.ID, &set.Version); err != nil {
+			return err
+		}
+		if err := versioning.Touch(tx, &models.Exercise{}, set.ExerciseID); err != nil {
+			return err
+		}
+		return outbox.Append(tx, evts...)
 	})
 }
+
+// FindByExerciseIDs возвращает подходы нескольких упражнений в порядке выполнения
+func (u *repoImpl) FindByExerciseIDs(exerciseIDs []int64) (sets []models.Set, err error) {
+	err = u.db.Where("exercise_id IN ?", exerciseIDs).
+		Order("exercise_id ASC").
+		Order("index ASC").
+		Find(&sets).Error
+	return sets, err
+}
@@ -10,6 +10,7 @@ type Repo interface {
 	Create(share *models.WorkoutShare) error
 	Get(token string) (share models.WorkoutShare, err error)
 	GetByWorkoutID(workoutID int64) (share models.WorkoutShare, err error)
+	SetCommentsEnabled(workoutID int64, enabled bool) error
 }
 
 type repoImpl struct {
@@ -43,3 +44,9 @@ func (u *repoImpl) Create(share *models.WorkoutShare) error {
 		return tx.Create(&share).Error
 	})
 }
+
+func (u *repoImpl) SetCommentsEnabled(workoutID int64, enabled bool) error {
+	return u.db.Model(&models.WorkoutShare{}).
+		Where("workout_day_id = ?", workoutID).
+		Update("comments_enabled", enabled).Error
+}
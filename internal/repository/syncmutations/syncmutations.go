@@ -0,0 +1,57 @@
+package syncmutations
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+type Repo interface {
+	Claim(mutation *models.SyncMutation) (bool, error)
+	SetResult(mutation *models.SyncMutation) error
+	FindByClientIDs(userID int64, clientIDs []string) ([]models.SyncMutation, error)
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+// Claim записывает мутацию, если ее client_id у пользователя еще не встречался, и возвращает false для повтора.
+// Параллельный повтор ждет на уникальном индексе, пока транзакция первого запроса не завершится
+func (u *repoImpl) Claim(mutation *models.SyncMutation) (bool, error) {
+	res := u.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "client_id"}},
+		DoNothing: true,
+	}).Create(mutation)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// SetResult сохраняет итог применения занятой мутации
+func (u *repoImpl) SetResult(mutation *models.SyncMutation) error {
+	return u.db.Model(&models.SyncMutation{}).
+		Where("id = ?", mutation.ID).
+		Updates(map[string]any{
+			"status":    mutation.Status,
+			"entity_id": mutation.EntityID,
+		}).Error
+}
+
+func (u *repoImpl) FindByClientIDs(userID int64, clientIDs []string) (mutations []models.SyncMutation, err error) {
+	if len(clientIDs) == 0 {
+		return nil, nil
+	}
+	err = u.db.
+		Where("user_id = ? AND client_id IN ?", userID, clientIDs).
+		Find(&mutations).Error
+	return mutations, err
+}
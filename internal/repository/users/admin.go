@@ -0,0 +1,113 @@
+package users
+
+import (
+	"strings"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+// UsageStats — сводка по использованию сервиса для админки
+type UsageStats struct {
+	UsersTotal        int64
+	UsersNew30d       int64
+	UsersDisabled     int64
+	ActiveUsers7d     int64
+	ActiveUsers30d    int64
+	WorkoutsTotal     int64
+	WorkoutsCompleted int64
+	Workouts7d        int64
+	UsersByRole       map[string]int64
+}
+
+func (u *repoImpl) Search(query string, offset, limit int) ([]UserWithCount, int64, error) {
+	tx := u.db.Table("users")
+	if query = strings.TrimSpace(query); query != "" {
+		pattern := "%" + escapeLike(strings.ToLower(query)) + "%"
+		tx = tx.Where(`LOWER(users.username) LIKE ? OR LOWER(users.first_name) LIKE ? OR LOWER(users.last_name) LIKE ?
+			OR LOWER(users.email) LIKE ? OR LOWER(users.yandex_login) LIKE ? OR CAST(users.id AS TEXT) = ?`,
+			pattern, pattern, pattern, pattern, pattern, query)
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []UserWithCount
+	err := tx.
+		Select("users.*, COUNT(w.id) as workout_count").
+		Joins(`
+        LEFT JOIN workout_days w 
+        ON w.user_id = users.id 
+        AND w.completed = true
+    `).
+		Group("users.id").
+		Order("users.id ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+func (u *repoImpl) SetRole(userID int64, role string) error {
+	return u.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("role", role).Error
+}
+
+// SetDisabled блокирует (at != nil) или разблокирует аккаунт
+func (u *repoImpl) SetDisabled(userID int64, at *time.Time) error {
+	return u.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("disabled_at", at).Error
+}
+
+func (u *repoImpl) UsageStats(now time.Time) (*UsageStats, error) {
+	stats := &UsageStats{UsersByRole: make(map[string]int64)}
+	weekAgo := now.AddDate(0, 0, -7)
+	monthAgo := now.AddDate(0, 0, -30)
+
+	err := u.db.Raw(`
+		SELECT
+			COUNT(*)                                         AS users_total,
+			COUNT(*) FILTER (WHERE created_at >= ?)          AS users_new30d,
+			COUNT(*) FILTER (WHERE disabled_at IS NOT NULL)  AS users_disabled
+		FROM users`, monthAgo).
+		Row().Scan(&stats.UsersTotal, &stats.UsersNew30d, &stats.UsersDisabled)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.db.Raw(`
+		SELECT
+			COUNT(DISTINCT user_id) FILTER (WHERE started_at >= ?) AS active7d,
+			COUNT(DISTINCT user_id) FILTER (WHERE started_at >= ?) AS active30d,
+			COUNT(*)                                               AS workouts_total,
+			COUNT(*) FILTER (WHERE completed)                      AS workouts_completed,
+			COUNT(*) FILTER (WHERE started_at >= ?)                AS workouts7d
+		FROM workout_days`, weekAgo, monthAgo, weekAgo).
+		Row().Scan(&stats.ActiveUsers7d, &stats.ActiveUsers30d, &stats.WorkoutsTotal, &stats.WorkoutsCompleted, &stats.Workouts7d)
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []struct {
+		Role  string
+		Count int64
+	}
+	if err = u.db.Table("users").Select("role, COUNT(*) AS count").Group("role").Scan(&roles).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range roles {
+		stats.UsersByRole[r.Role] = r.Count
+	}
+	return stats, nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,226 @@
+package users
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/outbox"
+	"gorm.io/gorm"
+)
+
+// таблицы, записи которых переезжают к основному пользователю при слиянии аккаунтов
+var userOwnedTables = []string{
+	"workout_days",
+	"workout_programs",
+	"measurements",
+	"push_subscriptions",
+	"rest_timers",
+	"sync_mutations",
+	"workout_edits",
+	"user_identities",
+	"auth_sessions",
+	"user_credentials",
+	"workout_reactions",
+	"webhooks",
+	"personal_access_tokens",
+}
+
+// ссылки на пользователя в колонках с другими названиями
+var userReferenceColumns = []struct{ table, column string }{
+	{"coach_links", "coach_id"},
+	{"coach_links", "athlete_id"},
+	{"workout_comments", "author_id"},
+	{"follows", "follower_id"},
+	{"follows", "followee_id"},
+	{"challenges", "owner_id"},
+	{"challenge_participants", "user_id"},
+	{"challenge_contributions", "user_id"},
+}
+
+func (u *repoImpl) LinkTelegram(userID int64, tgUser dto.TelegramUser) error {
+	return u.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"chat_id":  tgUser.ID,
+			"username": tgUser.Username,
+		}).Error
+}
+
+func (u *repoImpl) LinkYandex(userID int64, profile *dto.YandexProfile) error {
+	return u.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"yandex_id":    profile.ID,
+			"yandex_login": profile.Login,
+		}).Error
+}
+
+func (u *repoImpl) UnlinkTelegram(userID int64) error {
+	return u.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"chat_id":  nil,
+			"username": "",
+		}).Error
+}
+
+// UnlinkYandex пишет NULL, а не пустую строку: yandex_id уникален
+func (u *repoImpl) UnlinkYandex(userID int64) error {
+	return u.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"yandex_id":    nil,
+			"yandex_login": "",
+		}).Error
+}
+
+func (u *repoImpl) HasEmailCredential(userID int64) (bool, error) {
+	var count int64
+	err := u.db.Table("user_credentials").Where("user_id = ?", userID).Count(&count).Error
+	return count > 0, err
+}
+
+func (u *repoImpl) UnlinkEmail(userID int64) error {
+	return u.db.Exec("DELETE FROM user_credentials WHERE user_id = ?", userID).Error
+}
+
+// Merge переносит все данные пользователя sourceID к targetID, забирает его
+// привязки к провайдерам и удаляет исходного пользователя. События пишутся в outbox той же транзакцией
+func (u *repoImpl) Merge(sourceID, targetID int64, evts ...events.Event) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		var source, target models.User
+		if err := tx.Where("id = ?", sourceID).First(&source).Error; err != nil {
+			return NotFoundUserErr
+		}
+		if err := tx.Where("id = ?", targetID).First(&target).Error; err != nil {
+			return NotFoundUserErr
+		}
+
+		// одна и та же подписка браузера не может принадлежать двум пользователям
+		if err := tx.Exec(`
+			DELETE FROM push_subscriptions
+			WHERE user_id = ? AND endpoint IN (SELECT endpoint FROM push_subscriptions WHERE user_id = ?)`,
+			sourceID, targetID).Error; err != nil {
+			return err
+		}
+
+		// у пользователя может быть только один локальный вход, оставляем вход основного
+		if err := tx.Exec(`
+			DELETE FROM user_credentials
+			WHERE user_id = ? AND EXISTS (SELECT 1 FROM user_credentials WHERE user_id = ?)`,
+			sourceID, targetID).Error; err != nil {
+			return err
+		}
+
+		// одна и та же связь с тренером у обоих аккаунтов нарушила бы уникальность активных связей
+		if err := tx.Exec(`
+			UPDATE coach_links SET status = 'revoked', revoked_at = NOW()
+			WHERE status = 'active' AND (
+				(athlete_id = ? AND coach_id IN (SELECT coach_id FROM coach_links WHERE status = 'active' AND athlete_id = ?))
+				OR (coach_id = ? AND athlete_id IN (SELECT athlete_id FROM coach_links WHERE status = 'active' AND coach_id = ?)))`,
+			sourceID, targetID, sourceID, targetID).Error; err != nil {
+			return err
+		}
+
+		// подписки, которые уже есть у основного аккаунта, нарушили бы первичный ключ
+		if err := tx.Exec(`
+			DELETE FROM follows s
+			WHERE (s.follower_id = ? AND EXISTS (
+					SELECT 1 FROM follows t WHERE t.follower_id = ? AND t.followee_id = s.followee_id))
+				OR (s.followee_id = ? AND EXISTS (
+					SELECT 1 FROM follows t WHERE t.followee_id = ? AND t.follower_id = s.follower_id))
+				OR (s.follower_id = ? AND s.followee_id = ?)
+				OR (s.follower_id = ? AND s.followee_id = ?)`,
+			sourceID, targetID, sourceID, targetID, sourceID, targetID, targetID, sourceID).Error; err != nil {
+			return err
+		}
+
+		// на одну тренировку у пользователя одна реакция, оставляем реакцию основного
+		if err := tx.Exec(`
+			DELETE FROM workout_reactions
+			WHERE user_id = ? AND workout_day_id IN (SELECT workout_day_id FROM workout_reactions WHERE user_id = ?)`,
+			sourceID, targetID).Error; err != nil {
+			return err
+		}
+
+		// в челлендже, где участвуют оба аккаунта, остается участие основного; результат пересчитается при подведении итогов
+		if err := tx.Exec(`
+			DELETE FROM challenge_participants
+			WHERE user_id = ? AND challenge_id IN (SELECT challenge_id FROM challenge_participants WHERE user_id = ?)`,
+			sourceID, targetID).Error; err != nil {
+			return err
+		}
+
+		// значки считаются по истории: основной аккаунт получит их заново по объединенной истории
+		if err := tx.Exec(`DELETE FROM user_achievements WHERE user_id = ?`, sourceID).Error; err != nil {
+			return err
+		}
+
+		for _, table := range userOwnedTables {
+			if err := tx.Table(table).
+				Where("user_id = ?", sourceID).
+				Update("user_id", targetID).Error; err != nil {
+				return err
+			}
+		}
+		for _, ref := range userReferenceColumns {
+			if err := tx.Table(ref.table).
+				Where(ref.column+" = ?", sourceID).
+				Update(ref.column, targetID).Error; err != nil {
+				return err
+			}
+		}
+		// тренер не может быть тренером самому себе
+		if err := tx.Exec(`
+			UPDATE coach_links SET status = 'revoked', revoked_at = NOW()
+			WHERE status = 'active' AND coach_id = athlete_id`).Error; err != nil {
+			return err
+		}
+
+		// сначала освобождаем уникальные привязки у исходного пользователя
+		if err := tx.Model(&models.User{}).
+			Where("id = ?", sourceID).
+			Updates(map[string]interface{}{
+				"chat_id":           nil,
+				"yandex_id":         nil,
+				"active_program_id": nil,
+			}).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		if target.ChatID == 0 && source.ChatID != 0 {
+			updates["chat_id"] = source.ChatID
+			updates["username"] = source.Username
+		}
+		if target.YandexID == "" && source.YandexID != "" {
+			updates["yandex_id"] = source.YandexID
+			updates["yandex_login"] = source.YandexLogin
+		}
+		if target.ActiveProgramID == nil && source.ActiveProgramID != nil {
+			updates["active_program_id"] = *source.ActiveProgramID
+		}
+		if target.Email == "" && source.Email != "" {
+			updates["email"] = source.Email
+		}
+		if target.FirstName == "" && source.FirstName != "" {
+			updates["first_name"] = source.FirstName
+			updates["last_name"] = source.LastName
+		}
+		if target.Icon == "" && source.Icon != "" {
+			updates["icon"] = source.Icon
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(&models.User{}).
+				Where("id = ?", targetID).
+				Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Delete(&models.User{}, sourceID).Error; err != nil {
+			return err
+		}
+		return outbox.Append(tx, evts...)
+	})
+}
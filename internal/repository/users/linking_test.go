@@ -0,0 +1,120 @@
+package users_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/repotest"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/users"
+)
+
+type seeder struct {
+	t  *testing.T
+	db *gorm.DB
+}
+
+// user создает пользователя; пустой yandex_id пишется как NULL, иначе двое без Яндекса нарушат уникальность
+func (s seeder) user(u models.User) int64 {
+	query := s.db
+	if u.YandexID == "" {
+		query = query.Omit("YandexID")
+	}
+	require.NoError(s.t, query.Create(&u).Error)
+	return u.ID
+}
+
+func (s seeder) workout(userID int64) int64 {
+	program := &models.WorkoutProgram{UserID: userID, Name: "program"}
+	require.NoError(s.t, s.db.Create(program).Error)
+	dayType := &models.WorkoutDayType{WorkoutProgramID: program.ID, Name: "day"}
+	require.NoError(s.t, s.db.Create(dayType).Error)
+	workout := &models.WorkoutDay{UserID: userID, WorkoutDayTypeID: dayType.ID, StartedAt: time.Now().Add(-time.Hour)}
+	require.NoError(s.t, s.db.Create(workout).Error)
+	return workout.ID
+}
+
+func (s seeder) create(values ...any) {
+	for _, v := range values {
+		require.NoError(s.t, s.db.Create(v).Error)
+	}
+}
+
+func (s seeder) count(query string, args ...any) int {
+	var n int
+	require.NoError(s.t, s.db.Raw(query, args...).Scan(&n).Error)
+	return n
+}
+
+func activeCoaching(coachID, athleteID int64) *models.CoachLink {
+	return &models.CoachLink{CoachID: coachID, AthleteID: &athleteID, Status: models.CoachLinkActive,
+		Scopes: "workouts", ExpiresAt: time.Now().Add(time.Hour)}
+}
+
+// TestMerge вливает аккаунт, у которого с основным общие подписки, тренер и реакции,
+// и проверяет, что данные переехали, дубли не нарушили ограничения, а событие записано
+func TestMerge(t *testing.T) {
+	s := seeder{t: t, db: repotest.Open(t)}
+	repo := users.NewRepo(s.db)
+
+	target := s.user(models.User{FirstName: "target", YandexID: "ya-target"})
+	source := s.user(models.User{FirstName: "source", ChatID: 4242, Username: "source_tg"})
+	coach := s.user(models.User{FirstName: "coach", YandexID: "ya-coach"})
+	friend := s.user(models.User{FirstName: "friend", YandexID: "ya-friend"})
+
+	s.workout(source)
+	friendWorkout := s.workout(friend)
+	s.create(
+		&models.Measurement{UserID: source, CreatedAt: time.Now()},
+		// на friend подписаны оба аккаунта, а source подписан на target
+		&models.Follow{FollowerID: source, FolloweeID: friend, Status: models.FollowAccepted},
+		&models.Follow{FollowerID: target, FolloweeID: friend, Status: models.FollowAccepted},
+		&models.Follow{FollowerID: source, FolloweeID: target, Status: models.FollowAccepted},
+		// один тренер у обоих аккаунтов, и target тренирует source
+		activeCoaching(coach, source),
+		activeCoaching(coach, target),
+		activeCoaching(target, source),
+		&models.WorkoutReaction{WorkoutDayID: friendWorkout, UserID: source, Emoji: "🔥"},
+		&models.WorkoutReaction{WorkoutDayID: friendWorkout, UserID: target, Emoji: "💪"},
+		&models.UserAchievement{UserID: source, Code: "first_workout", EarnedAt: time.Now()},
+	)
+
+	require.NoError(t, repo.Merge(source, target, events.UsersMerged{UserID: target, SourceID: source}))
+
+	_, err := repo.GetByID(source)
+	assert.ErrorIs(t, err, users.NotFoundUserErr, "влитый аккаунт удален")
+	merged, err := repo.GetByID(target)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4242, merged.ChatID, "привязка телеграма переехала")
+	assert.Equal(t, "source_tg", merged.Username)
+	assert.Equal(t, "ya-target", merged.YandexID, "своя привязка осталась")
+	assert.Equal(t, "target", merged.FirstName)
+
+	assert.Equal(t, 1, s.count("SELECT COUNT(*) FROM workout_days WHERE user_id = ?", target))
+	assert.Equal(t, 1, s.count("SELECT COUNT(*) FROM measurements WHERE user_id = ?", target))
+	assert.Equal(t, 1, s.count("SELECT COUNT(*) FROM follows WHERE follower_id = ?", target),
+		"дубль подписки и подписка на себя удалены")
+	assert.Equal(t, 1, s.count("SELECT COUNT(*) FROM coach_links WHERE status = 'active'"),
+		"остался только тренер основного аккаунта")
+	assert.Equal(t, 0, s.count("SELECT COUNT(*) FROM coach_links WHERE coach_id = athlete_id AND status = 'active'"))
+	assert.Equal(t, 1, s.count("SELECT COUNT(*) FROM workout_reactions WHERE user_id = ?", target),
+		"реакция основного аккаунта")
+	assert.Equal(t, 0, s.count("SELECT COUNT(*) FROM user_achievements"), "значки выдадутся заново по объединенной истории")
+	assert.Equal(t, 1, s.count("SELECT COUNT(*) FROM outbox_events WHERE type = ? AND user_id = ?",
+		events.TypeUsersMerged, target))
+}
+
+func TestMergeUnknownUser(t *testing.T) {
+	s := seeder{t: t, db: repotest.Open(t)}
+	repo := users.NewRepo(s.db)
+	target := s.user(models.User{FirstName: "target"})
+
+	err := repo.Merge(target+1, target, events.UsersMerged{UserID: target, SourceID: target + 1})
+	assert.ErrorIs(t, err, users.NotFoundUserErr)
+	assert.Equal(t, 0, s.count("SELECT COUNT(*) FROM outbox_events"), "событие откатилось вместе с транзакцией")
+}
@@ -0,0 +1,36 @@
+package users
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+func (u *repoImpl) SetPrivacy(userID int64, workoutsVisibility, recordsVisibility string, feedDigest bool) error {
+	return u.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"workouts_visibility": workoutsVisibility,
+			"records_visibility":  recordsVisibility,
+			"feed_digest":         feedDigest,
+		}).Error
+}
+
+// FindDigestRecipients — пользователи с телеграмом и хотя бы одной подпиской, которым сводка не уходила с sentBefore
+func (u *repoImpl) FindDigestRecipients(sentBefore time.Time, limit int) ([]models.User, error) {
+	var result []models.User
+	err := u.db.
+		Where("feed_digest AND disabled_at IS NULL AND chat_id IS NOT NULL AND chat_id <> 0").
+		Where("feed_digest_sent_at IS NULL OR feed_digest_sent_at < ?", sentBefore).
+		Where("EXISTS (SELECT 1 FROM follows f WHERE f.follower_id = users.id AND f.status = ?)", models.FollowAccepted).
+		Order("id").
+		Limit(limit).
+		Find(&result).Error
+	return result, err
+}
+
+func (u *repoImpl) MarkDigestSent(userID int64, at time.Time) error {
+	return u.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("feed_digest_sent_at", at).Error
+}
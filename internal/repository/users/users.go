@@ -7,14 +7,16 @@ import (
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/outbox"
 	"gorm.io/gorm"
 )
 
 type Repo interface {
 	GetTop10() ([]models.User, error)
 	FindTopN(offset, limit int) ([]UserWithCount, error)
-	Save(user *models.User) error
+	Save(user *models.User, evts ...events.Event) error
 	GetByID(ID int64) (*models.User, error)
 	ChangeIcon(userID int64, name string) error
 
@@ -27,6 +29,33 @@ type Repo interface {
 
 	CreateYandex(profile *dto.YandexProfile) (*models.User, error)
 	GetByYandexID(chatID string) (*models.User, error)
+
+	// ----- oidc -----
+
+	CreateWithIdentity(user *models.User, identity *models.UserIdentity) error
+
+	// ----- linking -----
+
+	LinkTelegram(userID int64, tgUser dto.TelegramUser) error
+	LinkYandex(userID int64, profile *dto.YandexProfile) error
+	UnlinkTelegram(userID int64) error
+	UnlinkYandex(userID int64) error
+	HasEmailCredential(userID int64) (bool, error)
+	UnlinkEmail(userID int64) error
+	Merge(sourceID, targetID int64, evts ...events.Event) error
+
+	// ----- admin -----
+
+	Search(query string, offset, limit int) ([]UserWithCount, int64, error)
+	SetRole(userID int64, role string) error
+	SetDisabled(userID int64, at *time.Time) error
+	UsageStats(now time.Time) (*UsageStats, error)
+
+	// ----- social -----
+
+	SetPrivacy(userID int64, workoutsVisibility, recordsVisibility string, feedDigest bool) error
+	FindDigestRecipients(sentBefore time.Time, limit int) ([]models.User, error)
+	MarkDigestSent(userID int64, at time.Time) error
 }
 
 type repoImpl struct {
@@ -39,9 +68,17 @@ func NewRepo(db *gorm.DB) Repo {
 	}
 }
 
-func (u *repoImpl) Save(user *models.User) error {
-	u.db.Save(user)
-	return nil
+func (u *repoImpl) Save(user *models.User, evts ...events.Event) error {
+	if len(evts) == 0 {
+		u.db.Save(user)
+		return nil
+	}
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(user).Error; err != nil {
+			return err
+		}
+		return outbox.Append(tx, evts...)
+	})
 }
 
 func (u *repoImpl) CreateTelegram(from *tgbotapi.User) (*models.User, error) {
@@ -74,6 +111,17 @@ func (u *repoImpl) CreateYandex(profile *dto.YandexProfile) (*models.User, error
 	return &user, err
 }
 
+// CreateWithIdentity создает пользователя сразу вместе с привязкой к OIDC провайдеру
+func (u *repoImpl) CreateWithIdentity(user *models.User, identity *models.UserIdentity) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		identity.UserID = user.ID
+		return tx.Create(identity).Error
+	})
+}
+
 var (
 	NotFoundUserErr = errors.New("not found user")
 )
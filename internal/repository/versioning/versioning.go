@@ -0,0 +1,53 @@
+package versioning
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ConflictErr = errors.New("version conflict")
+)
+
+// Update сохраняет модель, только если версия в базе совпадает с версией модели,
+// и увеличивает версию. Если запись успели изменить, возвращает ConflictErr.
+func Update(tx *gorm.DB, model any, id int64, version *int64) error {
+	expected := *version
+	*version = expected + 1
+
+	res := tx.Model(model).
+		Where("id = ? AND version = ?", id, expected).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(model)
+	if res.Error != nil {
+		*version = expected
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		*version = expected
+		return ConflictErr
+	}
+	return nil
+}
+
+// Touch увеличивает версию записи без изменения остальных полей
+func Touch(tx *gorm.DB, model any, id int64) error {
+	return tx.Model(model).
+		Where("id = ?", id).
+		UpdateColumns(map[string]any{
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now(),
+		}).Error
+}
+
+// Check сравнивает ожидаемую клиентом версию с текущей, 0 означает «без проверки»
+func Check(expected, actual int64) error {
+	if expected != 0 && expected != actual {
+		return ConflictErr
+	}
+	return nil
+}
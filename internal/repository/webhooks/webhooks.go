@@ -0,0 +1,139 @@
+package webhooks
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+var NotFoundWebhookErr = errors.New("not found webhook")
+
+type Repo interface {
+	Create(webhook *models.Webhook) error
+	Get(webhookID int64) (*models.Webhook, error)
+	Save(webhook *models.Webhook) error
+	Delete(webhookID int64) error
+	FindByUser(userID int64) ([]models.Webhook, error)
+	CountByUser(userID int64) (int64, error)
+	// FindSubscribed — активные вебхуки пользователя, подписанные на тип события
+	FindSubscribed(userID int64, eventType string) ([]models.Webhook, error)
+
+	// ----- deliveries -----
+
+	CreateDeliveries(deliveries []models.WebhookDelivery) error
+	FindDueDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error)
+	SaveDelivery(delivery *models.WebhookDelivery) error
+	FindDeliveries(webhookID int64, offset, limit int) ([]models.WebhookDelivery, int64, error)
+	DeleteDeliveriesBefore(t time.Time) (int64, error)
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+func (u *repoImpl) Create(webhook *models.Webhook) error {
+	return u.db.Create(webhook).Error
+}
+
+func (u *repoImpl) Get(webhookID int64) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := u.db.First(&webhook, webhookID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, NotFoundWebhookErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (u *repoImpl) Save(webhook *models.Webhook) error {
+	return u.db.Save(webhook).Error
+}
+
+func (u *repoImpl) Delete(webhookID int64) error {
+	res := u.db.Delete(&models.Webhook{}, webhookID)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return NotFoundWebhookErr
+	}
+	return nil
+}
+
+func (u *repoImpl) FindByUser(userID int64) ([]models.Webhook, error) {
+	var result []models.Webhook
+	err := u.db.Where("user_id = ?", userID).Order("id").Find(&result).Error
+	return result, err
+}
+
+func (u *repoImpl) CountByUser(userID int64) (int64, error) {
+	var count int64
+	err := u.db.Model(&models.Webhook{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (u *repoImpl) FindSubscribed(userID int64, eventType string) ([]models.Webhook, error) {
+	var result []models.Webhook
+	err := u.db.
+		Where("user_id = @user AND active AND event_types @> jsonb_build_array(@type::TEXT)",
+			sql.Named("user", userID), sql.Named("type", eventType)).
+		Order("id").
+		Find(&result).Error
+	return result, err
+}
+
+func (u *repoImpl) CreateDeliveries(deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return u.db.Create(&deliveries).Error
+}
+
+// FindDueDeliveries — ожидающие доставки, время попытки которых наступило, вместе с вебхуком
+func (u *repoImpl) FindDueDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var result []models.WebhookDelivery
+	err := u.db.
+		Preload("Webhook").
+		Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+		Order("next_attempt_at, id").
+		Limit(limit).
+		Find(&result).Error
+	return result, err
+}
+
+func (u *repoImpl) SaveDelivery(delivery *models.WebhookDelivery) error {
+	return u.db.Omit("Webhook").Save(delivery).Error
+}
+
+func (u *repoImpl) FindDeliveries(webhookID int64, offset, limit int) ([]models.WebhookDelivery, int64, error) {
+	var total int64
+	if err := u.db.Model(&models.WebhookDelivery{}).Where("webhook_id = ?", webhookID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var result []models.WebhookDelivery
+	err := u.db.
+		Where("webhook_id = ?", webhookID).
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&result).Error
+	return result, total, err
+}
+
+func (u *repoImpl) DeleteDeliveriesBefore(t time.Time) (int64, error) {
+	res := u.db.Where("status <> ? AND created_at < ?", models.WebhookDeliveryPending, t).Delete(&models.WebhookDelivery{})
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,36 @@
+package workoutedits
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+type Repo interface {
+	Create(edit *models.WorkoutEdit) error
+	FindByWorkoutID(workoutID int64) ([]models.WorkoutEdit, error)
+}
+
+type repoImpl struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) Repo {
+	return &repoImpl{
+		db: db,
+	}
+}
+
+func (u *repoImpl) Create(edit *models.WorkoutEdit) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Create(edit).Error
+	})
+}
+
+func (u *repoImpl) FindByWorkoutID(workoutID int64) (edits []models.WorkoutEdit, err error) {
+	err = u.db.
+		Where("workout_day_id = ?", workoutID).
+		Order("created_at ASC, id ASC").
+		Find(&edits).Error
+	return edits, err
+}
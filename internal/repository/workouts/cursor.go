@@ -0,0 +1,64 @@
+package workouts
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"gorm.io/gorm"
+)
+
+var InvalidCursorErr = errors.New("invalid cursor")
+
+// Cursor — позиция в списке тренировок, упорядоченном по (started_at, id) по убыванию.
+// Клиент получает его непрозрачной строкой (Encode) и возвращает за следующей страницей
+type Cursor struct {
+	StartedAt time.Time
+	ID        int64
+}
+
+// CursorOf — курсор, указывающий сразу за тренировкой w
+func CursorOf(w models.WorkoutDay) *Cursor {
+	return &Cursor{StartedAt: w.StartedAt, ID: w.ID}
+}
+
+func (c *Cursor) Encode() string {
+	raw := strconv.FormatInt(c.StartedAt.UnixMicro(), 10) + "." + strconv.FormatInt(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor разбирает строку из Encode; пустая строка — первая страница (nil)
+func DecodeCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, InvalidCursorErr
+	}
+	micros, id, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return nil, InvalidCursorErr
+	}
+	startedAt, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, InvalidCursorErr
+	}
+	c := &Cursor{StartedAt: time.UnixMicro(startedAt).UTC()}
+	if c.ID, err = strconv.ParseInt(id, 10, 64); err != nil || c.ID <= 0 {
+		return nil, InvalidCursorErr
+	}
+	return c, nil
+}
+
+// before оставляет строки строго после cursor в порядке started_at DESC, id DESC.
+// В postgres started_at хранится с точностью до микросекунд, поэтому курсор их и сохраняет
+func before(query *gorm.DB, cursor *Cursor) *gorm.DB {
+	if cursor == nil {
+		return query
+	}
+	return query.Where("(started_at, id) < (?, ?)", cursor.StartedAt, cursor.ID)
+}
@@ -1,19 +1,60 @@
 package workouts
 
 import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/outbox"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/versioning"
 	"gorm.io/gorm"
 )
 
 type Repo interface {
 	Create(workoutDay *models.WorkoutDay) error
 	Delete(workout *models.WorkoutDay) error
-	Save(workout *models.WorkoutDay) error
+	Save(workout *models.WorkoutDay, evts ...events.Event) error
 	Get(workoutID int64) (models.WorkoutDay, error)
 	Count(userID int64) (count int64, err error)
-	FindAll(userID int64) ([]models.WorkoutDay, error)
 	Find(userID int64, offset, limit int) ([]models.WorkoutDay, error)
+	FindBefore(userID int64, cursor *Cursor, limit int) ([]models.WorkoutDay, error)
+	Iterate(userID int64, batchSize int, fn func(batch []models.WorkoutDay) error) error
+	SumCompleted(userID int64, since *time.Time) (Totals, error)
+	FindFiltered(userID int64, filter Filter, offset, limit int) ([]models.WorkoutDay, int64, error)
+	FindByIDs(ids []int64) ([]models.WorkoutDay, error)
+	FindPage(userID int64, offset, limit int) ([]models.WorkoutDay, error)
 	FindPreviousByType(userID int64, dayTypeID int64, activeProgramID int64) (models.WorkoutDay, error)
+	FindUpdatedSince(userID int64, since time.Time) ([]models.WorkoutDay, error)
+	FindAbandoned(inactiveSince time.Time, limit int) ([]Abandoned, error)
+}
+
+// статусы тренировки для Filter
+const (
+	StatusActive    = "active"    // идет прямо сейчас
+	StatusEditing   = "editing"   // переоткрыта для правки или записана задним числом
+	StatusCompleted = "completed" // завершена
+)
+
+// Filter ограничивает выборку тренировок; нулевые поля не фильтруют
+type Filter struct {
+	From      *time.Time // started_at >= From
+	To        *time.Time // started_at < To
+	Status    string
+	DayTypeID int64
+}
+
+// Abandoned незавершенная тренировка без активности с указанного момента
+type Abandoned struct {
+	WorkoutDayID    int64
+	LastActivityAt  time.Time  // последнее любое изменение тренировки, упражнений или подходов
+	LastCompletedAt *time.Time // время последнего выполненного подхода
+}
+
+// Totals — агрегаты по завершенным тренировкам, считаются в БД
+type Totals struct {
+	Workouts      int
+	Duration      time.Duration
+	CardioMinutes int // фактические минуты выполненных подходов, как Set.GetRealMinutes
 }
 
 type repoImpl struct {
@@ -38,9 +79,13 @@ func (u *repoImpl) Delete(workout *models.WorkoutDay) error {
 	})
 }
 
-func (u *repoImpl) Save(workout *models.WorkoutDay) error {
+// Save обновляет только саму тренировку (без упражнений и подходов) с проверкой версии
+func (u *repoImpl) Save(workout *models.WorkoutDay, evts ...events.Event) error {
 	return u.db.Transaction(func(tx *gorm.DB) error {
-		return tx.Save(workout).Error
+		if err := versioning.Update(tx, workout, workout.ID, &workout.Version); err != nil {
+			return err
+		}
+		return outbox.Append(tx, evts...)
 	})
 }
 
@@ -66,33 +111,126 @@ func (u *repoImpl) Count(userID int64) (count int64, err error) {
 	return count, err
 }
 
-func (u *repoImpl) FindAll(userID int64) (workouts []models.WorkoutDay, err error) {
-	err = u.db.Transaction(func(tx *gorm.DB) error {
-		return tx.Where("user_id = ?", userID).
-			Order("started_at DESC").
-			Preload("WorkoutDayType").
-			Preload("Exercises.ExerciseType").
-			Preload("Exercises.Sets", func(db *gorm.DB) *gorm.DB { return db.Order("sets.index ASC") }).
-			Preload("Exercises", func(db *gorm.DB) *gorm.DB { return db.Order("exercises.index ASC") }).
-			Find(&workouts).Error
-	})
+// Find — страница для списка тренировок: из связей нужен только день программы
+func (u *repoImpl) Find(userID int64, offset, limit int) (workouts []models.WorkoutDay, err error) {
+	err = u.db.Where("user_id = ?", userID).
+		Order("started_at DESC, id DESC").
+		Preload("WorkoutDayType").
+		Offset(offset).
+		Limit(limit).
+		Find(&workouts).Error
 	return workouts, err
 }
 
-func (u *repoImpl) Find(userID int64, offset, limit int) (workouts []models.WorkoutDay, err error) {
-	err = u.db.Transaction(func(tx *gorm.DB) error {
-		return tx.Where("user_id = ?", userID).
-			Order("started_at DESC").
+// FindBefore — то же, что Find, но страница начинается сразу после cursor (nil — с начала).
+// Keyset по (started_at, id) не просматривает пропущенные строки и не съезжает,
+// если между запросами страниц добавилась тренировка
+func (u *repoImpl) FindBefore(userID int64, cursor *Cursor, limit int) (workouts []models.WorkoutDay, err error) {
+	err = before(u.db.Where("user_id = ?", userID), cursor).
+		Order("started_at DESC, id DESC").
+		Preload("WorkoutDayType").
+		Limit(limit).
+		Find(&workouts).Error
+	return workouts, err
+}
+
+// Iterate отдает все тренировки пользователя пачками по batchSize, новые первыми, со всеми
+// упражнениями и подходами. В памяти одновременно только одна пачка, поэтому выгрузка
+// многолетней истории не растет вместе с ней. Ошибка из fn прерывает обход и возвращается как есть
+func (u *repoImpl) Iterate(userID int64, batchSize int, fn func(batch []models.WorkoutDay) error) error {
+	var cursor *Cursor
+	for {
+		var batch []models.WorkoutDay
+		err := before(u.db.Where("user_id = ?", userID), cursor).
+			Order("started_at DESC, id DESC").
 			Preload("WorkoutDayType").
 			Preload("Exercises.ExerciseType").
 			Preload("Exercises.Sets", func(db *gorm.DB) *gorm.DB { return db.Order("sets.index ASC") }).
 			Preload("Exercises", func(db *gorm.DB) *gorm.DB { return db.Order("exercises.index ASC") }).
-			Offset(offset).
-			Limit(limit).
-			Find(&workouts).Error
-	})
+			Limit(batchSize).
+			Find(&batch).Error
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		if err = fn(batch); err != nil {
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		cursor = CursorOf(batch[len(batch)-1])
+	}
+}
 
-	return workouts, err
+// SumCompleted считает число, суммарную длительность и кардио-минуты завершенных тренировок,
+// начатых не раньше since (nil — за все время), не загружая сами тренировки
+func (u *repoImpl) SumCompleted(userID int64, since *time.Time) (totals Totals, err error) {
+	var row struct {
+		Workouts      int
+		Seconds       float64
+		CardioMinutes int
+	}
+	query := u.db.Model(&models.WorkoutDay{}).
+		Select(`COUNT(*) AS workouts,
+			COALESCE(SUM(EXTRACT(EPOCH FROM ended_at - started_at)), 0) AS seconds,
+			COALESCE(SUM((
+				SELECT SUM(CASE WHEN s.fact_minutes > 0 THEN s.fact_minutes ELSE s.minutes END)
+				FROM exercises e
+				JOIN sets s ON s.exercise_id = e.id
+				WHERE e.workout_day_id = workout_days.id AND s.completed
+			)), 0) AS cardio_minutes`).
+		Where("user_id = ? AND completed", userID)
+	if since != nil {
+		query = query.Where("started_at >= ?", *since)
+	}
+	if err = query.Scan(&row).Error; err != nil {
+		return totals, err
+	}
+	return Totals{
+		Workouts:      row.Workouts,
+		Duration:      time.Duration(row.Seconds * float64(time.Second)),
+		CardioMinutes: row.CardioMinutes,
+	}, nil
+}
+
+func (u *repoImpl) FindFiltered(userID int64, filter Filter, offset, limit int) (workouts []models.WorkoutDay, total int64, err error) {
+	query := u.db.Model(&models.WorkoutDay{}).Where("user_id = ?", userID)
+	if filter.From != nil {
+		query = query.Where("started_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("started_at < ?", *filter.To)
+	}
+	if filter.DayTypeID != 0 {
+		query = query.Where("workout_day_type_id = ?", filter.DayTypeID)
+	}
+	switch filter.Status {
+	case StatusActive:
+		query = query.Where("completed = FALSE AND ended_at IS NULL")
+	case StatusEditing:
+		query = query.Where("completed = FALSE AND ended_at IS NOT NULL")
+	case StatusCompleted:
+		query = query.Where("completed = TRUE")
+	}
+	// Session позволяет переиспользовать условия для Count и Find
+	query = query.Session(&gorm.Session{})
+
+	if err = query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err = query.
+		Order("started_at DESC, id DESC").
+		Preload("WorkoutDayType").
+		Preload("Exercises.ExerciseType").
+		Preload("Exercises.Sets", func(db *gorm.DB) *gorm.DB { return db.Order("sets.index ASC") }).
+		Preload("Exercises", func(db *gorm.DB) *gorm.DB { return db.Order("exercises.index ASC") }).
+		Offset(offset).
+		Limit(limit).
+		Find(&workouts).Error
+	return workouts, total, err
 }
 
 func (u *repoImpl) FindPreviousByType(userID int64, dayTypeID int64, activeProgramID int64) (workout models.WorkoutDay, err error) {
@@ -104,3 +242,61 @@ func (u *repoImpl) FindPreviousByType(userID int64, dayTypeID int64, activeProgr
 		First(&workout).Error
 	return workout, err
 }
+
+// FindUpdatedSince возвращает тренировки, в которых менялись сама тренировка, упражнения или подходы
+func (u *repoImpl) FindUpdatedSince(userID int64, since time.Time) (workouts []models.WorkoutDay, err error) {
+	err = u.db.
+		Where("user_id = ?", userID).
+		Where("updated_at > ? OR id IN (?)", since,
+			u.db.Model(&models.Exercise{}).Select("workout_day_id").Where("updated_at > ?", since)).
+		Order("started_at ASC").
+		Preload("WorkoutDayType").
+		Preload("Exercises.WorkoutDay").
+		Preload("Exercises.ExerciseType").
+		Preload("Exercises.Sets", func(db *gorm.DB) *gorm.DB { return db.Order("sets.index ASC") }).
+		Preload("Exercises.Sets.Exercise.ExerciseType").
+		Preload("Exercises", func(db *gorm.DB) *gorm.DB { return db.Order("exercises.index ASC") }).
+		Find(&workouts).Error
+	return workouts, err
+}
+
+// FindAbandoned ищет активные тренировки, в которых ничего не менялось с inactiveSince.
+// Тренировки в режиме правки (время окончания уже задано) не трогаем
+func (u *repoImpl) FindAbandoned(inactiveSince time.Time, limit int) (res []Abandoned, err error) {
+	err = u.db.Raw(`
+		SELECT a.workout_day_id, a.last_activity_at, a.last_completed_at
+		FROM (
+			SELECT w.id AS workout_day_id,
+			       GREATEST(w.started_at, w.updated_at,
+			                COALESCE(MAX(e.updated_at), w.started_at),
+			                COALESCE(MAX(s.completed_at), w.started_at)) AS last_activity_at,
+			       MAX(s.completed_at) FILTER (WHERE s.completed) AS last_completed_at
+			FROM workout_days w
+			LEFT JOIN exercises e ON e.workout_day_id = w.id
+			LEFT JOIN sets s ON s.exercise_id = e.id
+			WHERE w.completed = FALSE AND w.ended_at IS NULL
+			GROUP BY w.id
+		) a
+		WHERE a.last_activity_at < ?
+		ORDER BY a.last_activity_at ASC
+		LIMIT ?`, inactiveSince, limit).
+		Scan(&res).Error
+	return res, err
+}
+
+// FindByIDs возвращает тренировки без связанных сущностей, их догружает вызывающий
+func (u *repoImpl) FindByIDs(ids []int64) (workouts []models.WorkoutDay, err error) {
+	err = u.db.Where("id IN ?", ids).Find(&workouts).Error
+	return workouts, err
+}
+
+// FindPage — страница тренировок пользователя без связанных сущностей, новые первыми
+func (u *repoImpl) FindPage(userID int64, offset, limit int) (workouts []models.WorkoutDay, err error) {
+	err = u.db.Where("user_id = ?", userID).
+		Order("started_at DESC").
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&workouts).Error
+	return workouts, err
+}
@@ -0,0 +1,209 @@
+package workouts_test
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/repotest"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/workouts"
+)
+
+const (
+	fiveYears = 5 * 365
+	batchSize = 100
+	pageSize  = 50
+)
+
+// liveHeap — память, которая переживает сборку мусора
+func liveHeap() int64 {
+	var stats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	return int64(stats.HeapAlloc)
+}
+
+type iterated struct {
+	workouts int
+	sets     int
+	order    bool  // строго новые первыми
+	growth   int64 // наибольший прирост живой кучи, пока жива пачка
+}
+
+func iterate(t testing.TB, repo workouts.Repo, userID int64, size int) iterated {
+	var res iterated
+	res.order = true
+	var prev time.Time
+	base := liveHeap()
+	err := repo.Iterate(userID, size, func(batch []models.WorkoutDay) error {
+		for i := range batch {
+			w := &batch[i]
+			if !prev.IsZero() && !w.StartedAt.Before(prev) {
+				res.order = false
+			}
+			res.workouts++
+			for _, e := range w.Exercises {
+				res.sets += len(e.Sets)
+			}
+			prev = w.StartedAt
+		}
+		res.growth = max(res.growth, liveHeap()-base)
+		runtime.KeepAlive(batch)
+		return nil
+	})
+	require.NoError(t, err)
+	return res
+}
+
+// TestIterateFiveYears обходит пятилетнюю историю пачками и целиком: пачки должны
+// отдать все подходы в нужном порядке и держать в памяти в разы меньше всей истории
+func TestIterateFiveYears(t *testing.T) {
+	db := repotest.Open(t)
+	h := repotest.SeedHistory(t, db, fiveYears)
+	repo := workouts.NewRepo(db)
+
+	batched := iterate(t, repo, h.UserID, batchSize)
+	assert.Equal(t, fiveYears, batched.workouts)
+	assert.Equal(t, fiveYears*repotest.HistoryExercises*repotest.HistorySets, batched.sets)
+	assert.True(t, batched.order, "workouts must come newest first")
+
+	whole := iterate(t, repo, h.UserID, fiveYears)
+	require.Equal(t, batched.sets, whole.sets)
+	t.Logf("live heap: batch of %d — %.1f MB, whole history — %.1f MB",
+		batchSize, float64(batched.growth)/(1<<20), float64(whole.growth)/(1<<20))
+	// пачка — 1/18 истории; тройной запас покрывает шум сборщика и кэши gorm
+	assert.Less(t, batched.growth*3, whole.growth, "a batch must not hold the whole history")
+}
+
+// TestFindBeforePagesFiveYears листает историю курсором. Тренировка-двойник с тем же
+// started_at стоит на границе страниц, а новая тренировка появляется посреди листания:
+// ни одна строка не должна потеряться или повториться
+func TestFindBeforePagesFiveYears(t *testing.T) {
+	db := repotest.Open(t)
+	h := repotest.SeedHistory(t, db, fiveYears)
+	repo := workouts.NewRepo(db)
+
+	// 50-я сверху тренировка и ее двойник с большим id делят 50-е и 51-е места
+	require.NoError(t, db.Exec(`INSERT INTO workout_days (user_id, workout_day_type_id, started_at, ended_at, completed)
+		SELECT user_id, workout_day_type_id, started_at, ended_at, completed
+		FROM workout_days WHERE user_id = ? AND started_at = ?`, h.UserID, h.End.AddDate(0, 0, 1-pageSize)).Error)
+
+	first, err := repo.Find(h.UserID, 0, pageSize)
+	require.NoError(t, err)
+
+	seen := map[int64]bool{}
+	var cursor *workouts.Cursor
+	var prev models.WorkoutDay
+	for page := 0; ; page++ {
+		items, err := repo.FindBefore(h.UserID, cursor, pageSize)
+		require.NoError(t, err)
+		if page == 0 {
+			assert.Equal(t, first, items, "the first page must match offset pagination")
+			require.NoError(t, db.Create(&models.WorkoutDay{UserID: h.UserID, WorkoutDayTypeID: h.DayTypeID,
+				StartedAt: h.End.Add(time.Hour)}).Error)
+		}
+		for _, w := range items {
+			require.False(t, seen[w.ID], "workout %d is repeated", w.ID)
+			seen[w.ID] = true
+			if prev.ID != 0 {
+				assert.True(t, w.StartedAt.Before(prev.StartedAt) || w.StartedAt.Equal(prev.StartedAt) && w.ID < prev.ID)
+			}
+			prev = w
+		}
+		if len(items) < pageSize {
+			break
+		}
+		// курсор проходит через клиента строкой
+		cursor, err = workouts.DecodeCursor(workouts.CursorOf(items[len(items)-1]).Encode())
+		require.NoError(t, err)
+	}
+	assert.Len(t, seen, fiveYears+1, "the seeded history and the twin, without the workout added while paging")
+}
+
+// TestSumCompletedFiveYears сверяет итоги из SQL с известной историей
+func TestSumCompletedFiveYears(t *testing.T) {
+	db := repotest.Open(t)
+	h := repotest.SeedHistory(t, db, fiveYears)
+	repo := workouts.NewRepo(db)
+	// незавершенная тренировка в итоги не входит
+	require.NoError(t, db.Create(&models.WorkoutDay{UserID: h.UserID, WorkoutDayTypeID: h.DayTypeID, StartedAt: h.End.Add(time.Hour)}).Error)
+
+	cardioPerWorkout := repotest.HistorySets * repotest.HistoryCardioMinutes
+	all, err := repo.SumCompleted(h.UserID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, workouts.Totals{
+		Workouts:      fiveYears,
+		Duration:      fiveYears * time.Hour,
+		CardioMinutes: fiveYears * cardioPerWorkout,
+	}, all)
+
+	since := h.End.AddDate(-1, 0, 1)
+	year, err := repo.SumCompleted(h.UserID, &since)
+	require.NoError(t, err)
+	assert.Equal(t, 365, year.Workouts)
+	assert.Equal(t, 365*time.Hour, year.Duration)
+	assert.Equal(t, 365*cardioPerWorkout, year.CardioMinutes)
+}
+
+// BenchmarkIterate сообщает наибольший прирост живой кучи за обход пятилетней истории
+func BenchmarkIterate(b *testing.B) {
+	db := repotest.Open(b)
+	h := repotest.SeedHistory(b, db, fiveYears)
+	repo := workouts.NewRepo(db)
+
+	for _, size := range []int{batchSize, fiveYears} {
+		b.Run("batch="+strconv.Itoa(size), func(b *testing.B) {
+			var peak int64
+			b.ReportAllocs()
+			for b.Loop() {
+				peak = max(peak, iterate(b, repo, h.UserID, size).growth)
+			}
+			b.ReportMetric(float64(peak)/(1<<20), "peak-heap-MB")
+		})
+	}
+}
+
+// BenchmarkDeepPage — страница в конце пятилетней истории: курсор против смещения
+func BenchmarkDeepPage(b *testing.B) {
+	db := repotest.Open(b)
+	h := repotest.SeedHistory(b, db, fiveYears)
+	repo := workouts.NewRepo(db)
+
+	offset := fiveYears - pageSize
+	page, err := repo.Find(h.UserID, offset-1, 1)
+	require.NoError(b, err)
+	cursor := workouts.CursorOf(page[0])
+
+	b.Run("cursor", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := repo.FindBefore(h.UserID, cursor, pageSize); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("offset", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := repo.Find(h.UserID, offset, pageSize); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkSumCompleted(b *testing.B) {
+	db := repotest.Open(b)
+	h := repotest.SeedHistory(b, db, fiveYears)
+	repo := workouts.NewRepo(db)
+
+	since := h.End.AddDate(0, -1, 0)
+	for b.Loop() {
+		if _, err := repo.SumCompleted(h.UserID, &since); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package achievements
+
+import "fmt"
+
+// Kind — по какой части истории считается значок
+type Kind string
+
+const (
+	KindWorkouts     Kind = "workouts"     // число завершенных тренировок
+	KindRecords      Kind = "records"      // число рекордов
+	KindMeasurements Kind = "measurements" // число замеров тела
+	KindStreak       Kind = "streak"       // недель подряд хотя бы с одной тренировкой
+)
+
+type Badge struct {
+	Code        string
+	Title       string
+	Description string
+	Icon        string
+	Kind        Kind
+	Threshold   int
+}
+
+// Catalog — все значки в порядке показа. Коды хранятся в user_achievements, менять их нельзя
+var Catalog = []Badge{
+	countBadge(KindWorkouts, 1, "🏁", "Первая тренировка", "Завершите первую тренировку"),
+	countBadge(KindWorkouts, 10, "💪", "Втянулся", "Завершите 10 тренировок"),
+	countBadge(KindWorkouts, 50, "🔥", "Полтинник", "Завершите 50 тренировок"),
+	countBadge(KindWorkouts, 100, "💯", "Сотня", "Завершите 100 тренировок"),
+	countBadge(KindWorkouts, 250, "🏋️", "Железный характер", "Завершите 250 тренировок"),
+	countBadge(KindWorkouts, 500, "👑", "Легенда зала", "Завершите 500 тренировок"),
+
+	countBadge(KindRecords, 1, "🏆", "Первый рекорд", "Поднимите в упражнении больше, чем когда-либо раньше"),
+	countBadge(KindRecords, 10, "🥇", "Рекордсмен", "Установите 10 личных рекордов"),
+	countBadge(KindRecords, 50, "🚀", "Машина прогресса", "Установите 50 личных рекордов"),
+
+	countBadge(KindMeasurements, 1, "📏", "Точка отсчёта", "Запишите первый замер тела"),
+	countBadge(KindMeasurements, 10, "📊", "Под контролем", "Запишите 10 замеров тела"),
+	countBadge(KindMeasurements, 50, "📚", "Летописец", "Запишите 50 замеров тела"),
+
+	countBadge(KindStreak, 4, "📅", "Месяц без пропусков", "Тренируйтесь 4 недели подряд"),
+	countBadge(KindStreak, 8, "⚡", "Два месяца подряд", "Тренируйтесь 8 недель подряд"),
+	countBadge(KindStreak, 12, "🌟", "Квартал без пропусков", "Тренируйтесь 12 недель подряд"),
+	countBadge(KindStreak, 26, "🛡️", "Полгода подряд", "Тренируйтесь 26 недель подряд"),
+	countBadge(KindStreak, 52, "🏅", "Год без пропусков", "Тренируйтесь 52 недели подряд"),
+}
+
+func countBadge(kind Kind, threshold int, icon, title, description string) Badge {
+	return Badge{
+		Code:        fmt.Sprintf("%s_%d", kind, threshold),
+		Title:       title,
+		Description: description,
+		Icon:        icon,
+		Kind:        kind,
+		Threshold:   threshold,
+	}
+}
+
+// Get ищет значок по коду; значки, убранные из каталога, не показываются
+func Get(code string) (Badge, bool) {
+	for _, b := range Catalog {
+		if b.Code == code {
+			return b, true
+		}
+	}
+	return Badge{}, false
+}
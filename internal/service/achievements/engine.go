@@ -0,0 +1,140 @@
+package achievements
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+)
+
+type EventType string
+
+const (
+	WorkoutFinished   EventType = "workout_finished"
+	MeasurementLogged EventType = "measurement_logged"
+)
+
+type Event struct {
+	Type   EventType
+	UserID int64
+}
+
+// Kinds — какие значки может изменить событие. Рекорды и серии недель появляются только
+// с завершением тренировки, поэтому проверяются вместе с ней
+func (e Event) Kinds() []Kind {
+	switch e.Type {
+	case WorkoutFinished:
+		return []Kind{KindWorkouts, KindRecords, KindStreak}
+	case MeasurementLogged:
+		return []Kind{KindMeasurements}
+	}
+	return nil
+}
+
+// Evaluator проверяет значки пользователя по событию и возвращает только что полученные
+type Evaluator interface {
+	Execute(event Event) (*dto.AchievementUnlock, error)
+}
+
+// Notifier объявляет пользователю о новых значках
+type Notifier interface {
+	NotifyAchievementsUnlocked(unlock dto.AchievementUnlock) error
+}
+
+// значки, полученные раньше, выданы задним числом (например, после первой проверки старой истории) — о них не объявляем
+const announceWindow = 24 * time.Hour
+
+// Engine проверяет значки по доменным событиям из outbox, не задерживая завершение тренировки
+type Engine struct {
+	evaluator Evaluator
+
+	mu        sync.RWMutex
+	notifiers []Notifier
+}
+
+func NewEngine(evaluator Evaluator, notifiers ...Notifier) *Engine {
+	return &Engine{
+		evaluator: evaluator,
+		notifiers: notifiers,
+	}
+}
+
+// AddNotifier подключает канал уведомлений, который стал доступен после старта (например, телеграм-бот)
+func (e *Engine) AddNotifier(n Notifier) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.notifiers = append(e.notifiers, n)
+}
+
+// Subscriptions — доменные события, на которые подписывается движок
+func Subscriptions() []events.Type {
+	return []events.Type{events.TypeWorkoutFinished, events.TypeMeasurementCreated}
+}
+
+// Handle — подписчик шины событий. Ошибка проверки возвращается, чтобы outbox повторил доставку:
+// значки выдаются идемпотентно, повтор не создаст дублей
+func (e *Engine) Handle(event events.Event) error {
+	var mapped Event
+	switch ev := event.(type) {
+	case events.WorkoutFinished:
+		mapped = Event{Type: WorkoutFinished, UserID: ev.UserID}
+	case events.MeasurementCreated:
+		mapped = Event{Type: MeasurementLogged, UserID: ev.UserID}
+	default:
+		return nil
+	}
+	if mapped.UserID == 0 {
+		return nil
+	}
+	return e.handle(mapped, time.Now())
+}
+
+func (e *Engine) handle(event Event, now time.Time) error {
+	unlock, err := e.evaluator.Execute(event)
+	if err != nil {
+		return err
+	}
+	if unlock == nil {
+		return nil
+	}
+
+	fresh := unlock.Achievements[:0:0]
+	for _, a := range unlock.Achievements {
+		if a.EarnedAt != nil && now.Sub(*a.EarnedAt) <= announceWindow {
+			fresh = append(fresh, a)
+		}
+	}
+	if len(fresh) == 0 {
+		return nil
+	}
+	unlock.Achievements = fresh
+
+	e.mu.RLock()
+	notifiers := append([]Notifier(nil), e.notifiers...)
+	e.mu.RUnlock()
+
+	// значки уже выданы: ошибка доставки уведомления не повод проверять событие заново
+	for _, n := range notifiers {
+		if err = n.NotifyAchievementsUnlocked(*unlock); err != nil {
+			slog.Error("achievements notify failed", "err", err)
+		}
+	}
+	return nil
+}
+
+// Describe — заголовок и текст уведомления для каналов без разметки
+func Describe(unlock dto.AchievementUnlock) (title, body string) {
+	if len(unlock.Achievements) == 1 {
+		a := unlock.Achievements[0]
+		return fmt.Sprintf("%s Новое достижение: %s", a.Icon, a.Title), a.Description
+	}
+	titles := make([]string, 0, len(unlock.Achievements))
+	for _, a := range unlock.Achievements {
+		titles = append(titles, a.Icon+" "+a.Title)
+	}
+	return fmt.Sprintf("🎖 Новые достижения: %d", len(unlock.Achievements)), strings.Join(titles, ", ")
+}
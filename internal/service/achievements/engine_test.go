@@ -0,0 +1,107 @@
+package achievements
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+)
+
+type evaluatorStub struct {
+	events []Event
+	unlock *dto.AchievementUnlock
+	err    error
+}
+
+func (s *evaluatorStub) Execute(event Event) (*dto.AchievementUnlock, error) {
+	s.events = append(s.events, event)
+	return s.unlock, s.err
+}
+
+type notifierStub struct {
+	unlocks []dto.AchievementUnlock
+}
+
+func (n *notifierStub) NotifyAchievementsUnlocked(unlock dto.AchievementUnlock) error {
+	n.unlocks = append(n.unlocks, unlock)
+	return nil
+}
+
+func TestEngineAnnouncesOnlyRecentAchievements(t *testing.T) {
+	now := time.Now()
+	recent := now.Add(-time.Minute)
+	old := now.AddDate(-1, 0, 0)
+
+	evaluator := &evaluatorStub{unlock: &dto.AchievementUnlock{
+		UserID: 7,
+		Achievements: []dto.AchievementItem{
+			{Code: "workouts_1", EarnedAt: &old},
+			{Code: "workouts_10", EarnedAt: &recent},
+		},
+	}}
+	notifier := &notifierStub{}
+	engine := NewEngine(evaluator, notifier)
+
+	require.NoError(t, engine.handle(Event{Type: WorkoutFinished, UserID: 7}, now))
+
+	require.Len(t, notifier.unlocks, 1)
+	require.Len(t, notifier.unlocks[0].Achievements, 1)
+	assert.Equal(t, "workouts_10", notifier.unlocks[0].Achievements[0].Code)
+}
+
+func TestEngineSkipsRetroactiveOnlyUnlock(t *testing.T) {
+	now := time.Now()
+	old := now.AddDate(0, -2, 0)
+
+	notifier := &notifierStub{}
+	engine := NewEngine(&evaluatorStub{unlock: &dto.AchievementUnlock{
+		UserID:       7,
+		Achievements: []dto.AchievementItem{{Code: "streak_4", EarnedAt: &old}},
+	}}, notifier)
+
+	require.NoError(t, engine.handle(Event{Type: WorkoutFinished, UserID: 7}, now))
+	require.NoError(t, engine.handle(Event{Type: MeasurementLogged, UserID: 7}, now))
+
+	assert.Empty(t, notifier.unlocks)
+}
+
+func TestEngineHandleMapsDomainEvents(t *testing.T) {
+	evaluator := &evaluatorStub{}
+	engine := NewEngine(evaluator)
+
+	require.NoError(t, engine.Handle(events.WorkoutFinished{WorkoutID: 1, UserID: 3}))
+	require.NoError(t, engine.Handle(events.MeasurementCreated{MeasurementID: 2, UserID: 3}))
+	require.NoError(t, engine.Handle(events.WorkoutStarted{WorkoutID: 1, UserID: 3}))
+	require.NoError(t, engine.Handle(events.WorkoutFinished{WorkoutID: 1}))
+
+	assert.Equal(t, []Event{
+		{Type: WorkoutFinished, UserID: 3},
+		{Type: MeasurementLogged, UserID: 3},
+	}, evaluator.events)
+}
+
+func TestEngineHandleReturnsEvaluateError(t *testing.T) {
+	engine := NewEngine(&evaluatorStub{err: errors.New("db is down")})
+
+	assert.Error(t, engine.Handle(events.WorkoutFinished{WorkoutID: 1, UserID: 3}))
+}
+
+func TestDescribe(t *testing.T) {
+	title, body := Describe(dto.AchievementUnlock{Achievements: []dto.AchievementItem{
+		{Icon: "🏆", Title: "Первый рекорд", Description: "Поднимите больше"},
+	}})
+	assert.Equal(t, "🏆 Новое достижение: Первый рекорд", title)
+	assert.Equal(t, "Поднимите больше", body)
+
+	title, body = Describe(dto.AchievementUnlock{Achievements: []dto.AchievementItem{
+		{Icon: "🏁", Title: "Первая тренировка"},
+		{Icon: "📅", Title: "Месяц без пропусков"},
+	}})
+	assert.Equal(t, "🎖 Новые достижения: 2", title)
+	assert.Equal(t, "🏁 Первая тренировка, 📅 Месяц без пропусков", body)
+}
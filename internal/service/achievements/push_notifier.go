@@ -0,0 +1,19 @@
+package achievements
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/push"
+)
+
+type pushNotifier struct {
+	push *push.Service
+}
+
+func NewPushNotifier(push *push.Service) Notifier {
+	return &pushNotifier{push: push}
+}
+
+func (n *pushNotifier) NotifyAchievementsUnlocked(unlock dto.AchievementUnlock) error {
+	title, body := Describe(unlock)
+	return n.push.SendAchievementsUnlocked(unlock.UserID, title, body)
+}
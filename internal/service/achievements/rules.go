@@ -0,0 +1,145 @@
+package achievements
+
+import (
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/achievements"
+)
+
+// недели считаются по московскому времени, как и время в боте; неделя начинается с понедельника
+const weekOffset = 3 * time.Hour
+
+// Evaluate проверяет значки выбранных видов (все, если kinds пустой) по всей истории пользователя.
+// Время получения берется из истории, поэтому повторная проверка дает тот же результат,
+// а значки за прошлые тренировки выдаются задним числом
+func Evaluate(history achievements.History, kinds ...Kind) []models.UserAchievement {
+	var result []models.UserAchievement
+	for _, badge := range Catalog {
+		if !hasKind(kinds, badge.Kind) {
+			continue
+		}
+		if fact, ok := reachedAt(history, badge); ok {
+			result = append(result, models.UserAchievement{
+				Code:         badge.Code,
+				EarnedAt:     fact.At,
+				WorkoutDayID: fact.WorkoutID,
+			})
+		}
+	}
+	return result
+}
+
+// Progress — текущее значение каждого вида значков
+type Progress struct {
+	Counts     map[Kind]int
+	Streak     int // текущая серия недель
+	BestStreak int
+}
+
+func CalcProgress(history achievements.History, now time.Time) Progress {
+	current, best := streaks(history.Workouts, now)
+	return Progress{
+		Counts: map[Kind]int{
+			KindWorkouts:     len(history.Workouts),
+			KindRecords:      len(history.Records),
+			KindMeasurements: len(history.Measurements),
+		},
+		Streak:     current,
+		BestStreak: best,
+	}
+}
+
+// Of — прогресс к значку, не больше порога
+func (p Progress) Of(badge Badge) int {
+	value := p.Counts[badge.Kind]
+	if badge.Kind == KindStreak {
+		value = p.Streak
+	}
+	return min(value, badge.Threshold)
+}
+
+func reachedAt(history achievements.History, badge Badge) (achievements.Fact, bool) {
+	var facts []achievements.Fact
+	switch badge.Kind {
+	case KindWorkouts:
+		facts = history.Workouts
+	case KindRecords:
+		facts = history.Records
+	case KindMeasurements:
+		facts = history.Measurements
+	case KindStreak:
+		return streakReachedAt(history.Workouts, badge.Threshold)
+	}
+	if len(facts) < badge.Threshold {
+		return achievements.Fact{}, false
+	}
+	return facts[badge.Threshold-1], true
+}
+
+// streakReachedAt ищет тренировку, на которой серия впервые достигла нужного числа недель
+func streakReachedAt(workouts []achievements.Fact, weeks int) (result achievements.Fact, ok bool) {
+	walkWeeks(workouts, func(w achievements.Fact, streak int) bool {
+		if streak >= weeks {
+			result, ok = w, true
+		}
+		return !ok
+	})
+	return result, ok
+}
+
+// streaks возвращает текущую серию (она не прерывается, пока идет неделя после последней тренировки) и лучшую
+func streaks(workouts []achievements.Fact, now time.Time) (current, best int) {
+	var streak int
+	var last achievements.Fact
+	walkWeeks(workouts, func(w achievements.Fact, s int) bool {
+		streak, last = s, w
+		best = max(best, s)
+		return true
+	})
+	if streak > 0 && weekIndex(now)-weekIndex(last.At) <= 1 {
+		current = streak
+	}
+	return current, best
+}
+
+// walkWeeks вызывает fn на первой тренировке каждой недели с длиной серии на эту неделю;
+// fn возвращает false, чтобы остановить обход
+func walkWeeks(workouts []achievements.Fact, fn func(w achievements.Fact, streak int) bool) {
+	var streak int
+	var last int64
+	for i, w := range workouts {
+		week := weekIndex(w.At)
+		switch {
+		case i > 0 && week == last:
+			continue
+		case i > 0 && week == last+1:
+			streak++
+		default:
+			streak = 1
+		}
+		last = week
+		if !fn(w, streak) {
+			return
+		}
+	}
+}
+
+// weekIndex — номер недели от понедельника 29.12.1969
+func weekIndex(t time.Time) int64 {
+	days := (t.Unix() + int64(weekOffset/time.Second)) / 86400
+	// 01.01.1970 — четверг, сдвигаем начало отсчета на понедельник
+	return (days + 3) / 7
+}
+
+func hasKind(kinds []Kind, kind Kind) bool {
+	if len(kinds) == 0 {
+		return true
+	}
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package achievements
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/achievements"
+)
+
+// понедельник 12:00 по Москве
+var monday = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+func workoutsAt(times ...time.Time) []achievements.Fact {
+	facts := make([]achievements.Fact, 0, len(times))
+	for i, t := range times {
+		id := int64(i + 1)
+		facts = append(facts, achievements.Fact{WorkoutID: &id, At: t})
+	}
+	return facts
+}
+
+func weekly(from time.Time, weeks int) []time.Time {
+	times := make([]time.Time, 0, weeks)
+	for i := 0; i < weeks; i++ {
+		times = append(times, from.AddDate(0, 0, 7*i))
+	}
+	return times
+}
+
+func codes(awards []models.UserAchievement) []string {
+	result := make([]string, 0, len(awards))
+	for _, a := range awards {
+		result = append(result, a.Code)
+	}
+	return result
+}
+
+func TestEvaluateCountBadgesEarnedAtThresholdFact(t *testing.T) {
+	history := achievements.History{Workouts: workoutsAt(weekly(monday, 10)...)}
+
+	awards := Evaluate(history, KindWorkouts)
+
+	require.Equal(t, []string{"workouts_1", "workouts_10"}, codes(awards))
+	assert.Equal(t, monday, awards[0].EarnedAt)
+	assert.Equal(t, monday.AddDate(0, 0, 63), awards[1].EarnedAt)
+	assert.Equal(t, int64(10), *awards[1].WorkoutDayID)
+}
+
+func TestEvaluateFiltersByKind(t *testing.T) {
+	history := achievements.History{
+		Workouts:     workoutsAt(monday),
+		Measurements: []achievements.Fact{{At: monday}},
+	}
+
+	assert.Equal(t, []string{"measurements_1"}, codes(Evaluate(history, KindMeasurements)))
+	assert.Equal(t, []string{"workouts_1", "measurements_1"}, codes(Evaluate(history)))
+}
+
+func TestEvaluateStreak(t *testing.T) {
+	// три недели подряд, пропуск, затем четыре недели подряд с двумя тренировками в одной неделе
+	times := weekly(monday, 3)
+	restart := monday.AddDate(0, 0, 28)
+	times = append(times, restart, restart.AddDate(0, 0, 2))
+	times = append(times, weekly(restart.AddDate(0, 0, 7), 3)...)
+
+	awards := Evaluate(achievements.History{Workouts: workoutsAt(times...)}, KindStreak)
+
+	require.Equal(t, []string{"streak_4"}, codes(awards))
+	assert.Equal(t, restart.AddDate(0, 0, 21), awards[0].EarnedAt)
+}
+
+func TestWeekStartsOnMondayMoscowTime(t *testing.T) {
+	// воскресенье 23:30 и понедельник 00:30 по Москве — разные недели, хотя в UTC это одно воскресенье
+	sunday := time.Date(2026, 3, 1, 20, 30, 0, 0, time.UTC)
+	assert.Equal(t, weekIndex(sunday)+1, weekIndex(sunday.Add(time.Hour)))
+	assert.Equal(t, weekIndex(monday), weekIndex(monday.AddDate(0, 0, 6)))
+}
+
+func TestCalcProgress(t *testing.T) {
+	history := achievements.History{
+		Workouts: workoutsAt(append(weekly(monday, 5), weekly(monday.AddDate(0, 0, 49), 2)...)...),
+		Records:  []achievements.Fact{{At: monday}, {At: monday}},
+	}
+
+	// последняя тренировка на прошлой неделе — серия еще не прервана
+	progress := CalcProgress(history, monday.AddDate(0, 0, 63))
+	assert.Equal(t, 2, progress.Streak)
+	assert.Equal(t, 5, progress.BestStreak)
+	assert.Equal(t, 7, progress.Of(Catalog[1]))
+	assert.Equal(t, 1, progress.Of(Catalog[0]))
+
+	// неделя без тренировок прервала серию
+	progress = CalcProgress(history, monday.AddDate(0, 0, 70))
+	assert.Equal(t, 0, progress.Streak)
+	assert.Equal(t, 5, progress.BestStreak)
+}
+
+func TestCatalogCodesAreUnique(t *testing.T) {
+	seen := make(map[string]bool, len(Catalog))
+	for _, b := range Catalog {
+		assert.False(t, seen[b.Code], b.Code)
+		seen[b.Code] = true
+	}
+}
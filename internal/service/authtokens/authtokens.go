@@ -0,0 +1,86 @@
+package authtokens
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+const (
+	AccessTTL  = 15 * time.Minute
+	RefreshTTL = 30 * 24 * time.Hour
+)
+
+var (
+	jwtSecret = []byte(os.Getenv("JWT_SECRET"))
+
+	InvalidTokenErr = errors.New("invalid token")
+)
+
+type Access struct {
+	Token     string
+	ID        string // jti
+	ExpiresAt time.Time
+}
+
+// IssueAccess выпускает короткоживущий access токен, привязанный к сессии sessionID
+func IssueAccess(userID int64, sessionID string) (*Access, error) {
+	jti := uuid.NewString()
+	expiresAt := time.Now().Add(AccessTTL)
+
+	claims := jwt.MapClaims{
+		"user_id": userID,
+		"sid":     sessionID,
+		"jti":     jti,
+		"exp":     expiresAt.Unix(),
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtSecret)
+	if err != nil {
+		return nil, err
+	}
+	return &Access{Token: signed, ID: jti, ExpiresAt: expiresAt}, nil
+}
+
+// Parse проверяет подпись и срок действия; токены без jti (выпущенные до появления сессий) не принимаются
+func Parse(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, InvalidTokenErr
+		}
+		return jwtSecret, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, InvalidTokenErr
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, InvalidTokenErr
+	}
+	if jti, _ := claims["jti"].(string); jti == "" {
+		return nil, InvalidTokenErr
+	}
+	return claims, nil
+}
+
+// NewRefresh генерирует refresh токен; в базе хранится только его хеш
+func NewRefresh() (token, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashRefresh(token), nil
+}
+
+func HashRefresh(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
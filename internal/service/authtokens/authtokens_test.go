@@ -0,0 +1,87 @@
+package authtokens
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func TestIssueAndParseAccess(t *testing.T) {
+	access, err := IssueAccess(42, "session-1")
+	if err != nil {
+		t.Fatalf("IssueAccess() error = %v", err)
+	}
+
+	claims, err := Parse(access.Token)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if claims["user_id"].(float64) != 42 || claims["sid"] != "session-1" || claims["jti"] != access.ID {
+		t.Errorf("Parse() claims = %v", claims)
+	}
+}
+
+func TestParseRejectsLegacyAndExpired(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+	}{
+		{
+			name:   "legacy token without jti",
+			claims: jwt.MapClaims{"user_id": 1, "exp": time.Now().Add(time.Hour).Unix()},
+		},
+		{
+			name:   "expired",
+			claims: jwt.MapClaims{"user_id": 1, "jti": "x", "exp": time.Now().Add(-time.Minute).Unix()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, tt.claims).SignedString(jwtSecret)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = Parse(signed); err == nil {
+				t.Errorf("Parse() expected error")
+			}
+		})
+	}
+}
+
+func TestNewRefresh(t *testing.T) {
+	token, hash, err := NewRefresh()
+	if err != nil {
+		t.Fatalf("NewRefresh() error = %v", err)
+	}
+	if HashRefresh(token) != hash {
+		t.Errorf("HashRefresh() does not match hash from NewRefresh()")
+	}
+
+	other, _, _ := NewRefresh()
+	if other == token {
+		t.Errorf("NewRefresh() returned the same token twice")
+	}
+}
+
+func TestNewPersonal(t *testing.T) {
+	token, hash, prefix, err := NewPersonal()
+	if err != nil {
+		t.Fatalf("NewPersonal() error = %v", err)
+	}
+	if !IsPersonal(token) {
+		t.Errorf("IsPersonal(%q) = false", token)
+	}
+	if HashRefresh(token) != hash {
+		t.Errorf("NewPersonal() hash does not match token")
+	}
+	if len(prefix) != personalDisplayLength || token[:len(prefix)] != prefix {
+		t.Errorf("NewPersonal() prefix = %q", prefix)
+	}
+
+	access, _ := IssueAccess(1, "session-1")
+	if IsPersonal(access.Token) {
+		t.Errorf("IsPersonal() = true for JWT")
+	}
+}
@@ -0,0 +1,52 @@
+package authtokens
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+)
+
+// PersonalPrefix отличает персональный токен от JWT в заголовке Authorization
+// и помогает сканерам секретов находить случайно опубликованные токены
+const PersonalPrefix = "tgb_pat_"
+
+// personalDisplayLength — сколько символов токена сохраняем открыто для списка токенов
+const personalDisplayLength = 16
+
+type Scope string
+
+const (
+	ScopeWorkoutsRead      Scope = "workouts:read"
+	ScopeMeasurementsRead  Scope = "measurements:read"
+	ScopeMeasurementsWrite Scope = "measurements:write"
+)
+
+// Scopes — права, которые можно выдать персональному токену
+var Scopes = []Scope{
+	ScopeWorkoutsRead,
+	ScopeMeasurementsRead,
+	ScopeMeasurementsWrite,
+}
+
+func IsKnownScope(scope Scope) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// NewPersonal генерирует персональный токен; в базе хранятся только хеш и открытое начало
+func NewPersonal() (token, hash, prefix string, err error) {
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", "", err
+	}
+	token = PersonalPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashRefresh(token), token[:personalDisplayLength], nil
+}
+
+func IsPersonal(token string) bool {
+	return strings.HasPrefix(token, PersonalPrefix)
+}
@@ -0,0 +1,18 @@
+package autofinish
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/push"
+)
+
+type pushNotifier struct {
+	push *push.Service
+}
+
+func NewPushNotifier(push *push.Service) Notifier {
+	return &pushNotifier{push: push}
+}
+
+func (n *pushNotifier) NotifyAutoFinished(workout dto.AutoFinishedWorkout) error {
+	return n.push.SendWorkoutAutoFinished(workout.UserID, workout.WorkoutID)
+}
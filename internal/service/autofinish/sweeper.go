@@ -0,0 +1,101 @@
+package autofinish
+
+import (
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	workoutusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/workouts"
+)
+
+const (
+	defaultInactiveFor = 3 * time.Hour
+	defaultInterval    = 10 * time.Minute
+)
+
+type Config struct {
+	InactiveFor time.Duration // сколько тренировка может простаивать до автозавершения
+	Interval    time.Duration // как часто искать брошенные тренировки
+}
+
+// ConfigFromEnv читает AUTO_FINISH_AFTER и AUTO_FINISH_INTERVAL (формат time.ParseDuration, например 3h или 90m)
+func ConfigFromEnv() Config {
+	return Config{
+		InactiveFor: durationFromEnv("AUTO_FINISH_AFTER", defaultInactiveFor),
+		Interval:    durationFromEnv("AUTO_FINISH_INTERVAL", defaultInterval),
+	}
+}
+
+func durationFromEnv(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		slog.Warn("invalid duration setting, using default", "key", key, "value", value, "default", def)
+		return def
+	}
+	return d
+}
+
+// Notifier сообщает пользователю об автозавершении и предлагает его отменить
+type Notifier interface {
+	NotifyAutoFinished(workout dto.AutoFinishedWorkout) error
+}
+
+type Sweeper struct {
+	autoFinishUC *workoutusecases.AutoFinishUseCase
+	config       Config
+
+	mu        sync.RWMutex
+	notifiers []Notifier
+}
+
+func NewSweeper(autoFinishUC *workoutusecases.AutoFinishUseCase, config Config, notifiers ...Notifier) *Sweeper {
+	return &Sweeper{
+		autoFinishUC: autoFinishUC,
+		config:       config,
+		notifiers:    notifiers,
+	}
+}
+
+// AddNotifier подключает канал уведомлений, который стал доступен после старта (например, телеграм-бот)
+func (s *Sweeper) AddNotifier(n Notifier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notifiers = append(s.notifiers, n)
+}
+
+func (s *Sweeper) Run() {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.sweep()
+		<-ticker.C
+	}
+}
+
+func (s *Sweeper) sweep() {
+	finished, err := s.autoFinishUC.Execute(s.config.InactiveFor)
+	if err != nil {
+		slog.Error("auto finish failed", "err", err)
+		return
+	}
+
+	s.mu.RLock()
+	notifiers := append([]Notifier(nil), s.notifiers...)
+	s.mu.RUnlock()
+
+	for _, w := range finished {
+		slog.Info("workout auto finished", "workout_id", w.WorkoutID, "ended_at", w.EndedAt)
+		for _, n := range notifiers {
+			if notifyErr := n.NotifyAutoFinished(w); notifyErr != nil {
+				slog.Error("auto finish notify failed", "workout_id", w.WorkoutID, "err", notifyErr)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package denylist
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/revokedtokens"
+)
+
+// запас на рассинхрон часов между инстансами при подтягивании новых записей
+const syncOverlap = time.Minute
+
+// Denylist держит в памяти отозванные jti и id сессий, чтобы middleware не ходил в базу на каждый запрос.
+// Источник правды — таблица revoked_tokens, память периодически досинхронизируется с ней.
+type Denylist struct {
+	repo revokedtokens.Repo
+
+	mu       sync.RWMutex
+	items    map[string]time.Time // id -> когда запись можно забыть
+	syncedAt time.Time
+}
+
+func New(repo revokedtokens.Repo) *Denylist {
+	return &Denylist{
+		repo:  repo,
+		items: make(map[string]time.Time),
+	}
+}
+
+// Add отзывает id до expiresAt; после этого момента токен отвергнет проверка exp
+func (d *Denylist) Add(ids []string, expiresAt time.Time) error {
+	tokens := make([]models.RevokedToken, 0, len(ids))
+	for _, id := range ids {
+		tokens = append(tokens, models.RevokedToken{ID: id, ExpiresAt: expiresAt})
+	}
+	if err := d.repo.Add(tokens); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	for _, id := range ids {
+		d.items[id] = expiresAt
+	}
+	d.mu.Unlock()
+	return nil
+}
+
+func (d *Denylist) Contains(ids ...string) bool {
+	now := time.Now()
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		if expiresAt, ok := d.items[id]; ok && now.Before(expiresAt) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sync подтягивает записи, добавленные другими инстансами, и чистит истекшие
+func (d *Denylist) Sync() error {
+	now := time.Now()
+
+	d.mu.RLock()
+	since := d.syncedAt
+	d.mu.RUnlock()
+	if !since.IsZero() {
+		since = since.Add(-syncOverlap)
+	}
+
+	tokens, err := d.repo.FindCreatedSince(since)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	for _, t := range tokens {
+		d.items[t.ID] = t.ExpiresAt
+	}
+	for id, expiresAt := range d.items {
+		if !now.Before(expiresAt) {
+			delete(d.items, id)
+		}
+	}
+	d.syncedAt = now
+	d.mu.Unlock()
+
+	return d.repo.DeleteExpired(now)
+}
+
+func (d *Denylist) Run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := d.Sync(); err != nil {
+			slog.Error("denylist sync failed", "err", err)
+		}
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/constants"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/service/docgenerator/helpers"
-	summarysvc "github.com/SaenkoDmitry/training-tg-bot/internal/service/summary"
-	"github.com/SaenkoDmitry/training-tg-bot/internal/utils"
 	"github.com/xuri/excelize/v2"
 )
 
@@ -19,13 +17,17 @@ const (
 	ByExerciseSummarySheet      = "Динамика"
 )
 
+// WorkoutBatches отдает тренировки пачками в yield (например, workouts.Repo.Iterate);
+// ошибка из yield должна прерывать обход и возвращаться
+type WorkoutBatches func(yield func(batch []models.WorkoutDay) error) error
+
+// ExportWorkoutsToFile пишет тренировки потоково за один проход, а сводки строит по дневным
+// и недельным агрегатам (rollups.Repo). Файл может держать временные данные на диске,
+// после записи его нужно закрыть
 func (s *serviceImpl) ExportWorkoutsToFile(
-	workouts []models.WorkoutDay,
-	summary map[string]*summarysvc.ExerciseSummary,
-	byDateSummary map[string]*summarysvc.DateSummary,
-	exerciseProgressByDates []*summarysvc.ExerciseProgressByDates,
+	batches WorkoutBatches,
+	daily, weekly []models.StatsRollup,
 	groupCodesMap map[string]string,
-	byWeekAndExerciseTypeSummary map[utils.DateRange]map[string]*summarysvc.WeekSummary,
 ) (*excelize.File, error) {
 	f := excelize.NewFile()
 
@@ -33,18 +35,19 @@ func (s *serviceImpl) ExportWorkoutsToFile(
 	greedHeaderStyle := helpers.HeaderStyle(f, constants.GreenColor)
 	blueHeaderStyle := helpers.HeaderStyle(f, constants.BlueColor)
 
-	s.writeWorkoutsSheet(f, workouts, groupCodesMap)
-	s.writeTotalSummarySheet(f, summary)
-	s.writeByDateSummarySheet(f, byDateSummary)
-	s.writeByWeekAndExTypeSummarySheet(f, byWeekAndExerciseTypeSummary)
-	s.writeWorkoutProgressChartsSheet(f, exerciseProgressByDates, redHeaderStyle, greedHeaderStyle, blueHeaderStyle)
+	if err := s.writeWorkoutsSheet(f, batches, groupCodesMap, blueHeaderStyle); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	s.writeTotalSummarySheet(f, s.summaryService.BuildTotal(daily, groupCodesMap))
+	s.writeByDateSummarySheet(f, s.summaryService.BuildByDate(daily))
+	s.writeByWeekAndExTypeSummarySheet(f, s.summaryService.BuildByWeekAndExType(weekly, groupCodesMap))
+	s.writeWorkoutProgressChartsSheet(f, s.summaryService.BuildExerciseProgressByDates(daily, weekly), redHeaderStyle, greedHeaderStyle, blueHeaderStyle)
 
-	_ = f.SetRowStyle(WorkoutSheet, 1, 1, blueHeaderStyle)
 	_ = f.SetRowStyle(TotalSummarySheet, 1, 1, redHeaderStyle)
 	_ = f.SetRowStyle(ByWeekAndExTypeSummarySheet, 1, 1, greedHeaderStyle)
 	_ = f.SetRowStyle(ByDateSummarySheet, 1, 1, greedHeaderStyle)
 
-	helpers.AutoFitColumns(f, WorkoutSheet, 1, 8)
 	helpers.AutoFitColumns(f, TotalSummarySheet, 1, 7)
 	helpers.AutoFitColumns(f, ByWeekAndExTypeSummarySheet, 1, 10)
 	helpers.AutoFitColumns(f, ByDateSummarySheet, 1, 6)
@@ -0,0 +1,213 @@
+package docgenerator
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/rollups"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/summary"
+)
+
+const (
+	exercisesPerWorkout = 6
+	setsPerExercise     = 5
+	fiveYears           = 5 * 365
+)
+
+var (
+	testDayType       = &models.WorkoutDayType{ID: 1, Name: "Верх"}
+	testExerciseTypes = []*models.ExerciseType{
+		{ID: 1, Name: "Жим лежа", ExerciseGroupTypeCode: "chest", Units: "reps,weight"},
+		{ID: 2, Name: "Тяга штанги", ExerciseGroupTypeCode: "back", Units: "reps,weight"},
+		{ID: 3, Name: "Приседания", ExerciseGroupTypeCode: "legs", Units: "reps,weight"},
+		{ID: 4, Name: "Подтягивания", ExerciseGroupTypeCode: "back", Units: "reps"},
+		{ID: 5, Name: "Бег", ExerciseGroupTypeCode: "cardio", Units: "minutes,meters"},
+		{ID: 6, Name: "Жим стоя", ExerciseGroupTypeCode: "shoulders", Units: "reps,weight"},
+	}
+	testGroupCodes = map[string]string{
+		"chest": "Грудь", "back": "Спина", "legs": "Ноги", "cardio": "Кардио", "shoulders": "Плечи",
+	}
+)
+
+// history — ежедневные тренировки за days дней, новые первыми, как их отдает workouts.Repo.Iterate.
+// Пачка строится только когда ее запросили, так что в памяти генератора ничего не копится.
+// onBatch вызывается, пока пачка еще жива, — в этот момент память выгрузки максимальна
+func history(days, batchSize int, onBatch func()) WorkoutBatches {
+	end := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	return func(yield func(batch []models.WorkoutDay) error) error {
+		for from := 0; from < days; from += batchSize {
+			batch := make([]models.WorkoutDay, 0, batchSize)
+			for day := from; day < min(from+batchSize, days); day++ {
+				batch = append(batch, testWorkout(int64(days-day), end.AddDate(0, 0, -day)))
+			}
+			if err := yield(batch); err != nil {
+				return err
+			}
+			if onBatch != nil {
+				onBatch()
+			}
+			runtime.KeepAlive(batch)
+		}
+		return nil
+	}
+}
+
+func testWorkout(id int64, startedAt time.Time) models.WorkoutDay {
+	endedAt := startedAt.Add(time.Hour)
+	w := models.WorkoutDay{
+		ID: id, StartedAt: startedAt, EndedAt: &endedAt, Completed: true,
+		WorkoutDayTypeID: testDayType.ID, WorkoutDayType: testDayType,
+	}
+	for i := 0; i < exercisesPerWorkout; i++ {
+		exType := testExerciseTypes[i%len(testExerciseTypes)]
+		e := models.Exercise{ID: id*exercisesPerWorkout + int64(i), ExerciseType: exType, ExerciseTypeID: exType.ID, Index: i}
+		for j := 0; j < setsPerExercise; j++ {
+			s := models.Set{Index: j, Completed: true, CompletedAt: &endedAt}
+			if exType.ExerciseGroupTypeCode == "cardio" {
+				s.Minutes, s.Meters = 10, 1500
+			} else {
+				s.Reps, s.Weight = 10, float32(40+id%20)
+			}
+			e.Sets = append(e.Sets, s)
+		}
+		w.Exercises = append(w.Exercises, e)
+	}
+	return w
+}
+
+// testRollups — дневные и недельные агрегаты той же истории, как их считает rollups.Repo
+func testRollups(days int) (daily, weekly []models.StatsRollup) {
+	end := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	weeks := make(map[time.Time]map[int64]*models.StatsRollup)
+	var ordered []*models.StatsRollup
+	for day := days - 1; day >= 0; day-- {
+		w := testWorkout(int64(days-day), end.AddDate(0, 0, -day))
+		period := rollups.LocalDay(w.StartedAt)
+		for _, e := range w.Exercises {
+			r := models.StatsRollup{Period: period, ExerciseTypeID: e.ExerciseTypeID, ExerciseType: e.ExerciseType,
+				GroupCode: e.ExerciseType.ExerciseGroupTypeCode, Workouts: 1}
+			for _, set := range e.Sets {
+				r.Sets++
+				r.Reps += set.Reps
+				r.Tonnage += float64(set.Weight) * float64(set.Reps)
+				r.Minutes += set.Minutes
+				r.Meters += set.Meters
+				r.MaxWeight, r.MaxWeightReps = float64(set.Weight), set.Reps
+				r.MinMinutes, r.MaxMinutes, r.MinMeters, r.MaxMeters = set.Minutes, set.Minutes, set.Meters, set.Meters
+			}
+			daily = append(daily, r)
+
+			monday := rollups.WeekStart(period)
+			if weeks[monday] == nil {
+				weeks[monday] = make(map[int64]*models.StatsRollup)
+			}
+			wr, ok := weeks[monday][r.ExerciseTypeID]
+			if !ok {
+				wr = &models.StatsRollup{Period: monday, ExerciseTypeID: r.ExerciseTypeID, ExerciseType: r.ExerciseType, GroupCode: r.GroupCode}
+				weeks[monday][r.ExerciseTypeID] = wr
+				ordered = append(ordered, wr)
+			}
+			wr.Workouts += r.Workouts
+			wr.Sets += r.Sets
+			wr.Reps += r.Reps
+			wr.Tonnage += r.Tonnage
+			wr.Minutes += r.Minutes
+			wr.Meters += r.Meters
+			wr.MaxWeight = max(wr.MaxWeight, r.MaxWeight)
+		}
+	}
+	for _, wr := range ordered {
+		weekly = append(weekly, *wr)
+	}
+	return daily, weekly
+}
+
+func TestExportWorkoutsToFile(t *testing.T) {
+	s := NewService(summary.NewService())
+
+	daily, weekly := testRollups(14)
+	f, err := s.ExportWorkoutsToFile(history(14, 5, nil), daily, weekly, testGroupCodes)
+	require.NoError(t, err)
+	defer f.Close()
+
+	rows, err := f.GetRows(WorkoutSheet)
+	require.NoError(t, err)
+	require.Len(t, rows, 1+14*exercisesPerWorkout*setsPerExercise)
+	assert.Equal(t, []string{"2026-01-01", "Верх", "Жим лежа", "Грудь", "1", "54", "10", "0", "0"}, rows[1])
+	assert.Equal(t, "2025-12-19", rows[len(rows)-1][0])
+
+	// сводки собраны по агрегатам той же истории
+	rows, err = f.GetRows(TotalSummarySheet)
+	require.NoError(t, err)
+	assert.Len(t, rows, 1+len(testExerciseTypes))
+
+	rows, err = f.GetRows(ByDateSummarySheet)
+	require.NoError(t, err)
+	assert.Len(t, rows, 1+14)
+
+	assert.Equal(t, WorkoutSheet, f.GetSheetName(0))
+	assert.Equal(t, -1, func() int { idx, _ := f.GetSheetIndex(DefaultSheet); return idx }())
+}
+
+func TestExportWorkoutsToFileStopsOnError(t *testing.T) {
+	s := NewService(summary.NewService())
+
+	failing := func(yield func(batch []models.WorkoutDay) error) error {
+		return assert.AnError
+	}
+	_, err := s.ExportWorkoutsToFile(failing, nil, nil, testGroupCodes)
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+// BenchmarkExportWorkoutsToFile выгружает годы ежедневных тренировок (5 лет — ~55 тыс. подходов)
+// и сообщает живую кучу: batch-heap-MB — пока идут пачки, peak-heap-MB — с листами сводок.
+// Строки листа тренировок копятся в памяти только до 16 МБ, дальше excelize пишет их во временный
+// файл, поэтому при пачках по 100 рост между 5 и 10 годами дают лишь дневные агрегаты.
+// Одна пачка на всю историю — то, что раньше делал FindAll
+func BenchmarkExportWorkoutsToFile(b *testing.B) {
+	for _, bc := range []struct {
+		name      string
+		days      int
+		batchSize int
+	}{
+		{"1y/batch=100", 365, 100},
+		{"5y/batch=100", fiveYears, 100},
+		{"10y/batch=100", 2 * fiveYears, 100},
+		{"5y/all-at-once", fiveYears, fiveYears},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			s := NewService(summary.NewService())
+			daily, weekly := testRollups(bc.days)
+			var batchPeak, peak uint64
+			var stats runtime.MemStats
+			// после GC в HeapAlloc остается только живая память
+			live := func() uint64 {
+				runtime.GC()
+				runtime.ReadMemStats(&stats)
+				return stats.HeapAlloc
+			}
+			sample := func() { batchPeak = max(batchPeak, live()) }
+
+			b.ReportAllocs()
+			for b.Loop() {
+				runtime.GC()
+				f, err := s.ExportWorkoutsToFile(history(bc.days, bc.batchSize, sample), daily, weekly, testGroupCodes)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err = f.WriteToBuffer(); err != nil {
+					b.Fatal(err)
+				}
+				peak = max(peak, batchPeak, live())
+				_ = f.Close()
+			}
+			b.ReportMetric(float64(batchPeak)/(1<<20), "batch-heap-MB")
+			b.ReportMetric(float64(peak)/(1<<20), "peak-heap-MB")
+		})
+	}
+}
@@ -4,19 +4,11 @@ import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 	summarysvc "github.com/SaenkoDmitry/training-tg-bot/internal/service/summary"
-	"github.com/SaenkoDmitry/training-tg-bot/internal/utils"
 	"github.com/xuri/excelize/v2"
 )
 
 type Service interface {
-	ExportWorkoutsToFile(
-		workouts []models.WorkoutDay,
-		summary map[string]*summarysvc.ExerciseSummary,
-		byDateSummary map[string]*summarysvc.DateSummary,
-		exerciseProgressByDates []*summarysvc.ExerciseProgressByDates,
-		groupCodesMap map[string]string,
-		typeSummary map[utils.DateRange]map[string]*summarysvc.WeekSummary,
-	) (*excelize.File, error)
+	ExportWorkoutsToFile(batches WorkoutBatches, daily, weekly []models.StatsRollup, groupCodesMap map[string]string) (*excelize.File, error)
 
 	ExportMeasurementsToFile(measurements []*dto.Measurement) (*excelize.File, error)
 }
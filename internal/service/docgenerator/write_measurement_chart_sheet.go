@@ -7,6 +7,7 @@ import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/service/docgenerator/helpers"
 	"github.com/xuri/excelize/v2"
+	"log/slog"
 	"strconv"
 )
 
@@ -69,7 +70,7 @@ func (s *serviceImpl) writeMeasurementChartSheet(
 		chart := makeChart(sheet, firstRow, lastRow, ch.RangeSymbol, ch.CategoryName)
 		err := f.AddChart(sheet, fmt.Sprintf("M%d", i*betweenChartRowsCount+2), chart)
 		if err != nil {
-			fmt.Println("error while build chart:", err.Error())
+			slog.Error("build chart failed", "err", err)
 			return
 		}
 	}
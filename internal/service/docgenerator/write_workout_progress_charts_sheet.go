@@ -3,6 +3,7 @@ package docgenerator
 import (
 	"fmt"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/service/docgenerator/helpers"
+	"log/slog"
 	"math"
 	"strconv"
 	"strings"
@@ -132,7 +133,7 @@ func (s *serviceImpl) writeProgressChart(
 
 	err := f.AddChart(sheet, fmt.Sprintf("F%d", firstRow+1), chart)
 	if err != nil {
-		fmt.Println("error while build chart:", err.Error())
+		slog.Error("build chart failed", "err", err)
 	}
 
 	return firstRow + betweenExerciseRows
@@ -1,7 +1,6 @@
 package docgenerator
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/SaenkoDmitry/training-tg-bot/internal/messages"
@@ -9,11 +8,33 @@ import (
 	"github.com/xuri/excelize/v2"
 )
 
-func (s *serviceImpl) writeWorkoutsSheet(f *excelize.File, workouts []models.WorkoutDay, groupCodesMap map[string]string) {
+// ширины колонок листа тренировок задаются заранее: потоковый лист нельзя перечитать для AutoFitColumns
+var workoutsSheetWidths = []float64{14, 30, 40, 20, 12, 10, 10, 10, 10}
+
+// writeWorkoutsSheet пишет лист тренировок потоково: строки уходят в StreamWriter по мере
+// получения пачек, так что вся история в памяти не нужна
+func (s *serviceImpl) writeWorkoutsSheet(
+	f *excelize.File,
+	batches WorkoutBatches,
+	groupCodesMap map[string]string,
+	headerStyle int,
+) error {
 	sheet := WorkoutSheet
-	_, _ = f.NewSheet(sheet)
+	if _, err := f.NewSheet(sheet); err != nil {
+		return err
+	}
 
-	headers := []string{
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+	for i, width := range workoutsSheetWidths {
+		if err = sw.SetColWidth(i+1, i+1, width); err != nil {
+			return err
+		}
+	}
+
+	headers := []interface{}{
 		messages.WorkoutDate,
 		"Тренировка",
 		"Упражнение",
@@ -24,28 +45,41 @@ func (s *serviceImpl) writeWorkoutsSheet(f *excelize.File, workouts []models.Wor
 		messages.Minutes,
 		messages.Meters,
 	}
-
-	for i, h := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		_ = f.SetCellValue(sheet, cell, h)
+	if err = sw.SetRow("A1", headers, excelize.RowOpts{StyleID: headerStyle}); err != nil {
+		return err
 	}
 
 	row := 2
-
-	for _, w := range workouts {
-		for _, e := range w.Exercises {
-			for i, set := range e.Sets {
-				_ = f.SetCellValue(sheet, fmt.Sprintf("A%d", row), w.StartedAt.Add(3*time.Hour).Format("2006-01-02"))
-				_ = f.SetCellValue(sheet, fmt.Sprintf("B%d", row), w.WorkoutDayType.Name)
-				_ = f.SetCellValue(sheet, fmt.Sprintf("C%d", row), e.ExerciseType.Name)
-				_ = f.SetCellValue(sheet, fmt.Sprintf("D%d", row), groupCodesMap[e.ExerciseType.ExerciseGroupTypeCode])
-				_ = f.SetCellValue(sheet, fmt.Sprintf("E%d", row), i+1)
-				_ = f.SetCellValue(sheet, fmt.Sprintf("F%d", row), set.Weight)
-				_ = f.SetCellValue(sheet, fmt.Sprintf("G%d", row), set.Reps)
-				_ = f.SetCellValue(sheet, fmt.Sprintf("H%d", row), set.Minutes)
-				_ = f.SetCellValue(sheet, fmt.Sprintf("I%d", row), set.Meters)
-				row++
+	err = batches(func(batch []models.WorkoutDay) error {
+		for i := range batch {
+			w := &batch[i]
+			date := w.StartedAt.Add(3 * time.Hour).Format("2006-01-02")
+			for _, e := range w.Exercises {
+				for j, set := range e.Sets {
+					cell, _ := excelize.CoordinatesToCellName(1, row)
+					values := []interface{}{
+						date,
+						w.WorkoutDayType.Name,
+						e.ExerciseType.Name,
+						groupCodesMap[e.ExerciseType.ExerciseGroupTypeCode],
+						j + 1,
+						set.Weight,
+						set.Reps,
+						set.Minutes,
+						set.Meters,
+					}
+					if err := sw.SetRow(cell, values); err != nil {
+						return err
+					}
+					row++
+				}
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+
+	return sw.Flush()
 }
@@ -0,0 +1,69 @@
+package eventbus
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+)
+
+// Handler обрабатывает событие. Ошибка означает, что событие будет доставлено этому подписчику повторно,
+// поэтому обработчик должен быть идемпотентным
+type Handler func(event events.Event) error
+
+type subscription struct {
+	name    string
+	handler Handler
+	types   map[events.Type]struct{} // пустой набор — все события
+}
+
+func (s subscription) accepts(t events.Type) bool {
+	if len(s.types) == 0 {
+		return true
+	}
+	_, ok := s.types[t]
+	return ok
+}
+
+// Bus — подписчики доменных событий внутри процесса. Сами события приходят из outbox через Relay
+type Bus struct {
+	mu            sync.RWMutex
+	subscriptions []subscription
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe регистрирует обработчик. Имя сохраняется в outbox как отметка о доставке,
+// поэтому его нельзя менять без повторной доставки уже обработанных событий
+func (b *Bus) Subscribe(name string, handler Handler, types ...events.Type) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, s := range b.subscriptions {
+		if s.name == name {
+			panic(fmt.Sprintf("eventbus: subscriber %q already registered", name))
+		}
+	}
+
+	set := make(map[events.Type]struct{}, len(types))
+	for _, t := range types {
+		set[t] = struct{}{}
+	}
+	b.subscriptions = append(b.subscriptions, subscription{name: name, handler: handler, types: set})
+}
+
+// subscribers возвращает подписчиков события в порядке регистрации
+func (b *Bus) subscribers(t events.Type) []subscription {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	result := make([]subscription, 0, len(b.subscriptions))
+	for _, s := range b.subscriptions {
+		if s.accepts(t) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,172 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+const (
+	pollInterval = time.Second
+	batchSize    = 100
+
+	// после maxAttempts неудачных попыток событие помечается failed_at и больше не доставляется
+	maxAttempts = 10
+	baseBackoff = 5 * time.Second
+	maxBackoff  = time.Hour
+
+	retention       = 7 * 24 * time.Hour
+	cleanupInterval = time.Hour
+)
+
+// Store — часть репозитория outbox, нужная для доставки
+type Store interface {
+	FindPending(now time.Time, limit int) ([]models.OutboxEvent, error)
+	MarkDelivered(eventID int64, subscriber string) error
+	MarkProcessed(eventID int64, at time.Time) error
+	MarkRetry(eventID int64, attempts int, next time.Time, lastError string) error
+	MarkFailed(eventID int64, at time.Time, lastError string) error
+	DeleteProcessedBefore(t time.Time) (int64, error)
+}
+
+// Relay забирает закоммиченные события из outbox и раздаёт их подписчикам шины.
+// Доставка «хотя бы один раз»: подписчик, уже отметившийся в delivered_to, событие повторно не получает,
+// но упавший посреди обработки процесс может повторить последний вызов
+type Relay struct {
+	store Store
+	bus   *Bus
+}
+
+func NewRelay(store Store, bus *Bus) *Relay {
+	return &Relay{
+		store: store,
+		bus:   bus,
+	}
+}
+
+func (r *Relay) Run() {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	lastCleanup := time.Time{}
+	for {
+		now := time.Now()
+		for r.dispatch(now) == batchSize {
+			now = time.Now()
+		}
+
+		if now.Sub(lastCleanup) >= cleanupInterval {
+			if deleted, err := r.store.DeleteProcessedBefore(now.Add(-retention)); err != nil {
+				slog.Error("outbox cleanup failed", "err", err)
+			} else if deleted > 0 {
+				slog.Info("outbox cleanup finished", "deleted", deleted)
+			}
+			lastCleanup = now
+		}
+
+		<-ticker.C
+	}
+}
+
+// dispatch доставляет одну пачку событий и возвращает её размер
+func (r *Relay) dispatch(now time.Time) int {
+	pending, err := r.store.FindPending(now, batchSize)
+	if err != nil {
+		slog.Error("outbox fetch failed", "err", err)
+		return 0
+	}
+
+	for i := range pending {
+		r.deliver(&pending[i], now)
+	}
+	return len(pending)
+}
+
+func (r *Relay) deliver(row *models.OutboxEvent, now time.Time) {
+	event, err := events.Decode(events.Type(row.Type), []byte(row.Payload))
+	if err != nil {
+		// повтор не поможет: payload не прочитает и следующая попытка
+		r.fail(row, now, err.Error())
+		return
+	}
+
+	var delivered []string
+	_ = json.Unmarshal([]byte(row.DeliveredTo), &delivered)
+
+	var errs []string
+	for _, s := range r.bus.subscribers(event.Type()) {
+		if contains(delivered, s.name) {
+			continue
+		}
+		if handleErr := safeHandle(s.handler, event); handleErr != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", s.name, handleErr.Error()))
+			continue
+		}
+		if markErr := r.store.MarkDelivered(row.ID, s.name); markErr != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", s.name, markErr.Error()))
+		}
+	}
+
+	if len(errs) == 0 {
+		if err = r.store.MarkProcessed(row.ID, now); err != nil {
+			slog.Error("outbox mark processed failed", "event_id", row.ID, "err", err)
+		}
+		return
+	}
+
+	lastError := strings.Join(errs, "; ")
+	attempts := row.Attempts + 1
+	if attempts >= maxAttempts {
+		r.fail(row, now, lastError)
+		return
+	}
+	if err = r.store.MarkRetry(row.ID, attempts, now.Add(Backoff(attempts)), lastError); err != nil {
+		slog.Error("outbox mark retry failed", "event_id", row.ID, "err", err)
+	}
+}
+
+func (r *Relay) fail(row *models.OutboxEvent, now time.Time, lastError string) {
+	slog.Error("outbox event failed", "event_id", row.ID, "type", row.Type, "err", lastError)
+	if err := r.store.MarkFailed(row.ID, now, lastError); err != nil {
+		slog.Error("cannot mark outbox event as failed", "event_id", row.ID, "err", err)
+	}
+}
+
+// Backoff — пауза перед попыткой attempts+1: 5s, 10s, 20s ... но не больше часа
+func Backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	d := baseBackoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return d
+}
+
+// safeHandle не даёт панике одного подписчика остановить доставку остальным
+func safeHandle(handler Handler, event events.Event) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("panic: %v", rec)
+		}
+	}()
+	return handler(event)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
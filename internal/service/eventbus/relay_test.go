@@ -0,0 +1,189 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+type storeStub struct {
+	rows      []models.OutboxEvent
+	delivered map[int64][]string
+	processed map[int64]time.Time
+	retries   map[int64]time.Time
+	failed    map[int64]string
+}
+
+func newStoreStub(rows ...models.OutboxEvent) *storeStub {
+	return &storeStub{
+		rows:      rows,
+		delivered: map[int64][]string{},
+		processed: map[int64]time.Time{},
+		retries:   map[int64]time.Time{},
+		failed:    map[int64]string{},
+	}
+}
+
+func (s *storeStub) FindPending(now time.Time, limit int) ([]models.OutboxEvent, error) {
+	var result []models.OutboxEvent
+	for _, row := range s.rows {
+		if _, ok := s.processed[row.ID]; ok {
+			continue
+		}
+		if _, ok := s.failed[row.ID]; ok {
+			continue
+		}
+		if next, ok := s.retries[row.ID]; ok && next.After(now) {
+			continue
+		}
+		delivered, _ := json.Marshal(append([]string{}, s.delivered[row.ID]...))
+		row.DeliveredTo = string(delivered)
+		result = append(result, row)
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (s *storeStub) MarkDelivered(eventID int64, subscriber string) error {
+	s.delivered[eventID] = append(s.delivered[eventID], subscriber)
+	return nil
+}
+
+func (s *storeStub) MarkProcessed(eventID int64, at time.Time) error {
+	s.processed[eventID] = at
+	return nil
+}
+
+func (s *storeStub) MarkRetry(eventID int64, attempts int, next time.Time, _ string) error {
+	for i := range s.rows {
+		if s.rows[i].ID == eventID {
+			s.rows[i].Attempts = attempts
+		}
+	}
+	s.retries[eventID] = next
+	return nil
+}
+
+func (s *storeStub) MarkFailed(eventID int64, _ time.Time, lastError string) error {
+	s.failed[eventID] = lastError
+	return nil
+}
+
+func (s *storeStub) DeleteProcessedBefore(time.Time) (int64, error) {
+	return 0, nil
+}
+
+func outboxRow(t *testing.T, id int64, event events.Event) models.OutboxEvent {
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+	return models.OutboxEvent{ID: id, Type: string(event.Type()), Payload: string(payload), DeliveredTo: "[]"}
+}
+
+func TestRelayDeliversToMatchingSubscribers(t *testing.T) {
+	store := newStoreStub(
+		outboxRow(t, 1, events.WorkoutFinished{WorkoutID: 10, UserID: 7}),
+		outboxRow(t, 2, events.MeasurementCreated{MeasurementID: 3, UserID: 7}),
+	)
+
+	var finished, all []events.Event
+	bus := NewBus()
+	bus.Subscribe("finished", func(e events.Event) error {
+		finished = append(finished, e)
+		return nil
+	}, events.TypeWorkoutFinished)
+	bus.Subscribe("all", func(e events.Event) error {
+		all = append(all, e)
+		return nil
+	})
+
+	now := time.Now()
+	assert.Equal(t, 2, NewRelay(store, bus).dispatch(now))
+
+	require.Len(t, finished, 1)
+	assert.Equal(t, events.WorkoutFinished{WorkoutID: 10, UserID: 7}, finished[0])
+	assert.Len(t, all, 2)
+	assert.Len(t, store.processed, 2)
+	assert.Equal(t, []string{"finished", "all"}, store.delivered[1])
+}
+
+func TestRelayRetriesOnlyFailedSubscriber(t *testing.T) {
+	store := newStoreStub(outboxRow(t, 1, events.WorkoutFinished{WorkoutID: 10, UserID: 7}))
+
+	okCalls, flakyCalls := 0, 0
+	bus := NewBus()
+	bus.Subscribe("ok", func(events.Event) error {
+		okCalls++
+		return nil
+	})
+	bus.Subscribe("flaky", func(events.Event) error {
+		flakyCalls++
+		if flakyCalls == 1 {
+			return errors.New("temporary")
+		}
+		return nil
+	})
+	relay := NewRelay(store, bus)
+
+	now := time.Now()
+	relay.dispatch(now)
+	assert.Empty(t, store.processed)
+	assert.Equal(t, now.Add(Backoff(1)), store.retries[1])
+
+	// до истечения паузы событие не берётся
+	assert.Zero(t, relay.dispatch(now.Add(time.Second)))
+
+	relay.dispatch(now.Add(Backoff(1)))
+	assert.Equal(t, 1, okCalls)
+	assert.Equal(t, 2, flakyCalls)
+	assert.Contains(t, store.processed, int64(1))
+}
+
+func TestRelayMarksFailedAfterMaxAttempts(t *testing.T) {
+	row := outboxRow(t, 1, events.SetCompleted{SetID: 5, UserID: 7})
+	row.Attempts = maxAttempts - 1
+	store := newStoreStub(row)
+
+	bus := NewBus()
+	bus.Subscribe("broken", func(events.Event) error {
+		panic("boom")
+	})
+
+	NewRelay(store, bus).dispatch(time.Now())
+
+	assert.Equal(t, "broken: panic: boom", store.failed[1])
+	assert.Empty(t, store.processed)
+}
+
+func TestRelayFailsUnknownEventType(t *testing.T) {
+	store := newStoreStub(models.OutboxEvent{ID: 1, Type: "unknown", Payload: "{}", DeliveredTo: "[]"})
+
+	NewRelay(store, NewBus()).dispatch(time.Now())
+
+	assert.Contains(t, store.failed, int64(1))
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 5*time.Second, Backoff(0))
+	assert.Equal(t, 5*time.Second, Backoff(1))
+	assert.Equal(t, 10*time.Second, Backoff(2))
+	assert.Equal(t, 40*time.Second, Backoff(4))
+	assert.Equal(t, time.Hour, Backoff(20))
+}
+
+func TestBusRejectsDuplicateSubscriber(t *testing.T) {
+	bus := NewBus()
+	bus.Subscribe("achievements", func(events.Event) error { return nil })
+
+	assert.Panics(t, func() {
+		bus.Subscribe("achievements", func(events.Event) error { return nil })
+	})
+}
@@ -0,0 +1,105 @@
+package feeddigest
+
+import (
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	socialusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/social"
+)
+
+const (
+	defaultPeriod   = 24 * time.Hour
+	defaultInterval = time.Hour
+)
+
+type Config struct {
+	Period   time.Duration // как часто пользователь получает сводку
+	Interval time.Duration // как часто искать получателей
+}
+
+// ConfigFromEnv читает FEED_DIGEST_PERIOD и FEED_DIGEST_INTERVAL (формат time.ParseDuration, например 24h или 30m)
+func ConfigFromEnv() Config {
+	return Config{
+		Period:   durationFromEnv("FEED_DIGEST_PERIOD", defaultPeriod),
+		Interval: durationFromEnv("FEED_DIGEST_INTERVAL", defaultInterval),
+	}
+}
+
+func durationFromEnv(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		slog.Warn("invalid duration setting, using default", "key", key, "value", value, "default", def)
+		return def
+	}
+	return d
+}
+
+// Notifier доставляет сводку активности подписок
+type Notifier interface {
+	NotifyFeedDigest(digest dto.FeedDigest) error
+}
+
+type Runner struct {
+	buildDigestsUC *socialusecases.BuildDigestsUseCase
+	config         Config
+
+	mu        sync.RWMutex
+	notifiers []Notifier
+}
+
+func NewRunner(buildDigestsUC *socialusecases.BuildDigestsUseCase, config Config, notifiers ...Notifier) *Runner {
+	return &Runner{
+		buildDigestsUC: buildDigestsUC,
+		config:         config,
+		notifiers:      notifiers,
+	}
+}
+
+// AddNotifier подключает канал доставки, который стал доступен после старта (например, телеграм-бот)
+func (r *Runner) AddNotifier(n Notifier) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notifiers = append(r.notifiers, n)
+}
+
+func (r *Runner) Run() {
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		r.send()
+		<-ticker.C
+	}
+}
+
+func (r *Runner) send() {
+	r.mu.RLock()
+	notifiers := append([]Notifier(nil), r.notifiers...)
+	r.mu.RUnlock()
+
+	// сборка отмечает сводки отправленными, поэтому без каналов доставки ждем следующего тика
+	if len(notifiers) == 0 {
+		return
+	}
+
+	digests, err := r.buildDigestsUC.Execute(time.Now(), r.config.Period)
+	if err != nil {
+		slog.Error("feed digest failed", "err", err)
+		return
+	}
+
+	for _, d := range digests {
+		for _, n := range notifiers {
+			if notifyErr := n.NotifyFeedDigest(d); notifyErr != nil {
+				slog.Error("feed digest notify failed", "err", notifyErr)
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package leaderboard
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	challengeusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/challenges"
+)
+
+const defaultFinishInterval = 5 * time.Minute
+
+// Notifier рассылает участникам итоги челленджа
+type Notifier interface {
+	NotifyChallengeFinished(result dto.ChallengeResult) error
+}
+
+type Finisher struct {
+	finishUC *challengeusecases.FinishUseCase
+	interval time.Duration
+
+	mu        sync.RWMutex
+	notifiers []Notifier
+}
+
+func NewFinisher(finishUC *challengeusecases.FinishUseCase, notifiers ...Notifier) *Finisher {
+	return &Finisher{
+		finishUC:  finishUC,
+		interval:  defaultFinishInterval,
+		notifiers: notifiers,
+	}
+}
+
+// AddNotifier подключает канал уведомлений, который стал доступен после старта (например, телеграм-бот)
+func (f *Finisher) AddNotifier(n Notifier) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.notifiers = append(f.notifiers, n)
+}
+
+// Run ждет первый тик перед подведением итогов: за это время успевает подключиться телеграм-бот
+func (f *Finisher) Run() {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		f.finish()
+	}
+}
+
+func (f *Finisher) finish() {
+	results, err := f.finishUC.Execute(time.Now())
+	if err != nil {
+		slog.Error("challenge finish failed", "err", err)
+		return
+	}
+
+	f.mu.RLock()
+	notifiers := append([]Notifier(nil), f.notifiers...)
+	f.mu.RUnlock()
+
+	for _, r := range results {
+		slog.Info("challenge finished", "challenge_id", r.Challenge.ID, "participants", len(r.Recipients))
+		for _, n := range notifiers {
+			if notifyErr := n.NotifyChallengeFinished(r); notifyErr != nil {
+				slog.Error("challenge notify failed", "challenge_id", r.Challenge.ID, "err", notifyErr)
+			}
+		}
+	}
+}
+
+// Describe — заголовок и текст итогов для конкретного участника
+func Describe(result dto.ChallengeResult, recipient dto.ChallengeRecipient) (string, string) {
+	title := fmt.Sprintf("🏁 Челлендж «%s» завершён", result.Challenge.Title)
+	body := fmt.Sprintf("Ваше место: %d из %d, результат: %s", recipient.Rank, len(result.Recipients),
+		FormatScore(result.Challenge.Metric, recipient.Score))
+	if len(result.Leaderboard) > 0 && recipient.Rank != 1 {
+		winner := result.Leaderboard[0]
+		body += fmt.Sprintf(". Победитель — %s (%s)", winner.User.Name, FormatScore(result.Challenge.Metric, winner.Score))
+	}
+	return title, body
+}
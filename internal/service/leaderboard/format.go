@@ -0,0 +1,24 @@
+package leaderboard
+
+import (
+	"fmt"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+// FormatScore выводит результат в единицах метрики челленджа
+func FormatScore(metric string, score float64) string {
+	switch metric {
+	case models.ChallengeMetricWorkouts:
+		return fmt.Sprintf("%.0f трен.", score)
+	case models.ChallengeMetricTonnage:
+		return fmt.Sprintf("%.0f кг", score)
+	case models.ChallengeMetricCardioMinutes:
+		return fmt.Sprintf("%.0f мин", score)
+	case models.ChallengeMetricMeters:
+		return fmt.Sprintf("%.0f м", score)
+	case models.ChallengeMetricE1RM:
+		return fmt.Sprintf("%.1f кг", score)
+	}
+	return fmt.Sprintf("%g", score)
+}
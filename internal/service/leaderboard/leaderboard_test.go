@@ -0,0 +1,55 @@
+package leaderboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/realtime"
+)
+
+func TestTrackerCollectsChangedWorkouts(t *testing.T) {
+	tracker := NewTracker(nil)
+	hub := realtime.NewHub()
+	hub.AddListener(tracker.Listen)
+
+	hub.Publish(realtime.Event{Type: realtime.SetCompleted, WorkoutID: 1, SetID: 10})
+	hub.Publish(realtime.Event{Type: realtime.SetChanged, WorkoutID: 1, SetID: 11})
+	hub.Publish(realtime.Event{Type: realtime.SetRemoved, WorkoutID: 2, SetID: 20})
+
+	// таймеры и переходы между упражнениями на результат не влияют
+	hub.Publish(realtime.Event{Type: realtime.TimerStarted, WorkoutID: 3})
+	hub.Publish(realtime.Event{Type: realtime.ExerciseMoved, WorkoutID: 4})
+
+	assert.Equal(t, map[int64]struct{}{1: {}, 2: {}}, tracker.dirty)
+}
+
+func TestDescribe(t *testing.T) {
+	result := dto.ChallengeResult{
+		Challenge: dto.ChallengeItem{ID: 5, Title: "Март", Metric: models.ChallengeMetricTonnage},
+		Leaderboard: []dto.LeaderboardEntry{
+			{Rank: 1, User: dto.SocialUser{ID: 1, Name: "Аня"}, Score: 12500},
+			{Rank: 2, User: dto.SocialUser{ID: 2, Name: "Петя"}, Score: 9800.4},
+		},
+		Recipients: []dto.ChallengeRecipient{
+			{UserID: 1, Rank: 1, Score: 12500},
+			{UserID: 2, Rank: 2, Score: 9800.4},
+		},
+	}
+
+	title, body := Describe(result, result.Recipients[0])
+	assert.Equal(t, "🏁 Челлендж «Март» завершён", title)
+	assert.Equal(t, "Ваше место: 1 из 2, результат: 12500 кг", body)
+
+	_, body = Describe(result, result.Recipients[1])
+	assert.Equal(t, "Ваше место: 2 из 2, результат: 9800 кг. Победитель — Аня (12500 кг)", body)
+}
+
+func TestFormatScore(t *testing.T) {
+	assert.Equal(t, "12 трен.", FormatScore(models.ChallengeMetricWorkouts, 12))
+	assert.Equal(t, "95 мин", FormatScore(models.ChallengeMetricCardioMinutes, 95))
+	assert.Equal(t, "5000 м", FormatScore(models.ChallengeMetricMeters, 5000))
+	assert.Equal(t, "116.7 кг", FormatScore(models.ChallengeMetricE1RM, 116.66))
+}
@@ -0,0 +1,24 @@
+package leaderboard
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/push"
+)
+
+type pushNotifier struct {
+	push *push.Service
+}
+
+func NewPushNotifier(push *push.Service) Notifier {
+	return &pushNotifier{push: push}
+}
+
+func (n *pushNotifier) NotifyChallengeFinished(result dto.ChallengeResult) error {
+	for _, r := range result.Recipients {
+		title, body := Describe(result, r)
+		if err := n.push.SendChallengeFinished(r.UserID, result.Challenge.ID, title, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package leaderboard
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	challengeusecases "github.com/SaenkoDmitry/training-tg-bot/internal/application/usecase/challenges"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/realtime"
+)
+
+const defaultFlushInterval = 5 * time.Second
+
+// Tracker копит тренировки, в которых менялись подходы, и пачкой пересчитывает их вклад в челленджи.
+// Несколько отметок подряд в одной тренировке дают один пересчет
+type Tracker struct {
+	recalculateUC *challengeusecases.RecalculateUseCase
+	interval      time.Duration
+
+	mu    sync.Mutex
+	dirty map[int64]struct{}
+}
+
+func NewTracker(recalculateUC *challengeusecases.RecalculateUseCase) *Tracker {
+	return &Tracker{
+		recalculateUC: recalculateUC,
+		interval:      defaultFlushInterval,
+		dirty:         make(map[int64]struct{}),
+	}
+}
+
+// Listen подключается к realtime.Hub через AddListener
+func (t *Tracker) Listen(event realtime.Event) {
+	switch event.Type {
+	case realtime.SetCompleted, realtime.SetChanged, realtime.SetAdded, realtime.SetRemoved:
+	default:
+		return
+	}
+
+	t.mu.Lock()
+	t.dirty[event.WorkoutID] = struct{}{}
+	t.mu.Unlock()
+}
+
+func (t *Tracker) Run() {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		t.flush()
+	}
+}
+
+func (t *Tracker) flush() {
+	t.mu.Lock()
+	if len(t.dirty) == 0 {
+		t.mu.Unlock()
+		return
+	}
+	workoutIDs := make([]int64, 0, len(t.dirty))
+	for id := range t.dirty {
+		workoutIDs = append(workoutIDs, id)
+	}
+	t.dirty = make(map[int64]struct{})
+	t.mu.Unlock()
+
+	if err := t.recalculateUC.Execute(workoutIDs); err != nil {
+		slog.Error("leaderboard recalculate failed", "err", err)
+
+		// вернем тренировки в очередь, чтобы пересчитать их на следующем тике
+		t.mu.Lock()
+		for _, id := range workoutIDs {
+			t.dirty[id] = struct{}{}
+		}
+		t.mu.Unlock()
+	}
+}
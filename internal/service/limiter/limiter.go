@@ -1,15 +1,20 @@
 package limiter
 
 import (
+	"strconv"
 	"sync"
 	"time"
 )
 
+// RateLimiter пропускает не больше tokens запросов на ключ за interval. Окно отсчитывается от первого запроса,
+// ключи с истекшим окном периодически удаляются, чтобы память не росла от разовых ip и адресов
 type RateLimiter struct {
-	tokens   int
-	buckets  map[int64]*tokenBucket // userID -> bucket
-	mu       sync.Mutex
-	interval time.Duration
+	tokens    int
+	buckets   map[string]*tokenBucket
+	mu        sync.Mutex
+	interval  time.Duration
+	lastSweep time.Time
+	now       func() time.Time
 }
 
 type tokenBucket struct {
@@ -19,32 +24,51 @@ type tokenBucket struct {
 
 func NewRateLimiter(tokens int, interval time.Duration) *RateLimiter {
 	return &RateLimiter{
-		tokens:   tokens,
-		buckets:  make(map[int64]*tokenBucket),
-		interval: interval,
+		tokens:    tokens,
+		buckets:   make(map[string]*tokenBucket),
+		interval:  interval,
+		lastSweep: time.Now(),
+		now:       time.Now,
 	}
 }
 
 func (rl *RateLimiter) Allow(userID int64) bool {
+	return rl.AllowKey(strconv.FormatInt(userID, 10))
+}
+
+// AllowKey — то же ограничение для строковых ключей (email, ip), которые не являются id пользователя
+func (rl *RateLimiter) AllowKey(key string) bool {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	bucket, exists := rl.buckets[userID]
-	now := time.Now()
+	now := rl.now()
+	rl.sweep(now)
 
+	bucket, exists := rl.buckets[key]
 	if !exists || now.Sub(bucket.lastReset) > rl.interval {
-		// Новый bucket: 10 созданий в минуту
-		rl.buckets[userID] = &tokenBucket{
+		bucket = &tokenBucket{
 			tokens:    rl.tokens,
 			lastReset: now,
 		}
-		return true
+		rl.buckets[key] = bucket
 	}
 
 	if bucket.tokens > 0 {
 		bucket.tokens--
 		return true
 	}
-
 	return false
 }
+
+// sweep не чаще раза за interval удаляет ключи, окно которых уже закончилось; вызывать под rl.mu
+func (rl *RateLimiter) sweep(now time.Time) {
+	if now.Sub(rl.lastSweep) <= rl.interval {
+		return
+	}
+	for key, bucket := range rl.buckets {
+		if now.Sub(bucket.lastReset) > rl.interval {
+			delete(rl.buckets, key)
+		}
+	}
+	rl.lastSweep = now
+}
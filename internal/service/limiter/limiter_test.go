@@ -0,0 +1,63 @@
+package limiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestLimiter(tokens int, interval time.Duration) (*RateLimiter, *clock) {
+	c := &clock{now: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)}
+	rl := NewRateLimiter(tokens, interval)
+	rl.now = func() time.Time { return c.now }
+	rl.lastSweep = c.now
+	return rl, c
+}
+
+func TestAllowKeyLimitsPerKey(t *testing.T) {
+	rl, _ := newTestLimiter(3, time.Minute)
+
+	for i := range 3 {
+		assert.True(t, rl.AllowKey("a@example.com"), "попытка %d", i+1)
+	}
+	assert.False(t, rl.AllowKey("a@example.com"))
+	assert.True(t, rl.AllowKey("b@example.com"), "другой ключ считается отдельно")
+}
+
+func TestAllowKeyResetsAfterInterval(t *testing.T) {
+	rl, c := newTestLimiter(1, time.Minute)
+
+	assert.True(t, rl.AllowKey("10.0.0.1"))
+	assert.False(t, rl.AllowKey("10.0.0.1"))
+
+	c.advance(time.Minute)
+	assert.False(t, rl.AllowKey("10.0.0.1"), "окно еще не закончилось")
+
+	c.advance(time.Second)
+	assert.True(t, rl.AllowKey("10.0.0.1"))
+}
+
+func TestStaleBucketsAreEvicted(t *testing.T) {
+	rl, c := newTestLimiter(5, time.Minute)
+
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		rl.AllowKey(ip)
+	}
+	c.advance(30 * time.Second)
+	rl.AllowKey("10.0.0.4")
+	assert.Len(t, rl.buckets, 4, "окна еще действуют")
+
+	c.advance(45 * time.Second)
+	rl.AllowKey("10.0.0.5")
+	assert.Len(t, rl.buckets, 2, "первые три ключа истекли")
+	assert.NotContains(t, rl.buckets, "10.0.0.1")
+}
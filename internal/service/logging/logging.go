@@ -0,0 +1,94 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Setup включает JSON-логи в stdout: для slog и для стандартного log, чьи строки
+// становятся записями уровня INFO. Уровень задается LOG_LEVEL (debug, info, warn, error), по умолчанию info
+func Setup() {
+	slog.SetDefault(New(os.Stdout, LevelFromEnv()))
+}
+
+// New — JSON-логгер, который дописывает к записям атрибуты из контекста (request_id, user_id)
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(&contextHandler{Handler: slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})})
+}
+
+func LevelFromEnv() slog.Level {
+	switch strings.ToLower(os.Getenv("LOG_LEVEL")) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
+
+// Fatal пишет ошибку и завершает процесс, как log.Fatalf
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+type ctxKey struct{}
+
+// attrs общие для запроса: middleware кладет их в контекст до маршрутизации,
+// а Auth дописывает пользователя позже, поэтому набор изменяемый
+type attrs struct {
+	mu    sync.Mutex
+	attrs []slog.Attr
+}
+
+// NewContext заводит в контексте набор атрибутов для всех записей с этим контекстом
+func NewContext(ctx context.Context, args ...slog.Attr) context.Context {
+	return context.WithValue(ctx, ctxKey{}, &attrs{attrs: args})
+}
+
+// AddAttrs дописывает атрибуты в набор из NewContext; без него ничего не делает
+func AddAttrs(ctx context.Context, args ...slog.Attr) {
+	a, ok := ctx.Value(ctxKey{}).(*attrs)
+	if !ok {
+		return
+	}
+	a.mu.Lock()
+	a.attrs = append(a.attrs, args...)
+	a.mu.Unlock()
+}
+
+// Attrs возвращает копию атрибутов контекста
+func Attrs(ctx context.Context) []slog.Attr {
+	a, ok := ctx.Value(ctxKey{}).(*attrs)
+	if !ok {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]slog.Attr(nil), a.attrs...)
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		r.AddAttrs(Attrs(ctx)...)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(args []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(args)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,36 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextAttrsAreAddedToRecords(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+
+	ctx := NewContext(context.Background(), slog.String("request_id", "req-1"))
+	// пользователь становится известен позже, уже после того, как контекст передан дальше
+	AddAttrs(ctx, slog.Int64("user_id", 7))
+	logger.InfoContext(ctx, "done", "status", 200)
+	logger.DebugContext(ctx, "skipped")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "done", entry["msg"])
+	assert.Equal(t, "req-1", entry["request_id"])
+	assert.EqualValues(t, 7, entry["user_id"])
+	assert.EqualValues(t, 200, entry["status"])
+}
+
+func TestAddAttrsWithoutContextIsNoop(t *testing.T) {
+	ctx := context.Background()
+	AddAttrs(ctx, slog.Int64("user_id", 7))
+	assert.Empty(t, Attrs(ctx))
+}
@@ -0,0 +1,30 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// FileMailer — заглушка для локальной разработки: каждое письмо сохраняется в отдельный .eml файл
+type FileMailer struct {
+	dir     string
+	counter atomic.Int64
+}
+
+func NewFileMailer(dir string) *FileMailer {
+	return &FileMailer{dir: dir}
+}
+
+func (m *FileMailer) Send(msg Message) error {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	recipient := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(msg.To)
+	name := fmt.Sprintf("%s_%d_%s.eml", time.Now().Format("20060102T150405"), m.counter.Add(1), recipient)
+	return os.WriteFile(filepath.Join(m.dir, name), render("outbox@localhost", msg), 0o600)
+}
@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileMailerSend(t *testing.T) {
+	dir := t.TempDir()
+	m := NewFileMailer(dir)
+
+	err := m.Send(Message{
+		To:      "ivan@example.com\r\nBcc: evil@example.com",
+		Subject: "Вход в Form Journey",
+		Text:    "строка 1\nстрока 2",
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one .eml file, got %v (%v)", files, err)
+	}
+	raw, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(raw)
+
+	if strings.Contains(content, "\r\nBcc:") {
+		t.Errorf("header injection via recipient was not sanitized:\n%s", content)
+	}
+	if !strings.Contains(content, "Subject: =?utf-8?q?") {
+		t.Errorf("subject is not encoded:\n%s", content)
+	}
+	if !strings.Contains(content, "строка 1\r\nстрока 2") {
+		t.Errorf("body line endings are not normalized:\n%s", content)
+	}
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// метрики приложения; имена и метки — часть контракта с дашбордами и алертами
+var (
+	// route — шаблон маршрута chi (/api/workouts/{workout_id}), а не путь, чтобы не плодить ряды
+	HTTPRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "http_request_duration_seconds",
+		Help: "Время обработки HTTP запроса",
+	}, []string{"method", "route", "status"})
+
+	// kind — message, callback или other; result — ok или panic
+	TelegramUpdates = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "telegram_updates_total",
+		Help: "Обработанные обновления Telegram",
+	}, []string{"kind", "result"})
+	TelegramUpdateDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "telegram_update_duration_seconds",
+		Help: "Время обработки обновления Telegram",
+	}, []string{"kind"})
+	// action — название сценария (UseCase.Name), в котором пользователь получил сообщение об ошибке
+	TelegramErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "telegram_update_errors_total",
+		Help: "Ошибки сценариев бота, показанные пользователю",
+	}, []string{"action"})
+
+	// outcome — sent, expired (подписка удалена) или failed
+	PushDeliveries = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "push_deliveries_total",
+		Help: "Исходы отправки web push",
+	}, []string{"kind", "outcome"})
+
+	// на сколько позже назначенного времени сработал таймер
+	TimerLag = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "timer_scheduling_lag_seconds",
+		Help:    "Опоздание срабатывания таймера относительно назначенного времени",
+		Buckets: []float64{.01, .05, .1, .25, .5, 1, 2, 5, 10, 30},
+	}, []string{"timer"})
+)
+
+// RegisterDBStats отдает статистику пула соединений; значения читаются при каждом опросе.
+// Имена прежние, поэтому вместо collectors.NewDBStatsCollector (go_sql_*) — свои функции
+func RegisterDBStats(r prometheus.Registerer, db *sql.DB) {
+	gauge := func(name, help string, fn func(sql.DBStats) float64) prometheus.Collector {
+		return prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: name, Help: help}, func() float64 {
+			return fn(db.Stats())
+		})
+	}
+	counter := func(name, help string, fn func(sql.DBStats) float64) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{Name: name, Help: help}, func() float64 {
+			return fn(db.Stats())
+		})
+	}
+	r.MustRegister(
+		gauge("db_pool_max_open_connections", "Предел открытых соединений", func(s sql.DBStats) float64 {
+			return float64(s.MaxOpenConnections)
+		}),
+		gauge("db_pool_open_connections", "Открытые соединения", func(s sql.DBStats) float64 {
+			return float64(s.OpenConnections)
+		}),
+		gauge("db_pool_in_use_connections", "Занятые соединения", func(s sql.DBStats) float64 {
+			return float64(s.InUse)
+		}),
+		gauge("db_pool_idle_connections", "Свободные соединения", func(s sql.DBStats) float64 {
+			return float64(s.Idle)
+		}),
+		counter("db_pool_wait_count_total", "Сколько раз ждали свободное соединение", func(s sql.DBStats) float64 {
+			return float64(s.WaitCount)
+		}),
+		counter("db_pool_wait_duration_seconds_total", "Суммарное ожидание свободного соединения", func(s sql.DBStats) float64 {
+			return s.WaitDuration.Seconds()
+		}),
+	)
+}
@@ -0,0 +1,33 @@
+// Package metrics — метрики приложения на github.com/prometheus/client_golang
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Default — реестр приложения, его отдает /metrics. Кроме метрик приложения в нем
+// стандартные метрики рантайма Go (go_goroutines, go_memstats_*, ...) и процесса (process_*)
+var Default = NewRegistry()
+
+// factory регистрирует метрики приложения в Default
+var factory = promauto.With(Default)
+
+// NewRegistry создает реестр с коллекторами рантайма Go и процесса
+func NewRegistry() *prometheus.Registry {
+	r := prometheus.NewRegistry()
+	r.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return r
+}
+
+// Handler отдает метрики реестра r в формате, который запросил Prometheus
+func Handler(r *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(r, promhttp.HandlerOpts{Registry: r})
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerServesRuntimeAndAppMetrics(t *testing.T) {
+	HTTPRequestDuration.WithLabelValues("GET", "/api/workouts/{workout_id}", "200").Observe(0.05)
+	TelegramUpdates.WithLabelValues("message", "ok").Inc()
+
+	rec := httptest.NewRecorder()
+	Handler(Default).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain; version=0.0.4"))
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE go_goroutines gauge\n",
+		"# TYPE go_memstats_heap_alloc_bytes gauge\n",
+		`http_request_duration_seconds_bucket{method="GET",route="/api/workouts/{workout_id}",status="200",le="0.05"} 1`,
+		`telegram_updates_total{kind="message",result="ok"} 1`,
+	} {
+		assert.Contains(t, body, want)
+	}
+}
+
+func TestRegistryIncludesProcessCollector(t *testing.T) {
+	families, err := NewRegistry().Gather()
+	require.NoError(t, err)
+
+	names := map[string]bool{}
+	for _, f := range families {
+		names[f.GetName()] = true
+	}
+	assert.True(t, names["go_goroutines"])
+	// процессные метрики библиотека читает из procfs
+	if runtime.GOOS == "linux" {
+		assert.True(t, names["process_resident_memory_bytes"])
+	}
+}
+
+func TestRegisterDBStatsKeepsNames(t *testing.T) {
+	r := prometheus.NewRegistry()
+	RegisterDBStats(r, &sql.DB{})
+
+	err := testutil.GatherAndCompare(r, strings.NewReader(`
+# HELP db_pool_in_use_connections Занятые соединения
+# TYPE db_pool_in_use_connections gauge
+db_pool_in_use_connections 0
+# HELP db_pool_wait_count_total Сколько раз ждали свободное соединение
+# TYPE db_pool_wait_count_total counter
+db_pool_wait_count_total 0
+`), "db_pool_in_use_connections", "db_pool_wait_count_total")
+	assert.NoError(t, err)
+	assert.Panics(t, func() { RegisterDBStats(r, &sql.DB{}) }, "повторная регистрация")
+}
@@ -0,0 +1,41 @@
+package oauth
+
+import (
+	"os"
+	"strings"
+)
+
+// RegistryFromEnv собирает OIDC провайдеров из переменных окружения:
+//
+//	OAUTH_PROVIDERS=google,keycloak
+//	OAUTH_GOOGLE_ISSUER=https://accounts.google.com
+//	OAUTH_GOOGLE_CLIENT_ID=...
+//	OAUTH_GOOGLE_CLIENT_SECRET=...
+//	OAUTH_GOOGLE_SCOPES=openid email profile (необязательно)
+//	OAUTH_GOOGLE_TITLE=Google (необязательно)
+func RegistryFromEnv() *Registry {
+	providers := make([]Provider, 0)
+	for _, name := range strings.Split(os.Getenv("OAUTH_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OAUTH_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+		cfg := OIDCConfig{
+			Name:         name,
+			Title:        os.Getenv(prefix + "TITLE"),
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+		}
+		if scopes := os.Getenv(prefix + "SCOPES"); scopes != "" {
+			cfg.Scopes = strings.Fields(scopes)
+		}
+		if cfg.Issuer == "" || cfg.ClientID == "" {
+			continue
+		}
+		providers = append(providers, NewOIDCProvider(cfg, nil))
+	}
+	return NewRegistry(providers...)
+}
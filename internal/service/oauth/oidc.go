@@ -0,0 +1,400 @@
+package oauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// как часто перечитывать discovery и ключи, даже если незнакомый kid не встречался
+const metadataTTL = time.Hour
+
+type OIDCConfig struct {
+	Name         string
+	Title        string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// OIDCProvider — стандартный OpenID Connect провайдер (Google, VK ID, Keycloak и т.п.)
+type OIDCProvider struct {
+	cfg    OIDCConfig
+	client *http.Client
+
+	mu          sync.Mutex
+	discovery   *discoveryDocument
+	keys        map[string]interface{} // kid -> публичный ключ
+	refreshedAt time.Time
+}
+
+func NewOIDCProvider(cfg OIDCConfig, client *http.Client) *OIDCProvider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	if cfg.Title == "" {
+		cfg.Title = cfg.Name
+	}
+	cfg.Issuer = strings.TrimSuffix(cfg.Issuer, "/")
+	return &OIDCProvider{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+func (p *OIDCProvider) Name() string {
+	return p.cfg.Name
+}
+
+func (p *OIDCProvider) Title() string {
+	return p.cfg.Title
+}
+
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, req AuthRequest) (string, error) {
+	doc, err := p.metadata(ctx, false)
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", req.RedirectURI)
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", req.State)
+	q.Set("nonce", req.Nonce)
+	q.Set("code_challenge", CodeChallenge(req.CodeVerifier))
+	q.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(doc.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return doc.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+func (p *OIDCProvider) Exchange(ctx context.Context, code string, req AuthRequest) (*Identity, error) {
+	doc, err := p.metadata(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", req.RedirectURI)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("code_verifier", req.CodeVerifier)
+	if p.cfg.ClientSecret != "" {
+		form.Set("client_secret", p.cfg.ClientSecret)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, doc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("Accept", "application/json")
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		IDToken     string `json:"id_token"`
+	}
+	if err = doJSON(p.client, httpReq, &tokenResp); err != nil {
+		return nil, fmt.Errorf("token exchange: %w", err)
+	}
+	if tokenResp.IDToken == "" {
+		return nil, fmt.Errorf("%w: missing in token response", InvalidIDTokenErr)
+	}
+
+	claims, err := p.verifyIDToken(ctx, tokenResp.IDToken, req.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := identityFromClaims(p.cfg.Name, claims)
+	// имя и почта часто приходят только из userinfo, а не в id token
+	if (identity.Email == "" || identity.FirstName == "") && doc.UserinfoEndpoint != "" && tokenResp.AccessToken != "" {
+		p.fillFromUserinfo(ctx, doc.UserinfoEndpoint, tokenResp.AccessToken, identity)
+	}
+	return identity, nil
+}
+
+func (p *OIDCProvider) verifyIDToken(ctx context.Context, rawToken, nonce string) (jwt.MapClaims, error) {
+	parser := jwt.Parser{ValidMethods: []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}}
+
+	token, err := parser.Parse(rawToken, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.key(ctx, kid)
+	})
+	if err != nil || !token.Valid {
+		return nil, fmt.Errorf("%w: %v", InvalidIDTokenErr, err)
+	}
+	claims := token.Claims.(jwt.MapClaims)
+
+	if iss, _ := claims["iss"].(string); strings.TrimSuffix(iss, "/") != p.cfg.Issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", InvalidIDTokenErr, iss)
+	}
+	if !claims.VerifyAudience(p.cfg.ClientID, true) {
+		return nil, fmt.Errorf("%w: audience mismatch", InvalidIDTokenErr)
+	}
+	if azp, ok := claims["azp"].(string); ok && azp != p.cfg.ClientID {
+		return nil, fmt.Errorf("%w: authorized party mismatch", InvalidIDTokenErr)
+	}
+	if _, ok := claims["exp"]; !ok {
+		return nil, fmt.Errorf("%w: missing exp", InvalidIDTokenErr)
+	}
+	if got, _ := claims["nonce"].(string); nonce == "" || got != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", InvalidIDTokenErr)
+	}
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return nil, fmt.Errorf("%w: missing sub", InvalidIDTokenErr)
+	}
+	return claims, nil
+}
+
+func (p *OIDCProvider) fillFromUserinfo(ctx context.Context, endpoint, accessToken string, identity *Identity) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	var claims jwt.MapClaims
+	if err = doJSON(p.client, req, &claims); err != nil {
+		return
+	}
+	// userinfo другого пользователя не должен подмешаться к проверенному id token
+	if sub, _ := claims["sub"].(string); sub != identity.Subject {
+		return
+	}
+
+	info := identityFromClaims(p.cfg.Name, claims)
+	if identity.Email == "" {
+		identity.Email, identity.EmailVerified = info.Email, info.EmailVerified
+	}
+	if identity.FirstName == "" {
+		identity.FirstName, identity.LastName = info.FirstName, info.LastName
+	}
+	if identity.Login == "" {
+		identity.Login = info.Login
+	}
+}
+
+func identityFromClaims(provider string, claims jwt.MapClaims) *Identity {
+	str := func(key string) string {
+		v, _ := claims[key].(string)
+		return v
+	}
+
+	identity := &Identity{
+		Provider:  provider,
+		Subject:   str("sub"),
+		Email:     str("email"),
+		Login:     str("preferred_username"),
+		FirstName: str("given_name"),
+		LastName:  str("family_name"),
+	}
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = v
+	case string:
+		identity.EmailVerified = v == "true"
+	}
+	if identity.FirstName == "" {
+		identity.FirstName = str("name")
+	}
+	return identity
+}
+
+func (p *OIDCProvider) metadata(ctx context.Context, force bool) (*discoveryDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil && !force && time.Since(p.refreshedAt) < metadataTTL {
+		return p.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	var doc discoveryDocument
+	if err = doJSON(p.client, req, &doc); err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	if strings.TrimSuffix(doc.Issuer, "/") != p.cfg.Issuer {
+		return nil, fmt.Errorf("oidc discovery: issuer mismatch %q", doc.Issuer)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JwksURI == "" {
+		return nil, errors.New("oidc discovery: incomplete document")
+	}
+
+	keys, err := p.fetchKeys(ctx, doc.JwksURI)
+	if err != nil {
+		return nil, err
+	}
+
+	p.discovery = &doc
+	p.keys = keys
+	p.refreshedAt = time.Now()
+	return p.discovery, nil
+}
+
+// key ищет ключ по kid; незнакомый kid означает ротацию ключей у провайдера, тогда перечитываем jwks
+func (p *OIDCProvider) key(ctx context.Context, kid string) (interface{}, error) {
+	if k, ok := p.lookupKey(kid); ok {
+		return k, nil
+	}
+	if _, err := p.metadata(ctx, true); err != nil {
+		return nil, err
+	}
+	if k, ok := p.lookupKey(kid); ok {
+		return k, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (p *OIDCProvider) lookupKey(kid string) (interface{}, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if kid != "" {
+		k, ok := p.keys[kid]
+		return k, ok
+	}
+	// без kid допустимо, только если ключ единственный
+	if len(p.keys) == 1 {
+		for _, k := range p.keys {
+			return k, true
+		}
+	}
+	return nil, false
+}
+
+func (p *OIDCProvider) fetchKeys(ctx context.Context, jwksURI string) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err = doJSON(p.client, req, &set); err != nil {
+		return nil, fmt.Errorf("oidc jwks: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, parseErr := parseJWK(k)
+		if parseErr != nil {
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("oidc jwks: no usable signing keys")
+	}
+	return keys, nil
+}
+
+func parseJWK(k jsonWebKey) (interface{}, error) {
+	decode := func(s string) (*big.Int, error) {
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetBytes(b), nil
+	}
+
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func doJSON(client *http.Client, req *http.Request, dst interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(dst)
+}
@@ -0,0 +1,325 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/oauthlogins"
+)
+
+const (
+	testClientID = "training-app"
+	testKid      = "key-1"
+)
+
+// fakeIdP — минимальный OIDC провайдер: discovery, jwks, token и userinfo
+type fakeIdP struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu         sync.Mutex
+	challenges map[string]string // code -> code_challenge
+	nonces     map[string]string // code -> nonce
+	// mutate позволяет тесту испортить claims или подписать чужим ключом
+	mutate  func(claims jwt.MapClaims)
+	signKey *rsa.PrivateKey
+}
+
+func newFakeIdP(t *testing.T) *fakeIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &fakeIdP{
+		t:          t,
+		key:        key,
+		challenges: make(map[string]string),
+		nonces:     make(map[string]string),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"userinfo_endpoint":      idp.server.URL + "/userinfo",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": testKid,
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", idp.token)
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"sub":         "user-123",
+			"given_name":  "Иван",
+			"family_name": "Петров",
+		})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+// authorize имитирует согласие пользователя: запоминает challenge и nonce и выдает код
+func (idp *fakeIdP) authorize(authURL string) string {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		idp.t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" || q.Get("client_id") != testClientID {
+		idp.t.Fatalf("unexpected authorize params: %v", q)
+	}
+
+	code := "code-" + q.Get("state")
+	idp.mu.Lock()
+	idp.challenges[code] = q.Get("code_challenge")
+	idp.nonces[code] = q.Get("nonce")
+	idp.mu.Unlock()
+	return code
+}
+
+func (idp *fakeIdP) token(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+	code := r.PostForm.Get("code")
+
+	idp.mu.Lock()
+	challenge, ok := idp.challenges[code]
+	nonce := idp.nonces[code]
+	delete(idp.challenges, code)
+	idp.mu.Unlock()
+
+	if !ok || CodeChallenge(r.PostForm.Get("code_verifier")) != challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	claims := jwt.MapClaims{
+		"iss":   idp.server.URL,
+		"sub":   "user-123",
+		"aud":   testClientID,
+		"exp":   time.Now().Add(5 * time.Minute).Unix(),
+		"iat":   time.Now().Unix(),
+		"nonce": nonce,
+		"email": "ivan@example.com",
+	}
+	if idp.mutate != nil {
+		idp.mutate(claims)
+	}
+	signKey := idp.key
+	if idp.signKey != nil {
+		signKey = idp.signKey
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testKid
+	signed, err := token.SignedString(signKey)
+	if err != nil {
+		idp.t.Fatal(err)
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{
+		"access_token": "access-token",
+		"id_token":     signed,
+		"token_type":   "Bearer",
+	})
+}
+
+func (idp *fakeIdP) provider() *OIDCProvider {
+	return NewOIDCProvider(OIDCConfig{
+		Name:     "keycloak",
+		Issuer:   idp.server.URL,
+		ClientID: testClientID,
+	}, idp.server.Client())
+}
+
+func login(t *testing.T, idp *fakeIdP, p *OIDCProvider, tamper func(req *AuthRequest)) (*Identity, error) {
+	ctx := context.Background()
+	req, err := NewAuthRequest("https://app.example.com/auth-oauth/keycloak")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authURL, err := p.AuthCodeURL(ctx, req)
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	code := idp.authorize(authURL)
+
+	if tamper != nil {
+		tamper(&req)
+	}
+	return p.Exchange(ctx, code, req)
+}
+
+func TestOIDCProviderLogin(t *testing.T) {
+	idp := newFakeIdP(t)
+
+	identity, err := login(t, idp, idp.provider(), nil)
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+
+	want := Identity{
+		Provider:  "keycloak",
+		Subject:   "user-123",
+		Email:     "ivan@example.com",
+		FirstName: "Иван", // из userinfo
+		LastName:  "Петров",
+	}
+	if *identity != want {
+		t.Errorf("Exchange() = %+v, want %+v", *identity, want)
+	}
+}
+
+func TestOIDCProviderRejects(t *testing.T) {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(claims jwt.MapClaims)
+		signKey *rsa.PrivateKey
+		tamper  func(req *AuthRequest)
+	}{
+		{
+			name:   "wrong nonce",
+			mutate: func(claims jwt.MapClaims) { claims["nonce"] = "replayed" },
+		},
+		{
+			name:   "wrong audience",
+			mutate: func(claims jwt.MapClaims) { claims["aud"] = "another-client" },
+		},
+		{
+			name:   "wrong issuer",
+			mutate: func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.com" },
+		},
+		{
+			name:   "expired",
+			mutate: func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Minute).Unix() },
+		},
+		{
+			name:    "foreign signature",
+			signKey: otherKey,
+		},
+		{
+			name:   "wrong pkce verifier",
+			tamper: func(req *AuthRequest) { req.CodeVerifier = "stolen-code-without-verifier" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := newFakeIdP(t)
+			idp.mutate = tt.mutate
+			idp.signKey = tt.signKey
+
+			if _, err := login(t, idp, idp.provider(), tt.tamper); err == nil {
+				t.Errorf("Exchange() expected error")
+			}
+		})
+	}
+}
+
+// memLogins — oauthlogins.Repo в памяти
+type memLogins map[string]models.OAuthPendingLogin
+
+func (m memLogins) Create(login *models.OAuthPendingLogin) error {
+	m[login.State] = *login
+	return nil
+}
+
+func (m memLogins) Take(state string) (*models.OAuthPendingLogin, error) {
+	login, ok := m[state]
+	if !ok {
+		return nil, oauthlogins.NotFoundLoginErr
+	}
+	delete(m, state)
+	return &login, nil
+}
+
+func (m memLogins) DeleteExpired(now time.Time) error {
+	for state, login := range m {
+		if !login.ExpiresAt.After(now) {
+			delete(m, state)
+		}
+	}
+	return nil
+}
+
+func TestStateStoreIsSingleUse(t *testing.T) {
+	store := NewStateStore(memLogins{})
+	req, err := NewAuthRequest("https://app.example.com/auth-oauth/google")
+	if err != nil {
+		t.Fatal(err)
+	}
+	binding, err := store.Put(PendingLogin{Provider: "google", Request: req})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = store.Take("keycloak", req.State, binding); err == nil {
+		t.Errorf("Take() with another provider expected error")
+	}
+	// неудачная попытка тоже сжигает state
+	if _, err = store.Take("google", req.State, binding); err == nil {
+		t.Errorf("Take() after consumed state expected error")
+	}
+
+	binding, _ = store.Put(PendingLogin{Provider: "google", Request: req, LinkUserID: 7})
+	got, err := store.Take("google", req.State, binding)
+	if err != nil || got.Request.Nonce != req.Nonce || got.Request.CodeVerifier != req.CodeVerifier || got.LinkUserID != 7 {
+		t.Errorf("Take() = %+v, %v", got, err)
+	}
+}
+
+// ссылка возврата от провайдера, открытая в другом браузере, не завершает вход
+func TestStateStoreRequiresBinding(t *testing.T) {
+	store := NewStateStore(memLogins{})
+	req, err := NewAuthRequest("https://app.example.com/auth-oauth/google")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, foreign := range []string{"", "attacker-binding"} {
+		if _, err = store.Put(PendingLogin{Provider: "google", Request: req}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = store.Take("google", req.State, foreign); !errors.Is(err, InvalidStateErr) {
+			t.Errorf("Take() with binding %q = %v, want InvalidStateErr", foreign, err)
+		}
+	}
+}
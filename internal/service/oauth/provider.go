@@ -0,0 +1,70 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+var (
+	UnknownProviderErr = errors.New("unknown oauth provider")
+	InvalidStateErr    = errors.New("invalid or expired oauth state")
+	InvalidIDTokenErr  = errors.New("invalid id token")
+)
+
+// Identity — пользователь, как его видит внешний провайдер
+type Identity struct {
+	Provider      string
+	Subject       string // стабильный id пользователя у провайдера (sub)
+	Email         string
+	EmailVerified bool
+	Login         string
+	FirstName     string
+	LastName      string
+}
+
+// AuthRequest — параметры одного входа, которые должны дожить от редиректа до обмена кода
+type AuthRequest struct {
+	State        string
+	Nonce        string
+	CodeVerifier string // PKCE
+	RedirectURI  string
+}
+
+type Provider interface {
+	Name() string
+	Title() string
+	AuthCodeURL(ctx context.Context, req AuthRequest) (string, error)
+	Exchange(ctx context.Context, code string, req AuthRequest) (*Identity, error)
+}
+
+type Registry struct {
+	providers map[string]Provider
+}
+
+func NewRegistry(providers ...Provider) *Registry {
+	r := &Registry{providers: make(map[string]Provider)}
+	for _, p := range providers {
+		r.providers[p.Name()] = p
+	}
+	return r
+}
+
+func (r *Registry) Get(name string) (Provider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, UnknownProviderErr
+	}
+	return p, nil
+}
+
+func (r *Registry) List() []Provider {
+	res := make([]Provider, 0, len(r.providers))
+	for _, p := range r.providers {
+		res = append(res, p)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name() < res[j].Name()
+	})
+	return res
+}
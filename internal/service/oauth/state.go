@@ -0,0 +1,102 @@
+package oauth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/oauthlogins"
+)
+
+const stateTTL = 10 * time.Minute
+
+// PendingLogin — незавершенный вход, ждущий возврата пользователя от провайдера
+type PendingLogin struct {
+	Provider   string
+	Request    AuthRequest
+	LinkUserID int64 // не 0, если это привязка провайдера к уже вошедшему пользователю
+}
+
+// StateStore хранит незавершенные входы в базе, чтобы их видел любой инстанс; каждый state можно использовать
+// только один раз. Вход привязан к браузеру, который его начал: вместе с state нужно предъявить binding из cookie,
+// иначе чужую ссылку возврата от провайдера можно было бы подсунуть жертве (login CSRF)
+type StateStore struct {
+	repo oauthlogins.Repo
+}
+
+func NewStateStore(repo oauthlogins.Repo) *StateStore {
+	return &StateStore{
+		repo: repo,
+	}
+}
+
+// Put сохраняет вход и возвращает binding, который нужно положить в cookie браузера
+func (s *StateStore) Put(login PendingLogin) (string, error) {
+	binding, err := randomString()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	if err = s.repo.DeleteExpired(now); err != nil {
+		return "", err
+	}
+
+	record := &models.OAuthPendingLogin{
+		State:        login.Request.State,
+		Provider:     login.Provider,
+		BindingHash:  hashBinding(binding),
+		Nonce:        login.Request.Nonce,
+		CodeVerifier: login.Request.CodeVerifier,
+		RedirectURI:  login.Request.RedirectURI,
+		ExpiresAt:    now.Add(stateTTL),
+		CreatedAt:    now,
+	}
+	if login.LinkUserID != 0 {
+		record.LinkUserID = &login.LinkUserID
+	}
+	if err = s.repo.Create(record); err != nil {
+		return "", err
+	}
+	return binding, nil
+}
+
+// Take достает и сразу удаляет вход по state; провайдер и binding должны совпадать с теми, что были при начале входа
+func (s *StateStore) Take(provider, state, binding string) (PendingLogin, error) {
+	record, err := s.repo.Take(state)
+	if errors.Is(err, oauthlogins.NotFoundLoginErr) {
+		return PendingLogin{}, InvalidStateErr
+	}
+	if err != nil {
+		return PendingLogin{}, err
+	}
+
+	if record.Provider != provider || time.Now().After(record.ExpiresAt) {
+		return PendingLogin{}, InvalidStateErr
+	}
+	if binding == "" || subtle.ConstantTimeCompare([]byte(hashBinding(binding)), []byte(record.BindingHash)) != 1 {
+		return PendingLogin{}, InvalidStateErr
+	}
+
+	login := PendingLogin{
+		Provider: record.Provider,
+		Request: AuthRequest{
+			State:        record.State,
+			Nonce:        record.Nonce,
+			CodeVerifier: record.CodeVerifier,
+			RedirectURI:  record.RedirectURI,
+		},
+	}
+	if record.LinkUserID != nil {
+		login.LinkUserID = *record.LinkUserID
+	}
+	return login, nil
+}
+
+func hashBinding(binding string) string {
+	sum := sha256.Sum256([]byte(binding))
+	return hex.EncodeToString(sum[:])
+}
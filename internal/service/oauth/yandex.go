@@ -0,0 +1,101 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// YandexProvider — Яндекс ID: обычный OAuth 2.0 без id token, профиль берется из login.yandex.ru/info
+type YandexProvider struct {
+	clientID     string
+	clientSecret string
+	client       *http.Client
+}
+
+func NewYandexProviderFromEnv() *YandexProvider {
+	return &YandexProvider{
+		clientID:     os.Getenv("YANDEX_CLIENT_ID"),
+		clientSecret: os.Getenv("YANDEX_CLIENT_SECRET"),
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *YandexProvider) Name() string {
+	return "yandex"
+}
+
+func (p *YandexProvider) Title() string {
+	return "Яндекс ID"
+}
+
+func (p *YandexProvider) AuthCodeURL(_ context.Context, req AuthRequest) (string, error) {
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.clientID)
+	q.Set("redirect_uri", req.RedirectURI)
+	q.Set("state", req.State)
+	if req.CodeVerifier != "" {
+		q.Set("code_challenge", CodeChallenge(req.CodeVerifier))
+		q.Set("code_challenge_method", "S256")
+	}
+	return "https://oauth.yandex.ru/authorize?" + q.Encode(), nil
+}
+
+func (p *YandexProvider) Exchange(ctx context.Context, code string, req AuthRequest) (*Identity, error) {
+	data := url.Values{}
+	data.Set("grant_type", "authorization_code")
+	data.Set("code", code)
+	data.Set("client_id", p.clientID)
+	data.Set("client_secret", p.clientSecret)
+	if req.CodeVerifier != "" {
+		data.Set("code_verifier", req.CodeVerifier)
+	}
+
+	tokenReq, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://oauth.yandex.ru/token", strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	tokenReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err = doJSON(p.client, tokenReq, &tokenResp); err != nil {
+		return nil, fmt.Errorf("yandex token exchange: %w", err)
+	}
+
+	profileReq, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://login.yandex.ru/info", nil)
+	if err != nil {
+		return nil, err
+	}
+	profileReq.Header.Set("Authorization", "OAuth "+tokenResp.AccessToken)
+
+	var profile struct {
+		ID           string `json:"id"`
+		Login        string `json:"login"`
+		FirstName    string `json:"first_name"`
+		LastName     string `json:"last_name"`
+		DefaultEmail string `json:"default_email"`
+	}
+	if err = doJSON(p.client, profileReq, &profile); err != nil {
+		return nil, fmt.Errorf("yandex profile: %w", err)
+	}
+	if profile.ID == "" {
+		return nil, fmt.Errorf("yandex profile: empty id")
+	}
+
+	return &Identity{
+		Provider:  p.Name(),
+		Subject:   profile.ID,
+		Email:     profile.DefaultEmail,
+		Login:     profile.Login,
+		FirstName: profile.FirstName,
+		LastName:  profile.LastName,
+	}, nil
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/constants"
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/metrics"
 	"github.com/SherClockHolmes/webpush-go"
 	"gorm.io/gorm"
 	"io"
+	"log/slog"
 	"net/http"
 	"os"
 )
@@ -49,17 +51,124 @@ func (p *Service) SendWorkoutFinished(userID, workoutID int64) error {
 	payloadJSON, _ := json.Marshal(payload)
 
 	for _, sub := range subs {
-		status, err := sendPush(&sub, payloadJSON)
-		if err != nil {
-			if status == http.StatusGone || status == http.StatusNotFound {
-				p.db.Delete(&sub)
-			}
-		}
+		p.deliver("rest_timer", &sub, payloadJSON)
+	}
+
+	return nil
+}
+
+func (p *Service) SendWorkoutAutoFinished(userID, workoutID int64) error {
+	var workout models.WorkoutDay
+
+	if err := p.db.Preload("WorkoutDayType").First(&workout, workoutID).Error; err != nil {
+		return err
+	}
+
+	var subs []models.PushSubscription
+	if err := p.db.Where("user_id = ?", userID).Find(&subs).Error; err != nil {
+		return err
+	}
+
+	payload := &Payload{
+		Title: "Тренировка завершена автоматически 💤",
+		Body:  workout.WorkoutDayType.Name + " — откройте, если хотите продолжить",
+		URL:   fmt.Sprintf("/workouts/%d", workout.ID),
+		Tag:   fmt.Sprintf("workout-auto-finish-%d", workout.ID),
+	}
+
+	payloadJSON, _ := json.Marshal(payload)
+
+	for _, sub := range subs {
+		p.deliver("auto_finish", &sub, payloadJSON)
 	}
 
 	return nil
 }
 
+// SendShareActivity сообщает владельцу о реакции или комментарии к расшаренной тренировке
+func (p *Service) SendShareActivity(userID, workoutID int64, title, body string) error {
+	var subs []models.PushSubscription
+	if err := p.db.Where("user_id = ?", userID).Find(&subs).Error; err != nil {
+		return err
+	}
+
+	payload := &Payload{
+		Title: title,
+		Body:  body,
+		URL:   fmt.Sprintf("/workouts/%d", workoutID),
+		Tag:   fmt.Sprintf("workout-social-%d", workoutID),
+	}
+
+	payloadJSON, _ := json.Marshal(payload)
+
+	for _, sub := range subs {
+		p.deliver("share_activity", &sub, payloadJSON)
+	}
+
+	return nil
+}
+
+// SendChallengeFinished сообщает участнику итоги челленджа
+func (p *Service) SendChallengeFinished(userID, challengeID int64, title, body string) error {
+	var subs []models.PushSubscription
+	if err := p.db.Where("user_id = ?", userID).Find(&subs).Error; err != nil {
+		return err
+	}
+
+	payload := &Payload{
+		Title: title,
+		Body:  body,
+		URL:   fmt.Sprintf("/challenges/%d", challengeID),
+		Tag:   fmt.Sprintf("challenge-%d", challengeID),
+	}
+
+	payloadJSON, _ := json.Marshal(payload)
+
+	for _, sub := range subs {
+		p.deliver("challenge_finished", &sub, payloadJSON)
+	}
+
+	return nil
+}
+
+func (p *Service) SendAchievementsUnlocked(userID int64, title, body string) error {
+	var subs []models.PushSubscription
+	if err := p.db.Where("user_id = ?", userID).Find(&subs).Error; err != nil {
+		return err
+	}
+
+	payload := &Payload{
+		Title: title,
+		Body:  body,
+		URL:   "/achievements",
+		Tag:   "achievements",
+	}
+
+	payloadJSON, _ := json.Marshal(payload)
+
+	for _, sub := range subs {
+		p.deliver("achievements", &sub, payloadJSON)
+	}
+
+	return nil
+}
+
+// deliver отправляет push на одну подписку и учитывает исход в метриках.
+// Подписку, которую сервис push считает несуществующей (404, 410), удаляем
+func (p *Service) deliver(kind string, sub *models.PushSubscription, payload []byte) {
+	status, err := sendPush(sub, payload)
+	switch {
+	case status == http.StatusGone || status == http.StatusNotFound:
+		metrics.PushDeliveries.WithLabelValues(kind, "expired").Inc()
+		p.db.Delete(sub)
+	case err != nil || status >= http.StatusBadRequest:
+		metrics.PushDeliveries.WithLabelValues(kind, "failed").Inc()
+		slog.Warn("push delivery failed", "kind", kind, "user_id", sub.UserID, "status", status, "err", err)
+	default:
+		metrics.PushDeliveries.WithLabelValues(kind, "sent").Inc()
+	}
+}
+
 func sendPush(sub *models.PushSubscription, payload []byte) (int, error) {
 	subscription := &webpush.Subscription{
 		Endpoint: sub.Endpoint,
@@ -77,21 +186,16 @@ func sendPush(sub *models.PushSubscription, payload []byte) (int, error) {
 		Subscriber:      constants.Domain,
 	}
 
-	fmt.Println("push payload:", string(payload))
-
 	resp, err := webpush.SendNotification(payload, subscription, options)
 	if err != nil {
-		fmt.Println("push error:", err.Error())
 		if resp != nil {
-			fmt.Println("push error status:", resp.StatusCode)
 			return resp.StatusCode, err
 		}
 		return 0, err
 	}
 
 	defer resp.Body.Close()
-	fmt.Println("push status:", resp.StatusCode)
 	bodyBytes, _ := io.ReadAll(resp.Body)
-	fmt.Println("push response body:", string(bodyBytes))
+	slog.Debug("push sent", "status", resp.StatusCode, "payload", string(payload), "response", string(bodyBytes))
 	return resp.StatusCode, nil
 }
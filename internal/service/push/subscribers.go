@@ -0,0 +1,12 @@
+package push
+
+import "github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+
+// HandleRestTimerFinished — подписчик шины событий: сообщает об окончании отдыха
+func (p *Service) HandleRestTimerFinished(event events.Event) error {
+	e, ok := event.(events.RestTimerFinished)
+	if !ok {
+		return nil
+	}
+	return p.SendWorkoutFinished(e.UserID, e.WorkoutID)
+}
@@ -0,0 +1,56 @@
+package rbac
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+// Permission — право на действие, которое проверяет validator. Роли раздают права целиком,
+// отдельным пользователям права не выдаются
+type Permission string
+
+const (
+	ViewUsers           Permission = "users.view"
+	ManageUsers         Permission = "users.manage"
+	ViewUsageStats      Permission = "stats.view"
+	EditExerciseLibrary Permission = "exercise_library.edit"
+	ViewAuditLog        Permission = "audit_log.view"
+	ViewAnyWorkouts     Permission = "workouts.view_any"
+	CoachAthletes       Permission = "athletes.coach" // приглашать спортсменов; доступ к их данным дает согласие спортсмена
+)
+
+var rolePermissions = map[string][]Permission{
+	models.RoleAdmin: {
+		ViewUsers,
+		ManageUsers,
+		ViewUsageStats,
+		EditExerciseLibrary,
+		ViewAuditLog,
+		ViewAnyWorkouts,
+		CoachAthletes,
+	},
+	models.RoleCoach: {
+		CoachAthletes,
+	},
+	models.RoleAthlete: {},
+}
+
+func Can(role string, permission Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Allowed проверяет право конкретного пользователя: заблокированному не разрешено ничего
+func Allowed(user *models.User, permission Permission) bool {
+	if user == nil || user.IsDisabled() {
+		return false
+	}
+	return Can(user.Role, permission)
+}
+
+func Permissions(role string) []Permission {
+	return append([]Permission{}, rolePermissions[role]...)
+}
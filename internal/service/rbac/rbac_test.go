@@ -0,0 +1,30 @@
+package rbac
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+func TestCan(t *testing.T) {
+	assert.True(t, Can(models.RoleAdmin, ManageUsers))
+	assert.True(t, Can(models.RoleAdmin, EditExerciseLibrary))
+	assert.False(t, Can(models.RoleCoach, ManageUsers))
+	assert.True(t, Can(models.RoleCoach, CoachAthletes))
+	assert.False(t, Can(models.RoleAthlete, CoachAthletes))
+	assert.False(t, Can(models.RoleAthlete, ViewUsers))
+	assert.False(t, Can("unknown", ViewUsers))
+}
+
+func TestAllowedDisabledUser(t *testing.T) {
+	now := time.Now()
+	admin := &models.User{ID: 1, Role: models.RoleAdmin}
+	assert.True(t, Allowed(admin, ViewAuditLog))
+
+	admin.DisabledAt = &now
+	assert.False(t, Allowed(admin, ViewAuditLog))
+	assert.False(t, Allowed(nil, ViewAuditLog))
+}
@@ -0,0 +1,102 @@
+package realtime
+
+import (
+	"sync"
+	"time"
+)
+
+type EventType string
+
+const (
+	SetCompleted  EventType = "set_completed"
+	SetChanged    EventType = "set_changed"
+	SetAdded      EventType = "set_added"
+	SetRemoved    EventType = "set_removed"
+	ExerciseMoved EventType = "exercise_moved"
+	TimerStarted  EventType = "timer_started"
+	TimerCanceled EventType = "timer_canceled"
+	TimerFinished EventType = "timer_finished"
+)
+
+type Event struct {
+	Type       EventType `json:"type"`
+	WorkoutID  int64     `json:"workout_id"`
+	ExerciseID int64     `json:"exercise_id,omitempty"`
+	SetID      int64     `json:"set_id,omitempty"`
+	TimerID    int64     `json:"timer_id,omitempty"`
+	Index      int       `json:"index"`
+	Version    int64     `json:"version,omitempty"` // версия подхода после изменения
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// размер буфера канала подписчика: медленный клиент теряет события, а не блокирует запись
+const subscriberBuffer = 32
+
+// Listener получает события всех тренировок; вызывается синхронно из Publish и не должен блокироваться
+type Listener func(Event)
+
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[int64]map[chan Event]struct{} // workoutID -> каналы подписчиков
+	listeners   []Listener
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[int64]map[chan Event]struct{}),
+	}
+}
+
+// Subscribe подписывает на события тренировки, вызов функции отписки закрывает канал
+func (h *Hub) Subscribe(workoutID int64) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	h.mu.Lock()
+	if _, ok := h.subscribers[workoutID]; !ok {
+		h.subscribers[workoutID] = make(map[chan Event]struct{})
+	}
+	h.subscribers[workoutID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers[workoutID], ch)
+			if len(h.subscribers[workoutID]) == 0 {
+				delete(h.subscribers, workoutID)
+			}
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// AddListener подписывает фоновые обработчики (например, пересчет таблиц лидеров) на все события
+func (h *Hub) AddListener(l Listener) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.listeners = append(h.listeners, l)
+}
+
+func (h *Hub) Publish(event Event) {
+	if h == nil || event.WorkoutID == 0 {
+		return
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subscribers[event.WorkoutID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	for _, l := range h.listeners {
+		l(event)
+	}
+}
@@ -0,0 +1,75 @@
+package realtime
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishReachesOnlyWorkoutSubscribers(t *testing.T) {
+	h := NewHub()
+	mine, unsubMine := h.Subscribe(1)
+	defer unsubMine()
+	other, unsubOther := h.Subscribe(2)
+	defer unsubOther()
+
+	var heard []Event
+	h.AddListener(func(e Event) { heard = append(heard, e) })
+
+	h.Publish(Event{Type: SetCompleted, WorkoutID: 1, SetID: 10})
+
+	got := <-mine
+	assert.Equal(t, SetCompleted, got.Type)
+	assert.Equal(t, int64(10), got.SetID)
+	assert.False(t, got.CreatedAt.IsZero(), "время проставляется при публикации")
+	assert.Empty(t, other, "событие чужой тренировки")
+	require.Len(t, heard, 1, "фоновые обработчики получают все события")
+}
+
+func TestPublishDropsEventsForSlowSubscriber(t *testing.T) {
+	h := NewHub()
+	ch, unsub := h.Subscribe(1)
+	defer unsub()
+
+	for i := range subscriberBuffer + 5 {
+		h.Publish(Event{Type: SetChanged, WorkoutID: 1, Index: i})
+	}
+	assert.Len(t, ch, subscriberBuffer, "переполненный буфер не блокирует публикацию")
+}
+
+func TestUnsubscribeClosesChannelOnce(t *testing.T) {
+	h := NewHub()
+	ch, unsub := h.Subscribe(1)
+
+	unsub()
+	unsub()
+	_, open := <-ch
+	assert.False(t, open)
+	assert.Empty(t, h.subscribers, "пустая тренировка удаляется из карты")
+
+	// публикация без подписчиков и в nil-хаб не паникует
+	h.Publish(Event{Type: SetAdded, WorkoutID: 1})
+	var nilHub *Hub
+	nilHub.Publish(Event{Type: SetAdded, WorkoutID: 1})
+}
+
+// TestConcurrentSubscribePublish запускается с -race: подписка, публикация и отписка
+// из разных горутин не должны гоняться и писать в закрытый канал
+func TestConcurrentSubscribePublish(t *testing.T) {
+	h := NewHub()
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 100 {
+				ch, unsub := h.Subscribe(1)
+				h.Publish(Event{Type: SetChanged, WorkoutID: 1})
+				<-ch
+				unsub()
+			}
+		})
+	}
+	wg.Wait()
+	assert.Empty(t, h.subscribers)
+}
@@ -0,0 +1,53 @@
+package sharenotify
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+)
+
+// Notifier доставляет владельцу уведомление о реакции или комментарии к расшаренной тренировке
+type Notifier interface {
+	NotifyShareActivity(activity dto.ShareActivity) error
+}
+
+// Dispatcher рассылает уведомление по всем подключенным каналам, не задерживая ответ API
+type Dispatcher struct {
+	mu        sync.RWMutex
+	notifiers []Notifier
+}
+
+func NewDispatcher(notifiers ...Notifier) *Dispatcher {
+	return &Dispatcher{notifiers: notifiers}
+}
+
+// AddNotifier подключает канал уведомлений, который стал доступен после старта (например, телеграм-бот)
+func (d *Dispatcher) AddNotifier(n Notifier) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.notifiers = append(d.notifiers, n)
+}
+
+func (d *Dispatcher) Notify(activity dto.ShareActivity) {
+	d.mu.RLock()
+	notifiers := append([]Notifier(nil), d.notifiers...)
+	d.mu.RUnlock()
+
+	go func() {
+		for _, n := range notifiers {
+			if err := n.NotifyShareActivity(activity); err != nil {
+				slog.Error("share activity notify failed", "err", err)
+			}
+		}
+	}()
+}
+
+// Describe — заголовок и текст уведомления для каналов без разметки
+func Describe(activity dto.ShareActivity) (title, body string) {
+	if activity.Kind == dto.ShareActivityComment {
+		return fmt.Sprintf("💬 %s прокомментировал тренировку", activity.ActorName), activity.Text
+	}
+	return fmt.Sprintf("%s %s оценил тренировку", activity.Emoji, activity.ActorName), ""
+}
@@ -0,0 +1,53 @@
+package sharenotify
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+)
+
+type chanNotifier struct {
+	got chan dto.ShareActivity
+	err error
+}
+
+func (n *chanNotifier) NotifyShareActivity(activity dto.ShareActivity) error {
+	n.got <- activity
+	return n.err
+}
+
+func TestDispatcherNotifiesAllChannels(t *testing.T) {
+	failing := &chanNotifier{got: make(chan dto.ShareActivity, 1), err: errors.New("push is down")}
+	late := &chanNotifier{got: make(chan dto.ShareActivity, 1)}
+
+	d := NewDispatcher(failing)
+	d.AddNotifier(late)
+
+	activity := dto.ShareActivity{Kind: dto.ShareActivityReaction, OwnerID: 1, WorkoutID: 7, Emoji: "🔥"}
+	d.Notify(activity)
+
+	// ошибка одного канала не мешает доставке в следующий
+	for _, n := range []*chanNotifier{failing, late} {
+		select {
+		case got := <-n.got:
+			assert.Equal(t, activity, got)
+		case <-time.After(time.Second):
+			require.Fail(t, "notification was not delivered")
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	title, body := Describe(dto.ShareActivity{Kind: dto.ShareActivityComment, ActorName: "Иван", Text: "Отлично!"})
+	assert.Equal(t, "💬 Иван прокомментировал тренировку", title)
+	assert.Equal(t, "Отлично!", body)
+
+	title, body = Describe(dto.ShareActivity{Kind: dto.ShareActivityReaction, ActorName: "Иван", Emoji: "💪"})
+	assert.Equal(t, "💪 Иван оценил тренировку", title)
+	assert.Empty(t, body)
+}
@@ -0,0 +1,19 @@
+package sharenotify
+
+import (
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/dto"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/push"
+)
+
+type pushNotifier struct {
+	push *push.Service
+}
+
+func NewPushNotifier(push *push.Service) Notifier {
+	return &pushNotifier{push: push}
+}
+
+func (n *pushNotifier) NotifyShareActivity(activity dto.ShareActivity) error {
+	title, body := Describe(activity)
+	return n.push.SendShareActivity(activity.OwnerID, activity.WorkoutID, title, body)
+}
@@ -10,11 +10,13 @@ import (
 	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
 )
 
+// Service собирает сводки выгрузки из дневных и недельных агрегатов (rollups.Repo),
+// поэтому их стоимость зависит от числа дней и недель, а не подходов
 type Service interface {
-	BuildTotal(workouts []models.WorkoutDay, groupCodesMap map[string]string) map[string]*ExerciseSummary
-	BuildByDate(workouts []models.WorkoutDay) map[string]*DateSummary
-	BuildExerciseProgressByDates(workouts []models.WorkoutDay) []*ExerciseProgressByDates
-	BuildByWeekAndExType(workouts []models.WorkoutDay, groupCodesMap map[string]string) map[utils.DateRange]map[string]*WeekSummary
+	BuildTotal(daily []models.StatsRollup, groupCodesMap map[string]string) map[string]*ExerciseSummary
+	BuildByDate(daily []models.StatsRollup) map[string]*DateSummary
+	BuildExerciseProgressByDates(daily, weekly []models.StatsRollup) []*ExerciseProgressByDates
+	BuildByWeekAndExType(weekly []models.StatsRollup, groupCodesMap map[string]string) map[utils.DateRange]map[string]*WeekSummary
 }
 
 type serviceImpl struct {
@@ -24,44 +26,27 @@ func NewService() Service {
 	return &serviceImpl{}
 }
 
-func (s *serviceImpl) BuildTotal(workouts []models.WorkoutDay, groupCodesMap map[string]string) map[string]*ExerciseSummary {
+func (s *serviceImpl) BuildTotal(daily []models.StatsRollup, groupCodesMap map[string]string) map[string]*ExerciseSummary {
 	summary := make(map[string]*ExerciseSummary)
 
-	for _, w := range workouts {
-		if !w.Completed {
-			continue
-		}
-		date := w.StartedAt.Add(3 * time.Hour).Format("2006-01-02")
-
-		for _, e := range w.Exercises {
-			if e.CompletedSets() == 0 {
-				continue
-			}
-
-			sum, ok := summary[e.ExerciseType.Name]
-			if !ok {
-				sum = &ExerciseSummary{
-					Workouts: make(map[string]struct{}),
-				}
-				summary[e.ExerciseType.Name] = sum
+	for _, r := range daily {
+		name := exerciseName(r)
+		sum, ok := summary[name]
+		if !ok {
+			sum = &ExerciseSummary{
+				Workouts: make(map[string]struct{}),
 			}
+			summary[name] = sum
+		}
 
-			sum.ExerciseType = groupCodesMap[e.ExerciseType.ExerciseGroupTypeCode]
-			sum.Workouts[date] = struct{}{}
-
-			for _, set := range e.Sets {
-				if !set.Completed {
-					continue
-				}
-				sum.Sets++
-				sum.TotalWeight += float64(set.Weight) * float64(set.Reps)
-				sum.TotalReps += set.Reps
-				sum.TotalMinutes += set.Minutes
-
-				if set.Weight > sum.MaxWeight {
-					sum.MaxWeight = set.Weight
-				}
-			}
+		sum.ExerciseType = groupCodesMap[r.GroupCode]
+		sum.Workouts[r.Period.Format("2006-01-02")] = struct{}{}
+		sum.Sets += r.Sets
+		sum.TotalWeight += r.Tonnage
+		sum.TotalReps += r.Reps
+		sum.TotalMinutes += r.Minutes
+		if float32(r.MaxWeight) > sum.MaxWeight {
+			sum.MaxWeight = float32(r.MaxWeight)
 		}
 	}
 
@@ -74,108 +59,69 @@ func (s *serviceImpl) BuildTotal(workouts []models.WorkoutDay, groupCodesMap map
 	return summary
 }
 
-func (s *serviceImpl) BuildByDate(workouts []models.WorkoutDay) map[string]*DateSummary {
+func (s *serviceImpl) BuildByDate(daily []models.StatsRollup) map[string]*DateSummary {
 	result := make(map[string]*DateSummary)
 
-	for _, w := range workouts {
-		date := w.StartedAt.Add(3 * time.Hour).Format("2006-01-02")
+	for _, r := range daily {
+		date := r.Period.Format("2006-01-02")
 
 		d, ok := result[date]
 		if !ok {
 			d = &DateSummary{
-				Workouts:  1,
 				Exercises: make(map[string]struct{}),
 			}
 			result[date] = d
 		}
 
-		for _, e := range w.Exercises {
-			d.Exercises[e.ExerciseType.Name] = struct{}{}
-
-			for _, sum := range e.Sets {
-				d.Sets++
-				d.TotalVolume += sum.Weight * float32(sum.Reps)
-
-				if sum.Weight > d.MaxWeight {
-					d.MaxWeight = sum.Weight
-				}
-			}
+		// тренировки считаются по упражнениям, поэтому за день берем максимум, а не сумму
+		d.Workouts = max(d.Workouts, r.Workouts)
+		d.Exercises[exerciseName(r)] = struct{}{}
+		d.Sets += r.Sets
+		d.TotalVolume += float32(r.Tonnage)
+		if float32(r.MaxWeight) > d.MaxWeight {
+			d.MaxWeight = float32(r.MaxWeight)
 		}
 	}
 
 	return result
 }
 
-func (s *serviceImpl) BuildExerciseProgressByDates(workouts []models.WorkoutDay) []*ExerciseProgressByDates {
+// BuildExerciseProgressByDates строит динамику по дням, а для кардио — по неделям
+func (s *serviceImpl) BuildExerciseProgressByDates(daily, weekly []models.StatsRollup) []*ExerciseProgressByDates {
 	exerciseWithProgressesMap := make(map[string]map[string]*Progress) // exName -> date -> progress
 
-	for _, w := range workouts {
-		if !w.Completed {
-			continue
+	add := func(r models.StatsRollup, key string) {
+		name := exerciseName(r)
+		if _, ok := exerciseWithProgressesMap[name]; !ok {
+			exerciseWithProgressesMap[name] = make(map[string]*Progress)
 		}
-
-		currDate := w.StartedAt.Add(3 * time.Hour).Format("02.01.2006")
-		thisWeekRange := utils.GetThisWeekRange(w.StartedAt)
-
-		var key string
-		for _, e := range w.Exercises {
-			if e.CompletedSets() == 0 {
-				continue
-			}
-
-			if e.ExerciseType.ExerciseGroupTypeCode == "cardio" {
-				key = thisWeekRange.Format()
-			} else {
-				key = currDate
-			}
-
-			sumWeight := float32(0)
-			countOfReps := 0
-
-			if _, ok := exerciseWithProgressesMap[e.ExerciseType.Name]; !ok {
-				exerciseWithProgressesMap[e.ExerciseType.Name] = make(map[string]*Progress)
-			}
-			if _, ok := exerciseWithProgressesMap[e.ExerciseType.Name][key]; !ok {
-				exerciseWithProgressesMap[e.ExerciseType.Name][key] = &Progress{
-					Units:     e.ExerciseType.Units,
-					GroupCode: e.ExerciseType.ExerciseGroupTypeCode,
-				}
-			}
-
-			tempProgress := exerciseWithProgressesMap[e.ExerciseType.Name][key]
-
-			for _, set := range e.Sets {
-				if !set.Completed {
-					continue
-				}
-
-				countOfReps += set.GetRealReps()
-				sumWeight += set.GetRealWeight() * float32(set.GetRealReps())
-				if tempProgress.MaxWeight < set.GetRealWeight() ||
-					tempProgress.MaxWeight == set.GetRealWeight() && tempProgress.MaxReps < set.GetRealReps() {
-					tempProgress.MaxWeight = set.GetRealWeight()
-					tempProgress.MaxReps = set.GetRealReps()
-				}
-
-				tempProgress.SumMinutes += set.GetRealMinutes()
-				if tempProgress.MinMinutes == 0 {
-					tempProgress.MinMinutes = set.GetRealMinutes()
-					tempProgress.MaxMinutes = set.GetRealMinutes()
-				} else {
-					tempProgress.MinMinutes = min(tempProgress.MinMinutes, set.GetRealMinutes())
-					tempProgress.MaxMinutes = max(tempProgress.MaxMinutes, set.GetRealMinutes())
-				}
-
-				tempProgress.SumMeters += set.GetRealMeters()
-				if tempProgress.MinMeters == 0 {
-					tempProgress.MinMeters = set.GetRealMeters()
-					tempProgress.MaxMeters = set.GetRealMeters()
-				} else {
-					tempProgress.MinMeters = min(tempProgress.MinMeters, set.GetRealMeters())
-					tempProgress.MaxMeters = max(tempProgress.MaxMeters, set.GetRealMeters())
-				}
-			}
-			tempProgress.AvgWeight = sumWeight / float32(countOfReps)
+		progress := &Progress{
+			MaxWeight:  float32(r.MaxWeight),
+			MaxReps:    r.MaxWeightReps,
+			MinMinutes: r.MinMinutes,
+			MaxMinutes: r.MaxMinutes,
+			SumMinutes: r.Minutes,
+			MinMeters:  r.MinMeters,
+			MaxMeters:  r.MaxMeters,
+			SumMeters:  r.Meters,
+			GroupCode:  r.GroupCode,
+		}
+		if r.ExerciseType != nil {
+			progress.Units = r.ExerciseType.Units
+		}
+		if r.Reps > 0 {
+			progress.AvgWeight = float32(r.Tonnage / float64(r.Reps))
+		}
+		exerciseWithProgressesMap[name][key] = progress
+	}
+	for _, r := range daily {
+		if r.GroupCode != "cardio" {
+			add(r, r.Period.Format("02.01.2006"))
+		}
+	}
+	for _, r := range weekly {
+		if r.GroupCode == "cardio" {
+			add(r, weekRange(r.Period).Format())
 		}
 	}
 
@@ -216,24 +162,32 @@ func (s *serviceImpl) BuildExerciseProgressByDates(workouts []models.WorkoutDay)
 	return result
 }
 
-func (s *serviceImpl) BuildByWeekAndExType(workouts []models.WorkoutDay, groupCodesMap map[string]string) map[utils.DateRange]map[string]*WeekSummary {
+func (s *serviceImpl) BuildByWeekAndExType(weekly []models.StatsRollup, groupCodesMap map[string]string) map[utils.DateRange]map[string]*WeekSummary {
 	result := make(map[utils.DateRange]map[string]*WeekSummary)
-	for _, w := range workouts {
-		thisWeek := utils.GetThisWeekRange(w.StartedAt)
+	for _, r := range weekly {
+		thisWeek := weekRange(r.Period)
 		if _, ok := result[thisWeek]; !ok {
 			result[thisWeek] = map[string]*WeekSummary{}
 		}
-		for _, e := range w.Exercises {
-			groupName := groupCodesMap[e.ExerciseType.ExerciseGroupTypeCode]
-			if _, ok := result[thisWeek][groupName]; !ok {
-				result[thisWeek][groupName] = &WeekSummary{}
-			}
-			for _, set := range e.Sets {
-				result[thisWeek][groupName].SumWeight += float32(set.GetRealReps()) * set.GetRealWeight()
-				result[thisWeek][groupName].SumMinutes += set.GetRealMinutes()
-				result[thisWeek][groupName].SumMeters += set.GetRealMeters()
-			}
+		groupName := groupCodesMap[r.GroupCode]
+		if _, ok := result[thisWeek][groupName]; !ok {
+			result[thisWeek][groupName] = &WeekSummary{}
 		}
+		result[thisWeek][groupName].SumWeight += float32(r.Tonnage)
+		result[thisWeek][groupName].SumMinutes += r.Minutes
+		result[thisWeek][groupName].SumMeters += r.Meters
 	}
 	return result
 }
+
+// weekRange — та же неделя, что utils.GetThisWeekRange для тренировки в этот понедельник
+func weekRange(monday time.Time) utils.DateRange {
+	return utils.DateRange{From: monday, To: monday.AddDate(0, 0, 6)}
+}
+
+func exerciseName(r models.StatsRollup) string {
+	if r.ExerciseType == nil {
+		return ""
+	}
+	return r.ExerciseType.Name
+}
@@ -0,0 +1,81 @@
+package summary
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/utils"
+)
+
+var (
+	bench = &models.ExerciseType{ID: 1, Name: "Жим лежа", ExerciseGroupTypeCode: "chest", Units: "reps,weight"}
+	run   = &models.ExerciseType{ID: 2, Name: "Бег", ExerciseGroupTypeCode: "cardio", Units: "minutes,meters"}
+
+	groupCodes = map[string]string{"chest": "Грудь", "cardio": "Кардио"}
+)
+
+func day(d int) time.Time {
+	return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestBuildTotalAndByDate(t *testing.T) {
+	daily := []models.StatsRollup{
+		{Period: day(5), ExerciseTypeID: 1, ExerciseType: bench, GroupCode: "chest", Workouts: 1, Sets: 3, Reps: 30, Tonnage: 1500, MaxWeight: 60},
+		{Period: day(5), ExerciseTypeID: 2, ExerciseType: run, GroupCode: "cardio", Workouts: 2, Sets: 1, Minutes: 20, Meters: 3000},
+		{Period: day(7), ExerciseTypeID: 1, ExerciseType: bench, GroupCode: "chest", Workouts: 1, Sets: 2, Reps: 10, Tonnage: 700, MaxWeight: 70},
+	}
+	s := NewService()
+
+	total := s.BuildTotal(daily, groupCodes)
+	require.Contains(t, total, "Жим лежа")
+	assert.Equal(t, "Грудь", total["Жим лежа"].ExerciseType)
+	assert.Len(t, total["Жим лежа"].Workouts, 2)
+	assert.Equal(t, 5, total["Жим лежа"].Sets)
+	assert.Equal(t, float32(70), total["Жим лежа"].MaxWeight)
+	assert.Equal(t, float64(55), total["Жим лежа"].AvgWeight)
+	assert.Equal(t, 20, total["Бег"].TotalMinutes)
+
+	byDate := s.BuildByDate(daily)
+	require.Contains(t, byDate, "2026-01-05")
+	// упражнения одной тренировки не должны умножать число тренировок за день
+	assert.Equal(t, 2, byDate["2026-01-05"].Workouts)
+	assert.Len(t, byDate["2026-01-05"].Exercises, 2)
+	assert.Equal(t, float32(1500), byDate["2026-01-05"].TotalVolume)
+}
+
+func TestBuildByWeekUsesSameRangesAsWorkouts(t *testing.T) {
+	monday := day(5)
+	weekly := []models.StatsRollup{
+		{Period: monday, ExerciseTypeID: 1, ExerciseType: bench, GroupCode: "chest", Tonnage: 2200},
+		{Period: monday, ExerciseTypeID: 2, ExerciseType: run, GroupCode: "cardio", Minutes: 20, Meters: 3000, MinMinutes: 20, MaxMinutes: 20},
+	}
+	s := NewService()
+
+	// тренировка в воскресенье вечером по Москве — та же неделя, что и у агрегата
+	sunday := time.Date(2026, 1, 11, 20, 0, 0, 0, time.UTC)
+	byWeek := s.BuildByWeekAndExType(weekly, groupCodes)
+	require.Contains(t, byWeek, utils.GetThisWeekRange(sunday))
+	assert.Equal(t, float32(2200), byWeek[utils.GetThisWeekRange(sunday)]["Грудь"].SumWeight)
+	assert.Equal(t, 3000, byWeek[utils.GetThisWeekRange(sunday)]["Кардио"].SumMeters)
+
+	// кардио в динамике идет по неделям, остальное — по дням
+	daily := []models.StatsRollup{{Period: day(6), ExerciseTypeID: 1, ExerciseType: bench, GroupCode: "chest", Reps: 10, Tonnage: 600, MaxWeight: 60, MaxWeightReps: 10}}
+	progress := s.BuildExerciseProgressByDates(daily, weekly)
+	require.Len(t, progress, 2)
+	for _, p := range progress {
+		require.Len(t, p.DateWithProgress, 1)
+		switch p.ExerciseName {
+		case "Бег":
+			assert.Equal(t, "05.01.26 – 11.01.26", p.DateWithProgress[0].Date)
+			assert.Equal(t, 20, p.DateWithProgress[0].Progress.SumMinutes)
+		case "Жим лежа":
+			assert.Equal(t, "06.01.2026", p.DateWithProgress[0].Date)
+			assert.Equal(t, float32(60), p.DateWithProgress[0].Progress.AvgWeight)
+			assert.Equal(t, "reps,weight", p.DateWithProgress[0].Progress.Units)
+		}
+	}
+}
@@ -2,9 +2,12 @@ package timermanager
 
 import (
 	"errors"
-	"fmt"
-	"github.com/SaenkoDmitry/training-tg-bot/internal/service/push"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/application/events"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/repository/outbox"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/metrics"
+	"github.com/SaenkoDmitry/training-tg-bot/internal/service/realtime"
 	"gorm.io/gorm"
+	"log/slog"
 	"sync"
 	"time"
 
@@ -15,13 +18,13 @@ type TimerManager struct {
 	db     *gorm.DB
 	mu     sync.Mutex
 	timers map[int64]*time.Timer
-	push   *push.Service
+	hub    *realtime.Hub
 }
 
-func NewTimerManager(db *gorm.DB, push *push.Service) *TimerManager {
+func NewTimerManager(db *gorm.DB, hub *realtime.Hub) *TimerManager {
 	return &TimerManager{
 		db:     db,
-		push:   push,
+		hub:    hub,
 		timers: make(map[int64]*time.Timer),
 	}
 }
@@ -39,6 +42,12 @@ func (tm *TimerManager) Start(userID, workoutID int64, seconds int) (*TimerDTO,
 
 	tm.schedule(&timer)
 
+	tm.hub.Publish(realtime.Event{
+		Type:      realtime.TimerStarted,
+		WorkoutID: timer.WorkoutID,
+		TimerID:   timer.ID,
+	})
+
 	return mapTimerDTO(timer), nil
 }
 
@@ -105,15 +114,31 @@ func (tm *TimerManager) fire(timerID int64) {
 	if timer.Canceled || timer.Sent {
 		return
 	}
-
-	// отправляем push
-	if err := tm.push.SendWorkoutFinished(timer.UserID, timer.WorkoutID); err != nil {
-		fmt.Println("push error:", err)
+	metrics.TimerLag.WithLabelValues("rest").Observe(time.Since(timer.EndsAt).Seconds())
+
+	// push уходит подписчиком RestTimerFinished: отметка и событие пишутся вместе,
+	// поэтому уведомление не потеряется и не уйдет дважды при перезапуске
+	err := tm.db.Transaction(func(tx *gorm.DB) error {
+		timer.Sent = true
+		if err := tx.Save(&timer).Error; err != nil {
+			return err
+		}
+		return outbox.Append(tx, events.RestTimerFinished{
+			TimerID:   timer.ID,
+			WorkoutID: timer.WorkoutID,
+			UserID:    timer.UserID,
+		})
+	})
+	if err != nil {
+		slog.Error("rest timer finish failed", "timer_id", timerID, "err", err)
 		return
 	}
 
-	timer.Sent = true
-	tm.db.Save(&timer)
+	tm.hub.Publish(realtime.Event{
+		Type:      realtime.TimerFinished,
+		WorkoutID: timer.WorkoutID,
+		TimerID:   timer.ID,
+	})
 
 	tm.mu.Lock()
 	delete(tm.timers, timerID)
@@ -134,6 +159,12 @@ func (tm *TimerManager) Cancel(timerID int64, userID int64) error {
 	timer.Canceled = true
 	tm.db.Save(&timer)
 
+	tm.hub.Publish(realtime.Event{
+		Type:      realtime.TimerCanceled,
+		WorkoutID: timer.WorkoutID,
+		TimerID:   timer.ID,
+	})
+
 	tm.mu.Lock()
 	if t, ok := tm.timers[timerID]; ok {
 		t.Stop()
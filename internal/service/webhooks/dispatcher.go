@@ -0,0 +1,155 @@
+package webhooks
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+const (
+	pollInterval = 2 * time.Second
+	batchSize    = 50
+
+	// после MaxAttempts неудачных попыток доставка помечается failed
+	MaxAttempts = 8
+	baseBackoff = 30 * time.Second
+	maxBackoff  = 6 * time.Hour
+
+	retention       = 30 * 24 * time.Hour
+	cleanupInterval = time.Hour
+)
+
+type Config struct {
+	AllowPrivate bool // разрешить адреса во внутренней сети (для self-hosted установок рядом с Home Assistant)
+}
+
+// ConfigFromEnv читает WEBHOOKS_ALLOW_PRIVATE (true/false)
+func ConfigFromEnv() Config {
+	allow, _ := strconv.ParseBool(os.Getenv("WEBHOOKS_ALLOW_PRIVATE"))
+	return Config{AllowPrivate: allow}
+}
+
+// Store — часть репозитория вебхуков, нужная для доставки
+type Store interface {
+	FindDueDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error)
+	SaveDelivery(delivery *models.WebhookDelivery) error
+	DeleteDeliveriesBefore(t time.Time) (int64, error)
+}
+
+// Dispatcher отправляет ожидающие доставки и планирует повторы неудачных
+type Dispatcher struct {
+	store  Store
+	sender *Sender
+}
+
+func NewDispatcher(store Store, sender *Sender) *Dispatcher {
+	return &Dispatcher{
+		store:  store,
+		sender: sender,
+	}
+}
+
+func (d *Dispatcher) Run() {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	lastCleanup := time.Time{}
+	for {
+		now := time.Now()
+		for d.dispatch(now) == batchSize {
+			now = time.Now()
+		}
+
+		if now.Sub(lastCleanup) >= cleanupInterval {
+			if _, err := d.store.DeleteDeliveriesBefore(now.Add(-retention)); err != nil {
+				slog.Error("webhook deliveries cleanup failed", "err", err)
+			}
+			lastCleanup = now
+		}
+
+		<-ticker.C
+	}
+}
+
+// dispatch отправляет одну пачку доставок и возвращает ее размер
+func (d *Dispatcher) dispatch(now time.Time) int {
+	due, err := d.store.FindDueDeliveries(now, batchSize)
+	if err != nil {
+		slog.Error("webhook deliveries fetch failed", "err", err)
+		return 0
+	}
+
+	for i := range due {
+		delivery := &due[i]
+		if delivery.Webhook == nil || !delivery.Webhook.Active {
+			Apply(delivery, Result{Err: fmt.Errorf("webhook is disabled")}, now, true)
+		} else {
+			Apply(delivery, d.sender.Send(RequestFor(delivery)), now, false)
+		}
+		if err = d.store.SaveDelivery(delivery); err != nil {
+			slog.Error("webhook delivery save failed", "err", err)
+		}
+	}
+	return len(due)
+}
+
+// RequestFor собирает запрос доставки; Webhook должен быть загружен
+func RequestFor(delivery *models.WebhookDelivery) Request {
+	return Request{
+		DeliveryID: delivery.ID,
+		EventType:  delivery.EventType,
+		URL:        delivery.Webhook.URL,
+		Secret:     delivery.Webhook.Secret,
+		Body:       []byte(delivery.Payload),
+	}
+}
+
+// Apply записывает результат попытки в доставку: успех, повтор с паузой или окончательную ошибку.
+// final — повторять не нужно (например, тестовое событие)
+func Apply(delivery *models.WebhookDelivery, res Result, now time.Time, final bool) {
+	delivery.Attempts++
+	delivery.ResponseStatus, delivery.ResponseBody, delivery.Error = nil, nil, nil
+	if res.StatusCode != 0 {
+		status, body := res.StatusCode, res.Body
+		delivery.ResponseStatus, delivery.ResponseBody = &status, &body
+	}
+	durationMs := int(res.Duration.Milliseconds())
+	delivery.DurationMs = &durationMs
+
+	if res.OK() {
+		delivery.Status = models.WebhookDeliverySuccess
+		delivery.DeliveredAt = &now
+		return
+	}
+
+	errText := fmt.Sprintf("unexpected status %d", res.StatusCode)
+	if res.Err != nil {
+		errText = res.Err.Error()
+	}
+	delivery.Error = &errText
+
+	if final || delivery.Attempts >= MaxAttempts {
+		delivery.Status = models.WebhookDeliveryFailed
+		return
+	}
+	delivery.NextAttemptAt = now.Add(Backoff(delivery.Attempts))
+}
+
+// Backoff — пауза перед попыткой attempts+1: 30s, 1m, 2m ... но не больше 6 часов
+func Backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	d := baseBackoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return d
+}
@@ -0,0 +1,111 @@
+package webhooks
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	requestTimeout  = 10 * time.Second
+	maxResponseBody = 2048 // в журнал сохраняется только начало ответа
+	userAgent       = "training-bot-webhooks/1.0"
+)
+
+var PrivateAddressErr = errors.New("webhook address is private")
+
+type Request struct {
+	DeliveryID int64
+	EventType  string
+	URL        string
+	Secret     string
+	Body       []byte
+}
+
+type Result struct {
+	StatusCode int
+	Body       string
+	Duration   time.Duration
+	Err        error
+}
+
+// OK — получатель принял событие (ответил 2xx)
+func (r Result) OK() bool {
+	return r.Err == nil && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewSender: без allowPrivate запросы во внутреннюю сеть сервера (localhost, 10.0.0.0/8 и т.п.) запрещены,
+// проверяется уже разрешенный адрес, поэтому DNS-имя, указывающее внутрь, тоже не пройдет
+func NewSender(allowPrivate bool) *Sender {
+	dialer := &net.Dialer{Timeout: requestTimeout}
+	if !allowPrivate {
+		dialer.Control = denyPrivate
+	}
+	return &Sender{
+		client: &http.Client{
+			Timeout:   requestTimeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext, Proxy: nil},
+			// редирект мог бы увести запрос на другой адрес, 3xx считается неуспешной доставкой
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+func (s *Sender) Send(req Request) Result {
+	timestamp := s.now().Unix()
+
+	httpReq, err := http.NewRequest(http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return Result{Err: err}
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", userAgent)
+	httpReq.Header.Set(HeaderEvent, req.EventType)
+	httpReq.Header.Set(HeaderDelivery, strconv.FormatInt(req.DeliveryID, 10))
+	httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Body))
+
+	started := time.Now()
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return Result{Duration: time.Since(started), Err: err}
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	return Result{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		Duration:   time.Since(started),
+	}
+}
+
+func denyPrivate(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("unexpected address %q", address)
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return PrivateAddressErr
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// заголовки запроса вебхука
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"  // id доставки: один и тот же при повторах, по нему получатель отбрасывает дубли
+	HeaderTimestamp = "X-Webhook-Timestamp" // unix-время отправки, входит в подпись
+	HeaderSignature = "X-Webhook-Signature" // sha256=<hex HMAC-SHA256(secret, timestamp + "." + body)>
+)
+
+const signaturePrefix = "sha256="
+
+// Sign подписывает тело вместе со временем отправки, чтобы перехваченный запрос нельзя было повторить позже
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify — проверка подписи на стороне получателя
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
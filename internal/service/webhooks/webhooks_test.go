@@ -0,0 +1,211 @@
+package webhooks
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SaenkoDmitry/training-tg-bot/internal/models"
+)
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+// receiver — локальный получатель вебхуков, отвечает статусами из statuses по очереди
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []received
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	r.requests = append(r.requests, received{header: req.Header.Clone(), body: body})
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	r.mu.Unlock()
+
+	w.WriteHeader(status)
+	w.Write([]byte("ack"))
+}
+
+type storeStub struct {
+	deliveries []models.WebhookDelivery
+}
+
+func (s *storeStub) FindDueDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var result []models.WebhookDelivery
+	for _, d := range s.deliveries {
+		if d.Status == models.WebhookDeliveryPending && !d.NextAttemptAt.After(now) {
+			result = append(result, d)
+		}
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (s *storeStub) SaveDelivery(delivery *models.WebhookDelivery) error {
+	for i := range s.deliveries {
+		if s.deliveries[i].ID == delivery.ID {
+			s.deliveries[i] = *delivery
+		}
+	}
+	return nil
+}
+
+func (s *storeStub) DeleteDeliveriesBefore(time.Time) (int64, error) {
+	return 0, nil
+}
+
+func newDelivery(id int64, url string) models.WebhookDelivery {
+	return models.WebhookDelivery{
+		ID:        id,
+		WebhookID: 1,
+		EventType: "workout_finished",
+		Payload:   `{"event":"workout_finished","data":{"workout_id":10}}`,
+		Status:    models.WebhookDeliveryPending,
+		Webhook:   &models.Webhook{ID: 1, URL: url, Secret: "s3cr3t", Active: true},
+	}
+}
+
+func TestSenderSignsPayload(t *testing.T) {
+	rcv := &receiver{}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	body := []byte(`{"event":"test"}`)
+	res := NewSender(true).Send(Request{DeliveryID: 42, EventType: "test", URL: server.URL, Secret: "s3cr3t", Body: body})
+
+	require.NoError(t, res.Err)
+	assert.True(t, res.OK())
+	assert.Equal(t, "ack", res.Body)
+
+	require.Len(t, rcv.requests, 1)
+	got := rcv.requests[0]
+	assert.Equal(t, body, got.body)
+	assert.Equal(t, "test", got.header.Get(HeaderEvent))
+	assert.Equal(t, "42", got.header.Get(HeaderDelivery))
+	assert.Equal(t, "application/json", got.header.Get("Content-Type"))
+
+	timestamp, err := strconv.ParseInt(got.header.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.True(t, Verify("s3cr3t", timestamp, got.body, got.header.Get(HeaderSignature)))
+	assert.False(t, Verify("other", timestamp, got.body, got.header.Get(HeaderSignature)))
+	assert.False(t, Verify("s3cr3t", timestamp+1, got.body, got.header.Get(HeaderSignature)))
+}
+
+func TestSenderRejectsPrivateAddress(t *testing.T) {
+	rcv := &receiver{}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	res := NewSender(false).Send(Request{URL: server.URL, Body: []byte(`{}`)})
+
+	assert.ErrorIs(t, res.Err, PrivateAddressErr)
+	assert.Empty(t, rcv.requests)
+}
+
+func TestSenderDoesNotFollowRedirects(t *testing.T) {
+	server := httptest.NewServer(http.RedirectHandler("http://169.254.169.254/", http.StatusFound))
+	defer server.Close()
+
+	res := NewSender(true).Send(Request{URL: server.URL, Body: []byte(`{}`)})
+
+	require.NoError(t, res.Err)
+	assert.Equal(t, http.StatusFound, res.StatusCode)
+	assert.False(t, res.OK())
+}
+
+func TestDispatcherRetriesWithBackoff(t *testing.T) {
+	rcv := &receiver{statuses: []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusNoContent}}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	store := &storeStub{deliveries: []models.WebhookDelivery{newDelivery(1, server.URL)}}
+	dispatcher := NewDispatcher(store, NewSender(true))
+
+	now := time.Now()
+	assert.Equal(t, 1, dispatcher.dispatch(now))
+	d := store.deliveries[0]
+	assert.Equal(t, models.WebhookDeliveryPending, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, *d.ResponseStatus)
+	assert.Equal(t, now.Add(Backoff(1)), d.NextAttemptAt)
+
+	// до истечения паузы доставка не повторяется
+	assert.Zero(t, dispatcher.dispatch(now.Add(time.Second)))
+
+	now = d.NextAttemptAt
+	dispatcher.dispatch(now)
+	assert.Equal(t, now.Add(Backoff(2)), store.deliveries[0].NextAttemptAt)
+
+	now = store.deliveries[0].NextAttemptAt
+	dispatcher.dispatch(now)
+	d = store.deliveries[0]
+	assert.Equal(t, models.WebhookDeliverySuccess, d.Status)
+	assert.Equal(t, 3, d.Attempts)
+	assert.Nil(t, d.Error)
+	require.NotNil(t, d.DeliveredAt)
+	assert.Equal(t, now, *d.DeliveredAt)
+
+	require.Len(t, rcv.requests, 3)
+	// повторы приходят с тем же id доставки
+	for _, r := range rcv.requests {
+		assert.Equal(t, "1", r.header.Get(HeaderDelivery))
+	}
+}
+
+func TestDispatcherGivesUpAfterMaxAttempts(t *testing.T) {
+	rcv := &receiver{statuses: []int{http.StatusServiceUnavailable}}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	delivery := newDelivery(1, server.URL)
+	delivery.Attempts = MaxAttempts - 1
+	store := &storeStub{deliveries: []models.WebhookDelivery{delivery}}
+
+	NewDispatcher(store, NewSender(true)).dispatch(time.Now())
+
+	d := store.deliveries[0]
+	assert.Equal(t, models.WebhookDeliveryFailed, d.Status)
+	assert.Equal(t, MaxAttempts, d.Attempts)
+	require.NotNil(t, d.Error)
+	assert.Equal(t, "unexpected status 503", *d.Error)
+}
+
+func TestDispatcherSkipsDisabledWebhook(t *testing.T) {
+	rcv := &receiver{}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	delivery := newDelivery(1, server.URL)
+	delivery.Webhook.Active = false
+	store := &storeStub{deliveries: []models.WebhookDelivery{delivery}}
+
+	NewDispatcher(store, NewSender(true)).dispatch(time.Now())
+
+	assert.Equal(t, models.WebhookDeliveryFailed, store.deliveries[0].Status)
+	assert.Empty(t, rcv.requests)
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, Backoff(1))
+	assert.Equal(t, time.Minute, Backoff(2))
+	assert.Equal(t, 4*time.Minute, Backoff(4))
+	assert.Equal(t, 6*time.Hour, Backoff(MaxAttempts+10))
+}
@@ -190,3 +190,50 @@ func GetThisWeekRange(date time.Time) DateRange {
 	to := date.AddDate(0, 0, 7-int(align)-1)
 	return DateRange{From: from, To: to}
 }
+
+var mskZone = time.FixedZone("MSK", 3*60*60)
+
+// ParseWorkoutTimes разбирает ввод вида "19:45", "18:30-19:45" или "18.10.2026 18:30-19:45".
+// Время указывается по Москве, без даты берется defaultDate в формате 02.01.2006.
+// Если указано одно время, это время окончания, startedAt тогда nil
+func ParseWorkoutTimes(text, defaultDate string) (startedAt, endedAt *time.Time, err error) {
+	text = strings.TrimSpace(text)
+	date := defaultDate
+	if parts := strings.Fields(text); len(parts) > 1 && strings.Contains(parts[0], ".") {
+		date = parts[0]
+		text = strings.Join(parts[1:], "")
+	}
+	text = strings.ReplaceAll(text, " ", "")
+
+	parse := func(clock string) (time.Time, error) {
+		return time.ParseInLocation("02.01.2006 15:04", date+" "+clock, mskZone)
+	}
+
+	clocks := strings.Split(text, "-")
+	switch len(clocks) {
+	case 1:
+		end, parseErr := parse(clocks[0])
+		if parseErr != nil {
+			return nil, nil, parseErr
+		}
+		end = end.UTC()
+		return nil, &end, nil
+	case 2:
+		start, parseErr := parse(clocks[0])
+		if parseErr != nil {
+			return nil, nil, parseErr
+		}
+		end, parseErr := parse(clocks[1])
+		if parseErr != nil {
+			return nil, nil, parseErr
+		}
+		// тренировка через полночь
+		if !end.After(start) {
+			end = end.AddDate(0, 0, 1)
+		}
+		start, end = start.UTC(), end.UTC()
+		return &start, &end, nil
+	default:
+		return nil, nil, fmt.Errorf("invalid workout times: %q", text)
+	}
+}
@@ -400,3 +400,73 @@ func TestGetThisWeek(t *testing.T) {
 		})
 	}
 }
+
+func TestParseWorkoutTimes(t *testing.T) {
+	mustTime := func(s string) *time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &v
+	}
+
+	tests := []struct {
+		name        string
+		text        string
+		defaultDate string
+		wantStart   *time.Time
+		wantEnd     *time.Time
+		wantErr     bool
+	}{
+		{
+			name:        "only end time",
+			text:        "19:45",
+			defaultDate: "18.10.2026",
+			wantEnd:     mustTime("2026-10-18T16:45:00Z"),
+		},
+		{
+			name:        "range on default date",
+			text:        "18:30 - 19:45",
+			defaultDate: "18.10.2026",
+			wantStart:   mustTime("2026-10-18T15:30:00Z"),
+			wantEnd:     mustTime("2026-10-18T16:45:00Z"),
+		},
+		{
+			name:        "range with explicit date",
+			text:        "17.10.2026 08:00-09:10",
+			defaultDate: "18.10.2026",
+			wantStart:   mustTime("2026-10-17T05:00:00Z"),
+			wantEnd:     mustTime("2026-10-17T06:10:00Z"),
+		},
+		{
+			name:        "range over midnight",
+			text:        "23:30-00:40",
+			defaultDate: "18.10.2026",
+			wantStart:   mustTime("2026-10-18T20:30:00Z"),
+			wantEnd:     mustTime("2026-10-18T21:40:00Z"),
+		},
+		{
+			name:        "garbage",
+			text:        "вчера вечером",
+			defaultDate: "18.10.2026",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := ParseWorkoutTimes(tt.text, tt.defaultDate)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseWorkoutTimes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(start, tt.wantStart) {
+				t.Errorf("ParseWorkoutTimes() start = %v, want %v", start, tt.wantStart)
+			}
+			if !reflect.DeepEqual(end, tt.wantEnd) {
+				t.Errorf("ParseWorkoutTimes() end = %v, want %v", end, tt.wantEnd)
+			}
+		})
+	}
+}
@@ -22,11 +22,22 @@ import AuthTelegram from "./pages/AuthTelegram.tsx";
 import {UserIconProvider} from "./context/UserIconContext.tsx";
 import {ThemeProvider} from "./context/ThemeContext.tsx";
 import AuthYandex from "./pages/AuthYandex.tsx";
+import AuthOAuth from "./pages/AuthOAuth.tsx";
+import AuthEmail from "./pages/AuthEmail.tsx";
 import StatsPageGroup from "./pages/StatsPageGroup.tsx";
 import StatsPageGroupExercise from "./pages/StatsPageGroupExercise.tsx";
 import StatsPageSelectGroup from "./pages/StatsPageSelectGroup.tsx";
 import StatsPageMeasurement from "./pages/StatsPageMeasurement.tsx";
 import PublicLayout from "./components/PublicLayout.tsx";
+import AdminPage from "./pages/AdminPage.tsx";
+import CoachingPage from "./pages/CoachingPage.tsx";
+import FeedPage from "./pages/FeedPage.tsx";
+import ChallengesPage from "./pages/ChallengesPage.tsx";
+import ChallengePage from "./pages/ChallengePage.tsx";
+import ChallengeJoinPage from "./pages/ChallengeJoinPage.tsx";
+import AchievementsPage from "./pages/AchievementsPage.tsx";
+import WebhooksPage from "./pages/WebhooksPage.tsx";
+import AccessTokensPage from "./pages/AccessTokensPage.tsx";
 
 const App = () => {
     return (
@@ -37,6 +48,8 @@ const App = () => {
                         <Routes>
                             <Route path="/auth-telegram" element={<AuthTelegram/>}/>
                             <Route path="/auth-yandex" element={<AuthYandex/>}/>
+                            <Route path="/auth-oauth/:provider" element={<AuthOAuth/>}/>
+                            <Route path="/auth-email" element={<AuthEmail/>}/>
 
                             {/* Публичная страница профиля */}
                             <Route path="/profile" element={<MainLayout><ProfilePage/></MainLayout>}/>
@@ -165,6 +178,78 @@ const App = () => {
                                     </RequireAuth>
                                 }
                             />
+                            <Route
+                                path="/admin"
+                                element={
+                                    <RequireAuth>
+                                        <MainLayout><AdminPage/></MainLayout>
+                                    </RequireAuth>
+                                }
+                            />
+                            <Route
+                                path="/coaching"
+                                element={
+                                    <RequireAuth>
+                                        <MainLayout><CoachingPage/></MainLayout>
+                                    </RequireAuth>
+                                }
+                            />
+                            <Route
+                                path="/feed"
+                                element={
+                                    <RequireAuth>
+                                        <MainLayout><FeedPage/></MainLayout>
+                                    </RequireAuth>
+                                }
+                            />
+                            <Route
+                                path="/challenges"
+                                element={
+                                    <RequireAuth>
+                                        <MainLayout><ChallengesPage/></MainLayout>
+                                    </RequireAuth>
+                                }
+                            />
+                            <Route
+                                path="/challenges/join/:code"
+                                element={
+                                    <RequireAuth>
+                                        <MainLayout><ChallengeJoinPage/></MainLayout>
+                                    </RequireAuth>
+                                }
+                            />
+                            <Route
+                                path="/challenges/:id"
+                                element={
+                                    <RequireAuth>
+                                        <MainLayout><ChallengePage/></MainLayout>
+                                    </RequireAuth>
+                                }
+                            />
+                            <Route
+                                path="/achievements"
+                                element={
+                                    <RequireAuth>
+                                        <MainLayout><AchievementsPage/></MainLayout>
+                                    </RequireAuth>
+                                }
+                            />
+                            <Route
+                                path="/webhooks"
+                                element={
+                                    <RequireAuth>
+                                        <MainLayout><WebhooksPage/></MainLayout>
+                                    </RequireAuth>
+                                }
+                            />
+                            <Route
+                                path="/tokens"
+                                element={
+                                    <RequireAuth>
+                                        <MainLayout><AccessTokensPage/></MainLayout>
+                                    </RequireAuth>
+                                }
+                            />
                         </Routes>
                     </RestTimerProvider>
                 </UserIconProvider>
@@ -0,0 +1,20 @@
+import {api} from "./client.ts";
+
+export const getMyAccessTokens = () =>
+    api<PersonalAccessToken[]>("/api/tokens");
+
+export const getAccessTokenScopes = () =>
+    api<AccessTokenScope[]>("/api/tokens/scopes");
+
+export const createAccessToken = (body: {
+    name: string;
+    scopes: string[];
+    expires_in_days: number;
+}) =>
+    api<PersonalAccessToken>("/api/tokens", {
+        method: "POST",
+        body: JSON.stringify(body),
+    });
+
+export const revokeAccessToken = (id: number) =>
+    api(`/api/tokens/${id}`, {method: "DELETE"});
@@ -0,0 +1,45 @@
+import {api} from "./client.ts";
+
+export const METRICS: { code: ChallengeMetric; title: string; unit: string }[] = [
+    {code: "workouts", title: "Количество тренировок", unit: "трен."},
+    {code: "tonnage", title: "Тоннаж", unit: "кг"},
+    {code: "cardio_minutes", title: "Минуты кардио", unit: "мин"},
+    {code: "meters", title: "Дистанция", unit: "м"},
+    {code: "e1rm", title: "Расчётный 1ПМ в упражнении", unit: "кг"},
+];
+
+export const formatScore = (metric: ChallengeMetric, score: number) => {
+    const unit = METRICS.find(m => m.code === metric)?.unit ?? "";
+    const value = metric === "e1rm" ? score.toFixed(1) : Math.round(score).toString();
+    return `${value} ${unit}`;
+};
+
+export const getMyChallenges = () =>
+    api<ChallengeItem[]>("/api/challenges");
+
+export const createChallenge = (body: {
+    title: string;
+    metric: ChallengeMetric;
+    exercise_type_id?: number;
+    starts_at: string;
+    ends_at: string;
+}) =>
+    api<ChallengeItem>("/api/challenges", {
+        method: "POST",
+        body: JSON.stringify(body),
+    });
+
+export const getChallenge = (id: number) =>
+    api<ChallengeDetails>(`/api/challenges/${id}`);
+
+export const deleteChallenge = (id: number) =>
+    api(`/api/challenges/${id}`, {method: "DELETE"});
+
+export const leaveChallenge = (id: number) =>
+    api(`/api/challenges/${id}/participants/me`, {method: "DELETE"});
+
+export const getChallengeInvite = (code: string) =>
+    api<ChallengeInvite>(`/api/challenges/invites/${code}`);
+
+export const joinChallenge = (code: string) =>
+    api<ChallengeItem>(`/api/challenges/invites/${code}/join`, {method: "POST"});
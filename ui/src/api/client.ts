@@ -1,34 +1,113 @@
+export type FieldError = {
+    field: string;
+    code: string;
+    message: string;
+};
+
+// ApiError — ошибка API: code стабилен и годится для условий, message уже переведен для показа
+export class ApiError extends Error {
+    status: number;
+    code: string;
+    fields: FieldError[];
+    requestId?: string;
+
+    constructor(status: number, code: string, message: string, fields: FieldError[] = [], requestId?: string) {
+        super(message);
+        this.status = status;
+        this.code = code;
+        this.fields = fields;
+        this.requestId = requestId;
+    }
+}
+
+async function toApiError(res: Response): Promise<ApiError> {
+    const text = await res.text();
+    try {
+        const {error} = JSON.parse(text);
+        return new ApiError(res.status, error.code, error.message, error.fields ?? [], error.request_id);
+    } catch {
+        // ответ не от API, например от прокси
+        return new ApiError(res.status, "internal", text || res.statusText);
+    }
+}
+
+export function saveTokens(data: { token: string; refresh_token?: string }) {
+    localStorage.setItem("token", data.token);
+    if (data.refresh_token) {
+        localStorage.setItem("refresh_token", data.refresh_token);
+    }
+}
+
+export function clearTokens() {
+    localStorage.removeItem("token");
+    localStorage.removeItem("refresh_token");
+}
+
+// параллельные запросы с истекшим токеном ждут одного обновления: повторный refresh старым токеном сервер считает кражей
+let refreshing: Promise<boolean> | null = null;
+
+export function refreshTokens(): Promise<boolean> {
+    if (!refreshing) {
+        refreshing = (async () => {
+            const refreshToken = localStorage.getItem("refresh_token");
+            if (!refreshToken) {
+                return false;
+            }
+            const res = await fetch("/api/auth/refresh", {
+                method: "POST",
+                headers: {"Content-Type": "application/json"},
+                body: JSON.stringify({refresh_token: refreshToken}),
+            });
+            if (!res.ok) {
+                clearTokens();
+                return false;
+            }
+            saveTokens(await res.json());
+            return true;
+        })().finally(() => {
+            refreshing = null;
+        });
+    }
+    return refreshing;
+}
+
+async function authFetch(url: string, options: RequestInit, headers: Headers): Promise<Response> {
+    const send = () => {
+        const token = localStorage.getItem("token");
+        if (token) {
+            headers.set("Authorization", `Bearer ${token}`);
+        }
+        return fetch(url, {...options, headers});
+    };
+
+    const res = await send();
+    if (res.status === 401 && await refreshTokens()) {
+        return send();
+    }
+    return res;
+}
+
 export async function api<T>(
     url: string,
     options: RequestInit = {}
 ): Promise<T> {
-    const token = localStorage.getItem("token");
     const headers = new Headers(options.headers || {});
     headers.set("Content-Type", "application/json");
-    if (token) {
-        headers.set("Authorization", `Bearer ${token}`);
-    }
 
-    const res = await fetch(url, {...options, headers});
+    const res = await authFetch(url, options, headers);
     if (!res.ok) {
-        const err = new Error(await res.text());
-        (err as any).status = res.status;
-        throw err;
+        throw await toApiError(res);
     }
 
     return res.json().catch(() => ({} as T));
 }
 
 export async function apiBlob(url: string, options: RequestInit = {}): Promise<Blob> {
-    const token = localStorage.getItem("token");
     const headers = new Headers(options.headers || {});
-    if (token) {
-        headers.set("Authorization", `Bearer ${token}`);
-    }
 
-    const res = await fetch(url, {...options, headers});
+    const res = await authFetch(url, options, headers);
     if (!res.ok) {
-        throw new Error(await res.text());
+        throw await toApiError(res);
     }
 
     return res.blob();
@@ -0,0 +1,39 @@
+import {api} from "./client.ts";
+
+export const getMyWebhooks = () =>
+    api<Webhook[]>("/api/webhooks");
+
+export const getWebhookEventTypes = () =>
+    api<WebhookEventType[]>("/api/webhooks/event-types");
+
+export const createWebhook = (body: {
+    url: string;
+    secret?: string;
+    event_types: string[];
+    description: string;
+}) =>
+    api<Webhook>("/api/webhooks", {
+        method: "POST",
+        body: JSON.stringify(body),
+    });
+
+export const updateWebhook = (id: number, body: {
+    url?: string;
+    event_types?: string[];
+    description?: string;
+    active?: boolean;
+    rotate_secret?: boolean;
+}) =>
+    api<Webhook>(`/api/webhooks/${id}`, {
+        method: "PUT",
+        body: JSON.stringify(body),
+    });
+
+export const deleteWebhook = (id: number) =>
+    api(`/api/webhooks/${id}`, {method: "DELETE"});
+
+export const getWebhookDeliveries = (id: number, offset = 0, limit = 20) =>
+    api<WebhookDeliveries>(`/api/webhooks/${id}/deliveries?offset=${offset}&limit=${limit}`);
+
+export const sendTestWebhook = (id: number) =>
+    api<WebhookDelivery>(`/api/webhooks/${id}/test`, {method: "POST"});
@@ -34,3 +34,35 @@ export const getPublicWorkout = async (token: string): Promise<ReadWorkoutDTO> =
         method: 'GET',
     });
 };
+
+export const getShareSocial = async (token: string): Promise<ShareSocial> => {
+    return api<ShareSocial>(`/api/public/workouts/${token}/social/`);
+};
+
+export const reactToShare = async (token: string, emoji: string | null): Promise<ShareSocial> => {
+    if (!emoji) {
+        return api<ShareSocial>(`/api/public/workouts/${token}/social/reaction`, {method: 'DELETE'});
+    }
+    return api<ShareSocial>(`/api/public/workouts/${token}/social/reaction`, {
+        method: 'PUT',
+        body: JSON.stringify({emoji}),
+    });
+};
+
+export const commentShare = async (token: string, text: string): Promise<WorkoutComment> => {
+    return api<WorkoutComment>(`/api/public/workouts/${token}/social/comments`, {
+        method: 'POST',
+        body: JSON.stringify({text}),
+    });
+};
+
+export const deleteShareComment = async (token: string, commentId: number): Promise<void> => {
+    await api(`/api/public/workouts/${token}/social/comments/${commentId}`, {method: 'DELETE'});
+};
+
+export const setShareCommentsEnabled = async (workoutId: number, enabled: boolean): Promise<void> => {
+    await api(`/api/workouts/${workoutId}/share/comments`, {
+        method: 'PUT',
+        body: JSON.stringify({enabled}),
+    });
+};
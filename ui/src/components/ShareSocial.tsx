@@ -0,0 +1,113 @@
+import React, {useEffect, useState} from "react";
+import {Trash2} from "lucide-react";
+import {useAuth} from "../context/AuthContext";
+import Button from "./Button";
+import {
+    commentShare,
+    deleteShareComment,
+    getShareSocial,
+    reactToShare,
+    setShareCommentsEnabled,
+} from "../api/workouts.ts";
+
+interface Props {
+    token: string;
+    workoutId: number;
+    onError: (text: string) => void;
+}
+
+const ShareSocialBlock: React.FC<Props> = ({token, workoutId, onError}) => {
+    const {user} = useAuth();
+    const [social, setSocial] = useState<ShareSocial | null>(null);
+    const [text, setText] = useState("");
+
+    const load = () => getShareSocial(token).then(setSocial).catch(() => null);
+
+    useEffect(() => {
+        load();
+    }, [token, user?.id]);
+
+    if (!social) return null;
+
+    const react = async (emoji: string) => {
+        try {
+            setSocial(await reactToShare(token, social.my_reaction === emoji ? null : emoji));
+        } catch (e: any) {
+            onError(e?.status === 429 ? "Слишком часто, попробуйте позже" : "Не удалось поставить реакцию ❌");
+        }
+    };
+
+    const send = async () => {
+        try {
+            await commentShare(token, text);
+            setText("");
+            await load();
+        } catch (e: any) {
+            onError(e?.status === 429 ? "Слишком часто, попробуйте позже" : "Не удалось отправить комментарий ❌");
+        }
+    };
+
+    const remove = async (comment: WorkoutComment) => {
+        if (!window.confirm("Удалить комментарий?")) return;
+        await deleteShareComment(token, comment.id).catch(() => onError("Не удалось удалить ❌"));
+        await load();
+    };
+
+    const toggleComments = async () => {
+        await setShareCommentsEnabled(workoutId, !social.comments_enabled).catch(() => onError("Ошибка ❌"));
+        await load();
+    };
+
+    const countOf = (emoji: string) => social.reactions.find(r => r.emoji === emoji)?.count ?? 0;
+
+    return (
+        <div className="card stack">
+            <div style={{display: "flex", gap: 8, flexWrap: "wrap"}}>
+                {social.allowed_emoji.map(emoji => (
+                    <Button
+                        key={emoji}
+                        variant={social.my_reaction === emoji ? "active" : "ghost"}
+                        disabled={!user}
+                        onClick={() => react(emoji)}
+                    >
+                        {emoji} {countOf(emoji) || ""}
+                    </Button>
+                ))}
+            </div>
+
+            {social.comments.map(c => (
+                <div key={c.id} style={{display: "flex", gap: 8, alignItems: "flex-start"}}>
+                    <div style={{flex: 1}}>
+                        <b>{c.author_name}</b>: {c.text}
+                    </div>
+                    {user && (social.is_owner || user.id === c.author_id) && (
+                        <button className="minus" onClick={() => remove(c)}><Trash2 size={16}/></button>
+                    )}
+                </div>
+            ))}
+
+            {user && (social.comments_enabled || social.is_owner) && (
+                <div style={{display: "flex", gap: 8}}>
+                    <input
+                        placeholder="Комментарий"
+                        value={text}
+                        maxLength={2000}
+                        onChange={(e) => setText(e.target.value)}
+                        style={{flex: 1}}
+                    />
+                    <Button variant="primary" onClick={send} disabled={!text.trim()}>Отправить</Button>
+                </div>
+            )}
+            {!user && <div style={{opacity: 0.6, fontSize: 14}}>Войдите, чтобы оценить или прокомментировать</div>}
+            {!social.comments_enabled && <div style={{opacity: 0.6, fontSize: 14}}>Комментарии закрыты владельцем</div>}
+
+            {social.is_owner && (
+                <Button variant="ghost" onClick={toggleComments}>
+                    {social.comments_enabled ? "Закрыть комментарии" : "Открыть комментарии"}
+                </Button>
+            )}
+        </div>
+    );
+};
+
+export default ShareSocialBlock;
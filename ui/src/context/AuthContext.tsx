@@ -1,4 +1,5 @@
 import React, {createContext, useContext, useEffect, useRef, useState} from 'react';
+import {api, clearTokens} from '../api/client.ts';
 
 type AuthContextType = {
     user: User | null;
@@ -22,29 +23,29 @@ export const AuthProvider: React.FC<{children: React.ReactNode}> = ({children})
     };
 
     const loadMe = async () => {
-        const token = localStorage.getItem("token");
-
-        if (!token) {
+        if (!localStorage.getItem("token")) {
             setUser(null);
             return;
         }
 
-        const res = await fetch('/api/me', {
-            headers: {
-                Authorization: `Bearer ${token}`
-            }
-        });
-
-        if (res.status !== 200) {
+        try {
+            setUser(await api<User>('/api/me'));
+        } catch {
             setUser(null);
-            return;
         }
-
-        setUser(await res.json());
     };
 
     const logout = async () => {
-        localStorage.removeItem("token");
+        const token = localStorage.getItem("token");
+        await fetch('/api/logout', {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+                ...(token ? {Authorization: `Bearer ${token}`} : {}),
+            },
+            body: JSON.stringify({refresh_token: localStorage.getItem("refresh_token")}),
+        }).catch(() => undefined);
+        clearTokens();
         setUser(null);
     };
 
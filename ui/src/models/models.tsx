@@ -23,6 +23,220 @@ interface User {
     first_name: string;
     username?: string;
     photo_url?: string;
+    role?: "admin" | "coach" | "athlete";
+}
+
+interface AdminUser {
+    id: number;
+    username: string;
+    first_name: string;
+    last_name: string;
+    email: string;
+    role: "admin" | "coach" | "athlete";
+    providers: string[];
+    workout_count: number;
+    created_at: string;
+    disabled_at?: string;
+}
+
+interface WorkoutComment {
+    id: number;
+    workout_id: number;
+    author_id: number;
+    author_name: string;
+    text: string;
+    public: boolean;
+    created_at: string;
+}
+
+interface ShareSocial {
+    reactions: { emoji: string; count: number }[];
+    allowed_emoji: string[];
+    my_reaction?: string;
+    comments: WorkoutComment[];
+    comments_enabled: boolean;
+    is_owner: boolean;
+}
+
+interface CoachLinkItem {
+    id: number;
+    user_id: number;
+    name: string;
+    scopes: string[];
+    accepted_at?: string;
+}
+
+interface CoachInvite {
+    id: number;
+    code: string;
+    scopes: string[];
+    expires_at: string;
+}
+
+interface CoachingOverview {
+    athletes: CoachLinkItem[];
+    coaches: CoachLinkItem[];
+    invites: CoachInvite[];
+}
+
+interface SocialUser {
+    id: number;
+    name: string;
+    icon?: string;
+}
+
+interface FollowItem {
+    user: SocialUser;
+    status: "pending" | "accepted";
+    created_at: string;
+}
+
+interface FollowList {
+    items: FollowItem[];
+    pagination: Pagination;
+}
+
+type Visibility = "private" | "followers" | "public";
+
+interface PrivacySettings {
+    workouts_visibility: Visibility;
+    records_visibility: Visibility;
+    feed_digest: boolean;
+}
+
+interface FeedItem {
+    kind: "workout" | "record";
+    user: SocialUser;
+    workout_id: number;
+    day_type_name?: string;
+    started_at: string;
+    ended_at?: string;
+    exercise_name?: string;
+    weight?: number;
+    prev_weight?: number;
+}
+
+interface FeedResult {
+    items: FeedItem[];
+    pagination: Pagination;
+}
+
+type ChallengeMetric = "workouts" | "tonnage" | "cardio_minutes" | "meters" | "e1rm";
+
+interface ChallengeItem {
+    id: number;
+    title: string;
+    metric: ChallengeMetric;
+    exercise_type_id?: number;
+    exercise_name?: string;
+    invite_code?: string;
+    starts_at: string;
+    ends_at: string;
+    finished_at?: string;
+    is_owner: boolean;
+}
+
+interface LeaderboardEntry {
+    rank: number;
+    user: SocialUser;
+    score: number;
+    is_me: boolean;
+}
+
+interface ChallengeDetails {
+    challenge: ChallengeItem;
+    leaderboard: LeaderboardEntry[];
+}
+
+interface ChallengeInvite {
+    challenge: ChallengeItem;
+    owner_name: string;
+    participants: number;
+    joined: boolean;
+}
+
+interface AchievementItem {
+    code: string;
+    title: string;
+    description: string;
+    icon: string;
+    kind: "workouts" | "records" | "measurements" | "streak";
+    threshold: number;
+    progress: number;
+    earned: boolean;
+    earned_at?: string;
+    workout_id?: number;
+}
+
+interface Achievements {
+    items: AchievementItem[];
+    streak: { current: number; best: number };
+    earned: number;
+    total: number;
+}
+
+interface Webhook {
+    id: number;
+    url: string;
+    event_types: string[];
+    description: string;
+    active: boolean;
+    secret?: string;
+    secret_hint: string;
+    created_at: string;
+}
+
+interface WebhookEventType {
+    type: string;
+    description: string;
+}
+
+interface WebhookDelivery {
+    id: number;
+    event_type: string;
+    status: "pending" | "success" | "failed";
+    attempts: number;
+    next_attempt_at?: string;
+    response_status?: number;
+    response_body?: string;
+    error?: string;
+    duration_ms?: number;
+    delivered_at?: string;
+    created_at: string;
+    payload: unknown;
+}
+
+interface WebhookDeliveries {
+    items: WebhookDelivery[];
+    pagination: Pagination;
+}
+
+interface PersonalAccessToken {
+    id: number;
+    name: string;
+    prefix: string;
+    scopes: string[];
+    token?: string; // только в ответе на создание
+    expires_at?: string;
+    last_used_at?: string;
+    created_at: string;
+}
+
+interface AccessTokenScope {
+    scope: string;
+    description: string;
+}
+
+interface UsageStats {
+    users_total: number;
+    users_new_30d: number;
+    users_disabled: number;
+    active_users_7d: number;
+    active_users_30d: number;
+    workouts_total: number;
+    workouts_completed: number;
+    workouts_7d: number;
+    users_by_role: Record<string, number>;
 }
 
 interface ExerciseType {
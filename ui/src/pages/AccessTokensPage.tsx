@@ -0,0 +1,114 @@
+import React, {useEffect, useState} from "react";
+import Button from "../components/Button";
+import Toast from "../components/Toast";
+import {createAccessToken, getAccessTokenScopes, getMyAccessTokens, revokeAccessToken} from "../api/accessTokens.ts";
+
+const EXPIRATION_OPTIONS = [
+    {days: 30, title: "30 дней"},
+    {days: 90, title: "90 дней"},
+    {days: 365, title: "1 год"},
+    {days: 0, title: "Бессрочно"},
+];
+
+const AccessTokensPage: React.FC = () => {
+    const [tokens, setTokens] = useState<PersonalAccessToken[]>([]);
+    const [scopes, setScopes] = useState<AccessTokenScope[]>([]);
+    const [toast, setToast] = useState<string | null>(null);
+    // токен показывается один раз — сразу после создания
+    const [created, setCreated] = useState<string | null>(null);
+
+    // --- Форма создания ---
+    const [name, setName] = useState("");
+    const [selected, setSelected] = useState<string[]>([]);
+    const [expiresInDays, setExpiresInDays] = useState(90);
+
+    const load = () => getMyAccessTokens()
+        .then(setTokens)
+        .catch(() => setToast("Не удалось загрузить ❌"));
+
+    useEffect(() => {
+        load();
+        getAccessTokenScopes().then(setScopes).catch(() => setScopes([]));
+    }, []);
+
+    const scopeTitle = (scope: string) =>
+        scopes.find(s => s.scope === scope)?.description ?? scope;
+
+    const toggle = (scope: string) =>
+        setSelected(prev => prev.includes(scope) ? prev.filter(s => s !== scope) : [...prev, scope]);
+
+    const create = async () => {
+        try {
+            const token = await createAccessToken({name, scopes: selected, expires_in_days: expiresInDays});
+            setCreated(token.token ?? null);
+            setName("");
+            setSelected([]);
+            load();
+        } catch {
+            setToast("Проверьте название и права ❌");
+        }
+    };
+
+    const revoke = async (t: PersonalAccessToken) => {
+        if (!confirm(`Отозвать токен «${t.name}»? Скрипты с ним перестанут работать.`)) return;
+        await revokeAccessToken(t.id).catch(() => setToast("Не удалось отозвать ❌"));
+        load();
+    };
+
+    return (
+        <div className="page stack">
+            <h2>API-токены</h2>
+
+            {created && (
+                <div className="card stack">
+                    <div style={{fontWeight: 600}}>Новый токен</div>
+                    <code style={{wordBreak: "break-all"}}>{created}</code>
+                    <div style={{fontSize: 12, opacity: 0.7}}>
+                        Сохраните его: больше он показан не будет. Передавайте в заголовке
+                        Authorization: Bearer &lt;токен&gt; при запросах к /api/v1.
+                    </div>
+                    <Button variant="ghost" onClick={() => setCreated(null)}>Скрыть</Button>
+                </div>
+            )}
+
+            {tokens.length === 0 && <div className="card">Токенов пока нет</div>}
+
+            {tokens.map(t => (
+                <div key={t.id} className="card stack">
+                    <div style={{fontWeight: 600}}>🔑 {t.name}</div>
+                    <div style={{fontSize: 12, opacity: 0.7}}>
+                        <code>{t.prefix}…</code> · {t.scopes.map(scopeTitle).join(" · ")}
+                    </div>
+                    <div style={{fontSize: 12, opacity: 0.7}}>
+                        {t.expires_at ? `действует до ${new Date(t.expires_at).toLocaleDateString()}` : "бессрочный"}
+                        {" · "}
+                        {t.last_used_at ? `использован ${new Date(t.last_used_at).toLocaleString()}` : "еще не использован"}
+                    </div>
+                    <Button variant="danger" onClick={() => revoke(t)}>Отозвать</Button>
+                </div>
+            ))}
+
+            <div className="card stack">
+                <div style={{fontWeight: 600}}>Новый токен</div>
+                <input placeholder="Название, например «Экспорт в таблицу»" value={name}
+                       onChange={(e) => setName(e.target.value)}/>
+                {scopes.map(s => (
+                    <label key={s.scope} style={{display: "flex", gap: 8, alignItems: "center"}}>
+                        <input type="checkbox" checked={selected.includes(s.scope)} onChange={() => toggle(s.scope)}/>
+                        {s.description}
+                    </label>
+                ))}
+                <select value={expiresInDays} onChange={(e) => setExpiresInDays(Number(e.target.value))}>
+                    {EXPIRATION_OPTIONS.map(o => <option key={o.days} value={o.days}>{o.title}</option>)}
+                </select>
+                <Button variant="active" onClick={create} disabled={!name.trim() || selected.length === 0}>
+                    Выпустить
+                </Button>
+            </div>
+
+            {toast && <Toast message={toast} onClose={() => setToast(null)}/>}
+        </div>
+    );
+};
+
+export default AccessTokensPage;
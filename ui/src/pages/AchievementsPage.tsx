@@ -0,0 +1,72 @@
+import React, {useEffect, useState} from "react";
+import {api} from "../api/client.ts";
+import Toast from "../components/Toast";
+
+const KINDS: { code: AchievementItem["kind"]; title: string }[] = [
+    {code: "workouts", title: "Тренировки"},
+    {code: "streak", title: "Серии недель"},
+    {code: "records", title: "Рекорды"},
+    {code: "measurements", title: "Замеры"},
+];
+
+const AchievementsPage: React.FC = () => {
+    const [data, setData] = useState<Achievements | null>(null);
+    const [toast, setToast] = useState<string | null>(null);
+
+    useEffect(() => {
+        api<Achievements>("/api/achievements")
+            .then(setData)
+            .catch(() => setToast("Не удалось загрузить ❌"));
+    }, []);
+
+    return (
+        <div className="page stack">
+            <h2>Достижения</h2>
+
+            {data && (
+                <>
+                    <div className="card" style={{display: "flex", justifyContent: "space-around", textAlign: "center"}}>
+                        <div>
+                            <div style={{fontSize: 24, fontWeight: 600}}>🔥 {data.streak.current}</div>
+                            <div style={{fontSize: 12, opacity: 0.6}}>недель подряд</div>
+                        </div>
+                        <div>
+                            <div style={{fontSize: 24, fontWeight: 600}}>{data.streak.best}</div>
+                            <div style={{fontSize: 12, opacity: 0.6}}>лучшая серия</div>
+                        </div>
+                        <div>
+                            <div style={{fontSize: 24, fontWeight: 600}}>{data.earned}/{data.total}</div>
+                            <div style={{fontSize: 12, opacity: 0.6}}>значков</div>
+                        </div>
+                    </div>
+
+                    {KINDS.map(kind => (
+                        <div key={kind.code} className="card stack">
+                            <div style={{fontWeight: 600}}>{kind.title}</div>
+                            {data.items.filter(a => a.kind === kind.code).map(a => (
+                                <div
+                                    key={a.code}
+                                    style={{display: "flex", gap: 12, alignItems: "center", opacity: a.earned ? 1 : 0.5}}
+                                >
+                                    <span style={{fontSize: 28, filter: a.earned ? "none" : "grayscale(1)"}}>{a.icon}</span>
+                                    <div style={{flex: 1}}>
+                                        <div style={{fontWeight: 600}}>{a.title}</div>
+                                        <div style={{fontSize: 12, opacity: 0.7}}>
+                                            {a.earned && a.earned_at
+                                                ? `получено ${new Date(a.earned_at).toLocaleDateString()}`
+                                                : `${a.description} · ${a.progress}/${a.threshold}`}
+                                        </div>
+                                    </div>
+                                </div>
+                            ))}
+                        </div>
+                    ))}
+                </>
+            )}
+
+            {toast && <Toast message={toast} onClose={() => setToast(null)}/>}
+        </div>
+    );
+};
+
+export default AchievementsPage;
@@ -0,0 +1,92 @@
+import React, {useEffect, useState} from "react";
+import {api} from "../api/client.ts";
+import Button from "../components/Button";
+import Toast from "../components/Toast";
+
+const ROLES: AdminUser["role"][] = ["athlete", "coach", "admin"];
+
+const AdminPage: React.FC = () => {
+    const [stats, setStats] = useState<UsageStats | null>(null);
+    const [users, setUsers] = useState<AdminUser[]>([]);
+    const [query, setQuery] = useState("");
+    const [toast, setToast] = useState<string | null>(null);
+
+    const loadUsers = (q: string) =>
+        api<{ items: AdminUser[] }>(`/api/admin/users?q=${encodeURIComponent(q)}&limit=50`)
+            .then(res => setUsers(res.items))
+            .catch(() => setToast("Нет доступа ❌"));
+
+    useEffect(() => {
+        api<UsageStats>("/api/admin/stats").then(setStats).catch(() => null);
+        loadUsers("");
+    }, []);
+
+    const toggleDisabled = async (u: AdminUser) => {
+        const action = u.disabled_at ? "enable" : "disable";
+        if (action === "disable" && !window.confirm(`Заблокировать ${u.first_name || u.username}?`)) return;
+        try {
+            await api(`/api/admin/users/${u.id}/${action}`, {method: "POST"});
+            await loadUsers(query);
+        } catch {
+            setToast("Не удалось изменить статус ❌");
+        }
+    };
+
+    const changeRole = async (u: AdminUser, role: string) => {
+        try {
+            await api(`/api/admin/users/${u.id}/role`, {method: "PUT", body: JSON.stringify({role})});
+            await loadUsers(query);
+        } catch {
+            setToast("Не удалось изменить роль ❌");
+        }
+    };
+
+    return (
+        <div className="page stack">
+            <h2>Админка</h2>
+
+            {stats && (
+                <div className="card">
+                    <div>Пользователей: {stats.users_total} (новых за 30 дней: {stats.users_new_30d})</div>
+                    <div>Активных за 7 / 30 дней: {stats.active_users_7d} / {stats.active_users_30d}</div>
+                    <div>Тренировок: {stats.workouts_total} (завершено: {stats.workouts_completed}, за 7 дней: {stats.workouts_7d})</div>
+                    <div>Заблокировано: {stats.users_disabled}</div>
+                </div>
+            )}
+
+            <div style={{display: "flex", gap: 8}}>
+                <input
+                    placeholder="Имя, username, email или id"
+                    value={query}
+                    onChange={(e) => setQuery(e.target.value)}
+                    onKeyDown={(e) => e.key === "Enter" && loadUsers(query)}
+                    style={{flex: 1}}
+                />
+                <Button variant="primary" onClick={() => loadUsers(query)}>Найти</Button>
+            </div>
+
+            {users.map(u => (
+                <div key={u.id} className="card" style={{opacity: u.disabled_at ? 0.6 : 1}}>
+                    <div style={{fontWeight: 600}}>
+                        #{u.id} {u.first_name} {u.last_name} {u.username && `(@${u.username})`}
+                    </div>
+                    <div style={{fontSize: 14, opacity: 0.7}}>
+                        {u.workout_count} тренировок · {u.providers.join(", ") || "email / oidc"}
+                    </div>
+                    <div style={{display: "flex", gap: 8, marginTop: 8}}>
+                        <select value={u.role} onChange={(e) => changeRole(u, e.target.value)}>
+                            {ROLES.map(r => <option key={r} value={r}>{r}</option>)}
+                        </select>
+                        <Button variant={u.disabled_at ? "active" : "danger"} onClick={() => toggleDisabled(u)}>
+                            {u.disabled_at ? "Разблокировать" : "Заблокировать"}
+                        </Button>
+                    </div>
+                </div>
+            ))}
+
+            {toast && <Toast message={toast} onClose={() => setToast(null)}/>}
+        </div>
+    );
+};
+
+export default AdminPage;
@@ -0,0 +1,104 @@
+import {useEffect, useState} from "react";
+import {useNavigate} from "react-router-dom";
+import {useAuth} from "../context/AuthContext.tsx";
+import {api, saveTokens} from "../api/client.ts";
+import Button from "../components/Button";
+
+type Tokens = { token: string; refresh_token?: string };
+
+// Страница перехода по ссылке из письма: подтверждение email, вход по magic link и сброс пароля
+const AuthEmail = () => {
+    const navigate = useNavigate();
+    const {refreshUser} = useAuth();
+    const params = new URLSearchParams(window.location.search);
+    const action = params.get("action");
+    const token = params.get("token");
+
+    const [password, setPassword] = useState("");
+    const [error, setError] = useState<string | null>(null);
+
+    const finish = (data: Tokens) => {
+        saveTokens(data);
+        refreshUser();
+        navigate("/");
+    };
+
+    useEffect(() => {
+        if (!token || !action) {
+            navigate("/profile");
+            return;
+        }
+
+        // подтверждение и сброс пароля ждут ввода пароля
+        if (action !== "magic") return;
+
+        api<Tokens>("/api/email/magic-link/consume", {method: "POST", body: JSON.stringify({token})})
+            .then(finish)
+            .catch(() => setError("Ссылка недействительна или устарела"));
+    }, []);
+
+    // пароль из регистрации сохранится, только если его ввести: иначе его мог задать кто-то другой
+    const verify = () => {
+        api<Tokens>("/api/email/verify", {
+            method: "POST",
+            body: JSON.stringify({token, password}),
+        })
+            .then(finish)
+            .catch(() => setError("Ссылка недействительна или устарела"));
+    };
+
+    const resetPassword = () => {
+        api<Tokens>("/api/email/password/reset", {
+            method: "POST",
+            body: JSON.stringify({token, password}),
+        })
+            .then(finish)
+            .catch(() => setError("Не удалось сменить пароль"));
+    };
+
+    if (error) {
+        return (
+            <div className="page stack">
+                <div className="card">{error}</div>
+                <Button variant="primary" onClick={() => navigate("/profile")}>На страницу входа</Button>
+            </div>
+        );
+    }
+
+    if (action === "verify") {
+        return (
+            <div className="page stack">
+                <h2>Подтверждение email</h2>
+                <div>Введите пароль, указанный при регистрации. Без него email тоже подтвердится, но пароль придется задать заново.</div>
+                <input
+                    type="password"
+                    placeholder="Пароль"
+                    value={password}
+                    onChange={(e) => setPassword(e.target.value)}
+                />
+                <Button variant="primary" onClick={verify}>
+                    Подтвердить
+                </Button>
+            </div>
+        );
+    }
+
+    if (action !== "reset") return null;
+
+    return (
+        <div className="page stack">
+            <h2>Новый пароль</h2>
+            <input
+                type="password"
+                placeholder="Не меньше 8 символов"
+                value={password}
+                onChange={(e) => setPassword(e.target.value)}
+            />
+            <Button variant="primary" onClick={resetPassword} disabled={password.length < 8}>
+                Сохранить
+            </Button>
+        </div>
+    );
+};
+
+export default AuthEmail;
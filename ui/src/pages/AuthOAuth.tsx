@@ -0,0 +1,40 @@
+import {useEffect} from "react";
+import {useNavigate, useParams} from "react-router-dom";
+import {useAuth} from "../context/AuthContext.tsx";
+import {api, saveTokens} from "../api/client.ts";
+
+// Страница возврата от OIDC провайдера: state и PKCE проверяет сервер, сюда приходят только code и state
+const AuthOAuth = () => {
+    const navigate = useNavigate();
+    const {provider} = useParams<{ provider: string }>();
+    const {refreshUser} = useAuth();
+
+    useEffect(() => {
+        const params = new URLSearchParams(window.location.search);
+        const code = params.get("code");
+        const state = params.get("state");
+
+        if (!code || !state || !provider) {
+            navigate("/profile");
+            return;
+        }
+
+        api<{ token?: string; refresh_token?: string }>(`/api/oauth/${provider}/login`, {
+            method: "POST",
+            body: JSON.stringify({code, state}),
+        })
+            .then(data => {
+                // при привязке к уже вошедшему пользователю токены не выдаются
+                if (data.token) {
+                    saveTokens({token: data.token, refresh_token: data.refresh_token});
+                }
+                refreshUser();
+                navigate(data.token ? "/" : "/profile");
+            })
+            .catch(() => navigate("/profile"));
+    }, []);
+
+    return null;
+};
+
+export default AuthOAuth;
@@ -1,6 +1,7 @@
 import {useEffect} from "react";
 import {useNavigate} from "react-router-dom";
 import {useAuth} from "../context/AuthContext.tsx";
+import {saveTokens} from "../api/client.ts";
 
 const AuthTelegram = () => {
     const navigate = useNavigate();
@@ -24,7 +25,7 @@ const AuthTelegram = () => {
         })
             .then(res => res.json())
             .then(data => {
-                localStorage.setItem("token", data.token);
+                saveTokens(data);
                 refreshUser();
                 navigate("/");
             });
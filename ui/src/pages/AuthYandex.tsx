@@ -1,5 +1,6 @@
 import {useEffect} from "react";
 import {useAuth} from "../context/AuthContext.tsx";
+import {saveTokens} from "../api/client.ts";
 import {useNavigate} from "react-router-dom";
 
 const AuthYandex = () => {
@@ -29,7 +30,7 @@ const AuthYandex = () => {
         })
             .then(res => res.json())
             .then(data => {
-                localStorage.setItem("token", data.token);
+                saveTokens(data);
                 refreshUser();
                 navigate("/");
             });
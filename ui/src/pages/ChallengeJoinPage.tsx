@@ -0,0 +1,57 @@
+import React, {useEffect, useState} from "react";
+import {useNavigate, useParams} from "react-router-dom";
+import Button from "../components/Button";
+import Toast from "../components/Toast";
+import {getChallengeInvite, joinChallenge, METRICS} from "../api/challenges.ts";
+
+const ChallengeJoinPage: React.FC = () => {
+    const {code} = useParams();
+    const navigate = useNavigate();
+    const [invite, setInvite] = useState<ChallengeInvite | null>(null);
+    const [toast, setToast] = useState<string | null>(null);
+
+    useEffect(() => {
+        getChallengeInvite(code ?? "")
+            .then(setInvite)
+            .catch(() => setToast("Приглашение не найдено ❌"));
+    }, [code]);
+
+    const join = async () => {
+        try {
+            const challenge = await joinChallenge(code ?? "");
+            navigate(`/challenges/${challenge.id}`);
+        } catch {
+            setToast("Не удалось вступить в челлендж ❌");
+        }
+    };
+
+    return (
+        <div className="page stack">
+            {invite && (
+                <div className="card stack" style={{textAlign: "center"}}>
+                    <div style={{fontSize: 18, fontWeight: 600}}>{invite.challenge.title}</div>
+                    <div>
+                        {METRICS.find(m => m.code === invite.challenge.metric)?.title}
+                        {invite.challenge.exercise_name && ` · ${invite.challenge.exercise_name}`}
+                    </div>
+                    <div style={{fontSize: 14, opacity: 0.7}}>
+                        {new Date(invite.challenge.starts_at).toLocaleDateString()} — {new Date(invite.challenge.ends_at).toLocaleDateString()}
+                    </div>
+                    <div style={{fontSize: 14, opacity: 0.7}}>
+                        Организатор: {invite.owner_name} · участников: {invite.participants}
+                    </div>
+
+                    {invite.joined
+                        ? <Button variant="primary" onClick={() => navigate(`/challenges/${invite.challenge.id}`)}>
+                            Открыть таблицу лидеров
+                        </Button>
+                        : <Button variant="active" onClick={join}>Вступить</Button>}
+                </div>
+            )}
+
+            {toast && <Toast message={toast} onClose={() => setToast(null)}/>}
+        </div>
+    );
+};
+
+export default ChallengeJoinPage;
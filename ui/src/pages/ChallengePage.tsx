@@ -0,0 +1,100 @@
+import React, {useEffect, useState} from "react";
+import {useNavigate, useParams} from "react-router-dom";
+import Button from "../components/Button";
+import Toast from "../components/Toast";
+import {deleteChallenge, formatScore, getChallenge, leaveChallenge, METRICS} from "../api/challenges.ts";
+
+const MEDALS = ["🥇", "🥈", "🥉"];
+
+const ChallengePage: React.FC = () => {
+    const {id} = useParams();
+    const navigate = useNavigate();
+    const [details, setDetails] = useState<ChallengeDetails | null>(null);
+    const [toast, setToast] = useState<string | null>(null);
+
+    const load = () => getChallenge(Number(id))
+        .then(setDetails)
+        .catch(() => setToast("Челлендж не найден ❌"));
+
+    useEffect(() => {
+        load();
+        // таблица лидеров пересчитывается в фоне через несколько секунд после изменения подходов
+        const interval = setInterval(load, 30000);
+        return () => clearInterval(interval);
+    }, [id]);
+
+    if (!details) {
+        return <div className="page">{toast && <Toast message={toast} onClose={() => setToast(null)}/>}</div>;
+    }
+
+    const {challenge, leaderboard} = details;
+    const inviteLink = challenge.invite_code ? `${window.location.origin}/challenges/join/${challenge.invite_code}` : "";
+    const finished = !!challenge.finished_at;
+
+    const copyInvite = async () => {
+        await navigator.clipboard.writeText(inviteLink).catch(() => null);
+        setToast("Ссылка скопирована 📋");
+    };
+
+    const leave = async () => {
+        if (!window.confirm("Выйти из челленджа?")) return;
+        await leaveChallenge(challenge.id).catch(() => null);
+        navigate("/challenges");
+    };
+
+    const remove = async () => {
+        if (!window.confirm("Удалить челлендж для всех участников?")) return;
+        await deleteChallenge(challenge.id).catch(() => null);
+        navigate("/challenges");
+    };
+
+    return (
+        <div className="page stack">
+            <h2>{challenge.title}</h2>
+
+            <div className="card stack">
+                <div>
+                    {METRICS.find(m => m.code === challenge.metric)?.title}
+                    {challenge.exercise_name && ` · ${challenge.exercise_name}`}
+                </div>
+                <div style={{fontSize: 14, opacity: 0.7}}>
+                    {new Date(challenge.starts_at).toLocaleString()} — {new Date(challenge.ends_at).toLocaleString()}
+                </div>
+                {finished && <div>🏁 Челлендж завершён</div>}
+            </div>
+
+            <div className="card stack">
+                <div style={{fontWeight: 600}}>Таблица лидеров</div>
+                {leaderboard.map(e => (
+                    <div
+                        key={e.user.id}
+                        style={{display: "flex", gap: 8, fontWeight: e.is_me ? 600 : 400}}
+                    >
+                        <span style={{width: 32}}>{MEDALS[e.rank - 1] ?? `${e.rank}.`}</span>
+                        <span style={{flex: 1}}>{e.user.name}</span>
+                        <span>{formatScore(challenge.metric, e.score)}</span>
+                    </div>
+                ))}
+            </div>
+
+            {inviteLink && !finished && (
+                <div className="card stack">
+                    <div style={{fontWeight: 600}}>Пригласить друзей</div>
+                    <div style={{fontSize: 14, wordBreak: "break-all"}}>{inviteLink}</div>
+                    <div style={{fontSize: 14, opacity: 0.7}}>
+                        Или в боте: <code>/join_challenge {challenge.invite_code}</code>
+                    </div>
+                    <Button variant="primary" onClick={copyInvite}>Скопировать ссылку</Button>
+                </div>
+            )}
+
+            {challenge.is_owner
+                ? <Button variant="danger" onClick={remove}>Удалить челлендж</Button>
+                : !finished && <Button variant="ghost" onClick={leave}>Выйти из челленджа</Button>}
+
+            {toast && <Toast message={toast} onClose={() => setToast(null)}/>}
+        </div>
+    );
+};
+
+export default ChallengePage;
@@ -0,0 +1,131 @@
+import React, {useEffect, useState} from "react";
+import {useNavigate} from "react-router-dom";
+import Button from "../components/Button";
+import Toast from "../components/Toast";
+import {createChallenge, getMyChallenges, METRICS} from "../api/challenges.ts";
+import {getExerciseGroups, getExerciseTypesByGroup} from "../api/exercises.ts";
+
+const toDateInput = (d: Date) => d.toISOString().slice(0, 10);
+
+const ChallengesPage: React.FC = () => {
+    const navigate = useNavigate();
+    const [challenges, setChallenges] = useState<ChallengeItem[]>([]);
+    const [toast, setToast] = useState<string | null>(null);
+
+    // --- Форма создания ---
+    const [title, setTitle] = useState("");
+    const [metric, setMetric] = useState<ChallengeMetric>("workouts");
+    const [startsAt, setStartsAt] = useState(toDateInput(new Date()));
+    const [endsAt, setEndsAt] = useState(toDateInput(new Date(Date.now() + 30 * 24 * 3600 * 1000)));
+    const [groups, setGroups] = useState<Group[]>([]);
+    const [types, setTypes] = useState<ExerciseType[]>([]);
+    const [exerciseTypeID, setExerciseTypeID] = useState("");
+
+    // --- Вступление по коду ---
+    const [code, setCode] = useState("");
+
+    const load = () => getMyChallenges()
+        .then(setChallenges)
+        .catch(() => setToast("Не удалось загрузить ❌"));
+
+    useEffect(() => {
+        load();
+        getExerciseGroups().then(setGroups).catch(() => setGroups([]));
+    }, []);
+
+    const create = async () => {
+        try {
+            const created = await createChallenge({
+                title,
+                metric,
+                exercise_type_id: metric === "e1rm" ? Number(exerciseTypeID) : undefined,
+                // границы периода — полночь по местному времени, конец включительно
+                starts_at: new Date(`${startsAt}T00:00:00`).toISOString(),
+                ends_at: new Date(new Date(`${endsAt}T00:00:00`).getTime() + 24 * 3600 * 1000).toISOString(),
+            });
+            navigate(`/challenges/${created.id}`);
+        } catch {
+            setToast("Проверьте название, метрику и даты ❌");
+        }
+    };
+
+    const isActive = (c: ChallengeItem) => !c.finished_at && new Date(c.ends_at) > new Date();
+
+    return (
+        <div className="page stack">
+            <h2>Челленджи</h2>
+
+            {challenges.length === 0 && <div className="card">Вы пока не участвуете в челленджах</div>}
+
+            {challenges.map(c => (
+                <div key={c.id} className="card" style={{cursor: "pointer"}} onClick={() => navigate(`/challenges/${c.id}`)}>
+                    <div style={{fontWeight: 600}}>{isActive(c) ? "🔥" : "🏁"} {c.title}</div>
+                    <div style={{fontSize: 14, opacity: 0.7}}>
+                        {METRICS.find(m => m.code === c.metric)?.title}
+                        {c.exercise_name && ` · ${c.exercise_name}`}
+                    </div>
+                    <div style={{fontSize: 12, opacity: 0.6}}>
+                        {new Date(c.starts_at).toLocaleDateString()} — {new Date(c.ends_at).toLocaleDateString()}
+                    </div>
+                </div>
+            ))}
+
+            <div className="card stack">
+                <div style={{fontWeight: 600}}>Вступить по коду</div>
+                <div style={{display: "flex", gap: 8}}>
+                    <input
+                        placeholder="Код приглашения"
+                        value={code}
+                        onChange={(e) => setCode(e.target.value.trim())}
+                        style={{flex: 1}}
+                    />
+                    <Button variant="primary" onClick={() => navigate(`/challenges/join/${code}`)} disabled={!code}>
+                        Открыть
+                    </Button>
+                </div>
+            </div>
+
+            <div className="card stack">
+                <div style={{fontWeight: 600}}>Новый челлендж</div>
+                <input placeholder="Название" value={title} onChange={(e) => setTitle(e.target.value)}/>
+                <select value={metric} onChange={(e) => setMetric(e.target.value as ChallengeMetric)}>
+                    {METRICS.map(m => <option key={m.code} value={m.code}>{m.title}</option>)}
+                </select>
+
+                {metric === "e1rm" && (
+                    <>
+                        <select onChange={(e) => getExerciseTypesByGroup(e.target.value).then(setTypes)}>
+                            <option>Группа</option>
+                            {groups.map(g => <option key={g.code} value={g.code}>{g.name}</option>)}
+                        </select>
+                        <select value={exerciseTypeID} onChange={(e) => setExerciseTypeID(e.target.value)}>
+                            <option value="">Упражнение</option>
+                            {types.map(t => <option key={t.id} value={t.id}>{t.name}</option>)}
+                        </select>
+                    </>
+                )}
+
+                <label>
+                    Начало
+                    <input type="date" value={startsAt} onChange={(e) => setStartsAt(e.target.value)}/>
+                </label>
+                <label>
+                    Окончание
+                    <input type="date" value={endsAt} onChange={(e) => setEndsAt(e.target.value)}/>
+                </label>
+
+                <Button
+                    variant="active"
+                    onClick={create}
+                    disabled={!title || (metric === "e1rm" && !exerciseTypeID)}
+                >
+                    Создать
+                </Button>
+            </div>
+
+            {toast && <Toast message={toast} onClose={() => setToast(null)}/>}
+        </div>
+    );
+};
+
+export default ChallengesPage;
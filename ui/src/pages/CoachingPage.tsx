@@ -0,0 +1,137 @@
+import React, {useEffect, useState} from "react";
+import {api} from "../api/client.ts";
+import {useAuth} from "../context/AuthContext";
+import Button from "../components/Button";
+import Toast from "../components/Toast";
+
+const SCOPES: { code: string; title: string }[] = [
+    {code: "workouts", title: "Тренировки"},
+    {code: "stats", title: "Статистика"},
+    {code: "measurements", title: "Замеры"},
+    {code: "programs", title: "Программы"},
+    {code: "comments", title: "Комментарии"},
+];
+
+const CoachingPage: React.FC = () => {
+    const {user} = useAuth();
+    const [overview, setOverview] = useState<CoachingOverview | null>(null);
+    const [code, setCode] = useState("");
+    const [toast, setToast] = useState<string | null>(null);
+
+    const load = () =>
+        api<CoachingOverview>("/api/coaching/")
+            .then(setOverview)
+            .catch(() => setToast("Не удалось загрузить ❌"));
+
+    useEffect(() => {
+        load();
+    }, []);
+
+    const createInvite = async () => {
+        try {
+            await api("/api/coaching/invites", {method: "POST", body: JSON.stringify({})});
+            await load();
+        } catch {
+            setToast("Не удалось создать приглашение ❌");
+        }
+    };
+
+    const acceptInvite = async () => {
+        try {
+            await api("/api/coaching/invites/accept", {method: "POST", body: JSON.stringify({code})});
+            setCode("");
+            setToast("Тренер подключён ✅");
+            await load();
+        } catch {
+            setToast("Код недействителен ❌");
+        }
+    };
+
+    const revoke = async (link: CoachLinkItem) => {
+        if (!window.confirm(`Отключить ${link.name}?`)) return;
+        await api(`/api/coaching/links/${link.id}`, {method: "DELETE"}).catch(() => null);
+        await load();
+    };
+
+    const toggleScope = async (link: CoachLinkItem, scope: string) => {
+        const scopes = link.scopes.includes(scope)
+            ? link.scopes.filter(s => s !== scope)
+            : [...link.scopes, scope];
+        if (scopes.length === 0) {
+            setToast("Оставьте хотя бы одну область или отключите тренера");
+            return;
+        }
+        try {
+            await api(`/api/coaching/links/${link.id}/scopes`, {method: "PUT", body: JSON.stringify({scopes})});
+            await load();
+        } catch {
+            setToast("Не удалось сохранить ❌");
+        }
+    };
+
+    const isCoach = user?.role === "coach" || user?.role === "admin";
+
+    return (
+        <div className="page stack">
+            <h2>Тренер и спортсмены</h2>
+
+            <div className="card stack">
+                <div style={{fontWeight: 600}}>Подключить тренера</div>
+                <div style={{display: "flex", gap: 8}}>
+                    <input
+                        placeholder="Код приглашения"
+                        value={code}
+                        onChange={(e) => setCode(e.target.value)}
+                        style={{flex: 1}}
+                    />
+                    <Button variant="primary" onClick={acceptInvite} disabled={!code}>Принять</Button>
+                </div>
+            </div>
+
+            {overview?.coaches.map(c => (
+                <div key={c.id} className="card">
+                    <div style={{fontWeight: 600}}>Тренер: {c.name}</div>
+                    <div style={{display: "flex", flexWrap: "wrap", gap: 8, marginTop: 8}}>
+                        {SCOPES.map(s => (
+                            <label key={s.code} style={{display: "flex", gap: 4}}>
+                                <input
+                                    type="checkbox"
+                                    checked={c.scopes.includes(s.code)}
+                                    onChange={() => toggleScope(c, s.code)}
+                                />
+                                {s.title}
+                            </label>
+                        ))}
+                    </div>
+                    <Button variant="danger" onClick={() => revoke(c)} style={{marginTop: 8}}>Отключить</Button>
+                </div>
+            ))}
+
+            {isCoach && (
+                <>
+                    <Button variant="active" onClick={createInvite}>Пригласить спортсмена</Button>
+
+                    {overview?.invites.map(i => (
+                        <div key={i.id} className="card">
+                            Код <b>{i.code}</b> действует до {new Date(i.expires_at).toLocaleDateString()}
+                        </div>
+                    ))}
+
+                    {overview?.athletes.map(a => (
+                        <div key={a.id} className="card">
+                            <div style={{fontWeight: 600}}>#{a.user_id} {a.name}</div>
+                            <div style={{fontSize: 14, opacity: 0.7}}>
+                                {a.scopes.map(s => SCOPES.find(x => x.code === s)?.title ?? s).join(", ")}
+                            </div>
+                            <Button variant="danger" onClick={() => revoke(a)} style={{marginTop: 8}}>Отключить</Button>
+                        </div>
+                    ))}
+                </>
+            )}
+
+            {toast && <Toast message={toast} onClose={() => setToast(null)}/>}
+        </div>
+    );
+};
+
+export default CoachingPage;
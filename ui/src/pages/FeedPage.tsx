@@ -0,0 +1,192 @@
+import React, {useEffect, useState} from "react";
+import {api} from "../api/client.ts";
+import {useAuth} from "../context/AuthContext";
+import Button from "../components/Button";
+import Toast from "../components/Toast";
+
+const PAGE_SIZE = 20;
+
+const VISIBILITY: { code: Visibility; title: string }[] = [
+    {code: "private", title: "Только я"},
+    {code: "followers", title: "Подписчики"},
+    {code: "public", title: "Все"},
+];
+
+const FeedPage: React.FC = () => {
+    const {user} = useAuth();
+    const [feed, setFeed] = useState<FeedItem[]>([]);
+    const [total, setTotal] = useState(0);
+    const [requests, setRequests] = useState<FollowItem[]>([]);
+    const [following, setFollowing] = useState<FollowItem[]>([]);
+    const [privacy, setPrivacy] = useState<PrivacySettings | null>(null);
+    const [followID, setFollowID] = useState("");
+    const [toast, setToast] = useState<string | null>(null);
+
+    const loadFeed = async (offset: number) => {
+        const res = await api<FeedResult>(`/api/social/feed?offset=${offset}&limit=${PAGE_SIZE}`);
+        setFeed(prev => offset === 0 ? res.items : [...prev, ...res.items]);
+        setTotal(res.pagination.total);
+    };
+
+    const loadFollows = async () => {
+        const [pending, following] = await Promise.all([
+            api<FollowList>("/api/social/followers?pending=true"),
+            api<FollowList>("/api/social/following"),
+        ]);
+        setRequests(pending.items);
+        setFollowing(following.items);
+    };
+
+    useEffect(() => {
+        Promise.all([
+            loadFeed(0),
+            loadFollows(),
+            api<PrivacySettings>("/api/social/privacy").then(setPrivacy),
+        ]).catch(() => setToast("Не удалось загрузить ❌"));
+    }, []);
+
+    const follow = async () => {
+        try {
+            const res = await api<{ status: string }>(`/api/social/follow/${Number(followID)}`, {method: "POST"});
+            setFollowID("");
+            setToast(res.status === "accepted" ? "Вы подписались ✅" : "Заявка отправлена 📨");
+            await loadFollows();
+            await loadFeed(0);
+        } catch {
+            setToast("Пользователь не найден ❌");
+        }
+    };
+
+    const unfollow = async (item: FollowItem) => {
+        if (!window.confirm(`Отписаться от ${item.user.name}?`)) return;
+        await api(`/api/social/follow/${item.user.id}`, {method: "DELETE"}).catch(() => null);
+        await loadFollows();
+        await loadFeed(0);
+    };
+
+    const answer = async (item: FollowItem, accept: boolean) => {
+        const url = accept
+            ? `/api/social/followers/${item.user.id}/accept`
+            : `/api/social/followers/${item.user.id}`;
+        await api(url, {method: accept ? "POST" : "DELETE"}).catch(() => null);
+        await loadFollows();
+    };
+
+    const savePrivacy = async (changed: Partial<PrivacySettings>) => {
+        if (!privacy) return;
+        const next = {...privacy, ...changed};
+        try {
+            await api("/api/social/privacy", {method: "PUT", body: JSON.stringify(next)});
+            setPrivacy(next);
+            setToast("Сохранено ✅");
+        } catch {
+            setToast("Не удалось сохранить ❌");
+        }
+    };
+
+    const describe = (item: FeedItem) => {
+        if (item.kind === "record") {
+            return `🏆 рекорд в «${item.exercise_name}»: ${item.weight} кг (было ${item.prev_weight} кг)`;
+        }
+        return `💪 тренировка${item.day_type_name ? ` «${item.day_type_name}»` : ""}`;
+    };
+
+    return (
+        <div className="page stack">
+            <h2>Лента друзей</h2>
+
+            <div className="card stack">
+                <div style={{fontSize: 14, opacity: 0.7}}>Ваш ID для подписки: <b>{user?.id}</b></div>
+                <div style={{display: "flex", gap: 8}}>
+                    <input
+                        type="number"
+                        placeholder="ID пользователя"
+                        value={followID}
+                        onChange={(e) => setFollowID(e.target.value)}
+                        style={{flex: 1}}
+                    />
+                    <Button variant="primary" onClick={follow} disabled={!followID}>Подписаться</Button>
+                </div>
+            </div>
+
+            {requests.length > 0 && (
+                <div className="card stack">
+                    <div style={{fontWeight: 600}}>Заявки на подписку</div>
+                    {requests.map(r => (
+                        <div key={r.user.id} style={{display: "flex", gap: 8, alignItems: "center"}}>
+                            <span style={{flex: 1}}>#{r.user.id} {r.user.name}</span>
+                            <Button variant="active" onClick={() => answer(r, true)}>Принять</Button>
+                            <Button variant="danger" onClick={() => answer(r, false)}>Отклонить</Button>
+                        </div>
+                    ))}
+                </div>
+            )}
+
+            {feed.length === 0 && <div className="card">В ленте пока пусто</div>}
+
+            {feed.map(item => (
+                <div key={`${item.kind}-${item.workout_id}-${item.exercise_name ?? ""}`} className="card">
+                    <div style={{fontWeight: 600}}>{item.user.name}</div>
+                    <div>{describe(item)}</div>
+                    <div style={{fontSize: 12, opacity: 0.6}}>
+                        {new Date(item.ended_at ?? item.started_at).toLocaleString()}
+                    </div>
+                </div>
+            ))}
+
+            {feed.length < total && (
+                <Button variant="ghost" onClick={() => loadFeed(feed.length)}>Показать ещё</Button>
+            )}
+
+            {following.length > 0 && (
+                <div className="card stack">
+                    <div style={{fontWeight: 600}}>Мои подписки</div>
+                    {following.map(f => (
+                        <div key={f.user.id} style={{display: "flex", gap: 8, alignItems: "center"}}>
+                            <span style={{flex: 1}}>
+                                #{f.user.id} {f.user.name}{f.status === "pending" && " (ожидает одобрения)"}
+                            </span>
+                            <Button variant="ghost" onClick={() => unfollow(f)}>Отписаться</Button>
+                        </div>
+                    ))}
+                </div>
+            )}
+
+            {privacy && (
+                <div className="card stack">
+                    <div style={{fontWeight: 600}}>Приватность</div>
+                    <label>
+                        Кто видит мои тренировки
+                        <select
+                            value={privacy.workouts_visibility}
+                            onChange={(e) => savePrivacy({workouts_visibility: e.target.value as Visibility})}
+                        >
+                            {VISIBILITY.map(v => <option key={v.code} value={v.code}>{v.title}</option>)}
+                        </select>
+                    </label>
+                    <label>
+                        Кто видит мои рекорды
+                        <select
+                            value={privacy.records_visibility}
+                            onChange={(e) => savePrivacy({records_visibility: e.target.value as Visibility})}
+                        >
+                            {VISIBILITY.map(v => <option key={v.code} value={v.code}>{v.title}</option>)}
+                        </select>
+                    </label>
+                    <label style={{display: "flex", gap: 8}}>
+                        <input
+                            type="checkbox"
+                            checked={privacy.feed_digest}
+                            onChange={(e) => savePrivacy({feed_digest: e.target.checked})}
+                        />
+                        Присылать сводку ленты в Telegram
+                    </label>
+                </div>
+            )}
+
+            {toast && <Toast message={toast} onClose={() => setToast(null)}/>}
+        </div>
+    );
+};
+
+export default FeedPage;
@@ -2,16 +2,17 @@ import React, {useEffect, useState} from "react";
 import {useAuth} from "../context/AuthContext";
 import Button from "../components/Button";
 import Toast from "../components/Toast";
-import {Bell, BellOff, ChartNoAxesCombined, LogOut, Moon, Pencil, Sun, UserLock} from "lucide-react";
+import {Award, Bell, BellOff, ChartNoAxesCombined, KeyRound, LogOut, Moon, Newspaper, Pencil, ShieldCheck, Sun, Trophy, UserLock, Users, Webhook} from "lucide-react";
 import type {IconName} from "../components/IconPicker";
 import IconPicker, {ICONS} from "../components/IconPicker";
 import {subscribePush, unsubscribePush} from "../api/subscribePush";
 import {useUserIcon} from "../hooks/useUserIcons.ts";
 import {useNavigate} from "react-router-dom";
 import {getVapidKey} from "../api/vapid.ts";
+import {api, saveTokens} from "../api/client.ts";
 
 const ProfilePage: React.FC = () => {
-    const {user, logout, loading} = useAuth();
+    const {user, logout, loading, refreshUser} = useAuth();
     const [toast, setToast] = useState<string | null>(null);
     const [notificationsEnabled, setNotificationsEnabled] = useState(false);
     const [checking, setChecking] = useState(true);
@@ -23,6 +24,51 @@ const ProfilePage: React.FC = () => {
 
     const isMobile = window.innerWidth <= 768;
 
+    const [oauthProviders, setOAuthProviders] = useState<{ name: string; title: string }[]>([]);
+    useEffect(() => {
+        api<{ name: string; title: string }[]>("/api/oauth/providers")
+            .then(setOAuthProviders)
+            .catch(() => setOAuthProviders([]));
+    }, []);
+
+    // --- Вход по email ---
+    const [email, setEmail] = useState("");
+    const [password, setPassword] = useState("");
+
+    const emailLogin = async () => {
+        try {
+            const data = await api<{ token: string; refresh_token?: string }>("/api/email/login", {
+                method: "POST",
+                body: JSON.stringify({email, password}),
+            });
+            saveTokens(data);
+            refreshUser();
+        } catch {
+            setToast("Неверный email или пароль ❌");
+        }
+    };
+
+    const emailRegister = async () => {
+        try {
+            await api("/api/email/register", {
+                method: "POST",
+                body: JSON.stringify({email, password, origin: window.location.origin}),
+            });
+            setToast("Проверьте почту, чтобы подтвердить email ✉️");
+        } catch {
+            setToast("Не удалось зарегистрироваться ❌");
+        }
+    };
+
+    const emailLink = async (url: string) => {
+        if (!email) return;
+        await api(url, {
+            method: "POST",
+            body: JSON.stringify({email, origin: window.location.origin}),
+        }).catch(() => null);
+        setToast("Если такой email есть, мы отправили письмо ✉️");
+    };
+
     const [darkMode, setDarkMode] = useState<boolean>(() => {
         // Читаем из localStorage
         const saved = localStorage.getItem("darkMode");
@@ -188,8 +234,48 @@ const ProfilePage: React.FC = () => {
                             />
                             Войти через Yandex ID
                         </Button>
+
+                        {oauthProviders.map(p => (
+                            <Button
+                                key={p.name}
+                                variant="primary"
+                                onClick={() => {
+                                    const origin = window.location.origin;
+                                    window.location.href = `/api/oauth/${p.name}/login?origin=${encodeURIComponent(origin)}`;
+                                }}
+                            >
+                                Войти через {p.title}
+                            </Button>
+                        ))}
+
+                        <input
+                            type="email"
+                            placeholder="Email"
+                            value={email}
+                            onChange={(e) => setEmail(e.target.value)}
+                        />
+                        <input
+                            type="password"
+                            placeholder="Пароль"
+                            value={password}
+                            onChange={(e) => setPassword(e.target.value)}
+                        />
+                        <Button variant="primary" onClick={emailLogin} disabled={!email || !password}>
+                            Войти по email
+                        </Button>
+                        <Button variant="ghost" onClick={emailRegister} disabled={!email || password.length < 8}>
+                            Зарегистрироваться
+                        </Button>
+                        <Button variant="ghost" onClick={() => emailLink("/api/email/magic-link")} disabled={!email}>
+                            Прислать ссылку для входа
+                        </Button>
+                        <Button variant="ghost" onClick={() => emailLink("/api/email/password/forgot")} disabled={!email}>
+                            Забыли пароль?
+                        </Button>
                     </div>
 
+                    {toast && <Toast message={toast} onClose={() => setToast(null)}/>}
+
                 </div>
             )}
 
@@ -254,6 +340,34 @@ const ProfilePage: React.FC = () => {
                         <ChartNoAxesCombined/>Посмотреть динамику
                     </Button>}
 
+                    <Button variant="ghost" onClick={() => navigate(`/coaching`)}>
+                        <Users/>Тренер и спортсмены
+                    </Button>
+
+                    <Button variant="ghost" onClick={() => navigate(`/feed`)}>
+                        <Newspaper/>Лента друзей
+                    </Button>
+
+                    <Button variant="ghost" onClick={() => navigate(`/challenges`)}>
+                        <Trophy/>Челленджи
+                    </Button>
+
+                    <Button variant="ghost" onClick={() => navigate(`/achievements`)}>
+                        <Award/>Достижения
+                    </Button>
+
+                    <Button variant="ghost" onClick={() => navigate(`/webhooks`)}>
+                        <Webhook/>Вебхуки
+                    </Button>
+
+                    <Button variant="ghost" onClick={() => navigate(`/tokens`)}>
+                        <KeyRound/>API-токены
+                    </Button>
+
+                    {user.role === "admin" && <Button variant="ghost" onClick={() => navigate(`/admin`)}>
+                        <ShieldCheck/>Админка
+                    </Button>}
+
                     <Button
                         variant="danger"
                         onClick={logout}
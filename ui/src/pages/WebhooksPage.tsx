@@ -0,0 +1,190 @@
+import React, {useEffect, useState} from "react";
+import Button from "../components/Button";
+import Toast from "../components/Toast";
+import {
+    createWebhook,
+    deleteWebhook,
+    getMyWebhooks,
+    getWebhookDeliveries,
+    getWebhookEventTypes,
+    sendTestWebhook,
+    updateWebhook,
+} from "../api/webhooks.ts";
+
+const STATUS_ICONS: Record<WebhookDelivery["status"], string> = {
+    pending: "⏳",
+    success: "✅",
+    failed: "❌",
+};
+
+const WebhooksPage: React.FC = () => {
+    const [webhooks, setWebhooks] = useState<Webhook[]>([]);
+    const [eventTypes, setEventTypes] = useState<WebhookEventType[]>([]);
+    const [toast, setToast] = useState<string | null>(null);
+    // секрет показывается один раз — сразу после создания или смены
+    const [secret, setSecret] = useState<string | null>(null);
+
+    // --- Журнал доставок ---
+    const [openedID, setOpenedID] = useState<number | null>(null);
+    const [deliveries, setDeliveries] = useState<WebhookDelivery[]>([]);
+
+    // --- Форма создания ---
+    const [url, setUrl] = useState("");
+    const [description, setDescription] = useState("");
+    const [selected, setSelected] = useState<string[]>([]);
+
+    const load = () => getMyWebhooks()
+        .then(setWebhooks)
+        .catch(() => setToast("Не удалось загрузить ❌"));
+
+    useEffect(() => {
+        load();
+        getWebhookEventTypes().then(setEventTypes).catch(() => setEventTypes([]));
+    }, []);
+
+    const eventTitle = (type: string) =>
+        eventTypes.find(t => t.type === type)?.description ?? type;
+
+    const toggle = (type: string) =>
+        setSelected(prev => prev.includes(type) ? prev.filter(t => t !== type) : [...prev, type]);
+
+    const openDeliveries = async (id: number) => {
+        if (openedID === id) {
+            setOpenedID(null);
+            return;
+        }
+        try {
+            const result = await getWebhookDeliveries(id);
+            setDeliveries(result.items);
+            setOpenedID(id);
+        } catch {
+            setToast("Не удалось загрузить журнал ❌");
+        }
+    };
+
+    const create = async () => {
+        try {
+            const created = await createWebhook({url, description, event_types: selected});
+            setSecret(created.secret ?? null);
+            setUrl("");
+            setDescription("");
+            setSelected([]);
+            load();
+        } catch {
+            setToast("Проверьте адрес и события ❌");
+        }
+    };
+
+    const test = async (id: number) => {
+        try {
+            const delivery = await sendTestWebhook(id);
+            setToast(delivery.status === "success"
+                ? `Доставлено, ответ ${delivery.response_status} ✅`
+                : `Не доставлено: ${delivery.error ?? delivery.response_status} ❌`);
+            if (openedID === id) {
+                getWebhookDeliveries(id).then(r => setDeliveries(r.items));
+            }
+        } catch {
+            setToast("Не удалось отправить ❌");
+        }
+    };
+
+    const toggleActive = async (w: Webhook) => {
+        await updateWebhook(w.id, {active: !w.active}).catch(() => setToast("Не удалось изменить ❌"));
+        load();
+    };
+
+    const rotate = async (w: Webhook) => {
+        if (!confirm("Старый секрет перестанет работать. Продолжить?")) return;
+        try {
+            const updated = await updateWebhook(w.id, {rotate_secret: true});
+            setSecret(updated.secret ?? null);
+            load();
+        } catch {
+            setToast("Не удалось сменить секрет ❌");
+        }
+    };
+
+    const remove = async (w: Webhook) => {
+        if (!confirm("Удалить вебхук вместе с журналом доставок?")) return;
+        await deleteWebhook(w.id).catch(() => setToast("Не удалось удалить ❌"));
+        load();
+    };
+
+    return (
+        <div className="page stack">
+            <h2>Вебхуки</h2>
+
+            {secret && (
+                <div className="card stack">
+                    <div style={{fontWeight: 600}}>Секрет для проверки подписи</div>
+                    <code style={{wordBreak: "break-all"}}>{secret}</code>
+                    <div style={{fontSize: 12, opacity: 0.7}}>
+                        Сохраните его: больше он показан не будет. Подпись приходит в заголовке
+                        X-Webhook-Signature как HMAC-SHA256 от «X-Webhook-Timestamp.тело».
+                    </div>
+                    <Button variant="ghost" onClick={() => setSecret(null)}>Скрыть</Button>
+                </div>
+            )}
+
+            {webhooks.length === 0 && <div className="card">Вебхуков пока нет</div>}
+
+            {webhooks.map(w => (
+                <div key={w.id} className="card stack">
+                    <div style={{fontWeight: 600, wordBreak: "break-all", opacity: w.active ? 1 : 0.5}}>
+                        {w.active ? "🟢" : "⚪️"} {w.description || w.url}
+                    </div>
+                    {w.description && <div style={{fontSize: 12, opacity: 0.7, wordBreak: "break-all"}}>{w.url}</div>}
+                    <div style={{fontSize: 12, opacity: 0.7}}>
+                        {w.event_types.map(eventTitle).join(" · ")} · секрет {w.secret_hint}
+                    </div>
+                    <div style={{display: "flex", gap: 8, flexWrap: "wrap"}}>
+                        <Button variant="primary" onClick={() => test(w.id)}>Тест</Button>
+                        <Button variant="ghost" onClick={() => openDeliveries(w.id)}>Журнал</Button>
+                        <Button variant="ghost" onClick={() => toggleActive(w)}>
+                            {w.active ? "Выключить" : "Включить"}
+                        </Button>
+                        <Button variant="ghost" onClick={() => rotate(w)}>Сменить секрет</Button>
+                        <Button variant="danger" onClick={() => remove(w)}>Удалить</Button>
+                    </div>
+
+                    {openedID === w.id && (
+                        <div className="stack">
+                            {deliveries.length === 0 && <div style={{fontSize: 14, opacity: 0.7}}>Доставок пока нет</div>}
+                            {deliveries.map(d => (
+                                <div key={d.id} style={{fontSize: 12}}>
+                                    {STATUS_ICONS[d.status]} {new Date(d.created_at).toLocaleString()} · {eventTitle(d.event_type)}
+                                    {d.response_status !== undefined && ` · HTTP ${d.response_status}`}
+                                    {` · попыток ${d.attempts}`}
+                                    {d.error && <div style={{opacity: 0.7}}>{d.error}</div>}
+                                    {d.next_attempt_at && (
+                                        <div style={{opacity: 0.7}}>повтор {new Date(d.next_attempt_at).toLocaleString()}</div>
+                                    )}
+                                </div>
+                            ))}
+                        </div>
+                    )}
+                </div>
+            ))}
+
+            <div className="card stack">
+                <div style={{fontWeight: 600}}>Новый вебхук</div>
+                <input placeholder="https://example.com/hook" value={url} onChange={(e) => setUrl(e.target.value)}/>
+                <input placeholder="Описание" value={description} onChange={(e) => setDescription(e.target.value)}/>
+                {eventTypes.map(t => (
+                    <label key={t.type} style={{display: "flex", gap: 8, alignItems: "center"}}>
+                        <input type="checkbox" checked={selected.includes(t.type)} onChange={() => toggle(t.type)}/>
+                        {t.description}
+                    </label>
+                ))}
+                <Button variant="active" onClick={create} disabled={!url || selected.length === 0}>
+                    Добавить
+                </Button>
+            </div>
+
+            {toast && <Toast message={toast} onClose={() => setToast(null)}/>}
+        </div>
+    );
+};
+
+export default WebhooksPage;
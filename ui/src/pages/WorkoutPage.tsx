@@ -9,6 +9,8 @@ import {getExerciseGroups} from "../api/exercises.ts";
 import ShareSheet from "../components/ShareSheet.tsx";
 import {useShare} from "../hooks/useShare.ts";
 import Toast from "../components/Toast.tsx";
+import ShareSocialBlock from "../components/ShareSocial.tsx";
+import {ApiError} from "../api/client.ts";
 
 const WorkoutPage = () => {
 
@@ -50,10 +52,8 @@ const WorkoutPage = () => {
             const result = await createShare(data.progress.workout.id);
             await openShare(result.share_url);
         } catch (e: any) {
-            console.log('e.status:', e.status);
-            console.log(e.message, e.message == "rate limit exceeded")
-            if (e.message?.trim() === 'rate limit exceeded') {
-                setToast('Слишком много запросов. Подождите минуту');
+            if (e instanceof ApiError && e.code === 'rate_limited') {
+                setToast(e.message);
             } else {
                 setToast('Не удалось создать ссылку');
             }
@@ -267,6 +267,10 @@ const WorkoutPage = () => {
             ))}
         </div>
 
+        {isPublicMode && token && (
+            <ShareSocialBlock token={token} workoutId={workout.id} onError={setToast}/>
+        )}
+
         {isPublicMode && (
             <div className="stack"
                  style={{textAlign: 'center', marginTop: '1rem', paddingTop: '1rem', borderTop: '1px solid #eee'}}>